@@ -89,15 +89,10 @@ func anonymousGoroutine() {
 func closureTrap() {
 	fmt.Println("\n--- Example 4: Closure trap ---")
 
+	// The racy version lives in the race suite so this example stays clean
+	// under -race: go run -race . 18 closure -buggy
 	fmt.Println("Wrong example - Closure trap:")
-	for i := 1; i <= 3; i++ {
-		// Wrong: all goroutines will capture the value of i when the loop ends
-		go func() {
-			fmt.Printf("Wrong closure: i = %d\n", i)
-		}()
-	}
-
-	time.Sleep(100 * time.Millisecond)
+	fmt.Println("go func() { use(i) }() inside the loop shares one i, so goroutines see whatever value it holds when they run")
 
 	fmt.Println("\nCorrect example - Pass parameters:")
 	for i := 1; i <= 3; i++ {
@@ -301,23 +296,14 @@ func commonMistakes() {
 	time.Sleep(100 * time.Millisecond)
 
 	fmt.Println("Mistake 5: Race condition")
-	var shared int
-	var wg sync.WaitGroup
-
-	for i := 0; i < 1000; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			shared++ // Race condition!
-		}()
-	}
-
-	wg.Wait()
-	fmt.Printf("Shared variable value: %d (may not be 1000)\n", shared)
+	// shared++ from many goroutines loses updates; see it reported with
+	// go run -race . 18 counter -buggy
+	fmt.Println("Unsynchronized shared++ may not reach 1000")
 
 	// Correct approach: use mutex
 	var safeShared int
 	var mu sync.Mutex
+	var wg sync.WaitGroup
 
 	for i := 0; i < 1000; i++ {
 		wg.Add(1)
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math/rand"
+	"reflect"
 	"time"
 )
 
@@ -362,21 +363,21 @@ func dynamicChannelSelect() {
 	dynamicSelectWithReflection(channels)
 }
 
-// Use reflection to implement dynamic select (simplified version)
+// dynamicSelectWithReflection receives from every channel that has not
+// delivered yet. reflect.Select takes its cases as a slice, so their number
+// is decided at run time; 31_dynamic_select.go builds on this.
 func dynamicSelectWithReflection(channels []chan int) {
-	// Simplified implementation here, actual usage requires reflect package
-	fmt.Println("Note: Complete reflection implementation requires reflect package")
-	fmt.Println("This shows the concept, actual projects can use reflect.Select")
-
-	// Simulate dynamic selection
+	cases := make([]reflect.SelectCase, len(channels))
 	for i, ch := range channels {
-		select {
-		case value := <-ch:
-			fmt.Printf("Dynamic selection received from channels[%d]: %d\n", i, value)
-			return
-		default:
-			continue
-		}
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+	}
+
+	// channels[0] was drained by the select above
+	cases[0].Chan = reflect.Value{} // A zero Chan makes reflect.Select ignore the case
+	for remaining := len(channels) - 1; remaining > 0; remaining-- {
+		chosen, value, _ := reflect.Select(cases)
+		fmt.Printf("reflect.Select received from channels[%d]: %d\n", chosen, value.Int())
+		cases[chosen].Chan = reflect.Value{}
 	}
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -37,6 +38,18 @@ func ContextExamples() {
 
 	// Example 9: Common mistakes examples
 	contextMistakes()
+
+	// Example 10: Typed keys and a request-scoped carrier
+	typedContextValues()
+
+	// Example 11: Middleware chain
+	middlewareChainContext()
+
+	// Example 12: Deadline budgeting across chained calls
+	budgetContext()
+
+	// Example 13: Tracing cancellation causes
+	cancelCauseContext()
 }
 
 // Example 1: Basic context
@@ -353,9 +366,9 @@ func contextMistakes() {
 	fmt.Println("\n--- Example 9: Common mistakes examples ---")
 
 	fmt.Println("Mistake 1: Forgetting to call cancel function")
-	_, _ = context.WithCancel(context.Background())
-	// Forgetting to call cancel() may cause goroutine leaks
-	// Intentionally not calling cancel here to demonstrate the mistake
+	// ctx, _ := context.WithCancel(context.Background()) // Wrong
+	// Forgetting to call cancel() keeps the context and its timer alive until
+	// the parent is cancelled; go vet reports it as a lost cancel
 
 	// Correct approach: use defer
 	ctx2, cancel2 := context.WithCancel(context.Background())
@@ -429,3 +442,183 @@ func contextMistakes() {
 		fmt.Printf("Operation cancelled: %v\n", ctx5.Err())
 	}
 }
+
+// Example 10: Typed keys and a request-scoped carrier
+func typedContextValues() {
+	fmt.Println("\n--- Example 10: Typed keys and a request-scoped carrier ---")
+
+	// Two packages both store an "id" under a string key: the inner value
+	// hides the outer one, and every reader has to assert the type
+	ctx := context.WithValue(context.Background(), "id", "user-42")
+	ctx = context.WithValue(ctx, "id", 1001)
+	fmt.Printf("String keys: ctx.Value(\"id\") = %v, the user ID is hidden\n", ctx.Value("id"))
+
+	// Typed keys with the same name stay apart, and Value returns the type
+	userIDKey := NewContextKey[string]("id")
+	orderIDKey := NewContextKey[int]("id")
+	ctx = userIDKey.WithValue(context.Background(), "user-42")
+	ctx = orderIDKey.WithValue(ctx, 1001)
+	userID, _ := userIDKey.Value(ctx)
+	orderID, _ := orderIDKey.Value(ctx)
+	_, found := NewContextKey[string]("id").Value(ctx)
+	fmt.Printf("Typed keys: user %s, order %d; a third key named \"id\" finds a value: %t\n", userID, orderID, found)
+
+	// The carrier: one struct under one key, copied whenever it changes
+	base := WithRequestValues(context.Background(), func(rv *RequestValues) {
+		rv.RequestID = "req-1"
+		rv.SetAttr("token", "t-alice")
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// A stage started before authentication keeps seeing its own values
+		time.Sleep(10 * time.Millisecond)
+		rv := RequestValuesFrom(base)
+		fmt.Printf("Earlier stage: request %s, user %q, role %q\n", rv.RequestID, rv.User, rv.Attr("role"))
+	}()
+	authed := WithRequestValues(base, func(rv *RequestValues) {
+		rv.User = "alice"
+		rv.SetAttr("role", "admin")
+	})
+	rv := RequestValuesFrom(authed)
+	fmt.Printf("After auth:    request %s, user %q, role %q, token %q\n", rv.RequestID, rv.User, rv.Attr("role"), rv.Attr("token"))
+	wg.Wait()
+}
+
+// Example 11: Middleware chain
+func middlewareChainContext() {
+	fmt.Println("\n--- Example 11: Middleware chain ---")
+
+	// Each middleware adds to the context and calls the next; the handler at
+	// the end reads everything from the carrier
+	requests := 0
+	newID := func() string {
+		requests++
+		return fmt.Sprintf("req-%d", requests)
+	}
+	handler := ChainHandlers(
+		func(ctx context.Context, payload string) (string, error) {
+			rv := RequestValuesFrom(ctx)
+			return fmt.Sprintf("%s: %s of tenant %s ordered %s", rv.RequestID, rv.User, rv.Tenant, payload), nil
+		},
+		TraceMiddleware("gateway"),
+		RequestIDMiddleware(newID),
+		AuthMiddleware(map[string]string{"t-alice": "alice@acme"}),
+		TraceMiddleware("orders"),
+	)
+
+	for _, token := range []string{"t-alice", "t-mallory"} {
+		ctx, trace := WithTrace(context.Background())
+		ctx = WithRequestValues(ctx, func(rv *RequestValues) { rv.SetAttr("token", token) })
+		result, err := handler(ctx, "2 books")
+		fmt.Printf("Token %s: result %q, err %v\n", token, result, err)
+		trace.Print()
+	}
+}
+
+// Example 12: Deadline budgeting across chained calls
+func budgetContext() {
+	fmt.Println("\n--- Example 12: Deadline budgeting across chained calls ---")
+
+	// gateway -> service -> db. The service gets 80% of the time left,
+	// keeping 20ms back for the gateway; the db gets half of the service's
+	// time, so a slow db leaves the service time to answer from its cache.
+	newChain := func(dbLatency time.Duration) CtxHandler {
+		db := ChainHandlers(
+			func(ctx context.Context, key string) (string, error) {
+				if err := sleepCtx(ctx, dbLatency); err != nil {
+					return "", err
+				}
+				return "fresh " + key, nil
+			},
+			BudgetMiddleware("db", 0.5, 0),
+			TraceMiddleware("db"),
+		)
+		service := ChainHandlers(
+			func(ctx context.Context, key string) (string, error) {
+				value, err := db(ctx, key)
+				var budget *BudgetExceededError
+				if errors.As(err, &budget) && ctx.Err() == nil {
+					tracef(ctx, "%v; answering from the cache", budget)
+					return "cached " + key, nil
+				}
+				return value, err
+			},
+			BudgetMiddleware("service", 0.8, 20*time.Millisecond),
+			TraceMiddleware("service"),
+		)
+		return ChainHandlers(service, TraceMiddleware("gateway"))
+	}
+
+	for _, latency := range []time.Duration{30 * time.Millisecond, 200 * time.Millisecond} {
+		ctx, trace := WithTrace(context.Background())
+		ctx, cancel := context.WithTimeoutCause(ctx, 300*time.Millisecond, ErrRequestTimeout)
+		result, err := newChain(latency)(ctx, "price:42")
+		cancel()
+		fmt.Printf("db latency %v within a 300ms request: %q, err %v\n", latency, result, err)
+		trace.Print()
+	}
+}
+
+// Example 13: Tracing cancellation causes
+func cancelCauseContext() {
+	fmt.Println("\n--- Example 13: Tracing cancellation causes ---")
+
+	// The same stuck db call ended three different ways. ctx.Err() can only
+	// say "canceled" or "deadline exceeded"; context.Cause says why.
+	scenarios := []struct {
+		name  string
+		setup func(ctx context.Context) (context.Context, func())
+		share float64 // The db's budget share; 0 for no budget
+	}{
+		{"client disconnect", func(ctx context.Context) (context.Context, func()) {
+			ctx, cancel := context.WithCancelCause(ctx)
+			timer := time.AfterFunc(40*time.Millisecond, func() { cancel(ErrClientDisconnected) })
+			return ctx, func() { timer.Stop(); cancel(nil) }
+		}, 0},
+		{"request timeout", func(ctx context.Context) (context.Context, func()) {
+			return context.WithTimeoutCause(ctx, 60*time.Millisecond, ErrRequestTimeout)
+		}, 0},
+		{"db budget", func(ctx context.Context) (context.Context, func()) {
+			return context.WithTimeoutCause(ctx, 100*time.Millisecond, ErrRequestTimeout)
+		}, 0.25},
+		{"plain cancel", func(ctx context.Context) (context.Context, func()) {
+			ctx, cancel := context.WithCancel(ctx)
+			timer := time.AfterFunc(20*time.Millisecond, cancel)
+			return ctx, func() { timer.Stop(); cancel() }
+		}, 0},
+	}
+
+	type row struct {
+		name, err, cause string
+		deadline         bool
+	}
+	var rows []row
+	for _, sc := range scenarios {
+		middlewares := []CtxMiddleware{TraceMiddleware("db")}
+		if sc.share > 0 {
+			middlewares = append([]CtxMiddleware{BudgetMiddleware("db", sc.share, 0)}, middlewares...)
+		}
+		var seen context.Context // The context the db saw when it gave up
+		db := ChainHandlers(func(ctx context.Context, key string) (string, error) {
+			seen = ctx
+			return "", sleepCtx(ctx, time.Second)
+		}, middlewares...)
+
+		ctx, trace := WithTrace(context.Background())
+		ctx, cleanup := sc.setup(ctx)
+		_, err := ChainHandlers(db, TraceMiddleware("api"))(ctx, "orders")
+		cleanup()
+		fmt.Printf("%s:\n", sc.name)
+		trace.Print()
+		rows = append(rows, row{sc.name, seen.Err().Error(), context.Cause(seen).Error(),
+			errors.Is(err, context.DeadlineExceeded)})
+	}
+
+	fmt.Printf("\n%-18s %-26s %-36s %s\n", "scenario", "ctx.Err()", "context.Cause(ctx)", "is DeadlineExceeded")
+	for _, r := range rows {
+		fmt.Printf("%-18s %-26s %-36s %t\n", r.name, r.err, r.cause, r.deadline)
+	}
+	fmt.Println("Without a cause, as with the plain cancel, Cause returns the same as Err.")
+}
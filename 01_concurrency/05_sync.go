@@ -1,11 +1,166 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
 
+// CountDownLatch lets goroutines wait until a count of events has happened.
+// Unlike a WaitGroup, the waiters need not know who counts down, Await can
+// give up with a context, and the latch never resets: once open it stays open.
+type CountDownLatch struct {
+	mu    sync.Mutex
+	count int
+	open  chan struct{} // Closed when count reaches zero
+}
+
+// NewCountDownLatch creates a latch that opens after count CountDown calls
+func NewCountDownLatch(count int) *CountDownLatch {
+	l := &CountDownLatch{count: count, open: make(chan struct{})}
+	if count <= 0 {
+		l.count = 0
+		close(l.open)
+	}
+	return l
+}
+
+// CountDown records one event; calls after the latch opened are ignored
+func (l *CountDownLatch) CountDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.count == 0 {
+		return
+	}
+	l.count--
+	if l.count == 0 {
+		close(l.open)
+	}
+}
+
+// Count returns how many events are still outstanding
+func (l *CountDownLatch) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Await blocks until the latch opens or ctx is done
+func (l *CountDownLatch) Await(ctx context.Context) error {
+	select {
+	case <-l.open:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ErrBarrierBroken is returned by Await when a party of the current
+// generation gave up, or the barrier was Reset, before everyone arrived
+var ErrBarrierBroken = errors.New("barrier: broken")
+
+// barrierGeneration is one round of a CyclicBarrier
+type barrierGeneration struct {
+	id      int
+	arrived int
+	broken  bool
+	done    chan struct{} // Closed when the round trips or breaks
+}
+
+// CyclicBarrier makes a fixed number of parties wait for each other, then
+// releases them together and starts over, so one barrier separates every
+// phase of a phased computation. Each round is a generation, numbered from
+// 0; the last party to arrive runs the optional action before anyone is
+// released, a natural place for work between phases. If a party gives up,
+// the generation is broken for everyone in it until Reset.
+type CyclicBarrier struct {
+	parties int
+	action  func(generation int)
+
+	mu  sync.Mutex
+	gen *barrierGeneration
+}
+
+// NewCyclicBarrier creates a barrier for parties goroutines
+func NewCyclicBarrier(parties int, action func(generation int)) *CyclicBarrier {
+	return &CyclicBarrier{
+		parties: max(parties, 1),
+		action:  action,
+		gen:     &barrierGeneration{done: make(chan struct{})},
+	}
+}
+
+// Await waits for the other parties and returns the generation it took
+// part in. It fails with ErrBarrierBroken if the generation breaks, or with
+// ctx's error after breaking it itself.
+func (b *CyclicBarrier) Await(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	g := b.gen
+	if g.broken {
+		b.mu.Unlock()
+		return g.id, ErrBarrierBroken
+	}
+	g.arrived++
+	if g.arrived == b.parties {
+		// Last to arrive: run the action, then release this generation
+		if b.action != nil {
+			b.action(g.id)
+		}
+		b.gen = &barrierGeneration{id: g.id + 1, done: make(chan struct{})}
+		close(g.done)
+		b.mu.Unlock()
+		return g.id, nil
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-g.done:
+		if g.broken {
+			return g.id, ErrBarrierBroken
+		}
+		return g.id, nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		select {
+		case <-g.done: // Tripped or broke while we were giving up
+			if !g.broken {
+				return g.id, nil
+			}
+		default:
+			g.broken = true
+			close(g.done)
+		}
+		return g.id, ctx.Err()
+	}
+}
+
+// Reset breaks the current generation, if anyone is waiting in it, and
+// starts a fresh one; a broken barrier is usable again after Reset
+func (b *CyclicBarrier) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	g := b.gen
+	if g.arrived > 0 && !g.broken {
+		g.broken = true
+		close(g.done)
+	}
+	b.gen = &barrierGeneration{id: g.id + 1, done: make(chan struct{})}
+}
+
+// Waiting returns how many parties are waiting in the current generation
+func (b *CyclicBarrier) Waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.gen.broken {
+		return 0
+	}
+	return b.gen.arrived
+}
+
 // SyncExamples runs all sync package examples
 func SyncExamples() {
 	fmt.Println("=== Sync Package Synchronization Primitives Examples ===")
@@ -33,6 +188,15 @@ func SyncExamples() {
 
 	// Example 8: Comprehensive example
 	comprehensiveSyncExample()
+
+	// Example 9: CountDownLatch
+	countDownLatchExample()
+
+	// Example 10: CyclicBarrier phased computation
+	cyclicBarrierExample()
+
+	// Example 11: Broken barrier and Reset
+	brokenBarrierExample()
 }
 
 // Example 1: Mutex mutual exclusion lock
@@ -65,8 +229,12 @@ func mutexExample() {
 	var nestedMu sync.Mutex
 	nestedMu.Lock()
 	fmt.Println("First lock acquired")
-	nestedMu.Lock()                     // This will cause deadlock!
-	fmt.Println("Second lock acquired") // Will never execute here
+	// sync.Mutex is not reentrant: a second Lock here would wait for this
+	// goroutine to unlock and deadlock. TryLock shows it without hanging.
+	if !nestedMu.TryLock() {
+		fmt.Println("Second lock not acquired: locking again from the same goroutine would deadlock")
+	}
+	nestedMu.Unlock()
 }
 
 // Example 2: RWMutex read-write lock
@@ -452,3 +620,139 @@ func comprehensiveSyncExample() {
 	}
 	cache.mu.RUnlock()
 }
+
+// Example 9: CountDownLatch
+func countDownLatchExample() {
+	fmt.Println("\n--- Example 9: CountDownLatch ---")
+
+	// A start gate (count 1) lets every worker begin at the same moment, and
+	// a ready latch (count 3) lets main wait for all services to come up
+	startGate := NewCountDownLatch(1)
+	ready := NewCountDownLatch(3)
+	start := time.Now()
+	for _, svc := range []struct {
+		name    string
+		startup time.Duration
+	}{{"database", 60 * time.Millisecond}, {"cache", 20 * time.Millisecond}, {"search", 40 * time.Millisecond}} {
+		svc := svc
+		go func() {
+			startGate.Await(context.Background())
+			time.Sleep(svc.startup)
+			fmt.Printf("%-8s ready after %v\n", svc.name, time.Since(start).Round(10*time.Millisecond))
+			ready.CountDown()
+		}()
+	}
+
+	fmt.Printf("Services waiting at the gate; outstanding: %d\n", ready.Count())
+	startGate.CountDown() // Open the gate for all of them at once
+	if err := ready.Await(context.Background()); err == nil {
+		fmt.Printf("All services ready after %v\n", time.Since(start).Round(10*time.Millisecond))
+	}
+
+	// Await with a deadline: a latch nobody counts down in time
+	stuck := NewCountDownLatch(2)
+	stuck.CountDown()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	fmt.Printf("Waiting on a latch with %d outstanding: %v\n", stuck.Count(), stuck.Await(ctx))
+}
+
+// Example 10: CyclicBarrier phased computation
+func cyclicBarrierExample() {
+	fmt.Println("\n--- Example 10: CyclicBarrier Phased Computation ---")
+
+	// Heat diffusion along a rod: each phase, every cell becomes the average
+	// of its neighbours. Four workers each own a segment, but a cell at a
+	// segment edge reads its neighbour's cells, so no worker may start phase
+	// n+1 before all have finished phase n. The barrier action swaps the
+	// buffers between phases while everyone is stopped.
+	const workers, cellsPer, phases = 4, 3, 5
+	cur := make([]float64, workers*cellsPer)
+	next := make([]float64, len(cur))
+	cur[0], cur[len(cur)-1] = 100, 100 // Both ends held hot
+
+	barrier := NewCyclicBarrier(workers, func(generation int) {
+		cur, next = next, cur
+		fmt.Printf("  after phase %d: %s\n", generation+1, formatRod(cur))
+	})
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := w*cellsPer, (w+1)*cellsPer
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for phase := 0; phase < phases; phase++ {
+				for i := lo; i < hi; i++ {
+					if i == 0 || i == len(cur)-1 {
+						next[i] = cur[i]
+						continue
+					}
+					next[i] = (cur[i-1] + cur[i+1]) / 2
+				}
+				if _, err := barrier.Await(context.Background()); err != nil {
+					fmt.Printf("  worker stopped: %v\n", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("%d workers ran %d phases through one reusable barrier\n", workers, phases)
+}
+
+// formatRod prints cell temperatures compactly
+func formatRod(cells []float64) string {
+	parts := make([]string, len(cells))
+	for i, c := range cells {
+		parts[i] = fmt.Sprintf("%3.0f", c)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Example 11: Broken barrier and Reset
+func brokenBarrierExample() {
+	fmt.Println("\n--- Example 11: Broken Barrier and Reset ---")
+
+	// Three parties expected, but the third crashed and never arrives. The
+	// second gives up after 30ms, which breaks the generation: the first is
+	// released with ErrBarrierBroken instead of waiting forever.
+	barrier := NewCyclicBarrier(3, nil)
+	var wg sync.WaitGroup
+	results := make([]string, 2)
+	for p, timeout := range []time.Duration{0, 30 * time.Millisecond} {
+		p, timeout := p, timeout
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			gen, err := barrier.Await(ctx)
+			results[p] = fmt.Sprintf("party %d: generation %d, err=%v", p, gen, err)
+		}()
+	}
+	wg.Wait()
+	for _, r := range results {
+		fmt.Println("  " + r)
+	}
+	_, err := barrier.Await(context.Background())
+	fmt.Printf("Await on the broken barrier: %v\n", err)
+
+	// Reset starts a new generation; with all three present it trips
+	barrier.Reset()
+	gens := make([]int, 3)
+	for p := range gens {
+		p := p
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gens[p], _ = barrier.Await(context.Background())
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("After Reset all three met in generations %v\n", gens)
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -138,33 +144,264 @@ func (m BankMessage) Type() string {
 	return "bank"
 }
 
+// LookupMessage asks for a value by key; unlike GetMessage it carries no
+// Response channel and is meant for Ask
+type LookupMessage struct {
+	Key string
+}
+
+func (m LookupMessage) Type() string {
+	return "lookup"
+}
+
+// ReplyHandler handles a message sent with Ask; its return values complete
+// the caller's Future
+type ReplyHandler func(Message) (interface{}, error)
+
+var (
+	// ErrAskTimeout completes an Ask Future that got no reply in time
+	ErrAskTimeout = errors.New("actor: ask timed out")
+	// ErrNoReplyHandler is returned by Ask for message types without a ReplyHandler
+	ErrNoReplyHandler = errors.New("actor: no reply handler for message type")
+	// ErrActorStopped is returned when a message cannot be delivered because the actor stopped
+	ErrActorStopped = errors.New("actor: stopped")
+	// ErrMailboxFull is returned under BackpressureError, and fails the Ask of
+	// a message discarded by a drop policy
+	ErrMailboxFull = errors.New("actor: mailbox full")
+)
+
+// BackpressurePolicy decides what a send does when the mailbox is full
+type BackpressurePolicy int
+
+const (
+	BackpressureBlock      BackpressurePolicy = iota // Wait for room (the default)
+	BackpressureDropOldest                           // Discard the oldest queued message to make room
+	BackpressureDropNewest                           // Discard the message being sent
+	BackpressureError                                // Refuse the message with ErrMailboxFull
+)
+
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressureBlock:
+		return "block"
+	case BackpressureDropOldest:
+		return "drop-oldest"
+	case BackpressureDropNewest:
+		return "drop-newest"
+	case BackpressureError:
+		return "error"
+	}
+	return fmt.Sprintf("BackpressurePolicy(%d)", int(p))
+}
+
+// ActorOptions configures NewActorWithOptions
+type ActorOptions struct {
+	MailboxSize  int
+	Backpressure BackpressurePolicy
+}
+
+// ActorStats is a snapshot of an actor's metrics
+type ActorStats struct {
+	MailboxDepth int    // Messages queued right now
+	PeakDepth    int    // Most messages ever queued at once
+	Processed    uint64 // Messages handled
+	Dropped      uint64 // Messages discarded by a drop policy
+	Rejected     uint64 // Sends refused with ErrMailboxFull
+	AvgLatency   time.Duration
+	MaxLatency   time.Duration // Slowest single handler call
+}
+
+// actorMetrics is updated with atomics so Stats never waits for a handler
+type actorMetrics struct {
+	processed, dropped, rejected atomic.Uint64
+	latencyTotal, latencyMax     atomic.Int64
+	peakDepth                    atomic.Int64
+}
+
+// storeMax raises v to at least x
+func storeMax(v *atomic.Int64, x int64) {
+	for {
+		cur := v.Load()
+		if x <= cur || v.CompareAndSwap(cur, x) {
+			return
+		}
+	}
+}
+
+// askMessage wraps an Ask message with the Future its reply completes
+type askMessage struct {
+	Message
+	reply *Future
+}
+
 // Actor Actor struct
 type Actor struct {
-	ID       string
-	mailbox  chan Message
-	handlers map[string]func(Message)
-	wg       sync.WaitGroup
-	stop     chan struct{}
+	ID            string
+	mailbox       chan Message
+	handlers      map[string]func(Message)
+	replyHandlers map[string]ReplyHandler
+	onStop        func()
+	backpressure  BackpressurePolicy
+	metrics       actorMetrics
+	wg            sync.WaitGroup
+	stop          chan struct{}
+	closing       chan struct{} // Closed when Stop or StopGracefully begins
+
+	sendMu    sync.RWMutex // Held for reading by senders, for writing to close the mailbox
+	closeOnce sync.Once
+	stopOnce  sync.Once
 }
 
-// NewActor create new Actor
+// NewActor create new Actor with a 100-message mailbox that blocks senders when full
 func NewActor(id string) *Actor {
+	return NewActorWithOptions(id, ActorOptions{MailboxSize: 100, Backpressure: BackpressureBlock})
+}
+
+// NewActorWithOptions creates an Actor with the given mailbox size and backpressure policy
+func NewActorWithOptions(id string, opts ActorOptions) *Actor {
 	return &Actor{
-		ID:       id,
-		mailbox:  make(chan Message, 100),
-		handlers: make(map[string]func(Message)),
-		stop:     make(chan struct{}),
+		ID:            id,
+		mailbox:       make(chan Message, opts.MailboxSize),
+		handlers:      make(map[string]func(Message)),
+		replyHandlers: make(map[string]ReplyHandler),
+		backpressure:  opts.Backpressure,
+		stop:          make(chan struct{}),
+		closing:       make(chan struct{}),
 	}
 }
 
+// Stats returns a snapshot of the actor's metrics
+func (a *Actor) Stats() ActorStats {
+	m := &a.metrics
+	stats := ActorStats{
+		MailboxDepth: len(a.mailbox),
+		PeakDepth:    int(m.peakDepth.Load()),
+		Processed:    m.processed.Load(),
+		Dropped:      m.dropped.Load(),
+		Rejected:     m.rejected.Load(),
+		MaxLatency:   time.Duration(m.latencyMax.Load()),
+	}
+	if stats.Processed > 0 {
+		stats.AvgLatency = time.Duration(m.latencyTotal.Load() / int64(stats.Processed))
+	}
+	return stats
+}
+
 // RegisterHandler register message handler
 func (a *Actor) RegisterHandler(msgType string, handler func(Message)) {
 	a.handlers[msgType] = handler
 }
 
-// Send send message to Actor
+// RegisterReplyHandler registers the handler that answers Ask for msgType
+func (a *Actor) RegisterReplyHandler(msgType string, handler ReplyHandler) {
+	a.replyHandlers[msgType] = handler
+}
+
+// OnStop sets a hook that runs on the actor's goroutine after its last
+// message, however the actor was stopped
+func (a *Actor) OnStop(hook func()) {
+	a.onStop = hook
+}
+
+// enqueue puts msg in the mailbox unless the actor no longer accepts
+// messages, which is from the moment a stop begins. A full mailbox is handled
+// by the backpressure policy; under BackpressureBlock, timeout (nil waits
+// indefinitely) bounds the wait.
+func (a *Actor) enqueue(msg Message, timeout <-chan time.Time) error {
+	a.sendMu.RLock()
+	defer a.sendMu.RUnlock()
+	select {
+	case <-a.closing:
+		return ErrActorStopped
+	default:
+	}
+	defer func() { storeMax(&a.metrics.peakDepth, int64(len(a.mailbox))) }()
+
+	if a.backpressure == BackpressureBlock {
+		select {
+		case a.mailbox <- msg:
+			return nil
+		case <-a.closing:
+			return ErrActorStopped
+		case <-timeout:
+			return fmt.Errorf("%w: mailbox full", ErrAskTimeout)
+		}
+	}
+
+	for {
+		select {
+		case a.mailbox <- msg:
+			return nil
+		default:
+		}
+
+		switch a.backpressure {
+		case BackpressureError:
+			a.metrics.rejected.Add(1)
+			return ErrMailboxFull
+		case BackpressureDropNewest:
+			a.discard(msg)
+			return nil
+		case BackpressureDropOldest:
+			// The actor may take the oldest first; then the next send succeeds
+			select {
+			case oldest := <-a.mailbox:
+				a.discard(oldest)
+			default:
+			}
+		}
+	}
+}
+
+// discard counts a message dropped by backpressure and fails its Ask
+func (a *Actor) discard(msg Message) {
+	a.metrics.dropped.Add(1)
+	if ask, ok := msg.(askMessage); ok {
+		ask.reply.SetError(ErrMailboxFull)
+	}
+}
+
+// Ask sends msg and returns a Future completed with the reply handler's
+// result, or with ErrAskTimeout if no reply arrives within timeout. The
+// error is for messages that could not be enqueued at all: no reply handler,
+// a stopped actor, or a full mailbox (for the whole timeout under
+// BackpressureBlock, at once under BackpressureError).
+func (a *Actor) Ask(msg Message, timeout time.Duration) (*Future, error) {
+	if _, ok := a.replyHandlers[msg.Type()]; !ok {
+		return nil, fmt.Errorf("%w %q", ErrNoReplyHandler, msg.Type())
+	}
+
+	timer := time.NewTimer(timeout)
+	future := NewFuture()
+	if err := a.enqueue(askMessage{Message: msg, reply: future}, timer.C); err != nil {
+		timer.Stop()
+		return nil, err
+	}
+
+	// Whichever comes first wins; Future ignores the later completion
+	go func() {
+		select {
+		case <-timer.C:
+			future.SetError(ErrAskTimeout)
+		case <-future.done:
+			timer.Stop()
+		}
+	}()
+	return future, nil
+}
+
+// Send send message to Actor. Messages the actor refuses (it is stopping,
+// or BackpressureError with a full mailbox) are dropped with a log line;
+// use TrySend to handle those errors instead.
 func (a *Actor) Send(msg Message) {
-	a.mailbox <- msg
+	if err := a.TrySend(msg); err != nil {
+		fmt.Printf("Actor %s: dropped %s message: %v\n", a.ID, msg.Type(), err)
+	}
+}
+
+// TrySend is Send that reports ErrActorStopped or ErrMailboxFull to the caller
+func (a *Actor) TrySend(msg Message) error {
+	return a.enqueue(msg, nil)
 }
 
 // Start start Actor
@@ -172,28 +409,332 @@ func (a *Actor) Start() {
 	a.wg.Add(1)
 	go func() {
 		defer a.wg.Done()
+		defer func() {
+			if a.onStop != nil {
+				a.onStop()
+			}
+		}()
 		for {
 			select {
-			case msg := <-a.mailbox:
-				if handler, exists := a.handlers[msg.Type()]; exists {
-					handler(msg)
-				} else {
-					fmt.Printf("Actor %s: unknown message type %s\n", a.ID, msg.Type())
+			case msg, ok := <-a.mailbox:
+				if !ok {
+					select {
+					case <-a.stop: // Stop closes the mailbox too
+						a.abandon(nil)
+					default:
+						fmt.Printf("Actor %s: mailbox drained, stopping\n", a.ID)
+					}
+					return
+				}
+				// A forced stop wins over messages that are still queued
+				select {
+				case <-a.stop:
+					a.abandon(msg)
+					return
+				default:
 				}
+				a.handle(msg)
 			case <-a.stop:
-				fmt.Printf("Actor %s: stopping\n", a.ID)
+				a.abandon(nil)
 				return
 			}
 		}
 	}()
 }
 
-// Stop stop Actor
+func (a *Actor) handle(msg Message) {
+	start := time.Now()
+	defer func() {
+		elapsed := int64(time.Since(start))
+		a.metrics.processed.Add(1)
+		a.metrics.latencyTotal.Add(elapsed)
+		storeMax(&a.metrics.latencyMax, elapsed)
+	}()
+
+	if ask, ok := msg.(askMessage); ok {
+		ask.reply.complete(a.replyHandlers[ask.Type()](ask.Message))
+	} else if handler, exists := a.handlers[msg.Type()]; exists {
+		handler(msg)
+	} else {
+		fmt.Printf("Actor %s: unknown message type %s\n", a.ID, msg.Type())
+	}
+}
+
+// abandon discards what is left in the mailbox after a forced stop (plus
+// first, already received) and fails pending Asks instead of letting them
+// time out
+func (a *Actor) abandon(first Message) {
+	dropped := 0
+	drop := func(msg Message) {
+		if ask, ok := msg.(askMessage); ok {
+			ask.reply.SetError(ErrActorStopped)
+		}
+		dropped++
+	}
+	if first != nil {
+		drop(first)
+	}
+drain:
+	for {
+		select {
+		case msg, ok := <-a.mailbox:
+			if !ok {
+				break drain
+			}
+			drop(msg)
+		default:
+			break drain
+		}
+	}
+	if dropped > 0 {
+		fmt.Printf("Actor %s: stopping, dropped %d queued messages\n", a.ID, dropped)
+	} else {
+		fmt.Printf("Actor %s: stopping\n", a.ID)
+	}
+}
+
+// closeMailbox stops accepting messages. Closing closing first releases
+// senders blocked on a full mailbox, including a handler sending to its own
+// actor, which would otherwise hold the read lock forever; taking the write
+// lock then waits for senders still inside enqueue, so none can send on the
+// closed channel.
+func (a *Actor) closeMailbox() {
+	a.closeOnce.Do(func() {
+		close(a.closing)
+		a.sendMu.Lock()
+		defer a.sendMu.Unlock()
+		close(a.mailbox)
+	})
+}
+
+// Stop stop Actor. Messages still in the mailbox are dropped; use
+// StopGracefully to process them first. Safe to call more than once, but
+// not from one of the actor's own handlers, since it waits for the actor.
 func (a *Actor) Stop() {
-	close(a.stop)
+	a.stopOnce.Do(func() { close(a.stop) }) // Releases senders blocked on a full mailbox
+	a.closeMailbox()
 	a.wg.Wait()
 }
 
+// StopGracefully stops accepting messages, lets the actor process everything
+// already in its mailbox, then runs the OnStop hook. If ctx ends first the
+// actor is stopped as with Stop (remaining messages dropped, pending Asks
+// failed) and the context's error is returned without waiting for a handler
+// that is still running.
+func (a *Actor) StopGracefully(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		a.closeMailbox()
+		a.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		a.stopOnce.Do(func() { close(a.stop) })
+		return fmt.Errorf("actor %s: forced stop: %w", a.ID, ctx.Err())
+	}
+}
+
+// RoutingStrategy picks which routees receive a message
+type RoutingStrategy interface {
+	Select(msg Message, routees []*Actor) []*Actor
+}
+
+// routeeObserver is implemented by strategies that precompute state for a
+// routee set; the router calls it whenever routees are added or removed
+type routeeObserver interface {
+	RouteesChanged(routees []*Actor)
+}
+
+// ErrNoRoutees is returned when a router has nobody to deliver to
+var ErrNoRoutees = errors.New("router: no routees")
+
+// Router forwards messages to routees chosen by a pluggable strategy.
+// Routees can be added and removed while messages are being routed.
+type Router struct {
+	mu       sync.RWMutex
+	routees  []*Actor
+	strategy RoutingStrategy
+}
+
+// NewRouter creates a router over the given routees
+func NewRouter(strategy RoutingStrategy, routees ...*Actor) *Router {
+	r := &Router{strategy: strategy}
+	for _, a := range routees {
+		r.AddRoutee(a)
+	}
+	return r
+}
+
+// AddRoutee adds a routee; adding one that is already present is a no-op
+func (r *Router) AddRoutee(a *Actor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.routees {
+		if existing.ID == a.ID {
+			return
+		}
+	}
+	// Copy on write: strategies may hold on to the previous slice
+	r.routees = append(append([]*Actor(nil), r.routees...), a)
+	r.notify()
+}
+
+// RemoveRoutee removes the routee with the given ID and reports whether it was present
+func (r *Router) RemoveRoutee(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.routees {
+		if existing.ID == id {
+			routees := append([]*Actor(nil), r.routees[:i]...)
+			r.routees = append(routees, r.routees[i+1:]...)
+			r.notify()
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Router) notify() {
+	if observer, ok := r.strategy.(routeeObserver); ok {
+		observer.RouteesChanged(r.routees)
+	}
+}
+
+// Routees returns the current routees
+func (r *Router) Routees() []*Actor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.routees
+}
+
+// Select returns the routees msg would be delivered to, without sending it
+func (r *Router) Select(msg Message) []*Actor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.routees) == 0 {
+		return nil
+	}
+	return r.strategy.Select(msg, r.routees)
+}
+
+// Route delivers msg to the routees chosen by the strategy
+func (r *Router) Route(msg Message) error {
+	targets := r.Select(msg)
+	if len(targets) == 0 {
+		return ErrNoRoutees
+	}
+	for _, target := range targets {
+		target.Send(msg)
+	}
+	return nil
+}
+
+// RoundRobinStrategy cycles through the routees in order
+type RoundRobinStrategy struct {
+	next atomic.Uint64
+}
+
+func (s *RoundRobinStrategy) Select(_ Message, routees []*Actor) []*Actor {
+	i := s.next.Add(1) - 1
+	return []*Actor{routees[i%uint64(len(routees))]}
+}
+
+// RandomStrategy picks a routee uniformly at random
+type RandomStrategy struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewRandomStrategy creates a random strategy; a fixed seed makes runs repeatable
+func NewRandomStrategy(seed int64) *RandomStrategy {
+	return &RandomStrategy{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (s *RandomStrategy) Select(_ Message, routees []*Actor) []*Actor {
+	s.mu.Lock()
+	i := s.rng.Intn(len(routees))
+	s.mu.Unlock()
+	return []*Actor{routees[i]}
+}
+
+// BroadcastStrategy sends every message to every routee
+type BroadcastStrategy struct{}
+
+func (BroadcastStrategy) Select(_ Message, routees []*Actor) []*Actor {
+	return routees
+}
+
+// ConsistentHashStrategy sends messages with the same key to the same
+// routee. Each routee owns many points on a hash ring, so adding or removing
+// one only moves the keys next to its points, about 1/n of them, where
+// hashing modulo n would move most keys.
+type ConsistentHashStrategy struct {
+	key      func(Message) string
+	replicas int
+
+	mu   sync.RWMutex
+	ring []ringPoint // Sorted by hash
+}
+
+type ringPoint struct {
+	hash   uint64
+	routee *Actor
+}
+
+// NewConsistentHashStrategy hashes the key returned by key, with replicas
+// virtual points per routee (more points spread keys more evenly)
+func NewConsistentHashStrategy(key func(Message) string, replicas int) *ConsistentHashStrategy {
+	return &ConsistentHashStrategy{key: key, replicas: replicas}
+}
+
+// hashKey hashes with FNV-1a, then mixes the bits (the splitmix64 finalizer)
+// because FNV alone clusters short, similar keys such as "user-1", "user-2"
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// RouteesChanged rebuilds the ring
+func (s *ConsistentHashStrategy) RouteesChanged(routees []*Actor) {
+	ring := make([]ringPoint, 0, len(routees)*s.replicas)
+	for _, a := range routees {
+		for i := 0; i < s.replicas; i++ {
+			ring = append(ring, ringPoint{hash: hashKey(fmt.Sprintf("%s#%d", a.ID, i)), routee: a})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool { return ring[i].hash < ring[j].hash })
+
+	s.mu.Lock()
+	s.ring = ring
+	s.mu.Unlock()
+}
+
+func (s *ConsistentHashStrategy) Select(msg Message, _ []*Actor) []*Actor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.ring) == 0 {
+		return nil
+	}
+	h := hashKey(s.key(msg))
+	// First point clockwise from the key's hash, wrapping around
+	i := sort.Search(len(s.ring), func(i int) bool { return s.ring[i].hash >= h })
+	if i == len(s.ring) {
+		i = 0
+	}
+	return []*Actor{s.ring[i].routee}
+}
+
 // ActorExamples runs all Actor model examples
 func ActorExamples() {
 	fmt.Println("=== Actor Model Examples ===")
@@ -221,6 +762,18 @@ func ActorExamples() {
 
 	// Example 8: Comprehensive example
 	comprehensiveActorExample()
+
+	// Example 9: Ask pattern
+	askPatternExample()
+
+	// Example 10: Graceful shutdown
+	gracefulStopExample()
+
+	// Example 11: Router strategies
+	routerStrategiesExample()
+
+	// Example 12: Metrics and backpressure
+	backpressureExample()
 }
 
 // Example 1: Basic Actor
@@ -243,9 +796,9 @@ func basicActorExample() {
 		}
 	})
 
+	// Stop waits for the actor's goroutine, so a handler must not call it
 	actor.RegisterHandler("stop", func(msg Message) {
 		fmt.Printf("Actor %s: received stop message\n", actor.ID)
-		actor.Stop()
 	})
 
 	// Start Actor
@@ -339,10 +892,10 @@ func actorPoolExample() {
 		actors[i] = actor
 	}
 
-	// Send tasks
+	// Send tasks; the router takes turns instead of indexing by hand
+	pool := NewRouter(&RoundRobinStrategy{}, actors...)
 	for i := 1; i <= 10; i++ {
-		workerIndex := (i - 1) % poolSize
-		actors[workerIndex].Send(WorkMessage{ID: i, Task: fmt.Sprintf("Task%d", i)})
+		pool.Route(WorkMessage{ID: i, Task: fmt.Sprintf("Task%d", i)})
 	}
 
 	// Wait for all tasks to complete
@@ -473,7 +1026,7 @@ func routerActorExample() {
 	for i := 1; i <= 3; i++ {
 		targetID := fmt.Sprintf("target-%d", i)
 		target := NewActor(targetID)
-		target.RegisterHandler("data", func(msg Message) {
+		target.RegisterHandler("string", func(msg Message) {
 			if dataMsg, ok := msg.(StringMessage); ok {
 				fmt.Printf("%s: received data '%s'\n", target.ID, dataMsg.Content)
 			}
@@ -482,7 +1035,8 @@ func routerActorExample() {
 		targets[targetID] = target
 	}
 
-	// Register router handler
+	// Register router handler; routing by explicit name is the simplest
+	// strategy, Example 11 shows Router with pluggable ones
 	router.RegisterHandler("route", func(msg Message) {
 		if routeMsg, ok := msg.(RouteMessage); ok {
 			if target, exists := targets[routeMsg.Target]; exists {
@@ -634,10 +1188,13 @@ func comprehensiveActorExample() {
 	client1 := NewActor("client1")
 	client2 := NewActor("client2")
 
-	// Client handlers
+	// Client handlers forward the request to the bank and wait for its reply
+	// on a channel of their own, so concurrent clients never read each other's
 	clientHandler := func(clientID string) func(Message) {
 		return func(msg Message) {
 			if bankMsg, ok := msg.(BankMessage); ok {
+				bankMsg.Response = make(chan interface{}, 1)
+				bank.Send(bankMsg)
 				response := <-bankMsg.Response
 				fmt.Printf("%s: received response %v\n", clientID, response)
 			}
@@ -651,28 +1208,26 @@ func comprehensiveActorExample() {
 	client2.Start()
 
 	// Simulate banking operations
-	responseChan := make(chan interface{}, 1)
-
 	// Client1 creates account and deposits
-	client1.Send(BankMessage{Operation: "create", AccountID: "ACC001", Response: responseChan})
+	client1.Send(BankMessage{Operation: "create", AccountID: "ACC001"})
 	time.Sleep(50 * time.Millisecond)
-	client1.Send(BankMessage{Operation: "deposit", AccountID: "ACC001", Amount: 1000, Response: responseChan})
+	client1.Send(BankMessage{Operation: "deposit", AccountID: "ACC001", Amount: 1000})
 	time.Sleep(50 * time.Millisecond)
 
 	// Client2 creates account and deposits
-	client2.Send(BankMessage{Operation: "create", AccountID: "ACC002", Response: responseChan})
+	client2.Send(BankMessage{Operation: "create", AccountID: "ACC002"})
 	time.Sleep(50 * time.Millisecond)
-	client2.Send(BankMessage{Operation: "deposit", AccountID: "ACC002", Amount: 500, Response: responseChan})
+	client2.Send(BankMessage{Operation: "deposit", AccountID: "ACC002", Amount: 500})
 	time.Sleep(50 * time.Millisecond)
 
 	// Query balances
-	client1.Send(BankMessage{Operation: "balance", AccountID: "ACC001", Response: responseChan})
-	client2.Send(BankMessage{Operation: "balance", AccountID: "ACC002", Response: responseChan})
+	client1.Send(BankMessage{Operation: "balance", AccountID: "ACC001"})
+	client2.Send(BankMessage{Operation: "balance", AccountID: "ACC002"})
 	time.Sleep(50 * time.Millisecond)
 
 	// Withdraw
-	client1.Send(BankMessage{Operation: "withdraw", AccountID: "ACC001", Amount: 300, Response: responseChan})
-	client2.Send(BankMessage{Operation: "withdraw", AccountID: "ACC002", Amount: 200, Response: responseChan})
+	client1.Send(BankMessage{Operation: "withdraw", AccountID: "ACC001", Amount: 300})
+	client2.Send(BankMessage{Operation: "withdraw", AccountID: "ACC002", Amount: 200})
 	time.Sleep(50 * time.Millisecond)
 
 	time.Sleep(200 * time.Millisecond)
@@ -685,3 +1240,232 @@ func comprehensiveActorExample() {
 	client1.Stop()
 	client2.Stop()
 }
+
+// Example 9: Ask pattern
+func askPatternExample() {
+	fmt.Println("\n--- Example 9: Ask Pattern ---")
+
+	// The same key-value state as Example 5, but replies go through Ask
+	// instead of a Response channel carried by every message
+	store := NewActor("store")
+	state := map[string]interface{}{"name": "Alice", "age": 30}
+
+	store.RegisterReplyHandler("lookup", func(msg Message) (interface{}, error) {
+		key := msg.(LookupMessage).Key
+		if key == "slow" {
+			time.Sleep(200 * time.Millisecond) // Outlives the caller's timeout
+		}
+		value, exists := state[key]
+		if !exists {
+			return nil, fmt.Errorf("key %q not found", key)
+		}
+		return value, nil
+	})
+	store.Start()
+
+	// Several Asks in flight at once; each Future gets its own reply
+	keys := []string{"name", "age", "missing"}
+	futures := make([]*Future, len(keys))
+	for i, key := range keys {
+		future, err := store.Ask(LookupMessage{Key: key}, time.Second)
+		if err != nil {
+			fmt.Printf("Ask %s failed: %v\n", key, err)
+			return
+		}
+		futures[i] = future
+	}
+	for i, future := range futures {
+		value, err := future.Get()
+		fmt.Printf("Ask lookup %s: value=%v err=%v\n", keys[i], value, err)
+	}
+
+	// A reply that arrives after the timeout is dropped
+	future, _ := store.Ask(LookupMessage{Key: "slow"}, 50*time.Millisecond)
+	_, err := future.Get()
+	fmt.Printf("Ask lookup slow (50ms timeout): err=%v, timed out: %v\n", err, errors.Is(err, ErrAskTimeout))
+
+	// Message types without a reply handler are rejected up front
+	_, err = store.Ask(StringMessage{Content: "hello"}, time.Second)
+	fmt.Printf("Ask with a string message: %v\n", err)
+
+	store.Stop()
+	_, err = store.Ask(LookupMessage{Key: "name"}, time.Second)
+	fmt.Printf("Ask after Stop: %v\n", err)
+}
+
+// Example 10: Graceful shutdown
+func gracefulStopExample() {
+	fmt.Println("\n--- Example 10: Graceful Shutdown ---")
+
+	// newCounter builds an actor that takes perItem to process a work message
+	newCounter := func(id string, perItem time.Duration) (*Actor, *int) {
+		processed := 0
+		actor := NewActor(id)
+		actor.RegisterHandler("work", func(msg Message) {
+			time.Sleep(perItem)
+			processed++
+		})
+		actor.OnStop(func() {
+			fmt.Printf("Actor %s: OnStop hook, flushing %d processed items\n", id, processed)
+		})
+		return actor, &processed
+	}
+
+	// Stop drops whatever is still queued
+	abrupt, processed := newCounter("abrupt", 5*time.Millisecond)
+	abrupt.Start()
+	for i := 0; i < 10; i++ {
+		abrupt.Send(WorkMessage{ID: i})
+	}
+	time.Sleep(12 * time.Millisecond)
+	abrupt.Stop()
+	fmt.Printf("Stop: processed %d of 10\n", *processed)
+
+	// StopGracefully processes everything already queued, then refuses more
+	graceful, processed := newCounter("graceful", 5*time.Millisecond)
+	graceful.Start()
+	for i := 0; i < 10; i++ {
+		graceful.Send(WorkMessage{ID: i})
+	}
+	err := graceful.StopGracefully(context.Background())
+	fmt.Printf("StopGracefully: processed %d of 10, err=%v\n", *processed, err)
+	graceful.Send(WorkMessage{ID: 10})
+
+	// A deadline bounds the drain; past it the actor is stopped by force and
+	// queued Asks fail at once instead of waiting for their own timeout
+	stuck, processed := newCounter("stuck", 40*time.Millisecond)
+	stuck.RegisterReplyHandler("lookup", func(msg Message) (interface{}, error) {
+		return "never reached", nil
+	})
+	stuck.Start()
+	for i := 0; i < 10; i++ {
+		stuck.Send(WorkMessage{ID: i})
+	}
+	pending, _ := stuck.Ask(LookupMessage{Key: "status"}, 5*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = stuck.StopGracefully(ctx)
+	fmt.Printf("StopGracefully with 100ms deadline: returned after %v, err=%v\n",
+		time.Since(start).Round(10*time.Millisecond), err)
+	_, askErr := pending.Get()
+	fmt.Printf("Queued Ask failed with: %v\n", askErr)
+	stuck.Stop() // Waits for the handler that was still running
+	fmt.Printf("Forced stop: processed %d of 10\n", *processed)
+}
+
+// Example 11: Router strategies
+func routerStrategiesExample() {
+	fmt.Println("\n--- Example 11: Router Strategies ---")
+
+	// Routees record which of them handled each task
+	var mu sync.Mutex
+	handledBy := make(map[string][]int)
+	var wg sync.WaitGroup
+	newRoutee := func(id string) *Actor {
+		a := NewActor(id)
+		a.RegisterHandler("work", func(msg Message) {
+			mu.Lock()
+			handledBy[id] = append(handledBy[id], msg.(WorkMessage).ID)
+			mu.Unlock()
+			wg.Done()
+		})
+		a.Start()
+		return a
+	}
+	routees := []*Actor{newRoutee("r1"), newRoutee("r2"), newRoutee("r3")}
+	defer func() {
+		for _, a := range routees {
+			a.Stop()
+		}
+	}()
+
+	run := func(name string, router *Router, tasks, fanout int) {
+		mu.Lock()
+		handledBy = make(map[string][]int)
+		mu.Unlock()
+		wg.Add(tasks * fanout)
+		for i := 1; i <= tasks; i++ {
+			router.Route(WorkMessage{ID: i, Task: fmt.Sprintf("user-%d", i%4)})
+		}
+		wg.Wait()
+		fmt.Printf("%-12s r1=%v r2=%v r3=%v\n", name+":", handledBy["r1"], handledBy["r2"], handledBy["r3"])
+	}
+
+	byTask := func(msg Message) string { return msg.(WorkMessage).Task }
+	run("round-robin", NewRouter(&RoundRobinStrategy{}, routees...), 6, 1)
+	run("random", NewRouter(NewRandomStrategy(1), routees...), 6, 1)
+	run("broadcast", NewRouter(BroadcastStrategy{}, routees...), 2, len(routees))
+	run("hash(user)", NewRouter(NewConsistentHashStrategy(byTask, 100), routees...), 8, 1)
+
+	// Dynamic routees: a consistent hash keeps most keys where they were when
+	// a routee joins, where hashing modulo the routee count reshuffles them
+	const keys = 1000
+	hashRouter := NewRouter(NewConsistentHashStrategy(byTask, 100), routees...)
+	owner := func(i int) string {
+		return hashRouter.Select(WorkMessage{Task: fmt.Sprintf("key-%d", i)})[0].ID
+	}
+	before := make([]string, keys)
+	for i := range before {
+		before[i] = owner(i)
+	}
+	extra := newRoutee("r4")
+	routees = append(routees, extra)
+	hashRouter.AddRoutee(extra)
+
+	movedHash, movedModulo := 0, 0
+	for i := range before {
+		if owner(i) != before[i] {
+			movedHash++
+		}
+		if h := hashKey(fmt.Sprintf("key-%d", i)); h%3 != h%4 {
+			movedModulo++
+		}
+	}
+	fmt.Printf("Adding a 4th routee moved %d of %d keys with consistent hashing, %d with modulo hashing\n",
+		movedHash, keys, movedModulo)
+
+	hashRouter.RemoveRoutee("r4")
+	restored := true
+	for i := range before {
+		restored = restored && owner(i) == before[i]
+	}
+	fmt.Printf("After removing it again every key is back on its old routee: %v\n", restored)
+
+	err := NewRouter(BroadcastStrategy{}).Route(WorkMessage{ID: 1})
+	fmt.Printf("Routing with no routees: %v\n", err)
+}
+
+// Example 12: Metrics and backpressure
+func backpressureExample() {
+	fmt.Println("\n--- Example 12: Metrics and Backpressure ---")
+
+	// A producer sends 20 messages as fast as it can to an actor that needs
+	// 2ms per message and has room for 5
+	fmt.Printf("%-12s %9s %9s %7s %8s %5s %8s %8s  %s\n",
+		"policy", "send time", "processed", "dropped", "rejected", "peak", "avg", "max", "handled IDs")
+	for _, policy := range []BackpressurePolicy{BackpressureBlock, BackpressureDropOldest, BackpressureDropNewest, BackpressureError} {
+		var handled []int
+		a := NewActorWithOptions("slow-"+policy.String(), ActorOptions{MailboxSize: 5, Backpressure: policy})
+		a.RegisterHandler("number", func(msg Message) {
+			time.Sleep(2 * time.Millisecond)
+			handled = append(handled, msg.(NumberMessage).Value)
+		})
+		a.Start()
+
+		start := time.Now()
+		for i := 1; i <= 20; i++ {
+			a.TrySend(NumberMessage{Value: i}) // Rejections show up in Stats
+		}
+		sendTime := time.Since(start)
+		a.StopGracefully(context.Background())
+
+		st := a.Stats()
+		fmt.Printf("%-12s %9v %9d %7d %8d %5d %8v %8v  %v\n", policy, sendTime.Round(time.Millisecond),
+			st.Processed, st.Dropped, st.Rejected, st.PeakDepth,
+			st.AvgLatency.Round(100*time.Microsecond), st.MaxLatency.Round(100*time.Microsecond), handled)
+	}
+	fmt.Println("block slows the producer to the actor's pace; drop-oldest keeps the freshest messages,")
+	fmt.Println("drop-newest the earliest, and error hands the decision back to the sender")
+}
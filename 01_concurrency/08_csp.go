@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/chans"
 )
 
 // Define request and response structures
@@ -45,6 +52,9 @@ func CSPExamples() {
 
 	// Example 8: Comprehensive example
 	comprehensiveCSPExample()
+
+	// Example 9: Request coalescing batcher
+	batcherCSPExample()
 }
 
 // Example 1: Basic CSP communication
@@ -87,61 +97,77 @@ func multiProcessCSPExample() {
 	ch2 := make(chan int)
 	ch3 := make(chan int)
 
-	// Process 1: Data generator
-	go func() {
-		defer close(ch1)
-		for i := 1; i <= 5; i++ {
-			fmt.Printf("Generator: generating data %d\n", i)
-			ch1 <- i
-			time.Sleep(100 * time.Millisecond)
-		}
-	}()
-
-	// Process 2: Data processor
-	go func() {
-		defer close(ch2)
-		for data := range ch1 {
-			processed := data * 2
-			fmt.Printf("Processor: processing %d -> %d\n", data, processed)
-			ch2 <- processed
-			time.Sleep(50 * time.Millisecond)
-		}
-	}()
-
-	// Process 3: Data validator
-	go func() {
-		defer close(ch3)
-		for data := range ch2 {
-			if data%2 == 0 {
+	// The processes run in a structured scope (26_structured.go): the
+	// example returns when the last of them has finished instead of
+	// sleeping and hoping they are done, and an error in one stops the rest
+	err := Structured(context.Background(), func(g *StructuredGroup) error {
+		// Process 1: Data generator
+		g.Go(func(ctx context.Context) error {
+			defer close(ch1)
+			for i := 1; i <= 5; i++ {
+				fmt.Printf("Generator: generating data %d\n", i)
+				select {
+				case ch1 <- i:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			return nil
+		})
+
+		// Process 2: Data processor
+		g.Go(func(ctx context.Context) error {
+			defer close(ch2)
+			for data := range ch1 {
+				processed := data * 2
+				fmt.Printf("Processor: processing %d -> %d\n", data, processed)
+				select {
+				case ch2 <- processed:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+			return nil
+		})
+
+		// Process 3: Data validator
+		g.Go(func(ctx context.Context) error {
+			defer close(ch3)
+			for data := range ch2 {
+				if data%2 != 0 {
+					return fmt.Errorf("validator: validation failed %d", data)
+				}
 				fmt.Printf("Validator: validation passed %d\n", data)
-				ch3 <- data
-			} else {
-				fmt.Printf("Validator: validation failed %d\n", data)
+				select {
+				case ch3 <- data:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
 			}
-		}
-	}()
-
-	// Main process: Result collector
-	go func() {
-		for result := range ch3 {
-			fmt.Printf("Collector: collecting result %d\n", result)
-		}
-		fmt.Println("Collector: finished collecting")
-	}()
+			return nil
+		})
 
-	time.Sleep(1 * time.Second)
+		// Main process: Result collector
+		g.Go(func(ctx context.Context) error {
+			for result := range ch3 {
+				fmt.Printf("Collector: collecting result %d\n", result)
+			}
+			fmt.Println("Collector: finished collecting")
+			return nil
+		})
+		return nil
+	})
+	fmt.Printf("All processes finished, err=%v\n", err)
 }
 
 // Example 3: Pipeline processing
 func pipelineCSPExample() {
 	fmt.Println("\n--- Example 3: Pipeline Processing ---")
 
-	// Create pipeline stages
-	stage1 := make(chan int, 10)
-	stage2 := make(chan int, 10)
-	stage3 := make(chan int, 10)
-
 	// Stage 1: Data input
+	stage1 := make(chan int, 10)
 	go func() {
 		defer close(stage1)
 		for i := 1; i <= 10; i++ {
@@ -150,50 +176,45 @@ func pipelineCSPExample() {
 		}
 	}()
 
-	// Stage 2: Data filtering
-	go func() {
-		defer close(stage2)
-		for data := range stage1 {
-			if data%2 == 0 {
-				fmt.Printf("Stage2: filter passed %d\n", data)
-				stage2 <- data
-			} else {
+	// Stages 2 and 3 as a Pipeline (22_pipeline.go): it owns the channels
+	// between stages, closes them in order and reports when all are done
+	results, errs := NewPipeline(stage1).
+		// Stage 2: Data filtering
+		Stage(1, func(_ context.Context, data int) (int, error) {
+			if data%2 != 0 {
 				fmt.Printf("Stage2: filter dropped %d\n", data)
+				return 0, ErrSkip
 			}
-		}
-	}()
-
-	// Stage 3: Data transformation
-	go func() {
-		defer close(stage3)
-		for data := range stage2 {
+			fmt.Printf("Stage2: filter passed %d\n", data)
+			return data, nil
+		}).
+		// Stage 3: Data transformation
+		Stage(1, func(_ context.Context, data int) (int, error) {
 			transformed := data * data
 			fmt.Printf("Stage3: transform %d -> %d\n", data, transformed)
-			stage3 <- transformed
-		}
-	}()
-
-	// Result output
+			return transformed, nil
+		}).
+		Buffer(10).
+		Run(context.Background())
 	go func() {
-		for result := range stage3 {
-			fmt.Printf("Output: %d\n", result)
+		for err := range errs {
+			fmt.Printf("Pipeline error: %v\n", err)
 		}
-		fmt.Println("Pipeline processing completed")
 	}()
 
-	time.Sleep(1 * time.Second)
+	// Result output
+	for result := range results {
+		fmt.Printf("Output: %d\n", result)
+	}
+	fmt.Println("Pipeline processing completed")
 }
 
 // Example 4: Fan-in Fan-out
 func fanInFanOutCSPExample() {
 	fmt.Println("\n--- Example 4: Fan-in Fan-out ---")
 
-	// Create input and output channels
+	// Create input channel
 	input := make(chan int, 10)
-	output1 := make(chan int, 5)
-	output2 := make(chan int, 5)
-	output3 := make(chan int, 5)
-	merged := make(chan int, 15)
 
 	// Data generator
 	go func() {
@@ -205,94 +226,25 @@ func fanInFanOutCSPExample() {
 		}
 	}()
 
-	// Fan-out: Distribute input to multiple workers
-	go func() {
-		defer close(output1)
-		defer close(output2)
-		defer close(output3)
-
-		counter := 0
-		for data := range input {
-			switch counter % 3 {
-			case 0:
-				fmt.Printf("Dispatcher: sending to Worker1: %d\n", data)
-				output1 <- data
-			case 1:
-				fmt.Printf("Dispatcher: sending to Worker2: %d\n", data)
-				output2 <- data
-			case 2:
-				fmt.Printf("Dispatcher: sending to Worker3: %d\n", data)
-				output3 <- data
-			}
-			counter++
-		}
-	}()
-
-	// Worker 1
-	go func() {
-		for data := range output1 {
-			processed := data * 10
-			fmt.Printf("Worker1: processing %d -> %d\n", data, processed)
-			merged <- processed
-			time.Sleep(100 * time.Millisecond)
-		}
-	}()
-
-	// Worker 2
-	go func() {
-		for data := range output2 {
-			processed := data * 100
-			fmt.Printf("Worker2: processing %d -> %d\n", data, processed)
-			merged <- processed
-			time.Sleep(100 * time.Millisecond)
-		}
-	}()
-
-	// Worker 3
-	go func() {
-		for data := range output3 {
-			processed := data * 1000
-			fmt.Printf("Worker3: processing %d -> %d\n", data, processed)
-			merged <- processed
-			time.Sleep(100 * time.Millisecond)
-		}
-	}()
-
-	// Fan-in: Merge results
-	go func() {
-		defer close(merged)
-		var wg sync.WaitGroup
-
-		// Wait for all workers to complete
-		wg.Add(3)
-		go func() {
-			defer wg.Done()
-			for range output1 {
-			}
-		}()
-		go func() {
-			defer wg.Done()
-			for range output2 {
-			}
-		}()
-		go func() {
-			defer wg.Done()
-			for range output3 {
-			}
-		}()
+	// Fan-out: three workers pull from input (23_fan_out_in.go), so an idle
+	// worker takes the next item instead of waiting for its round-robin turn
+	ctx := context.Background()
+	outputs := FanOut(ctx, input, 3, func(data int) (int, error) {
+		processed := data * 10
+		fmt.Printf("Worker: processing %d -> %d\n", data, processed)
+		time.Sleep(100 * time.Millisecond)
+		return processed, nil
+	})
 
-		wg.Wait()
-	}()
+	// Fan-in: Merge results. FanIn closes merged only after every worker
+	// channel is drained; FanInOrdered would also restore the input order.
+	merged := FanIn(ctx, outputs...)
 
 	// Result collector
-	go func() {
-		for result := range merged {
-			fmt.Printf("Collector: received result %d\n", result)
-		}
-		fmt.Println("Fan-in Fan-out processing completed")
-	}()
-
-	time.Sleep(2 * time.Second)
+	for result := range merged {
+		fmt.Printf("Collector: received result %d (input #%d)\n", result.Value, result.Index+1)
+	}
+	fmt.Println("Fan-in Fan-out processing completed")
 }
 
 // Example 5: Worker pool CSP
@@ -353,73 +305,44 @@ func workerPoolCSPExample() {
 func pubSubCSPExample() {
 	fmt.Println("\n--- Example 6: Publish Subscribe ---")
 
-	// Create topic channels
-	topic1 := make(chan string, 10)
-	topic2 := make(chan string, 10)
-
-	// Publisher
-	go func() {
-		messages := []struct {
-			topic   chan string
-			message string
-		}{
-			{topic1, "Topic1: Message1"},
-			{topic2, "Topic2: Message1"},
-			{topic1, "Topic1: Message2"},
-			{topic2, "Topic2: Message2"},
-			{topic1, "Topic1: Message3"},
-		}
-
-		for _, msg := range messages {
-			fmt.Printf("Publisher: publishing to %v: %s\n", msg.topic, msg.message)
-			msg.topic <- msg.message
-			time.Sleep(100 * time.Millisecond)
-		}
-
-		close(topic1)
-		close(topic2)
-	}()
+	// A topic-routing EventBus (21_event_bus.go) instead of one raw channel
+	// per topic: async subscribers still get their own goroutine and queue,
+	// and a wildcard covers every topic without extra plumbing
+	bus := NewEventBus()
+	subscribe := func(name, pattern string) {
+		bus.SubscribeWithOptions(pattern, func(e Event) error {
+			fmt.Printf("%s: received %s\n", name, e.Payload)
+			return nil
+		}, SubscribeOptions{Async: true, QueueSize: 10})
+	}
 
 	// Subscriber 1: Subscribe to Topic1
-	go func() {
-		for msg := range topic1 {
-			fmt.Printf("Subscriber1: received %s\n", msg)
-		}
-		fmt.Println("Subscriber1: finished subscribing")
-	}()
-
+	subscribe("Subscriber1", "topic1")
 	// Subscriber 2: Subscribe to Topic2
-	go func() {
-		for msg := range topic2 {
-			fmt.Printf("Subscriber2: received %s\n", msg)
-		}
-		fmt.Println("Subscriber2: finished subscribing")
-	}()
-
+	subscribe("Subscriber2", "topic2")
 	// Subscriber 3: Subscribe to both Topics
-	go func() {
-		done := make(chan bool, 2)
-
-		go func() {
-			for msg := range topic1 {
-				fmt.Printf("Subscriber3(Topic1): received %s\n", msg)
-			}
-			done <- true
-		}()
+	subscribe("Subscriber3", "*")
 
-		go func() {
-			for msg := range topic2 {
-				fmt.Printf("Subscriber3(Topic2): received %s\n", msg)
-			}
-			done <- true
-		}()
-
-		<-done
-		<-done
-		fmt.Println("Subscriber3: finished subscribing")
-	}()
+	// Publisher
+	messages := []struct {
+		topic   string
+		message string
+	}{
+		{"topic1", "Topic1: Message1"},
+		{"topic2", "Topic2: Message1"},
+		{"topic1", "Topic1: Message2"},
+		{"topic2", "Topic2: Message2"},
+		{"topic1", "Topic1: Message3"},
+	}
+	for _, msg := range messages {
+		fmt.Printf("Publisher: publishing to %s: %s\n", msg.topic, msg.message)
+		bus.Publish(msg.topic, msg.message)
+		time.Sleep(100 * time.Millisecond)
+	}
 
-	time.Sleep(1 * time.Second)
+	// Close waits for every subscriber to drain its queue
+	bus.Close()
+	fmt.Println("All subscribers finished subscribing")
 }
 
 // Example 7: Request Response
@@ -451,8 +374,13 @@ func requestResponseCSPExample() {
 		}
 	}()
 
+	// Clients share a WaitGroup so requests is closed only after the last send
+	var clients sync.WaitGroup
+	clients.Add(2)
+
 	// Client 1
 	go func() {
+		defer clients.Done()
 		for i := 1; i <= 3; i++ {
 			req := Request{ID: i, Data: fmt.Sprintf("Client1 data%d", i)}
 			fmt.Printf("Client1: sending request %d\n", i)
@@ -463,6 +391,7 @@ func requestResponseCSPExample() {
 
 	// Client 2
 	go func() {
+		defer clients.Done()
 		for i := 4; i <= 6; i++ {
 			req := Request{ID: i, Data: fmt.Sprintf("Client2 data%d", i)}
 			fmt.Printf("Client2: sending request %d\n", i)
@@ -481,7 +410,7 @@ func requestResponseCSPExample() {
 
 	// Close request channel
 	go func() {
-		time.Sleep(1 * time.Second)
+		clients.Wait()
 		close(requests)
 	}()
 
@@ -581,3 +510,212 @@ func comprehensiveCSPExample() {
 
 	time.Sleep(2 * time.Second)
 }
+
+// BatchResult is the demultiplexed answer for one request in a batch
+type BatchResult[Resp any] struct {
+	Value Resp
+	Err   error
+}
+
+// BatchHandler processes a whole batch and returns one result per request, in
+// order. A non-nil error fails every request in the batch.
+type BatchHandler[Req, Resp any] func(ctx context.Context, batch []Req) ([]BatchResult[Resp], error)
+
+// ErrBatcherClosed is returned when submitting to a closed batcher
+var ErrBatcherClosed = errors.New("batcher: closed")
+
+type batchItem[Req, Resp any] struct {
+	req   Req
+	reply chan BatchResult[Resp]
+}
+
+// Batcher coalesces individual requests into batches that are flushed when
+// they reach maxSize or when the oldest request has waited maxLatency
+type Batcher[Req, Resp any] struct {
+	handler    BatchHandler[Req, Resp]
+	maxSize    int
+	maxLatency time.Duration
+
+	in     chan batchItem[Req, Resp]
+	mu     sync.RWMutex // Guards closed against concurrent Submit
+	closed bool
+	done   chan struct{}
+
+	batches, sizeFlushes, timerFlushes atomic.Int64
+}
+
+// NewBatcher starts a batcher in front of handler
+func NewBatcher[Req, Resp any](handler BatchHandler[Req, Resp], maxSize int, maxLatency time.Duration) *Batcher[Req, Resp] {
+	b := &Batcher[Req, Resp]{
+		handler:    handler,
+		maxSize:    maxSize,
+		maxLatency: maxLatency,
+		in:         make(chan batchItem[Req, Resp]),
+		done:       make(chan struct{}),
+	}
+	go b.loop()
+	return b
+}
+
+// Submit queues a request and returns the channel its result will arrive on
+func (b *Batcher[Req, Resp]) Submit(ctx context.Context, req Req) <-chan BatchResult[Resp] {
+	reply := make(chan BatchResult[Resp], 1) // Buffered so a dispatch never blocks on a gone caller
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		reply <- BatchResult[Resp]{Err: ErrBatcherClosed}
+		return reply
+	}
+
+	select {
+	case b.in <- batchItem[Req, Resp]{req: req, reply: reply}:
+	case <-ctx.Done():
+		reply <- BatchResult[Resp]{Err: ctx.Err()}
+	}
+	return reply
+}
+
+// Do submits a request and waits for its result
+func (b *Batcher[Req, Resp]) Do(ctx context.Context, req Req) (Resp, error) {
+	select {
+	case r := <-b.Submit(ctx, req):
+		return r.Value, r.Err
+	case <-ctx.Done():
+		var zero Resp
+		return zero, ctx.Err()
+	}
+}
+
+func (b *Batcher[Req, Resp]) loop() {
+	defer close(b.done)
+
+	// chans.Batch does the size-or-latency grouping and flushes what is left
+	// once Close closes b.in
+	var inflight sync.WaitGroup
+	for batch := range chans.Batch(context.Background(), b.in, b.maxSize, b.maxLatency) {
+		b.batches.Add(1)
+		if len(batch) == b.maxSize {
+			b.sizeFlushes.Add(1)
+		} else {
+			b.timerFlushes.Add(1)
+		}
+
+		// Dispatch concurrently so the next batch can fill while this one runs
+		inflight.Add(1)
+		go func(batch []batchItem[Req, Resp]) {
+			defer inflight.Done()
+			b.dispatch(batch)
+		}(batch)
+	}
+	inflight.Wait()
+}
+
+// dispatch calls the handler and routes each result back to its caller
+func (b *Batcher[Req, Resp]) dispatch(batch []batchItem[Req, Resp]) {
+	reqs := make([]Req, len(batch))
+	for i, item := range batch {
+		reqs[i] = item.req
+	}
+
+	results, err := b.handler(context.Background(), reqs)
+	if err == nil && len(results) != len(batch) {
+		err = fmt.Errorf("batcher: handler returned %d results for %d requests", len(results), len(batch))
+	}
+
+	for i, item := range batch {
+		if err != nil {
+			item.reply <- BatchResult[Resp]{Err: err}
+		} else {
+			item.reply <- results[i]
+		}
+	}
+}
+
+// Close flushes pending requests, waits for in-flight batches and rejects new submissions
+func (b *Batcher[Req, Resp]) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.in)
+	}
+	b.mu.Unlock()
+	<-b.done
+}
+
+// Stats reports how many batches were sent and what triggered them
+func (b *Batcher[Req, Resp]) Stats() string {
+	return fmt.Sprintf("batches=%d (full=%d, timer=%d)",
+		b.batches.Load(), b.sizeFlushes.Load(), b.timerFlushes.Load())
+}
+
+// Example 9: Request coalescing batcher
+func batcherCSPExample() {
+	fmt.Println("\n--- Example 9: Request Coalescing Batcher ---")
+
+	// The backend charges a fixed cost per call, no matter how many requests it carries
+	var backendCalls atomic.Int64
+	backend := func(ctx context.Context, batch []Request) ([]BatchResult[Response], error) {
+		backendCalls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+
+		results := make([]BatchResult[Response], len(batch))
+		for i, req := range batch {
+			if strings.Contains(req.Data, "invalid") {
+				results[i].Err = fmt.Errorf("request %d: invalid payload", req.ID)
+				continue
+			}
+			results[i].Value = Response{RequestID: req.ID, Result: strings.ToUpper(req.Data)}
+		}
+		return results, nil
+	}
+
+	batcher := NewBatcher[Request, Response](backend, 8, 20*time.Millisecond)
+
+	// 20 clients send one request each, as in the request/response example
+	start := time.Now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failures []string
+	succeeded := 0
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			data := fmt.Sprintf("client%d data", id)
+			if id%7 == 0 {
+				data = fmt.Sprintf("client%d invalid", id)
+			}
+			resp, err := batcher.Do(context.Background(), Request{ID: id, Data: data})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, err.Error())
+				return
+			}
+			if resp.RequestID != id {
+				failures = append(failures, fmt.Sprintf("client %d got response for %d", id, resp.RequestID))
+				return
+			}
+			succeeded++
+		}(i)
+	}
+	wg.Wait()
+	sort.Strings(failures)
+
+	fmt.Printf("20 requests in %v using %d backend calls (unbatched: 20 calls)\n",
+		time.Since(start).Round(10*time.Millisecond), backendCalls.Load())
+	fmt.Printf("Succeeded: %d, per-request errors: %v\n", succeeded, failures)
+	fmt.Printf("Stats: %s\n", batcher.Stats())
+
+	// A lone request is flushed by the latency timer instead of waiting for a full batch
+	start = time.Now()
+	resp, err := batcher.Do(context.Background(), Request{ID: 99, Data: "lonely"})
+	fmt.Printf("Single request: %q err=%v after %v\n", resp.Result, err, time.Since(start).Round(10*time.Millisecond))
+
+	batcher.Close()
+	_, err = batcher.Do(context.Background(), Request{ID: 100, Data: "late"})
+	fmt.Printf("After Close: %v\n", err)
+	fmt.Printf("Final stats: %s\n", batcher.Stats())
+}
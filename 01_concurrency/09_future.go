@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -13,6 +16,7 @@ type Future struct {
 	result interface{}
 	err    error
 	done   chan struct{}
+	once   sync.Once
 	mu     sync.RWMutex
 }
 
@@ -23,20 +27,45 @@ func NewFuture() *Future {
 	}
 }
 
-// SetResult sets the Future result
+// SetResult sets the Future result. Only the first SetResult or SetError
+// takes effect, so a late reply cannot overwrite a timeout.
 func (f *Future) SetResult(result interface{}) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	f.result = result
-	close(f.done)
+	f.complete(result, nil)
 }
 
 // SetError sets the Future error
 func (f *Future) SetError(err error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	f.err = err
-	close(f.done)
+	f.complete(nil, err)
+}
+
+// complete settles the Future and reports whether this call did; calls
+// after the first are ignored
+func (f *Future) complete(result interface{}, err error) bool {
+	settled := false
+	f.once.Do(func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.result, f.err = result, err
+		close(f.done)
+		settled = true
+	})
+	return settled
+}
+
+// ErrFutureCancelled is the error of a Future settled by Cancel
+var ErrFutureCancelled = errors.New("future: cancelled")
+
+// Cancel settles the Future with ErrFutureCancelled unless it already has a
+// result, and reports whether it did. Producers watching Done stop working;
+// whatever they set afterwards is ignored.
+func (f *Future) Cancel() bool {
+	return f.complete(nil, ErrFutureCancelled)
+}
+
+// Done is closed once the Future settles, whether with a result, an error
+// or by Cancel. A producer selects on it to abandon work nobody waits for.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
 }
 
 // Get gets the Future result, blocking until completion
@@ -59,6 +88,18 @@ func (f *Future) GetWithTimeout(timeout time.Duration) (interface{}, error) {
 	}
 }
 
+// GetWithContext waits for the result until ctx ends, then returns the
+// context's error. It does not cancel the Future, which other callers may
+// still be waiting on; call Cancel when the work should stop.
+func (f *Future) GetWithContext(ctx context.Context) (interface{}, error) {
+	select {
+	case <-f.done:
+		return f.Get()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // IsDone checks if Future is completed
 func (f *Future) IsDone() bool {
 	select {
@@ -69,6 +110,126 @@ func (f *Future) IsDone() bool {
 	}
 }
 
+// settlesBefore waits until f or other settles and reports whether f did.
+// Combinators wait with it so that once their own Future has settled, an
+// input that never does pins no goroutine.
+func (f *Future) settlesBefore(other *Future) bool {
+	select {
+	case <-f.done:
+		return true
+	case <-other.done:
+		return false
+	}
+}
+
+// Async runs fn on its own goroutine and returns a Future for its result
+func Async(fn func() (interface{}, error)) *Future {
+	f := NewFuture()
+	go func() {
+		f.complete(fn())
+	}()
+	return f
+}
+
+// Then returns a Future for fn applied to this Future's result. An error
+// skips fn and passes straight through, so a chain of Thens needs a single
+// error check at the end.
+func (f *Future) Then(fn func(interface{}) (interface{}, error)) *Future {
+	next := NewFuture()
+	go func() {
+		if !f.settlesBefore(next) {
+			return // next was cancelled; stop waiting on f
+		}
+		result, err := f.Get()
+		if err != nil {
+			next.SetError(err)
+			return
+		}
+		next.complete(fn(result))
+	}()
+	return next
+}
+
+// ErrNoFutures is the error of Any or Race called without futures
+var ErrNoFutures = errors.New("future: no futures to wait for")
+
+// All completes with the results of every future, in argument order, or
+// with the first error as soon as any future fails
+func All(futures ...*Future) *Future {
+	all := NewFuture()
+	results := make([]interface{}, len(futures))
+	var wg sync.WaitGroup
+	wg.Add(len(futures))
+	for i, f := range futures {
+		go func(i int, f *Future) {
+			defer wg.Done()
+			if !f.settlesBefore(all) {
+				return // Another future failed, or all was cancelled
+			}
+			result, err := f.Get()
+			if err != nil {
+				all.SetError(fmt.Errorf("future %d: %w", i, err))
+				return
+			}
+			results[i] = result // Each goroutine writes its own slot
+		}(i, f)
+	}
+	go func() {
+		wg.Wait()
+		all.SetResult(results) // Ignored if an error came first
+	}()
+	return all
+}
+
+// Any completes with the first successful result, or with every error
+// joined once all futures have failed
+func Any(futures ...*Future) *Future {
+	anyOf := NewFuture()
+	if len(futures) == 0 {
+		anyOf.SetError(ErrNoFutures)
+		return anyOf
+	}
+	errs := make([]error, len(futures))
+	var wg sync.WaitGroup
+	wg.Add(len(futures))
+	for i, f := range futures {
+		go func(i int, f *Future) {
+			defer wg.Done()
+			if !f.settlesBefore(anyOf) {
+				return // Another future succeeded, or anyOf was cancelled
+			}
+			result, err := f.Get()
+			if err != nil {
+				errs[i] = fmt.Errorf("future %d: %w", i, err)
+				return
+			}
+			anyOf.SetResult(result)
+		}(i, f)
+	}
+	go func() {
+		wg.Wait()
+		anyOf.SetError(errors.Join(errs...)) // Ignored if a result came first
+	}()
+	return anyOf
+}
+
+// Race completes like the first future to settle, success or failure
+func Race(futures ...*Future) *Future {
+	race := NewFuture()
+	if len(futures) == 0 {
+		race.SetError(ErrNoFutures)
+		return race
+	}
+	for _, f := range futures {
+		go func(f *Future) {
+			if f.settlesBefore(race) {
+				race.complete(f.Get())
+			}
+		}(f)
+	}
+	return race
+}
+
 // Promise represents a Future that can set results
 type Promise struct {
 	*Future
@@ -108,6 +269,9 @@ func FutureExamples() {
 
 	// Example 8: Comprehensive example
 	comprehensiveFutureExample()
+
+	// Example 9: Combinators
+	futureCombinatorsExample()
 }
 
 // Example 1: Basic Future
@@ -214,71 +378,66 @@ func futureTimeoutExample() {
 	fmt.Println("\n--- Example 4: Timeout Handling ---")
 
 	future := NewFuture()
+	producerExited := make(chan int)
 
-	// Start long-running task
+	// Start long-running task; it works in steps and checks Done between
+	// them, so it notices when the consumer gives up
 	go func() {
 		fmt.Println("Long-running task: starting...")
-		time.Sleep(2 * time.Second) // Simulate long-running task
+		for step := 1; step <= 20; step++ {
+			select {
+			case <-future.Done():
+				producerExited <- step - 1
+				return
+			case <-time.After(100 * time.Millisecond): // Simulate one step of work
+			}
+		}
 		future.SetResult("Long-running task completed")
+		producerExited <- 20
 	}()
 
 	// Try to get result with timeout
-	fmt.Println("Attempting to get result (timeout 1 second)...")
-	result, err := future.GetWithTimeout(1 * time.Second)
+	fmt.Println("Attempting to get result (timeout 300ms)...")
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	result, err := future.GetWithContext(ctx)
 	if err != nil {
 		fmt.Printf("Timeout error: %v\n", err)
 
-		// Continue waiting
-		fmt.Println("Continuing to wait...")
-		result, err = future.Get()
-		if err != nil {
-			fmt.Printf("Final error: %v\n", err)
-		} else {
-			fmt.Printf("Final result: %v\n", result)
-		}
+		// Give up: without Cancel the producer would run all 20 steps for nobody
+		fmt.Printf("Cancelling the future: %v\n", future.Cancel())
+		fmt.Printf("Producer stopped after %d of 20 steps\n", <-producerExited)
+		_, err = future.Get()
+		fmt.Printf("Future now reports: %v\n", err)
 	} else {
 		fmt.Printf("Result obtained in time: %v\n", result)
 	}
+
+	// Cancel is a no-op once a result is in
+	done := NewFuture()
+	done.SetResult("already finished")
+	result, _ = done.Get()
+	fmt.Printf("Cancel after completion: %v, result still %q\n", done.Cancel(), result)
 }
 
 // Example 5: Compose Future
+// The same chain expressed as a declarative dataflow graph of IVars lives in
+// 04_generics/15_dataflow.go
 func composeFutureExample() {
 	fmt.Println("\n--- Example 5: Compose Future ---")
 
-	// First Future: Get user ID
-	userIDFuture := NewFuture()
-	go func() {
-		time.Sleep(100 * time.Millisecond)
-		userIDFuture.SetResult(12345)
-	}()
-
-	// Second Future: Get user info based on user ID
-	userInfoFuture := NewFuture()
-	go func() {
-		userID, err := userIDFuture.Get()
-		if err != nil {
-			userInfoFuture.SetError(err)
-			return
-		}
-
-		time.Sleep(200 * time.Millisecond)
-		userInfo := fmt.Sprintf("User info: ID=%v, Name=John, Age=30", userID)
-		userInfoFuture.SetResult(userInfo)
-	}()
-
-	// Third Future: Get orders based on user info
-	ordersFuture := NewFuture()
-	go func() {
-		userInfo, err := userInfoFuture.Get()
-		if err != nil {
-			ordersFuture.SetError(err)
-			return
-		}
-
-		time.Sleep(150 * time.Millisecond)
-		orders := fmt.Sprintf("Order info: %v order list", userInfo)
-		ordersFuture.SetResult(orders)
-	}()
+	// Each step starts when the previous one succeeds; an error anywhere
+	// skips the remaining steps and surfaces at the final Get
+	ordersFuture := Async(func() (interface{}, error) {
+		time.Sleep(100 * time.Millisecond) // Get user ID
+		return 12345, nil
+	}).Then(func(userID interface{}) (interface{}, error) {
+		time.Sleep(200 * time.Millisecond) // Get user info based on user ID
+		return fmt.Sprintf("User info: ID=%v, Name=John, Age=30", userID), nil
+	}).Then(func(userInfo interface{}) (interface{}, error) {
+		time.Sleep(150 * time.Millisecond) // Get orders based on user info
+		return fmt.Sprintf("Order info: %v order list", userInfo), nil
+	})
 
 	// Get final result
 	result, err := ordersFuture.Get()
@@ -287,6 +446,15 @@ func composeFutureExample() {
 	} else {
 		fmt.Printf("Compose Future result: %v\n", result)
 	}
+
+	// The same chain with a failing first step never runs the later ones
+	_, err = Async(func() (interface{}, error) {
+		return nil, errors.New("user service unavailable")
+	}).Then(func(userID interface{}) (interface{}, error) {
+		fmt.Println("not reached")
+		return nil, nil
+	}).Get()
+	fmt.Printf("Compose Future with failing first step: %v\n", err)
 }
 
 // Example 6: Parallel execution
@@ -316,38 +484,24 @@ func parallelFutureExample() {
 	// Execute all tasks in parallel
 	futures := make([]*Future, len(tasks))
 	for i, task := range tasks {
-		future := NewFuture()
-		futures[i] = future
-
-		go func(f *Future, t func() (interface{}, error)) {
-			result, err := t()
-			if err != nil {
-				f.SetError(err)
-			} else {
-				f.SetResult(result)
-			}
-		}(future, task)
+		futures[i] = Async(task)
 	}
 
-	// Wait for all tasks to complete
+	// Wait for all tasks to complete; results keep the task order. If one
+	// failed, All would report it but leave the others running; see
+	// structuredFuturesExample (26_structured.go) for the structured version.
 	fmt.Println("Waiting for all parallel tasks to complete...")
-	var wg sync.WaitGroup
-	wg.Add(len(futures))
-
-	for i, future := range futures {
-		go func(id int, f *Future) {
-			defer wg.Done()
-			result, err := f.Get()
-			if err != nil {
-				fmt.Printf("Task %d error: %v\n", id+1, err)
-			} else {
-				fmt.Printf("Task %d result: %v\n", id+1, result)
-			}
-		}(i, future)
+	start := time.Now()
+	results, err := All(futures...).Get()
+	if err != nil {
+		fmt.Printf("Parallel tasks error: %v\n", err)
+		return
 	}
-
-	wg.Wait()
-	fmt.Println("All parallel tasks completed")
+	for i, result := range results.([]interface{}) {
+		fmt.Printf("Task %d result: %v\n", i+1, result)
+	}
+	fmt.Printf("All parallel tasks completed in %v (the slowest task, not the sum)\n",
+		time.Since(start).Round(100*time.Millisecond))
 }
 
 // Example 7: Error handling
@@ -516,3 +670,55 @@ func comprehensiveFutureExample() {
 		fmt.Printf("Slow task result: %v\n", result)
 	}
 }
+
+// Example 9: Combinators
+func futureCombinatorsExample() {
+	fmt.Println("\n--- Example 9: Combinators ---")
+
+	// fetch simulates a call that takes d and then succeeds or fails
+	fetch := func(name string, d time.Duration, fail bool) *Future {
+		return Async(func() (interface{}, error) {
+			time.Sleep(d)
+			if fail {
+				return nil, fmt.Errorf("%s failed", name)
+			}
+			return name, nil
+		})
+	}
+
+	// All fails fast: the error arrives after 20ms, not after the 200ms call
+	start := time.Now()
+	_, err := All(fetch("profile", 200*time.Millisecond, false), fetch("billing", 20*time.Millisecond, true)).Get()
+	fmt.Printf("All: %v after %v\n", err, time.Since(start).Round(10*time.Millisecond))
+
+	// Any takes the first success and ignores failures while one may succeed
+	result, err := Any(
+		fetch("mirror-a", 10*time.Millisecond, true),
+		fetch("mirror-b", 60*time.Millisecond, false),
+		fetch("mirror-c", 120*time.Millisecond, false),
+	).Get()
+	fmt.Printf("Any: %v (err=%v)\n", result, err)
+
+	_, err = Any(fetch("mirror-a", 10*time.Millisecond, true), fetch("mirror-b", 20*time.Millisecond, true)).Get()
+	fmt.Printf("Any, all failing: %v\n", strings.ReplaceAll(err.Error(), "\n", "; "))
+
+	// Race takes whatever settles first, including an error; racing against
+	// a timer future gives a timeout
+	timeout := func(d time.Duration) *Future {
+		return Async(func() (interface{}, error) {
+			time.Sleep(d)
+			return nil, fmt.Errorf("timeout after %v", d)
+		})
+	}
+	result, err = Race(fetch("fast", 10*time.Millisecond, false), timeout(50*time.Millisecond)).Get()
+	fmt.Printf("Race fast vs 50ms timeout: %v (err=%v)\n", result, err)
+	_, err = Race(fetch("slow", 100*time.Millisecond, false), timeout(50*time.Millisecond)).Get()
+	fmt.Printf("Race slow vs 50ms timeout: %v\n", err)
+
+	// Then composes with the others: fan out, join, then post-process
+	summary, err := All(fetch("a", 10*time.Millisecond, false), fetch("b", 20*time.Millisecond, false)).
+		Then(func(results interface{}) (interface{}, error) {
+			return fmt.Sprintf("%d results: %v", len(results.([]interface{})), results), nil
+		}).Get()
+	fmt.Printf("All then Then: %v (err=%v)\n", summary, err)
+}
@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/chans"
+	"github.com/Rookie0x80/AIStudy-go/internal/pubsub"
 )
 
 // Reactive programming examples
 
-// Observable observable object
+// Observable observable object. By default it is hot: subscribers see only
+// what is emitted after they subscribe. NewReplaySubject and the From*
+// constructors below build the other flavours on the same type, so the
+// operators work on all of them.
 type Observable struct {
 	observers []chan interface{}
 	mu        sync.RWMutex
 	closed    bool
+
+	// replay, when set, keeps recent emissions for late subscribers
+	replay *replayBuffer
+	// subscribe, when set, serves Subscribe instead of the shared stream:
+	// cold sources and operators hand each subscriber its own channel, and
+	// Emit and Close do not apply to them. It also returns the function that
+	// cancels that subscription.
+	subscribe func() (chan interface{}, func())
 }
 
 // NewObservable creates a new Observable
@@ -24,18 +39,61 @@ func NewObservable() *Observable {
 
 // Subscribe subscribes to Observable
 func (o *Observable) Subscribe() chan interface{} {
+	ch, _ := o.subscribeWithCancel()
+	return ch
+}
+
+// subscribeWithCancel subscribes and also returns a function that ends the
+// subscription: a hot Observable stops sending to the channel and closes it,
+// a derived one stops its goroutines and cancels its own subscriptions.
+// Calling it more than once, or after the channel closed, does nothing.
+func (o *Observable) subscribeWithCancel() (chan interface{}, func()) {
+	if o.subscribe != nil {
+		return o.subscribe()
+	}
+	ch := o.subscribeShared()
+	return ch, func() { o.unsubscribe(ch) }
+}
+
+// subscribeShared adds a channel to the shared stream of a hot Observable
+func (o *Observable) subscribeShared() chan interface{} {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	if o.closed {
+	var replayed []interface{}
+	if o.replay != nil {
+		replayed = o.replay.snapshot()
+	} else if o.closed {
 		return nil
 	}
 
-	ch := make(chan interface{}, 10)
+	// Replayed values are queued before the channel is shared, so they
+	// come before anything emitted later and never count against its room
+	ch := make(chan interface{}, len(replayed)+10)
+	for _, data := range replayed {
+		ch <- data
+	}
+	if o.closed {
+		close(ch)
+		return ch
+	}
 	o.observers = append(o.observers, ch)
 	return ch
 }
 
+// unsubscribe removes ch from the shared stream and closes it
+func (o *Observable) unsubscribe(ch chan interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i, observer := range o.observers {
+		if observer == ch {
+			o.observers = append(o.observers[:i], o.observers[i+1:]...)
+			close(ch)
+			return
+		}
+	}
+}
+
 // Emit sends data to all observers
 func (o *Observable) Emit(data interface{}) {
 	o.mu.RLock()
@@ -45,6 +103,9 @@ func (o *Observable) Emit(data interface{}) {
 		return
 	}
 
+	if o.replay != nil {
+		o.replay.add(data)
+	}
 	for _, observer := range o.observers {
 		select {
 		case observer <- data:
@@ -66,6 +127,367 @@ func (o *Observable) Close() {
 	o.observers = nil
 }
 
+// replayBuffer holds the last size emissions of a replaying Observable. Emit
+// adds under the Observable's read lock, so the buffer has its own mutex.
+type replayBuffer struct {
+	mu     sync.Mutex
+	size   int
+	values []interface{}
+}
+
+func (r *replayBuffer) add(data interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, data)
+	if len(r.values) > r.size {
+		r.values = append(r.values[:0], r.values[1:]...)
+	}
+}
+
+func (r *replayBuffer) snapshot() []interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]interface{}(nil), r.values...)
+}
+
+// ReplaySubject is a Subject that replays its last n values, including
+// the ERROR and COMPLETE markers, to every new subscriber, so subscribing
+// late or after completion no longer loses data
+type ReplaySubject struct {
+	*Subject
+}
+
+// NewReplaySubject creates a ReplaySubject that buffers the last n values
+func NewReplaySubject(n int) *ReplaySubject {
+	s := NewSubject()
+	s.Observable.replay = &replayBuffer{size: max(n, 1)}
+	return &ReplaySubject{Subject: s}
+}
+
+// FromSlice creates a cold Observable: every subscriber receives all of
+// values, in order, from the beginning, and then completion
+func FromSlice(values ...interface{}) *Observable {
+	return newCold(func(ctx context.Context, emit func(interface{}) bool) {
+		for _, data := range values {
+			if !emit(data) {
+				return
+			}
+		}
+	})
+}
+
+// FromChannel creates a cold Observable over a channel, which by itself can
+// only be read once. The channel is drained on the first Subscribe and what
+// it yields is recorded, so every subscriber, whenever it arrives, gets the
+// whole sequence without loss and completes when the channel is closed.
+func FromChannel(ch <-chan interface{}) *Observable {
+	rec := &recording{}
+	rec.cond = sync.NewCond(&rec.mu)
+	var start sync.Once
+
+	return newCold(func(ctx context.Context, emit func(interface{}) bool) {
+		start.Do(func() { go rec.record(ch) })
+		// A subscriber that cancels while waiting for the next value is woken
+		stop := context.AfterFunc(ctx, func() {
+			rec.mu.Lock()
+			defer rec.mu.Unlock()
+			rec.cond.Broadcast()
+		})
+		defer stop()
+		for i := 0; ; i++ {
+			data, ok := rec.at(ctx, i)
+			if !ok || !emit(data) {
+				return
+			}
+		}
+	})
+}
+
+// recording is the shared, growing sequence read from FromChannel's channel
+type recording struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	values []interface{}
+	done   bool
+}
+
+func (r *recording) record(ch <-chan interface{}) {
+	for data := range ch {
+		r.mu.Lock()
+		r.values = append(r.values, data)
+		r.mu.Unlock()
+		r.cond.Broadcast()
+	}
+	r.mu.Lock()
+	r.done = true
+	r.mu.Unlock()
+	r.cond.Broadcast()
+}
+
+// at waits for the i-th value; ok is false once the sequence ended before it
+// or ctx was cancelled
+func (r *recording) at(ctx context.Context, i int) (data interface{}, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i >= len(r.values) && !r.done {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		r.cond.Wait()
+	}
+	if i >= len(r.values) {
+		return nil, false
+	}
+	return r.values[i], true
+}
+
+// Operators. Each one returns a derived Observable that does nothing until
+// it is subscribed; every Subscribe then subscribes to the source at that
+// moment and runs the operator on its own goroutine for that subscriber.
+// A chain over a cold source therefore stays cold, and a chain over a hot
+// one sees what the source emits from then on.
+
+// derive creates an Observable whose Subscribe is served by subscribe. Each
+// subscription gets a context that is cancelled when it is; subscribe returns
+// the subscriber's channel and a function releasing what it subscribed to.
+func derive(subscribe func(ctx context.Context) (chan interface{}, func())) *Observable {
+	return &Observable{subscribe: func() (chan interface{}, func()) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch, release := subscribe(ctx)
+		var once sync.Once
+		return ch, func() {
+			once.Do(func() {
+				cancel()
+				release()
+			})
+		}
+	}}
+}
+
+// emitTo returns an emit function for out that gives up, returning false,
+// once ctx is cancelled
+func emitTo(ctx context.Context, out chan<- interface{}) func(interface{}) bool {
+	return func(data interface{}) bool {
+		select {
+		case out <- data:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// newCold creates a cold Observable: each subscriber gets its own run of
+// produce, and its channel is closed when produce returns. produce should
+// return once emit reports the subscription was cancelled.
+func newCold(produce func(ctx context.Context, emit func(interface{}) bool)) *Observable {
+	return derive(func(ctx context.Context) (chan interface{}, func()) {
+		out := make(chan interface{}, 10)
+		go func() {
+			defer close(out)
+			produce(ctx, emitTo(ctx, out))
+		}()
+		return out, func() {}
+	})
+}
+
+// pipe applies body to one subscription of src per subscriber. Once body
+// returns, or the subscriber cancels, the subscription to src is cancelled,
+// so a hot source stops feeding it and a cold one stops producing for it.
+func pipe(src *Observable, body func(in <-chan interface{}, emit func(interface{}) bool)) *Observable {
+	return derive(func(ctx context.Context) (chan interface{}, func()) {
+		in, cancelIn := src.subscribeWithCancel()
+		out := make(chan interface{}, 10)
+		go func() {
+			defer close(out)
+			if in == nil {
+				return // Source was already closed
+			}
+			defer cancelIn()
+			body(in, emitTo(ctx, out))
+		}()
+		return out, cancelIn
+	})
+}
+
+// Map emits f(value) for every value of o
+func (o *Observable) Map(f func(interface{}) interface{}) *Observable {
+	return pipe(o, func(in <-chan interface{}, emit func(interface{}) bool) {
+		for data := range in {
+			if !emit(f(data)) {
+				return
+			}
+		}
+	})
+}
+
+// Filter emits the values of o for which keep returns true
+func (o *Observable) Filter(keep func(interface{}) bool) *Observable {
+	return pipe(o, func(in <-chan interface{}, emit func(interface{}) bool) {
+		for data := range in {
+			if keep(data) && !emit(data) {
+				return
+			}
+		}
+	})
+}
+
+// Take emits the first n values of o and then completes
+func (o *Observable) Take(n int) *Observable {
+	return pipe(o, func(in <-chan interface{}, emit func(interface{}) bool) {
+		for taken := 0; taken < n; taken++ {
+			data, ok := <-in
+			if !ok || !emit(data) {
+				return
+			}
+		}
+	})
+}
+
+// Debounce emits a value only once o has been quiet for d after it, so a
+// burst collapses to its last value. A pending value is flushed when o
+// completes.
+func (o *Observable) Debounce(d time.Duration) *Observable {
+	return pipe(o, func(in <-chan interface{}, emit func(interface{}) bool) {
+		timer := time.NewTimer(d)
+		timer.Stop()
+		defer timer.Stop()
+
+		var pending interface{}
+		hasPending := false
+		for {
+			select {
+			case data, ok := <-in:
+				if !ok {
+					if hasPending {
+						emit(pending)
+					}
+					return
+				}
+				pending, hasPending = data, true
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(d)
+			case <-timer.C:
+				if !emit(pending) {
+					return
+				}
+				pending, hasPending = nil, false
+			}
+		}
+	})
+}
+
+// subscribeAll subscribes to every source; closed hot sources give nil. The
+// returned function cancels all of the subscriptions.
+func subscribeAll(sources []*Observable) ([]chan interface{}, func()) {
+	chans := make([]chan interface{}, len(sources))
+	cancels := make([]func(), len(sources))
+	for i, src := range sources {
+		chans[i], cancels[i] = src.subscribeWithCancel()
+	}
+	return chans, func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+// Merge emits the values of all sources as they arrive and completes when
+// every source has
+func Merge(sources ...*Observable) *Observable {
+	return derive(func(ctx context.Context) (chan interface{}, func()) {
+		chans, cancelAll := subscribeAll(sources)
+		out := make(chan interface{}, 10)
+		emit := emitTo(ctx, out)
+		var wg sync.WaitGroup
+		for _, ch := range chans {
+			if ch == nil {
+				continue
+			}
+			wg.Add(1)
+			go func(ch <-chan interface{}) {
+				defer wg.Done()
+				for data := range ch {
+					if !emit(data) {
+						return
+					}
+				}
+			}(ch)
+		}
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		return out, cancelAll
+	})
+}
+
+// Concat emits all values of the first source, then of the second, and so
+// on. Every source is subscribed up front; values a later hot source emits
+// while an earlier one is still running wait in its subscription buffer,
+// and like any full subscriber, overflow is dropped by Emit.
+func Concat(sources ...*Observable) *Observable {
+	return derive(func(ctx context.Context) (chan interface{}, func()) {
+		chans, cancelAll := subscribeAll(sources)
+		out := make(chan interface{}, 10)
+		emit := emitTo(ctx, out)
+		go func() {
+			defer close(out)
+			defer cancelAll()
+			for _, ch := range chans {
+				if ch == nil {
+					continue
+				}
+				for data := range ch {
+					if !emit(data) {
+						return
+					}
+				}
+			}
+		}()
+		return out, cancelAll
+	})
+}
+
+// Zip pairs up the sources' values by position, emitting a []interface{}
+// with one value from each, and completes as soon as any source does
+func Zip(sources ...*Observable) *Observable {
+	return derive(func(ctx context.Context) (chan interface{}, func()) {
+		chans, cancelAll := subscribeAll(sources)
+		out := make(chan interface{}, 10)
+		emit := emitTo(ctx, out)
+		go func() {
+			defer close(out)
+			defer cancelAll() // The other sources stop feeding this subscriber
+			if len(chans) == 0 {
+				return
+			}
+			for {
+				tuple := make([]interface{}, len(chans))
+				for i, ch := range chans {
+					if ch == nil {
+						return // Source was already closed
+					}
+					data, ok := <-ch
+					if !ok {
+						return
+					}
+					tuple[i] = data
+				}
+				if !emit(tuple) {
+					return
+				}
+			}
+		}()
+		return out, cancelAll
+	})
+}
+
 // Subject topic, both Observable and Observer
 type Subject struct {
 	*Observable
@@ -124,6 +546,11 @@ func ReactiveExamples() {
 
 	// Example 8: Comprehensive example
 	comprehensiveReactiveExample()
+
+	// Example 9: Operators
+	reactiveOperatorsExample()
+	// Example 10: Cold vs hot observables
+	coldHotObservableExample()
 }
 
 // Example 1: Basic Observable
@@ -200,33 +627,17 @@ func dataStreamExample() {
 	// Create data source
 	dataSource := NewObservable()
 
-	// Data processor 1: Filter even numbers
-	evenFilter := NewObservable()
-	go func() {
-		ch := dataSource.Subscribe()
-		for data := range ch {
-			if num, ok := data.(int); ok && num%2 == 0 {
-				evenFilter.Emit(num)
-			}
-		}
-		evenFilter.Close()
-	}()
-
-	// Data processor 2: Square operation
-	squareProcessor := NewObservable()
-	go func() {
-		ch := evenFilter.Subscribe()
-		for data := range ch {
-			if num, ok := data.(int); ok {
-				squareProcessor.Emit(num * num)
-			}
-		}
-		squareProcessor.Close()
-	}()
+	// Filter even numbers, then square them. Each operator stands in for an
+	// intermediate Observable plus the goroutine that used to feed it.
+	results := dataSource.
+		Filter(func(v interface{}) bool { num, ok := v.(int); return ok && num%2 == 0 }).
+		Map(func(v interface{}) interface{} { return v.(int) * v.(int) })
 
 	// Result collector
+	done := make(chan struct{})
+	ch := results.Subscribe()
 	go func() {
-		ch := squareProcessor.Subscribe()
+		defer close(done)
 		for data := range ch {
 			fmt.Printf("Result: %v\n", data)
 		}
@@ -240,74 +651,67 @@ func dataStreamExample() {
 		time.Sleep(50 * time.Millisecond)
 	}
 
-	// Close data source
+	// Close data source; completion flows down the chain
 	dataSource.Close()
-	time.Sleep(200 * time.Millisecond)
+	<-done
 }
 
 // Example 4: Event handling
 func eventHandlingExample() {
 	fmt.Println("\n--- Example 4: Event Handling ---")
 
-	// Create event bus
-	eventBus := NewSubject()
+	// Create event bus; handlers subscribe to topic patterns instead of
+	// receiving every event and filtering strings themselves
+	eventBus := NewEventBus()
 
 	// Event handler 1: User events
-	userEventHandler := eventBus.Subscribe()
-	go func() {
-		for event := range userEventHandler {
-			if eventStr, ok := event.(string); ok {
-				if eventStr == "user_login" {
-					fmt.Println("User event handler: User login")
-				} else if eventStr == "user_logout" {
-					fmt.Println("User event handler: User logout")
-				}
-			}
+	eventBus.Subscribe("user.*", func(e Event) error {
+		switch e.Topic {
+		case "user.login":
+			fmt.Println("User event handler: User login")
+		case "user.logout":
+			fmt.Println("User event handler: User logout")
 		}
-	}()
+		return nil
+	})
 
 	// Event handler 2: System events
-	systemEventHandler := eventBus.Subscribe()
-	go func() {
-		for event := range systemEventHandler {
-			if eventStr, ok := event.(string); ok {
-				if eventStr == "system_start" {
-					fmt.Println("System event handler: System startup")
-				} else if eventStr == "system_shutdown" {
-					fmt.Println("System event handler: System shutdown")
-				}
-			}
+	eventBus.Subscribe("system.*", func(e Event) error {
+		switch e.Topic {
+		case "system.start":
+			fmt.Println("System event handler: System startup")
+		case "system.shutdown":
+			fmt.Println("System event handler: System shutdown")
 		}
-	}()
+		return nil
+	})
 
-	// Event handler 3: Log recording
-	logEventHandler := eventBus.Subscribe()
-	go func() {
-		for event := range logEventHandler {
-			fmt.Printf("Log recorder: Recording event %v\n", event)
-		}
-	}()
+	// Event handler 3: Log recording, asynchronous so it never slows down
+	// the publisher
+	eventBus.SubscribeWithOptions("**", func(e Event) error {
+		fmt.Printf("Log recorder: Recording event %s\n", e.Topic)
+		return nil
+	}, SubscribeOptions{Async: true, QueueSize: 10})
 
 	// Send events
 	fmt.Println("Sending events...")
-	eventBus.Next("user_login")
-	time.Sleep(100 * time.Millisecond)
-	eventBus.Next("system_start")
-	time.Sleep(100 * time.Millisecond)
-	eventBus.Next("user_logout")
-	time.Sleep(100 * time.Millisecond)
-	eventBus.Next("system_shutdown")
+	for _, topic := range []string{"user.login", "system.start", "user.logout", "system.shutdown"} {
+		eventBus.Publish(topic, nil)
+		time.Sleep(100 * time.Millisecond)
+	}
 
 	// Complete
-	eventBus.Complete()
+	eventBus.Close()
 }
 
 // Example 5: Asynchronous data stream
 func asyncDataStreamExample() {
 	fmt.Println("\n--- Example 5: Asynchronous Data Stream ---")
 
-	// Create asynchronous data source
-	asyncSource := NewObservable()
+	// Create asynchronous data source. The generator starts before the
+	// processors below subscribe, so a plain hot Observable could lose the
+	// first values; a ReplaySubject hands them to the late subscribers.
+	asyncSource := NewReplaySubject(5)
 
 	// Asynchronous data generator
 	go func() {
@@ -329,7 +733,7 @@ func asyncDataStreamExample() {
 				time.Sleep(100 * time.Millisecond)
 				processed := num * 2
 				fmt.Printf("Async processor1: %d -> %d\n", num, processed)
-				processor1.Emit(processed)
+				processor1.Emit(fmt.Sprintf("P1: %v", processed))
 			}
 		}
 		processor1.Close()
@@ -345,61 +749,38 @@ func asyncDataStreamExample() {
 				time.Sleep(150 * time.Millisecond)
 				processed := num * num
 				fmt.Printf("Async processor2: %d -> %d\n", num, processed)
-				processor2.Emit(processed)
+				processor2.Emit(fmt.Sprintf("P2: %v", processed))
 			}
 		}
 		processor2.Close()
 	}()
 
-	// Result merger
-	mergedResults := NewObservable()
-	go func() {
-		var wg sync.WaitGroup
-		wg.Add(2)
-
-		// Merge processor1 results
-		go func() {
-			defer wg.Done()
-			ch := processor1.Subscribe()
-			for data := range ch {
-				mergedResults.Emit(fmt.Sprintf("P1: %v", data))
-			}
-		}()
-
-		// Merge processor2 results
-		go func() {
-			defer wg.Done()
-			ch := processor2.Subscribe()
-			for data := range ch {
-				mergedResults.Emit(fmt.Sprintf("P2: %v", data))
-			}
-		}()
-
-		wg.Wait()
-		mergedResults.Close()
-	}()
-
-	// Result collector
-	go func() {
-		ch := mergedResults.Subscribe()
-		for data := range ch {
-			fmt.Printf("Result collector: %v\n", data)
-		}
-		fmt.Println("Asynchronous data stream processing completed")
-	}()
-
-	time.Sleep(2 * time.Second)
+	// Result merger and collector: chans.Merge forwards both processors'
+	// results as they arrive and closes once both have completed, so the
+	// example ends when the stream does
+	merged := chans.Merge[interface{}](context.Background(), processor1.Subscribe(), processor2.Subscribe())
+	for data := range merged {
+		fmt.Printf("Result collector: %v\n", data)
+	}
+	fmt.Println("Asynchronous data stream processing completed")
 }
 
 // Example 6: Error handling
 func reactiveErrorHandlingExample() {
 	fmt.Println("\n--- Example 6: Error Handling ---")
 
-	// Create Observable that may error
-	errorProneSource := NewObservable()
+	// Create Observable that may error. It is cold, so whenever the error
+	// handler gets round to subscribing it still sees every value.
+	errorProneSource := FromSlice("Normal data1", "ERROR: Simulated error1", "Normal data2", "ERROR: Simulated error2")
 
-	// Error handler
+	// The handlers below are hot: subscribe to them before anything can
+	// emit, or the first values would be lost
 	errorHandler := NewObservable()
+	recoveryHandler := NewObservable()
+	errorCh := errorHandler.Subscribe()
+	recoveryCh := recoveryHandler.Subscribe()
+
+	// Error handler
 	go func() {
 		ch := errorProneSource.Subscribe()
 		for data := range ch {
@@ -409,44 +790,31 @@ func reactiveErrorHandlingExample() {
 			} else {
 				fmt.Printf("Error handler: Normal data %v\n", data)
 			}
+			time.Sleep(100 * time.Millisecond)
 		}
 		errorHandler.Close()
 	}()
 
 	// Recovery handler
-	recoveryHandler := NewObservable()
 	go func() {
-		ch := errorHandler.Subscribe()
-		for data := range ch {
+		for data := range errorCh {
 			fmt.Printf("Recovery handler: %v\n", data)
 			recoveryHandler.Emit("Recovery successful")
 		}
 		recoveryHandler.Close()
 	}()
 
-	// Send normal data and error data
-	go func() {
-		defer errorProneSource.Close()
-
-		errorProneSource.Emit("Normal data1")
-		time.Sleep(100 * time.Millisecond)
-		errorProneSource.Emit("ERROR: Simulated error1")
-		time.Sleep(100 * time.Millisecond)
-		errorProneSource.Emit("Normal data2")
-		time.Sleep(100 * time.Millisecond)
-		errorProneSource.Emit("ERROR: Simulated error2")
-	}()
-
 	// Final result collector
+	done := make(chan struct{})
 	go func() {
-		ch := recoveryHandler.Subscribe()
-		for data := range ch {
+		defer close(done)
+		for data := range recoveryCh {
 			fmt.Printf("Final result: %v\n", data)
 		}
 		fmt.Println("Error handling completed")
 	}()
 
-	time.Sleep(1 * time.Second)
+	<-done
 }
 
 // Example 7: Compose operations
@@ -539,22 +907,26 @@ func comprehensiveReactiveExample() {
 	// Simulate a reactive user interface system
 	type UIEvent struct {
 		Type string
-		Data interface{}
+		Data string
 	}
 
-	// Create UI event bus
-	uiEventBus := NewSubject()
+	// Create UI event bus. A typed topic (internal/pubsub) instead of a
+	// Subject: subscribers receive UIEvent values, with no type assertion
+	broker := pubsub.NewBroker()
+	uiEvents, err := pubsub.OpenTopic[UIEvent](broker, "ui", pubsub.TopicOptions{Buffer: 10})
+	if err != nil {
+		fmt.Printf("Failed to open UI event topic: %v\n", err)
+		return
+	}
 
 	// User input processor
 	inputProcessor := NewObservable()
+	uiSub := uiEvents.Subscribe() // Before the first Publish, so no event is missed
 	go func() {
-		ch := uiEventBus.Subscribe()
-		for event := range ch {
-			if uiEvent, ok := event.(UIEvent); ok {
-				if uiEvent.Type == "input" {
-					fmt.Printf("Input processor: Processing input %v\n", uiEvent.Data)
-					inputProcessor.Emit(uiEvent.Data)
-				}
+		for event := range uiSub.C() {
+			if event.Type == "input" {
+				fmt.Printf("Input processor: Processing input %v\n", event.Data)
+				inputProcessor.Emit(event.Data)
 			}
 		}
 		inputProcessor.Close()
@@ -615,11 +987,132 @@ func comprehensiveReactiveExample() {
 	fmt.Println("Simulating user input...")
 	inputs := []string{"Hello", "", "World", "Reactive", "Programming"}
 	for _, input := range inputs {
-		uiEventBus.Next(UIEvent{Type: "input", Data: input})
+		uiEvents.Publish(context.Background(), UIEvent{Type: "input", Data: input})
 		time.Sleep(200 * time.Millisecond)
 	}
 
-	// Complete
-	uiEventBus.Complete()
+	// Complete: closing the broker ends the subscriber's range loop
+	broker.Close()
 	time.Sleep(500 * time.Millisecond)
 }
+
+// collect gathers every value of o and delivers them once o completes
+func collect(o *Observable) <-chan []interface{} {
+	result := make(chan []interface{}, 1)
+	ch := o.Subscribe()
+	go func() {
+		var values []interface{}
+		for data := range ch {
+			values = append(values, data)
+		}
+		result <- values
+	}()
+	return result
+}
+
+// emitAll emits values on o with a pause after each, then closes o
+func emitAll(o *Observable, pause time.Duration, values ...interface{}) {
+	for _, v := range values {
+		o.Emit(v)
+		time.Sleep(pause)
+	}
+	o.Close()
+}
+
+// Example 9: Operators
+func reactiveOperatorsExample() {
+	fmt.Println("\n--- Example 9: Operators ---")
+
+	// Take: only the first three values get through
+	numbers := NewObservable()
+	firstThree := collect(numbers.Take(3))
+	emitAll(numbers, 0, 1, 2, 3, 4, 5, 6)
+	fmt.Printf("Take(3): %v\n", <-firstThree)
+
+	// Debounce: a burst of keystrokes collapses to what was typed last
+	keystrokes := NewObservable()
+	searches := collect(keystrokes.Debounce(80 * time.Millisecond))
+	for _, text := range []string{"g", "go", "gor", "goro"} {
+		keystrokes.Emit(text)
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(150 * time.Millisecond) // User pauses: "goro" is searched
+	emitAll(keystrokes, 10*time.Millisecond, "c", "ch", "chan")
+	fmt.Printf("Debounce(80ms): %v\n", <-searches)
+
+	// Merge: values from both sources, in arrival order
+	left, right := NewObservable(), NewObservable()
+	merged := collect(Merge(left, right))
+	go emitAll(left, 20*time.Millisecond, "L1", "L2", "L3")
+	emitAll(right, 20*time.Millisecond, "R1", "R2", "R3")
+	fmt.Printf("Merge: %v\n", <-merged)
+
+	// Concat: all of the first source before any of the second, even though
+	// the second emits at the same time
+	first, second := NewObservable(), NewObservable()
+	concatenated := collect(Concat(first, second))
+	go emitAll(second, 10*time.Millisecond, "B1", "B2")
+	emitAll(first, 20*time.Millisecond, "A1", "A2", "A3")
+	fmt.Printf("Concat: %v\n", <-concatenated)
+
+	// Zip: pair up by position; the shorter source ends the stream
+	names, scores := NewObservable(), NewObservable()
+	pairs := collect(Zip(names, scores))
+	go emitAll(names, 0, "alice", "bob", "carol")
+	emitAll(scores, 0, 90, 85)
+	fmt.Printf("Zip: %v\n", <-pairs)
+
+	// Chained: squares of the odd numbers, at most two of them
+	source := NewObservable()
+	chained := collect(source.
+		Filter(func(v interface{}) bool { return v.(int)%2 == 1 }).
+		Map(func(v interface{}) interface{} { return v.(int) * v.(int) }).
+		Take(2))
+	emitAll(source, 0, 1, 2, 3, 4, 5)
+	fmt.Printf("Filter.Map.Take: %v\n", <-chained)
+}
+
+// Example 10: Cold vs hot observables
+func coldHotObservableExample() {
+	fmt.Println("\n--- Example 10: Cold vs Hot Observables ---")
+
+	// Hot: a late subscriber misses whatever was emitted before it came
+	hot := NewObservable()
+	early := collect(hot)
+	hot.Emit("h1")
+	hot.Emit("h2")
+	late := collect(hot)
+	hot.Emit("h3")
+	hot.Close()
+	fmt.Printf("Hot: early=%v late=%v\n", <-early, <-late)
+
+	// ReplaySubject: a late subscriber first gets the last n values
+	replay := NewReplaySubject(2)
+	replay.Next("r1")
+	replay.Next("r2")
+	replay.Next("r3")
+	lateReplay := collect(replay.Observable)
+	replay.Next("r4")
+	replay.Complete()
+	afterComplete := collect(replay.Observable) // Even after completion
+	fmt.Printf("Replay(2): late=%v afterComplete=%v\n", <-lateReplay, <-afterComplete)
+
+	// FromSlice: every subscriber gets the whole sequence from the start,
+	// and so does every operator chain built on it
+	numbers := FromSlice(1, 2, 3, 4)
+	fmt.Printf("FromSlice: first=%v second=%v\n", <-collect(numbers), <-collect(numbers))
+	doubled := numbers.Map(func(v interface{}) interface{} { return v.(int) * 2 })
+	fmt.Printf("FromSlice.Map: %v %v\n", <-collect(doubled), <-collect(doubled))
+
+	// FromChannel: the channel is read once, yet a subscriber that arrives
+	// after it has been drained still gets everything
+	ch := make(chan interface{})
+	fromChannel := FromChannel(ch)
+	firstRun := collect(fromChannel)
+	for _, word := range []string{"alpha", "beta", "gamma"} {
+		ch <- word
+	}
+	close(ch)
+	fmt.Printf("FromChannel: first=%v\n", <-firstRun)
+	fmt.Printf("FromChannel: after close=%v\n", <-collect(fromChannel))
+}
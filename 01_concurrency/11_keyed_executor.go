@@ -0,0 +1,294 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Keyed executor examples
+
+// ErrKeyQueueFull is returned when a key already has too many pending tasks
+var ErrKeyQueueFull = errors.New("keyed executor: queue for key is full")
+
+// ErrExecutorClosed is returned when submitting to a closed executor
+var ErrExecutorClosed = errors.New("keyed executor: closed")
+
+// keyWorker owns the task queue of a single key
+type keyWorker struct {
+	tasks chan func()
+}
+
+// KeyedExecutor runs tasks for the same key serially and in submission order,
+// while tasks for different keys run in parallel. Each active key gets its own
+// goroutine (an "actor per key") that exits after the key has been idle.
+type KeyedExecutor[K comparable] struct {
+	mu          sync.Mutex
+	workers     map[K]*keyWorker
+	maxQueue    int
+	idleTimeout time.Duration
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+// NewKeyedExecutor creates a keyed executor with a per-key queue limit and idle timeout
+func NewKeyedExecutor[K comparable](maxQueue int, idleTimeout time.Duration) *KeyedExecutor[K] {
+	if maxQueue < 1 {
+		maxQueue = 1
+	}
+	return &KeyedExecutor[K]{
+		workers:     make(map[K]*keyWorker),
+		maxQueue:    maxQueue,
+		idleTimeout: idleTimeout,
+	}
+}
+
+// Submit queues a task for the given key without blocking
+func (e *KeyedExecutor[K]) Submit(key K, task func()) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return ErrExecutorClosed
+	}
+
+	w, exists := e.workers[key]
+	if !exists {
+		w = &keyWorker{tasks: make(chan func(), e.maxQueue)}
+		e.workers[key] = w
+		e.wg.Add(1)
+		go e.run(key, w)
+	}
+
+	// Enqueueing under the lock prevents racing with idle cleanup
+	select {
+	case w.tasks <- task:
+		return nil
+	default:
+		return ErrKeyQueueFull
+	}
+}
+
+// run processes one key's tasks until the key is idle or the executor closes
+func (e *KeyedExecutor[K]) run(key K, w *keyWorker) {
+	defer e.wg.Done()
+
+	idle := time.NewTimer(e.idleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case task, ok := <-w.tasks:
+			if !ok {
+				return // Closed: queue fully drained
+			}
+			task()
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(e.idleTimeout)
+		case <-idle.C:
+			e.mu.Lock()
+			if len(w.tasks) == 0 && !e.closed {
+				delete(e.workers, key)
+				e.mu.Unlock()
+				return
+			}
+			e.mu.Unlock()
+			idle.Reset(e.idleTimeout)
+		}
+	}
+}
+
+// ActiveKeys returns the number of keys that currently own a worker goroutine
+func (e *KeyedExecutor[K]) ActiveKeys() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.workers)
+}
+
+// Pending returns the number of queued tasks for a key
+func (e *KeyedExecutor[K]) Pending(key K) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if w, exists := e.workers[key]; exists {
+		return len(w.tasks)
+	}
+	return 0
+}
+
+// Close stops accepting tasks, lets every queue drain and waits for all workers
+func (e *KeyedExecutor[K]) Close() {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+	e.closed = true
+	for _, w := range e.workers {
+		close(w.tasks)
+	}
+	e.mu.Unlock()
+
+	e.wg.Wait()
+}
+
+// KeyedExecutorExamples runs all keyed executor examples
+func KeyedExecutorExamples() {
+	// Example 1: Per-key ordering
+	keyedOrderingExample()
+
+	// Example 2: Parallelism across keys
+	keyedParallelismExample()
+
+	// Example 3: Queue length limits
+	keyedQueueLimitExample()
+
+	// Example 4: Idle key cleanup
+	keyedIdleCleanupExample()
+
+	// Example 5: Bank accounts without a global lock
+	keyedBankAccountExample()
+}
+
+// Example 1: Per-key ordering
+func keyedOrderingExample() {
+	fmt.Println("\n--- Example 1: Per-Key Ordering ---")
+
+	executor := NewKeyedExecutor[string](16, 100*time.Millisecond)
+
+	var mu sync.Mutex
+	order := make(map[string][]int)
+
+	for i := 1; i <= 5; i++ {
+		for _, key := range []string{"user-A", "user-B"} {
+			key, seq := key, i
+			executor.Submit(key, func() {
+				time.Sleep(time.Duration(5-seq) * time.Millisecond) // Later tasks are faster
+				mu.Lock()
+				order[key] = append(order[key], seq)
+				mu.Unlock()
+			})
+		}
+	}
+
+	executor.Close()
+
+	// Even though later tasks finish faster, each key observes submission order
+	fmt.Printf("user-A execution order: %v\n", order["user-A"])
+	fmt.Printf("user-B execution order: %v\n", order["user-B"])
+}
+
+// Example 2: Parallelism across keys
+func keyedParallelismExample() {
+	fmt.Println("\n--- Example 2: Parallelism Across Keys ---")
+
+	executor := NewKeyedExecutor[int](16, 100*time.Millisecond)
+
+	start := time.Now()
+	for key := 0; key < 4; key++ {
+		for i := 0; i < 3; i++ {
+			executor.Submit(key, func() {
+				time.Sleep(50 * time.Millisecond)
+			})
+		}
+	}
+	executor.Close()
+
+	// 4 keys * 3 tasks * 50ms would be 600ms if fully serial; per-key serial is ~150ms
+	elapsed := time.Since(start)
+	fmt.Printf("12 tasks over 4 keys took %v (fully serial would take 600ms)\n", elapsed.Round(10*time.Millisecond))
+}
+
+// Example 3: Queue length limits
+func keyedQueueLimitExample() {
+	fmt.Println("\n--- Example 3: Queue Length Limits ---")
+
+	executor := NewKeyedExecutor[string](2, 100*time.Millisecond)
+	release := make(chan struct{})
+
+	// The first task blocks the key so later tasks pile up in its queue
+	executor.Submit("hot-key", func() { <-release })
+	time.Sleep(10 * time.Millisecond)
+
+	for i := 1; i <= 4; i++ {
+		if err := executor.Submit("hot-key", func() {}); err != nil {
+			fmt.Printf("Task %d rejected: %v\n", i, err)
+		} else {
+			fmt.Printf("Task %d queued (pending=%d)\n", i, executor.Pending("hot-key"))
+		}
+	}
+
+	// Other keys are unaffected by the hot key's backlog
+	if err := executor.Submit("cold-key", func() {}); err == nil {
+		fmt.Println("cold-key task accepted while hot-key is saturated")
+	}
+
+	close(release)
+	executor.Close()
+
+	if err := executor.Submit("hot-key", func() {}); err != nil {
+		fmt.Printf("After Close: %v\n", err)
+	}
+}
+
+// Example 4: Idle key cleanup
+func keyedIdleCleanupExample() {
+	fmt.Println("\n--- Example 4: Idle Key Cleanup ---")
+
+	executor := NewKeyedExecutor[string](4, 50*time.Millisecond)
+
+	for _, key := range []string{"session-1", "session-2", "session-3"} {
+		executor.Submit(key, func() {})
+	}
+	time.Sleep(10 * time.Millisecond)
+	fmt.Printf("Active keys right after submission: %d\n", executor.ActiveKeys())
+
+	time.Sleep(150 * time.Millisecond)
+	fmt.Printf("Active keys after idle timeout: %d\n", executor.ActiveKeys())
+
+	// A key that was cleaned up is transparently recreated on the next submission
+	executor.Submit("session-1", func() {})
+	time.Sleep(10 * time.Millisecond)
+	fmt.Printf("Active keys after resubmitting session-1: %d\n", executor.ActiveKeys())
+
+	executor.Close()
+}
+
+// Example 5: Bank accounts without a global lock
+func keyedBankAccountExample() {
+	fmt.Println("\n--- Example 5: Bank Accounts Without a Global Lock ---")
+
+	// Each balance is only ever touched by its own key's worker, so no lock is needed
+	balances := map[string]*int{
+		"ACC001": new(int),
+		"ACC002": new(int),
+		"ACC003": new(int),
+	}
+
+	executor := NewKeyedExecutor[string](1000, 100*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for client := 0; client < 10; client++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				for id, balance := range balances {
+					balance := balance
+					if err := executor.Submit(id, func() { *balance += 10 }); err != nil {
+						fmt.Printf("Deposit to %s failed: %v\n", id, err)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	executor.Close()
+
+	for _, id := range []string{"ACC001", "ACC002", "ACC003"} {
+		fmt.Printf("%s balance: %d (Expected: 10000)\n", id, *balances[id])
+	}
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache loader examples: refresh-ahead, stale-while-revalidate and warm-up
+
+// LoadFunc fetches the value for a key from the slow backing store
+type LoadFunc[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// LoaderOptions configures freshness windows and warm-up concurrency
+type LoaderOptions struct {
+	TTL             time.Duration // How long a value is fresh
+	RefreshAhead    time.Duration // Refresh in the background this long before TTL expires
+	StaleTTL        time.Duration // After TTL, serve the stale value this long while revalidating
+	WarmConcurrency int           // Max concurrent loads during Warm
+}
+
+// LoaderStats counts how requests were served
+type LoaderStats struct {
+	Hits      int64 // Served fresh from cache
+	StaleHits int64 // Served stale while a refresh ran
+	Misses    int64 // Caller waited for a load
+	Loads     int64 // Calls to the backing LoadFunc
+	Refreshes int64 // Background refreshes started
+}
+
+type loaderEntry[V any] struct {
+	value    V
+	loadedAt time.Time
+}
+
+// loaderCall is an in-flight load shared by every caller of the same key
+type loaderCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Loader is a read-through cache that keeps hot keys fresh in the background
+type Loader[K comparable, V any] struct {
+	mu       sync.Mutex
+	entries  map[K]*loaderEntry[V]
+	inflight map[K]*loaderCall[V]
+	load     LoadFunc[K, V]
+	opts     LoaderOptions
+
+	ctx    context.Context // Parent of background refreshes
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	hits, staleHits, misses, loads, refreshes atomic.Int64
+}
+
+// NewLoader creates a loader around a backing LoadFunc
+func NewLoader[K comparable, V any](load LoadFunc[K, V], opts LoaderOptions) *Loader[K, V] {
+	if opts.WarmConcurrency < 1 {
+		opts.WarmConcurrency = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Loader[K, V]{
+		entries:  make(map[K]*loaderEntry[V]),
+		inflight: make(map[K]*loaderCall[V]),
+		load:     load,
+		opts:     opts,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Get returns the cached value, refreshing or loading it as needed
+func (l *Loader[K, V]) Get(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	entry, exists := l.entries[key]
+	l.mu.Unlock()
+
+	if exists {
+		age := time.Since(entry.loadedAt)
+		switch {
+		case age < l.opts.TTL-l.opts.RefreshAhead:
+			l.hits.Add(1)
+			return entry.value, nil
+		case age < l.opts.TTL:
+			// Still fresh, but close to expiry: refresh ahead of time
+			l.hits.Add(1)
+			l.refreshAsync(key)
+			return entry.value, nil
+		case age < l.opts.TTL+l.opts.StaleTTL:
+			// Expired but within the stale window: serve it and revalidate
+			l.staleHits.Add(1)
+			l.refreshAsync(key)
+			return entry.value, nil
+		}
+	}
+
+	l.misses.Add(1)
+	return l.loadShared(ctx, key)
+}
+
+// loadShared performs a load, or joins one already in flight for the same key
+func (l *Loader[K, V]) loadShared(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	call, running := l.inflight[key]
+	if !running {
+		call = &loaderCall[V]{done: make(chan struct{})}
+		l.inflight[key] = call
+		l.wg.Add(1)
+		go l.doLoad(key, call)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// refreshAsync starts a background load unless one is already running
+func (l *Loader[K, V]) refreshAsync(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, running := l.inflight[key]; running {
+		return
+	}
+	call := &loaderCall[V]{done: make(chan struct{})}
+	l.inflight[key] = call
+	l.refreshes.Add(1)
+	l.wg.Add(1)
+	go l.doLoad(key, call)
+}
+
+// doLoad runs the LoadFunc detached from any single caller's context
+func (l *Loader[K, V]) doLoad(key K, call *loaderCall[V]) {
+	defer l.wg.Done()
+
+	l.loads.Add(1)
+	call.value, call.err = l.load(l.ctx, key)
+
+	l.mu.Lock()
+	if call.err == nil {
+		l.entries[key] = &loaderEntry[V]{value: call.value, loadedAt: time.Now()}
+	}
+	// On error the previous (possibly stale) entry is kept
+	delete(l.inflight, key)
+	l.mu.Unlock()
+
+	close(call.done)
+}
+
+// Warm loads the given keys with bounded concurrency and reports every failure
+func (l *Loader[K, V]) Warm(ctx context.Context, keys []K) error {
+	sem := make(chan struct{}, l.opts.WarmConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for _, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		wg.Add(1)
+		go func(key K) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := l.loadShared(ctx, key); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("warm %v: %w", key, err))
+				mu.Unlock()
+			}
+		}(key)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Stats returns a snapshot of the loader counters
+func (l *Loader[K, V]) Stats() LoaderStats {
+	return LoaderStats{
+		Hits:      l.hits.Load(),
+		StaleHits: l.staleHits.Load(),
+		Misses:    l.misses.Load(),
+		Loads:     l.loads.Load(),
+		Refreshes: l.refreshes.Load(),
+	}
+}
+
+// Close cancels background refreshes and waits for them to finish
+func (l *Loader[K, V]) Close() {
+	l.cancel()
+	l.wg.Wait()
+}
+
+// slowBackend simulates a remote store where every read takes a while
+type slowBackend struct {
+	latency  time.Duration
+	versions sync.Map // key -> *atomic.Int64
+	calls    atomic.Int64
+	failKeys map[string]bool
+}
+
+func (b *slowBackend) Load(ctx context.Context, key string) (string, error) {
+	b.calls.Add(1)
+	select {
+	case <-time.After(b.latency):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	if b.failKeys[key] {
+		return "", fmt.Errorf("backend unavailable for %s", key)
+	}
+
+	counter, _ := b.versions.LoadOrStore(key, new(atomic.Int64))
+	version := counter.(*atomic.Int64).Add(1)
+	return fmt.Sprintf("%s@v%d", key, version), nil
+}
+
+// CacheLoaderExamples runs all cache loader examples
+func CacheLoaderExamples() {
+	fmt.Println("=== Cache Loader (Refresh-Ahead) Examples ===")
+
+	// Example 1: Read-through loading with deduplication
+	readThroughLoaderExample()
+
+	// Example 2: Refresh-ahead
+	refreshAheadExample()
+
+	// Example 3: Stale-while-revalidate
+	staleWhileRevalidateExample()
+
+	// Example 4: Bulk warm-up with bounded concurrency
+	warmUpExample()
+}
+
+// Example 1: Read-through loading with deduplication
+func readThroughLoaderExample() {
+	fmt.Println("\n--- Example 1: Read-Through Loading With Deduplication ---")
+
+	backend := &slowBackend{latency: 100 * time.Millisecond}
+	loader := NewLoader(backend.Load, LoaderOptions{TTL: time.Second})
+	defer loader.Close()
+
+	// Ten concurrent callers for a cold key share a single backend call
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loader.Get(context.Background(), "user:1")
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("10 concurrent cold reads took %v, backend calls: %d\n",
+		time.Since(start).Round(10*time.Millisecond), backend.calls.Load())
+
+	// A warm read does not touch the backend
+	start = time.Now()
+	value, _ := loader.Get(context.Background(), "user:1")
+	fmt.Printf("Warm read returned %s in %v\n", value, time.Since(start).Round(time.Millisecond))
+
+	// A caller can give up without cancelling the shared load
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := loader.Get(ctx, "user:2"); err != nil {
+		fmt.Printf("Impatient caller: %v\n", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	value, _ = loader.Get(context.Background(), "user:2")
+	fmt.Printf("Load still completed for later callers: %s\n", value)
+	fmt.Printf("Stats: %+v\n", loader.Stats())
+}
+
+// Example 2: Refresh-ahead
+func refreshAheadExample() {
+	fmt.Println("\n--- Example 2: Refresh-Ahead ---")
+
+	backend := &slowBackend{latency: 50 * time.Millisecond}
+	loader := NewLoader(backend.Load, LoaderOptions{
+		TTL:          300 * time.Millisecond,
+		RefreshAhead: 150 * time.Millisecond,
+	})
+	defer loader.Close()
+
+	loader.Get(context.Background(), "config")
+
+	// Keep reading the hot key; reads never block on the backend after the first
+	var slowest time.Duration
+	for i := 0; i < 10; i++ {
+		time.Sleep(60 * time.Millisecond)
+		start := time.Now()
+		value, _ := loader.Get(context.Background(), "config")
+		if elapsed := time.Since(start); elapsed > slowest {
+			slowest = elapsed
+		}
+		if i%3 == 0 {
+			fmt.Printf("t=%3dms read %s\n", (i+1)*60, value)
+		}
+	}
+	fmt.Printf("Slowest read after warm-up: %v (backend latency is 50ms)\n", slowest.Round(time.Millisecond))
+	fmt.Printf("Stats: %+v\n", loader.Stats())
+}
+
+// Example 3: Stale-while-revalidate
+func staleWhileRevalidateExample() {
+	fmt.Println("\n--- Example 3: Stale-While-Revalidate ---")
+
+	backend := &slowBackend{latency: 80 * time.Millisecond}
+	loader := NewLoader(backend.Load, LoaderOptions{
+		TTL:      100 * time.Millisecond,
+		StaleTTL: 500 * time.Millisecond,
+	})
+	defer loader.Close()
+
+	value, _ := loader.Get(context.Background(), "price")
+	fmt.Printf("Initial load: %s\n", value)
+
+	time.Sleep(150 * time.Millisecond) // Past TTL, inside the stale window
+
+	start := time.Now()
+	value, _ = loader.Get(context.Background(), "price")
+	fmt.Printf("Expired read served immediately: %s (%v)\n", value, time.Since(start).Round(time.Millisecond))
+
+	time.Sleep(120 * time.Millisecond) // Let the revalidation finish
+	value, _ = loader.Get(context.Background(), "price")
+	fmt.Printf("After revalidation: %s\n", value)
+
+	time.Sleep(700 * time.Millisecond) // Past TTL + StaleTTL: too old to serve
+	start = time.Now()
+	value, _ = loader.Get(context.Background(), "price")
+	fmt.Printf("Too-stale read waits for a load: %s (%v)\n", value, time.Since(start).Round(10*time.Millisecond))
+	fmt.Printf("Stats: %+v\n", loader.Stats())
+}
+
+// Example 4: Bulk warm-up with bounded concurrency
+func warmUpExample() {
+	fmt.Println("\n--- Example 4: Bulk Warm-Up With Bounded Concurrency ---")
+
+	backend := &slowBackend{
+		latency:  100 * time.Millisecond,
+		failKeys: map[string]bool{"product:7": true},
+	}
+	loader := NewLoader(backend.Load, LoaderOptions{TTL: time.Minute, WarmConcurrency: 4})
+	defer loader.Close()
+
+	keys := make([]string, 12)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("product:%d", i+1)
+	}
+
+	// 12 keys / 4 at a time * 100ms ≈ 300ms
+	start := time.Now()
+	err := loader.Warm(context.Background(), keys)
+	fmt.Printf("Warmed %d keys in %v\n", len(keys), time.Since(start).Round(10*time.Millisecond))
+	if err != nil {
+		fmt.Printf("Warm-up errors: %v\n", err)
+	}
+
+	start = time.Now()
+	for _, key := range keys[:5] {
+		loader.Get(context.Background(), key)
+	}
+	fmt.Printf("5 reads after warm-up took %v\n", time.Since(start).Round(time.Millisecond))
+	fmt.Printf("Stats: %+v\n", loader.Stats())
+}
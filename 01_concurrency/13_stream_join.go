@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stream join examples: windowed join of two channels by key
+
+// UnmatchedPolicy decides what happens to items that expire without a partner
+type UnmatchedPolicy int
+
+const (
+	DropUnmatched      UnmatchedPolicy = iota // Inner join
+	EmitUnmatchedLeft                         // Left outer join
+	EmitUnmatchedRight                        // Right outer join
+	EmitUnmatchedBoth                         // Full outer join
+)
+
+func (p UnmatchedPolicy) String() string {
+	return [...]string{"inner", "left outer", "right outer", "full outer"}[p]
+}
+
+// JoinOptions configures JoinByKey
+type JoinOptions struct {
+	Unmatched UnmatchedPolicy
+	// Now is the time source; nil means time.Now. It is called exactly once per
+	// received item and once per sweep, so a scripted clock makes joins deterministic.
+	Now func() time.Time
+	// SweepInterval is how often expired items are evicted while no input arrives;
+	// zero means window/2
+	SweepInterval time.Duration
+}
+
+// Joined is one join result; one side is missing for unmatched items
+type Joined[A, B any] struct {
+	Left     A
+	Right    B
+	HasLeft  bool
+	HasRight bool
+}
+
+func (j Joined[A, B]) String() string {
+	left, right := "-", "-"
+	if j.HasLeft {
+		left = fmt.Sprint(j.Left)
+	}
+	if j.HasRight {
+		right = fmt.Sprint(j.Right)
+	}
+	return fmt.Sprintf("(%s, %s)", left, right)
+}
+
+// pendingItem is an item waiting in the join window
+type pendingItem[T any] struct {
+	value   T
+	arrived time.Time
+	matched bool
+}
+
+// JoinByKey emits a pair for every A and B with equal keys that arrive within
+// window of each other. Items stay joinable for the whole window, so one item
+// can match several partners. Expired items that never matched are dropped or
+// emitted alone depending on the unmatched policy; when both inputs close, all
+// remaining items are flushed the same way. JoinByKey panics if window is not
+// positive, as time.NewTicker does for its interval.
+func JoinByKey[A, B any, K comparable](
+	ctx context.Context,
+	chA <-chan A,
+	chB <-chan B,
+	keyA func(A) K,
+	keyB func(B) K,
+	window time.Duration,
+	opts JoinOptions,
+) <-chan Joined[A, B] {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	if window <= 0 {
+		panic("JoinByKey: non-positive window")
+	}
+	sweepInterval := opts.SweepInterval
+	if sweepInterval <= 0 {
+		sweepInterval = max(window/2, time.Nanosecond)
+	}
+	emitLeft := opts.Unmatched == EmitUnmatchedLeft || opts.Unmatched == EmitUnmatchedBoth
+	emitRight := opts.Unmatched == EmitUnmatchedRight || opts.Unmatched == EmitUnmatchedBoth
+
+	out := make(chan Joined[A, B])
+
+	go func() {
+		defer close(out)
+
+		lefts := make(map[K][]*pendingItem[A])
+		rights := make(map[K][]*pendingItem[B])
+
+		send := func(j Joined[A, B]) bool {
+			select {
+			case out <- j:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// evict removes items that arrived before cutoff (all items when flushing)
+		evict := func(cutoff time.Time, flush bool) bool {
+			for key, items := range lefts {
+				kept := items[:0]
+				for _, item := range items {
+					if !flush && item.arrived.After(cutoff) {
+						kept = append(kept, item)
+					} else if !item.matched && emitLeft && !send(Joined[A, B]{Left: item.value, HasLeft: true}) {
+						return false
+					}
+				}
+				if len(kept) == 0 {
+					delete(lefts, key)
+				} else {
+					lefts[key] = kept
+				}
+			}
+			for key, items := range rights {
+				kept := items[:0]
+				for _, item := range items {
+					if !flush && item.arrived.After(cutoff) {
+						kept = append(kept, item)
+					} else if !item.matched && emitRight && !send(Joined[A, B]{Right: item.value, HasRight: true}) {
+						return false
+					}
+				}
+				if len(kept) == 0 {
+					delete(rights, key)
+				} else {
+					rights[key] = kept
+				}
+			}
+			return true
+		}
+
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+
+		for chA != nil || chB != nil {
+			select {
+			case a, ok := <-chA:
+				if !ok {
+					chA = nil
+					continue
+				}
+				t := now()
+				if !evict(t.Add(-window), false) {
+					return
+				}
+				key := keyA(a)
+				item := &pendingItem[A]{value: a, arrived: t}
+				for _, partner := range rights[key] {
+					item.matched, partner.matched = true, true
+					if !send(Joined[A, B]{Left: a, Right: partner.value, HasLeft: true, HasRight: true}) {
+						return
+					}
+				}
+				lefts[key] = append(lefts[key], item)
+
+			case b, ok := <-chB:
+				if !ok {
+					chB = nil
+					continue
+				}
+				t := now()
+				if !evict(t.Add(-window), false) {
+					return
+				}
+				key := keyB(b)
+				item := &pendingItem[B]{value: b, arrived: t}
+				for _, partner := range lefts[key] {
+					item.matched, partner.matched = true, true
+					if !send(Joined[A, B]{Left: partner.value, Right: b, HasLeft: true, HasRight: true}) {
+						return
+					}
+				}
+				rights[key] = append(rights[key], item)
+
+			case <-ticker.C:
+				if !evict(now().Add(-window), false) {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		evict(time.Time{}, true)
+	}()
+
+	return out
+}
+
+// scriptedClock returns the next scripted timestamp on every call. JoinByKey
+// reads its clock once per received item, so each item gets its scripted time
+// regardless of how the producer and join goroutines are scheduled.
+type scriptedClock struct {
+	mu    sync.Mutex
+	times []time.Time
+	next  int
+}
+
+func (c *scriptedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.times[c.next]
+	if c.next < len(c.times)-1 {
+		c.next++
+	}
+	return t
+}
+
+// Order and Payment are the two streams joined in the examples
+type Order struct {
+	ID     string
+	Amount int
+}
+
+type Payment struct {
+	OrderID string
+	Method  string
+}
+
+func (o Order) String() string   { return fmt.Sprintf("order %s $%d", o.ID, o.Amount) }
+func (p Payment) String() string { return fmt.Sprintf("payment %s via %s", p.OrderID, p.Method) }
+
+// joinEvent is one scripted arrival on either stream
+type joinEvent struct {
+	after   time.Duration
+	order   *Order
+	payment *Payment
+}
+
+// runScriptedJoin feeds events in order with scripted arrival times, so the
+// result depends only on the script, not on goroutine scheduling
+func runScriptedJoin(events []joinEvent, window time.Duration, policy UnmatchedPolicy) []Joined[Order, Payment] {
+	clock := &scriptedClock{}
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, e := range events {
+		at = at.Add(e.after)
+		clock.times = append(clock.times, at)
+	}
+
+	orders := make(chan Order)
+	payments := make(chan Payment)
+
+	joined := JoinByKey(context.Background(), orders, payments,
+		func(o Order) string { return o.ID },
+		func(p Payment) string { return p.OrderID },
+		window,
+		JoinOptions{Unmatched: policy, Now: clock.Now, SweepInterval: time.Hour},
+	)
+
+	go func() {
+		defer close(orders)
+		defer close(payments)
+		for _, e := range events {
+			if e.order != nil {
+				orders <- *e.order
+			} else {
+				payments <- *e.payment
+			}
+		}
+	}()
+
+	var results []Joined[Order, Payment]
+	for j := range joined {
+		results = append(results, j)
+	}
+	return results
+}
+
+// StreamJoinExamples runs all stream join examples
+func StreamJoinExamples() {
+	fmt.Println("=== Stream Join (Windowed Join By Key) Examples ===")
+
+	// Example 1: Inner join within a time window
+	innerJoinExample()
+
+	// Example 2: Unmatched item policies
+	unmatchedPolicyExample()
+
+	// Example 3: Many-to-many matches
+	manyToManyJoinExample()
+
+	// Example 4: Live join on real time
+	liveJoinExample()
+}
+
+func orderScript() []joinEvent {
+	return []joinEvent{
+		{after: 0, order: &Order{"A1", 30}},
+		{after: 2 * time.Second, payment: &Payment{"A1", "card"}}, // 2s after order: joins
+		{after: time.Second, order: &Order{"A2", 75}},             // never paid
+		{after: time.Second, payment: &Payment{"A9", "cash"}},     // payment for unknown order
+		{after: time.Second, order: &Order{"A3", 12}},
+		{after: 10 * time.Second, payment: &Payment{"A3", "voucher"}}, // 10s later: too late
+	}
+}
+
+// Example 1: Inner join within a time window
+func innerJoinExample() {
+	fmt.Println("\n--- Example 1: Inner Join Within a Time Window ---")
+
+	results := runScriptedJoin(orderScript(), 5*time.Second, DropUnmatched)
+	fmt.Printf("Window 5s, %d joined pairs:\n", len(results))
+	for _, j := range results {
+		fmt.Printf("  %v\n", j)
+	}
+
+	// The same script with a wider window also catches the late voucher payment
+	results = runScriptedJoin(orderScript(), 15*time.Second, DropUnmatched)
+	fmt.Printf("Window 15s, %d joined pairs:\n", len(results))
+	for _, j := range results {
+		fmt.Printf("  %v\n", j)
+	}
+}
+
+// Example 2: Unmatched item policies
+func unmatchedPolicyExample() {
+	fmt.Println("\n--- Example 2: Unmatched Item Policies ---")
+
+	for _, policy := range []UnmatchedPolicy{DropUnmatched, EmitUnmatchedLeft, EmitUnmatchedRight, EmitUnmatchedBoth} {
+		results := runScriptedJoin(orderScript(), 5*time.Second, policy)
+		lines := make([]string, len(results))
+		for i, j := range results {
+			lines[i] = j.String()
+		}
+		// Unmatched items are emitted in map order when they expire together
+		sort.Strings(lines)
+		fmt.Printf("%-11s join: %s\n", policy, strings.Join(lines, " "))
+	}
+}
+
+// Example 3: Many-to-many matches
+func manyToManyJoinExample() {
+	fmt.Println("\n--- Example 3: Many-to-Many Matches ---")
+
+	// A split payment: two payments for one order, plus a duplicate order event
+	events := []joinEvent{
+		{after: 0, order: &Order{"B1", 100}},
+		{after: time.Second, payment: &Payment{"B1", "card"}},
+		{after: time.Second, payment: &Payment{"B1", "gift-card"}},
+		{after: time.Second, order: &Order{"B1", 100}}, // Redelivered order joins both payments
+	}
+
+	for _, j := range runScriptedJoin(events, 5*time.Second, DropUnmatched) {
+		fmt.Printf("  %v\n", j)
+	}
+}
+
+// Example 4: Live join on real time
+func liveJoinExample() {
+	fmt.Println("\n--- Example 4: Live Join on Real Time ---")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	orders := make(chan Order)
+	payments := make(chan Payment)
+
+	joined := JoinByKey(ctx, orders, payments,
+		func(o Order) string { return o.ID },
+		func(p Payment) string { return p.OrderID },
+		100*time.Millisecond,
+		JoinOptions{Unmatched: EmitUnmatchedLeft},
+	)
+
+	// Orders and payments come from independent producers
+	go func() {
+		defer close(orders)
+		for i := 1; i <= 4; i++ {
+			orders <- Order{ID: fmt.Sprintf("C%d", i), Amount: i * 10}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+	go func() {
+		defer close(payments)
+		time.Sleep(30 * time.Millisecond)
+		for _, id := range []string{"C1", "C2", "C4"} {
+			payments <- Payment{OrderID: id, Method: "card"}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	// C3 is never paid; it is emitted alone when its window expires or the inputs close
+	start := time.Now()
+	for j := range joined {
+		status := "paid"
+		if !j.HasRight {
+			status = "UNPAID"
+		}
+		fmt.Printf("  t=%3dms %-6s %v\n", time.Since(start).Milliseconds()/10*10, status, j)
+	}
+}
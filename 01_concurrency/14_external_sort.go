@@ -0,0 +1,412 @@
+package main
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// External sort examples: sorting a stream larger than the memory budget
+
+// ExternalSorter sorts a stream of int64 values by spilling sorted chunks to
+// temp files and k-way merging them with a heap
+type ExternalSorter struct {
+	MemoryBudget int    // Max values held in memory across all chunk buffers
+	Parallelism  int    // Chunks sorted and spilled concurrently
+	TempDir      string // Parent directory for spill files ("" means os.TempDir)
+
+	chunks     atomic.Int64
+	peakInUse  atomic.Int64
+	inUse      atomic.Int64
+	spillBytes atomic.Int64
+}
+
+// NewExternalSorter creates a sorter with the given memory budget and parallelism
+func NewExternalSorter(memoryBudget, parallelism int) *ExternalSorter {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	return &ExternalSorter{MemoryBudget: memoryBudget, Parallelism: parallelism}
+}
+
+// chunkSize splits the budget between the chunk being filled and the ones being sorted
+func (s *ExternalSorter) chunkSize() int {
+	size := s.MemoryBudget / (s.Parallelism + 1)
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// Sort consumes in and returns a channel of sorted values plus an error channel
+// that yields at most one error once the output is closed
+func (s *ExternalSorter) Sort(ctx context.Context, in <-chan int64) (<-chan int64, <-chan error) {
+	out := make(chan int64, 1024)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		dir, err := os.MkdirTemp(s.TempDir, "extsort-")
+		if err != nil {
+			errc <- err
+			return
+		}
+		defer os.RemoveAll(dir)
+
+		files, err := s.spill(ctx, in, dir)
+		if err != nil {
+			errc <- err
+			return
+		}
+		if err := s.merge(ctx, files, out); err != nil {
+			errc <- err
+		}
+	}()
+
+	return out, errc
+}
+
+// spill runs phase 1: cut the input into chunks, sort them in parallel and write them out
+func (s *ExternalSorter) spill(ctx context.Context, in <-chan int64, dir string) ([]string, error) {
+	chunks := make(chan []int64) // Unbuffered: at most Parallelism chunks wait to be sorted
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		files    []string
+		firstErr error
+	)
+
+	for i := 0; i < s.Parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				sort.Slice(chunk, func(a, b int) bool { return chunk[a] < chunk[b] })
+				path := filepath.Join(dir, fmt.Sprintf("chunk-%06d.bin", s.chunks.Add(1)))
+				err := writeChunk(path, chunk)
+				s.spillBytes.Add(int64(len(chunk) * 8))
+				s.inUse.Add(-int64(len(chunk)))
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				files = append(files, path)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	size := s.chunkSize()
+	chunk := make([]int64, 0, size)
+	flush := func() bool {
+		if len(chunk) == 0 {
+			return true
+		}
+		select {
+		case chunks <- chunk:
+			chunk = make([]int64, 0, size)
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	cancelled := false
+loop:
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				break loop
+			}
+			chunk = append(chunk, v)
+			s.trackInUse(1)
+			if len(chunk) == size && !flush() {
+				cancelled = true
+				break loop
+			}
+		case <-ctx.Done():
+			cancelled = true
+			break loop
+		}
+	}
+	if !cancelled && !flush() {
+		cancelled = true
+	}
+
+	close(chunks)
+	wg.Wait()
+
+	if cancelled {
+		return nil, ctx.Err()
+	}
+	return files, firstErr
+}
+
+func (s *ExternalSorter) trackInUse(delta int64) {
+	current := s.inUse.Add(delta)
+	for {
+		peak := s.peakInUse.Load()
+		if current <= peak || s.peakInUse.CompareAndSwap(peak, current) {
+			return
+		}
+	}
+}
+
+// merge runs phase 2: k-way merge of the sorted chunk files
+func (s *ExternalSorter) merge(ctx context.Context, files []string, out chan<- int64) error {
+	h := &mergeHeap{}
+	for _, path := range files {
+		r, err := openChunk(path)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if r.Next() {
+			h.items = append(h.items, r)
+		} else if r.err != nil {
+			return r.err
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		r := h.items[0]
+		select {
+		case out <- r.current:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if r.Next() {
+			heap.Fix(h, 0)
+		} else {
+			if r.err != nil {
+				return r.err
+			}
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// Stats describes the last sort
+func (s *ExternalSorter) Stats() string {
+	return fmt.Sprintf("chunks=%d chunkSize=%d peakInMemory=%d spilled=%dKB",
+		s.chunks.Load(), s.chunkSize(), s.peakInUse.Load(), s.spillBytes.Load()/1024)
+}
+
+// writeChunk stores values as fixed-width little-endian integers
+func writeChunk(path string, values []int64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	var buf [8]byte
+	for _, v := range values {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		if _, err := w.Write(buf[:]); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// chunkReader streams values back from a chunk file
+type chunkReader struct {
+	file    *os.File
+	reader  *bufio.Reader
+	current int64
+	err     error
+}
+
+func openChunk(path string) (*chunkReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &chunkReader{file: f, reader: bufio.NewReader(f)}, nil
+}
+
+// Next advances to the next value, returning false at EOF or on error
+func (r *chunkReader) Next() bool {
+	var buf [8]byte
+	if _, err := io.ReadFull(r.reader, buf[:]); err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.err = err
+		}
+		return false
+	}
+	r.current = int64(binary.LittleEndian.Uint64(buf[:]))
+	return true
+}
+
+func (r *chunkReader) Close() error {
+	return r.file.Close()
+}
+
+// mergeHeap orders chunk readers by their current value
+type mergeHeap struct {
+	items []*chunkReader
+}
+
+func (h *mergeHeap) Len() int           { return len(h.items) }
+func (h *mergeHeap) Less(i, j int) bool { return h.items[i].current < h.items[j].current }
+func (h *mergeHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *mergeHeap) Push(x interface{}) { h.items = append(h.items, x.(*chunkReader)) }
+func (h *mergeHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// randomStream emits n pseudo-random values without ever materializing them,
+// stopping early when ctx is cancelled
+func randomStream(ctx context.Context, n int, seed int64) <-chan int64 {
+	out := make(chan int64, 1024)
+	go func() {
+		defer close(out)
+		rng := rand.New(rand.NewSource(seed))
+		for i := 0; i < n; i++ {
+			select {
+			case out <- rng.Int63n(1_000_000_000):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// verifySorted drains a sorted stream and checks order, count and checksum
+func verifySorted(sorted <-chan int64) (count int, sum int64, ordered bool) {
+	ordered = true
+	first := true
+	var prev int64
+	for v := range sorted {
+		if !first && v < prev {
+			ordered = false
+		}
+		prev, first = v, false
+		count++
+		sum += v
+	}
+	return count, sum, ordered
+}
+
+// ExternalSortExamples runs all external sort examples
+func ExternalSortExamples() {
+	fmt.Println("=== External Merge Sort Examples ===")
+
+	// Example 1: Sorting a stream larger than the memory budget
+	externalSortBasicExample()
+
+	// Example 2: Parallel chunk sorting
+	externalSortParallelExample()
+
+	// Example 3: Cancellation cleans up spill files
+	externalSortCancellationExample()
+}
+
+// Example 1: Sorting a stream larger than the memory budget
+func externalSortBasicExample() {
+	fmt.Println("\n--- Example 1: Sorting a Stream Larger Than Memory ---")
+
+	const n = 500_000
+	var expectedSum int64
+	for v := range randomStream(context.Background(), n, 1) {
+		expectedSum += v
+	}
+
+	sorter := NewExternalSorter(50_000, 2) // Budget is 10% of the input
+	sorted, errc := sorter.Sort(context.Background(), randomStream(context.Background(), n, 1))
+
+	count, sum, ordered := verifySorted(sorted)
+	if err := <-errc; err != nil {
+		fmt.Printf("Sort failed: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Input: %d values, memory budget: %d values\n", n, sorter.MemoryBudget)
+	fmt.Printf("Output: %d values, sorted=%v, checksum matches=%v\n", count, ordered, sum == expectedSum)
+	fmt.Printf("Stats: %s\n", sorter.Stats())
+}
+
+// Example 2: Parallel chunk sorting
+func externalSortParallelExample() {
+	fmt.Println("\n--- Example 2: Parallel Chunk Sorting ---")
+
+	const n = 1_000_000
+	for _, parallelism := range []int{1, 2, 4} {
+		sorter := NewExternalSorter(100_000, parallelism)
+
+		start := time.Now()
+		sorted, errc := sorter.Sort(context.Background(), randomStream(context.Background(), n, 2))
+		count, _, ordered := verifySorted(sorted)
+		if err := <-errc; err != nil {
+			fmt.Printf("Sort failed: %v\n", err)
+			return
+		}
+
+		// A fixed budget means more workers get smaller chunks and the merge gets wider
+		fmt.Printf("parallelism=%d: %d values sorted=%v in %v (%s)\n",
+			parallelism, count, ordered, time.Since(start).Round(time.Millisecond), sorter.Stats())
+	}
+}
+
+// Example 3: Cancellation cleans up spill files
+func externalSortCancellationExample() {
+	fmt.Println("\n--- Example 3: Cancellation Cleans Up Spill Files ---")
+
+	tempDir, err := os.MkdirTemp("", "extsort-demo-")
+	if err != nil {
+		fmt.Printf("Cannot create temp dir: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sorter := NewExternalSorter(10_000, 2)
+	sorter.TempDir = tempDir
+
+	sorted, errc := sorter.Sort(ctx, randomStream(ctx, 1_000_000, 3))
+
+	// Read a few values from the merge phase, then give up
+	received := 0
+	for range sorted {
+		received++
+		if received == 1000 {
+			cancel()
+			break
+		}
+	}
+	for range sorted {
+		// Drain until the sorter notices the cancellation
+	}
+
+	err = <-errc
+	entries, _ := os.ReadDir(tempDir)
+	fmt.Printf("Stopped after %d values: %v\n", received, err)
+	fmt.Printf("Spill directories left behind: %d\n", len(entries))
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Directory hasher examples: hashing a file tree with bounded workers
+
+// FileHash is the streamed result for one file
+type FileHash struct {
+	Path string // Relative to the hashed root, slash-separated
+	Size int64
+	Hash string
+	Err  error
+}
+
+// ctxReader stops a long read as soon as the context is cancelled
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// HashDirectory walks root and hashes every regular file with at most workers
+// files open at a time. Results stream as they complete; per-file failures are
+// reported in FileHash.Err and the error channel yields the walk error
+// (including ctx cancellation), which callers read after draining results.
+// workers below 1 means runtime.NumCPU().
+func HashDirectory(ctx context.Context, root string, workers int) (<-chan FileHash, <-chan error) {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	paths := make(chan string)
+	results := make(chan FileHash)
+	errc := make(chan error, 1)
+
+	// Walker: feeds paths until the tree is exhausted or ctx is cancelled
+	go func() {
+		defer close(paths)
+		errc <- filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			select {
+			case paths <- path:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	// Workers: hash files and stream results
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				result := hashFile(ctx, root, path)
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results, errc
+}
+
+func hashFile(ctx context.Context, root, path string) FileHash {
+	rel, _ := filepath.Rel(root, path)
+	result := FileHash{Path: filepath.ToSlash(rel)}
+
+	f, err := os.Open(path)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	result.Size, result.Err = io.Copy(h, ctxReader{ctx: ctx, r: f})
+	if result.Err == nil {
+		result.Hash = hex.EncodeToString(h.Sum(nil))
+	}
+	return result
+}
+
+// Manifest is the aggregated, order-independent result of hashing a tree
+type Manifest struct {
+	Files      map[string]FileHash
+	TotalBytes int64
+	Errors     []FileHash
+}
+
+// BuildManifest collects streamed results into a manifest
+func BuildManifest(results <-chan FileHash) *Manifest {
+	m := &Manifest{Files: make(map[string]FileHash)}
+	for r := range results {
+		if r.Err != nil {
+			m.Errors = append(m.Errors, r)
+			continue
+		}
+		m.Files[r.Path] = r
+		m.TotalBytes += r.Size
+	}
+	return m
+}
+
+// RootHash hashes the sorted (path, hash) list, so it is independent of completion order
+func (m *Manifest) RootHash() string {
+	h := sha256.New()
+	for _, path := range m.sortedPaths() {
+		fmt.Fprintf(h, "%s %s\n", path, m.Files[path].Hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (m *Manifest) sortedPaths() []string {
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Diff reports files added, removed or changed relative to an older manifest
+func (m *Manifest) Diff(old *Manifest) (added, removed, changed []string) {
+	for _, p := range m.sortedPaths() {
+		prev, exists := old.Files[p]
+		switch {
+		case !exists:
+			added = append(added, p)
+		case prev.Hash != m.Files[p].Hash:
+			changed = append(changed, p)
+		}
+	}
+	for _, p := range old.sortedPaths() {
+		if _, exists := m.Files[p]; !exists {
+			removed = append(removed, p)
+		}
+	}
+	return added, removed, changed
+}
+
+// createSampleTree writes a small project-like tree for the examples
+func createSampleTree() (string, error) {
+	root, err := os.MkdirTemp("", "dirhash-")
+	if err != nil {
+		return "", err
+	}
+
+	files := map[string]int{
+		"README.md":              2 << 10,
+		"go.mod":                 100,
+		"cmd/app/main.go":        8 << 10,
+		"internal/db/db.go":      32 << 10,
+		"internal/db/schema.sql": 4 << 10,
+		"internal/api/api.go":    64 << 10,
+		"assets/logo.png":        512 << 10,
+		"assets/video.bin":       4 << 20,
+	}
+	for i := 0; i < 40; i++ {
+		files[fmt.Sprintf("data/part-%02d.csv", i)] = (i + 1) << 12
+	}
+
+	for name, size := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return root, err
+		}
+		content := []byte(strings.Repeat(name+"\n", size/(len(name)+1)+1))[:size]
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return root, err
+		}
+	}
+	return root, nil
+}
+
+// DirHasherExamples runs all directory hasher examples
+func DirHasherExamples() {
+	fmt.Println("=== Parallel Directory Hasher Examples ===")
+
+	root, err := createSampleTree()
+	if root != "" {
+		defer os.RemoveAll(root)
+	}
+	if err != nil {
+		fmt.Printf("Cannot create sample tree: %v\n", err)
+		return
+	}
+
+	// Example 1: Streaming results as they complete
+	streamingHashExample(root)
+
+	// Example 2: Manifests are independent of worker count
+	manifestExample(root)
+
+	// Example 3: Detecting changes with manifest diffs
+	manifestDiffExample(root)
+
+	// Example 4: Cancelling mid-walk
+	hashCancellationExample(root)
+}
+
+// Example 1: Streaming results as they complete
+func streamingHashExample(root string) {
+	fmt.Println("\n--- Example 1: Streaming Results As They Complete ---")
+
+	results, errc := HashDirectory(context.Background(), root, 4)
+
+	count := 0
+	for r := range results {
+		count++
+		if r.Err != nil {
+			fmt.Printf("  %-24s error: %v\n", r.Path, r.Err)
+		} else if count <= 5 {
+			fmt.Printf("  %-24s %8d bytes  %s…\n", r.Path, r.Size, r.Hash[:16])
+		}
+	}
+	if err := <-errc; err != nil {
+		fmt.Printf("Walk error: %v\n", err)
+	}
+	fmt.Printf("  ... %d files hashed (completion order, not walk order)\n", count)
+}
+
+// Example 2: Manifests are independent of worker count
+func manifestExample(root string) {
+	fmt.Println("\n--- Example 2: Manifests Are Independent of Worker Count ---")
+
+	for _, workers := range []int{1, 4, 16} {
+		start := time.Now()
+		results, errc := HashDirectory(context.Background(), root, workers)
+		manifest := BuildManifest(results)
+		if err := <-errc; err != nil {
+			fmt.Printf("Walk error: %v\n", err)
+			return
+		}
+		fmt.Printf("workers=%2d: %d files, %d KB, root=%s… in %v\n",
+			workers, len(manifest.Files), manifest.TotalBytes>>10, manifest.RootHash()[:16],
+			time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// Example 3: Detecting changes with manifest diffs
+func manifestDiffExample(root string) {
+	fmt.Println("\n--- Example 3: Detecting Changes With Manifest Diffs ---")
+
+	hash := func() *Manifest {
+		results, errc := HashDirectory(context.Background(), root, 4)
+		m := BuildManifest(results)
+		<-errc
+		return m
+	}
+
+	before := hash()
+
+	os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/changed\n"), 0o644)
+	os.Remove(filepath.Join(root, "data", "part-07.csv"))
+	os.WriteFile(filepath.Join(root, "CHANGELOG.md"), []byte("v2\n"), 0o644)
+
+	after := hash()
+	added, removed, changed := after.Diff(before)
+	fmt.Printf("Root hash changed: %v\n", before.RootHash() != after.RootHash())
+	fmt.Printf("Added: %v\nRemoved: %v\nChanged: %v\n", added, removed, changed)
+}
+
+// Example 4: Cancelling mid-walk
+func hashCancellationExample(root string) {
+	fmt.Println("\n--- Example 4: Cancelling Mid-Walk ---")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results, errc := HashDirectory(ctx, root, 2)
+
+	received := 0
+	for r := range results {
+		received++
+		if r.Err != nil {
+			fmt.Printf("  %s interrupted: %v\n", r.Path, r.Err)
+		}
+		if received == 10 {
+			cancel()
+		}
+	}
+
+	fmt.Printf("Received %d results before shutdown, walk error: %v\n", received, <-errc)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Connection pool examples: how the acquisition policy shapes tail latency
+
+// PoolPolicy decides which waiter gets a released connection and which idle
+// connection is handed out next
+type PoolPolicy int
+
+const (
+	// FIFO serves waiters in arrival order and rotates through idle connections
+	FIFO PoolPolicy = iota
+	// LIFO serves the newest waiter and reuses the most recently released
+	// connection, which keeps a few connections hot at the cost of fairness
+	LIFO
+)
+
+func (p PoolPolicy) String() string {
+	if p == LIFO {
+		return "LIFO"
+	}
+	return "FIFO"
+}
+
+// ErrPoolTimeout is returned when no connection became free within MaxWait
+var ErrPoolTimeout = errors.New("pool: timed out waiting for a connection")
+
+// PoolConn is a simulated connection
+type PoolConn struct {
+	ID   int
+	Uses int
+}
+
+// PoolStats summarizes acquisitions since the pool was created
+type PoolStats struct {
+	Acquired  int
+	Timeouts  int
+	P50, P99  time.Duration
+	MaxWait   time.Duration // Longest wait of a successful acquisition
+	ConnsUsed int           // Distinct connections handed out
+}
+
+// ConnPool is a fixed-size pool with a configurable acquisition policy
+type ConnPool struct {
+	mu      sync.Mutex
+	policy  PoolPolicy
+	maxWait time.Duration
+	idle    []*PoolConn
+	waiters *list.List // Of chan *PoolConn, oldest at the front
+
+	waits    []time.Duration
+	timeouts int
+	used     map[int]bool
+}
+
+// NewConnPool creates a pool of size connections
+func NewConnPool(size int, policy PoolPolicy, maxWait time.Duration) *ConnPool {
+	p := &ConnPool{
+		policy:  policy,
+		maxWait: maxWait,
+		waiters: list.New(),
+		used:    make(map[int]bool),
+	}
+	for i := 1; i <= size; i++ {
+		p.idle = append(p.idle, &PoolConn{ID: i})
+	}
+	return p
+}
+
+// Acquire returns an idle connection or waits up to MaxWait for one
+func (p *ConnPool) Acquire() (*PoolConn, error) {
+	start := time.Now()
+
+	p.mu.Lock()
+	if len(p.idle) > 0 {
+		conn := p.takeIdle()
+		p.recordLocked(conn, 0)
+		p.mu.Unlock()
+		return conn, nil
+	}
+	ready := make(chan *PoolConn, 1) // Buffered so Release never blocks
+	elem := p.waiters.PushBack(ready)
+	p.mu.Unlock()
+
+	timer := time.NewTimer(p.maxWait)
+	defer timer.Stop()
+
+	select {
+	case conn := <-ready:
+		p.mu.Lock()
+		p.recordLocked(conn, time.Since(start))
+		p.mu.Unlock()
+		return conn, nil
+	case <-timer.C:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		select {
+		case conn := <-ready:
+			// Release handed us a connection just as the timer fired
+			p.recordLocked(conn, time.Since(start))
+			return conn, nil
+		default:
+			p.waiters.Remove(elem)
+			p.timeouts++
+			return nil, ErrPoolTimeout
+		}
+	}
+}
+
+// takeIdle must be called with p.mu held and at least one idle connection
+func (p *ConnPool) takeIdle() *PoolConn {
+	var conn *PoolConn
+	if p.policy == LIFO {
+		conn = p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+	} else {
+		conn = p.idle[0]
+		p.idle = p.idle[1:]
+	}
+	return conn
+}
+
+func (p *ConnPool) recordLocked(conn *PoolConn, wait time.Duration) {
+	conn.Uses++
+	p.used[conn.ID] = true
+	p.waits = append(p.waits, wait)
+}
+
+// Release hands the connection to a waiter chosen by the policy, or returns it to the idle set
+func (p *ConnPool) Release(conn *PoolConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.waiters.Len() == 0 {
+		p.idle = append(p.idle, conn)
+		return
+	}
+
+	elem := p.waiters.Front()
+	if p.policy == LIFO {
+		elem = p.waiters.Back()
+	}
+	p.waiters.Remove(elem)
+	elem.Value.(chan *PoolConn) <- conn
+}
+
+// Stats computes latency percentiles over all successful acquisitions
+func (p *ConnPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	waits := append([]time.Duration(nil), p.waits...)
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+
+	stats := PoolStats{Acquired: len(waits), Timeouts: p.timeouts, ConnsUsed: len(p.used)}
+	if len(waits) > 0 {
+		stats.P50 = waits[len(waits)*50/100]
+		stats.P99 = waits[len(waits)*99/100]
+		stats.MaxWait = waits[len(waits)-1]
+	}
+	return stats
+}
+
+// runPoolLoad has workers issue requests that each hold a connection for holdTime
+func runPoolLoad(pool *ConnPool, workers, requestsPerWorker int, holdTime func(*rand.Rand) time.Duration) {
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i < requestsPerWorker; i++ {
+				conn, err := pool.Acquire()
+				if err != nil {
+					continue // The request fails; a real client would surface the error
+				}
+				time.Sleep(holdTime(rng))
+				pool.Release(conn)
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+}
+
+func printPoolStats(policy PoolPolicy, s PoolStats) {
+	fmt.Printf("%s: acquired=%d timeouts=%d p50=%v p99=%v max=%v conns used=%d\n",
+		policy, s.Acquired, s.Timeouts,
+		s.P50.Round(time.Microsecond*100), s.P99.Round(time.Microsecond*100),
+		s.MaxWait.Round(time.Microsecond*100), s.ConnsUsed)
+}
+
+// ConnPoolExamples runs all connection pool examples
+func ConnPoolExamples() {
+	fmt.Println("=== Connection Pool Fairness Examples ===")
+
+	// Example 1: Tail latency under overload
+	poolTailLatencyExample()
+
+	// Example 2: Starvation with max wait timeouts
+	poolStarvationExample()
+
+	// Example 3: Connection locality under light load
+	poolLocalityExample()
+}
+
+// Example 1: Tail latency under overload
+func poolTailLatencyExample() {
+	fmt.Println("\n--- Example 1: Tail Latency Under Overload ---")
+	fmt.Println("4 connections, 16 workers, 1-2ms per request, no effective timeout")
+
+	hold := func(rng *rand.Rand) time.Duration {
+		return time.Millisecond + time.Duration(rng.Intn(1000))*time.Microsecond
+	}
+	for _, policy := range []PoolPolicy{FIFO, LIFO} {
+		pool := NewConnPool(4, policy, time.Second)
+		runPoolLoad(pool, 16, 20, hold)
+		printPoolStats(policy, pool.Stats())
+	}
+	fmt.Println("LIFO wins the median because recent arrivals cut in line; the waiters they skip pay in the tail")
+}
+
+// Example 2: Starvation with max wait timeouts
+func poolStarvationExample() {
+	fmt.Println("\n--- Example 2: Starvation With Max Wait Timeouts ---")
+	fmt.Println("Same load with a 15ms max wait")
+
+	hold := func(rng *rand.Rand) time.Duration {
+		return time.Millisecond + time.Duration(rng.Intn(1000))*time.Microsecond
+	}
+	for _, policy := range []PoolPolicy{FIFO, LIFO} {
+		pool := NewConnPool(4, policy, 15*time.Millisecond)
+		runPoolLoad(pool, 16, 20, hold)
+		printPoolStats(policy, pool.Stats())
+	}
+	fmt.Println("FIFO spreads the wait evenly; LIFO starves the oldest waiters until they time out")
+}
+
+// Example 3: Connection locality under light load
+func poolLocalityExample() {
+	fmt.Println("\n--- Example 3: Connection Locality Under Light Load ---")
+	fmt.Println("8 connections, 2 workers: the pool is never exhausted")
+
+	hold := func(rng *rand.Rand) time.Duration { return 200 * time.Microsecond }
+	for _, policy := range []PoolPolicy{FIFO, LIFO} {
+		pool := NewConnPool(8, policy, time.Second)
+		runPoolLoad(pool, 2, 50, hold)
+		printPoolStats(policy, pool.Stats())
+	}
+	fmt.Println("LIFO keeps reusing the same warm connections, letting the rest idle out")
+}
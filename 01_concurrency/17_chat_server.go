@@ -0,0 +1,545 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Chat server capstone: actors own room state, a broker fans messages out to
+// client inboxes, a token bucket limits posting and a shutdown coordinator
+// drains everything in order
+
+var (
+	ErrServerClosed = errors.New("chat: server is shutting down")
+	ErrRateLimited  = errors.New("chat: rate limit exceeded")
+	ErrNotMember    = errors.New("chat: not a member of the room")
+	ErrNameTaken    = errors.New("chat: name already connected")
+)
+
+// ChatEvent is what a client receives
+type ChatEvent struct {
+	Room string
+	From string
+	Text string
+	Seq  int // Per-room sequence number, assigned by the room actor
+}
+
+func (e ChatEvent) String() string {
+	if e.From == "" {
+		return fmt.Sprintf("[%s #%d] * %s", e.Room, e.Seq, e.Text)
+	}
+	return fmt.Sprintf("[%s #%d] %s: %s", e.Room, e.Seq, e.From, e.Text)
+}
+
+// Room actor messages
+type joinRoomMessage struct {
+	client *ChatClient
+	reply  chan error
+}
+
+func (m joinRoomMessage) Type() string { return "join" }
+
+type leaveRoomMessage struct {
+	client *ChatClient
+}
+
+func (m leaveRoomMessage) Type() string { return "leave" }
+
+type postMessage struct {
+	client *ChatClient
+	text   string
+	reply  chan error
+}
+
+func (m postMessage) Type() string { return "post" }
+
+// chatRateLimiter is a token bucket: burst tokens, refilled at rate per second
+type chatRateLimiter struct {
+	mu     sync.Mutex
+	tokens float64
+	burst  float64
+	rate   float64
+	last   time.Time
+}
+
+func newChatRateLimiter(rate float64, burst int) *chatRateLimiter {
+	return &chatRateLimiter{tokens: float64(burst), burst: float64(burst), rate: rate, last: time.Now()}
+}
+
+func (l *chatRateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// ChatClient is a connected user with a bounded inbox
+type ChatClient struct {
+	Name    string
+	inbox   chan ChatEvent
+	limiter *chatRateLimiter
+	delay   time.Duration // Per-message processing time, to simulate slow clients
+
+	closeOnce    sync.Once
+	done         chan struct{}
+	dropped      atomic.Int64
+	disconnected atomic.Bool
+
+	mu       sync.Mutex
+	received []ChatEvent
+}
+
+// read consumes the inbox until the server closes it
+func (c *ChatClient) read() {
+	defer close(c.done)
+	for event := range c.inbox {
+		time.Sleep(c.delay)
+		c.mu.Lock()
+		c.received = append(c.received, event)
+		c.mu.Unlock()
+	}
+}
+
+// Received returns the events delivered so far
+func (c *ChatClient) Received() []ChatEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ChatEvent(nil), c.received...)
+}
+
+// Done is closed once the client's inbox has been closed and drained
+func (c *ChatClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// ChatBroker fans room events out to subscribed clients without ever blocking
+// on a slow one: a full inbox drops the event, and too many drops disconnect
+type ChatBroker struct {
+	mu       sync.RWMutex
+	topics   map[string]map[*ChatClient]bool
+	maxDrops int64
+}
+
+func NewChatBroker(maxDrops int64) *ChatBroker {
+	return &ChatBroker{topics: make(map[string]map[*ChatClient]bool), maxDrops: maxDrops}
+}
+
+func (b *ChatBroker) Subscribe(topic string, c *ChatClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[*ChatClient]bool)
+	}
+	b.topics[topic][c] = true
+}
+
+func (b *ChatBroker) Unsubscribe(topic string, c *ChatClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.topics[topic], c)
+}
+
+// Publish delivers event to every subscriber of topic
+func (b *ChatBroker) Publish(topic string, event ChatEvent) {
+	var slow []*ChatClient
+
+	b.mu.RLock()
+	for c := range b.topics[topic] {
+		select {
+		case c.inbox <- event:
+		default:
+			if c.dropped.Add(1) >= b.maxDrops {
+				slow = append(slow, c)
+			}
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, c := range slow {
+		b.Disconnect(c)
+	}
+}
+
+// Disconnect removes a client from every topic and closes its inbox. Inboxes
+// are only closed under the write lock, so Publish never sends on a closed one.
+func (b *ChatBroker) Disconnect(c *ChatClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, subscribers := range b.topics {
+		delete(subscribers, c)
+	}
+	c.closeOnce.Do(func() {
+		c.disconnected.Store(true)
+		close(c.inbox)
+	})
+}
+
+// Close closes every remaining inbox
+func (b *ChatBroker) Close(clients []*ChatClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topics = make(map[string]map[*ChatClient]bool)
+	for _, c := range clients {
+		c.closeOnce.Do(func() { close(c.inbox) })
+	}
+}
+
+// ShutdownCoordinator runs registered phases in order under one deadline
+type ShutdownCoordinator struct {
+	mu     sync.Mutex
+	phases []shutdownPhase
+}
+
+type shutdownPhase struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (s *ShutdownCoordinator) Register(name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases = append(s.phases, shutdownPhase{name: name, fn: fn})
+}
+
+// Shutdown runs every phase, even after a failure, and reports all errors
+func (s *ShutdownCoordinator) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	phases := append([]shutdownPhase(nil), s.phases...)
+	s.mu.Unlock()
+
+	var errs []error
+	for _, phase := range phases {
+		start := time.Now()
+		err := phase.fn(ctx)
+		status := "ok"
+		if err != nil {
+			status = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", phase.name, err))
+		}
+		fmt.Printf("  shutdown phase %-20s %-6v %s\n", phase.name, time.Since(start).Round(time.Millisecond), status)
+	}
+	return errors.Join(errs...)
+}
+
+// ChatOptions tunes limits for the server
+type ChatOptions struct {
+	InboxSize     int
+	MaxDrops      int64   // Dropped events before a slow client is disconnected
+	PostRate      float64 // Posts per second per client
+	PostBurst     int
+	DrainDeadline time.Duration
+}
+
+// ChatServer wires rooms, broker, limiter and shutdown together
+type ChatServer struct {
+	opts     ChatOptions
+	broker   *ChatBroker
+	shutdown ShutdownCoordinator
+
+	mu      sync.Mutex
+	closed  bool
+	rooms   map[string]*Actor
+	clients map[string]*ChatClient
+}
+
+func NewChatServer(opts ChatOptions) *ChatServer {
+	s := &ChatServer{
+		opts:    opts,
+		broker:  NewChatBroker(opts.MaxDrops),
+		rooms:   make(map[string]*Actor),
+		clients: make(map[string]*ChatClient),
+	}
+
+	s.shutdown.Register("stop accepting", func(ctx context.Context) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		return nil
+	})
+	s.shutdown.Register("drain rooms", s.drainRooms)
+	s.shutdown.Register("close connections", func(ctx context.Context) error {
+		s.broker.Close(s.clientList())
+		return s.waitClients(ctx)
+	})
+	return s
+}
+
+// Connect registers a client; slow clients take delay to process each event
+func (s *ChatServer) Connect(name string, delay time.Duration) (*ChatClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrServerClosed
+	}
+	if _, exists := s.clients[name]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrNameTaken, name)
+	}
+
+	c := &ChatClient{
+		Name:    name,
+		inbox:   make(chan ChatEvent, s.opts.InboxSize),
+		limiter: newChatRateLimiter(s.opts.PostRate, s.opts.PostBurst),
+		delay:   delay,
+		done:    make(chan struct{}),
+	}
+	s.clients[name] = c
+	go c.read()
+	return c, nil
+}
+
+// room returns the actor for a room, creating it on first use
+func (s *ChatServer) room(name string) (*Actor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrServerClosed
+	}
+	if room, ok := s.rooms[name]; ok {
+		return room, nil
+	}
+
+	room := NewActor("room-" + name)
+	members := make(map[*ChatClient]bool) // Only touched by this actor's goroutine
+	seq := 0
+	publish := func(from, text string) {
+		seq++
+		s.broker.Publish(name, ChatEvent{Room: name, From: from, Text: text, Seq: seq})
+	}
+
+	room.RegisterHandler("join", func(msg Message) {
+		m := msg.(joinRoomMessage)
+		members[m.client] = true
+		s.broker.Subscribe(name, m.client)
+		publish("", m.client.Name+" joined")
+		m.reply <- nil
+	})
+	room.RegisterHandler("leave", func(msg Message) {
+		m := msg.(leaveRoomMessage)
+		delete(members, m.client)
+		s.broker.Unsubscribe(name, m.client)
+		publish("", m.client.Name+" left")
+	})
+	room.RegisterHandler("post", func(msg Message) {
+		m := msg.(postMessage)
+		if !members[m.client] {
+			m.reply <- fmt.Errorf("%w: %s", ErrNotMember, name)
+			return
+		}
+		publish(m.client.Name, m.text)
+		m.reply <- nil
+	})
+
+	room.Start()
+	s.rooms[name] = room
+	return room, nil
+}
+
+// Join adds the client to a room
+func (s *ChatServer) Join(c *ChatClient, roomName string) error {
+	room, err := s.room(roomName)
+	if err != nil {
+		return err
+	}
+	reply := make(chan error, 1)
+	room.Send(joinRoomMessage{client: c, reply: reply})
+	return <-reply
+}
+
+// Leave removes the client from a room
+func (s *ChatServer) Leave(c *ChatClient, roomName string) error {
+	room, err := s.room(roomName)
+	if err != nil {
+		return err
+	}
+	room.Send(leaveRoomMessage{client: c})
+	return nil
+}
+
+// Post broadcasts text to a room the client has joined
+func (s *ChatServer) Post(c *ChatClient, roomName, text string) error {
+	if !c.limiter.Allow() {
+		return ErrRateLimited
+	}
+	room, err := s.room(roomName)
+	if err != nil {
+		return err
+	}
+	reply := make(chan error, 1)
+	room.Send(postMessage{client: c, text: text, reply: reply})
+	return <-reply
+}
+
+// drainRooms lets every room finish its queued messages, then stops it
+func (s *ChatServer) drainRooms(ctx context.Context) error {
+	s.mu.Lock()
+	rooms := make([]*Actor, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, room := range rooms {
+		if err := room.StopGracefully(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ChatServer) clientList() []*ChatClient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clients := make([]*ChatClient, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, c)
+	}
+	return clients
+}
+
+// waitClients waits for readers to process what is left in their inboxes
+func (s *ChatServer) waitClients(ctx context.Context) error {
+	for _, c := range s.clientList() {
+		select {
+		case <-c.Done():
+		case <-ctx.Done():
+			return fmt.Errorf("client %s still draining: %w", c.Name, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// Shutdown stops accepting work, drains rooms and closes client connections
+func (s *ChatServer) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.DrainDeadline)
+	defer cancel()
+	return s.shutdown.Shutdown(ctx)
+}
+
+func defaultChatOptions() ChatOptions {
+	return ChatOptions{InboxSize: 16, MaxDrops: 5, PostRate: 50, PostBurst: 10, DrainDeadline: time.Second}
+}
+
+func printTranscript(c *ChatClient) {
+	events := c.Received()
+	fmt.Printf("%s received %d events:\n", c.Name, len(events))
+	for _, e := range events {
+		fmt.Printf("  %v\n", e)
+	}
+}
+
+// ChatServerExamples runs all chat server examples
+func ChatServerExamples() {
+	fmt.Println("=== Chat Server Capstone Examples ===")
+
+	// Example 1: Rooms, joins and broadcasts
+	chatRoomsExample()
+
+	// Example 2: Per-client rate limiting
+	chatRateLimitExample()
+
+	// Example 3: Slow clients are dropped, not waited for
+	chatSlowClientExample()
+
+	fmt.Println("\nThe end-to-end integration test lives in chat_server_test.go:")
+	fmt.Println("  go test -race -run TestChatServer ./01_concurrency")
+}
+
+// Example 1: Rooms, joins and broadcasts
+func chatRoomsExample() {
+	fmt.Println("\n--- Example 1: Rooms, Joins and Broadcasts ---")
+
+	server := NewChatServer(defaultChatOptions())
+	alice, _ := server.Connect("alice", 0)
+	bob, _ := server.Connect("bob", 0)
+	carol, _ := server.Connect("carol", 0)
+
+	server.Join(alice, "general")
+	server.Join(bob, "general")
+	server.Join(carol, "random")
+
+	server.Post(alice, "general", "hi bob")
+	server.Post(bob, "general", "hey alice")
+	server.Post(carol, "random", "anyone here?")
+	fmt.Printf("carol posting to a room she never joined: %v\n", server.Post(carol, "general", "hello?"))
+
+	server.Leave(bob, "general")
+	server.Post(alice, "general", "bye")
+
+	if err := server.Shutdown(); err != nil {
+		fmt.Printf("Shutdown error: %v\n", err)
+	}
+	for _, c := range []*ChatClient{alice, bob, carol} {
+		printTranscript(c)
+	}
+}
+
+// Example 2: Per-client rate limiting
+func chatRateLimitExample() {
+	fmt.Println("\n--- Example 2: Per-Client Rate Limiting ---")
+
+	opts := defaultChatOptions()
+	opts.PostBurst, opts.PostRate = 3, 20
+	server := NewChatServer(opts)
+	spammer, _ := server.Connect("spammer", 0)
+	server.Join(spammer, "general")
+
+	var results []string
+	for i := 1; i <= 8; i++ {
+		if err := server.Post(spammer, "general", fmt.Sprintf("buy now #%d", i)); err != nil {
+			results = append(results, "✗")
+		} else {
+			results = append(results, "✓")
+		}
+	}
+	fmt.Printf("Burst of 8 posts (burst=3): %s\n", strings.Join(results, " "))
+
+	time.Sleep(110 * time.Millisecond) // Refills about two tokens at 20/s
+	fmt.Printf("After 110ms: %v, %v, %v\n",
+		server.Post(spammer, "general", "a"), server.Post(spammer, "general", "b"), server.Post(spammer, "general", "c"))
+
+	server.Shutdown()
+}
+
+// Example 3: Slow clients are dropped, not waited for
+func chatSlowClientExample() {
+	fmt.Println("\n--- Example 3: Slow Clients Are Dropped, Not Waited For ---")
+
+	opts := defaultChatOptions()
+	opts.InboxSize, opts.MaxDrops, opts.PostBurst, opts.PostRate = 4, 5, 100, 1000
+	server := NewChatServer(opts)
+
+	fast, _ := server.Connect("fast", 0)
+	slow, _ := server.Connect("slowpoke", 20*time.Millisecond)
+	server.Join(fast, "general")
+	server.Join(slow, "general")
+
+	start := time.Now()
+	for i := 1; i <= 30; i++ {
+		server.Post(fast, "general", fmt.Sprintf("msg %d", i))
+	}
+	fmt.Printf("30 posts broadcast in %v (the slow reader never blocks the room)\n",
+		time.Since(start).Round(time.Millisecond))
+
+	<-slow.Done()
+	fmt.Printf("fast received %d events, slowpoke received %d, dropped %d, disconnected=%v\n",
+		len(fast.Received()), len(slow.Received()), slow.dropped.Load(), slow.disconnected.Load())
+
+	server.Shutdown()
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Race suite: each classic data race comes as a buggy and a fixed variant.
+// By default only the fixes run, so the whole program stays clean under the
+// race detector. Buggy variants run on request:
+//
+//	go run -race . 18                     # all fixes, must report no races
+//	go run -race . 18 lazyinit -buggy     # one bug, exits with status 66
+//	go run -race . 18 all -buggy          # every bug
+//
+// race_suite_test.go runs the same cases under go test: the fixes as
+// ordinary tests, the bugs only with the -buggy test flag.
+//
+// Without -race most bugs still "work" most of the time, which is exactly why
+// they survive code review.
+
+// raceOutcome is what a variant observed, and whether that is the right answer
+type raceOutcome struct {
+	result  string
+	correct bool
+}
+
+// raceCase pairs a racy implementation with its fix
+type raceCase struct {
+	name  string
+	title string
+	bug   string // Why the buggy variant races
+	fix   string // What the fixed variant does instead
+	buggy func() raceOutcome
+	fixed func() raceOutcome
+}
+
+// raceWorkers is how many goroutines each variant starts
+const raceWorkers = 50
+
+var raceCases = []raceCase{
+	{
+		name:  "lazyinit",
+		title: "Lazy Initialization",
+		bug:   "check-then-set on a shared variable; several goroutines can see nil and load",
+		fix:   "sync.OnceValue runs the loader exactly once and publishes the result safely",
+		buggy: lazyInitBuggy,
+		fixed: lazyInitFixed,
+	},
+	{
+		name:  "dcl",
+		title: "Double-Checked Locking",
+		bug:   "the unlocked fast-path read races with the write made under the lock",
+		fix:   "the fast path loads an atomic.Pointer, which pairs with the locked store",
+		buggy: doubleCheckedBuggy,
+		fixed: doubleCheckedFixed,
+	},
+	{
+		name:  "append",
+		title: "Slice Append",
+		bug:   "append reads and writes the slice header; concurrent appends lose elements",
+		fix:   "each goroutine writes its own preallocated index, so nothing is shared",
+		buggy: sliceAppendBuggy,
+		fixed: sliceAppendFixed,
+	},
+	{
+		name:  "closure",
+		title: "Closure Capture in Loops",
+		bug:   "with go < 1.22 semantics every closure shares one loop variable",
+		fix:   "pass the loop variable as an argument so each goroutine gets a copy",
+		buggy: closureCaptureBuggy,
+		fixed: closureCaptureFixed,
+	},
+	{
+		name:  "counter",
+		title: "Unsynchronized Counter",
+		bug:   "count++ is a read-modify-write; concurrent increments overwrite each other",
+		fix:   "atomic.Int64.Add makes each increment indivisible",
+		buggy: counterBuggy,
+		fixed: counterFixed,
+	},
+	{
+		name:  "map",
+		title: "Concurrent Map Writes",
+		bug:   "maps are not safe for concurrent writes; the runtime may abort the program",
+		fix:   "a mutex serializes writers (sync.Map suits append-only key sets)",
+		buggy: mapWritesBuggy,
+		fixed: mapWritesFixed,
+	},
+}
+
+// ==========================================
+// Lazy Initialization
+// ==========================================
+
+type raceConfig struct {
+	endpoint string
+}
+
+// loadRaceConfig simulates an expensive load and counts how often it runs
+func loadRaceConfig(loads *atomic.Int32) *raceConfig {
+	loads.Add(1)
+	time.Sleep(time.Millisecond)
+	return &raceConfig{endpoint: "https://config.local"}
+}
+
+func lazyInitBuggy() raceOutcome {
+	var loads atomic.Int32
+	var config *raceConfig
+	get := func() *raceConfig {
+		if config == nil { // Racy read
+			config = loadRaceConfig(&loads) // Racy write
+		}
+		return config
+	}
+
+	runConcurrently(func(int) { get() })
+	return raceOutcome{fmt.Sprintf("loaded %d time(s)", loads.Load()), loads.Load() == 1}
+}
+
+func lazyInitFixed() raceOutcome {
+	var loads atomic.Int32
+	get := sync.OnceValue(func() *raceConfig { return loadRaceConfig(&loads) })
+
+	runConcurrently(func(int) { get() })
+	return raceOutcome{fmt.Sprintf("loaded %d time(s)", loads.Load()), loads.Load() == 1}
+}
+
+// ==========================================
+// Double-Checked Locking
+// ==========================================
+
+func doubleCheckedBuggy() raceOutcome {
+	var loads atomic.Int32
+	var mu sync.Mutex
+	var instance *raceConfig
+	get := func() *raceConfig {
+		if instance == nil { // Unlocked read, unordered with the write below
+			mu.Lock()
+			if instance == nil {
+				instance = loadRaceConfig(&loads)
+			}
+			mu.Unlock()
+		}
+		return instance
+	}
+
+	runConcurrently(func(int) { get() })
+	// Loads once even when racy: the bug is that a reader may observe the
+	// pointer before the fields it points to, which no count can show
+	return raceOutcome{fmt.Sprintf("loaded %d time(s)", loads.Load()), loads.Load() == 1}
+}
+
+func doubleCheckedFixed() raceOutcome {
+	var loads atomic.Int32
+	var mu sync.Mutex
+	var instance atomic.Pointer[raceConfig]
+	get := func() *raceConfig {
+		if c := instance.Load(); c != nil {
+			return c
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if c := instance.Load(); c != nil {
+			return c
+		}
+		c := loadRaceConfig(&loads)
+		instance.Store(c)
+		return c
+	}
+
+	runConcurrently(func(int) { get() })
+	return raceOutcome{fmt.Sprintf("loaded %d time(s)", loads.Load()), loads.Load() == 1}
+}
+
+// ==========================================
+// Slice Append
+// ==========================================
+
+func sliceAppendBuggy() raceOutcome {
+	var results []int
+	runConcurrently(func(i int) {
+		results = append(results, i*i)
+	})
+	return raceOutcome{fmt.Sprintf("%d of %d results kept", len(results), raceWorkers), len(results) == raceWorkers}
+}
+
+func sliceAppendFixed() raceOutcome {
+	results := make([]int, raceWorkers)
+	runConcurrently(func(i int) {
+		results[i] = i * i // Distinct elements are distinct memory locations
+	})
+	return raceOutcome{fmt.Sprintf("%d of %d results kept", len(results), raceWorkers), len(results) == raceWorkers}
+}
+
+// ==========================================
+// Closure Capture in Loops
+// ==========================================
+
+func closureCaptureBuggy() raceOutcome {
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var wg sync.WaitGroup
+	launched := 0
+	for i := 0; i < raceWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			seen[i] = true // Reads the shared i while the loop increments it
+			mu.Unlock()
+		}()
+		// go vet only inspects a go statement that ends the loop body, so this
+		// line hides the bug from vet; the race detector still finds it
+		launched++
+	}
+	wg.Wait()
+	return raceOutcome{fmt.Sprintf("%d distinct values from %d goroutines", len(seen), launched), len(seen) == raceWorkers}
+}
+
+func closureCaptureFixed() raceOutcome {
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var wg sync.WaitGroup
+	launched := 0
+	for i := 0; i < raceWorkers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			mu.Lock()
+			seen[i] = true
+			mu.Unlock()
+		}(i)
+		launched++
+	}
+	wg.Wait()
+	return raceOutcome{fmt.Sprintf("%d distinct values from %d goroutines", len(seen), launched), len(seen) == raceWorkers}
+}
+
+// ==========================================
+// Unsynchronized Counter
+// ==========================================
+
+func counterBuggy() raceOutcome {
+	count := 0
+	runConcurrently(func(int) {
+		for j := 0; j < 1000; j++ {
+			count++
+		}
+	})
+	return raceOutcome{fmt.Sprintf("count=%d (want %d)", count, raceWorkers*1000), count == raceWorkers*1000}
+}
+
+func counterFixed() raceOutcome {
+	var count atomic.Int64
+	runConcurrently(func(int) {
+		for j := 0; j < 1000; j++ {
+			count.Add(1)
+		}
+	})
+	return raceOutcome{fmt.Sprintf("count=%d (want %d)", count.Load(), raceWorkers*1000), count.Load() == raceWorkers*1000}
+}
+
+// ==========================================
+// Concurrent Map Writes
+// ==========================================
+
+func mapWritesBuggy() raceOutcome {
+	hits := make(map[string]int)
+	runConcurrently(func(i int) {
+		hits[fmt.Sprintf("page-%d", i%5)]++
+	})
+	return raceOutcome{fmt.Sprintf("%d total hits", sumHits(hits)), sumHits(hits) == raceWorkers}
+}
+
+func mapWritesFixed() raceOutcome {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	runConcurrently(func(i int) {
+		key := fmt.Sprintf("page-%d", i%5)
+		mu.Lock()
+		hits[key]++
+		mu.Unlock()
+	})
+	return raceOutcome{fmt.Sprintf("%d total hits", sumHits(hits)), sumHits(hits) == raceWorkers}
+}
+
+func sumHits(hits map[string]int) int {
+	total := 0
+	for _, n := range hits {
+		total += n
+	}
+	return total
+}
+
+// ==========================================
+// Suite Runner
+// ==========================================
+
+// runConcurrently starts raceWorkers goroutines released at the same moment,
+// which makes the interleavings the bugs depend on far more likely
+func runConcurrently(fn func(i int)) {
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < raceWorkers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+}
+
+func runRaceCase(c raceCase, buggy bool) bool {
+	variant, fn, why := "fixed", c.fixed, c.fix
+	if buggy {
+		variant, fn, why = "buggy", c.buggy, c.bug
+	}
+	fmt.Printf("\n--- %s (%s, %s) ---\n", c.title, c.name, variant)
+	fmt.Printf("Why: %s\n", why)
+
+	outcome := fn()
+	verdict := "PASS"
+	switch {
+	case !outcome.correct:
+		verdict = "WRONG RESULT"
+	case buggy:
+		verdict = "looks right (the race is still there)"
+	}
+	fmt.Printf("Result: %s -> %s\n", outcome.result, verdict)
+	return outcome.correct
+}
+
+// RaceSuiteExamples runs the fixed variants, or the buggy ones with -buggy.
+// Arguments after the example number select a case: 18 [case|all] [-buggy].
+func RaceSuiteExamples() {
+	fmt.Println("=== Race Detector Suite Examples ===")
+
+	flags := flag.NewFlagSet("race suite", flag.ContinueOnError)
+	buggy := flags.Bool("buggy", false, "run the racy variants instead of the fixes")
+
+	// Accept the case name before or after the flag
+	var args []string
+	for _, arg := range os.Args[2:] {
+		if strings.HasPrefix(arg, "-") {
+			args = append([]string{arg}, args...)
+		} else {
+			args = append(args, arg)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return
+	}
+
+	selected := "all"
+	if flags.NArg() > 0 {
+		selected = flags.Arg(0)
+	}
+
+	var names []string
+	var cases []raceCase
+	for _, c := range raceCases {
+		names = append(names, c.name)
+		if selected == "all" || selected == c.name {
+			cases = append(cases, c)
+		}
+	}
+	if len(cases) == 0 {
+		sort.Strings(names)
+		fmt.Printf("Unknown case %q, choose one of: all, %s\n", selected, strings.Join(names, ", "))
+		return
+	}
+
+	if raceDetectorEnabled {
+		fmt.Println("Race detector: ON")
+	} else {
+		fmt.Println("Race detector: OFF (rerun with go run -race to see the reports)")
+	}
+
+	passed := 0
+	for _, c := range cases {
+		if runRaceCase(c, *buggy) {
+			passed++
+		}
+	}
+
+	fmt.Printf("\n%d/%d variants produced the right result\n", passed, len(cases))
+	if *buggy {
+		fmt.Println("Under -race the program exits with status 66 once any race was reported.")
+	} else {
+		fmt.Println("Try a buggy variant: go run -race . 18 <case> -buggy")
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Buffer sizing examples: the same producer/consumer workload run across
+// channel capacities, measuring what a buffer actually buys. A buffer absorbs
+// variation between producer and consumer; it never adds capacity, so when
+// the consumer is simply slower it only adds queueing delay.
+
+// unboundedCapacity marks the configuration that uses an unbounded queue
+const unboundedCapacity = -1
+
+// bufferWorkload describes how long each side spends per item
+type bufferWorkload struct {
+	name    string
+	items   int
+	produce func(i int, rng *rand.Rand) time.Duration // Pause before sending item i
+	consume func(rng *rand.Rand) time.Duration        // Work per received item
+}
+
+// BufferRunStats is the outcome of one workload at one capacity
+type BufferRunStats struct {
+	Capacity   int
+	Elapsed    time.Duration
+	Throughput float64       // Items per second
+	P50, P99   time.Duration // Time from send to receive
+	MaxWait    time.Duration
+	Blocked    time.Duration // Total time the producer spent blocked in send
+	Idle       time.Duration // Total time the consumer spent waiting for items
+	MaxDepth   int           // Most items queued at once
+}
+
+func (s BufferRunStats) label() string {
+	if s.Capacity == unboundedCapacity {
+		return "unbounded"
+	}
+	return fmt.Sprintf("%d", s.Capacity)
+}
+
+// timedItem carries its send time so the consumer can measure queueing delay
+type timedItem struct {
+	sent time.Time
+}
+
+// unboundedQueue forwards in to the returned channel through a growable
+// slice, so sends on in never wait for the consumer. depth reports the queue
+// length after every change.
+func unboundedQueue(in <-chan timedItem, depth func(int)) <-chan timedItem {
+	out := make(chan timedItem)
+	go func() {
+		defer close(out)
+		var queue []timedItem
+		for in != nil || len(queue) > 0 {
+			// A nil channel disables its select case
+			var send chan timedItem
+			var next timedItem
+			if len(queue) > 0 {
+				send, next = out, queue[0]
+			}
+			select {
+			case item, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queue = append(queue, item)
+			case send <- next:
+				queue = queue[1:]
+			}
+			depth(len(queue))
+		}
+	}()
+	return out
+}
+
+// runBufferWorkload pushes the workload through one channel configuration
+func runBufferWorkload(w bufferWorkload, capacity int) BufferRunStats {
+	var mu sync.Mutex
+	maxDepth := 0
+	recordDepth := func(d int) {
+		mu.Lock()
+		maxDepth = max(maxDepth, d)
+		mu.Unlock()
+	}
+
+	var send chan<- timedItem
+	var recv <-chan timedItem
+	if capacity == unboundedCapacity {
+		in := make(chan timedItem)
+		send, recv = in, unboundedQueue(in, recordDepth)
+	} else {
+		ch := make(chan timedItem, capacity)
+		send, recv = ch, ch
+	}
+
+	start := time.Now()
+	var blocked time.Duration
+	go func() {
+		defer close(send)
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < w.items; i++ {
+			time.Sleep(w.produce(i, rng))
+			t0 := time.Now()
+			send <- timedItem{sent: t0}
+			blocked += time.Since(t0)
+			if capacity != unboundedCapacity {
+				recordDepth(len(send))
+			}
+		}
+	}()
+
+	rng := rand.New(rand.NewSource(2))
+	waits := make([]time.Duration, 0, w.items)
+	var idle time.Duration
+	for {
+		t0 := time.Now()
+		item, ok := <-recv
+		if !ok {
+			break
+		}
+		idle += time.Since(t0)
+		waits = append(waits, time.Since(item.sent))
+		time.Sleep(w.consume(rng))
+	}
+	elapsed := time.Since(start)
+
+	// The producer closed send before recv drained, so blocked is final
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	return BufferRunStats{
+		Capacity:   capacity,
+		Elapsed:    elapsed,
+		Throughput: float64(w.items) / elapsed.Seconds(),
+		P50:        waits[len(waits)*50/100],
+		P99:        waits[len(waits)*99/100],
+		MaxWait:    waits[len(waits)-1],
+		Blocked:    blocked,
+		Idle:       idle,
+		MaxDepth:   maxDepth,
+	}
+}
+
+// bufferCapacities are the configurations every workload is run against
+var bufferCapacities = []int{0, 1, 16, 256, unboundedCapacity}
+
+// compareBufferSizes runs a workload at every capacity and prints a table
+func compareBufferSizes(w bufferWorkload) []BufferRunStats {
+	fmt.Printf("Workload: %s, %d items\n", w.name, w.items)
+	fmt.Printf("%-10s %9s %9s %9s %9s %10s %10s %6s\n",
+		"buffer", "items/s", "p50 wait", "p99 wait", "max wait", "prod.block", "cons.idle", "depth")
+
+	results := make([]BufferRunStats, 0, len(bufferCapacities))
+	for _, capacity := range bufferCapacities {
+		s := runBufferWorkload(w, capacity)
+		results = append(results, s)
+		fmt.Printf("%-10s %9.0f %9v %9v %9v %10v %10v %6d\n", s.label(), s.Throughput,
+			roundMs(s.P50), roundMs(s.P99), roundMs(s.MaxWait), roundMs(s.Blocked), roundMs(s.Idle), s.MaxDepth)
+	}
+	return results
+}
+
+func roundMs(d time.Duration) time.Duration {
+	return d.Round(100 * time.Microsecond)
+}
+
+// smallestSufficientBuffer picks the smallest capacity whose throughput is
+// within tolerance of the best one
+func smallestSufficientBuffer(results []BufferRunStats, tolerance float64) BufferRunStats {
+	best := 0.0
+	for _, s := range results {
+		best = max(best, s.Throughput)
+	}
+	for _, s := range results {
+		if s.Throughput >= best*(1-tolerance) {
+			return s
+		}
+	}
+	return results[len(results)-1]
+}
+
+// BufferSizingExamples measures channel capacities under three workloads
+func BufferSizingExamples() {
+	fmt.Println("=== Channel Buffer Sizing Examples ===")
+
+	// Example 1: Jittery but balanced producer and consumer
+	bufferJitterExample()
+
+	// Example 2: Bursty producer
+	bufferBurstExample()
+
+	// Example 3: Consumer slower than producer
+	bufferOverloadExample()
+}
+
+// jitter returns a duration uniformly spread around mean by ±spread
+func jitter(rng *rand.Rand, mean, spread time.Duration) time.Duration {
+	return mean - spread + time.Duration(rng.Int63n(int64(2*spread)+1))
+}
+
+// Example 1: Jittery but balanced producer and consumer
+func bufferJitterExample() {
+	fmt.Println("\n--- Example 1: Balanced Rates With Jitter ---")
+
+	results := compareBufferSizes(bufferWorkload{
+		name:    "both sides 1ms ± 0.9ms",
+		items:   150,
+		produce: func(_ int, rng *rand.Rand) time.Duration { return jitter(rng, time.Millisecond, 900*time.Microsecond) },
+		consume: func(rng *rand.Rand) time.Duration { return jitter(rng, time.Millisecond, 900*time.Microsecond) },
+	})
+
+	pick := smallestSufficientBuffer(results, 0.05)
+	fmt.Printf("Smallest buffer within 5%% of the best throughput: %s\n", pick.label())
+	fmt.Println("Unbuffered, each side waits out the other's slow items; a small buffer lets fast items make up for slow ones")
+}
+
+// Example 2: Bursty producer
+func bufferBurstExample() {
+	fmt.Println("\n--- Example 2: Bursty Producer ---")
+
+	results := compareBufferSizes(bufferWorkload{
+		name:  "bursts of 20 items then 30ms quiet, consumer 1ms per item",
+		items: 140,
+		produce: func(i int, _ *rand.Rand) time.Duration {
+			if i%20 == 0 && i > 0 {
+				return 30 * time.Millisecond // Quiet gap after each burst
+			}
+			return 0
+		},
+		consume: func(_ *rand.Rand) time.Duration { return time.Millisecond },
+	})
+
+	pick := smallestSufficientBuffer(results, 0.05)
+	fmt.Printf("Smallest buffer within 5%% of the best throughput: %s (a burst is 20 items)\n", pick.label())
+	fmt.Println("Unbuffered, the producer sits out every burst before its quiet gap; a buffer near the burst size frees it, larger ones add nothing")
+}
+
+// Example 3: Consumer slower than producer
+func bufferOverloadExample() {
+	fmt.Println("\n--- Example 3: Consumer Slower Than Producer ---")
+
+	results := compareBufferSizes(bufferWorkload{
+		name:    "producer as fast as possible, consumer 1ms per item",
+		items:   150,
+		produce: func(_ int, _ *rand.Rand) time.Duration { return 0 },
+		consume: func(_ *rand.Rand) time.Duration { return time.Millisecond },
+	})
+
+	first, last := results[0], results[len(results)-1]
+	fmt.Printf("Throughput unbuffered vs unbounded: %.0f vs %.0f items/s\n", first.Throughput, last.Throughput)
+	fmt.Printf("p99 wait unbuffered vs unbounded: %v vs %v, queue depth reached %d\n",
+		roundMs(first.P99), roundMs(last.P99), last.MaxDepth)
+	fmt.Println("The consumer is the bottleneck: buffering only turns producer blocking (backpressure) into queueing delay and memory")
+}
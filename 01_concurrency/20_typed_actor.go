@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/actor"
+)
+
+// Typed actor examples: actor.Actor[M] from internal/actor takes one message
+// type, so handlers receive M directly instead of asserting a Message. The
+// bridge functions below connect typed actors to the untyped Actor from
+// 07_actor.go.
+
+// BridgeToTyped registers handlers on an untyped actor that forward the given
+// message types to a typed one. The type assertion happens once, here; a
+// message that is not an M is reported instead of reaching the typed handler.
+func BridgeToTyped[M Message](from *Actor, to *actor.Actor[M], msgTypes ...string) {
+	for _, msgType := range msgTypes {
+		from.RegisterHandler(msgType, func(msg Message) {
+			typed, ok := msg.(M)
+			if !ok {
+				fmt.Printf("Bridge %s -> %s: %T is not a %T\n", from.ID, to.ID(), msg, typed)
+				return
+			}
+			if err := to.Send(typed); err != nil {
+				fmt.Printf("Bridge %s -> %s: %v\n", from.ID, to.ID(), err)
+			}
+		})
+	}
+}
+
+// ForwardToUntyped returns a typed handler that passes every message on to an
+// untyped actor, for typed actors whose messages also implement Message
+func ForwardToUntyped[M Message](to *Actor) func(M) {
+	return func(msg M) {
+		to.Send(msg)
+	}
+}
+
+// TypedActorExamples runs all typed actor examples
+func TypedActorExamples() {
+	fmt.Println("=== Typed Actor Examples ===")
+
+	// Example 1: Typed mailbox and handler
+	typedMailboxExample()
+
+	// Example 2: Typed request/reply
+	typedAskExample()
+
+	// Example 3: Bridging typed and untyped actors
+	typedBridgeExample()
+}
+
+// account commands for the typed bank actor; one struct, no assertions
+type accountOp int
+
+const (
+	opDeposit accountOp = iota
+	opWithdraw
+)
+
+type accountCommand struct {
+	Op      accountOp
+	Account string
+	Amount  int
+}
+
+// Example 1: Typed mailbox and handler
+func typedMailboxExample() {
+	fmt.Println("\n--- Example 1: Typed Mailbox and Handler ---")
+
+	balances := make(map[string]int)
+	var processed sync.WaitGroup
+	bank := actor.New("bank", 16, func(cmd accountCommand) {
+		defer processed.Done()
+		// cmd is already an accountCommand: compare with the
+		// msg.(BankMessage) check at the top of Example 8's handler
+		switch cmd.Op {
+		case opDeposit:
+			balances[cmd.Account] += cmd.Amount
+		case opWithdraw:
+			if balances[cmd.Account] < cmd.Amount {
+				fmt.Printf("Bank: %s cannot withdraw %d (balance %d)\n", cmd.Account, cmd.Amount, balances[cmd.Account])
+				return
+			}
+			balances[cmd.Account] -= cmd.Amount
+		}
+	})
+	bank.Start()
+
+	commands := []accountCommand{
+		{Op: opDeposit, Account: "ACC001", Amount: 1000},
+		{Op: opDeposit, Account: "ACC002", Amount: 500},
+		{Op: opWithdraw, Account: "ACC001", Amount: 300},
+		{Op: opWithdraw, Account: "ACC002", Amount: 800},
+	}
+	processed.Add(len(commands))
+	for _, cmd := range commands {
+		bank.Send(cmd)
+	}
+	// bank.Send(StringMessage{Content: "hi"}) would not compile:
+	// cannot use StringMessage{…} as accountCommand value
+	processed.Wait()
+	bank.Stop()
+	fmt.Printf("Balances: ACC001=%d ACC002=%d\n", balances["ACC001"], balances["ACC002"])
+
+	err := bank.Send(accountCommand{Op: opDeposit, Account: "ACC001", Amount: 1})
+	fmt.Printf("Send after Stop: %v\n", err)
+}
+
+// Example 2: Typed request/reply
+func typedAskExample() {
+	fmt.Println("\n--- Example 2: Typed Request/Reply ---")
+
+	// The reply type is part of the actor's type: Ask returns an int, not an
+	// interface{} to assert, and no message struct carries a Response channel
+	stock := map[string]int{"bolts": 120, "nuts": 80}
+	inventory := actor.New("inventory", 16, func(req actor.Request[string, int]) {
+		if req.Body == "slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		req.Reply(stock[req.Body])
+	})
+	inventory.Start()
+	defer inventory.Stop()
+
+	for _, item := range []string{"bolts", "nuts", "gears"} {
+		count, err := actor.Ask(inventory, item, time.Second)
+		fmt.Printf("Ask %s: %d (err=%v)\n", item, count, err)
+	}
+	_, err := actor.Ask(inventory, "slow", 20*time.Millisecond)
+	fmt.Printf("Ask slow with 20ms timeout: %v (timeout=%v)\n", err, errors.Is(err, actor.ErrTimeout))
+}
+
+// Example 3: Bridging typed and untyped actors
+func typedBridgeExample() {
+	fmt.Println("\n--- Example 3: Bridging Typed and Untyped Actors ---")
+
+	// Untyped side: a dispatcher that receives WorkMessage among other types,
+	// and a collector that prints ResultMessage
+	dispatcher := NewActor("dispatcher")
+	collector := NewActor("collector")
+	var done sync.WaitGroup
+	collector.RegisterHandler("result", func(msg Message) {
+		result := msg.(ResultMessage)
+		fmt.Printf("Collector: job %d -> %s\n", result.ID, result.Result)
+		done.Done()
+	})
+
+	// Typed side: a worker that only ever sees WorkMessage and emits
+	// ResultMessage to the untyped collector
+	toCollector := ForwardToUntyped[ResultMessage](collector)
+	worker := actor.New("worker", 16, func(work WorkMessage) {
+		toCollector(ResultMessage{ID: work.ID, Result: fmt.Sprintf("%s done", work.Task)})
+	})
+	BridgeToTyped[WorkMessage](dispatcher, worker, "work")
+
+	collector.Start()
+	worker.Start()
+	dispatcher.Start()
+
+	done.Add(3)
+	for i, task := range []string{"resize", "encode", "upload"} {
+		dispatcher.Send(WorkMessage{ID: i + 1, Task: task})
+	}
+	done.Wait()
+
+	dispatcher.Stop()
+	worker.Stop()
+	collector.Stop()
+}
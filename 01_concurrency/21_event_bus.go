@@ -0,0 +1,434 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event bus examples: hierarchical topics, wildcard subscriptions, sync and
+// async dispatch, and error handling per subscriber
+
+var (
+	// ErrInvalidTopic is returned for empty topics or segments, and for
+	// wildcards in a published topic
+	ErrInvalidTopic = errors.New("event bus: invalid topic")
+	// ErrBusClosed is returned when publishing to or subscribing on a closed bus
+	ErrBusClosed = errors.New("event bus: closed")
+	// ErrEventQueueFull is reported to an async subscriber whose queue had
+	// no room for an event; the event is dropped for that subscriber only
+	ErrEventQueueFull = errors.New("event bus: subscriber queue full")
+)
+
+// Event is one published message. Topics are dot-separated hierarchies
+// such as "user.login" or "order.payment.failed".
+type Event struct {
+	Topic   string
+	Payload interface{}
+}
+
+// EventHandler handles an event; a returned error, or a panic, is passed to
+// the subscription's OnError instead of reaching the publisher
+type EventHandler func(Event) error
+
+// SubscribeOptions configures how a subscription receives events
+type SubscribeOptions struct {
+	// Async delivers events on the subscription's own goroutine through a
+	// queue of QueueSize, so a slow handler never holds up Publish. Sync
+	// subscriptions run inside Publish, in subscription order.
+	Async     bool
+	QueueSize int
+	// OnError receives the handler's errors and panics, and for async
+	// subscriptions ErrEventQueueFull; nil logs them
+	OnError func(Event, error)
+}
+
+// Subscription is a handler registered for a topic pattern. In a pattern,
+// "*" matches exactly one segment and "**" matches any number of them, so
+// "user.*" matches "user.login" but not "user.profile.updated", which
+// "user.**" does.
+type Subscription struct {
+	id      int
+	pattern []string
+	handler EventHandler
+	opts    SubscribeOptions
+	bus     *EventBus
+
+	// Async only: queue is closed under queueMu so Publish never sends on a
+	// closed channel
+	queue   chan Event
+	queueMu sync.RWMutex
+	closed  bool
+	done    chan struct{}
+}
+
+// Pattern returns the topic pattern the subscription was made with
+func (s *Subscription) Pattern() string {
+	return strings.Join(s.pattern, ".")
+}
+
+// Unsubscribe stops delivery to s. An async subscription first handles what
+// is already queued; Unsubscribe waits for that. Safe to call more than once,
+// but not from s's own async handler.
+func (s *Subscription) Unsubscribe() {
+	s.bus.remove(s)
+	s.stop()
+}
+
+// EventBus routes published events to every subscription whose pattern
+// matches the topic
+type EventBus struct {
+	mu     sync.Mutex
+	subs   []*Subscription // Copy-on-write: Publish reads a snapshot without locking
+	nextID int
+	closed bool
+}
+
+// NewEventBus creates an empty event bus
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe registers a synchronous handler for a topic pattern
+func (b *EventBus) Subscribe(pattern string, handler EventHandler) (*Subscription, error) {
+	return b.SubscribeWithOptions(pattern, handler, SubscribeOptions{})
+}
+
+// SubscribeWithOptions registers a handler for a topic pattern
+func (b *EventBus) SubscribeWithOptions(pattern string, handler EventHandler, opts SubscribeOptions) (*Subscription, error) {
+	segments, err := splitTopic(pattern, true)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrBusClosed
+	}
+
+	b.nextID++
+	s := &Subscription{id: b.nextID, pattern: segments, handler: handler, opts: opts, bus: b}
+	if opts.Async {
+		s.queue = make(chan Event, max(opts.QueueSize, 1))
+		s.done = make(chan struct{})
+		go s.run()
+	}
+
+	subs := make([]*Subscription, len(b.subs), len(b.subs)+1)
+	copy(subs, b.subs)
+	b.subs = append(subs, s)
+	return s, nil
+}
+
+// Publish delivers an event to every matching subscription and returns how
+// many matched. Handler failures go to each subscriber's OnError; the error
+// returned here is only about the topic or the bus.
+func (b *EventBus) Publish(topic string, payload interface{}) (int, error) {
+	segments, err := splitTopic(topic, false)
+	if err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return 0, ErrBusClosed
+	}
+	subs := b.subs
+	b.mu.Unlock()
+
+	event := Event{Topic: topic, Payload: payload}
+	matched := 0
+	for _, s := range subs {
+		if !matchTopic(s.pattern, segments) {
+			continue
+		}
+		matched++
+		if s.opts.Async {
+			s.enqueue(event)
+		} else {
+			s.handle(event)
+		}
+	}
+	return matched, nil
+}
+
+// Close unsubscribes everything, waiting for async subscribers to drain,
+// and rejects later Publish and Subscribe calls
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = nil
+	b.closed = true
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.stop()
+	}
+}
+
+// remove drops s from the bus's subscription list
+func (b *EventBus) remove(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*Subscription, 0, len(b.subs))
+	for _, other := range b.subs {
+		if other != s {
+			subs = append(subs, other)
+		}
+	}
+	b.subs = subs
+}
+
+// enqueue hands an event to an async subscription without blocking
+func (s *Subscription) enqueue(event Event) {
+	s.queueMu.RLock()
+	defer s.queueMu.RUnlock()
+	if s.closed {
+		return // Unsubscribed after Publish took its snapshot
+	}
+	select {
+	case s.queue <- event:
+	default:
+		s.fail(event, ErrEventQueueFull)
+	}
+}
+
+// run is an async subscription's goroutine
+func (s *Subscription) run() {
+	defer close(s.done)
+	for event := range s.queue {
+		s.handle(event)
+	}
+}
+
+// stop closes an async subscription's queue and waits for it to drain
+func (s *Subscription) stop() {
+	if !s.opts.Async {
+		return
+	}
+	s.queueMu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.queueMu.Unlock()
+	<-s.done
+}
+
+// handle runs the handler, turning an error or a panic into an OnError call
+func (s *Subscription) handle(event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.fail(event, fmt.Errorf("handler panicked: %v", r))
+		}
+	}()
+	if err := s.handler(event); err != nil {
+		s.fail(event, err)
+	}
+}
+
+// fail reports a delivery failure for this subscriber only
+func (s *Subscription) fail(event Event, err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(event, err)
+		return
+	}
+	fmt.Printf("EventBus: subscriber %d (%s) failed on %s: %v\n", s.id, s.Pattern(), event.Topic, err)
+}
+
+// splitTopic validates a topic, or a pattern when wildcards are allowed,
+// and splits it into segments
+func splitTopic(topic string, wildcards bool) ([]string, error) {
+	segments := strings.Split(topic, ".")
+	for _, seg := range segments {
+		if seg == "" || (!wildcards && (seg == "*" || seg == "**")) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+		}
+	}
+	return segments, nil
+}
+
+// matchTopic reports whether a topic's segments match a pattern's
+func matchTopic(pattern, topic []string) bool {
+	if len(pattern) == 0 {
+		return len(topic) == 0
+	}
+	switch pattern[0] {
+	case "**":
+		// Let ** swallow 0, 1, 2, ... segments
+		for i := 0; i <= len(topic); i++ {
+			if matchTopic(pattern[1:], topic[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(topic) > 0 && matchTopic(pattern[1:], topic[1:])
+	default:
+		return len(topic) > 0 && pattern[0] == topic[0] && matchTopic(pattern[1:], topic[1:])
+	}
+}
+
+// EventBusExamples runs all event bus examples
+func EventBusExamples() {
+	fmt.Println("=== Event Bus Examples ===")
+
+	// Example 1: Topic hierarchies and wildcards
+	eventBusWildcardExample()
+
+	// Example 2: Synchronous vs asynchronous dispatch
+	eventBusDispatchExample()
+
+	// Example 3: Per-subscriber error handling
+	eventBusErrorExample()
+
+	// Example 4: Unsubscribe and close
+	eventBusLifecycleExample()
+}
+
+// Example 1: Topic hierarchies and wildcards
+func eventBusWildcardExample() {
+	fmt.Println("\n--- Example 1: Topic Hierarchies and Wildcards ---")
+
+	bus := NewEventBus()
+	defer bus.Close()
+
+	for _, pattern := range []string{"user.login", "user.*", "user.**", "*.login", "**"} {
+		pattern := pattern
+		bus.Subscribe(pattern, func(e Event) error {
+			fmt.Printf("  %-10s <- %s\n", pattern, e.Topic)
+			return nil
+		})
+	}
+
+	for _, topic := range []string{"user.login", "user.profile.updated", "admin.login", "system"} {
+		fmt.Printf("Publish %s\n", topic)
+		matched, _ := bus.Publish(topic, nil)
+		fmt.Printf("  matched %d\n", matched)
+	}
+
+	_, err := bus.Publish("user.*", nil)
+	fmt.Printf("Publish with a wildcard: %v\n", err)
+	_, err = bus.Subscribe("user..login", func(Event) error { return nil })
+	fmt.Printf("Subscribe to an empty segment: %v\n", err)
+}
+
+// Example 2: Synchronous vs asynchronous dispatch
+func eventBusDispatchExample() {
+	fmt.Println("\n--- Example 2: Synchronous vs Asynchronous Dispatch ---")
+
+	bus := NewEventBus()
+	slowHandler := func(name string) EventHandler {
+		return func(e Event) error {
+			time.Sleep(50 * time.Millisecond) // Simulate slow work, e.g. sending an email
+			fmt.Printf("%s handled %s #%v\n", name, e.Topic, e.Payload)
+			return nil
+		}
+	}
+
+	// A sync subscriber runs inside Publish: the publisher pays for it
+	syncSub, _ := bus.Subscribe("order.created", slowHandler("sync"))
+	start := time.Now()
+	for i := 1; i <= 3; i++ {
+		bus.Publish("order.created", i)
+	}
+	fmt.Printf("3 publishes with a sync subscriber took %v\n", time.Since(start).Round(10*time.Millisecond))
+	syncSub.Unsubscribe()
+
+	// An async subscriber queues events and handles them in order on its
+	// own goroutine; Publish returns at once
+	bus.SubscribeWithOptions("order.created", slowHandler("async"), SubscribeOptions{Async: true, QueueSize: 10})
+	start = time.Now()
+	for i := 1; i <= 3; i++ {
+		bus.Publish("order.created", i)
+	}
+	fmt.Printf("3 publishes with an async subscriber took %v\n", time.Since(start).Round(10*time.Millisecond))
+
+	bus.Close() // Waits for the async subscriber to drain its queue
+	fmt.Printf("Close returned after %v\n", time.Since(start).Round(10*time.Millisecond))
+}
+
+// Example 3: Per-subscriber error handling
+func eventBusErrorExample() {
+	fmt.Println("\n--- Example 3: Per-Subscriber Error Handling ---")
+
+	bus := NewEventBus()
+
+	var mu sync.Mutex
+	var failures []string
+	record := func(name string) func(Event, error) {
+		return func(e Event, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, fmt.Sprintf("%s: %s: %v", name, e.Topic, err))
+		}
+	}
+
+	// One subscriber returns errors, one panics, one has a tiny queue; none
+	// of them stops the healthy subscriber or the publisher
+	bus.SubscribeWithOptions("payment.*", func(e Event) error {
+		if e.Payload.(int) > 100 {
+			return fmt.Errorf("amount %d over limit", e.Payload)
+		}
+		return nil
+	}, SubscribeOptions{OnError: record("validator")})
+	bus.SubscribeWithOptions("payment.*", func(e Event) error {
+		if e.Topic == "payment.refund" {
+			panic("refunds not implemented")
+		}
+		return nil
+	}, SubscribeOptions{OnError: record("ledger")})
+	block := make(chan struct{})
+	bus.SubscribeWithOptions("payment.*", func(e Event) error {
+		<-block // Stuck until released: its queue of 1 overflows
+		return nil
+	}, SubscribeOptions{Async: true, QueueSize: 1, OnError: record("auditor")})
+	healthy := 0
+	bus.Subscribe("payment.*", func(e Event) error {
+		healthy++
+		return nil
+	})
+	// No OnError: failures are logged by the bus
+	bus.Subscribe("payment.refund", func(e Event) error {
+		return errors.New("notification service down")
+	})
+
+	bus.Publish("payment.charge", 50)
+	bus.Publish("payment.charge", 500)
+	bus.Publish("payment.refund", 20)
+	close(block)
+	bus.Close()
+
+	fmt.Printf("Healthy subscriber handled %d of 3 events\n", healthy)
+	for _, f := range failures {
+		fmt.Println("  " + f)
+	}
+}
+
+// Example 4: Unsubscribe and close
+func eventBusLifecycleExample() {
+	fmt.Println("\n--- Example 4: Unsubscribe and Close ---")
+
+	bus := NewEventBus()
+	received := 0
+	sub, _ := bus.Subscribe("metrics.**", func(e Event) error {
+		received++
+		return nil
+	})
+
+	bus.Publish("metrics.cpu", 0.5)
+	sub.Unsubscribe()
+	sub.Unsubscribe() // Idempotent
+	matched, _ := bus.Publish("metrics.cpu", 0.7)
+	fmt.Printf("Received %d event(s); after Unsubscribe, %d subscriber(s) matched\n", received, matched)
+
+	bus.Close()
+	_, err := bus.Publish("metrics.cpu", 0.9)
+	fmt.Printf("Publish after Close: %v\n", err)
+	_, err = bus.Subscribe("metrics.*", func(Event) error { return nil })
+	fmt.Printf("Subscribe after Close: %v\n", err)
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pipeline examples: the stage-per-goroutine pattern of the CSP pipeline,
+// built from a reusable builder with cancellation and error reporting
+
+// ErrSkip, returned by a stage, drops the item without reporting an error;
+// it turns a stage into a filter
+var ErrSkip = errors.New("pipeline: skip item")
+
+// StageFunc processes one item of a pipeline stage
+type StageFunc[T any] func(ctx context.Context, item T) (T, error)
+
+// StageError reports the stage and item that failed
+type StageError[T any] struct {
+	Stage int    // Zero-based position in the pipeline
+	Name  string // Stage name, if given
+	Item  T      // The input the stage failed on
+	Err   error
+}
+
+func (e *StageError[T]) Error() string {
+	name := e.Name
+	if name == "" {
+		name = fmt.Sprintf("stage %d", e.Stage)
+	}
+	return fmt.Sprintf("%s failed on %v: %v", name, e.Item, e.Err)
+}
+
+func (e *StageError[T]) Unwrap() error {
+	return e.Err
+}
+
+type pipelineStage[T any] struct {
+	name    string
+	workers int
+	fn      StageFunc[T]
+}
+
+// Pipeline chains stages over a source channel. Each stage runs its own
+// workers, stages are linked by bounded channels, and every failure arrives
+// on one error channel as a *StageError[T].
+type Pipeline[T any] struct {
+	source      <-chan T
+	stages      []pipelineStage[T]
+	buffer      int
+	stopOnError bool
+}
+
+// NewPipeline creates a pipeline reading from source. Source must be closed
+// to end the run normally; cancelling the context also ends it.
+func NewPipeline[T any](source <-chan T) *Pipeline[T] {
+	return &Pipeline[T]{source: source, buffer: 1}
+}
+
+// Stage appends a stage run by workers goroutines. With more than one
+// worker, items may leave the stage in a different order.
+func (p *Pipeline[T]) Stage(workers int, fn StageFunc[T]) *Pipeline[T] {
+	return p.NamedStage("", workers, fn)
+}
+
+// NamedStage is Stage with a name used in errors
+func (p *Pipeline[T]) NamedStage(name string, workers int, fn StageFunc[T]) *Pipeline[T] {
+	p.stages = append(p.stages, pipelineStage[T]{name: name, workers: max(workers, 1), fn: fn})
+	return p
+}
+
+// Buffer sets the capacity of the channels between stages (default 1).
+// Small buffers keep memory bounded: a slow stage makes its upstream wait.
+func (p *Pipeline[T]) Buffer(n int) *Pipeline[T] {
+	p.buffer = max(n, 0)
+	return p
+}
+
+// StopOnError cancels the whole run at the first stage error instead of
+// dropping the failed item and carrying on
+func (p *Pipeline[T]) StopOnError() *Pipeline[T] {
+	p.stopOnError = true
+	return p
+}
+
+// Run starts the pipeline. Results arrive on the first channel and stage
+// errors on the second; both close when the run ends, and the caller must
+// drain both (or cancel ctx) so the workers are not left blocked.
+func (p *Pipeline[T]) Run(ctx context.Context) (<-chan T, <-chan error) {
+	ctx, cancel := context.WithCancel(ctx)
+	errs := make(chan error, p.buffer)
+	var wg sync.WaitGroup
+
+	// The source may never close; this stops reading it on cancellation
+	in := make(chan T, p.buffer)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(in)
+		for {
+			select {
+			case item, ok := <-p.source:
+				if !ok {
+					return
+				}
+				select {
+				case in <- item:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var stageIn <-chan T = in
+	for i, stage := range p.stages {
+		stageIn = p.runStage(ctx, cancel, i, stage, stageIn, errs, &wg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errs)
+		cancel()
+	}()
+	return stageIn, errs
+}
+
+// runStage starts a stage's workers and returns its output channel, closed
+// once every worker has finished
+func (p *Pipeline[T]) runStage(ctx context.Context, cancel context.CancelFunc, index int, stage pipelineStage[T], in <-chan T, errs chan<- error, wg *sync.WaitGroup) <-chan T {
+	out := make(chan T, p.buffer)
+	var workers sync.WaitGroup
+	workers.Add(stage.workers)
+	wg.Add(stage.workers)
+	for w := 0; w < stage.workers; w++ {
+		go func() {
+			defer wg.Done()
+			defer workers.Done()
+			for item := range in {
+				if ctx.Err() != nil {
+					continue // Cancelled: drain so upstream can finish
+				}
+				result, err := stage.fn(ctx, item)
+				if errors.Is(err, ErrSkip) || (err != nil && ctx.Err() != nil) {
+					continue // Filtered out, or failed because the run was cancelled
+				}
+				if err != nil {
+					select {
+					case errs <- &StageError[T]{Stage: index, Name: stage.name, Item: item, Err: err}:
+					case <-ctx.Done():
+					}
+					if p.stopOnError {
+						cancel()
+					}
+					continue
+				}
+				select {
+				case out <- result:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Collect runs the pipeline and gathers its results. The error joins every
+// stage error, plus ctx's error if the run was cancelled from outside.
+func (p *Pipeline[T]) Collect(ctx context.Context) ([]T, error) {
+	out, errs := p.Run(ctx)
+
+	var stageErrs []error
+	var errWG sync.WaitGroup
+	errWG.Add(1)
+	go func() {
+		defer errWG.Done()
+		for err := range errs {
+			stageErrs = append(stageErrs, err)
+		}
+	}()
+
+	var results []T
+	for item := range out {
+		results = append(results, item)
+	}
+	errWG.Wait()
+	if err := ctx.Err(); err != nil {
+		stageErrs = append(stageErrs, err)
+	}
+	return results, errors.Join(stageErrs...)
+}
+
+// sliceSource returns a closed channel holding items, for pipeline sources
+func sliceSource[T any](items ...T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+// PipelineExamples runs all pipeline examples
+func PipelineExamples() {
+	fmt.Println("=== Pipeline Examples ===")
+
+	// Example 1: Building a pipeline
+	basicPipelineExample()
+
+	// Example 2: Per-stage concurrency
+	concurrentStagePipelineExample()
+
+	// Example 3: Error aggregation
+	errorPipelineExample()
+
+	// Example 4: Cancellation
+	cancelPipelineExample()
+}
+
+// Example 1: Building a pipeline
+func basicPipelineExample() {
+	fmt.Println("\n--- Example 1: Building a Pipeline ---")
+
+	// Normalize words, drop short ones, then decorate them
+	words := sliceSource("  Go ", "is", "FUN", "and", "Concurrent  ", "ok")
+	results, err := NewPipeline(words).
+		Stage(1, func(_ context.Context, w string) (string, error) {
+			return strings.ToLower(strings.TrimSpace(w)), nil
+		}).
+		Stage(1, func(_ context.Context, w string) (string, error) {
+			if len(w) < 3 {
+				return "", ErrSkip
+			}
+			return w, nil
+		}).
+		Stage(1, func(_ context.Context, w string) (string, error) {
+			return "<" + w + ">", nil
+		}).
+		Collect(context.Background())
+
+	// One worker per stage keeps the source order
+	fmt.Printf("Results: %v (err=%v)\n", results, err)
+}
+
+// Example 2: Per-stage concurrency
+func concurrentStagePipelineExample() {
+	fmt.Println("\n--- Example 2: Per-Stage Concurrency ---")
+
+	ids := make([]int, 12)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	slow := func(_ context.Context, n int) (int, error) {
+		time.Sleep(50 * time.Millisecond) // Simulate I/O, e.g. a lookup per item
+		return n * 10, nil
+	}
+	fast := func(_ context.Context, n int) (int, error) {
+		return n + 1, nil
+	}
+
+	for _, workers := range []int{1, 4} {
+		start := time.Now()
+		results, _ := NewPipeline(sliceSource(ids...)).
+			Stage(workers, slow).
+			Stage(1, fast).
+			Buffer(4).
+			Collect(context.Background())
+		sort.Ints(results) // Several workers finish out of order
+		fmt.Printf("Slow stage with %d worker(s): %d results in %v, first %v\n",
+			workers, len(results), time.Since(start).Round(10*time.Millisecond), results[:3])
+	}
+}
+
+// Example 3: Error aggregation
+func errorPipelineExample() {
+	fmt.Println("\n--- Example 3: Error Aggregation ---")
+
+	parse := func(_ context.Context, s string) (string, error) {
+		if strings.ContainsAny(s, "xyz") {
+			return "", fmt.Errorf("malformed record %q", s)
+		}
+		return strings.ToUpper(s), nil
+	}
+	store := func(_ context.Context, s string) (string, error) {
+		if s == "DUP" {
+			return "", errors.New("duplicate key")
+		}
+		return s, nil
+	}
+	records := []string{"a", "bx", "dup", "c", "yy", "d"}
+
+	// Default: failed items are reported and the rest carry on
+	results, err := NewPipeline(sliceSource(records...)).
+		NamedStage("parse", 1, parse).
+		NamedStage("store", 1, store).
+		Collect(context.Background())
+	fmt.Printf("Stored %v\n", results)
+	fmt.Printf("Errors:\n  %s\n", strings.ReplaceAll(err.Error(), "\n", "\n  "))
+
+	var stageErr *StageError[string]
+	if errors.As(err, &stageErr) {
+		fmt.Printf("First failure: stage %d (%s), item %q\n", stageErr.Stage, stageErr.Name, stageErr.Item)
+	}
+
+	// StopOnError: the first failure cancels the run
+	results, err = NewPipeline(sliceSource(records...)).
+		NamedStage("parse", 1, parse).
+		NamedStage("store", 1, store).
+		StopOnError().
+		Collect(context.Background())
+	fmt.Printf("StopOnError: stored %v, err: %v\n", results, err)
+}
+
+// Example 4: Cancellation
+func cancelPipelineExample() {
+	fmt.Println("\n--- Example 4: Cancellation ---")
+
+	// An endless source: only cancellation can end this pipeline
+	source := make(chan int)
+	stopSource := make(chan struct{})
+	go func() {
+		defer close(source)
+		for i := 1; ; i++ {
+			select {
+			case source <- i:
+			case <-stopSource:
+				return
+			}
+		}
+	}()
+	defer close(stopSource)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	results, err := NewPipeline(source).
+		Stage(2, func(ctx context.Context, n int) (int, error) {
+			select {
+			case <-time.After(30 * time.Millisecond): // Work that honours ctx
+				return n * n, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}).
+		Collect(ctx) // Returns once every worker has stopped
+
+	fmt.Printf("Processed %d items before the deadline\n", len(results))
+	fmt.Printf("Deadline exceeded: %v\n", errors.Is(err, context.DeadlineExceeded))
+}
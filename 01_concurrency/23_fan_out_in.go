@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Fan-out/fan-in examples: spreading work over goroutines and merging the
+// results back, in completion order or in input order
+
+// FanResult is one output of FanOut. Index is the position of the input in
+// the source channel, which FanInOrdered uses to restore the input order.
+type FanResult[R any] struct {
+	Index int
+	Value R
+	Err   error
+}
+
+// FanOut starts workers goroutines that apply fn to the items of in and
+// returns one result channel per worker. Workers pull from in, so a slow
+// item does not hold up the others. Every channel closes once in is
+// exhausted or ctx is cancelled; merge them with FanIn or FanInOrdered.
+func FanOut[T, R any](ctx context.Context, in <-chan T, workers int, fn func(T) (R, error)) []<-chan FanResult[R] {
+	type job struct {
+		index int
+		item  T
+	}
+
+	// Number the items as they come in; the jobs channel is what workers share
+	jobs := make(chan job)
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			select {
+			case item, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- job{index, item}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	outs := make([]<-chan FanResult[R], max(workers, 1))
+	for w := range outs {
+		out := make(chan FanResult[R])
+		outs[w] = out
+		go func() {
+			defer close(out)
+			for j := range jobs {
+				value, err := fn(j.item)
+				select {
+				case out <- FanResult[R]{Index: j.index, Value: value, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	return outs
+}
+
+// FanIn merges channels into one, in whatever order values arrive. The
+// result closes once every input has closed or ctx is cancelled.
+func FanIn[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	merged := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for v := range ch {
+				select {
+				case merged <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+
+	// Close only after every forwarder has returned, so none sends on a
+	// closed channel; ranging over the inputs a second time here would
+	// steal values from the forwarders instead
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+	return merged
+}
+
+// FanInOrdered merges FanOut results and emits them in input order. Results
+// that finish early wait in a buffer until everything before them is out,
+// so one slow item delays, but never reorders, the rest.
+func FanInOrdered[R any](ctx context.Context, chans ...<-chan FanResult[R]) <-chan FanResult[R] {
+	ordered := make(chan FanResult[R])
+	go func() {
+		defer close(ordered)
+		pending := make(map[int]FanResult[R])
+		next := 0
+		for r := range FanIn(ctx, chans...) {
+			pending[r.Index] = r
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				select {
+				case ordered <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ordered
+}
+
+// FanOutInExamples runs all fan-out/fan-in examples
+func FanOutInExamples() {
+	fmt.Println("=== Fan-out/Fan-in Examples ===")
+
+	// Example 1: Completion order vs input order
+	fanOrderingExample()
+
+	// Example 2: Per-item errors
+	fanErrorsExample()
+
+	// Example 3: Cancellation
+	fanCancelExample()
+}
+
+// intSource sends 1..n on a channel and closes it
+func intSource(n int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= n; i++ {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+// Example 1: Completion order vs input order
+func fanOrderingExample() {
+	fmt.Println("\n--- Example 1: Completion Order vs Input Order ---")
+
+	// Earlier items take longer, so they finish last
+	slowFirst := func(n int) (int, error) {
+		time.Sleep(time.Duration(7-n) * 15 * time.Millisecond)
+		return n * n, nil
+	}
+	ctx := context.Background()
+
+	var unordered []int
+	for r := range FanIn(ctx, FanOut(ctx, intSource(6), 3, slowFirst)...) {
+		unordered = append(unordered, r.Value)
+	}
+	fmt.Printf("FanIn (completion order): %v\n", unordered)
+
+	var ordered []int
+	for r := range FanInOrdered(ctx, FanOut(ctx, intSource(6), 3, slowFirst)...) {
+		ordered = append(ordered, r.Value)
+	}
+	fmt.Printf("FanInOrdered (input order): %v\n", ordered)
+}
+
+// Example 2: Per-item errors
+func fanErrorsExample() {
+	fmt.Println("\n--- Example 2: Per-Item Errors ---")
+
+	// Errors travel with their result, so one bad item neither stops the
+	// others nor loses track of which input failed
+	parsePort := func(s string) (int, error) {
+		var port int
+		if _, err := fmt.Sscanf(s, "%d", &port); err != nil || port < 1 || port > 65535 {
+			return 0, fmt.Errorf("invalid port %q", s)
+		}
+		return port, nil
+	}
+	inputs := []string{"80", "http", "443", "70000", "8080"}
+	in := make(chan string, len(inputs))
+	for _, s := range inputs {
+		in <- s
+	}
+	close(in)
+
+	ctx := context.Background()
+	var errs []error
+	for r := range FanInOrdered(ctx, FanOut(ctx, in, 2, parsePort)...) {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+			fmt.Printf("  #%d %-6s error\n", r.Index, inputs[r.Index])
+			continue
+		}
+		fmt.Printf("  #%d %-6s -> %d\n", r.Index, inputs[r.Index], r.Value)
+	}
+	fmt.Printf("%d errors:\n%v\n", len(errs), errors.Join(errs...))
+}
+
+// Example 3: Cancellation
+func fanCancelExample() {
+	fmt.Println("\n--- Example 3: Cancellation ---")
+
+	// An endless source; cancelling ctx stops the dispatcher, the workers and
+	// the merge, and closes the merged channel
+	endless := make(chan int)
+	stop := make(chan struct{})
+	go func() {
+		for i := 1; ; i++ {
+			select {
+			case endless <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	defer close(stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	work := func(n int) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return n, nil
+	}
+	received := 0
+	for r := range FanInOrdered(ctx, FanOut(ctx, endless, 4, work)...) {
+		received++
+		if r.Index == 19 {
+			cancel() // Enough: the loop ends once the merge notices
+		}
+	}
+	cancel()
+	fmt.Printf("Received %d results, then the merged channel closed\n", received)
+}
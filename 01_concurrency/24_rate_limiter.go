@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Rate limiter examples: token bucket, leaky bucket and sliding window
+// behind one Limiter interface
+
+var (
+	// ErrLimitExceeded is returned by Wait when the limiter cannot ever
+	// admit the request, e.g. a leaky bucket whose queue is full
+	ErrLimitExceeded = errors.New("rate limiter: limit exceeded")
+	// ErrWaitExceedsDeadline is returned by Wait when the request would only
+	// be admitted after the context's deadline, so waiting is pointless
+	ErrWaitExceedsDeadline = errors.New("rate limiter: wait would exceed context deadline")
+)
+
+// Limiter admits events at a bounded rate
+type Limiter interface {
+	// Allow reports whether an event may happen now, consuming a slot if so
+	Allow() bool
+	// Wait blocks until an event may happen or ctx is done
+	Wait(ctx context.Context) error
+	// Reserve books a slot and says when it may be used, without blocking
+	Reserve() *Reservation
+}
+
+// Reservation is a slot booked by Reserve. The holder waits Delay, then
+// acts; or calls Cancel to give the slot back for others.
+type Reservation struct {
+	ok     bool
+	at     time.Time
+	cancel func()
+	once   sync.Once
+}
+
+// OK reports whether the limiter granted the reservation at all
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns how long to wait before acting on the reservation
+func (r *Reservation) Delay() time.Duration {
+	return max(time.Until(r.at), 0)
+}
+
+// Cancel returns the slot to the limiter, if the reservation was granted
+// and has not come due yet
+func (r *Reservation) Cancel() {
+	if !r.ok || r.cancel == nil {
+		return
+	}
+	r.once.Do(func() {
+		if time.Now().Before(r.at) {
+			r.cancel()
+		}
+	})
+}
+
+// waitReservation implements Limiter.Wait on top of Reserve
+func waitReservation(ctx context.Context, r *Reservation) error {
+	if !r.OK() {
+		return ErrLimitExceeded
+	}
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(r.at) {
+		r.Cancel()
+		return ErrWaitExceedsDeadline
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// TokenBucket refills at rate tokens per second up to burst; each event
+// takes one. Idle time builds up a burst; sustained load gets the rate.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens per second
+	burst  float64
+	tokens float64 // May go negative: tokens owed to reservations
+	last   time.Time
+}
+
+// NewTokenBucket creates a full token bucket
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last call; callers hold mu
+func (b *TokenBucket) refill(now time.Time) {
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// Allow takes a token if one is available
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Reserve takes a token now, going into debt if the bucket is empty; the
+// delay is the time needed to earn the debt back
+func (b *TokenBucket) Reserve() *Reservation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.refill(now)
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	return &Reservation{ok: true, at: now.Add(wait), cancel: func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.refill(time.Now())
+		b.tokens = min(b.burst, b.tokens+1)
+	}}
+}
+
+// Wait blocks until a token is available
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	return waitReservation(ctx, b.Reserve())
+}
+
+// LeakyBucket lets events out at one per interval, queueing at most
+// capacity of them; unlike a token bucket it never lets a burst through
+type LeakyBucket struct {
+	mu       sync.Mutex
+	interval time.Duration
+	capacity int
+	next     time.Time // When the next event may leave the bucket
+}
+
+// NewLeakyBucket creates a leaky bucket draining rate events per second
+func NewLeakyBucket(rate float64, capacity int) *LeakyBucket {
+	return &LeakyBucket{interval: time.Duration(float64(time.Second) / rate), capacity: max(capacity, 1)}
+}
+
+// Allow admits an event only if it can leave right away
+func (b *LeakyBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if b.next.After(now) {
+		return false
+	}
+	b.next = now.Add(b.interval)
+	return true
+}
+
+// Reserve queues an event behind those already waiting; it fails once
+// capacity events are queued
+func (b *LeakyBucket) Reserve() *Reservation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	at := b.next
+	if at.Before(now) {
+		at = now
+	}
+	// Events ahead of this one, counting one that is partly through
+	if queued := int((at.Sub(now) + b.interval - 1) / b.interval); queued >= b.capacity {
+		return &Reservation{}
+	}
+	b.next = at.Add(b.interval)
+	return &Reservation{ok: true, at: at, cancel: func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// Only the tail of the queue can be handed back without moving
+		// events that are already scheduled
+		if b.next.Equal(at.Add(b.interval)) {
+			b.next = at
+		}
+	}}
+}
+
+// Wait queues the event and blocks until it leaves the bucket
+func (b *LeakyBucket) Wait(ctx context.Context) error {
+	return waitReservation(ctx, b.Reserve())
+}
+
+// SlidingWindow admits at most limit events in any window of the given
+// length, by keeping the times of the events in the current window. There
+// is no boundary effect as with fixed windows, where 2×limit events can
+// pass around the moment one window turns into the next.
+type SlidingWindow struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	events []time.Time // Ascending; may include reserved future times
+}
+
+// NewSlidingWindow creates a sliding window limiter
+func NewSlidingWindow(limit int, window time.Duration) *SlidingWindow {
+	return &SlidingWindow{limit: max(limit, 1), window: window}
+}
+
+// prune drops events that have left the window; callers hold mu
+func (w *SlidingWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.window)
+	i := 0
+	for i < len(w.events) && !w.events[i].After(cutoff) {
+		i++
+	}
+	w.events = w.events[i:]
+}
+
+// Allow admits an event if fewer than limit happened in the last window
+func (w *SlidingWindow) Allow() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	w.prune(now)
+	n := len(w.events)
+	if n >= w.limit || (n > 0 && w.events[n-1].After(now)) {
+		return false // Full, or reservations are still waiting their turn
+	}
+	w.events = append(w.events, now)
+	return true
+}
+
+// Reserve books the earliest time at which the window has room: when the
+// limit-th most recent event slides out of it
+func (w *SlidingWindow) Reserve() *Reservation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	w.prune(now)
+	at := now
+	if n := len(w.events); n >= w.limit {
+		if free := w.events[n-w.limit].Add(w.window); free.After(at) {
+			at = free
+		}
+	}
+	w.events = append(w.events, at)
+	return &Reservation{ok: true, at: at, cancel: func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for i, t := range w.events {
+			if t.Equal(at) {
+				w.events = append(w.events[:i], w.events[i+1:]...)
+				return
+			}
+		}
+	}}
+}
+
+// Wait blocks until the window has room
+func (w *SlidingWindow) Wait(ctx context.Context) error {
+	return waitReservation(ctx, w.Reserve())
+}
+
+// RateLimiterExamples runs all rate limiter examples
+func RateLimiterExamples() {
+	fmt.Println("=== Rate Limiter Examples ===")
+
+	// Example 1: Allow under a burst
+	limiterBurstExample()
+
+	// Example 2: Wait paces callers
+	limiterWaitExample()
+
+	// Example 3: Reserve, cancel and deadlines
+	limiterReserveExample()
+
+	// Example 4: Concurrent clients sharing a limiter
+	limiterConcurrentExample()
+}
+
+// newLimiters builds one limiter of each kind at roughly 10 events per second
+func newLimiters() []struct {
+	name    string
+	limiter Limiter
+} {
+	return []struct {
+		name    string
+		limiter Limiter
+	}{
+		{"token bucket", NewTokenBucket(10, 5)},
+		{"leaky bucket", NewLeakyBucket(10, 5)},
+		{"sliding window", NewSlidingWindow(5, 500*time.Millisecond)},
+	}
+}
+
+// Example 1: Allow under a burst
+func limiterBurstExample() {
+	fmt.Println("\n--- Example 1: Allow Under a Burst ---")
+
+	// 20 requests at once, then 20 more spread over a second
+	for _, l := range newLimiters() {
+		var burst strings.Builder
+		for i := 0; i < 20; i++ {
+			if l.limiter.Allow() {
+				burst.WriteByte('+')
+			} else {
+				burst.WriteByte('.')
+			}
+		}
+		spread := 0
+		for i := 0; i < 20; i++ {
+			time.Sleep(50 * time.Millisecond)
+			if l.limiter.Allow() {
+				spread++
+			}
+		}
+		fmt.Printf("%-15s burst %s  then %d/20 over 1s\n", l.name, burst.String(), spread)
+	}
+	fmt.Println("(+ allowed, . rejected)")
+}
+
+// Example 2: Wait paces callers
+func limiterWaitExample() {
+	fmt.Println("\n--- Example 2: Wait Paces Callers ---")
+
+	for _, l := range newLimiters() {
+		start := time.Now()
+		var offsets []string
+		for i := 0; i < 8; i++ {
+			if err := l.limiter.Wait(context.Background()); err != nil {
+				offsets = append(offsets, "err")
+				continue
+			}
+			offsets = append(offsets, fmt.Sprint(time.Since(start).Round(50*time.Millisecond).Milliseconds()))
+		}
+		fmt.Printf("%-15s admitted at ms: %s\n", l.name, strings.Join(offsets, " "))
+	}
+}
+
+// Example 3: Reserve, cancel and deadlines
+func limiterReserveExample() {
+	fmt.Println("\n--- Example 3: Reserve, Cancel and Deadlines ---")
+
+	bucket := NewTokenBucket(10, 1)
+	first := bucket.Reserve()
+	second := bucket.Reserve()
+	fmt.Printf("Reserve: first delay %v, second delay %v\n",
+		first.Delay().Round(10*time.Millisecond), second.Delay().Round(10*time.Millisecond))
+	second.Cancel()
+	third := bucket.Reserve()
+	fmt.Printf("Cancelling the second hands its slot to a third, which waits %v, not 200ms\n", third.Delay().Round(10*time.Millisecond))
+
+	// Wait refuses up front when the slot lies beyond the deadline
+	busy := NewSlidingWindow(1, time.Second)
+	busy.Allow()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := busy.Wait(ctx)
+	fmt.Printf("Wait with a 100ms deadline on a 1s window: %v (after %v)\n", err, time.Since(start).Round(time.Millisecond))
+
+	// A leaky bucket with a full queue cannot reserve at all
+	leaky := NewLeakyBucket(1, 2)
+	for i := 1; i <= 3; i++ {
+		r := leaky.Reserve()
+		fmt.Printf("Leaky reserve %d: ok=%t delay=%v\n", i, r.OK(), r.Delay().Round(100*time.Millisecond))
+	}
+	fmt.Printf("Leaky Wait with a full queue: %v\n", leaky.Wait(context.Background()))
+}
+
+// Example 4: Concurrent clients sharing a limiter
+func limiterConcurrentExample() {
+	fmt.Println("\n--- Example 4: Concurrent Clients Sharing a Limiter ---")
+
+	// 8 clients hammer one limiter for 600ms; whatever the contention, the
+	// admitted total stays near the configured rate
+	for _, l := range newLimiters() {
+		ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
+		var admitted atomic.Int64
+		var wg sync.WaitGroup
+		for c := 0; c < 8; c++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for l.limiter.Wait(ctx) == nil {
+					admitted.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		cancel()
+		fmt.Printf("%-15s admitted %d events in 600ms\n", l.name, admitted.Load())
+	}
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Semaphore examples: admission control with a weighted semaphore, and a
+// BoundedGroup that caps how many goroutines of a group run at once
+
+// ErrWeightTooLarge is returned when acquiring more than the semaphore's size
+var ErrWeightTooLarge = errors.New("semaphore: weight exceeds size")
+
+// semWaiter is an Acquire call queued for capacity
+type semWaiter struct {
+	n     int64
+	ready chan struct{} // Closed once the weight has been granted
+}
+
+// Semaphore limits the combined weight of concurrent holders. Waiters are
+// served in arrival order, so a heavy request is not starved by a stream
+// of light ones; a light request arriving behind it waits too.
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List // Of *semWaiter
+}
+
+// NewSemaphore creates a semaphore with the given total weight
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire waits until weight n is available or ctx is done
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if n > s.size {
+		return fmt.Errorf("%w: %d > %d", ErrWeightTooLarge, n, s.size)
+	}
+
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+	w := &semWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// Granted while we were giving up: hand the weight back
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// Leaving the head of the queue may unblock smaller waiters
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes weight n if it is available right now
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release returns weight n. Releasing more than is held is a bug and panics.
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("semaphore: released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// InUse returns the weight currently held
+func (s *Semaphore) InUse() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cur
+}
+
+// notifyWaiters grants waiters from the front while they fit; callers hold mu
+func (s *Semaphore) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(*semWaiter)
+		if s.size-s.cur < w.n {
+			return // Keep FIFO order: nobody overtakes the head
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// BoundedGroup runs a group of goroutines, at most limit at a time. Like an
+// errgroup, the first error cancels the group's context and is returned by
+// Wait; Go blocks while the group is at its limit, which pushes back on
+// whoever is producing the work.
+type BoundedGroup struct {
+	sem    *Semaphore
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewBoundedGroup creates a group and the context its goroutines receive,
+// which is cancelled by the first error or once Wait returns
+func NewBoundedGroup(ctx context.Context, limit int) (*BoundedGroup, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &BoundedGroup{sem: NewSemaphore(int64(max(limit, 1))), ctx: ctx, cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine once a slot is free. If the group's
+// context ends first, fn is not run and the context's error is recorded.
+func (g *BoundedGroup) Go(fn func(ctx context.Context) error) {
+	if err := g.sem.Acquire(g.ctx, 1); err != nil {
+		g.fail(err)
+		return
+	}
+	g.start(fn)
+}
+
+// TryGo runs fn only if a slot is free right now, and reports whether it did
+func (g *BoundedGroup) TryGo(fn func(ctx context.Context) error) bool {
+	if !g.sem.TryAcquire(1) {
+		return false
+	}
+	g.start(fn)
+	return true
+}
+
+// start runs fn on an acquired slot
+func (g *BoundedGroup) start(fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer g.sem.Release(1)
+		if err := fn(g.ctx); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// fail records the first error and cancels the group
+func (g *BoundedGroup) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		g.cancel()
+	})
+}
+
+// Wait waits for every goroutine started by Go and returns the first error
+func (g *BoundedGroup) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+// SemaphoreExamples runs all semaphore examples
+func SemaphoreExamples() {
+	fmt.Println("=== Semaphore Examples ===")
+
+	// Example 1: Capping concurrent goroutines
+	semaphoreCapExample()
+
+	// Example 2: Weighted admission
+	weightedSemaphoreExample()
+
+	// Example 3: Giving up on a wait
+	semaphoreTimeoutExample()
+
+	// Example 4: BoundedGroup
+	boundedGroupExample()
+}
+
+// peakTracker records the highest concurrency seen
+type peakTracker struct {
+	cur, peak atomic.Int64
+}
+
+func (p *peakTracker) enter() {
+	cur := p.cur.Add(1)
+	for {
+		peak := p.peak.Load()
+		if cur <= peak || p.peak.CompareAndSwap(peak, cur) {
+			return
+		}
+	}
+}
+
+func (p *peakTracker) leave() {
+	p.cur.Add(-1)
+}
+
+// Example 1: Capping concurrent goroutines
+func semaphoreCapExample() {
+	fmt.Println("\n--- Example 1: Capping Concurrent Goroutines ---")
+
+	// 20 goroutines start at once; only 3 may be inside the critical part
+	sem := NewSemaphore(3)
+	var tracker peakTracker
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem.Acquire(context.Background(), 1)
+			defer sem.Release(1)
+			tracker.enter()
+			defer tracker.leave()
+			time.Sleep(20 * time.Millisecond) // Simulate a call to a rate-sensitive backend
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("20 tasks, limit 3: peak concurrency %d, took %v\n",
+		tracker.peak.Load(), time.Since(start).Round(10*time.Millisecond))
+}
+
+// Example 2: Weighted admission
+func weightedSemaphoreExample() {
+	fmt.Println("\n--- Example 2: Weighted Admission ---")
+
+	// A 100MB memory budget shared by jobs of different sizes
+	budget := NewSemaphore(100)
+	jobs := []struct {
+		name string
+		mb   int64
+	}{
+		{"thumbnail", 10}, {"video", 80}, {"report", 30}, {"thumbnail", 10}, {"archive", 60}, {"thumbnail", 10},
+	}
+
+	var mu sync.Mutex
+	var log []string
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i, job := range jobs {
+		i, job := i, job
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Duration(i) * 5 * time.Millisecond) // Arrive in order
+			budget.Acquire(context.Background(), job.mb)
+			mu.Lock()
+			log = append(log, fmt.Sprintf("%-9s %2dMB admitted at %3dms", job.name, job.mb, time.Since(start).Milliseconds()/10*10))
+			mu.Unlock()
+			time.Sleep(50 * time.Millisecond)
+			budget.Release(job.mb)
+		}()
+	}
+	wg.Wait()
+	for _, line := range log {
+		fmt.Println("  " + line)
+	}
+	fmt.Println("When 20MB freed up the queued thumbnail did not overtake the 30MB report ahead of it")
+
+	err := budget.Acquire(context.Background(), 150)
+	fmt.Printf("Acquire 150MB of 100MB: %v\n", err)
+}
+
+// Example 3: Giving up on a wait
+func semaphoreTimeoutExample() {
+	fmt.Println("\n--- Example 3: Giving Up on a Wait ---")
+
+	sem := NewSemaphore(2)
+	sem.Acquire(context.Background(), 2)
+	fmt.Printf("TryAcquire while full: %t\n", sem.TryAcquire(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := sem.Acquire(ctx, 1)
+	fmt.Printf("Acquire with a 50ms timeout: %v\n", err)
+
+	sem.Release(2)
+	fmt.Printf("After release: TryAcquire %t, in use %d\n", sem.TryAcquire(2), sem.InUse())
+	sem.Release(2)
+}
+
+// Example 4: BoundedGroup
+func boundedGroupExample() {
+	fmt.Println("\n--- Example 4: BoundedGroup ---")
+
+	// Fetch 10 pages, at most 3 at a time
+	var tracker peakTracker
+	group, _ := NewBoundedGroup(context.Background(), 3)
+	var fetched atomic.Int64
+	for page := 1; page <= 10; page++ {
+		group.Go(func(ctx context.Context) error {
+			tracker.enter()
+			defer tracker.leave()
+			time.Sleep(20 * time.Millisecond)
+			fetched.Add(1)
+			return nil
+		})
+	}
+	err := group.Wait()
+	fmt.Printf("Fetched %d pages, peak concurrency %d, err=%v\n", fetched.Load(), tracker.peak.Load(), err)
+
+	// The first failure cancels the rest: pages still waiting for a slot
+	// are never started, and running ones see ctx cancelled
+	group, _ = NewBoundedGroup(context.Background(), 3)
+	var started, cancelled atomic.Int64
+	for page := 1; page <= 10; page++ {
+		page := page
+		group.Go(func(ctx context.Context) error {
+			started.Add(1)
+			if page == 4 {
+				return fmt.Errorf("page %d: 503 Service Unavailable", page)
+			}
+			select {
+			case <-time.After(30 * time.Millisecond):
+				return nil
+			case <-ctx.Done():
+				cancelled.Add(1)
+				return ctx.Err()
+			}
+		})
+	}
+	err = group.Wait()
+	fmt.Printf("Started %d of 10, %d cancelled mid-flight, err=%v\n", started.Load(), cancelled.Load(), err)
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Structured concurrency examples: goroutines started in a scope finish
+// before the scope does, so errors, panics and cancellation all flow back
+// to the code that started them
+
+// ErrGroupFinished is returned by Go once Wait has been called; a group
+// that has finished cannot start goroutines that would outlive it
+var ErrGroupFinished = errors.New("structured group: already finished")
+
+// StructuredGroup runs goroutines that share a context. The first failure
+// cancels that context so siblings can stop early; Wait returns every
+// failure joined, not just the first, and a panic in a goroutine comes back
+// as an error from Wait rather than crashing the program.
+type StructuredGroup struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	errs     []error
+	failed   bool // The group cancelled ctx itself, after an error
+	finished bool
+}
+
+// NewStructuredGroup creates a group whose context is derived from ctx
+func NewStructuredGroup(ctx context.Context) *StructuredGroup {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &StructuredGroup{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context shared by the group's goroutines
+func (g *StructuredGroup) Context() context.Context {
+	return g.ctx
+}
+
+// Go starts fn in the group. It fails with ErrGroupFinished after Wait.
+func (g *StructuredGroup) Go(fn func(ctx context.Context) error) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.finished {
+		return ErrGroupFinished
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("goroutine panicked: %v", r)
+			}
+			g.record(err)
+		}()
+		err = fn(g.ctx)
+	}()
+	return nil
+}
+
+// record keeps a goroutine's error; the first one cancels the group. A
+// goroutine that merely stopped because a sibling failed adds nothing new.
+func (g *StructuredGroup) record(err error) {
+	if err == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.failed && errors.Is(err, context.Canceled) {
+		return
+	}
+	g.errs = append(g.errs, err)
+	g.abortLocked(err)
+}
+
+// abortLocked cancels the group with cause the first time it is called;
+// callers hold mu
+func (g *StructuredGroup) abortLocked(cause error) {
+	if !g.failed {
+		g.failed = true
+		g.cancel(cause)
+	}
+}
+
+// Wait waits for every goroutine, closes the group to new ones, cancels
+// its context and returns all errors joined
+func (g *StructuredGroup) Wait() error {
+	g.mu.Lock()
+	g.finished = true
+	g.mu.Unlock()
+
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
+
+// Structured runs body with a fresh group and waits for the group before
+// returning, so nothing body starts can outlive the call. Body's own error
+// is joined with the group's.
+func Structured(ctx context.Context, body func(g *StructuredGroup) error) error {
+	g := NewStructuredGroup(ctx)
+	var bodyErr error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				bodyErr = fmt.Errorf("scope panicked: %v", r)
+			}
+			if bodyErr != nil {
+				g.mu.Lock()
+				g.abortLocked(bodyErr)
+				g.mu.Unlock()
+			}
+		}()
+		bodyErr = body(g)
+	}()
+	return errors.Join(bodyErr, g.Wait())
+}
+
+// StructuredExamples runs all structured concurrency examples
+func StructuredExamples() {
+	fmt.Println("=== Structured Concurrency Examples ===")
+
+	// Example 1: Errors are collected and cancel the siblings
+	structuredErrorsExample()
+
+	// Example 2: Parallel futures, structured
+	structuredFuturesExample()
+
+	// Example 3: Nested scopes and panics
+	structuredNestingExample()
+
+	// Example 4: Nothing outlives the scope
+	structuredLifetimeExample()
+}
+
+// Example 1: Errors are collected and cancel the siblings
+func structuredErrorsExample() {
+	fmt.Println("\n--- Example 1: Errors Are Collected and Cancel the Siblings ---")
+
+	var mu sync.Mutex
+	var events []string
+	note := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, fmt.Sprintf(format, args...))
+	}
+
+	g := NewStructuredGroup(context.Background())
+	for _, replica := range []string{"eu", "us", "ap"} {
+		replica := replica
+		g.Go(func(ctx context.Context) error {
+			select {
+			case <-time.After(200 * time.Millisecond):
+				note("%s: synced", replica)
+				return nil
+			case <-ctx.Done():
+				note("%s: stopped (%v)", replica, context.Cause(ctx))
+				return ctx.Err()
+			}
+		})
+	}
+	g.Go(func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return errors.New("schema check failed")
+	})
+	g.Go(func(ctx context.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		return errors.New("disk quota exceeded") // Fails on its own, also reported
+	})
+
+	start := time.Now()
+	err := g.Wait()
+	sort.Strings(events)
+	for _, e := range events {
+		fmt.Println("  " + e)
+	}
+	fmt.Printf("Wait returned after %v with:\n  %s\n",
+		time.Since(start).Round(10*time.Millisecond), strings.ReplaceAll(err.Error(), "\n", "\n  "))
+	fmt.Printf("Go after Wait: %v\n", g.Go(func(context.Context) error { return nil }))
+}
+
+// Example 2: Parallel futures, structured
+func structuredFuturesExample() {
+	fmt.Println("\n--- Example 2: Parallel Futures, Structured ---")
+
+	// The tasks of the Future parallel example, but task 2 fails. With
+	// Async+All the error surfaces, yet tasks 1 and 3 keep running in the
+	// background. In a scope, the failure cancels them and the scope ends
+	// only when they have really stopped.
+	durations := []time.Duration{300, 200, 400, 100}
+	results := make([]string, len(durations))
+	start := time.Now()
+	err := Structured(context.Background(), func(g *StructuredGroup) error {
+		for i, d := range durations {
+			i, d := i, d*time.Millisecond
+			g.Go(func(ctx context.Context) error {
+				if i == 1 {
+					time.Sleep(50 * time.Millisecond)
+					return fmt.Errorf("task %d: upstream timeout", i+1)
+				}
+				select {
+				case <-time.After(d):
+					results[i] = fmt.Sprintf("Task %d completed", i+1)
+					return nil
+				case <-ctx.Done():
+					results[i] = fmt.Sprintf("Task %d cancelled", i+1)
+					return ctx.Err()
+				}
+			})
+		}
+		return nil
+	})
+	for i, r := range results {
+		if r == "" {
+			r = "(failed)"
+		}
+		fmt.Printf("Task %d result: %s\n", i+1, r)
+	}
+	fmt.Printf("Scope ended after %v: %v\n", time.Since(start).Round(10*time.Millisecond), err)
+
+	// Futures and scopes mix: a scope can resolve a Future when it is done
+	future := NewFuture()
+	go func() {
+		var total int
+		var mu sync.Mutex
+		err := Structured(context.Background(), func(g *StructuredGroup) error {
+			for _, n := range []int{1, 2, 3, 4} {
+				n := n
+				g.Go(func(context.Context) error {
+					mu.Lock()
+					defer mu.Unlock()
+					total += n * n
+					return nil
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			future.SetError(err)
+			return
+		}
+		future.SetResult(total)
+	}()
+	total, err := future.Get()
+	fmt.Printf("Future resolved by a scope: %v (err=%v)\n", total, err)
+}
+
+// Example 3: Nested scopes and panics
+func structuredNestingExample() {
+	fmt.Println("\n--- Example 3: Nested Scopes and Panics ---")
+
+	// Each region runs its own scope inside the outer one. A panic deep
+	// inside comes back as an error from the outer scope, with context.
+	err := Structured(context.Background(), func(outer *StructuredGroup) error {
+		for _, region := range []string{"north", "south"} {
+			region := region
+			outer.Go(func(ctx context.Context) error {
+				err := Structured(ctx, func(inner *StructuredGroup) error {
+					for _, shard := range []int{1, 2, 3} {
+						shard := shard
+						inner.Go(func(ctx context.Context) error {
+							if region == "south" && shard == 2 {
+								var counts map[string]int
+								counts["shard"]++ // Assignment to a nil map
+							}
+							return nil
+						})
+					}
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("region %s: %w", region, err)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	fmt.Printf("Outer scope: %v\n", err)
+}
+
+// Example 4: Nothing outlives the scope
+func structuredLifetimeExample() {
+	fmt.Println("\n--- Example 4: Nothing Outlives the Scope ---")
+
+	before := runtime.NumGoroutine()
+	err := Structured(context.Background(), func(g *StructuredGroup) error {
+		// Workers run until their context ends; the body returning an error
+		// cancels it, and Structured still waits for all of them
+		for i := 0; i < 5; i++ {
+			g.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		}
+		time.Sleep(20 * time.Millisecond)
+		return errors.New("body gave up")
+	})
+	fmt.Printf("Scope error: %v\n", err)
+	fmt.Printf("Goroutines before %d, after %d\n", before, runtime.NumGoroutine())
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/chans"
+)
+
+// Channel utility examples: the reusable helpers of internal/chans for
+// copying, merging and grouping channel values
+
+// ChannelUtilsExamples runs all channel utility examples
+func ChannelUtilsExamples() {
+	fmt.Println("=== Channel Utilities Examples ===")
+
+	// Example 1: Tee and Broadcast
+	teeBroadcastExample()
+
+	// Example 2: Merge
+	mergeChannelsExample()
+
+	// Example 3: Batch
+	batchChannelExample()
+
+	// Example 4: Window
+	windowChannelExample()
+
+	// Example 5: Cancellation
+	channelUtilsCancelExample()
+}
+
+// tickSource sends values on a channel, pausing between them, then closes it
+func tickSource[T any](pause time.Duration, values ...T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range values {
+			ch <- v
+			time.Sleep(pause)
+		}
+	}()
+	return ch
+}
+
+// Example 1: Tee and Broadcast
+func teeBroadcastExample() {
+	fmt.Println("\n--- Example 1: Tee and Broadcast ---")
+
+	ctx := context.Background()
+
+	// Tee: one stream both stored and summed, without reading it twice
+	store, sum := chans.Tee(ctx, tickSource(0, 3, 1, 4, 1, 5))
+	var stored []int
+	total := 0
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := range store {
+			stored = append(stored, v)
+		}
+	}()
+	for v := range sum {
+		total += v
+	}
+	wg.Wait()
+	fmt.Printf("Tee: stored %v, sum %d\n", stored, total)
+
+	// Broadcast: every subscriber sees every value; the slow one sets the
+	// pace, so the fast ones are never more than a value ahead
+	start := time.Now()
+	outs := chans.Broadcast(ctx, tickSource(0, "a", "b", "c"), 3)
+	var mu sync.Mutex
+	var lines []string
+	for i, out := range outs {
+		i, out := i, out
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var got []string
+			for v := range out {
+				if i == 2 {
+					time.Sleep(20 * time.Millisecond) // The slow subscriber
+				}
+				got = append(got, v)
+			}
+			mu.Lock()
+			lines = append(lines, fmt.Sprintf("subscriber %d got %v", i, got))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Println("  " + line)
+	}
+	fmt.Printf("Broadcast took %v, paced by the slow subscriber\n", time.Since(start).Round(10*time.Millisecond))
+}
+
+// Example 2: Merge
+func mergeChannelsExample() {
+	fmt.Println("\n--- Example 2: Merge ---")
+
+	// Three sensors reporting at different rates, read as one stream
+	ctx := context.Background()
+	merged := chans.Merge(ctx,
+		tickSource(30*time.Millisecond, "temp=21", "temp=22"),
+		tickSource(20*time.Millisecond, "humidity=40", "humidity=41", "humidity=43"),
+		tickSource(50*time.Millisecond, "pressure=1013"),
+	)
+	count := 0
+	for reading := range merged {
+		count++
+		fmt.Printf("  %s\n", reading)
+	}
+	fmt.Printf("%d readings; the merged channel closed after the last sensor\n", count)
+}
+
+// Example 3: Batch
+func batchChannelExample() {
+	fmt.Println("\n--- Example 3: Batch ---")
+
+	// Log lines arrive in a burst, then trickle in. Batches of 4 fill during
+	// the burst; in the trickle, maxWait sends whatever has arrived.
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		for i := 1; i <= 9; i++ {
+			lines <- fmt.Sprintf("line%d", i)
+		}
+		for i := 10; i <= 12; i++ {
+			time.Sleep(40 * time.Millisecond)
+			lines <- fmt.Sprintf("line%d", i)
+		}
+	}()
+
+	start := time.Now()
+	for batch := range chans.Batch(context.Background(), lines, 4, 50*time.Millisecond) {
+		fmt.Printf("  at %3dms write %d lines: %v\n", time.Since(start).Milliseconds()/10*10, len(batch), batch)
+	}
+}
+
+// Example 4: Window
+func windowChannelExample() {
+	fmt.Println("\n--- Example 4: Window ---")
+
+	ctx := context.Background()
+	prices := []float64{10, 11, 12, 11, 13, 15, 14}
+
+	// Step 1: a moving average over the last 3 prices
+	fmt.Print("3-point moving average:")
+	for w := range chans.Window(ctx, tickSource(0, prices...), 3, 1) {
+		fmt.Printf(" %.2f", (w[0]+w[1]+w[2])/3)
+	}
+	fmt.Println()
+
+	// Step == size: disjoint chunks; the incomplete tail is dropped
+	fmt.Print("Chunks of 3:")
+	for w := range chans.Window(ctx, tickSource(0, prices...), 3, 3) {
+		fmt.Printf(" %v", w)
+	}
+	fmt.Println()
+
+	// Step > size: sample 2 values out of every 3
+	fmt.Print("2 of every 3:")
+	for w := range chans.Window(ctx, tickSource(0, prices...), 2, 3) {
+		fmt.Printf(" %v", w)
+	}
+	fmt.Println()
+}
+
+// Example 5: Cancellation
+func channelUtilsCancelExample() {
+	fmt.Println("\n--- Example 5: Cancellation ---")
+
+	// An endless source feeding a chain of helpers. Cancelling ctx closes
+	// every channel in the chain, so the loop below ends by itself.
+	endless := make(chan int)
+	stop := make(chan struct{})
+	go func() {
+		for i := 1; ; i++ {
+			select {
+			case endless <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	defer close(stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	left, right := chans.Tee(ctx, endless)
+	batches := 0
+	for range chans.Batch(ctx, chans.Merge(ctx, left, right), 100, 0) {
+		batches++
+	}
+	fmt.Printf("Received %d full batches before the timeout closed the chain: %v\n", batches, ctx.Err())
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/actor"
+)
+
+// Benchmark harness examples: one workload run through mutex, atomic,
+// channel, actor and worker pool implementations, compared by throughput,
+// allocations and latency percentiles
+
+// benchConfig is the workload every approach runs: clients goroutines each
+// perform ops increments spread over accounts counters, and spin for work
+// iterations per op outside the shared state to imitate real request work
+type benchConfig struct {
+	clients  int
+	ops      int
+	accounts int
+	work     int
+}
+
+// benchImpl is a running instance of one approach. do performs op i for the
+// given client and returns once the op has taken effect; total reads the sum
+// of all counters once every client has finished.
+type benchImpl struct {
+	do    func(client, i int)
+	total func() int64
+	stop  func()
+}
+
+// benchApproach builds a fresh implementation for a workload
+type benchApproach struct {
+	name  string
+	about string
+	start func(cfg benchConfig) benchImpl
+}
+
+// benchResult is one row of the comparison table
+type benchResult struct {
+	name               string
+	opsPerSec          float64
+	allocsPerOp        float64
+	p50, p90, p99, max time.Duration
+	correct            bool
+}
+
+// spin burns a little CPU so ops are not pure synchronization
+func spin(n int) int {
+	acc := 1
+	for i := 0; i < n; i++ {
+		acc = acc*31 + i
+	}
+	return acc
+}
+
+// benchSink keeps spin's result alive so the compiler cannot drop the work
+var benchSink atomic.Int64
+
+var benchApproaches = []benchApproach{
+	{
+		name:  "mutex",
+		about: "counters guarded by one sync.Mutex",
+		start: func(cfg benchConfig) benchImpl {
+			var mu sync.Mutex
+			counters := make([]int64, cfg.accounts)
+			return benchImpl{
+				do: func(_, i int) {
+					mu.Lock()
+					counters[i%cfg.accounts]++
+					mu.Unlock()
+				},
+				total: func() int64 { return sumInt64(counters) },
+				stop:  func() {},
+			}
+		},
+	},
+	{
+		name:  "atomic",
+		about: "one atomic.Int64 per counter",
+		start: func(cfg benchConfig) benchImpl {
+			counters := make([]atomic.Int64, cfg.accounts)
+			return benchImpl{
+				do: func(_, i int) { counters[i%cfg.accounts].Add(1) },
+				total: func() int64 {
+					var sum int64
+					for i := range counters {
+						sum += counters[i].Load()
+					}
+					return sum
+				},
+				stop: func() {},
+			}
+		},
+	},
+	{
+		name:  "channel",
+		about: "an owner goroutine applies ops sent on a channel",
+		start: func(cfg benchConfig) benchImpl {
+			type op struct {
+				account int
+				done    chan struct{}
+			}
+			ops := make(chan op, cfg.clients)
+			counters := make([]int64, cfg.accounts)
+			finished := make(chan struct{})
+			go func() {
+				defer close(finished)
+				for o := range ops {
+					counters[o.account]++
+					o.done <- struct{}{}
+				}
+			}()
+			// One reply channel per client, reused for every op it sends
+			replies := make([]chan struct{}, cfg.clients)
+			for c := range replies {
+				replies[c] = make(chan struct{}, 1)
+			}
+			return benchImpl{
+				do: func(client, i int) {
+					ops <- op{account: i % cfg.accounts, done: replies[client]}
+					<-replies[client]
+				},
+				total: func() int64 {
+					close(ops)
+					<-finished
+					return sumInt64(counters)
+				},
+				stop: func() {},
+			}
+		},
+	},
+	{
+		name:  "actor",
+		about: "internal/actor with a typed Ask per op",
+		start: func(cfg benchConfig) benchImpl {
+			counters := make([]int64, cfg.accounts)
+			a := actor.New("ledger", cfg.clients, func(req actor.Request[int, int64]) {
+				if req.Body < 0 { // Total query; only the actor touches counters
+					req.Reply(sumInt64(counters))
+					return
+				}
+				counters[req.Body]++
+				req.Reply(counters[req.Body])
+			})
+			a.Start()
+			return benchImpl{
+				do: func(_, i int) {
+					actor.Ask(a, i%cfg.accounts, time.Second)
+				},
+				total: func() int64 {
+					total, _ := actor.Ask(a, -1, time.Second)
+					return total
+				},
+				stop: a.Stop,
+			}
+		},
+	},
+	{
+		name:  "worker pool",
+		about: "ops queued to runtime.NumCPU() workers updating atomics",
+		start: func(cfg benchConfig) benchImpl {
+			type job struct {
+				account int
+				done    chan struct{}
+			}
+			jobs := make(chan job, cfg.clients)
+			counters := make([]atomic.Int64, cfg.accounts)
+			var wg sync.WaitGroup
+			for w := 0; w < runtime.NumCPU(); w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := range jobs {
+						counters[j.account].Add(1)
+						j.done <- struct{}{}
+					}
+				}()
+			}
+			replies := make([]chan struct{}, cfg.clients)
+			for c := range replies {
+				replies[c] = make(chan struct{}, 1)
+			}
+			return benchImpl{
+				do: func(client, i int) {
+					jobs <- job{account: i % cfg.accounts, done: replies[client]}
+					<-replies[client]
+				},
+				total: func() int64 {
+					var sum int64
+					for i := range counters {
+						sum += counters[i].Load()
+					}
+					return sum
+				},
+				stop: func() {
+					close(jobs)
+					wg.Wait()
+				},
+			}
+		},
+	},
+}
+
+func sumInt64(values []int64) int64 {
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// runBench measures one approach on cfg
+func runBench(a benchApproach, cfg benchConfig) benchResult {
+	impl := a.start(cfg)
+	latencies := make([][]time.Duration, cfg.clients)
+	for c := range latencies {
+		latencies[c] = make([]time.Duration, cfg.ops) // Allocated before measuring
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for c := 0; c < cfg.clients; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			sink := 0
+			for i := 0; i < cfg.ops; i++ {
+				sink += spin(cfg.work)
+				opStart := time.Now()
+				impl.do(c, c*cfg.ops+i)
+				latencies[c][i] = time.Since(opStart)
+			}
+			benchSink.Add(int64(sink))
+		}(c)
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	total := impl.total()
+	impl.stop()
+
+	all := make([]time.Duration, 0, cfg.clients*cfg.ops)
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	percentile := func(p float64) time.Duration {
+		return all[min(int(p*float64(len(all))), len(all)-1)]
+	}
+
+	n := cfg.clients * cfg.ops
+	return benchResult{
+		name:        a.name,
+		opsPerSec:   float64(n) / elapsed.Seconds(),
+		allocsPerOp: float64(after.Mallocs-before.Mallocs) / float64(n),
+		p50:         percentile(0.50),
+		p90:         percentile(0.90),
+		p99:         percentile(0.99),
+		max:         all[len(all)-1],
+		correct:     total == int64(n),
+	}
+}
+
+// printBenchTable prints results fastest first
+func printBenchTable(results []benchResult) {
+	sort.Slice(results, func(i, j int) bool { return results[i].opsPerSec > results[j].opsPerSec })
+	fmt.Printf("%-12s %12s %10s %10s %10s %10s %10s  %s\n",
+		"approach", "ops/s", "allocs/op", "p50", "p90", "p99", "max", "total")
+	for _, r := range results {
+		check := "ok"
+		if !r.correct {
+			check = "WRONG"
+		}
+		fmt.Printf("%-12s %12.0f %10.2f %10v %10v %10v %10v  %s\n",
+			r.name, r.opsPerSec, r.allocsPerOp, r.p50, r.p90, r.p99, r.max.Round(time.Microsecond), check)
+	}
+}
+
+// BenchHarnessExamples runs every approach, or the ones named after the
+// example number, on a configurable workload:
+// 28 [approach...] [-clients N] [-ops N] [-accounts N] [-work N]
+func BenchHarnessExamples() {
+	fmt.Println("=== Benchmark Harness Examples ===")
+
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	cfg := benchConfig{}
+	flags.IntVar(&cfg.clients, "clients", 8, "concurrent client goroutines")
+	flags.IntVar(&cfg.ops, "ops", 20000, "ops per client")
+	flags.IntVar(&cfg.accounts, "accounts", 16, "counters the ops are spread over")
+	flags.IntVar(&cfg.work, "work", 50, "spin iterations per op outside the shared state")
+
+	// Accept approach names before or after the flags, as the race suite does
+	var args, names []string
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		if strings.HasPrefix(arg, "-") {
+			args = append(args, arg)
+			if !strings.Contains(arg, "=") && i+1 < len(os.Args) {
+				args = append(args, os.Args[i+1])
+				i++
+			}
+		} else {
+			names = append(names, arg)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return
+	}
+	cfg.clients, cfg.ops, cfg.accounts = max(cfg.clients, 1), max(cfg.ops, 1), max(cfg.accounts, 1)
+
+	selected := benchApproaches
+	if len(names) > 0 {
+		selected = nil
+		for _, name := range names {
+			found := false
+			for _, a := range benchApproaches {
+				if a.name == name || strings.ReplaceAll(a.name, " ", "-") == name {
+					selected, found = append(selected, a), true
+				}
+			}
+			if !found {
+				fmt.Printf("Unknown approach %q\n", name)
+				return
+			}
+		}
+	}
+
+	fmt.Printf("\nWorkload: %d clients x %d ops over %d counters, %d spin iterations per op, GOMAXPROCS=%d\n",
+		cfg.clients, cfg.ops, cfg.accounts, cfg.work, runtime.GOMAXPROCS(0))
+	for _, a := range selected {
+		fmt.Printf("  %-12s %s\n", a.name, a.about)
+	}
+	fmt.Println()
+
+	var results []benchResult
+	for _, a := range selected {
+		results = append(results, runBench(a, cfg))
+	}
+	printBenchTable(results)
+
+	fmt.Println("\nReading the table:")
+	fmt.Println("  - atomic and mutex touch shared memory directly; their cost is contention")
+	fmt.Println("  - channel, actor and worker pool hand each op to another goroutine, so")
+	fmt.Println("    every op pays for a send, a receive and a context switch")
+	fmt.Println("  - actor allocates a reply channel and a timer per Ask; allocs/op shows it")
+	fmt.Println("  - p99 and max reveal queueing: an op waits behind everyone ahead of it")
+	fmt.Println("Try -clients 64, -work 0 or -accounts 1 to see how the ranking moves.")
+	fmt.Println("For ns/op under the testing package, with -cpu to vary GOMAXPROCS:")
+	fmt.Println("  go test -bench=Contention -benchmem -cpu=1,4,8 ./01_concurrency")
+}
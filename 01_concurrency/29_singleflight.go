@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Single-flight examples: collapsing concurrent calls for the same key into
+// one, and a memoizing cache with a TTL built on top of it
+
+// ErrSingleFlightPanic wraps the value of a shared call that panicked
+var ErrSingleFlightPanic = errors.New("singleflight: call panicked")
+
+// SingleFlightResult is what DoChan delivers
+type SingleFlightResult[V any] struct {
+	Value  V
+	Err    error
+	Shared bool // The result went to more than one caller
+}
+
+// flight is one call in progress and everyone waiting on it
+type flight[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+	dups  int // Callers that joined after the first
+}
+
+// SingleFlight makes concurrent calls for the same key share one execution:
+// the first caller runs fn, later callers wait for its result instead of
+// running fn again. Once the call returns, the next caller starts a new one,
+// so nothing is cached; see MemoCache for that.
+type SingleFlight[K comparable, V any] struct {
+	mu      sync.Mutex
+	flights map[K]*flight[V]
+}
+
+// NewSingleFlight creates an empty group
+func NewSingleFlight[K comparable, V any]() *SingleFlight[K, V] {
+	return &SingleFlight[K, V]{flights: make(map[K]*flight[V])}
+}
+
+// Do runs fn for key, or waits for the call already running for key, and
+// reports whether the result was shared with other callers
+func (s *SingleFlight[K, V]) Do(key K, fn func() (V, error)) (V, error, bool) {
+	f, leader := s.join(key)
+	if leader {
+		s.run(key, f, fn)
+	} else {
+		<-f.done
+	}
+	s.mu.Lock()
+	shared := f.dups > 0
+	s.mu.Unlock()
+	return f.value, f.err, shared
+}
+
+// DoChan is Do without blocking. A caller that stops waiting on the channel
+// does not stop the call, which still serves everyone else.
+func (s *SingleFlight[K, V]) DoChan(key K, fn func() (V, error)) <-chan SingleFlightResult[V] {
+	ch := make(chan SingleFlightResult[V], 1) // Buffered: nobody has to receive
+	f, leader := s.join(key)
+	if leader {
+		go s.run(key, f, fn)
+	}
+	go func() {
+		<-f.done
+		s.mu.Lock()
+		shared := f.dups > 0
+		s.mu.Unlock()
+		ch <- SingleFlightResult[V]{Value: f.value, Err: f.err, Shared: shared}
+	}()
+	return ch
+}
+
+// Forget makes the next call for key start a new execution even if one is
+// still running, for when the running one is known to be stale
+func (s *SingleFlight[K, V]) Forget(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.flights, key)
+}
+
+// join returns the flight for key and whether the caller must run it
+func (s *SingleFlight[K, V]) join(key K) (*flight[V], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f, ok := s.flights[key]; ok {
+		f.dups++
+		return f, false
+	}
+	f := &flight[V]{done: make(chan struct{})}
+	s.flights[key] = f
+	return f, true
+}
+
+// run executes fn for f and releases its waiters. A panic becomes an error
+// for every waiter instead of crashing only the leader's goroutine.
+func (s *SingleFlight[K, V]) run(key K, f *flight[V], fn func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			f.err = fmt.Errorf("%w: %v", ErrSingleFlightPanic, r)
+		}
+		s.mu.Lock()
+		if s.flights[key] == f { // Forget may have replaced it
+			delete(s.flights, key)
+		}
+		s.mu.Unlock()
+		close(f.done)
+	}()
+	f.value, f.err = fn()
+}
+
+// memoEntry is a cached value and when it stops being fresh
+type memoEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// MemoCache memoizes a slow function per key for ttl. Misses for the same
+// key share one call through a SingleFlight, errors are not cached, and a
+// caller whose context ends stops waiting without cancelling the call that
+// other callers may be sharing.
+type MemoCache[K comparable, V any] struct {
+	fn     func(ctx context.Context, key K) (V, error)
+	ttl    time.Duration
+	flight *SingleFlight[K, V]
+
+	mu      sync.Mutex
+	entries map[K]memoEntry[V]
+	epochs  map[K]uint64 // Bumped by Invalidate so an older call cannot store
+
+	hits, misses, calls atomic.Int64
+}
+
+// NewMemoCache creates a cache in front of fn
+func NewMemoCache[K comparable, V any](ttl time.Duration, fn func(ctx context.Context, key K) (V, error)) *MemoCache[K, V] {
+	return &MemoCache[K, V]{
+		fn:      fn,
+		ttl:     ttl,
+		flight:  NewSingleFlight[K, V](),
+		entries: make(map[K]memoEntry[V]),
+		epochs:  make(map[K]uint64),
+	}
+}
+
+// Get returns the fresh cached value for key, or computes it
+func (c *MemoCache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && time.Now().Before(entry.expires) {
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return entry.value, nil
+	}
+	delete(c.entries, key) // Expired entries go on first touch
+	epoch := c.epochs[key]
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	// The call outlives this caller's cancellation but keeps its values
+	detached := context.WithoutCancel(ctx)
+	results := c.flight.DoChan(key, func() (V, error) {
+		c.calls.Add(1)
+		value, err := c.fn(detached, key)
+		if err == nil {
+			c.mu.Lock()
+			if c.epochs[key] == epoch {
+				c.entries[key] = memoEntry[V]{value: value, expires: time.Now().Add(c.ttl)}
+			}
+			c.mu.Unlock()
+		}
+		return value, err
+	})
+
+	select {
+	case r := <-results:
+		return r.Value, r.Err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Invalidate drops key; a call for it already running will not store its
+// result, and the next Get starts a new one
+func (c *MemoCache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.epochs[key]++
+	c.mu.Unlock()
+	c.flight.Forget(key)
+}
+
+// Stats returns cache hits, misses and calls to the underlying function
+func (c *MemoCache[K, V]) Stats() string {
+	return fmt.Sprintf("hits=%d misses=%d calls=%d", c.hits.Load(), c.misses.Load(), c.calls.Load())
+}
+
+// SingleFlightExamples runs all single-flight examples
+func SingleFlightExamples() {
+	fmt.Println("=== Single-Flight Examples ===")
+
+	// Example 1: A thundering herd collapsed to one call
+	singleFlightHerdExample()
+
+	// Example 2: Waiting with a deadline
+	singleFlightDeadlineExample()
+
+	// Example 3: Memoization with a TTL
+	memoCacheExample()
+
+	// Example 4: Errors, panics and invalidation
+	memoCacheFailureExample()
+}
+
+// Example 1: A thundering herd collapsed to one call
+func singleFlightHerdExample() {
+	fmt.Println("\n--- Example 1: A Thundering Herd Collapsed to One Call ---")
+
+	// 20 requests for the same profile arrive while it is being loaded; the
+	// slow backend (12_cache_loader.go) counts how often it is really hit
+	backend := &slowBackend{latency: 80 * time.Millisecond}
+	group := NewSingleFlight[string, string]()
+
+	var wg sync.WaitGroup
+	var shared atomic.Int64
+	results := make([]string, 20)
+	for i := range results {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, _, wasShared := group.Do("user:42", func() (string, error) {
+				return backend.Load(context.Background(), "user:42")
+			})
+			results[i] = value
+			if wasShared {
+				shared.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("20 concurrent requests -> %d backend call(s), %d results shared, all got %q\n",
+		backend.calls.Load(), shared.Load(), results[0])
+
+	// After the call returns nothing is remembered: the next request loads again
+	value, _, _ := group.Do("user:42", func() (string, error) {
+		return backend.Load(context.Background(), "user:42")
+	})
+	fmt.Printf("A later request starts a new call: %q (backend calls: %d)\n", value, backend.calls.Load())
+}
+
+// Example 2: Waiting with a deadline
+func singleFlightDeadlineExample() {
+	fmt.Println("\n--- Example 2: Waiting with a Deadline ---")
+
+	backend := &slowBackend{latency: 120 * time.Millisecond}
+	group := NewSingleFlight[string, string]()
+	load := func() (string, error) { return backend.Load(context.Background(), "report") }
+
+	// An impatient caller gives up after 40ms; the call keeps running and a
+	// patient caller that joined it gets the result without a second load
+	impatient := group.DoChan("report", load)
+	patient := group.DoChan("report", load)
+
+	select {
+	case r := <-impatient:
+		fmt.Printf("Impatient caller: %q\n", r.Value)
+	case <-time.After(40 * time.Millisecond):
+		fmt.Println("Impatient caller: gave up after 40ms")
+	}
+	r := <-patient
+	fmt.Printf("Patient caller: %q shared=%t (backend calls: %d)\n", r.Value, r.Shared, backend.calls.Load())
+}
+
+// Example 3: Memoization with a TTL
+func memoCacheExample() {
+	fmt.Println("\n--- Example 3: Memoization with a TTL ---")
+
+	backend := &slowBackend{latency: 50 * time.Millisecond}
+	cache := NewMemoCache(100*time.Millisecond, backend.Load)
+	ctx := context.Background()
+
+	timed := func(label string) {
+		start := time.Now()
+		value, err := cache.Get(ctx, "price:BTC")
+		fmt.Printf("  %-26s %-14q err=%v in %v\n", label, value, err, time.Since(start).Round(10*time.Millisecond))
+	}
+	timed("first Get (miss)")
+	timed("second Get (hit)")
+	time.Sleep(110 * time.Millisecond)
+	timed("after the TTL (reload)")
+
+	// A burst of concurrent misses for a new key still costs one call
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.Get(ctx, "price:ETH")
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("10 concurrent misses for a new key; %s, backend calls %d\n", cache.Stats(), backend.calls.Load())
+}
+
+// Example 4: Errors, panics and invalidation
+func memoCacheFailureExample() {
+	fmt.Println("\n--- Example 4: Errors, Panics and Invalidation ---")
+
+	var attempts atomic.Int64
+	flaky := func(ctx context.Context, key string) (int, error) {
+		n := attempts.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		switch {
+		case key == "boom":
+			panic("nil pointer in the backend client")
+		case n == 1:
+			return 0, errors.New("connection reset")
+		}
+		return int(n) * 100, nil
+	}
+	cache := NewMemoCache(time.Minute, flaky)
+	ctx := context.Background()
+
+	// Errors are not cached: the retry calls the function again
+	_, err := cache.Get(ctx, "quota")
+	fmt.Printf("First Get: err=%v\n", err)
+	value, err := cache.Get(ctx, "quota")
+	fmt.Printf("Retry: %d err=%v\n", value, err)
+	value, _ = cache.Get(ctx, "quota")
+	fmt.Printf("Cached: %d (calls so far: %d)\n", value, attempts.Load())
+
+	// A panic reaches every waiter as an error
+	_, err = cache.Get(ctx, "boom")
+	fmt.Printf("Panicking call: %v (is ErrSingleFlightPanic: %t)\n", err, errors.Is(err, ErrSingleFlightPanic))
+
+	// Invalidate forces the next Get to recompute
+	cache.Invalidate("quota")
+	value, _ = cache.Get(ctx, "quota")
+	fmt.Printf("After Invalidate: %d; %s\n", value, cache.Stats())
+}
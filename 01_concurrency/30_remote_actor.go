@@ -0,0 +1,979 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Remote actor examples: actors in two processes exchanging JSON messages
+// over TCP, addressed through ActorRefs that work the same whether the actor
+// is local or remote
+
+var (
+	// ErrUnknownActor is returned for a path that names no registered actor
+	ErrUnknownActor = errors.New("remote: unknown actor")
+	// ErrUnknownMessageType is returned for a message type the codec cannot encode or decode
+	ErrUnknownMessageType = errors.New("remote: unknown message type")
+	// ErrRemoteDisconnected fails the Asks in flight when a connection drops
+	ErrRemoteDisconnected = errors.New("remote: connection lost")
+	// ErrRemoteBacklog is returned when too many messages wait for a connection
+	ErrRemoteBacklog = errors.New("remote: outbox full")
+	// ErrNodeClosed is returned by a node after Close
+	ErrNodeClosed = errors.New("remote: node closed")
+)
+
+// remoteErrors are the errors that keep their identity across the wire, so
+// errors.Is works on a remote reply as it does on a local one
+var remoteErrors = []error{
+	ErrAskTimeout, ErrNoReplyHandler, ErrActorStopped, ErrMailboxFull,
+	ErrUnknownActor, ErrUnknownMessageType,
+}
+
+const (
+	remoteOutboxSize = 64
+	remoteMinBackoff = 50 * time.Millisecond
+	remoteMaxBackoff = time.Second
+)
+
+// ActorRef addresses an actor without saying where it runs. Code written
+// against it works unchanged whether the actor is in this process or on
+// another node.
+type ActorRef interface {
+	Path() string // "name" for a local actor, "name@host:port" for a remote one
+	Tell(msg Message) error
+	Ask(msg Message, timeout time.Duration) (*Future, error)
+}
+
+// localRef is an ActorRef to an actor in this process
+type localRef struct {
+	name  string
+	actor *Actor
+}
+
+func (r localRef) Path() string {
+	return r.name
+}
+
+func (r localRef) Tell(msg Message) error {
+	return r.actor.TrySend(msg)
+}
+
+func (r localRef) Ask(msg Message, timeout time.Duration) (*Future, error) {
+	return r.actor.Ask(msg, timeout)
+}
+
+// RemotePingMessage asks the receiver to answer with a PongMessage sent to
+// the actor at ReplyTo; a path, unlike a channel, can cross the wire
+type RemotePingMessage struct {
+	Count   int
+	ReplyTo string
+}
+
+func (m RemotePingMessage) Type() string {
+	return "remote-ping"
+}
+
+// envelope is one JSON line on the wire. A request names the target actor
+// and carries the message; an Ask also carries an ID and a timeout, and its
+// reply comes back with the same ID and either a payload or an error.
+type envelope struct {
+	ID        uint64          `json:"id,omitempty"`
+	To        string          `json:"to,omitempty"`
+	Type      string          `json:"type,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	TimeoutMs int64           `json:"timeout_ms,omitempty"`
+	Reply     bool            `json:"reply,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// messageDecoder turns a payload back into the Go type it was encoded from
+type messageDecoder func(payload json.RawMessage) (Message, error)
+
+// ActorNode is one process's actor system as seen from the network: a
+// registry of named actors, a TCP listener serving them to other nodes, and
+// a connection per remote node this one has looked up. Messages are
+// delivered at most once: a message written to a connection that then breaks
+// is lost, as with any send to a remote process that may crash.
+type ActorNode struct {
+	Name string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	actors   map[string]*Actor
+	decoders map[string]messageDecoder
+	remotes  map[string]*remoteNode // Keyed by address
+	listener net.Listener
+	conns    map[net.Conn]struct{} // Accepted connections, closed by Close
+}
+
+// NewActorNode creates a node that knows the JSON-friendly message types of
+// 07_actor.go; register others with RegisterRemoteMessage
+func NewActorNode(name string) *ActorNode {
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &ActorNode{
+		Name:     name,
+		ctx:      ctx,
+		cancel:   cancel,
+		actors:   make(map[string]*Actor),
+		decoders: make(map[string]messageDecoder),
+		remotes:  make(map[string]*remoteNode),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	RegisterRemoteMessage[StringMessage](n)
+	RegisterRemoteMessage[NumberMessage](n)
+	RegisterRemoteMessage[PingMessage](n)
+	RegisterRemoteMessage[PongMessage](n)
+	RegisterRemoteMessage[SetMessage](n)
+	RegisterRemoteMessage[LookupMessage](n)
+	return n
+}
+
+// RegisterRemoteMessage lets messages of type M travel to and from node n.
+// M must survive a JSON round trip: GetMessage, with its Response channel,
+// cannot, and interface{} fields come back as JSON's own types.
+func RegisterRemoteMessage[M Message](n *ActorNode) {
+	var zero M
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.decoders[zero.Type()] = func(payload json.RawMessage) (Message, error) {
+		var m M
+		err := json.Unmarshal(payload, &m)
+		return m, err
+	}
+}
+
+// logf prints an event of the node's own, such as a connection change
+func (n *ActorNode) logf(format string, args ...interface{}) {
+	fmt.Printf("  [%s] %s\n", n.Name, fmt.Sprintf(format, args...))
+}
+
+// Register makes a started actor reachable under name, locally and from
+// other nodes once the node listens
+func (n *ActorNode) Register(name string, a *Actor) ActorRef {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.actors[name] = a
+	return localRef{name: name, actor: a}
+}
+
+// Addr returns the address the node listens on, or "" before Listen
+func (n *ActorNode) Addr() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.listener == nil {
+		return ""
+	}
+	return n.listener.Addr().String()
+}
+
+// Lookup resolves a path to an ActorRef. "name" and "name@<own address>"
+// are local actors; "name@host:port" is a remote one, whose existence is
+// only checked when a message reaches that node.
+func (n *ActorNode) Lookup(path string) (ActorRef, error) {
+	name, addr, remote := strings.Cut(path, "@")
+	if remote && addr != n.Addr() {
+		r, err := n.remote(addr)
+		if err != nil {
+			return nil, err
+		}
+		return remoteRef{name: name, node: r}, nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	a, ok := n.actors[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownActor, path)
+	}
+	return localRef{name: name, actor: a}, nil
+}
+
+// Connected reports whether the node currently has a connection to addr
+func (n *ActorNode) Connected(addr string) bool {
+	n.mu.Lock()
+	r, ok := n.remotes[addr]
+	n.mu.Unlock()
+	if !ok {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.connected
+}
+
+// remote returns the connection to addr, starting it on first use
+func (n *ActorNode) remote(addr string) (*remoteNode, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ctx.Err() != nil {
+		return nil, ErrNodeClosed
+	}
+	r, ok := n.remotes[addr]
+	if !ok {
+		r = &remoteNode{
+			addr:    addr,
+			owner:   n,
+			outbox:  make(chan envelope, remoteOutboxSize),
+			pending: make(map[uint64]*Future),
+		}
+		n.remotes[addr] = r
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			r.run(n.ctx)
+		}()
+	}
+	return r, nil
+}
+
+// Listen serves the node's actors on addr ("127.0.0.1:0" picks a free port)
+// and returns the address it got
+func (n *ActorNode) Listen(addr string) (string, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	n.mu.Lock()
+	n.listener = l
+	n.mu.Unlock()
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return // Close closed the listener
+			}
+			n.mu.Lock()
+			if n.ctx.Err() != nil {
+				n.mu.Unlock()
+				conn.Close()
+				return
+			}
+			n.conns[conn] = struct{}{}
+			n.mu.Unlock()
+
+			n.wg.Add(1)
+			go func() {
+				defer n.wg.Done()
+				n.serve(conn)
+			}()
+		}
+	}()
+	return l.Addr().String(), nil
+}
+
+// Close stops listening, drops every connection and fails the Asks still
+// waiting on one. The registered actors keep running; their owner stops them.
+func (n *ActorNode) Close() {
+	n.mu.Lock()
+	n.cancel()
+	if n.listener != nil {
+		n.listener.Close()
+	}
+	for conn := range n.conns {
+		conn.Close()
+	}
+	n.mu.Unlock()
+	n.wg.Wait()
+}
+
+// serve delivers the requests arriving on an accepted connection, in order,
+// and writes back the replies to its Asks as they complete
+func (n *ActorNode) serve(conn net.Conn) {
+	var asks sync.WaitGroup
+	defer func() {
+		asks.Wait()
+		conn.Close()
+		n.mu.Lock()
+		delete(n.conns, conn)
+		n.mu.Unlock()
+	}()
+
+	var writeMu sync.Mutex
+	enc := json.NewEncoder(conn)
+	reply := func(env envelope) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		enc.Encode(env) // A failed write means the peer is gone; its reader notices
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	for {
+		var env envelope
+		if err := dec.Decode(&env); err != nil {
+			return
+		}
+		future, err := n.deliver(env)
+		switch {
+		case env.ID == 0:
+			if err != nil {
+				n.logf("dropped %s message for %s: %v", env.Type, env.To, err)
+			}
+		case err != nil:
+			reply(replyEnvelope(env.ID, nil, err))
+		default:
+			asks.Add(1)
+			go func(id uint64) {
+				defer asks.Done()
+				result, err := future.Get() // The actor's Ask timeout bounds the wait
+				reply(replyEnvelope(id, result, err))
+			}(env.ID)
+		}
+	}
+}
+
+// deliver hands a request to the named local actor: Tell for a request
+// without an ID, Ask otherwise
+func (n *ActorNode) deliver(env envelope) (*Future, error) {
+	n.mu.Lock()
+	a, ok := n.actors[env.To]
+	n.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q on %s", ErrUnknownActor, env.To, n.Name)
+	}
+	msg, err := n.decode(env.Type, env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if env.ID == 0 {
+		return nil, a.TrySend(msg)
+	}
+	timeout := time.Duration(env.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	return a.Ask(msg, timeout)
+}
+
+// decode rebuilds a message of a registered type
+func (n *ActorNode) decode(msgType string, payload json.RawMessage) (Message, error) {
+	n.mu.Lock()
+	decoder, ok := n.decoders[msgType]
+	n.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMessageType, msgType)
+	}
+	return decoder(payload)
+}
+
+// encodeMessage wraps msg in a request envelope for the actor named to
+func (n *ActorNode) encodeMessage(to string, msg Message) (envelope, error) {
+	n.mu.Lock()
+	_, ok := n.decoders[msg.Type()]
+	n.mu.Unlock()
+	if !ok {
+		return envelope{}, fmt.Errorf("%w %q", ErrUnknownMessageType, msg.Type())
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return envelope{}, err
+	}
+	return envelope{To: to, Type: msg.Type(), Payload: payload}, nil
+}
+
+// replyEnvelope encodes the outcome of an Ask. A result that is a Message
+// keeps its type name so the asker can decode it back into that type.
+func replyEnvelope(id uint64, result interface{}, err error) envelope {
+	env := envelope{ID: id, Reply: true}
+	if err == nil {
+		if msg, ok := result.(Message); ok {
+			env.Type = msg.Type()
+		}
+		env.Payload, err = json.Marshal(result)
+	}
+	if err != nil {
+		env.Error = err.Error()
+	}
+	return env
+}
+
+// remoteError rebuilds an error from its text, wrapping the sentinel it
+// started from so errors.Is still matches
+func remoteError(text string) error {
+	for _, sentinel := range remoteErrors {
+		if strings.HasPrefix(text, sentinel.Error()) {
+			return fmt.Errorf("%w%s", sentinel, strings.TrimPrefix(text, sentinel.Error()))
+		}
+	}
+	return fmt.Errorf("remote: %s", text)
+}
+
+// remoteNode is this node's connection to another node. One goroutine owns
+// the TCP connection: it dials with exponential backoff, writes the outbox,
+// and redials whenever the connection breaks, so refs to the other node
+// survive a restart of the process behind it. Messages sent while it is
+// disconnected wait in the outbox.
+type remoteNode struct {
+	addr   string
+	owner  *ActorNode
+	outbox chan envelope
+
+	mu        sync.Mutex
+	connected bool
+	nextID    uint64
+	pending   map[uint64]*Future // Asks waiting for a reply, by ID
+}
+
+// remoteRef is an ActorRef to an actor on another node
+type remoteRef struct {
+	name string
+	node *remoteNode
+}
+
+func (r remoteRef) Path() string {
+	return r.name + "@" + r.node.addr
+}
+
+// Tell queues msg for the remote actor. A nil error means the message was
+// accepted for sending, not that it arrived.
+func (r remoteRef) Tell(msg Message) error {
+	env, err := r.node.owner.encodeMessage(r.name, msg)
+	if err != nil {
+		return err
+	}
+	return r.node.enqueue(env)
+}
+
+// Ask sends msg and returns a Future completed with the remote reply, or
+// with ErrAskTimeout, or with ErrRemoteDisconnected if the connection drops
+// first. The remote actor applies the same timeout to its own Ask.
+func (r remoteRef) Ask(msg Message, timeout time.Duration) (*Future, error) {
+	env, err := r.node.owner.encodeMessage(r.name, msg)
+	if err != nil {
+		return nil, err
+	}
+	future := NewFuture()
+	r.node.mu.Lock()
+	r.node.nextID++
+	env.ID = r.node.nextID
+	r.node.pending[env.ID] = future
+	r.node.mu.Unlock()
+	env.TimeoutMs = timeout.Milliseconds()
+
+	if err := r.node.enqueue(env); err != nil {
+		r.node.forget(env.ID)
+		return nil, err
+	}
+
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			future.SetError(ErrAskTimeout)
+		case <-future.Done():
+		}
+		r.node.forget(env.ID) // A late reply finds no one waiting
+	}()
+	return future, nil
+}
+
+// enqueue puts a request in the outbox without blocking
+func (r *remoteNode) enqueue(env envelope) error {
+	if r.owner.ctx.Err() != nil {
+		return ErrNodeClosed
+	}
+	select {
+	case r.outbox <- env:
+		return nil
+	default:
+		return ErrRemoteBacklog
+	}
+}
+
+func (r *remoteNode) forget(id uint64) {
+	r.mu.Lock()
+	delete(r.pending, id)
+	r.mu.Unlock()
+}
+
+// run keeps a connection to the remote node until ctx ends
+func (r *remoteNode) run(ctx context.Context) {
+	defer r.failPending(ErrNodeClosed)
+
+	backoff := remoteMinBackoff
+	var dialer net.Dialer
+	for ctx.Err() == nil {
+		conn, err := dialer.DialContext(ctx, "tcp", r.addr)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.owner.logf("cannot reach %s, retrying in %v", r.addr, backoff)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			backoff = min(backoff*2, remoteMaxBackoff)
+			continue
+		}
+
+		backoff = remoteMinBackoff
+		r.setConnected(true)
+		r.owner.logf("connected to %s", r.addr)
+		r.session(ctx, conn)
+		r.setConnected(false)
+		r.failPending(ErrRemoteDisconnected)
+		if ctx.Err() == nil {
+			r.owner.logf("lost connection to %s", r.addr)
+		}
+	}
+}
+
+// session writes the outbox to conn while a reader completes the Asks, and
+// returns when either side of the connection fails or ctx ends
+func (r *remoteNode) session(ctx context.Context, conn net.Conn) {
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		dec := json.NewDecoder(bufio.NewReader(conn))
+		for {
+			var env envelope
+			if err := dec.Decode(&env); err != nil {
+				return
+			}
+			r.complete(env)
+		}
+	}()
+	defer func() {
+		conn.Close() // Unblocks the reader
+		<-readerDone
+	}()
+
+	enc := json.NewEncoder(conn)
+	for {
+		select {
+		case env := <-r.outbox:
+			if err := enc.Encode(env); err != nil {
+				return // env is lost; an Ask for it fails with the others
+			}
+		case <-readerDone:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// complete settles the Ask a reply belongs to
+func (r *remoteNode) complete(env envelope) {
+	r.mu.Lock()
+	future, ok := r.pending[env.ID]
+	delete(r.pending, env.ID)
+	r.mu.Unlock()
+	if !ok || !env.Reply {
+		return
+	}
+
+	switch {
+	case env.Error != "":
+		future.SetError(remoteError(env.Error))
+	case env.Type != "":
+		msg, err := r.owner.decode(env.Type, env.Payload)
+		if err != nil {
+			future.SetError(err)
+			return
+		}
+		future.SetResult(msg)
+	default:
+		// Plain values come back as JSON types: numbers are float64
+		var value interface{}
+		if err := json.Unmarshal(env.Payload, &value); err != nil {
+			future.SetError(err)
+			return
+		}
+		future.SetResult(value)
+	}
+}
+
+func (r *remoteNode) setConnected(connected bool) {
+	r.mu.Lock()
+	r.connected = connected
+	r.mu.Unlock()
+}
+
+// failPending fails every Ask still waiting for a reply
+func (r *remoteNode) failPending(err error) {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[uint64]*Future)
+	r.mu.Unlock()
+	for _, future := range pending {
+		future.SetError(err)
+	}
+}
+
+// newStoreActor creates a key-value store: SetMessage stores a value,
+// NumberMessage adds to "counter", and an Ask with LookupMessage reads a key
+func newStoreActor() *Actor {
+	store := NewActor("store")
+	state := map[string]interface{}{"counter": 0}
+	store.RegisterHandler("set", func(msg Message) {
+		set := msg.(SetMessage)
+		state[set.Key] = set.Value
+	})
+	store.RegisterHandler("number", func(msg Message) {
+		state["counter"] = state["counter"].(int) + msg.(NumberMessage).Value
+	})
+	store.RegisterReplyHandler("lookup", func(msg Message) (interface{}, error) {
+		key := msg.(LookupMessage).Key
+		value, ok := state[key]
+		if !ok {
+			return nil, fmt.Errorf("no key %q", key)
+		}
+		return value, nil
+	})
+	store.Start()
+	return store
+}
+
+// hostNodeB starts the node the examples talk to: a store and a ponger that
+// answers RemotePingMessages. It runs in a child process when one can be
+// started, and in this process otherwise.
+func hostNodeB(addr string) (*ActorNode, string, func(), error) {
+	node := NewActorNode(fmt.Sprintf("node-b pid %d", os.Getpid()))
+	RegisterRemoteMessage[RemotePingMessage](node)
+
+	store := newStoreActor()
+	ponger := NewActor("ponger")
+	ponger.RegisterHandler("remote-ping", func(msg Message) {
+		ping := msg.(RemotePingMessage)
+		node.logf("ping %d, replying to %s", ping.Count, ping.ReplyTo)
+		ref, err := node.Lookup(ping.ReplyTo)
+		if err == nil {
+			err = ref.Tell(PongMessage{Count: ping.Count})
+		}
+		if err != nil {
+			node.logf("cannot reply: %v", err)
+		}
+	})
+	ponger.Start()
+	node.Register("store", store)
+	node.Register("ponger", ponger)
+
+	shutdown := func() {
+		node.Close()
+		store.Stop()
+		ponger.Stop()
+	}
+	listening, err := node.Listen(addr)
+	if err != nil {
+		shutdown()
+		return nil, "", nil, err
+	}
+	return node, listening, shutdown, nil
+}
+
+// remoteListeningPrefix starts the line with which a child process announces
+// its address
+const remoteListeningPrefix = "node-b listening on "
+
+// runNodeB is the whole life of the child process: it hosts node B on addr
+// until its standard input closes, which happens when the parent stops it
+// or exits
+func runNodeB(addr string) {
+	_, listening, shutdown, err := hostNodeB(addr)
+	if err != nil {
+		fmt.Printf("node-b: %v\n", err)
+		return
+	}
+	fmt.Println(remoteListeningPrefix + listening)
+	io.Copy(io.Discard, os.Stdin)
+	shutdown()
+}
+
+// nodeBProcess is node B, running as a child process of this example or,
+// when that is not possible, inside it
+type nodeBProcess struct {
+	addr     string
+	pid      int
+	stop     func() // Lets node B shut down cleanly
+	kill     func() // Ends it abruptly, as a crash would
+	external bool
+}
+
+// startNodeB starts node B listening on addr
+func startNodeB(addr string) (*nodeBProcess, error) {
+	p, err := spawnNodeB(addr)
+	if err == nil {
+		return p, nil
+	}
+	fmt.Printf("Could not start a second process (%v); running node B in this one\n", err)
+	_, listening, shutdown, err := hostNodeB(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeBProcess{addr: listening, pid: os.Getpid(), stop: shutdown, kill: shutdown}, nil
+}
+
+// spawnNodeB runs this program again as "30 -node addr" and waits for the
+// child to announce its address. The child's output is copied to ours.
+func spawnNodeB(addr string) (*nodeBProcess, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(exe, "30", "-node", addr)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	ready := make(chan string, 1)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		lines := bufio.NewScanner(stdout)
+		announced := false
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case strings.HasPrefix(line, remoteListeningPrefix):
+				announced = true
+				ready <- strings.TrimPrefix(line, remoteListeningPrefix)
+			case announced: // Skip the banner before it
+				fmt.Println(line)
+			}
+		}
+	}()
+	wait := func() {
+		<-drained // Wait requires the output to be read first
+		cmd.Wait()
+	}
+
+	select {
+	case listening := <-ready:
+		return &nodeBProcess{
+			addr: listening,
+			pid:  cmd.Process.Pid,
+			stop: func() {
+				stdin.Close()
+				wait()
+			},
+			kill: func() {
+				cmd.Process.Kill()
+				wait()
+			},
+			external: true,
+		}, nil
+	case <-drained:
+		cmd.Wait()
+		return nil, errors.New("node B exited before listening")
+	case <-time.After(5 * time.Second):
+		cmd.Process.Kill()
+		wait()
+		return nil, errors.New("node B did not start listening in time")
+	}
+}
+
+// RemoteActorExamples runs all remote actor examples. "30 -node addr" runs
+// node B alone instead, which is how the examples start their second
+// process; run it by hand to watch node B in its own terminal.
+func RemoteActorExamples() {
+	fmt.Println("=== Remote Actor Examples ===")
+
+	flags := flag.NewFlagSet("remote", flag.ContinueOnError)
+	nodeAddr := flags.String("node", "", "host node B on this address instead of running the examples")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		return
+	}
+	if *nodeAddr != "" {
+		runNodeB(*nodeAddr)
+		return
+	}
+
+	nodeA := NewActorNode("node-a")
+	RegisterRemoteMessage[RemotePingMessage](nodeA)
+	if _, err := nodeA.Listen("127.0.0.1:0"); err != nil {
+		fmt.Printf("node-a cannot listen: %v\n", err)
+		nodeA.Close()
+		return
+	}
+	nodeB, err := startNodeB("127.0.0.1:0")
+	if err != nil {
+		fmt.Printf("Cannot start node B: %v\n", err)
+		nodeA.Close()
+		return
+	}
+	defer func() {
+		nodeA.Close()
+		nodeB.stop() // Example 3 replaces nodeB
+	}()
+	fmt.Printf("node-a (pid %d) listens on %s, node-b (pid %d) on %s\n",
+		os.Getpid(), nodeA.Addr(), nodeB.pid, nodeB.addr)
+
+	// Example 1: Location transparency
+	locationTransparencyExample(nodeA, nodeB)
+
+	// Example 2: Messages in both directions
+	twoWayRemoteExample(nodeA, nodeB)
+
+	// Example 3: Reconnecting after a crash
+	nodeB = remoteReconnectExample(nodeA, nodeB)
+}
+
+// useStore is client code that only knows an ActorRef
+func useStore(ref ActorRef) {
+	ref.Tell(SetMessage{Key: "greeting", Value: "hello"})
+	for i := 1; i <= 3; i++ {
+		ref.Tell(NumberMessage{Value: i})
+	}
+	for _, key := range []string{"greeting", "counter", "missing"} {
+		future, err := ref.Ask(LookupMessage{Key: key}, time.Second)
+		if err != nil {
+			fmt.Printf("  %-22s lookup %-8s failed: %v\n", ref.Path(), key, err)
+			continue
+		}
+		value, err := future.Get()
+		if err != nil {
+			fmt.Printf("  %-22s lookup %-8s -> error: %v\n", ref.Path(), key, err)
+			continue
+		}
+		fmt.Printf("  %-22s lookup %-8s -> %v\n", ref.Path(), key, value)
+	}
+}
+
+// Example 1: Location transparency
+func locationTransparencyExample(nodeA *ActorNode, nodeB *nodeBProcess) {
+	fmt.Println("\n--- Example 1: Location Transparency ---")
+
+	localStore := newStoreActor()
+	defer localStore.Stop()
+	local := nodeA.Register("store", localStore)
+	remote, err := nodeA.Lookup("store@" + nodeB.addr)
+	if err != nil {
+		fmt.Printf("Lookup failed: %v\n", err)
+		return
+	}
+
+	// The same function, once against each ref. Ordering holds remotely
+	// too: one connection delivers a sender's messages in order.
+	fmt.Println("The same client code against a local and a remote store:")
+	useStore(local)
+	useStore(remote)
+
+	// Errors keep their identity across the wire
+	for _, ref := range []ActorRef{local, remote} {
+		var err error
+		if future, askErr := ref.Ask(StringMessage{Content: "hi"}, time.Second); askErr != nil {
+			err = askErr // Known locally before sending
+		} else {
+			_, err = future.Get() // Known only when the remote node answers
+		}
+		fmt.Printf("  %-22s ask with a StringMessage: errors.Is(err, ErrNoReplyHandler)=%t\n",
+			ref.Path(), errors.Is(err, ErrNoReplyHandler))
+	}
+	missing, _ := nodeA.Lookup("nobody@" + nodeB.addr)
+	future, _ := missing.Ask(LookupMessage{Key: "x"}, time.Second)
+	_, err = future.Get()
+	fmt.Printf("  %-22s %v\n", missing.Path(), err)
+}
+
+// Example 2: Messages in both directions
+func twoWayRemoteExample(nodeA *ActorNode, nodeB *nodeBProcess) {
+	fmt.Println("\n--- Example 2: Messages in Both Directions ---")
+
+	// node-b's ponger answers each ping by looking up the ReplyTo path, so
+	// it dials back to node-a: each node is a client and a server
+	const pings = 3
+	pongs := make(chan int, pings)
+	receiver := NewActor("pongs")
+	receiver.RegisterHandler("pong", func(msg Message) {
+		pongs <- msg.(PongMessage).Count
+	})
+	receiver.Start()
+	defer receiver.Stop()
+	nodeA.Register("pongs", receiver)
+
+	ponger, _ := nodeA.Lookup("ponger@" + nodeB.addr)
+	for i := 1; i <= pings; i++ {
+		ponger.Tell(RemotePingMessage{Count: i, ReplyTo: "pongs@" + nodeA.Addr()})
+	}
+	timeout := time.After(2 * time.Second)
+	for i := 0; i < pings; i++ {
+		select {
+		case count := <-pongs:
+			fmt.Printf("node-a received pong %d from another process\n", count)
+		case <-timeout:
+			fmt.Println("node-a gave up waiting for pongs")
+			return
+		}
+	}
+}
+
+// Example 3: Reconnecting after a crash
+func remoteReconnectExample(nodeA *ActorNode, nodeB *nodeBProcess) *nodeBProcess {
+	fmt.Println("\n--- Example 3: Reconnecting After a Crash ---")
+
+	store, _ := nodeA.Lookup("store@" + nodeB.addr)
+	lookup := func(key string, timeout time.Duration) {
+		future, err := store.Ask(LookupMessage{Key: key}, timeout)
+		if err == nil {
+			var value interface{}
+			value, err = future.Get()
+			if err == nil {
+				fmt.Printf("lookup %s -> %v\n", key, value)
+				return
+			}
+		}
+		fmt.Printf("lookup %s failed: %v\n", key, err)
+	}
+	store.Tell(SetMessage{Key: "session", Value: "abc123"})
+	lookup("session", time.Second)
+
+	if !nodeB.external {
+		fmt.Println("node B runs in this process; skipping the crash")
+		return nodeB
+	}
+	fmt.Printf("Killing node-b (pid %d)\n", nodeB.pid)
+	nodeB.kill()
+	for nodeA.Connected(nodeB.addr) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// While node-b is down the ref still works: Asks time out, Tells wait in
+	// the outbox
+	lookup("session", 150*time.Millisecond)
+	fmt.Printf("Tell while down: err=%v\n", store.Tell(NumberMessage{Value: 10}))
+	time.Sleep(200 * time.Millisecond) // Let a few redials fail
+
+	restarted, err := spawnNodeB(nodeB.addr)
+	if err != nil {
+		fmt.Printf("Cannot restart node B: %v\n", err)
+		return &nodeBProcess{addr: nodeB.addr, stop: func() {}, kill: func() {}}
+	}
+	fmt.Printf("Restarted node-b as pid %d on the same address\n", restarted.pid)
+	for !nodeA.Connected(restarted.addr) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Same ref, new process: the queued Tell arrived, the old state did not survive
+	lookup("counter", time.Second)
+	lookup("session", time.Second)
+	return restarted
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dynamic select examples: reflect.Select over a set of channels decided at
+// run time, with cases added and removed while the loop is running
+
+var (
+	// ErrNotChannel is returned when a case is added for a value that is not a channel
+	ErrNotChannel = errors.New("dynselect: not a channel")
+	// ErrChannelDirection is returned for a receive from a send-only channel or the reverse
+	ErrChannelDirection = errors.New("dynselect: wrong channel direction")
+	// ErrSendType is returned when a send value does not fit the channel's element type
+	ErrSendType = errors.New("dynselect: value does not fit the channel")
+	// ErrDuplicateCase is returned when a case name is already in use
+	ErrDuplicateCase = errors.New("dynselect: case name already in use")
+)
+
+// DynamicEvent reports a case that fired
+type DynamicEvent struct {
+	Name   string
+	Dir    reflect.SelectDir // reflect.SelectRecv or reflect.SelectSend
+	Value  reflect.Value     // The received value; invalid for sends and closed channels
+	Closed bool              // A receive found the channel closed
+}
+
+// dynamicCase is a named entry of the case set
+type dynamicCase struct {
+	name string
+	sc   reflect.SelectCase
+}
+
+// DynamicSelect multiplexes over a set of channels that can change while it
+// runs. A select statement fixes its cases when the program is compiled;
+// reflect.Select takes them as a slice, so the set can be whatever the
+// program decides, of any channel types. Cases may be added and removed from
+// any goroutine, the event handler included; a change wakes a blocked Next,
+// which then selects over the new set.
+type DynamicSelect struct {
+	mu      sync.Mutex
+	cases   []dynamicCase
+	built   []reflect.SelectCase // Cases passed to reflect.Select; nil after a change
+	changed chan struct{}        // Wakes a blocked Next after a change
+}
+
+// NewDynamicSelect creates an empty case set
+func NewDynamicSelect() *DynamicSelect {
+	return &DynamicSelect{changed: make(chan struct{}, 1)}
+}
+
+// AddRecv adds a case receiving from ch, which may be a channel of any type
+func (d *DynamicSelect) AddRecv(name string, ch interface{}) error {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan {
+		return fmt.Errorf("%w: %s is %T", ErrNotChannel, name, ch)
+	}
+	if v.Type().ChanDir()&reflect.RecvDir == 0 {
+		return fmt.Errorf("%w: cannot receive from %s (%s)", ErrChannelDirection, name, v.Type())
+	}
+	return d.add(name, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: v})
+}
+
+// AddSend adds a case sending value on ch. It fires at most once: the case
+// is removed when the send happens.
+func (d *DynamicSelect) AddSend(name string, ch, value interface{}) error {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan {
+		return fmt.Errorf("%w: %s is %T", ErrNotChannel, name, ch)
+	}
+	if v.Type().ChanDir()&reflect.SendDir == 0 {
+		return fmt.Errorf("%w: cannot send on %s (%s)", ErrChannelDirection, name, v.Type())
+	}
+	elem := v.Type().Elem()
+	send := reflect.ValueOf(value)
+	switch {
+	case !send.IsValid(): // A nil interface sends the element type's zero value
+		send = reflect.Zero(elem)
+	case !send.Type().AssignableTo(elem):
+		return fmt.Errorf("%w: cannot send %s on %s (%s)", ErrSendType, send.Type(), name, v.Type())
+	}
+	return d.add(name, reflect.SelectCase{Dir: reflect.SelectSend, Chan: v, Send: send})
+}
+
+func (d *DynamicSelect) add(name string, sc reflect.SelectCase) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, c := range d.cases {
+		if c.name == name {
+			return fmt.Errorf("%w: %s", ErrDuplicateCase, name)
+		}
+	}
+	d.cases = append(d.cases, dynamicCase{name: name, sc: sc})
+	d.changedLocked()
+	return nil
+}
+
+// Remove drops the case named name and reports whether there was one
+func (d *DynamicSelect) Remove(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, c := range d.cases {
+		if c.name == name {
+			d.cases = append(d.cases[:i:i], d.cases[i+1:]...) // A copy: Next may hold the old slice
+			d.changedLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of cases
+func (d *DynamicSelect) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.cases)
+}
+
+// Names returns the case names in the order they were added
+func (d *DynamicSelect) Names() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	names := make([]string, len(d.cases))
+	for i, c := range d.cases {
+		names[i] = c.name
+	}
+	return names
+}
+
+// changedLocked drops the built cases and wakes a blocked Next
+func (d *DynamicSelect) changedLocked() {
+	d.built = nil
+	select {
+	case d.changed <- struct{}{}:
+	default: // A wake-up is already pending
+	}
+}
+
+// Next blocks until a case fires or ctx ends. A receive that finds its
+// channel closed and a send that happened remove their case, so a loop over
+// Next ends up with only live cases. One goroutine at a time calls Next.
+func (d *DynamicSelect) Next(ctx context.Context) (DynamicEvent, error) {
+	for {
+		// Cases 0 and 1 are fixed: cancellation and the change notification
+		d.mu.Lock()
+		if d.built == nil {
+			d.built = make([]reflect.SelectCase, 2, len(d.cases)+2)
+			d.built[1] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(d.changed)}
+			for _, c := range d.cases {
+				d.built = append(d.built, c.sc)
+			}
+		}
+		cases := d.built
+		entries := d.cases
+		d.mu.Unlock()
+		// ctx.Done() is nil for a context that cannot be cancelled, and
+		// reflect.Select, like select, never picks a nil channel
+		cases[0] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+
+		chosen, value, ok := reflect.Select(cases)
+		switch chosen {
+		case 0:
+			return DynamicEvent{}, ctx.Err()
+		case 1:
+			continue // The set changed; select over the new one
+		}
+
+		entry := entries[chosen-2]
+		event := DynamicEvent{Name: entry.name, Dir: entry.sc.Dir}
+		switch {
+		case entry.sc.Dir == reflect.SelectSend:
+			d.removeCase(entry)
+		case !ok:
+			event.Closed = true
+			d.removeCase(entry)
+		default:
+			event.Value = value
+		}
+		return event, nil
+	}
+}
+
+// removeCase removes entry unless it was already removed, or replaced by a
+// new case with the same name, while the select was running
+func (d *DynamicSelect) removeCase(entry dynamicCase) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, c := range d.cases {
+		if c.name == entry.name && c.sc.Chan.Equal(entry.sc.Chan) {
+			d.cases = append(d.cases[:i:i], d.cases[i+1:]...)
+			d.changedLocked()
+			return
+		}
+	}
+}
+
+// DynamicSelectExamples runs all dynamic select examples
+func DynamicSelectExamples() {
+	fmt.Println("=== Dynamic Select Examples ===")
+
+	// Example 1: A case set built at run time
+	dynamicFanInExample()
+
+	// Example 2: Adding and removing cases while running
+	dynamicSubscriptionsExample()
+
+	// Example 3: Send cases
+	dynamicDispatchExample()
+
+	// Example 4: Type checks and cost
+	dynamicSelectCostExample()
+}
+
+// Example 1: A case set built at run time
+func dynamicFanInExample() {
+	fmt.Println("\n--- Example 1: A Case Set Built at Run Time ---")
+
+	// The sources come from configuration, so their number and even their
+	// element types are unknown when the code is written
+	ticks := make(chan int)
+	names := make(chan string)
+	errs := make(chan error)
+	go func() {
+		defer close(ticks)
+		for i := 1; i <= 3; i++ {
+			ticks <- i
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	go func() {
+		defer close(names)
+		for _, n := range []string{"alice", "bob"} {
+			names <- n
+			time.Sleep(25 * time.Millisecond)
+		}
+	}()
+	go func() {
+		defer close(errs)
+		time.Sleep(15 * time.Millisecond)
+		errs <- errors.New("disk full")
+	}()
+
+	sel := NewDynamicSelect()
+	sel.AddRecv("ticks", ticks)
+	sel.AddRecv("names", names)
+	sel.AddRecv("errors", errs)
+	fmt.Printf("Selecting over %v\n", sel.Names())
+
+	received := map[string]int{}
+	for sel.Len() > 0 {
+		event, _ := sel.Next(context.Background())
+		if event.Closed {
+			fmt.Printf("  %-6s closed; %d case(s) left\n", event.Name, sel.Len())
+			continue
+		}
+		received[event.Name]++
+		// The value arrives as a reflect.Value; Interface recovers it
+		fmt.Printf("  %-6s %-9s %v\n", event.Name, event.Value.Type(), event.Value.Interface())
+	}
+	fmt.Printf("Received per source: %v\n", received)
+}
+
+// Example 2: Adding and removing cases while running
+func dynamicSubscriptionsExample() {
+	fmt.Println("\n--- Example 2: Adding and Removing Cases While Running ---")
+
+	// A feed publishes "<name> #n" every interval until stop closes
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	feed := func(name string, interval time.Duration) <-chan string {
+		ch := make(chan string)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 1; ; n++ {
+				select {
+				case ch <- fmt.Sprintf("%s #%d", name, n):
+				case <-stop:
+					return
+				}
+				time.Sleep(interval)
+			}
+		}()
+		return ch
+	}
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	sel := NewDynamicSelect()
+	sel.AddRecv("prices", feed("prices", 20*time.Millisecond))
+	sel.AddRecv("news", feed("news", 35*time.Millisecond))
+
+	// Another goroutine changes the set while the loop below is blocked in
+	// Next: it unsubscribes from news and subscribes to weather
+	go func() {
+		time.Sleep(80 * time.Millisecond)
+		sel.Remove("news")
+		sel.AddRecv("weather", feed("weather", 30*time.Millisecond))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	seen := map[string]int{}
+	for {
+		event, err := sel.Next(ctx)
+		if err != nil {
+			break
+		}
+		name := event.Name
+		seen[name]++
+		// The handler changes the set too: the third price subscribes to alerts
+		if name == "prices" && seen[name] == 3 {
+			alerts := make(chan string, 1)
+			alerts <- "price spike"
+			sel.AddRecv("alerts", alerts)
+		}
+		if name == "alerts" {
+			sel.Remove("alerts") // One alert is enough; the channel is never closed
+		}
+		if seen[name] <= 2 || name == "alerts" {
+			fmt.Printf("  %3dms %-8s %v\n", time.Since(start).Milliseconds()/10*10, name, event.Value)
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	summary := make([]string, len(keys))
+	for i, k := range keys {
+		summary[i] = fmt.Sprintf("%s=%d", k, seen[k])
+	}
+	fmt.Printf("Events: %s; cases at the end: %v\n", strings.Join(summary, " "), sel.Names())
+}
+
+// Example 3: Send cases
+func dynamicDispatchExample() {
+	fmt.Println("\n--- Example 3: Send Cases ---")
+
+	// A dispatcher offers the next job to every idle worker at once and lets
+	// reflect.Select pick whichever is ready first. Results come back on the
+	// same select, so sends and receives share one case set.
+	type result struct {
+		worker string
+		job    int
+	}
+	speeds := map[string]time.Duration{"fast": 5 * time.Millisecond, "medium": 12 * time.Millisecond, "slow": 30 * time.Millisecond}
+	workers := []string{"fast", "medium", "slow"}
+	results := make(chan result)
+	inboxes := map[string]chan int{}
+	var wg sync.WaitGroup
+	for _, name := range workers {
+		name, inbox := name, make(chan int)
+		inboxes[name] = inbox
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range inbox {
+				time.Sleep(speeds[name])
+				results <- result{name, job}
+			}
+		}()
+	}
+
+	sel := NewDynamicSelect()
+	sel.AddRecv("results", results)
+	const jobs = 12
+	next, done := 1, 0
+	offer := func() {
+		for _, w := range workers {
+			sel.AddSend("job->"+w, inboxes[w], next)
+		}
+	}
+	offer()
+	perWorker := map[string]int{}
+	for done < jobs {
+		event, _ := sel.Next(context.Background())
+		if event.Dir == reflect.SelectRecv {
+			r := event.Value.Interface().(result)
+			perWorker[r.worker]++
+			done++
+			continue
+		}
+		// One worker took the job: withdraw the other offers, offer the next
+		for _, w := range workers {
+			sel.Remove("job->" + w)
+		}
+		if next++; next <= jobs {
+			offer()
+		}
+	}
+	for _, inbox := range inboxes {
+		close(inbox)
+	}
+	wg.Wait()
+	fmt.Printf("%d jobs: fast=%d medium=%d slow=%d; faster workers are ready more often\n",
+		jobs, perWorker["fast"], perWorker["medium"], perWorker["slow"])
+}
+
+// Example 4: Type checks and cost
+func dynamicSelectCostExample() {
+	fmt.Println("\n--- Example 4: Type Checks and Cost ---")
+
+	// reflect.Select panics on a bad case; DynamicSelect checks up front
+	sel := NewDynamicSelect()
+	sendOnly := make(chan<- int)
+	sel.AddRecv("ok", make(chan int))
+	for _, err := range []error{
+		sel.AddRecv("number", 42),
+		sel.AddRecv("send-only", sendOnly),
+		sel.AddSend("wrong type", make(chan int), "text"),
+		sel.AddRecv("ok", make(chan int)),
+	} {
+		fmt.Printf("  %v\n", err)
+	}
+
+	// The price of flexibility: reflect.Select boxes values and allocates,
+	// and a select statement compiles to direct runtime calls
+	const rounds = 20000
+	measure := func(name string, fn func()) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < rounds; i++ {
+			fn()
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		fmt.Printf("  %-34s %6d ns/op %6.1f allocs/op\n", name,
+			elapsed.Nanoseconds()/rounds, float64(after.Mallocs-before.Mallocs)/rounds)
+	}
+
+	chs := make([]chan int, 4)
+	for i := range chs {
+		chs[i] = make(chan int, 1)
+	}
+	i := 0
+	measure("select statement, 4 cases", func() {
+		chs[i%4] <- i
+		select {
+		case <-chs[0]:
+		case <-chs[1]:
+		case <-chs[2]:
+		case <-chs[3]:
+		}
+		i++
+	})
+	for _, n := range []int{4, 64} {
+		many := make([]chan int, n)
+		cases := make([]reflect.SelectCase, n)
+		for j := range many {
+			many[j] = make(chan int, 1)
+			cases[j] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(many[j])}
+		}
+		measure(fmt.Sprintf("reflect.Select, %d cases", n), func() {
+			many[i%n] <- i
+			reflect.Select(cases)
+			i++
+		})
+	}
+	fmt.Println("Use reflect.Select when the set of channels is only known at run time;")
+	fmt.Println("for a fixed set, a select statement or chans.Merge is cheaper.")
+}
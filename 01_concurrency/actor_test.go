@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestStopGracefullyWithSelfSend stops an actor whose handler is blocked
+// sending to its own full mailbox. The send must fail with ErrActorStopped
+// so the actor can drain and stop, instead of both waiting for each other.
+func TestStopGracefullyWithSelfSend(t *testing.T) {
+	a := NewActorWithOptions("self-sender", ActorOptions{MailboxSize: 1, Backpressure: BackpressureBlock})
+	filled := make(chan struct{})
+	selfSend := make(chan error, 1)
+	var processed []int
+	a.RegisterHandler("number", func(msg Message) {
+		n := msg.(NumberMessage).Value
+		processed = append(processed, n)
+		if n == 1 {
+			<-filled
+			selfSend <- a.TrySend(NumberMessage{Value: 3}) // Blocks: message 2 fills the mailbox
+		}
+	})
+	a.Start()
+
+	if err := a.TrySend(NumberMessage{Value: 1}); err != nil {
+		t.Fatalf("send 1: %v", err)
+	}
+	if err := a.TrySend(NumberMessage{Value: 2}); err != nil { // Returns once the actor took 1
+		t.Fatalf("send 2: %v", err)
+	}
+	close(filled)
+	time.Sleep(20 * time.Millisecond) // Let the handler block in its own send
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := a.StopGracefully(ctx); err != nil {
+		t.Fatalf("StopGracefully: %v", err)
+	}
+	if err := <-selfSend; !errors.Is(err, ErrActorStopped) {
+		t.Errorf("self-send during stop: got %v, want ErrActorStopped", err)
+	}
+	if len(processed) != 2 || processed[0] != 1 || processed[1] != 2 {
+		t.Errorf("processed %v, want [1 2]", processed)
+	}
+	if err := a.TrySend(NumberMessage{Value: 4}); !errors.Is(err, ErrActorStopped) {
+		t.Errorf("send after stop: got %v, want ErrActorStopped", err)
+	}
+}
+
+// TestStopReleasesBlockedSender checks that Stop releases a sender waiting
+// on a full mailbox as well
+func TestStopReleasesBlockedSender(t *testing.T) {
+	a := NewActorWithOptions("blocked", ActorOptions{MailboxSize: 1, Backpressure: BackpressureBlock})
+	release := make(chan struct{})
+	a.RegisterHandler("number", func(Message) { <-release })
+	a.Start()
+	a.TrySend(NumberMessage{Value: 1})
+	a.TrySend(NumberMessage{Value: 2})
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- a.TrySend(NumberMessage{Value: 3}) }()
+	time.Sleep(20 * time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		a.Stop()
+		close(stopped)
+	}()
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrActorStopped) {
+			t.Errorf("blocked send: got %v, want ErrActorStopped", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("blocked sender was not released by Stop")
+	}
+	close(release)
+	<-stopped
+}
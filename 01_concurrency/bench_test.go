@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// Benchmarks for the approaches BenchHarnessExamples (28_bench_harness.go)
+// compares. The harness reports latency percentiles from a single run; these
+// let the testing package pick the iteration count and report ns/op and
+// allocations. Run them with:
+//
+//	go test -bench=Contention -benchmem ./01_concurrency
+//
+// and add -cpu=1,4,8 to see how each approach scales with GOMAXPROCS.
+
+// BenchmarkContention runs every benchApproach with b.RunParallel. Each
+// parallel goroutine is one client of the approach, so channel based ones
+// get a reply channel per goroutine; every counter update is one op.
+func BenchmarkContention(b *testing.B) {
+	for _, goroutinesPerCPU := range []int{1, 8} {
+		for _, a := range benchApproaches {
+			name := fmt.Sprintf("%s/x%d", strings.ReplaceAll(a.name, " ", "-"), goroutinesPerCPU)
+			b.Run(name, func(b *testing.B) {
+				benchContention(b, a, goroutinesPerCPU)
+			})
+		}
+	}
+}
+
+func benchContention(b *testing.B, a benchApproach, goroutinesPerCPU int) {
+	cfg := benchConfig{
+		clients:  goroutinesPerCPU * runtime.GOMAXPROCS(0),
+		accounts: 16,
+	}
+	impl := a.start(cfg)
+	var nextClient, nextOp atomic.Int64
+
+	b.SetParallelism(goroutinesPerCPU)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		client := int(nextClient.Add(1) - 1)
+		for pb.Next() {
+			impl.do(client, int(nextOp.Add(1)))
+		}
+	})
+	b.StopTimer()
+
+	if total := impl.total(); total != int64(b.N) {
+		b.Errorf("%s: total %d after %d ops", a.name, total, b.N)
+	}
+	impl.stop()
+}
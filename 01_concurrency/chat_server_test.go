@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// chatTestTimeout bounds every step that waits on the server, so a deadlock
+// fails the test instead of hanging it
+const chatTestTimeout = 5 * time.Second
+
+// within runs fn and fails the test if it errors or does not return in time
+func within(t *testing.T, what string, fn func() error) {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("%s: %v", what, err)
+		}
+	case <-time.After(chatTestTimeout):
+		t.Fatalf("%s: no result after %v", what, chatTestTimeout)
+	}
+}
+
+// receivedLast reports whether text is the last event c has received
+func receivedLast(c *ChatClient, text string) bool {
+	got := c.Received()
+	return len(got) > 0 && got[len(got)-1].Text == text
+}
+
+// TestChatServer connects clients, broadcasts from several of them at once,
+// disconnects a slow one and shuts the server down, then checks what every
+// client received
+func TestChatServer(t *testing.T) {
+	opts := defaultChatOptions()
+	opts.InboxSize, opts.MaxDrops, opts.PostBurst, opts.PostRate = 64, 3, 100, 1000
+	server := NewChatServer(opts)
+
+	names := []string{"u1", "u2", "u3", "u4"}
+	clients := make([]*ChatClient, len(names))
+	for i, name := range names {
+		c, err := server.Connect(name, 0)
+		if err != nil {
+			t.Fatalf("Connect(%s): %v", name, err)
+		}
+		clients[i] = c
+	}
+	if _, err := server.Connect("u1", 0); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("duplicate Connect: got %v, want ErrNameTaken", err)
+	}
+
+	// u1..u3 in "dev", u4 only in "ops"
+	for _, c := range clients[:3] {
+		within(t, c.Name+" joins dev", func() error { return server.Join(c, "dev") })
+	}
+	within(t, "u4 joins ops", func() error { return server.Join(clients[3], "ops") })
+
+	// Concurrent posters: the room must still deliver one total order to everyone
+	within(t, "concurrent posts", func() error {
+		var wg sync.WaitGroup
+		errs := make(chan error, 3*20)
+		for _, c := range clients[:3] {
+			wg.Add(1)
+			go func(c *ChatClient) {
+				defer wg.Done()
+				for i := 0; i < 20; i++ {
+					if err := server.Post(c, "dev", fmt.Sprintf("%s-%d", c.Name, i)); err != nil {
+						errs <- fmt.Errorf("%s post %d: %w", c.Name, i, err)
+					}
+				}
+			}(c)
+		}
+		wg.Wait()
+		close(errs)
+		var all []error
+		for err := range errs {
+			all = append(all, err)
+		}
+		return errors.Join(all...)
+	})
+
+	within(t, "non-member post", func() error {
+		if err := server.Post(clients[3], "dev", "intruder"); !errors.Is(err, ErrNotMember) {
+			return fmt.Errorf("got %v, want ErrNotMember", err)
+		}
+		return nil
+	})
+
+	// The slow client falls behind by construction: it takes 20ms per event
+	// while u4 below posts as fast as u4 itself can keep up
+	slow, err := server.Connect("slow", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Connect(slow): %v", err)
+	}
+	within(t, "slow joins ops", func() error { return server.Join(slow, "ops") })
+	within(t, "ops posts", func() error {
+		for i := 0; i < 80; i++ {
+			text := fmt.Sprintf("ops-%d", i)
+			if err := server.Post(clients[3], "ops", text); err != nil {
+				return err
+			}
+			// Wait for u4 to receive its own post before sending the next, so
+			// u4's inbox never holds more than one event and cannot overflow
+			for !receivedLast(clients[3], text) {
+				time.Sleep(50 * time.Microsecond)
+			}
+		}
+		return nil
+	})
+	within(t, "slow client disconnected", func() error {
+		<-slow.Done()
+		return nil
+	})
+	if !slow.disconnected.Load() || clients[3].disconnected.Load() {
+		t.Errorf("disconnected: slow=%v u4=%v, want only the slow client", slow.disconnected.Load(), clients[3].disconnected.Load())
+	}
+
+	within(t, "Shutdown", server.Shutdown)
+	for _, c := range clients {
+		within(t, c.Name+" connection closed", func() error {
+			<-c.Done()
+			return nil
+		})
+	}
+	if err := server.Post(clients[0], "dev", "late"); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Post after shutdown: got %v, want ErrServerClosed", err)
+	}
+	if err := server.Join(clients[0], "dev"); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Join after shutdown: got %v, want ErrServerClosed", err)
+	}
+	if _, err := server.Connect("late", 0); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Connect after shutdown: got %v, want ErrServerClosed", err)
+	}
+
+	// Every dev member saw the same sequence, except the join notices that
+	// came before it joined
+	reference := clients[0].Received()
+	for _, c := range clients[1:3] {
+		got := c.Received()
+		offset := len(reference) - len(got)
+		if offset < 0 || fmt.Sprint(reference[offset:]) != fmt.Sprint(got) {
+			t.Errorf("%s received a different order than u1:\n%v\nwant a suffix of\n%v", c.Name, got, reference)
+		}
+	}
+
+	posts := 0
+	for i, e := range reference {
+		if i > 0 && e.Seq != reference[i-1].Seq+1 {
+			t.Errorf("sequence gap: #%d after #%d", e.Seq, reference[i-1].Seq)
+		}
+		if e.From != "" {
+			posts++
+		}
+	}
+	if posts != 60 {
+		t.Errorf("u1 received %d posts, want 60", posts)
+	}
+
+	ops := clients[3].Received()
+	if len(ops) == 0 {
+		t.Error("u4 received nothing")
+	}
+	for _, e := range ops {
+		if e.Room != "ops" {
+			t.Errorf("u4 received %v from outside ops", e)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Context middleware chain used by examples 10-13 of 04_context.go: typed
+// context keys, a request-scoped values carrier, deadline budgets for
+// chained calls, and cancellation causes that say which stage gave up
+
+var (
+	// ErrUnauthenticated is returned by AuthMiddleware for an unknown token
+	ErrUnauthenticated = errors.New("chain: unauthenticated")
+	// ErrClientDisconnected is the cause of a request whose client went away
+	ErrClientDisconnected = errors.New("chain: client disconnected")
+	// ErrRequestTimeout is the cause of a request that used up its whole deadline
+	ErrRequestTimeout = errors.New("chain: request timed out")
+)
+
+// ContextKey is a typed key for a context value. Every NewContextKey is a
+// distinct key, even for the same name, so two packages storing a "user"
+// cannot overwrite each other, and Value returns a T instead of an
+// interface{} to assert.
+type ContextKey[T any] struct {
+	name string
+}
+
+// NewContextKey creates a key; name only shows up when printing
+func NewContextKey[T any](name string) *ContextKey[T] {
+	return &ContextKey[T]{name: name}
+}
+
+// WithValue returns a copy of ctx carrying value under k
+func (k *ContextKey[T]) WithValue(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// Value returns the value stored under k and whether there was one
+func (k *ContextKey[T]) Value(ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(k).(T)
+	return value, ok
+}
+
+func (k *ContextKey[T]) String() string {
+	return k.name
+}
+
+// RequestValues carries everything request-scoped under a single key, so a
+// handler reads one struct instead of probing for a dozen keys. A stored
+// RequestValues is never modified: WithRequestValues stores a changed copy,
+// so a stage running concurrently with a later one never sees its values
+// change.
+type RequestValues struct {
+	RequestID string
+	User      string
+	Tenant    string
+	attrs     map[string]string
+}
+
+var requestValuesKey = NewContextKey[*RequestValues]("request values")
+
+// RequestValuesFrom returns the request's values; outside a request they are empty
+func RequestValuesFrom(ctx context.Context) *RequestValues {
+	if rv, ok := requestValuesKey.Value(ctx); ok {
+		return rv
+	}
+	return &RequestValues{}
+}
+
+// WithRequestValues returns a context whose values are a copy of ctx's,
+// changed by set
+func WithRequestValues(ctx context.Context, set func(rv *RequestValues)) context.Context {
+	rv := *RequestValuesFrom(ctx)
+	rv.attrs = maps.Clone(rv.attrs)
+	set(&rv)
+	return requestValuesKey.WithValue(ctx, &rv)
+}
+
+// Attr returns a free-form attribute such as a header value
+func (rv *RequestValues) Attr(name string) string {
+	return rv.attrs[name]
+}
+
+// SetAttr sets an attribute; only call it on the copy WithRequestValues
+// passes to set
+func (rv *RequestValues) SetAttr(name, value string) {
+	if rv.attrs == nil {
+		rv.attrs = make(map[string]string)
+	}
+	rv.attrs[name] = value
+}
+
+// CtxHandler handles one request. What it knows about the request beyond
+// the payload, its deadline included, travels in ctx.
+type CtxHandler func(ctx context.Context, payload string) (string, error)
+
+// CtxMiddleware wraps a handler with behaviour that runs around it
+type CtxMiddleware func(next CtxHandler) CtxHandler
+
+// ChainHandlers wraps h in middlewares; the first one runs outermost
+func ChainHandlers(h CtxHandler, middlewares ...CtxMiddleware) CtxHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// RequestIDMiddleware assigns a request ID from next unless the request
+// arrived with one
+func RequestIDMiddleware(next func() string) CtxMiddleware {
+	return func(h CtxHandler) CtxHandler {
+		return func(ctx context.Context, payload string) (string, error) {
+			if RequestValuesFrom(ctx).RequestID == "" {
+				id := next()
+				ctx = WithRequestValues(ctx, func(rv *RequestValues) { rv.RequestID = id })
+			}
+			return h(ctx, payload)
+		}
+	}
+}
+
+// AuthMiddleware resolves the "token" attribute to a user and tenant
+// ("user@tenant" in tokens) and refuses requests without a known token
+func AuthMiddleware(tokens map[string]string) CtxMiddleware {
+	return func(h CtxHandler) CtxHandler {
+		return func(ctx context.Context, payload string) (string, error) {
+			identity, ok := tokens[RequestValuesFrom(ctx).Attr("token")]
+			if !ok {
+				return "", ErrUnauthenticated
+			}
+			user, tenant, _ := strings.Cut(identity, "@")
+			ctx = WithRequestValues(ctx, func(rv *RequestValues) {
+				rv.User, rv.Tenant = user, tenant
+			})
+			return h(ctx, payload)
+		}
+	}
+}
+
+// BudgetExceededError is the cancellation cause of a context whose budget
+// ran out. It unwraps to context.DeadlineExceeded.
+type BudgetExceededError struct {
+	Stage  string
+	Budget time.Duration
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%s exceeded its %v budget", e.Stage, e.Budget)
+}
+
+func (e *BudgetExceededError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// WithBudget gives a downstream stage share of the time left in ctx, and
+// never more than that time minus reserve, which the caller keeps to use the
+// result or fall back. The stage's context ends with a BudgetExceededError
+// naming it; if ctx ends first, its own cause wins. Without a deadline in
+// ctx there is nothing to divide and the stage gets none.
+func WithBudget(ctx context.Context, stage string, share float64, reserve time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	left := time.Until(deadline)
+	budget := max(min(time.Duration(float64(left)*share), left-reserve), 0)
+	cause := &BudgetExceededError{Stage: stage, Budget: budget.Round(time.Millisecond)}
+	return context.WithDeadlineCause(ctx, time.Now().Add(budget), cause)
+}
+
+// BudgetMiddleware runs the handler under WithBudget
+func BudgetMiddleware(stage string, share float64, reserve time.Duration) CtxMiddleware {
+	return func(h CtxHandler) CtxHandler {
+		return func(ctx context.Context, payload string) (string, error) {
+			ctx, cancel := WithBudget(ctx, stage, share, reserve)
+			defer cancel()
+			return h(ctx, payload)
+		}
+	}
+}
+
+// CtxTrace records what happened to one request, stage by stage
+type CtxTrace struct {
+	start time.Time
+	mu    sync.Mutex
+	lines []string
+}
+
+var (
+	traceKey      = NewContextKey[*CtxTrace]("trace")
+	traceDepthKey = NewContextKey[int]("trace depth")
+)
+
+// WithTrace starts a trace for the request in ctx
+func WithTrace(ctx context.Context) (context.Context, *CtxTrace) {
+	trace := &CtxTrace{start: time.Now()}
+	return traceKey.WithValue(ctx, trace), trace
+}
+
+// tracef adds a line to the request's trace, indented by stage depth; it
+// does nothing for an untraced request
+func tracef(ctx context.Context, format string, args ...interface{}) {
+	trace, ok := traceKey.Value(ctx)
+	if !ok {
+		return
+	}
+	depth, _ := traceDepthKey.Value(ctx)
+	line := fmt.Sprintf("%4dms %s%s", time.Since(trace.start).Milliseconds(),
+		strings.Repeat("  ", depth), fmt.Sprintf(format, args...))
+	trace.mu.Lock()
+	trace.lines = append(trace.lines, line)
+	trace.mu.Unlock()
+}
+
+// Print writes the trace, one line per event
+func (t *CtxTrace) Print() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, line := range t.lines {
+		fmt.Println("  " + line)
+	}
+}
+
+// DescribeEnd explains why a handler returned err. For a context that
+// ended, ctx.Err() only says canceled or deadline exceeded, while
+// context.Cause says who ended it and why.
+func DescribeEnd(ctx context.Context, err error) string {
+	if ctx.Err() == nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("%v (cause: %v)", ctx.Err(), context.Cause(ctx))
+}
+
+// TraceMiddleware traces the stage's entry, with the time it has left, and
+// its outcome
+func TraceMiddleware(stage string) CtxMiddleware {
+	return func(h CtxHandler) CtxHandler {
+		return func(ctx context.Context, payload string) (string, error) {
+			if left, ok := ctx.Deadline(); ok {
+				tracef(ctx, "-> %s, %v left", stage, time.Until(left).Round(time.Millisecond))
+			} else {
+				tracef(ctx, "-> %s, no deadline", stage)
+			}
+
+			depth, _ := traceDepthKey.Value(ctx)
+			result, err := h(traceDepthKey.WithValue(ctx, depth+1), payload)
+			if err != nil {
+				tracef(ctx, "<- %s failed: %s", stage, DescribeEnd(ctx, err))
+			} else {
+				tracef(ctx, "<- %s ok", stage)
+			}
+			return result, err
+		}
+	}
+}
+
+// sleepCtx waits d or until ctx ends. It then returns an error matching
+// both ctx.Err() and context.Cause, so callers can test for a deadline and
+// still learn why the context ended.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctxError(ctx)
+	}
+}
+
+// ctxError is the error of an ended context, wrapping its cause
+func ctxError(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if errors.Is(cause, ctx.Err()) {
+		return cause // Plain cancellation, or a cause that already wraps the error
+	}
+	return fmt.Errorf("%w: %w", ctx.Err(), cause)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHashDirectoryWorkers(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{"a.txt": "alpha", "sub/b.txt": "beta", "sub/deeper/c.txt": "gamma"}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		workers int
+	}{
+		{"zero defaults to NumCPU", 0},
+		{"negative defaults to NumCPU", -1},
+		{"one", 1},
+		{"more workers than files", 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan map[string]int64, 1)
+			go func() {
+				results, errc := HashDirectory(context.Background(), root, tt.workers)
+				sizes := make(map[string]int64)
+				for r := range results {
+					if r.Err != nil {
+						t.Errorf("%s: %v", r.Path, r.Err)
+					}
+					sizes[r.Path] = r.Size
+				}
+				if err := <-errc; err != nil {
+					t.Errorf("walk error: %v", err)
+				}
+				done <- sizes
+			}()
+
+			select {
+			case sizes := <-done:
+				if len(sizes) != len(files) {
+					t.Errorf("hashed %v, want %d files", sizes, len(files))
+				}
+				for name, content := range files {
+					if sizes[name] != int64(len(content)) {
+						t.Errorf("%s: size %d, want %d", name, sizes[name], len(content))
+					}
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("HashDirectory did not finish")
+			}
+		})
+	}
+}
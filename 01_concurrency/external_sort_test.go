@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/leakcheck"
+)
+
+// TestSortCancelledWhileSpilling checks that cancelling a sort that is still
+// reading its input stops the input producer too
+func TestSortCancelledWhileSpilling(t *testing.T) {
+	before := leakcheck.Take()
+	ctx, cancel := context.WithCancel(context.Background())
+	sorter := NewExternalSorter(10_000, 2)
+	sorter.TempDir = t.TempDir()
+
+	sorted, errc := sorter.Sort(ctx, randomStream(ctx, 1_000_000, 1))
+	cancel()
+	for range sorted {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+	for _, g := range before.Leaked(time.Second) {
+		t.Errorf("leaked goroutine %d [%s]:\n%s", g.ID, g.State, g.Stack)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/leakcheck"
+)
+
+func settledFuture(result interface{}, err error) *Future {
+	f := NewFuture()
+	f.complete(result, err)
+	return f
+}
+
+// TestCombinatorsDoNotWaitOnPendingInputs checks that once All, Any or Race
+// has settled, an input that never settles keeps no goroutine alive
+func TestCombinatorsDoNotWaitOnPendingInputs(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name       string
+		combine    func(pending *Future) *Future
+		wantResult interface{}
+		wantErr    error
+	}{
+		{"All fails fast", func(p *Future) *Future { return All(p, settledFuture(nil, errBoom)) }, nil, errBoom},
+		{"Any succeeds fast", func(p *Future) *Future { return Any(p, settledFuture("ok", nil)) }, "ok", nil},
+		{"Race settles fast", func(p *Future) *Future { return Race(p, settledFuture("first", nil)) }, "first", nil},
+		{"cancelled All", func(p *Future) *Future {
+			all := All(p)
+			all.Cancel()
+			return all
+		}, nil, ErrFutureCancelled},
+		{"cancelled Then", func(p *Future) *Future {
+			next := p.Then(func(v interface{}) (interface{}, error) { return v, nil })
+			next.Cancel()
+			return next
+		}, nil, ErrFutureCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := leakcheck.Take()
+			pending := NewFuture() // Never settles
+
+			result, err := tt.combine(pending).GetWithTimeout(5 * time.Second)
+			if !errors.Is(err, tt.wantErr) || result != tt.wantResult {
+				t.Errorf("got %v, %v; want %v, %v", result, err, tt.wantResult, tt.wantErr)
+			}
+			for _, g := range before.Leaked(time.Second) {
+				t.Errorf("leaked goroutine %d [%s]:\n%s", g.ID, g.State, g.Stack)
+			}
+		})
+	}
+}
+
+func TestCombinatorsWaitForEveryInput(t *testing.T) {
+	errBoom := errors.New("boom")
+	all, err := All(settledFuture(1, nil), Async(func() (interface{}, error) { return 2, nil })).GetWithTimeout(5 * time.Second)
+	if results, ok := all.([]interface{}); err != nil || !ok || len(results) != 2 || results[0] != 1 || results[1] != 2 {
+		t.Errorf("All = %v, %v; want [1 2]", all, err)
+	}
+
+	_, err = Any(settledFuture(nil, errBoom), settledFuture(nil, ErrFutureCancelled)).GetWithTimeout(5 * time.Second)
+	if !errors.Is(err, errBoom) || !errors.Is(err, ErrFutureCancelled) {
+		t.Errorf("Any of two failures = %v, want both errors joined", err)
+	}
+}
@@ -1,66 +1,43 @@
 package main
 
-import (
-	"fmt"
-	"os"
-	"strconv"
-)
+import "github.com/Rookie0x80/AIStudy-go/internal/topic"
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Please select an example to run:")
-		fmt.Println("1 - Goroutines Basic Examples")
-		fmt.Println("2 - Channels Communication Examples")
-		fmt.Println("3 - Select Multiplexing Examples")
-		fmt.Println("4 - Context Management Examples")
-		fmt.Println("5 - Sync Package Synchronization Primitives Examples")
-		fmt.Println("6 - Atomic Operations Examples")
-		fmt.Println("7 - Actor Model Examples")
-		fmt.Println("8 - CSP Pattern Examples")
-		fmt.Println("9 - Future/Promise Pattern Examples")
-		fmt.Println("10 - Reactive Programming Examples")
-		fmt.Println("Usage: go run main.go <example_number>")
-		return
-	}
-
-	example, err := strconv.Atoi(os.Args[1])
-	if err != nil {
-		fmt.Println("Invalid example number")
-		return
-	}
+// examples lists every runnable example; the shared topic package handles
+// argument parsing, help, the --interactive menu and the launcher's --list query
+var examples = []topic.Example{
+	{Number: 1, Title: "Goroutines Basic Examples", Run: GoroutinesExamples},
+	{Number: 2, Title: "Channels Communication Examples", Run: ChannelsExamples},
+	{Number: 3, Title: "Select Multiplexing Examples", Run: SelectExamples},
+	{Number: 4, Title: "Context Management Examples", Run: ContextExamples},
+	{Number: 5, Title: "Sync Package Synchronization Primitives Examples", Run: SyncExamples},
+	{Number: 6, Title: "Atomic Operations Examples", Run: AtomicExamples},
+	{Number: 7, Title: "Actor Model Examples", Run: ActorExamples},
+	{Number: 8, Title: "CSP Pattern Examples", Run: CSPExamples},
+	{Number: 9, Title: "Future/Promise Pattern Examples", Run: FutureExamples},
+	{Number: 10, Title: "Reactive Programming Examples", Run: ReactiveExamples},
+	{Number: 11, Title: "Keyed Executor (Per-Key Serialization) Examples", Run: KeyedExecutorExamples},
+	{Number: 12, Title: "Cache Loader (Refresh-Ahead) Examples", Run: CacheLoaderExamples},
+	{Number: 13, Title: "Stream Join Examples", Run: StreamJoinExamples},
+	{Number: 14, Title: "External Merge Sort Examples", Run: ExternalSortExamples},
+	{Number: 15, Title: "Parallel Directory Hasher Examples", Run: DirHasherExamples},
+	{Number: 16, Title: "Connection Pool Fairness Examples", Run: ConnPoolExamples},
+	{Number: 17, Title: "Chat Server Capstone Examples", Run: ChatServerExamples},
+	{Number: 18, Title: "Race Detector Suite Examples", Run: RaceSuiteExamples},
+	{Number: 19, Title: "Channel Buffer Sizing Examples", Run: BufferSizingExamples},
+	{Number: 20, Title: "Typed Actor Examples", Run: TypedActorExamples},
+	{Number: 21, Title: "Event Bus Examples", Run: EventBusExamples},
+	{Number: 22, Title: "Pipeline Examples", Run: PipelineExamples},
+	{Number: 23, Title: "Fan-out/Fan-in Examples", Run: FanOutInExamples},
+	{Number: 24, Title: "Rate Limiter Examples", Run: RateLimiterExamples},
+	{Number: 25, Title: "Semaphore Examples", Run: SemaphoreExamples},
+	{Number: 26, Title: "Structured Concurrency Examples", Run: StructuredExamples},
+	{Number: 27, Title: "Channel Utilities Examples", Run: ChannelUtilsExamples},
+	{Number: 28, Title: "Benchmark Harness Examples", Run: BenchHarnessExamples},
+	{Number: 29, Title: "Single-Flight Examples", Run: SingleFlightExamples},
+	{Number: 30, Title: "Remote Actor Examples", Run: RemoteActorExamples},
+	{Number: 31, Title: "Dynamic Select Examples", Run: DynamicSelectExamples},
+}
 
-	switch example {
-	case 1:
-		fmt.Println("=== Goroutines Basic Examples ===")
-		GoroutinesExamples()
-	case 2:
-		fmt.Println("=== Channels Communication Examples ===")
-		ChannelsExamples()
-	case 3:
-		fmt.Println("=== Select Multiplexing Examples ===")
-		SelectExamples()
-	case 4:
-		fmt.Println("=== Context Management Examples ===")
-		ContextExamples()
-	case 5:
-		fmt.Println("=== Sync Package Synchronization Primitives Examples ===")
-		SyncExamples()
-	case 6:
-		fmt.Println("=== Atomic Operations Examples ===")
-		AtomicExamples()
-	case 7:
-		fmt.Println("=== Actor Model Examples ===")
-		ActorExamples()
-	case 8:
-		fmt.Println("=== CSP Pattern Examples ===")
-		CSPExamples()
-	case 9:
-		fmt.Println("=== Future/Promise Pattern Examples ===")
-		FutureExamples()
-	case 10:
-		fmt.Println("=== Reactive Programming Examples ===")
-		ReactiveExamples()
-	default:
-		fmt.Println("Unknown example number")
-	}
+func main() {
+	topic.Main(topic.Topic{Name: "concurrency", Title: "Concurrency Programming", Examples: examples})
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+// The race suite (18_race_suite.go) as tests. The fixed variants always run
+// and must pass under the race detector:
+//
+//	go test -race -run RaceSuiteFixed ./01_concurrency
+//
+// The buggy variants are skipped unless the -buggy flag is given, as with
+// '18 <case> -buggy' for the example; -run picks the cases. They are expected
+// to fail: the race detector reports each race and fails the test, and
+// without -race they often compute a wrong result as well. The map case can
+// instead stop the whole test binary with "fatal error: concurrent map
+// writes", so run it on its own.
+//
+//	go test -race -run 'RaceSuiteBuggy/(lazyinit|counter)' ./01_concurrency -buggy
+
+var raceSuiteBuggy = flag.Bool("buggy", false, "run the race suite's buggy variants, which fail on purpose")
+
+// raceSuiteRuns repeats each variant, since a race only shows up when the
+// goroutines actually interleave
+const raceSuiteRuns = 5
+
+func TestRaceSuiteFixed(t *testing.T) {
+	for _, c := range raceCases {
+		t.Run(c.name, func(t *testing.T) {
+			for run := 0; run < raceSuiteRuns; run++ {
+				if outcome := c.fixed(); !outcome.correct {
+					t.Fatalf("run %d: %s", run, outcome.result)
+				}
+			}
+		})
+	}
+}
+
+func TestRaceSuiteBuggy(t *testing.T) {
+	if !*raceSuiteBuggy {
+		t.Skip("buggy variants race on purpose; pass -buggy to run them")
+	}
+	for _, c := range raceCases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Logf("expected to fail: %s", c.bug)
+			for run := 0; run < raceSuiteRuns; run++ {
+				if outcome := c.buggy(); !outcome.correct {
+					t.Errorf("run %d: %s", run, outcome.result)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/leakcheck"
+)
+
+// observerCount is how many subscriptions a hot Observable is feeding
+func observerCount(o *Observable) int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.observers)
+}
+
+// collectWithin waits for o to complete and returns what it emitted
+func collectWithin(t *testing.T, o *Observable) []interface{} {
+	t.Helper()
+	select {
+	case values := <-collect(o):
+		return values
+	case <-time.After(5 * time.Second):
+		t.Fatal("observable did not complete")
+		return nil
+	}
+}
+
+func checkNoLeaks(t *testing.T, before leakcheck.Snapshot) {
+	t.Helper()
+	for _, g := range before.Leaked(time.Second) {
+		t.Errorf("leaked goroutine %d [%s]:\n%s", g.ID, g.State, g.Stack)
+	}
+}
+
+// TestOperatorsReleaseHotSource checks that an operator that completes on its
+// own unsubscribes from a hot source that never closes
+func TestOperatorsReleaseHotSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(src *Observable) *Observable
+		emit  []interface{}
+		want  string
+	}{
+		{"Take", func(src *Observable) *Observable { return src.Take(2) }, []interface{}{1, 2, 3}, "[1 2]"},
+		{"Take(0)", func(src *Observable) *Observable { return src.Take(0) }, nil, "[]"},
+		{"Filter.Map.Take", func(src *Observable) *Observable {
+			return src.Filter(func(v interface{}) bool { return v.(int)%2 == 1 }).
+				Map(func(v interface{}) interface{} { return v.(int) * 10 }).
+				Take(2)
+		}, []interface{}{1, 2, 3, 4, 5}, "[10 30]"},
+		{"Zip with a shorter cold source", func(src *Observable) *Observable {
+			return Zip(src, FromSlice("a"))
+		}, []interface{}{1, 2}, "[[1 a]]"},
+		{"Concat after a Take", func(src *Observable) *Observable {
+			return Concat(src.Take(1), FromSlice("end"))
+		}, []interface{}{1, 2}, "[1 end]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := leakcheck.Take()
+			src := NewObservable()
+			result := collect(tt.build(src))
+			for _, v := range tt.emit {
+				src.Emit(v)
+			}
+
+			select {
+			case values := <-result:
+				if got := fmt.Sprint(values); got != tt.want {
+					t.Errorf("got %s, want %s", got, tt.want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("observable did not complete")
+			}
+			checkNoLeaks(t, before)
+			if n := observerCount(src); n != 0 {
+				t.Errorf("source still feeds %d subscriptions", n)
+			}
+		})
+	}
+}
+
+// TestTakeStopsColdProducers checks that a cold source stops producing for a
+// subscription that completed early, including one waiting on FromChannel
+func TestTakeStopsColdProducers(t *testing.T) {
+	values := make([]interface{}, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	before := leakcheck.Take()
+	if got := fmt.Sprint(collectWithin(t, FromSlice(values...).Map(func(v interface{}) interface{} { return v }).Take(3))); got != "[0 1 2]" {
+		t.Errorf("FromSlice.Map.Take(3) = %s, want [0 1 2]", got)
+	}
+	checkNoLeaks(t, before)
+
+	// The recorder goroutine lives as long as the channel, so start it first
+	ch := make(chan interface{}, 1)
+	ch <- "a"
+	source := FromChannel(ch)
+	collectWithin(t, source.Take(1))
+
+	before = leakcheck.Take()
+	// Merge waits on the recording for a second value that never comes
+	if got := fmt.Sprint(collectWithin(t, Merge(source).Take(1))); got != "[a]" {
+		t.Errorf("Merge(FromChannel).Take(1) = %s, want [a]", got)
+	}
+	checkNoLeaks(t, before)
+	close(ch)
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// joinStrings renders results sorted, since unmatched items that expire
+// together come out in map order; the test sorts what it expects the same way
+func joinStrings(results []Joined[Order, Payment]) []string {
+	lines := make([]string, len(results))
+	for i, j := range results {
+		lines[i] = j.String()
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestJoinByKeyScripted(t *testing.T) {
+	const (
+		a1     = "(order A1 $30, payment A1 via card)"
+		a3     = "(order A3 $12, payment A3 via voucher)"
+		a2     = "(order A2 $75, -)"
+		a3Left = "(order A3 $12, -)"
+		a9     = "(-, payment A9 via cash)"
+		a3Pay  = "(-, payment A3 via voucher)"
+	)
+	sec := time.Second
+	tests := []struct {
+		name   string
+		events []joinEvent
+		window time.Duration
+		policy UnmatchedPolicy
+		want   []string
+	}{
+		{"inner", orderScript(), 5 * sec, DropUnmatched, []string{a1}},
+		{"left outer", orderScript(), 5 * sec, EmitUnmatchedLeft, []string{a2, a3Left, a1}},
+		{"right outer", orderScript(), 5 * sec, EmitUnmatchedRight, []string{a9, a3Pay, a1}},
+		{"full outer", orderScript(), 5 * sec, EmitUnmatchedBoth, []string{a9, a3Pay, a2, a3Left, a1}},
+		{"wider window catches the late payment", orderScript(), 15 * sec, DropUnmatched, []string{a1, a3}},
+		{"no input", nil, 5 * sec, EmitUnmatchedBoth, nil},
+		{
+			name: "just inside the window joins",
+			events: []joinEvent{
+				{after: 0, order: &Order{"W1", 1}},
+				{after: 5*sec - time.Nanosecond, payment: &Payment{"W1", "card"}},
+			},
+			window: 5 * sec, policy: DropUnmatched,
+			want: []string{"(order W1 $1, payment W1 via card)"},
+		},
+		{
+			name: "exactly the window apart does not join",
+			events: []joinEvent{
+				{after: 0, order: &Order{"W1", 1}},
+				{after: 5 * sec, payment: &Payment{"W1", "card"}},
+			},
+			window: 5 * sec, policy: EmitUnmatchedBoth,
+			want: []string{"(-, payment W1 via card)", "(order W1 $1, -)"},
+		},
+		{
+			name: "payment before order joins",
+			events: []joinEvent{
+				{after: 0, payment: &Payment{"P1", "cash"}},
+				{after: sec, order: &Order{"P1", 5}},
+			},
+			window: 5 * sec, policy: EmitUnmatchedBoth,
+			want: []string{"(order P1 $5, payment P1 via cash)"},
+		},
+		{
+			name: "many to many",
+			events: []joinEvent{
+				{after: 0, order: &Order{"B1", 100}},
+				{after: sec, payment: &Payment{"B1", "card"}},
+				{after: sec, payment: &Payment{"B1", "gift-card"}},
+				{after: sec, order: &Order{"B1", 100}},
+			},
+			window: 5 * sec, policy: EmitUnmatchedBoth,
+			want: []string{
+				"(order B1 $100, payment B1 via card)",
+				"(order B1 $100, payment B1 via card)",
+				"(order B1 $100, payment B1 via gift-card)",
+				"(order B1 $100, payment B1 via gift-card)",
+			},
+		},
+		{
+			name: "same-side duplicates do not join each other",
+			events: []joinEvent{
+				{after: 0, order: &Order{"D1", 1}},
+				{after: sec, order: &Order{"D1", 1}},
+			},
+			window: 5 * sec, policy: EmitUnmatchedLeft,
+			want: []string{"(order D1 $1, -)", "(order D1 $1, -)"},
+		},
+		{
+			name: "matched item is not emitted again on expiry",
+			events: []joinEvent{
+				{after: 0, order: &Order{"M1", 1}},
+				{after: sec, payment: &Payment{"M1", "card"}},
+				{after: 10 * sec, order: &Order{"M2", 2}}, // Expires M1 and its payment
+			},
+			window: 5 * sec, policy: EmitUnmatchedBoth,
+			want: []string{"(order M1 $1, payment M1 via card)", "(order M2 $2, -)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan []string, 1)
+			go func() { done <- joinStrings(runScriptedJoin(tt.events, tt.window, tt.policy)) }()
+			want := append([]string{}, tt.want...)
+			sort.Strings(want)
+			select {
+			case got := <-done:
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("got %v\nwant %v", got, want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("join did not finish")
+			}
+		})
+	}
+}
+
+// manualClock is a clock the test moves forward by hand
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestJoinByKeySweepExpires checks that an unmatched item is emitted by the
+// periodic sweep once its window has passed, while both inputs stay open
+func TestJoinByKeySweepExpires(t *testing.T) {
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	orders := make(chan Order)
+	payments := make(chan Payment)
+	defer close(payments)
+	defer close(orders)
+
+	joined := JoinByKey(context.Background(), orders, payments,
+		func(o Order) string { return o.ID },
+		func(p Payment) string { return p.OrderID },
+		time.Minute,
+		JoinOptions{Unmatched: EmitUnmatchedLeft, Now: clock.Now, SweepInterval: time.Millisecond},
+	)
+	orders <- Order{"S1", 1}
+	clock.Advance(2 * time.Minute)
+
+	select {
+	case j := <-joined:
+		if !j.HasLeft || j.HasRight || j.Left.ID != "S1" {
+			t.Errorf("got %v, want order S1 alone", j)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expired order was not emitted by the sweep")
+	}
+}
+
+// TestJoinByKeyCancel checks that cancelling the context stops the join and
+// closes its output, even while a result is waiting for a reader and the
+// inputs are still open
+func TestJoinByKeyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	orders := make(chan Order)
+	payments := make(chan Payment)
+
+	joined := JoinByKey(ctx, orders, payments,
+		func(o Order) string { return o.ID },
+		func(p Payment) string { return p.OrderID },
+		time.Minute,
+		JoinOptions{},
+	)
+	orders <- Order{"X1", 1}
+	payments <- Payment{"X1", "card"} // The join now blocks sending this pair
+	cancel()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-joined:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("output not closed after cancel")
+		}
+	}
+}
+
+func TestJoinByKeyWindow(t *testing.T) {
+	join := func(window time.Duration) {
+		orders := make(chan Order)
+		payments := make(chan Payment)
+		close(orders)
+		close(payments)
+		for range JoinByKey(context.Background(), orders, payments,
+			func(o Order) string { return o.ID },
+			func(p Payment) string { return p.OrderID },
+			window, JoinOptions{}) {
+		}
+	}
+	tests := []struct {
+		name      string
+		window    time.Duration
+		wantPanic bool
+	}{
+		{"zero", 0, true},
+		{"negative", -time.Second, true},
+		{"one nanosecond sweeps every nanosecond", time.Nanosecond, false},
+		{"two nanoseconds", 2 * time.Nanosecond, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("JoinByKey(window %v) panic = %v, want panic %t", tt.window, r, tt.wantPanic)
+				}
+			}()
+			join(tt.window)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// --- Types for plugin registry examples ---
+
+// The driver registration pattern of database/sql and image: each
+// implementation registers a factory under a name from its own init, and
+// callers pick one by name at run time without importing its type. Here
+// the factories are plain functions whose result type is checked against
+// the Plugin interface (from the interface basics) with reflection when
+// they register, so a broken plugin fails at startup, not on first use.
+
+var (
+	pluginType = reflect.TypeOf((*Plugin)(nil)).Elem()
+	// Optional capability of plugins that hold resources
+	closerType = reflect.TypeOf((*interface{ Close() error })(nil)).Elem()
+)
+
+// pluginEntry is a registered factory and the type it builds
+type pluginEntry struct {
+	factory reflect.Value
+	typ     reflect.Type
+}
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = make(map[string]pluginEntry)
+)
+
+// RegisterPlugin makes a plugin available by name. factory must be a
+// func(map[string]string) (T, error) where T implements Plugin. Like
+// sql.Register it panics on a bad factory or a duplicate name: both are
+// programming errors, and registration runs from init.
+func RegisterPlugin(name string, factory interface{}) {
+	fv := reflect.ValueOf(factory)
+	ft := fv.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.In(0) != reflect.TypeOf(map[string]string(nil)) ||
+		ft.NumOut() != 2 || ft.Out(1) != reflect.TypeOf((*error)(nil)).Elem() {
+		panic(fmt.Sprintf("plugin: %s: factory must be func(map[string]string) (T, error), got %v", name, ft))
+	}
+	typ := ft.Out(0)
+	if !typ.Implements(pluginType) {
+		panic(fmt.Sprintf("plugin: %s: %v does not implement Plugin (missing method %s)",
+			name, typ, missingMethods(typ, pluginType)))
+	}
+
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if _, dup := plugins[name]; dup {
+		panic(fmt.Sprintf("plugin: Register called twice for plugin %s", name))
+	}
+	plugins[name] = pluginEntry{factory: fv, typ: typ}
+}
+
+func missingMethods(t, iface reflect.Type) string {
+	var missing []string
+	for i := 0; i < iface.NumMethod(); i++ {
+		if _, ok := t.MethodByName(iface.Method(i).Name); !ok {
+			missing = append(missing, iface.Method(i).Name)
+		}
+	}
+	return strings.Join(missing, ", ")
+}
+
+// PluginNames returns the registered names in sorted order
+func PluginNames() []string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PluginType reports the type the named plugin's factory builds
+func PluginType(name string) (reflect.Type, bool) {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	entry, ok := plugins[name]
+	return entry.typ, ok
+}
+
+// OpenPlugin builds the named plugin from its settings
+func OpenPlugin(name string, settings map[string]string) (Plugin, error) {
+	pluginsMu.RLock()
+	entry, ok := plugins[name]
+	pluginsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("plugin: unknown plugin %q (forgotten import?)", name)
+	}
+
+	out := entry.factory.Call([]reflect.Value{reflect.ValueOf(settings)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, fmt.Errorf("plugin: %s: %w", name, err)
+	}
+	return out[0].Interface().(Plugin), nil
+}
+
+// PrefixPlugin prints data behind a configured prefix, and must be closed
+type PrefixPlugin struct {
+	prefix string
+	count  int
+}
+
+func (p *PrefixPlugin) DoAction(data string) error {
+	p.count++
+	fmt.Printf("%s %s\n", p.prefix, data)
+	return nil
+}
+
+func (p *PrefixPlugin) Close() error {
+	fmt.Printf("Closing %s plugin after %d action(s)\n", p.prefix, p.count)
+	return nil
+}
+
+// Each plugin registers itself, as a driver package does in its init
+func init() {
+	RegisterPlugin("logger", func(map[string]string) (LoggerPlugin, error) {
+		return LoggerPlugin{}, nil
+	})
+	RegisterPlugin("validator", func(map[string]string) (ValidatorPlugin, error) {
+		return ValidatorPlugin{}, nil
+	})
+	RegisterPlugin("prefix", func(settings map[string]string) (*PrefixPlugin, error) {
+		prefix, ok := settings["prefix"]
+		if !ok {
+			return nil, fmt.Errorf("missing setting %q", "prefix")
+		}
+		return &PrefixPlugin{prefix: prefix}, nil
+	})
+}
+
+// Example 1: Plugins registered from init, listed by name
+func pluginRegistryListing() {
+	fmt.Println("\n--- Example 1: Plugins registered from init ---")
+	for _, name := range PluginNames() {
+		typ, _ := PluginType(name)
+		fmt.Printf("%-10s builds %-20v closer: %v\n", name, typ, typ.Implements(closerType))
+	}
+}
+
+// Example 2: Real-world - a pipeline chosen by configuration
+func pluginRegistryPipeline() {
+	fmt.Println("\n--- Example 2: Pipeline built from configuration ---")
+	config := "validator,prefix,logger" // E.g. read from a flag or file
+	settings := map[string]string{"prefix": ">>"}
+
+	var pipeline []Plugin
+	for _, name := range strings.Split(config, ",") {
+		p, err := OpenPlugin(name, settings)
+		if err != nil {
+			fmt.Println("Open error:", err)
+			return
+		}
+		pipeline = append(pipeline, p)
+	}
+	for _, p := range pipeline {
+		if err := p.DoAction("hello"); err != nil {
+			fmt.Println("Plugin error:", err)
+		}
+	}
+	// Optional capabilities are found with a type assertion, as database/sql
+	// does for driver.Pinger
+	for _, p := range pipeline {
+		if c, ok := p.(interface{ Close() error }); ok {
+			c.Close()
+		}
+	}
+}
+
+// Example 3: Error - unknown name and failing factory
+func pluginRegistryOpenErrors() {
+	fmt.Println("\n--- Example 3: Error - unknown plugin and bad settings ---")
+	if _, err := OpenPlugin("mailer", nil); err != nil {
+		fmt.Println("Open error:", err)
+	}
+	if _, err := OpenPlugin("prefix", map[string]string{}); err != nil {
+		fmt.Println("Open error:", err)
+	}
+}
+
+// Example 4: Error - registration is checked when the program starts
+func pluginRegistryBadRegistration() {
+	fmt.Println("\n--- Example 4: Error - bad registrations panic at startup ---")
+	try := func(name string, factory interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Println("Register panicked:", r)
+			}
+		}()
+		RegisterPlugin(name, factory)
+	}
+	// Email is a Notifier, not a Plugin
+	try("email", func(map[string]string) (Email, error) { return Email{}, nil })
+	try("logger", func(map[string]string) (LoggerPlugin, error) { return LoggerPlugin{}, nil })
+	try("sms", func() Plugin { return nil })
+}
+
+// PluginRegistryExamples runs all plugin registry examples
+func PluginRegistryExamples() {
+	pluginRegistryListing()
+	pluginRegistryPipeline()
+	pluginRegistryOpenErrors()
+	pluginRegistryBadRegistration()
+}
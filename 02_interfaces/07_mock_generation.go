@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// --- Types for mock generation examples ---
+
+// How testify/mock and gomock work underneath. Reflection can build a
+// function of any signature at run time (reflect.MakeFunc), but it cannot
+// give a new type methods, so every mocking tool needs one small struct
+// per interface whose methods forward to something generic. mockgen
+// writes that struct as code; here it is a stub with a <Method>Func field
+// per method, and Mock fills the fields with recording functions.
+
+// MockCall is one recorded call with its arguments
+type MockCall struct {
+	Method string
+	Args   []interface{}
+}
+
+func (c MockCall) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = fmt.Sprintf("%#v", a)
+	}
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Mock records the calls made to an interface and answers them with
+// canned results
+type Mock struct {
+	iface   reflect.Type
+	calls   []MockCall
+	results map[string][][]reflect.Value // Queued results per method
+}
+
+// NewMock returns a mock of the interface T
+func NewMock[T any]() *Mock {
+	iface := reflect.TypeOf((*T)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("mock: %v is not an interface", iface))
+	}
+	return &Mock{iface: iface, results: make(map[string][][]reflect.Value)}
+}
+
+// On queues results for the next call of method. Results are used in
+// order and the last ones repeat; a method without any returns zero
+// values. nil stands for the zero value of its result.
+func (m *Mock) On(method string, results ...interface{}) error {
+	sig, ok := m.iface.MethodByName(method)
+	if !ok {
+		return fmt.Errorf("mock: %v has no method %s", m.iface, method)
+	}
+	ft := sig.Type
+	if len(results) != ft.NumOut() {
+		return fmt.Errorf("mock: %s returns %d value(s), got %d", method, ft.NumOut(), len(results))
+	}
+
+	values := make([]reflect.Value, len(results))
+	for i, r := range results {
+		out := ft.Out(i)
+		switch v := reflect.ValueOf(r); {
+		case r == nil:
+			values[i] = reflect.Zero(out)
+		case v.Type().AssignableTo(out):
+			values[i] = reflect.New(out).Elem()
+			values[i].Set(v)
+		default:
+			return fmt.Errorf("mock: %s result %d must be %v, got %T", method, i, out, r)
+		}
+	}
+	m.results[method] = append(m.results[method], values)
+	return nil
+}
+
+// Func builds the implementation of method: it records the call and
+// returns the next queued results
+func (m *Mock) Func(method string) reflect.Value {
+	sig, _ := m.iface.MethodByName(method)
+	return reflect.MakeFunc(sig.Type, func(args []reflect.Value) []reflect.Value {
+		call := MockCall{Method: method, Args: make([]interface{}, len(args))}
+		for i, a := range args {
+			call.Args[i] = a.Interface()
+		}
+		m.calls = append(m.calls, call)
+
+		queued := m.results[method]
+		if len(queued) == 0 {
+			zeros := make([]reflect.Value, sig.Type.NumOut())
+			for i := range zeros {
+				zeros[i] = reflect.Zero(sig.Type.Out(i))
+			}
+			return zeros
+		}
+		if len(queued) > 1 {
+			m.results[method] = queued[1:]
+		}
+		return queued[0]
+	})
+}
+
+// Bind fills stub, a pointer to a struct with a <Method>Func field for
+// every method of the interface, with the mock's functions
+func (m *Mock) Bind(stub interface{}) error {
+	sv := reflect.ValueOf(stub)
+	if sv.Kind() != reflect.Ptr || sv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mock: stub must be a pointer to a struct, got %T", stub)
+	}
+	if !sv.Type().Implements(m.iface) {
+		return fmt.Errorf("mock: %T does not implement %v", stub, m.iface)
+	}
+	for i := 0; i < m.iface.NumMethod(); i++ {
+		method := m.iface.Method(i)
+		field := sv.Elem().FieldByName(method.Name + "Func")
+		if !field.IsValid() || field.Type() != method.Type {
+			return fmt.Errorf("mock: %T needs a field %sFunc of type %v", stub, method.Name, method.Type)
+		}
+		field.Set(m.Func(method.Name))
+	}
+	return nil
+}
+
+// Calls returns the recorded calls of method, or of every method if it
+// is empty
+func (m *Mock) Calls(method string) []MockCall {
+	var calls []MockCall
+	for _, c := range m.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// AssertCalled reports an error unless method was called with args
+func (m *Mock) AssertCalled(method string, args ...interface{}) error {
+	want := MockCall{Method: method, Args: args}
+	for _, c := range m.Calls(method) {
+		if reflect.DeepEqual(c.Args, args) {
+			return nil
+		}
+	}
+	return fmt.Errorf("mock: expected call %v; recorded %v", want, m.Calls(method))
+}
+
+// Stubs, one per interface: what mockgen would generate
+
+type storageStub struct {
+	SaveFunc func(data string) error
+}
+
+func (s *storageStub) Save(data string) error { return s.SaveFunc(data) }
+
+type paymentStub struct {
+	PayFunc func(amount float64) bool
+}
+
+func (s *paymentStub) Pay(amount float64) bool { return s.PayFunc(amount) }
+
+// clockStub was written by hand and forgot the field
+type clockStub struct{}
+
+func (clockStub) Now() int64 { return 0 }
+
+// Example 1: Mocking a dependency with canned results
+func mockCannedResults() {
+	fmt.Println("\n--- Example 1: Canned results and call recording ---")
+	mock := NewMock[Storage]()
+	mock.On("Save", nil)
+	mock.On("Save", errors.New("disk full"))
+
+	stub := &storageStub{}
+	if err := mock.Bind(stub); err != nil {
+		fmt.Println("Bind error:", err)
+		return
+	}
+	// The code under test only sees a Storage
+	var s Storage = stub
+	for _, item := range []string{"a", "b", "c"} {
+		fmt.Printf("Save(%q) = %v\n", item, s.Save(item))
+	}
+	fmt.Println("Recorded calls:", mock.Calls(""))
+}
+
+// Example 2: Real-world - capturing arguments of a payment
+func mockArgumentCapture() {
+	fmt.Println("\n--- Example 2: Argument capture in checkout ---")
+	mock := NewMock[Payment]()
+	mock.On("Pay", true)
+	mock.On("Pay", false)
+
+	stub := &paymentStub{}
+	if err := mock.Bind(stub); err != nil {
+		fmt.Println("Bind error:", err)
+		return
+	}
+	checkout := func(p Payment, cart []float64) (paid float64) {
+		for _, amount := range cart {
+			if p.Pay(amount) {
+				paid += amount
+			}
+		}
+		return paid
+	}
+	fmt.Printf("Paid %.2f of 3 items\n", checkout(stub, []float64{10, 20.5, 30}))
+	for i, c := range mock.Calls("Pay") {
+		fmt.Printf("Pay call %d charged %.2f\n", i+1, c.Args[0].(float64))
+	}
+	fmt.Println("Charged 20.5:", mock.AssertCalled("Pay", 20.5) == nil)
+	fmt.Println(mock.AssertCalled("Pay", 99.0))
+}
+
+// Example 3: Error - canned results and stubs are checked
+func mockMisuse() {
+	fmt.Println("\n--- Example 3: Error - wrong canned results and stubs ---")
+	mock := NewMock[Payment]()
+	fmt.Println(mock.On("Pay", "yes"))
+	fmt.Println(mock.On("Pay", true, nil))
+	fmt.Println(mock.On("Refund", true))
+	fmt.Println(NewMock[Clock]().Bind(&clockStub{}))
+	fmt.Println(NewMock[Clock]().Bind(&storageStub{}))
+}
+
+// MockGenerationExamples runs all mock generation examples
+func MockGenerationExamples() {
+	mockCannedResults()
+	mockArgumentCapture()
+	mockMisuse()
+}
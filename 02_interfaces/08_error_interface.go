@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// --- Types for error interface examples ---
+
+// error is just an interface with one method, Error() string, so any type
+// can be an error and everything about interfaces applies: the dynamic
+// type carries the details, assertions recover them, and a nil pointer
+// stored in an error is not a nil error.
+
+// Sentinel errors: fixed values compared by identity
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// FieldError is a typed error: callers extract the field with errors.As
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s: %s", e.Field, e.Reason)
+}
+
+// StatusError carries an HTTP status and wraps its cause
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+func (e *StatusError) Error() string {
+	return strconv.Itoa(e.Code) + ": " + e.Err.Error()
+}
+
+// Unwrap lets errors.Is and errors.As look at the cause
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// Is makes every 404 match ErrNotFound, whatever its cause
+func (e *StatusError) Is(target error) bool {
+	return target == ErrNotFound && e.Code == 404
+}
+
+// findUser fails in the three ways callers need to tell apart
+func findUser(id int) (string, error) {
+	switch {
+	case id <= 0:
+		return "", &FieldError{Field: "id", Reason: "must be positive"}
+	case id == 7:
+		return "", &StatusError{Code: 403, Err: ErrUnauthorized}
+	case id > 100:
+		return "", fmt.Errorf("find user %d: %w", id, ErrNotFound)
+	}
+	return "user" + strconv.Itoa(id), nil
+}
+
+// Example 1: Custom error types and sentinel errors
+func errorSentinelVsTyped() {
+	fmt.Println("\n--- Example 1: Sentinel vs typed errors ---")
+	for _, id := range []int{1, -1, 101} {
+		name, err := findUser(id)
+		var fe *FieldError
+		switch {
+		case err == nil:
+			fmt.Println("Found:", name)
+		case errors.As(err, &fe):
+			fmt.Printf("Typed error, bad field %q: %v\n", fe.Field, err)
+		case errors.Is(err, ErrNotFound):
+			fmt.Println("Sentinel error:", err)
+		}
+	}
+}
+
+// Example 2: errors.Is/As through layers of wrapping
+func errorWrapping() {
+	fmt.Println("\n--- Example 2: Wrapping with %w, errors.Is and errors.As ---")
+	_, err := findUser(7)
+	err = fmt.Errorf("load profile: %w", err)
+	fmt.Println("Error:", err)
+	fmt.Println("errors.Is ErrUnauthorized:", errors.Is(err, ErrUnauthorized))
+	var se *StatusError
+	if errors.As(err, &se) {
+		fmt.Println("errors.As StatusError, code:", se.Code)
+	}
+	// Comparing with == only sees the outermost error
+	fmt.Println("err == ErrUnauthorized:", err == ErrUnauthorized)
+	// %v instead of %w formats the cause but cuts the chain
+	flat := fmt.Errorf("load profile: %v", ErrUnauthorized)
+	fmt.Println("Formatted with verb v, errors.Is:", errors.Is(flat, ErrUnauthorized))
+	// A custom Is method: any 404 counts as ErrNotFound
+	missing := &StatusError{Code: 404, Err: errors.New("no such route")}
+	fmt.Println("404 errors.Is ErrNotFound:", errors.Is(missing, ErrNotFound))
+}
+
+// Example 3: Real-world - reporting every problem with errors.Join
+func errorJoin() {
+	fmt.Println("\n--- Example 3: Multi-errors with errors.Join ---")
+	validate := func(form map[string]string) error {
+		var errs []error
+		if form["name"] == "" {
+			errs = append(errs, &FieldError{Field: "name", Reason: "required"})
+		}
+		if _, err := strconv.Atoi(form["age"]); err != nil {
+			errs = append(errs, &FieldError{Field: "age", Reason: "not a number"})
+		}
+		return errors.Join(errs...) // nil when errs is empty
+	}
+
+	err := validate(map[string]string{"age": "ten"})
+	fmt.Printf("Joined error:\n%v\n", err)
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		fmt.Println("Number of errors:", len(joined.Unwrap()))
+	}
+	var fe *FieldError
+	fmt.Println("errors.As finds the first:", errors.As(err, &fe), fe.Field)
+	fmt.Println("Valid form error is nil:", validate(map[string]string{"name": "Ann", "age": "30"}) == nil)
+}
+
+// Example 4: Error - the nil error that is not nil
+func errorNilInterfacePitfall() {
+	fmt.Println("\n--- Example 4: Pitfall - typed nil pointer in an error ---")
+	// Returns its own pointer type as error: wrong
+	check := func(ok bool) error {
+		var fe *FieldError
+		if !ok {
+			fe = &FieldError{Field: "x", Reason: "bad"}
+		}
+		return fe // A nil *FieldError becomes a non-nil error
+	}
+	err := check(true)
+	fmt.Printf("err == nil? %v (dynamic type %T, holding a nil pointer: %v)\n", err == nil, err, err == (*FieldError)(nil))
+	// err.Error() // Uncomment to see panic: nil pointer dereference in Error
+
+	// Fixed: return the nil interface itself on success
+	fixed := func(ok bool) error {
+		if !ok {
+			return &FieldError{Field: "x", Reason: "bad"}
+		}
+		return nil
+	}
+	fmt.Println("Fixed, err == nil?", fixed(true) == nil)
+}
+
+// ErrorInterfaceExamples runs all error interface examples
+func ErrorInterfaceExamples() {
+	errorSentinelVsTyped()
+	errorWrapping()
+	errorJoin()
+	errorNilInterfacePitfall()
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// --- Types for io composition examples ---
+
+// io.Reader and io.Writer have one method each, so anything that moves
+// bytes can wrap anything else that does: each type below takes the
+// interface and implements it again, and io.Copy drives whatever chain
+// they form. (This file uses the io package's interfaces, not the
+// Reader/Writer of the composition examples.)
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// rot13Reader rotates letters by 13 places; applying it twice restores them
+type rot13Reader struct {
+	r io.Reader
+}
+
+func (r rot13Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	for i, b := range p[:n] {
+		switch {
+		case b >= 'a' && b <= 'z':
+			p[i] = 'a' + (b-'a'+13)%26
+		case b >= 'A' && b <= 'Z':
+			p[i] = 'A' + (b-'A'+13)%26
+		}
+	}
+	return n, err
+}
+
+// teeWriter writes everything to two writers, like io.MultiWriter
+type teeWriter struct {
+	a, b io.Writer
+}
+
+func (t teeWriter) Write(p []byte) (int, error) {
+	if n, err := t.a.Write(p); err != nil {
+		return n, err
+	}
+	return t.b.Write(p)
+}
+
+// rateLimitedReader reads at most limit bytes per tick, sleeping between
+// ticks
+type rateLimitedReader struct {
+	r     io.Reader
+	limit int
+	tick  time.Duration
+	reads int
+}
+
+func (l *rateLimitedReader) Read(p []byte) (int, error) {
+	if l.reads > 0 {
+		time.Sleep(l.tick)
+	}
+	l.reads++
+	if len(p) > l.limit {
+		p = p[:l.limit]
+	}
+	return l.r.Read(p)
+}
+
+// trackedSource is a ReadCloser standing in for a file or connection
+type trackedSource struct {
+	io.Reader
+	closed bool
+}
+
+func (s *trackedSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+// Example 1: Chaining readers: counting and rot13
+func ioReaderChain() {
+	fmt.Println("\n--- Example 1: Reader chain - counting and rot13 ---")
+	counter := &countingReader{r: strings.NewReader("Hello, Gopher!")}
+	var out strings.Builder
+	if _, err := io.Copy(&out, rot13Reader{counter}); err != nil {
+		fmt.Println("Copy error:", err)
+		return
+	}
+	fmt.Printf("Encoded %q, %d bytes counted\n", out.String(), counter.n)
+
+	// Reading the output through rot13 again decodes it
+	var back strings.Builder
+	io.Copy(&back, rot13Reader{strings.NewReader(out.String())})
+	fmt.Printf("Decoded %q\n", back.String())
+}
+
+// Example 2: Real-world - one write, a copy and a checksum
+func ioTeeWriter() {
+	fmt.Println("\n--- Example 2: Tee writer - copy and checksum in one pass ---")
+	var saved strings.Builder
+	hash := sha256.New() // A hash.Hash is an io.Writer too
+	w := teeWriter{&saved, hash}
+	fmt.Fprintf(w, "order %d shipped to %s\n", 42, "Lisbon")
+	fmt.Printf("Saved %q\n", saved.String())
+	fmt.Printf("sha256 %x...\n", hash.Sum(nil)[:8])
+}
+
+// Example 3: A rate-limited reader slows any source down
+func ioRateLimitedReader() {
+	fmt.Println("\n--- Example 3: Rate-limited reader ---")
+	limited := &rateLimitedReader{r: strings.NewReader(strings.Repeat("x", 40)), limit: 16, tick: 10 * time.Millisecond}
+	start := time.Now()
+	n, err := io.Copy(io.Discard, limited)
+	elapsed := time.Since(start)
+	fmt.Printf("Copied %d bytes in %d reads, took at least 30ms: %v (err %v)\n",
+		n, limited.reads, elapsed >= 30*time.Millisecond, err)
+}
+
+// Example 4: Real-world - the whole pipeline, and keeping Close
+func ioPipeline() {
+	fmt.Println("\n--- Example 4: Pipeline of small interfaces ---")
+	source := &trackedSource{Reader: strings.NewReader("Attack at dawn. Bring snacks.")}
+
+	// Wrapping hides the source's Close: a countingReader is only a Reader
+	counter := &countingReader{r: &rateLimitedReader{r: source, limit: 8, tick: time.Millisecond}}
+	_, isCloser := interface{}(counter).(io.Closer)
+	fmt.Println("Wrapped reader is an io.Closer?", isCloser)
+
+	// Embedding both interfaces in one struct puts it back
+	var rc io.ReadCloser = struct {
+		io.Reader
+		io.Closer
+	}{rot13Reader{counter}, source}
+
+	var saved strings.Builder
+	hash := sha256.New()
+	if _, err := io.Copy(teeWriter{&saved, hash}, rc); err != nil {
+		fmt.Println("Copy error:", err)
+	}
+	rc.Close()
+	fmt.Printf("Output %q\n", saved.String())
+	fmt.Printf("%d bytes, sha256 %x..., source closed: %v\n", counter.n, hash.Sum(nil)[:8], source.closed)
+}
+
+// IOCompositionExamples runs all io composition examples
+func IOCompositionExamples() {
+	ioReaderChain()
+	ioTeeWriter()
+	ioRateLimitedReader()
+	ioPipeline()
+}
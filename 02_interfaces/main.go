@@ -1,46 +1,21 @@
 package main
 
-import (
-	"fmt"
-	"os"
-	"strconv"
-)
+import "github.com/Rookie0x80/AIStudy-go/internal/topic"
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Please select an example to run:")
-		fmt.Println("1 - Interface Basics")
-		fmt.Println("2 - Implicit Implementation & Polymorphism")
-		fmt.Println("3 - Type Assertion & Type Switch")
-		fmt.Println("4 - Interface Composition")
-		fmt.Println("5 - Empty Interface & Any")
-		fmt.Println("Usage: go run main.go <example_number>")
-		return
-	}
-
-	example, err := strconv.Atoi(os.Args[1])
-	if err != nil {
-		fmt.Println("Invalid example number")
-		return
-	}
+// examples lists every runnable example; the shared topic package handles
+// argument parsing, help, the --interactive menu and the launcher's --list query
+var examples = []topic.Example{
+	{Number: 1, Title: "Interface Basics", Run: InterfaceBasicExamples},
+	{Number: 2, Title: "Implicit Implementation & Polymorphism", Run: ImplicitImplExamples},
+	{Number: 3, Title: "Type Assertion & Type Switch", Run: TypeAssertionExamples},
+	{Number: 4, Title: "Interface Composition", Run: InterfaceCompositionExamples},
+	{Number: 5, Title: "Empty Interface & Any", Run: EmptyInterfaceExamples},
+	{Number: 6, Title: "Plugin Registry", Run: PluginRegistryExamples},
+	{Number: 7, Title: "Mock Generation", Run: MockGenerationExamples},
+	{Number: 8, Title: "The error Interface", Run: ErrorInterfaceExamples},
+	{Number: 9, Title: "io.Reader/Writer Composition", Run: IOCompositionExamples},
+}
 
-	switch example {
-	case 1:
-		fmt.Println("=== Interface Basics ===")
-		InterfaceBasicExamples()
-	case 2:
-		fmt.Println("=== Implicit Implementation & Polymorphism ===")
-		ImplicitImplExamples()
-	case 3:
-		fmt.Println("=== Type Assertion & Type Switch ===")
-		TypeAssertionExamples()
-	case 4:
-		fmt.Println("=== Interface Composition ===")
-		InterfaceCompositionExamples()
-	case 5:
-		fmt.Println("=== Empty Interface & Any ===")
-		EmptyInterfaceExamples()
-	default:
-		fmt.Println("Unknown example number")
-	}
+func main() {
+	topic.Main(topic.Topic{Name: "interfaces", Title: "Interfaces & Polymorphism", Examples: examples})
 }
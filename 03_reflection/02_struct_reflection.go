@@ -27,6 +27,9 @@ func StructReflectionExamples() {
 
 	// Example 6: Struct field iteration patterns
 	structFieldIteration()
+
+	// Example 7: Promotion rules, shadowing and ambiguity
+	promotionRules()
 }
 
 // Example 1: Basic struct field reflection
@@ -343,6 +346,52 @@ func structFieldIteration() {
 	}
 }
 
+// Sample types for the promotion analyzer
+type promoLogger struct{ Level int }
+
+func (promoLogger) Log(msg string) {}
+func (promoLogger) Name() string   { return "logger" }
+
+type promoAuditor struct{ Level string }
+
+func (*promoAuditor) Log(msg string) {}
+
+type promoTimestamps struct {
+	ID        int
+	CreatedAt string
+}
+
+func (t *promoTimestamps) Touch() { t.CreatedAt = "now" } // Promoted to *promoService only
+
+type promoService struct {
+	promoLogger
+	*promoAuditor
+	promoTimestamps
+	ID string // Shadows promoTimestamps.ID
+}
+
+func (promoService) Name() string { return "service" } // Shadows promoLogger.Name
+
+// Example 7: Promotion rules, shadowing and ambiguity
+func promotionRules() {
+	fmt.Println("\n--- Example 7: Promotion Rules ---")
+
+	// The simple case from Example 3: every Person field is promoted
+	AnalyzePromotion(reflect.TypeOf(Employee{})).Print()
+
+	// Shallower members win, equal depths collide
+	fmt.Println()
+	AnalyzePromotion(reflect.TypeOf(promoService{})).Print()
+
+	// Ambiguity is only an error when the selector is used, so the type
+	// compiles and the colliding members stay reachable through their paths
+	s := promoService{promoAuditor: &promoAuditor{Level: "strict"}}
+	s.promoLogger.Level = 2
+	fmt.Printf("\nExplicit paths: s.promoLogger.Level=%d s.promoAuditor.Level=%q\n",
+		s.promoLogger.Level, s.promoAuditor.Level)
+	fmt.Printf("Shadowing: s.Name()=%q s.promoLogger.Name()=%q\n", s.Name(), s.promoLogger.Name())
+}
+
 // Helper function to check if a value is zero
 func isZeroValue(v reflect.Value) bool {
 	switch v.Kind() {
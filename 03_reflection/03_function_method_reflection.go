@@ -2,8 +2,15 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"time"
 )
 
 // FunctionMethodReflectionExamples demonstrates function and method reflection
@@ -27,6 +34,12 @@ func FunctionMethodReflectionExamples() {
 
 	// Example 6: Method discovery and documentation
 	methodDiscovery()
+
+	// Example 7: Named argument binding
+	namedArgumentCalls()
+
+	// Example 8: JSON-RPC over HTTP
+	rpcOverHTTP()
 }
 
 // Example 1: Function type reflection
@@ -240,6 +253,234 @@ func methodDiscovery() {
 
 // Helper functions
 
+// SearchArgs is bound from a JSON object; defaults come from the default tags
+type SearchArgs struct {
+	Query   string        `json:"query" validate:"required"`
+	Limit   int           `json:"limit" default:"3"`
+	Timeout time.Duration `json:"timeout" default:"1s"`
+	Tags    []string      `json:"tags"`
+}
+
+func searchUsers(ctx context.Context, args SearchArgs) ([]string, error) {
+	if args.Query == "" {
+		return nil, fmt.Errorf("query must not be empty")
+	}
+	users := []string{"alice", "alfred", "alina", "albert", "bob"}
+	var matches []string
+	deadline := time.Now().Add(args.Timeout)
+	for _, u := range users {
+		if time.Now().After(deadline) {
+			return matches, ctx.Err()
+		}
+		if strings.HasPrefix(u, args.Query) && len(matches) < args.Limit {
+			matches = append(matches, u)
+		}
+	}
+	if len(args.Tags) > 0 {
+		matches = append(matches, "tags="+strings.Join(args.Tags, "+"))
+	}
+	return matches, nil
+}
+
+// Example 7: Named argument binding
+func namedArgumentCalls() {
+	fmt.Println("\n--- Example 7: Named Argument Binding ---")
+
+	registry := NewNamedRegistry()
+	registrations := []error{
+		registry.Register("add", simpleAdd, Param("a"), Param("b")),
+		registry.Register("divide", divideWithError, Param("a"), Optional("b", 1)),
+		registry.Register("sum", sum, Param("nums")),
+		registry.Register("greet", greetUser, Optional("name", "World")),
+		registry.Register("handleCtx", sampleHandler, Param("data")),
+		registry.RegisterArgs("search", searchUsers),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			fmt.Printf("Registration error: %v\n", err)
+		}
+	}
+	fmt.Printf("Bad registration: %v\n", registry.Register("add2", simpleAdd, Param("a")))
+
+	// Arguments arrive as JSON objects, so numbers are float64 and durations are strings
+	calls := []struct {
+		name string
+		json string
+	}{
+		{"add", `{"a": 5, "b": 3}`},
+		{"divide", `{"a": 10}`},
+		{"divide", `{"a": 10, "b": 0}`},
+		{"sum", `{"nums": [1, 2, 3, 4, 5]}`},
+		{"greet", `{}`},
+		{"greet", `{"name": "Alice"}`},
+		{"handleCtx", `{"data": "test data"}`},
+		{"search", `{"query": "al"}`},
+		{"search", `{"query": "al", "limit": "10", "timeout": "250ms", "tags": "admin,staff"}`},
+		{"search", `{"limit": 2}`},
+		{"search", `{"query": ""}`},
+		{"add", `{"a": 5}`},
+		{"add", `{"a": 5, "b": 3, "c": 1}`},
+		{"add", `{"a": 5.5, "b": "x"}`},
+	}
+
+	for _, call := range calls {
+		var args map[string]interface{}
+		if err := json.Unmarshal([]byte(call.json), &args); err != nil {
+			fmt.Printf("  bad JSON: %v\n", err)
+			continue
+		}
+
+		result := registry.CallNamed(call.name, args)
+		if result.Err != nil {
+			fmt.Printf("  %s %s -> error: %v\n", call.name, call.json, strings.ReplaceAll(result.Err.Error(), "\n", "; "))
+		} else {
+			fmt.Printf("  %s %s -> %v\n", call.name, call.json, result.Values)
+		}
+	}
+
+	// Typed results without type assertions at the call site
+	total, err := CallNamedAs[int](registry, "sum", map[string]interface{}{"nums": []interface{}{"1", 2.0, 3}})
+	fmt.Printf("Typed sum: %d (err=%v)\n", total, err)
+	_, err = CallNamedAs[string](registry, "sum", map[string]interface{}{"nums": []int{1}})
+	fmt.Printf("Wrong result type: %v\n", err)
+}
+
+// Example 8: JSON-RPC over HTTP
+func rpcOverHTTP() {
+	fmt.Println("\n--- Example 8: JSON-RPC over HTTP ---")
+
+	// The event handlers from Example 5 plus methods of other shapes: the
+	// server reads each signature to know what to decode and encode
+	registry := NewHandlerRegistry()
+	registry.Register("user.create", createUserHandler)
+	methods := map[string]interface{}{
+		"user.get":       rpcGetUser,
+		"math.divide":    rpcDivide,
+		"system.version": func() (string, error) { return "1.4.2", nil },
+		"system.crash":   func(ctx context.Context) error { panic("nil map write") },
+	}
+	for name, fn := range methods {
+		if err := registry.RegisterMethod(name, fn); err != nil {
+			fmt.Printf("Register error: %v\n", err)
+			return
+		}
+	}
+	fmt.Printf("Bad method shape: %v\n", registry.RegisterMethod("bad", func(a, b int) int { return a + b }))
+
+	server := httptest.NewServer(NewRPCServer(registry))
+	defer server.Close()
+	ctx := context.Background()
+
+	// What goes over the wire
+	fmt.Println("\nRaw exchange:")
+	for _, body := range []string{
+		`{"jsonrpc": "2.0", "method": "math.divide", "params": {"a": 10, "b": 4}, "id": 1}`,
+		`{"jsonrpc": "2.0", "method": "user.create", "params": "ann@example.com", "id": 2}`,
+	} {
+		fmt.Printf("  --> %s\n", body)
+		fmt.Printf("  <-- %s", rpcPost(server.URL, body))
+	}
+
+	// The client encodes params and decodes results into typed values
+	fmt.Println("\nThrough RPCClient:")
+	client := NewRPCClient(server.URL)
+	var user rpcUser
+	if err := client.Call(ctx, "user.get", rpcUserQuery{ID: 1}, &user); err != nil {
+		fmt.Printf("  user.get error: %v\n", err)
+		return
+	}
+	fmt.Printf("  user.get {ID: 1} -> %+v\n", user)
+	var version string
+	_ = client.Call(ctx, "system.version", nil, &version)
+	fmt.Printf("  system.version -> %q\n", version)
+
+	// Every failure comes back as an *RPCError with a code
+	fmt.Println("\nErrors:")
+	calls := []struct {
+		method string
+		params interface{}
+	}{
+		{"user.get", rpcUserQuery{ID: 99}},             // Handler chose the code
+		{"math.divide", rpcDivideArgs{A: 1}},           // Plain error from the handler
+		{"math.divide", map[string]string{"a": "one"}}, // Params do not decode
+		{"user.get", nil},                              // Params missing
+		{"user.delete", nil},                           // No such method
+		{"system.crash", nil},                          // Panic, recovered
+	}
+	for _, c := range calls {
+		err := client.Call(ctx, c.method, c.params, nil)
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) {
+			fmt.Printf("  %-14s %6d %s\n", c.method, rpcErr.Code, rpcErr.Message)
+		}
+	}
+	fmt.Printf("  %-14s %s", "(not JSON)", rpcPost(server.URL, `{"jsonrpc": `))
+
+	// GET lists the methods with the Go types behind them
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		fmt.Printf("List error: %v\n", err)
+		return
+	}
+	defer resp.Body.Close()
+	var infos []RPCMethodInfo
+	_ = json.NewDecoder(resp.Body).Decode(&infos)
+	fmt.Println("\nGET lists the methods:")
+	for _, info := range infos {
+		fmt.Printf("  %-14s params %-22s result %s\n", info.Name, orDash(info.Params), orDash(info.Result))
+	}
+
+	// The registry still runs event handlers directly, and refuses methods
+	fmt.Printf("\nExecute(\"math.divide\"): %v\n", registry.Execute("math.divide", ctx, "10/4"))
+}
+
+// rpcPost sends a raw request body and returns the raw response
+func rpcPost(url, body string) string {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		return err.Error() + "\n"
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return string(data)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+type rpcDivideArgs struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+func rpcDivide(args rpcDivideArgs) (float64, error) {
+	if args.B == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return args.A / args.B, nil
+}
+
+type rpcUserQuery struct {
+	ID int `json:"id"`
+}
+
+type rpcUser struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+func rpcGetUser(ctx context.Context, q *rpcUserQuery) (*rpcUser, error) {
+	if q.ID != 1 {
+		return nil, &RPCError{Code: 404, Message: "user not found", Data: map[string]int{"id": q.ID}}
+	}
+	return &rpcUser{ID: 1, Name: "Ann", Roles: []string{"admin"}}, nil
+}
+
 func simpleAdd(a, b int) int {
 	return a + b
 }
@@ -407,6 +648,9 @@ func (hr *HandlerRegistry) Execute(event string, ctx context.Context, data strin
 	if !exists {
 		return fmt.Errorf("no handler registered for event: %s", event)
 	}
+	if handler.Type() != reflect.TypeOf((func(context.Context, string) error)(nil)) {
+		return fmt.Errorf("%s is an RPC method, not an event handler", event)
+	}
 
 	args := []reflect.Value{
 		reflect.ValueOf(ctx),
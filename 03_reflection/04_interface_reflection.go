@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 // InterfaceReflectionExamples demonstrates interface reflection
@@ -28,6 +30,9 @@ func InterfaceReflectionExamples() {
 
 	// Example 6: Runtime interface satisfaction
 	runtimeInterfaceSatisfaction()
+
+	// Example 7: Interface-based dispatch over containers
+	interfaceContainerDispatch()
 }
 
 // Example 1: Basic interface type checking
@@ -240,6 +245,78 @@ func runtimeInterfaceSatisfaction() {
 			fmt.Printf("  Satisfies no registered interfaces\n")
 		}
 	}
+
+	// The full matrix over a registry of types, with what each one lacks
+	checker.RegisterType("", reflect.TypeOf(SimpleCalculator{}))
+	checker.RegisterType("", reflect.TypeOf(partialCalculator{}))
+	checker.RegisterType("", reflect.TypeOf(&partialCalculator{}))
+	checker.RegisterType("", reflect.TypeOf(LogEventHandler{}))
+	checker.RegisterType("ReadWriter", reflect.TypeOf((*ReadWriter)(nil)).Elem())
+	fmt.Printf("\nConformance report:\n%v", checker.Report())
+}
+
+// Example 7: Interface-based dispatch over containers
+func interfaceContainerDispatch() {
+	fmt.Println("\n--- Example 7: Interface-Based Dispatch Over Containers ---")
+
+	dispatcher := NewInterfaceDispatcher()
+
+	// Handlers are tried in registration order, so more specific interfaces go first
+	dispatcher.Register("error", reflect.TypeOf((*error)(nil)).Elem(), func(v interface{}) {
+		fmt.Printf("  [error printer]    %v\n", v.(error).Error())
+	})
+	dispatcher.Register("fmt.Stringer", reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), func(v interface{}) {
+		fmt.Printf("  [stringer printer] %s\n", v.(fmt.Stringer).String())
+	})
+	dispatcher.Register("EventHandler", reflect.TypeOf((*EventHandler)(nil)).Elem(), func(v interface{}) {
+		fmt.Printf("  [handler printer]  %T ready to handle events\n", v)
+	})
+	dispatcher.SetFallback(func(v interface{}) {
+		fmt.Printf("  [fallback]         %T: %+v\n", v, v)
+	})
+
+	// A mixed event slice: some values know how to print themselves, others don't
+	events := []interface{}{
+		loginEvent{User: "alice"},
+		&logoutEvent{User: "bob"},
+		CustomError{Message: "disk full", Code: 507},
+		LogEventHandler{Name: "audit"},
+		Event{Type: "raw", Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		42,
+		nil,
+	}
+
+	fmt.Println("Grouping slice values by first satisfied interface:")
+	groups, err := dispatcher.Group(events)
+	if err != nil {
+		fmt.Printf("Grouping error: %v\n", err)
+		return
+	}
+	for _, name := range dispatcher.GroupNames() {
+		fmt.Printf("  %-14s -> %d value(s)\n", name, len(groups[name]))
+	}
+
+	fmt.Println("\nDispatching slice values:")
+	if err := dispatcher.Dispatch(events); err != nil {
+		fmt.Printf("Dispatch error: %v\n", err)
+	}
+
+	// Maps work too; keys are visited in sorted order so output is stable
+	byID := map[string]interface{}{
+		"evt-2": &logoutEvent{User: "carol"},
+		"evt-1": loginEvent{User: "dave"},
+		"evt-3": 3.14,
+	}
+
+	fmt.Println("\nDispatching map values:")
+	if err := dispatcher.Dispatch(byID); err != nil {
+		fmt.Printf("Dispatch error: %v\n", err)
+	}
+
+	// Non-container input is reported instead of panicking
+	if err := dispatcher.Dispatch("not a container"); err != nil {
+		fmt.Printf("\nExpected error: %v\n", err)
+	}
 }
 
 // Helper functions
@@ -415,9 +492,19 @@ func formatMethodSignature(method reflect.Method) string {
 	return formatFunctionSignature(method.Type)
 }
 
-// Interface satisfaction checker
+// Interface satisfaction checker. Besides answering for single values, it
+// keeps a registry of types and reports every registered type against
+// every registered interface, naming the methods each one lacks.
 type InterfaceSatisfactionChecker struct {
 	interfaces map[string]reflect.Type
+	ifaceOrder []string // Registration order, the report's columns
+	types      []namedType
+}
+
+// namedType is a type registered for the conformance report
+type namedType struct {
+	name string
+	t    reflect.Type
 }
 
 func NewInterfaceSatisfactionChecker() *InterfaceSatisfactionChecker {
@@ -430,15 +517,29 @@ func (isc *InterfaceSatisfactionChecker) RegisterInterface(name string, ifaceTyp
 	if ifaceType.Kind() != reflect.Interface {
 		panic(fmt.Sprintf("Type %v is not an interface", ifaceType))
 	}
+	if _, exists := isc.interfaces[name]; !exists {
+		isc.ifaceOrder = append(isc.ifaceOrder, name)
+	}
 	isc.interfaces[name] = ifaceType
 }
 
+// RegisterType adds a type to the conformance report; an empty name
+// means the type's own
+func (isc *InterfaceSatisfactionChecker) RegisterType(name string, t reflect.Type) {
+	if name == "" {
+		name = t.String()
+	}
+	isc.types = append(isc.types, namedType{name: name, t: t})
+}
+
+// GetSatisfiedInterfaces returns the interfaces obj satisfies, in
+// registration order
 func (isc *InterfaceSatisfactionChecker) GetSatisfiedInterfaces(obj interface{}) []string {
 	t := reflect.TypeOf(obj)
 	var satisfied []string
 
-	for name, ifaceType := range isc.interfaces {
-		if t.Implements(ifaceType) {
+	for _, name := range isc.ifaceOrder {
+		if t.Implements(isc.interfaces[name]) {
 			satisfied = append(satisfied, name)
 		}
 	}
@@ -455,3 +556,292 @@ func (isc *InterfaceSatisfactionChecker) CheckSatisfaction(obj interface{}, inte
 	t := reflect.TypeOf(obj)
 	return t.Implements(ifaceType)
 }
+
+// MissingMethod is an interface method a type does not provide
+type MissingMethod struct {
+	Name string
+	Want reflect.Type // The interface's signature
+	Got  reflect.Type // The type's method of that name, if its signature differs
+	// PointerReceiver means the method is declared on *T, so only a
+	// pointer to the type has it
+	PointerReceiver bool
+}
+
+func (m MissingMethod) String() string {
+	switch {
+	case m.Got != nil:
+		return fmt.Sprintf("%s%s, has %s%s", m.Name, formatFunctionSignature(m.Want), m.Name, formatFunctionSignature(m.Got))
+	case m.PointerReceiver:
+		return fmt.Sprintf("%s%s, declared on the pointer receiver", m.Name, formatFunctionSignature(m.Want))
+	}
+	return fmt.Sprintf("%s%s", m.Name, formatFunctionSignature(m.Want))
+}
+
+// MissingMethods lists the methods of ifaceType that t lacks, in the
+// interface's method order. It is empty exactly when t implements it.
+func MissingMethods(t, ifaceType reflect.Type) []MissingMethod {
+	var missing []MissingMethod
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		want := ifaceType.Method(i)
+		if m, ok := t.MethodByName(want.Name); ok {
+			if got := methodSignature(t, m); got != want.Type {
+				missing = append(missing, MissingMethod{Name: want.Name, Want: want.Type, Got: got})
+			}
+			continue
+		}
+
+		mm := MissingMethod{Name: want.Name, Want: want.Type}
+		if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+			ptr := reflect.PointerTo(t)
+			if m, ok := ptr.MethodByName(want.Name); ok && methodSignature(ptr, m) == want.Type {
+				mm.PointerReceiver = true
+			}
+		}
+		missing = append(missing, mm)
+	}
+	return missing
+}
+
+// methodSignature is m's type without the receiver, comparable to an
+// interface method's type
+func methodSignature(t reflect.Type, m reflect.Method) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		return m.Type
+	}
+	in := make([]reflect.Type, m.Type.NumIn()-1)
+	for i := range in {
+		in[i] = m.Type.In(i + 1)
+	}
+	out := make([]reflect.Type, m.Type.NumOut())
+	for i := range out {
+		out[i] = m.Type.Out(i)
+	}
+	return reflect.FuncOf(in, out, m.Type.IsVariadic())
+}
+
+// Conformance is one cell of the report: a type against an interface
+type Conformance struct {
+	Type      string
+	Interface string
+	Missing   []MissingMethod
+}
+
+func (c Conformance) Implements() bool {
+	return len(c.Missing) == 0
+}
+
+// ConformanceReport is the matrix of registered types (rows) against
+// registered interfaces (columns)
+type ConformanceReport struct {
+	Types      []string
+	Interfaces []string
+	Cells      [][]Conformance // Cells[row][column]
+}
+
+// Report checks every registered type against every registered interface
+func (isc *InterfaceSatisfactionChecker) Report() ConformanceReport {
+	report := ConformanceReport{Interfaces: append([]string(nil), isc.ifaceOrder...)}
+	for _, nt := range isc.types {
+		report.Types = append(report.Types, nt.name)
+		row := make([]Conformance, len(isc.ifaceOrder))
+		for j, iface := range isc.ifaceOrder {
+			row[j] = Conformance{
+				Type:      nt.name,
+				Interface: iface,
+				Missing:   MissingMethods(nt.t, isc.interfaces[iface]),
+			}
+		}
+		report.Cells = append(report.Cells, row)
+	}
+	return report
+}
+
+// String prints the matrix, marking each cell "yes" or with the number of
+// missing methods, followed by the missing methods of each "no" cell
+func (r ConformanceReport) String() string {
+	width := 0
+	for _, name := range r.Types {
+		width = max(width, len(name))
+	}
+	var b strings.Builder
+	line := func(first string, cells []string) {
+		row := fmt.Sprintf("%-*s", width, first)
+		for j, cell := range cells {
+			row += fmt.Sprintf("  %-*s", max(len(r.Interfaces[j]), 10), cell)
+		}
+		b.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+	line("", r.Interfaces)
+
+	var gaps []Conformance
+	for i, name := range r.Types {
+		marks := make([]string, len(r.Interfaces))
+		for j, cell := range r.Cells[i] {
+			marks[j] = "yes"
+			if !cell.Implements() {
+				marks[j] = fmt.Sprintf("no (%d)", len(cell.Missing))
+				gaps = append(gaps, cell)
+			}
+		}
+		line(name, marks)
+	}
+
+	for _, cell := range gaps {
+		fmt.Fprintf(&b, "%s lacks for %s:\n", cell.Type, cell.Interface)
+		for _, m := range cell.Missing {
+			fmt.Fprintf(&b, "  %v\n", m)
+		}
+	}
+	return b.String()
+}
+
+// partialCalculator almost implements Calculator: Multiply has a pointer
+// receiver and Divide the wrong signature
+type partialCalculator struct{}
+
+func (partialCalculator) Add(a, b int) int      { return a + b }
+func (partialCalculator) Subtract(a, b int) int { return a - b }
+func (*partialCalculator) Multiply(a, b int) int {
+	return a * b
+}
+func (partialCalculator) Divide(a, b float64) float64 { return a / b }
+
+// Event types used by the container dispatch example
+type loginEvent struct {
+	User string
+}
+
+func (e loginEvent) String() string {
+	return fmt.Sprintf("user %s logged in", e.User)
+}
+
+type logoutEvent struct {
+	User string
+}
+
+// String is defined on the pointer receiver, so only *logoutEvent is a Stringer
+func (e *logoutEvent) String() string {
+	return fmt.Sprintf("user %s logged out", e.User)
+}
+
+// FallbackGroup is the group name used for values that satisfy no registered interface
+const FallbackGroup = "(fallback)"
+
+// interfaceRoute pairs a registered interface with its handler
+type interfaceRoute struct {
+	name      string
+	ifaceType reflect.Type
+	handler   func(interface{})
+}
+
+// InterfaceDispatcher routes values stored in containers to handlers based on
+// which registered interface their dynamic type satisfies
+type InterfaceDispatcher struct {
+	routes   []interfaceRoute
+	fallback func(interface{})
+}
+
+func NewInterfaceDispatcher() *InterfaceDispatcher {
+	return &InterfaceDispatcher{}
+}
+
+func (d *InterfaceDispatcher) Register(name string, ifaceType reflect.Type, handler func(interface{})) {
+	if ifaceType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("Type %v is not an interface", ifaceType))
+	}
+	d.routes = append(d.routes, interfaceRoute{name: name, ifaceType: ifaceType, handler: handler})
+}
+
+func (d *InterfaceDispatcher) SetFallback(handler func(interface{})) {
+	d.fallback = handler
+}
+
+// GroupNames returns the group names in dispatch priority order
+func (d *InterfaceDispatcher) GroupNames() []string {
+	names := make([]string, 0, len(d.routes)+1)
+	for _, r := range d.routes {
+		names = append(names, r.name)
+	}
+	return append(names, FallbackGroup)
+}
+
+// Group scans a slice, array or map and groups its values by the first
+// registered interface they satisfy
+func (d *InterfaceDispatcher) Group(container interface{}) (map[string][]interface{}, error) {
+	values, err := containerValues(container)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]interface{})
+	for _, v := range values {
+		name := FallbackGroup
+		if r := d.match(v); r != nil {
+			name = r.name
+		}
+		groups[name] = append(groups[name], v)
+	}
+	return groups, nil
+}
+
+// Dispatch scans a slice, array or map and invokes the handler of the first
+// registered interface each value satisfies, or the fallback handler
+func (d *InterfaceDispatcher) Dispatch(container interface{}) error {
+	values, err := containerValues(container)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range values {
+		if r := d.match(v); r != nil {
+			r.handler(v)
+		} else if d.fallback != nil {
+			d.fallback(v)
+		}
+	}
+	return nil
+}
+
+func (d *InterfaceDispatcher) match(v interface{}) *interfaceRoute {
+	if v == nil {
+		return nil // A nil interface has no dynamic type to check
+	}
+
+	t := reflect.TypeOf(v)
+	for i := range d.routes {
+		if t.Implements(d.routes[i].ifaceType) {
+			return &d.routes[i]
+		}
+	}
+	return nil
+}
+
+// containerValues extracts the elements of a slice, array or map (in sorted
+// key order) as interface{} values
+func containerValues(container interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(container)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, v.Index(i).Interface())
+		}
+		return values, nil
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		values := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, v.MapIndex(k).Interface())
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("expected slice, array or map, got %v", v.Kind())
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 // ValueOperationsExamples demonstrates value operations and creation
@@ -26,6 +28,9 @@ func ValueOperationsExamples() {
 
 	// Example 6: Zero value handling
 	zeroValueHandling()
+
+	// Example 7: Emptiness semantics (omitempty vs IsZero vs nil)
+	emptinessSemantics()
 }
 
 // Example 1: Basic value operations
@@ -183,6 +188,52 @@ func zeroValueHandling() {
 	}
 }
 
+// emptinessSample covers every case where the three notions of emptiness disagree
+type emptinessSample struct {
+	NilTags   []string          `json:"nil_tags,omitempty"`
+	EmptyTags []string          `json:"empty_tags,omitempty"`
+	NilMeta   map[string]string `json:"nil_meta,omitempty"`
+	EmptyMeta map[string]string `json:"empty_meta,omitempty"`
+	Home      Address           `json:"home,omitempty"`
+	Work      *Address          `json:"work,omitempty"`
+	Scores    [3]int            `json:"scores,omitempty"`
+	Count     int               `json:"count,omitempty"`
+	Extra     interface{}       `json:"extra,omitempty"`
+	Note      string            `json:"note"`
+}
+
+// Example 7: Emptiness semantics (omitempty vs IsZero vs nil)
+func emptinessSemantics() {
+	fmt.Println("\n--- Example 7: Emptiness Semantics (omitempty vs IsZero vs nil) ---")
+
+	var nilAddress *Address
+	sample := emptinessSample{
+		EmptyTags: []string{},
+		EmptyMeta: map[string]string{},
+		Work:      &Address{},
+		Extra:     nilAddress, // Non-nil interface holding a nil pointer
+	}
+
+	report, err := AnalyzeEmptiness(sample)
+	if err != nil {
+		fmt.Printf("  Error: %v\n", err)
+		return
+	}
+	PrintEmptinessReport(report)
+
+	// The serializer honors omitempty with the same rule as encoding/json
+	data, _ := NewGenericSerializer().Serialize(sample)
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Printf("  Serializer keeps: %v\n", keys)
+
+	encoded, _ := json.Marshal(sample)
+	fmt.Printf("  encoding/json:    %s\n", encoded)
+}
+
 // Helper functions
 
 func analyzeValue(v reflect.Value) {
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/reflectutil"
 )
 
 // CommonMistakesExamples demonstrates common reflection mistakes and how to avoid them
@@ -99,6 +101,10 @@ func kindTypeConfusion() {
 	fmt.Printf("\nMistake 2: Wrong type comparisons\n")
 	demonstrateWrongTypeComparisons()
 
+	// Common mistake: Guessing assignability from Kind
+	fmt.Printf("\nMistake 3: Guessing assignability from Kind\n")
+	demonstrateAssignabilityExplanations()
+
 	// Safe practices
 	fmt.Printf("\nSafe practices:\n")
 	demonstrateCorrectTypeHandling()
@@ -276,20 +282,20 @@ func demonstrateNonPointerMistake() {
 func demonstrateSafeSetOperations() {
 	person := &Person{Name: "Alice", Age: 30}
 
-	if err := safeSetField(person, "Name", "Bob"); err != nil {
+	if err := reflectutil.Set(person, "Name", "Bob"); err != nil {
 		fmt.Printf("  Error setting Name: %v\n", err)
 	} else {
 		fmt.Printf("  Successfully set Name to: %s\n", person.Name)
 	}
 
-	if err := safeSetField(person, "Age", 35); err != nil {
+	if err := reflectutil.Set(person, "Age", 35); err != nil {
 		fmt.Printf("  Error setting Age: %v\n", err)
 	} else {
 		fmt.Printf("  Successfully set Age to: %d\n", person.Age)
 	}
 
 	// Try to set invalid field
-	if err := safeSetField(person, "InvalidField", "value"); err != nil {
+	if err := reflectutil.Set(person, "InvalidField", "value"); err != nil {
 		fmt.Printf("  Expected error for invalid field: %v\n", err)
 	}
 }
@@ -402,6 +408,35 @@ func demonstrateWrongTypeComparisons() {
 	fmt.Printf("    Same type: %v\n", t1 == t2)
 	fmt.Printf("    Convertible: %v\n", t1.ConvertibleTo(t2))
 	fmt.Printf("    Assignable: %v\n", t1.AssignableTo(t2))
+	fmt.Printf("    Why: %v\n", ExplainAssign(t1, t2))
+}
+
+func demonstrateAssignabilityExplanations() {
+	type Celsius float64
+	type Fahrenheit float64
+	type IntList []int
+
+	var stringer fmt.Stringer
+	var bidirectional chan int
+	var recvOnly <-chan int
+
+	pairs := []struct {
+		src, dst reflect.Type
+	}{
+		{reflect.TypeOf(Celsius(0)), reflect.TypeOf(Fahrenheit(0))},  // Same kind, both named
+		{reflect.TypeOf([]int{}), reflect.TypeOf(IntList{})},         // Unnamed to named
+		{reflect.TypeOf(int64(0)), reflect.TypeOf(int(0))},           // Numeric widening is not implicit
+		{reflect.TypeOf(65), reflect.TypeOf("")},                     // Classic rune surprise
+		{reflect.TypeOf(bidirectional), reflect.TypeOf(recvOnly)},    // Narrowing direction
+		{reflect.TypeOf(recvOnly), reflect.TypeOf(bidirectional)},    // Widening direction
+		{reflect.TypeOf(Person{}), reflect.TypeOf(&stringer).Elem()}, // Missing methods
+		{reflect.TypeOf(logoutEvent{}), reflect.TypeOf(&stringer).Elem()},
+		{reflect.TypeOf(&logoutEvent{}), reflect.TypeOf(&stringer).Elem()},
+	}
+
+	for _, p := range pairs {
+		fmt.Printf("  %v\n", ExplainAssign(p.src, p.dst))
+	}
 }
 
 func demonstrateCorrectTypeHandling() {
@@ -430,22 +465,45 @@ func demonstrateCorrectTypeHandling() {
 }
 
 func demonstrateSafeReflectionWrappers() {
-	person := &Person{Name: "Alice", Age: 30}
+	// The helpers live in internal/reflectutil: every failure is an error
+	// naming where on the path it happened, never a panic
+	person := &Person{Name: "Alice", Age: 30, Address: Address{City: "Lisbon"}}
 
-	// Safe field access
-	if name, err := safeGetField(person, "Name"); err != nil {
+	// Safe field access, by name or by path
+	if name, err := reflectutil.Get(person, "Name"); err != nil {
 		fmt.Printf("  Error getting Name: %v\n", err)
 	} else {
 		fmt.Printf("  Name: %v\n", name)
 	}
+	city, _ := reflectutil.Get(person, "Address.City")
+	fmt.Printf("  Address.City: %v\n", city)
+	_, err := reflectutil.Get(person, "Address.Zip")
+	fmt.Printf("  Address.Zip: %v\n", err)
+
+	// A chain of steps with one check at the end; nil pointers on the way
+	// stop it instead of panicking
+	profile := &profileRecord{Name: "Bo"}
+	chain := reflectutil.Of(profile).Field("Manager").Field("Address").Field("City")
+	fmt.Printf("  Manager.Address.City with no Manager: %v\n", chain.Err())
+
+	// Setting checks that the value keeps its meaning
+	for _, v := range []interface{}{int64(31), 31.5, "31"} {
+		fmt.Printf("  Set Age to %T %v: %v\n", v, v, reflectutil.Set(person, "Age", v))
+	}
 
-	// Safe method call
+	// Safe method call: argument checks, and panics come back as errors
 	calc := SimpleCalculator{Name: "TestCalc"}
-	if result, err := safeMethodCall(calc, "Add", 10, 20); err != nil {
+	if result, err := reflectutil.TryCallMethod(calc, "Add", 10, 20); err != nil {
 		fmt.Printf("  Error calling Add: %v\n", err)
 	} else {
-		fmt.Printf("  Add result: %v\n", result)
+		fmt.Printf("  Add result: %v\n", result[0])
 	}
+	_, err = reflectutil.TryCallMethod(calc, "Add", 10)
+	fmt.Printf("  Add with one argument: %v\n", err)
+	_, err = reflectutil.TryCallMethod(calc, "Divide", 1, 0)
+	fmt.Printf("  Divide by zero returns its error: %v\n", err)
+	_, err = reflectutil.TryCall(func(m map[string]int) { m["x"] = 1 }, nil)
+	fmt.Printf("  Function writing to a nil map: %v\n", err)
 }
 
 func demonstrateErrorHandlingPatterns() {
@@ -548,83 +606,6 @@ func safeTypeAnalysis(value interface{}) error {
 	return nil
 }
 
-func safeSetField(obj interface{}, fieldName string, value interface{}) error {
-	v := reflect.ValueOf(obj)
-	if v.Kind() != reflect.Ptr {
-		return fmt.Errorf("object must be a pointer")
-	}
-
-	v = v.Elem()
-	if !v.IsValid() {
-		return fmt.Errorf("invalid object")
-	}
-
-	field := v.FieldByName(fieldName)
-	if !field.IsValid() {
-		return fmt.Errorf("field %s not found", fieldName)
-	}
-
-	if !field.CanSet() {
-		return fmt.Errorf("field %s cannot be set", fieldName)
-	}
-
-	newValue := reflect.ValueOf(value)
-	if !newValue.Type().AssignableTo(field.Type()) {
-		if !newValue.Type().ConvertibleTo(field.Type()) {
-			return fmt.Errorf("value type %v not assignable to field type %v", newValue.Type(), field.Type())
-		}
-		newValue = newValue.Convert(field.Type())
-	}
-
-	field.Set(newValue)
-	return nil
-}
-
-func safeGetField(obj interface{}, fieldName string) (interface{}, error) {
-	v := reflect.ValueOf(obj)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
-
-	if !v.IsValid() {
-		return nil, fmt.Errorf("invalid object")
-	}
-
-	field := v.FieldByName(fieldName)
-	if !field.IsValid() {
-		return nil, fmt.Errorf("field %s not found", fieldName)
-	}
-
-	if !field.CanInterface() {
-		return nil, fmt.Errorf("field %s cannot be accessed", fieldName)
-	}
-
-	return field.Interface(), nil
-}
-
-func safeMethodCall(obj interface{}, methodName string, args ...interface{}) (interface{}, error) {
-	v := reflect.ValueOf(obj)
-	method := v.MethodByName(methodName)
-
-	if !method.IsValid() {
-		return nil, fmt.Errorf("method %s not found", methodName)
-	}
-
-	// Prepare arguments
-	argValues := make([]reflect.Value, len(args))
-	for i, arg := range args {
-		argValues[i] = reflect.ValueOf(arg)
-	}
-
-	// Call method
-	results := method.Call(argValues)
-	if len(results) == 0 {
-		return nil, nil
-	}
-
-	return results[0].Interface(), nil
-}
-
 func safeStringExtraction(value interface{}) (string, error) {
 	if value == nil {
 		return "", fmt.Errorf("nil value")
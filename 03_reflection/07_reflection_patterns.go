@@ -1,9 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"net/mail"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ReflectionPatternsExamples demonstrates reflection design patterns
@@ -27,6 +36,33 @@ func ReflectionPatternsExamples() {
 
 	// Example 6: Test Data Builder Pattern
 	testDataBuilderPattern()
+
+	// Example 7: Value Coercion
+	valueCoercionPattern()
+
+	// Example 8: Struct Copier With Hooks
+	structCopierPattern()
+
+	// Example 9: Constraint Documentation
+	constraintDocsPattern()
+
+	// Example 10: Naming Strategies
+	namingStrategyPattern()
+
+	// Example 11: Deep Copy and Deep Equal
+	deepCopyEqualPattern()
+
+	// Example 12: Struct Diff and Patch
+	structDiffPatchPattern()
+
+	// Example 13: Event Dispatcher
+	eventDispatcherPattern()
+
+	// Example 14: Query Builder
+	queryBuilderPattern()
+
+	// Example 15: Flatten and Unflatten
+	flattenPattern()
 }
 
 // Example 1: Object Mapper Pattern
@@ -66,13 +102,107 @@ func objectMapperPattern() {
 	fmt.Printf("Source: %+v\n", source)
 	fmt.Printf("Mapped DTO: %+v\n", dto)
 
-	// Map back
+	// Map back: the DTO's tags now say where each of its fields goes
 	var person2 Person
 	if err := mapper.Map(dto, &person2); err != nil {
 		fmt.Printf("Reverse mapping error: %v\n", err)
 		return
 	}
 	fmt.Printf("Reverse mapped: %+v\n", person2)
+	fmt.Printf("Round trip lossless: %v\n", DeepEqual(source, person2))
+
+	// Slices of structs, pointers and converters. Cents and dates have no
+	// obvious string form, so converters per (source, destination) type
+	// pair say how to write them and how to read them back.
+	fmt.Println("\nOrders with line items:")
+	converters := []interface{}{
+		func(c mapperCents) string { return fmt.Sprintf("%d.%02d", c/100, c%100) },
+		func(s string) (mapperCents, error) {
+			var units, cents int64
+			if _, err := fmt.Sscanf(s, "%d.%02d", &units, &cents); err != nil {
+				return 0, fmt.Errorf("price %q: %w", s, err)
+			}
+			return mapperCents(units*100 + cents), nil
+		},
+		func(t time.Time) string { return t.Format("2006-01-02") },
+		func(s string) (time.Time, error) { return time.Parse("2006-01-02", s) },
+	}
+	for _, conv := range converters {
+		if err := mapper.RegisterConverter(conv); err != nil {
+			fmt.Printf("Converter error: %v\n", err)
+			return
+		}
+	}
+
+	note := "leave at the door"
+	order := mapperOrder{
+		ID:       7,
+		Placed:   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Customer: &source,
+		Lines: []mapperLine{
+			{SKU: "BK-7", Qty: 2, Price: 1250},
+			{SKU: "PN-1", Qty: 10, Price: 99},
+		},
+		Note: &note,
+	}
+	var orderDTO mapperOrderDTO
+	if err := mapper.Map(order, &orderDTO); err != nil {
+		fmt.Printf("Mapping error: %v\n", err)
+		return
+	}
+	fmt.Printf("DTO: %+v\n", orderDTO)
+
+	// Back again: the nil *Person is allocated for Customer.Name, and each
+	// line DTO maps onto a mapperLine through its own tags
+	var order2 mapperOrder
+	if err := mapper.Map(orderDTO, &order2); err != nil {
+		fmt.Printf("Reverse mapping error: %v\n", err)
+		return
+	}
+	fmt.Printf("Back: ID %d, placed %s, customer %s in %s, note %q\n", order2.ID,
+		order2.Placed.Format("Jan 2 2006"), order2.Customer.Name, order2.Customer.Address.City, *order2.Note)
+	for _, line := range order2.Lines {
+		fmt.Printf("  %+v\n", line)
+	}
+
+	// Converter errors surface with the field and element they came from
+	orderDTO.Lines[1].Price = "cheap"
+	fmt.Printf("Bad price: %v\n", mapper.Map(orderDTO, &order2))
+	fmt.Printf("Bad converter: %v\n", mapper.RegisterConverter(func(a, b int) int { return a + b }))
+}
+
+// mapperCents is a price in cents, kept as an integer to avoid float rounding
+type mapperCents int64
+
+type mapperOrder struct {
+	ID       int
+	Placed   time.Time
+	Customer *Person
+	Lines    []mapperLine
+	Note     *string
+}
+
+type mapperLine struct {
+	SKU   string
+	Qty   int
+	Price mapperCents
+}
+
+// mapperOrderDTO only has the customer's name and city, so the reverse
+// mapping fills just those two fields of a new Person
+type mapperOrderDTO struct {
+	Number       int    `map:"ID"`
+	PlacedOn     string `map:"Placed"`
+	CustomerName string `map:"Customer.Name"`
+	CustomerCity string `map:"Customer.Address.City"`
+	Lines        []mapperLineDTO
+	Note         string
+}
+
+type mapperLineDTO struct {
+	SKU      string
+	Quantity int `map:"Qty"`
+	Price    string
 }
 
 // Example 2: Dependency Injection Container
@@ -104,8 +234,134 @@ func dependencyInjectionPattern() {
 	}
 
 	fmt.Printf("Dependencies injected successfully\n")
+
+	// Constructor injection: each constructor's parameters say what it
+	// needs, and the container resolves them by type
+	fmt.Println("\nConstructor injection:")
+	built := make(map[string]int)
+	app := NewDIContainer()
+	for _, p := range []struct {
+		ctor     interface{}
+		lifetime Lifetime
+	}{
+		{func() *appConfig { built["config"]++; return &appConfig{DSN: "postgres://app"} }, LifetimeSingleton},
+		{func(cfg *appConfig) (*appDB, error) {
+			built["db"]++
+			return &appDB{dsn: cfg.DSN}, nil
+		}, LifetimeSingleton},
+		{func() *requestInfo { built["request"]++; return &requestInfo{ID: built["request"]} }, LifetimeScoped},
+		{func(db *appDB, req *requestInfo) *userRepo { built["repo"]++; return &userRepo{db: db, req: req} }, LifetimeScoped},
+		{func() EmailService { built["mailer"]++; return consoleMailer{} }, LifetimeSingleton},
+		{func(repo *userRepo, mailer EmailService) *signupHandler {
+			built["handler"]++
+			return &signupHandler{repo: repo, mailer: mailer}
+		}, LifetimeTransient},
+	} {
+		if err := app.Provide(p.ctor, p.lifetime); err != nil {
+			fmt.Printf("Provide error: %v\n", err)
+			return
+		}
+	}
+
+	// Two requests, two handlers each: handlers are transient, the repo and
+	// request info live as long as the scope, the database forever
+	var first *signupHandler
+	for request := 1; request <= 2; request++ {
+		scope := app.NewScope()
+		h1, err := Resolve[*signupHandler](scope)
+		if err != nil {
+			fmt.Printf("Resolve error: %v\n", err)
+			return
+		}
+		h2, _ := Resolve[*signupHandler](scope)
+		if first == nil {
+			first = h1
+		}
+		fmt.Printf("Request %d: request ID %d, handlers differ %v, share a repo %v, same DB as request 1 %v\n",
+			request, h1.repo.req.ID, h1 != h2, h1.repo == h2.repo, h1.repo.db == first.repo.db)
+	}
+	fmt.Printf("Constructed: config %d, db %d, mailer %d, request %d, repo %d, handler %d\n",
+		built["config"], built["db"], built["mailer"], built["request"], built["repo"], built["handler"])
+
+	// `inject:""` fields are filled by type from the same constructors
+	var job struct {
+		Mailer EmailService `inject:""`
+		Config *appConfig   `inject:""`
+	}
+	if err := app.InjectDependencies(&job); err != nil {
+		fmt.Printf("Injection error: %v\n", err)
+		return
+	}
+	fmt.Printf("Injected by type: mailer %T, config %+v\n", job.Mailer, *job.Config)
+
+	// Mistakes, each reported with the chain of types that led to it
+	fmt.Println("\nResolution errors:")
+	_, err = Resolve[*signupHandler](app)
+	fmt.Printf("  Outside a scope: %v\n", err)
+
+	broken := NewDIContainer()
+	broken.Provide(func(b *cycleB) *cycleA { return &cycleA{} }, LifetimeTransient)
+	broken.Provide(func(c *cycleC) *cycleB { return &cycleB{} }, LifetimeTransient)
+	broken.Provide(func(a *cycleA) *cycleC { return &cycleC{} }, LifetimeTransient)
+	broken.Provide(func(a *cycleA) *userRepo { return &userRepo{} }, LifetimeTransient)
+	_, err = Resolve[*userRepo](broken)
+	fmt.Printf("  Cycle: %v\n", err)
+	fmt.Printf("  errors.Is(err, ErrDependencyCycle): %v\n", errors.Is(err, ErrDependencyCycle))
+
+	captive := NewDIContainer()
+	captive.Provide(func() *requestInfo { return &requestInfo{} }, LifetimeScoped)
+	captive.Provide(func(req *requestInfo) *userRepo { return &userRepo{req: req} }, LifetimeSingleton)
+	_, err = Resolve[*userRepo](captive.NewScope())
+	fmt.Printf("  Captive: %v\n", err)
+
+	missing := NewDIContainer()
+	missing.Provide(func(db *appDB) *userRepo { return &userRepo{db: db} }, LifetimeTransient)
+	_, err = Resolve[*userRepo](missing)
+	fmt.Printf("  Missing: %v\n", err)
+
+	failing := NewDIContainer()
+	failing.Provide(func() (*appDB, error) { return nil, errors.New("connection refused") }, LifetimeSingleton)
+	_, err = Resolve[*appDB](failing)
+	fmt.Printf("  Constructor: %v\n", err)
+
+	fmt.Printf("  Bad constructor: %v\n", app.Provide(func(names ...string) *appConfig { return nil }, LifetimeTransient))
 }
 
+// Services for the constructor injection example
+type appConfig struct {
+	DSN string
+}
+
+type appDB struct {
+	dsn string
+}
+
+type requestInfo struct {
+	ID int
+}
+
+type userRepo struct {
+	db  *appDB
+	req *requestInfo
+}
+
+type consoleMailer struct{}
+
+func (consoleMailer) SendEmail(to, subject, body string) error {
+	fmt.Printf("mail to %s: %s\n", to, subject)
+	return nil
+}
+
+type signupHandler struct {
+	repo   *userRepo
+	mailer EmailService
+}
+
+// A -> B -> C -> A: no order of construction can satisfy these
+type cycleA struct{}
+type cycleB struct{}
+type cycleC struct{}
+
 // Example 3: Struct Validation Framework
 func validationFrameworkPattern() {
 	fmt.Println("\n--- Example 3: Validation Framework ---")
@@ -193,6 +449,107 @@ func configurationBindingPattern() {
 	}
 
 	fmt.Printf("Bound configuration: %+v\n", config)
+
+	// Layered sources: a file, then the environment on top, then the
+	// default tags for whatever neither sets
+	dir, err := os.MkdirTemp("", "configbinder")
+	if err != nil {
+		fmt.Printf("Temp dir error: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"service.yaml": `# Service configuration
+name: checkout
+origins: [https://shop.example, "https://admin.example"]
+server:
+  host: 10.0.0.5
+database:
+  url: "postgres://db:5432/shop"   # Quoted, so the # in a password would survive
+  ports:
+    - 5432
+    - 5433
+`,
+		"service.json": `{"name": "checkout", "origins": ["https://shop.example", "https://admin.example"],
+  "server": {"host": "10.0.0.5"},
+  "database": {"url": "postgres://db:5432/shop", "ports": [5432, 5433]}}`,
+		"broken.json": `{"timeout": "soon", "server": {"port": "http"}, "database": {"max-conns": -1, "ports": [5432, "x"]}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			fmt.Printf("Write error: %v\n", err)
+			return
+		}
+	}
+
+	env := map[string]string{"APP_SERVER_PORT": "9090", "APP_TIMEOUT": "2s", "APP_DEBUG": "true"}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	fmt.Println("\nFile, then environment, then defaults:")
+	for _, name := range []string{"service.yaml", "service.json"} {
+		file, err := FileSource(filepath.Join(dir, name))
+		if err != nil {
+			fmt.Printf("Source error: %v\n", err)
+			return
+		}
+		var settings serviceSettings
+		if err := binder.BindSources(&settings, file, EnvSource("APP", lookupEnv)); err != nil {
+			fmt.Printf("Binding error: %v\n", err)
+			return
+		}
+		fmt.Printf("  %s: %+v\n", name, settings)
+	}
+	fmt.Println("  (server.port, timeout and debug from $APP_*; database.max-conns from its default)")
+
+	// Every problem at once instead of the first one
+	fmt.Println("\nAll errors at once:")
+	broken, err := FileSource(filepath.Join(dir, "broken.json"))
+	if err != nil {
+		fmt.Printf("Source error: %v\n", err)
+		return
+	}
+	var settings serviceSettings
+	err = binder.BindSources(&settings, broken, EnvSource("APP", func(name string) (string, bool) {
+		if name == "APP_DEBUG" {
+			return "maybe", true
+		}
+		return "", false
+	}))
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			fmt.Printf("  %s\n", strings.ReplaceAll(e.Error(), dir+string(filepath.Separator), ""))
+		}
+	}
+	var fieldErr *ConfigFieldError
+	if errors.As(err, &fieldErr) {
+		fmt.Printf("errors.Is(err, ErrConfigRequired): %v; errors.As finds %q first\n",
+			errors.Is(err, ErrConfigRequired), fieldErr.Key)
+	}
+}
+
+// serviceSettings exercises defaults, required keys, durations and lists
+type serviceSettings struct {
+	Name     string           `mapstructure:"name" required:"true"`
+	Timeout  time.Duration    `mapstructure:"timeout" default:"5s"`
+	Debug    bool             `mapstructure:"debug"`
+	Origins  []string         `mapstructure:"origins"`
+	Server   settingsServer   `mapstructure:"server"`
+	Database settingsDatabase `mapstructure:"database"`
+}
+
+type settingsServer struct {
+	Host string `mapstructure:"host" default:"0.0.0.0"`
+	Port int    `mapstructure:"port" default:"8080"`
+}
+
+type settingsDatabase struct {
+	URL      string `mapstructure:"url" required:"true"`
+	MaxConns uint   `mapstructure:"max-conns" default:"10"`
+	Ports    []int  `mapstructure:"ports"`
 }
 
 // Example 6: Test Data Builder Pattern
@@ -201,45 +558,1071 @@ func testDataBuilderPattern() {
 
 	builder := NewTestDataBuilder()
 
-	// Build test person
+	// Untagged types get a random value of each field's kind
 	person := builder.Build(Person{}).(*Person)
 	fmt.Printf("Generated person: %+v\n", *person)
 
-	// Build test user
 	user := builder.Build(User{}).(*User)
 	fmt.Printf("Generated user: %+v\n", *user)
 
-	// Build custom data
+	// Fixed values on top, by the same paths ApplyPatch takes
 	customPerson := builder.BuildWithRules(Person{}, map[string]interface{}{
-		"Name": "Custom Name",
-		"Age":  99,
+		"Name":         "Custom Name",
+		"Age":          99,
+		"Address.City": "Lisbon",
 	}).(*Person)
 	fmt.Printf("Custom person: %+v\n", *customPerson)
+
+	// `fake` tags make the data plausible, down through slices, maps and
+	// pointers; a generator registered for a type covers every field of it
+	err := builder.RegisterGenerator(func(r *rand.Rand) mapperCents {
+		return mapperCents(100 * (1 + r.Intn(50)))
+	})
+	if err != nil {
+		fmt.Printf("Generator error: %v\n", err)
+		return
+	}
+	builder.WithSeed(2024).WithLen(2, 2)
+	account := builder.Build(fakeAccount{}).(*fakeAccount)
+	fmt.Printf("\nAccount %s (%s), %s, %d logins, active %v\n",
+		account.ID, account.Plan, account.Owner.Name, account.Logins, account.Active)
+	fmt.Printf("  owner:    %s, %s, %s %s\n", account.Owner.Email, account.Owner.Phone, account.Owner.Address.City, account.Owner.Address.Country)
+	fmt.Printf("  contacts: %v\n", account.Contacts)
+	for _, inv := range account.Invoices {
+		fmt.Printf("  invoice:  %s %d cents, due %s\n", inv.Number, inv.Amount, inv.Due.Format("2006-01-02"))
+	}
+	fmt.Printf("  labels:   %v, note %q\n", account.Labels, *account.Note)
+
+	// The same seed builds the same data
+	again := builder.WithSeed(2024).Build(fakeAccount{}).(*fakeAccount)
+	fmt.Printf("Same seed, same account: %v\n", DeepEqual(account, again))
+
+	// Recursive types stop at the maximum depth
+	builder.WithMaxDepth(4).WithLen(1, 1)
+	node := builder.Build(codecNode{}).(*codecNode)
+	chain := []string{}
+	for n := node; n != nil; n = n.Next {
+		chain = append(chain, n.Name)
+	}
+	fmt.Printf("\nLinked list at max depth 4: %v\n", chain)
+
+	// A bad tag is reported by Fill with the field path; Build panics
+	var broken fakeBroken
+	fmt.Printf("Fill errors: %v\n", strings.ReplaceAll(builder.Fill(&broken).Error(), "\n", "; "))
+	fmt.Printf("errors.Is(err, ErrUnknownFaker): %v\n", errors.Is(builder.Fill(&broken), ErrUnknownFaker))
+}
+
+type fakeAccount struct {
+	ID       string `fake:"uuid"`
+	Plan     string `fake:"oneof,free,team,enterprise"`
+	Logins   uint16 `fake:"int,1,500"`
+	Active   bool
+	Owner    fakeOwner
+	Contacts []string `fake:"email"`
+	Invoices []*fakeInvoice
+	Labels   map[string]string `fake:"word"`
+	Note     *string           `fake:"sentence"`
+	Secret   string            `fake:"-"`
+}
+
+type fakeOwner struct {
+	Name    string `fake:"name"`
+	Email   string `fake:"email"`
+	Phone   string `fake:"phone"`
+	Address struct {
+		Street  string `fake:"street"`
+		City    string `fake:"city"`
+		Country string `fake:"country"`
+	}
+}
+
+type fakeInvoice struct {
+	Number string `fake:"int,1000,9999"`
+	Amount mapperCents
+	Due    time.Time
+}
+
+type fakeBroken struct {
+	Nickname string  `fake:"nickname"`
+	Score    int     `fake:"int,10,1"`
+	Ratio    float64 `fake:"float,low,high"`
+}
+
+// Example 7: Value Coercion
+func valueCoercionPattern() {
+	fmt.Println("\n--- Example 7: Value Coercion ---")
+
+	// Coercion goes further than AssignableTo/ConvertibleTo
+	cases := []struct {
+		value  interface{}
+		target reflect.Type
+	}{
+		{"8080", reflect.TypeOf(int(0))},
+		{"true", reflect.TypeOf(false)},
+		{"1m30s", reflect.TypeOf(time.Duration(0))},
+		{"2024-03-15", reflect.TypeOf(time.Time{})},
+		{float64(42), reflect.TypeOf(int(0))}, // encoding/json decodes numbers as float64
+		{42, reflect.TypeOf("")},              // Convert would produce "*", not "42"
+		{"a,b,c", reflect.TypeOf([]string{})},
+		{[]interface{}{"1", 2.0, 3}, reflect.TypeOf([]int{})},
+		{7, reflect.TypeOf((*int)(nil))},
+		{300, reflect.TypeOf(int8(0))},
+		{-1, reflect.TypeOf(uint(0))},
+		{3.5, reflect.TypeOf(int(0))},
+		{"abc", reflect.TypeOf(int(0))},
+	}
+
+	for _, c := range cases {
+		result, err := Coerce(c.value, c.target)
+		if err != nil {
+			fmt.Printf("  %-28s -> %-14v error: %v\n", fmt.Sprintf("%#v", c.value), c.target, err)
+			continue
+		}
+		display := result.Interface()
+		if result.Kind() == reflect.Ptr {
+			display = fmt.Sprintf("&%v", result.Elem().Interface())
+		}
+		fmt.Printf("  %-28s -> %-14v %v\n", fmt.Sprintf("%#v", c.value), c.target, display)
+	}
+
+	// The binder now accepts string-typed sources such as environment variables
+	fmt.Println("\nBinding string-only configuration:")
+	binder := NewConfigBinder()
+	var config Config
+	err := binder.Bind(map[string]interface{}{
+		"database.host": "db.internal",
+		"database.port": "5432",
+		"server.port":   "8080",
+	}, &config)
+	if err != nil {
+		fmt.Printf("Binding error: %v\n", err)
+	} else {
+		fmt.Printf("Database: %+v\n", config.Database)
+		fmt.Printf("Server: %+v\n", config.Server)
+	}
+
+	// Type mismatches are reported instead of being silently skipped
+	err = binder.Bind(map[string]interface{}{"server.port": "not-a-port"}, &config)
+	fmt.Printf("Invalid port: %v\n", err)
+
+	// The serializer accepts JSON-style float64 numbers for int fields
+	fmt.Println("\nDeserializing JSON-style data:")
+	var person Person
+	serializer := NewGenericSerializer()
+	if err := serializer.Deserialize(map[string]interface{}{"name": "Carol", "age": float64(41)}, &person); err != nil {
+		fmt.Printf("Deserialization error: %v\n", err)
+	} else {
+		fmt.Printf("Deserialized: %+v\n", person)
+	}
+}
+
+// Example 8: Struct Copier With Hooks
+func structCopierPattern() {
+	fmt.Println("\n--- Example 8: Struct Copier With Hooks ---")
+
+	customer := customerRecord{
+		ID:        1042,
+		FirstName: "Grace",
+		LastName:  "Hopper",
+		Email:     "grace@navy.mil",
+		Password:  "cobol4ever",
+		Tags:      []string{"vip", "early-adopter"},
+		Prefs:     map[string]string{"theme": "dark"},
+		Address:   &Address{Street: "1 Main St", City: "Arlington", Country: "USA"},
+		CreatedAt: time.Date(2019, 6, 1, 9, 30, 0, 0, time.UTC),
+	}
+
+	// Hooks transform individual fields; tags handle renames and exclusions
+	copier := NewCopier().
+		Transform("Email", func(src reflect.Value) (interface{}, error) {
+			email := src.String()
+			at := strings.Index(email, "@")
+			if at < 1 {
+				return nil, fmt.Errorf("invalid email %q", email)
+			}
+			return email[:1] + "***" + email[at:], nil
+		}).
+		Transform("MemberSince", func(src reflect.Value) (interface{}, error) {
+			return src.Interface().(time.Time).Format("Jan 2006"), nil
+		})
+
+	var dto customerDTO
+	if err := copier.Copy(&dto, &customer); err != nil {
+		fmt.Printf("Copy error: %v\n", err)
+		return
+	}
+	fmt.Printf("Source: id=%d name=%s %s email=%s password=%s created=%s\n",
+		customer.ID, customer.FirstName, customer.LastName, customer.Email, customer.Password,
+		customer.CreatedAt.Format("2006-01-02"))
+	fmt.Printf("DTO:    id=%q name=%q email=%s password=%q since=%q address=%+v\n",
+		dto.ID, dto.DisplayName, dto.Email, dto.Password, dto.MemberSince, *dto.Address)
+
+	// Deep copies are independent of the source
+	customer.Tags[0] = "churned"
+	customer.Prefs["theme"] = "light"
+	customer.Address.City = "Moved"
+	fmt.Printf("After mutating source, deep DTO keeps: tags=%v prefs=%v city=%s\n",
+		dto.Tags, dto.Prefs, dto.Address.City)
+
+	// Shallow copies share slices and maps with the source
+	var shallow customerRecord
+	NewCopier().DeepCopySlices(false).DeepCopyMaps(false).Copy(&shallow, &customer)
+	customer.Tags[0] = "reactivated"
+	customer.Prefs["theme"] = "solarized"
+	fmt.Printf("Shallow copy sees later source changes: tags=%v prefs=%v\n", shallow.Tags, shallow.Prefs)
+
+	// Skip works without touching the destination type
+	var partial customerRecord
+	NewCopier().Skip("Password", "Address").Copy(&partial, &customer)
+	fmt.Printf("Copy skipping Password and Address: password=%q address=%v\n", partial.Password, partial.Address)
+
+	// Hook errors carry the field path
+	bad := customer
+	bad.Email = "not-an-email"
+	if err := copier.Copy(&dto, &bad); err != nil {
+		fmt.Printf("Hook error: %v\n", err)
+	}
+}
+
+// customerRecord is a domain entity used by the copier example
+type customerRecord struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Email     string
+	Password  string
+	Tags      []string
+	Prefs     map[string]string
+	Address   *Address
+	CreatedAt time.Time
+}
+
+// customerDTO is the public view of customerRecord
+type customerDTO struct {
+	ID          string // int -> string via coercion
+	DisplayName string `copy:"-"` // Derived in AfterCopy
+	Email       string
+	Password    string `copy:"-"`
+	Tags        []string
+	Prefs       map[string]string
+	Address     *addressDTO
+	MemberSince string `copy:"CreatedAt"`
+}
+
+// addressDTO is compatible with Address but drops the street
+type addressDTO struct {
+	City    string
+	Country string
+}
+
+// AfterCopy derives fields that have no single source counterpart
+func (d *customerDTO) AfterCopy(src interface{}) error {
+	customer, ok := src.(customerRecord)
+	if !ok {
+		return fmt.Errorf("unexpected source %T", src)
+	}
+	d.DisplayName = customer.FirstName + " " + customer.LastName
+	return nil
+}
+
+// Example 11: Deep Copy and Deep Equal
+func deepCopyEqualPattern() {
+	fmt.Println("\n--- Example 11: Deep Copy and Deep Equal ---")
+
+	// An org chart with a cycle (each report's Manager is the boss) and a
+	// shared pointer (everyone sits in one office)
+	office := &Address{Street: "1 Loop Rd", City: "Cupertino", Country: "USA"}
+	boss := &orgMember{Name: "Ada", Skills: []string{"math"}, Meta: map[string]interface{}{"level": 7},
+		Office: office, notes: []string{"private"}}
+	for _, name := range []string{"Grace", "Linus"} {
+		boss.Reports = append(boss.Reports, &orgMember{Name: name, Skills: []string{"go"}, Office: office, Manager: boss})
+	}
+
+	// The copy is a new graph with the same shape
+	clone := DeepCopy(boss).(*orgMember)
+	fmt.Printf("New graph: clone != boss %t; cycle kept: clone.Reports[0].Manager == clone %t\n",
+		clone != boss, clone.Reports[0].Manager == clone)
+	fmt.Printf("Sharing kept: reports share one office %t, not the original's %t\n",
+		clone.Reports[0].Office == clone.Reports[1].Office, clone.Office != office)
+	if _, err := json.Marshal(boss); err != nil {
+		fmt.Printf("A JSON round trip cannot copy it: %v\n", err)
+	}
+
+	// Mutating the original leaves the copy alone, except for unexported
+	// fields, which reflect cannot set and DeepCopy therefore shares
+	boss.Reports[1].Skills[0] = "rust"
+	boss.Meta["level"] = 8
+	office.City = "Austin"
+	boss.notes[0] = "changed"
+	fmt.Printf("After mutating the original: skills=%v level=%v city=%s, unexported notes=%v\n",
+		clone.Reports[1].Skills, clone.Meta["level"], clone.Office.City, clone.notes)
+
+	// reflect.DeepEqual says whether; DeepDiff also says where
+	fresh := DeepCopy(boss).(*orgMember)
+	fresh.Reports[1].Skills[0] = "zig"
+	diff, _ := DeepDiff(boss, fresh)
+	fmt.Printf("reflect.DeepEqual %t, DeepEqual %t, DeepDiff %s\n", reflect.DeepEqual(boss, fresh), DeepEqual(boss, fresh), diff)
+	diff, _ = DeepDiff(boss, clone)
+	fmt.Printf("Original vs the old copy: %s\n", diff)
+
+	// A copy that duplicated the office instead of sharing it is equal by
+	// value; only SameShape notices
+	dup := DeepCopy(boss).(*orgMember)
+	dup.Reports[1].Office = DeepCopy(dup.Reports[1].Office).(*Address)
+	fmt.Printf("Office duplicated: reflect.DeepEqual %t, DeepEqual %t\n", reflect.DeepEqual(boss, dup), DeepEqual(boss, dup))
+	diff, same := SameShape(boss, dup)
+	fmt.Printf("SameShape %t: %s\n", same, diff)
+	_, same = SameShape(boss, DeepCopy(boss))
+	fmt.Printf("SameShape with a DeepCopy: %t\n", same)
+
+	// Cycles: comparison without a visited map follows Next around a ring
+	// forever. reflect.DeepEqual keeps one too, so both terminate.
+	const size = 10000
+	a, b := newRing(size), newRing(size)
+	if _, err := naiveDeepEqual(reflect.ValueOf(a), reflect.ValueOf(b), 0); err != nil {
+		fmt.Printf("Naive recursion on a %d-node ring: %v\n", size, err)
+	}
+	start := time.Now()
+	equal := DeepEqual(a, b)
+	ours := time.Since(start)
+	start = time.Now()
+	stdEqual := reflect.DeepEqual(a, b)
+	std := time.Since(start)
+	fmt.Printf("Ring of %d: DeepEqual %t in %v, reflect.DeepEqual %t in %v\n",
+		size, equal, ours.Round(time.Microsecond), stdEqual, std.Round(time.Microsecond))
+	fmt.Println("reflect.DeepEqual only answers yes or no, which keeps it faster; reach")
+	fmt.Println("for DeepDiff when the answer needs a where, and SameShape for aliasing.")
+}
+
+// orgMember is an org chart node; Manager and Reports point both ways
+type orgMember struct {
+	Name    string
+	Skills  []string
+	Meta    map[string]interface{}
+	Office  *Address
+	Manager *orgMember
+	Reports []*orgMember
+	notes   []string
+}
+
+// ringNode is a node of a circular linked list
+type ringNode struct {
+	Value int
+	Next  *ringNode
+}
+
+// newRing builds a ring of n nodes whose last node points back to the first
+func newRing(n int) *ringNode {
+	first := &ringNode{Value: 0}
+	last := first
+	for i := 1; i < n; i++ {
+		last.Next = &ringNode{Value: i}
+		last = last.Next
+	}
+	last.Next = first
+	return first
+}
+
+// naiveDeepEqual compares exported data recursively without remembering
+// what it has seen; the depth limit stands in for the stack overflow
+func naiveDeepEqual(a, b reflect.Value, depth int) (bool, error) {
+	const limit = 100000
+	if depth > limit {
+		return false, fmt.Errorf("still recursing after %d levels", limit)
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil(), nil
+		}
+		return naiveDeepEqual(a.Elem(), b.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if equal, err := naiveDeepEqual(a.Field(i), b.Field(i), depth+1); !equal || err != nil {
+				return equal, err
+			}
+		}
+		return true, nil
+	}
+	return a.Interface() == b.Interface(), nil
+}
+
+// Example 12: Struct Diff and Patch
+func structDiffPatchPattern() {
+	fmt.Println("\n--- Example 12: Struct Diff and Patch ---")
+
+	before := profileRecord{
+		Name:    "Ines",
+		Address: Address{Street: "1 Rua Augusta", City: "Lisbon", Country: "PT"},
+		Tags:    []string{"go", "sre", "oncall"},
+		Meta:    map[string]string{"team": "payments", "level": "3"},
+		Joined:  time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		notes:   "unexported, never compared",
+	}
+	after := DeepCopy(before).(profileRecord)
+	after.Address.City = "Porto"
+	after.Tags = after.Tags[:2]
+	after.Tags[1] = "lead"
+	after.Meta["level"] = "4"
+	delete(after.Meta, "team")
+	after.Meta["office"] = "OPO"
+	after.Manager = &Person{Name: "Rui"}
+	after.Joined = after.Joined.AddDate(0, 0, 1)
+	after.notes = "changed, still not reported"
+
+	changes := Diff(before, after)
+	fmt.Printf("Diff found %d changes:\n", len(changes))
+	for _, c := range changes {
+		fmt.Printf("  %v\n", c)
+	}
+
+	// The changes as a patch: path -> new value, ready to send as JSON and
+	// replay on another copy of the old value
+	replayed := DeepCopy(before).(profileRecord)
+	if err := ApplyPatch(&replayed, PatchOf(changes)); err != nil {
+		fmt.Printf("Patch error: %v\n", err)
+		return
+	}
+	fmt.Printf("Replayed patch reaches the new value: %v\n", len(Diff(replayed, after)) == 0)
+
+	// A hand-written patch, as it might arrive from a PATCH request: values
+	// are coerced into the field types, nil pointers and maps are allocated
+	var blank profileRecord
+	err := ApplyPatch(&blank, map[string]interface{}{
+		"Name":                 "Tomas",
+		"Manager.Age":          "52",
+		`Meta["level"]`:        2,
+		"Tags[0]":              "new",
+		"Tags[1]":              "hire",
+		"Joined":               "2024-01-15",
+		"Address.Country":      "PT",
+		"Manager.Address.City": "Faro",
+	})
+	if err != nil {
+		fmt.Printf("Patch error: %v\n", err)
+		return
+	}
+	fmt.Printf("Patched from zero: %s, tags %v, meta %v, manager %+v, joined %s\n", blank.Name,
+		blank.Tags, blank.Meta, *blank.Manager, blank.Joined.Format("2006-01-02"))
+
+	// Bad paths and values are all reported; the good ones still apply
+	err = ApplyPatch(&blank, map[string]interface{}{
+		"Nmae":            "typo",
+		"Tags[5]":         "gap",
+		"Age":             "old",
+		"Address.City[0]": "x",
+		"notes":           "private",
+		"Name":            "Tomas Silva",
+	})
+	fmt.Printf("Errors:\n")
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		fmt.Printf("  %v\n", e)
+	}
+	fmt.Printf("errors.Is(err, ErrPatchPath): %v, Name still applied: %q\n", errors.Is(err, ErrPatchPath), blank.Name)
+}
+
+// profileRecord has one of everything Diff and ApplyPatch walk through
+type profileRecord struct {
+	Name    string
+	Age     int
+	Address Address
+	Tags    []string
+	Meta    map[string]string
+	Manager *Person
+	Joined  time.Time
+	notes   string
+}
+
+// Example 13: Event Dispatcher
+func eventDispatcherPattern() {
+	fmt.Println("\n--- Example 13: Event Dispatcher ---")
+
+	bus := NewEventBus()
+
+	// Subscribe[T] checks the handler at compile time; the bus routes by
+	// the parameter type it reads through reflection
+	Subscribe(bus, func(e busUserSignedUp) {
+		fmt.Printf("  [welcome] mail to %s\n", e.Email)
+	})
+	Subscribe(bus, func(e busOrderPlaced) {
+		fmt.Printf("  [billing] order %d for %.2f\n", e.ID, e.Total)
+		// Handlers run outside the bus lock, so they can publish in turn
+		_, _ = bus.Publish(busInvoiceIssued{OrderID: e.ID})
+	})
+	Subscribe(bus, func(e busInvoiceIssued) {
+		fmt.Printf("  [billing] invoice for order %d\n", e.OrderID)
+	})
+
+	// An interface parameter receives every event that implements it
+	Subscribe(bus, func(e busAudited) {
+		fmt.Printf("  [audit]   %s\n", e.Audit())
+	})
+	seen := map[string]int{}
+	Subscribe(bus, func(e interface{}) {
+		seen[reflect.TypeOf(e).Name()]++
+	})
+
+	// SubscribeFunc takes any func(T) or func(T) error, checked at run time
+	unsubscribeFraud, err := bus.SubscribeFunc(func(e busOrderPlaced) error {
+		if e.Total > 1000 {
+			return fmt.Errorf("order %d needs review", e.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Subscribe error: %v\n", err)
+		return
+	}
+
+	fmt.Println("Handlers, in order:")
+	for _, t := range bus.Subscriptions() {
+		fmt.Printf("  func(%v)\n", t)
+	}
+
+	events := []interface{}{
+		busUserSignedUp{Email: "dana@example.com"},
+		busOrderPlaced{ID: 7, Total: 49.90},
+		busOrderPlaced{ID: 8, Total: 1250},
+		"a plain string",
+	}
+	for _, e := range events {
+		fmt.Printf("Publish %T:\n", e)
+		n, err := bus.Publish(e)
+		fmt.Printf("  -> %d handlers", n)
+		if err != nil {
+			fmt.Printf(", error: %v", err)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Catch-all counts: %v\n", seen)
+
+	// The match is on the dynamic type: a pointer is a different event, and
+	// only reaches handlers whose interface its method set satisfies
+	fmt.Println("\nPublish *busOrderPlaced:")
+	n, _ := bus.Publish(&busOrderPlaced{ID: 9, Total: 10})
+	fmt.Printf("  -> %d handlers (no func(busOrderPlaced) among them)\n", n)
+
+	unsubscribeFraud()
+	fmt.Println("\nAfter unsubscribing the fraud check:")
+	n, err = bus.Publish(busOrderPlaced{ID: 10, Total: 5000})
+	fmt.Printf("  -> %d handlers, error: %v\n", n, err)
+
+	// What the compiler would have caught with Subscribe[T]
+	for _, bad := range []interface{}{func(a, b int) {}, func(e busOrderPlaced) string { return "" }, "not a func"} {
+		_, err := bus.SubscribeFunc(bad)
+		fmt.Printf("SubscribeFunc(%T): %v\n", bad, err)
+	}
+	_, err = bus.Publish(nil)
+	fmt.Printf("Publish(nil): %v\n", err)
+}
+
+// busAudited is implemented by the events the audit log records
+type busAudited interface {
+	Audit() string
+}
+
+type busUserSignedUp struct {
+	Email string
+}
+
+func (e busUserSignedUp) Audit() string {
+	return "user signed up: " + e.Email
+}
+
+type busOrderPlaced struct {
+	ID    int
+	Total float64
+}
+
+func (e busOrderPlaced) Audit() string {
+	return fmt.Sprintf("order %d placed", e.ID)
+}
+
+type busInvoiceIssued struct {
+	OrderID int
 }
 
-// Object Mapper Implementation
+// Example 14: Query Builder
+func queryBuilderPattern() {
+	fmt.Println("\n--- Example 14: Query Builder ---")
+
+	// The existing User type, whose db tags were so far only ever printed
+	qb := NewQueryBuilder()
+	q, _ := qb.Select(User{}, Where("username", "=", "ann"))
+	fmt.Printf("%s\n  args %v\n", q.SQL, formatArgs(q.Args))
+
+	// A model with a key, database-filled columns, a nullable column and
+	// an embedded struct, written for PostgreSQL
+	qb = NewQueryBuilder().WithNumberedPlaceholders()
+	bio := "Writes Go"
+	member := qbMember{ID: 7, Email: "ann@example.com", DisplayName: "Ann", Bio: &bio, Password: "never stored"}
+	member.UpdatedAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	build := []struct {
+		name string
+		fn   func() (Query, error)
+	}{
+		{"select", func() (Query, error) {
+			return qb.Select(qbMember{}, Where("email", "LIKE", "%@example.com"), Where("id", ">", 100))
+		}},
+		{"insert", func() (Query, error) { return qb.Insert(member) }},
+		{"update", func() (Query, error) { return qb.Update(member) }},
+		{"update bio", func() (Query, error) { return qb.Update(member, "bio") }},
+		{"delete", func() (Query, error) { return qb.Delete(&member) }},
+	}
+	for _, b := range build {
+		q, err := b.fn()
+		if err != nil {
+			fmt.Printf("%s error: %v\n", b.name, err)
+			continue
+		}
+		fmt.Printf("%s\n  args %v\n", q.SQL, formatArgs(q.Args))
+	}
+
+	// Rows as a driver returns them: int64 ids, text timestamps, NULLs
+	rows := []map[string]interface{}{
+		{"id": int64(1), "email": "ann@example.com", "display_name": "Ann", "bio": "Writes Go",
+			"created_at": "2024-01-02 10:00:00", "updated_at": "2024-05-01 12:00:00"},
+		{"id": int64(2), "email": "bo@example.com", "display_name": "Bo", "bio": nil,
+			"created_at": "2024-02-03 09:30:00", "updated_at": nil},
+	}
+	var members []*qbMember
+	if err := ScanRows(rows, &members); err != nil {
+		fmt.Printf("Scan error: %v\n", err)
+		return
+	}
+	fmt.Println("\nScanned rows:")
+	for _, m := range members {
+		bio := "<NULL>"
+		if m.Bio != nil {
+			bio = *m.Bio
+		}
+		fmt.Printf("  #%d %s <%s> bio %s, created %s\n", m.ID, m.DisplayName, m.Email, bio, m.CreatedAt.Format("2006-01-02"))
+	}
+	var one qbMember
+	_ = ScanRows(rows[1:], &one)
+	fmt.Printf("  Into a single struct: #%d %s, updated_at zero: %v\n", one.ID, one.DisplayName, one.UpdatedAt.IsZero())
+
+	// Typos in column names are caught before they reach the database
+	fmt.Println("\nErrors:")
+	_, err := qb.Select(qbMember{}, Where("mail", "=", "x"))
+	fmt.Printf("  %v\n", err)
+	_, err = qb.Update(member, "created_at", "nickname")
+	fmt.Printf("  %v\n", err)
+	err = ScanRows([]map[string]interface{}{{"id": "seven"}}, &members)
+	fmt.Printf("  %v\n", err)
+	err = ScanRows([]map[string]interface{}{{"id": 1, "last_login": "2024-01-01"}}, &members)
+	fmt.Printf("  %v (errors.Is ErrUnknownColumn: %v)\n", err, errors.Is(err, ErrUnknownColumn))
+}
+
+// formatArgs prints nil arguments as NULL and times as dates
+func formatArgs(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		switch v := a.(type) {
+		case nil:
+			parts[i] = "NULL"
+		case time.Time:
+			parts[i] = v.Format("2006-01-02T15:04")
+		default:
+			parts[i] = fmt.Sprintf("%#v", v)
+		}
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// qbTimestamps is embedded, so its fields are columns of the embedding table
+type qbTimestamps struct {
+	CreatedAt time.Time `db:"created_at,auto"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type qbMember struct {
+	ID          int     `db:"id,pk,auto"`
+	Email       string  `db:"email"`
+	DisplayName string  // Column display_name, from SnakeNaming
+	Bio         *string `db:"bio"` // Nullable
+	Password    string  `db:"-"`
+	qbTimestamps
+}
+
+func (qbMember) TableName() string {
+	return "members"
+}
+
+// Example 15: Flatten and Unflatten
+func flattenPattern() {
+	fmt.Println("\n--- Example 15: Flatten and Unflatten ---")
+
+	// Configuration flattens to the keys the binder reads, lists included
+	settings := serviceSettings{
+		Name:    "checkout",
+		Timeout: 3 * time.Second,
+		Origins: []string{"https://shop.example", "https://admin.example"},
+		Server:  settingsServer{Host: "10.0.0.5", Port: 9090},
+		Database: settingsDatabase{
+			URL:      "postgres://db:5432/shop",
+			MaxConns: 20,
+			Ports:    []int{5432, 5433},
+		},
+	}
+	flat := Flatten(settings)
+	fmt.Println("Flattened settings:")
+	printFlatMap(flat)
+
+	var bound serviceSettings
+	err := NewConfigBinder().Bind(flat, &bound)
+	fmt.Printf("Bound back by ConfigBinder: equal %v, err %v\n", DeepEqual(settings, bound), err)
+
+	// Under ExactNaming the keys are Go field paths; pointers and maps
+	// are followed, and the round trip is lossless
+	exact := NewFlattener().WithNaming(ExactNaming)
+	record := profileRecord{
+		Name:    "Alice",
+		Age:     30,
+		Address: Address{City: "Lisbon", Country: "PT"},
+		Tags:    []string{"go", "ops"},
+		Meta:    map[string]string{"team": "core"},
+		Manager: &Person{Name: "Grace", Address: Address{City: "Porto"}},
+		Joined:  time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+	flat = exact.Flatten(record)
+	fmt.Println("\nFlattened profile, exact names:")
+	printFlatMap(flat)
+
+	var back profileRecord
+	err = exact.Unflatten(flat, &back)
+	fmt.Printf("Unflattened: equal %v, err %v\n", DeepEqual(record, back), err)
+
+	// ObjectMapper's map tags name the same paths
+	type profileCard struct {
+		Employee string `map:"Name"`
+		City     string `map:"Address.City"`
+		Manager  string `map:"Manager.Name"`
+	}
+	var card profileCard
+	if err := NewObjectMapper().Map(record, &card); err != nil {
+		fmt.Printf("Mapping error: %v\n", err)
+		return
+	}
+	cardVal := reflect.ValueOf(card)
+	for i := 0; i < cardVal.NumField(); i++ {
+		path := cardVal.Type().Field(i).Tag.Get("map")
+		fmt.Printf("  map:%-14q mapper %-7v flat %v\n", path, cardVal.Field(i).Interface(), flat[path])
+	}
+
+	// A sparse map patches a struct: lists grow to an index, maps gain
+	// entries, and everything else is kept
+	err = exact.Unflatten(map[string]interface{}{
+		"Address.City": "Porto",
+		"Tags.3":       "oncall",
+		"Meta.level":   3,
+	}, &back)
+	fmt.Printf("\nPatched: city %s, tags %q, meta %v, err %v\n", back.Address.City, back.Tags, back.Meta, err)
+
+	// Every key that does not fit is reported
+	err = exact.Unflatten(map[string]interface{}{
+		"Address.Zip": "1000",
+		"Age":         "thirty",
+		"Tags.first":  "x",
+		"Name":        "Alice",
+	}, &back)
+	fmt.Println("Errors:")
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			fmt.Printf("  %v\n", e)
+		}
+	}
+	fmt.Printf("  errors.Is ErrUnknownKey: %v\n", errors.Is(err, ErrUnknownKey))
+}
+
+// printFlatMap prints a flat map one key per line, in key order
+func printFlatMap(flat map[string]interface{}) {
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := flat[key]
+		if t, ok := value.(time.Time); ok {
+			value = t.Format("2006-01-02")
+		}
+		fmt.Printf("  %-24s %v\n", key, value)
+	}
+}
+
+// signupRequest exercises every rule the documentation exporter understands
+type signupRequest struct {
+	Username string   `json:"username" validate:"required,min=3,max=20"`
+	Email    string   `json:"email" validate:"required,email"`
+	Age      int      `json:"age,omitempty" validate:"min=13"`
+	Country  string   `json:"country" validate:"len=2"`
+	Tags     []string `json:"tags,omitempty" validate:"max=5"`
+	Referral string   `json:"referral,omitempty" validate:"alphanum"`
+}
+
+// Example 9: Constraint Documentation
+func constraintDocsPattern() {
+	fmt.Println("\n--- Example 9: Constraint Documentation ---")
+
+	// Markdown: Person is flattened into Employee, Address gets its own table
+	for _, obj := range []interface{}{Employee{}, User{}} {
+		doc, err := DescribeConstraints(reflect.TypeOf(obj))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		fmt.Println(doc.Markdown())
+	}
+
+	// OpenAPI-style schema; rules without a schema keyword survive as x-validate
+	doc, err := DescribeConstraints(reflect.TypeOf(signupRequest{}))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	schema, err := json.MarshalIndent(doc.OpenAPISchema(), "", "  ")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("OpenAPI schema for signupRequest:\n%s\n", schema)
+
+	// The validator reads the same tags, so the documented limits are the
+	// enforced ones
+	err = NewValidator().Validate(signupRequest{Username: "al", Email: "al.example.com", Age: 9, Country: "FRA"})
+	fmt.Printf("Validating a request outside the documented limits: %v\n", err)
+}
+
+// apiAccount has untagged fields, so its keys come from the naming strategy
+type apiAccount struct {
+	UserID      int
+	DisplayName string
+	APIKey      string
+	MaxRetries  int
+	Nickname    string `json:"nick"` // Tags still win
+}
+
+// Example 10: Naming Strategies
+func namingStrategyPattern() {
+	fmt.Println("\n--- Example 10: Naming Strategies ---")
+
+	strategies := []struct {
+		name   string
+		naming NamingStrategy
+	}{
+		{"snake", SnakeNaming},
+		{"camel", CamelNaming},
+		{"kebab", KebabNaming},
+		{"screaming", ScreamingNaming},
+	}
+
+	header := fmt.Sprintf("%-14s", "field")
+	for _, st := range strategies {
+		header += fmt.Sprintf(" %-16s", st.name)
+	}
+	fmt.Println(strings.TrimRight(header, " "))
+	for _, field := range []string{"UserID", "HTTPServer", "CreatedAt", "APIKey", "Address2Line"} {
+		row := fmt.Sprintf("%-14s", field)
+		for _, st := range strategies {
+			row += fmt.Sprintf(" %-16s", st.naming.Name(field))
+		}
+		fmt.Println(strings.TrimRight(row, " "))
+	}
+
+	// Serializer keys per strategy; naming_test.go checks that each one
+	// deserializes back to the same struct
+	account := apiAccount{UserID: 7, DisplayName: "Ada", APIKey: "k-123", MaxRetries: 3, Nickname: "ada"}
+	for _, st := range strategies {
+		data, err := NewGenericSerializer().WithNaming(st.naming).Serialize(account)
+		if err != nil {
+			fmt.Printf("Serialization error: %v\n", err)
+			continue
+		}
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Printf("Serialized %-10s %s\n", st.name+":", strings.Join(keys, " "))
+	}
+
+	// Binder: kebab-case config keys without a single mapstructure tag
+	type tlsSettings struct {
+		CertFile string
+		KeyFile  string
+	}
+	type serverSettings struct {
+		ListenAddr     string
+		MaxConnections int
+		TLS            tlsSettings
+	}
+	settings := map[string]interface{}{
+		"listen-addr":     ":8443",
+		"max-connections": "512",
+		"tls.cert-file":   "/etc/tls/cert.pem",
+		"tls.key-file":    "/etc/tls/key.pem",
+	}
+	var lower, kebab serverSettings
+	NewConfigBinder().Bind(settings, &lower)
+	NewConfigBinder().WithNaming(KebabNaming).Bind(settings, &kebab)
+	fmt.Printf("Binder, default lowercase keys: %+v\n", lower)
+	fmt.Printf("Binder, kebab keys:             %+v\n", kebab)
+
+	// Mapper: UserId and UserID only match once both are normalized
+	type legacyUser struct {
+		UserId   int
+		FullName string
+	}
+	type userView struct {
+		UserID   int
+		FullName string
+	}
+	var exact, normalized userView
+	NewObjectMapper().Map(legacyUser{UserId: 42, FullName: "Grace"}, &exact)
+	NewObjectMapper().WithNaming(SnakeNaming).Map(legacyUser{UserId: 42, FullName: "Grace"}, &normalized)
+	fmt.Printf("Mapper, exact names: %+v; with snake matching: %+v\n", exact, normalized)
+}
+
+// Object Mapper Implementation. A `map` tag links two fields in both
+// directions: on a destination field it names the source path to read, and
+// on a source field it names the destination path to write. The DTO that
+// carries the tags therefore maps to and from an untagged entity.
 type ObjectMapper struct {
-	mappings map[string]string
+	mappings   map[string]string
+	naming     NamingStrategy // Optional: also match fields whose names agree under it
+	converters map[converterKey]reflect.Value
+}
+
+// converterKey selects a converter by exact source and destination type
+type converterKey struct {
+	from, to reflect.Type
 }
 
 func NewObjectMapper() *ObjectMapper {
 	return &ObjectMapper{
-		mappings: make(map[string]string),
+		mappings:   make(map[string]string),
+		converters: make(map[converterKey]reflect.Value),
+	}
+}
+
+// WithNaming lets untagged fields match source fields that only differ in
+// spelling, e.g. UserID and UserId under SnakeNaming (both user_id)
+func (om *ObjectMapper) WithNaming(naming NamingStrategy) *ObjectMapper {
+	om.naming = naming
+	return om
+}
+
+// RegisterConverter adds a conversion used whenever a value of type S is
+// mapped to a D. fn is a func(S) D or func(S) (D, error); the pair is read
+// from its signature, and a later converter for the same pair replaces the
+// earlier one.
+func (om *ObjectMapper) RegisterConverter(fn interface{}) error {
+	fv := reflect.ValueOf(fn)
+	ft := fv.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 1 ||
+		!(ft.NumOut() == 1 || (ft.NumOut() == 2 && ft.Out(1) == errorType)) {
+		return fmt.Errorf("converter must be func(S) D or func(S) (D, error), got %v", ft)
 	}
+	om.converters[converterKey{ft.In(0), ft.Out(0)}] = fv
+	return nil
 }
 
 func (om *ObjectMapper) Map(source, destination interface{}) error {
 	srcVal := reflect.ValueOf(source)
 	destVal := reflect.ValueOf(destination)
 
-	if destVal.Kind() != reflect.Ptr {
-		return fmt.Errorf("destination must be a pointer")
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer")
 	}
 
 	destVal = destVal.Elem()
-	return om.mapValues(srcVal, destVal)
+	return om.mapValue(destVal, srcVal)
+}
+
+// mapValue stores src in dest, converting as needed: a registered
+// converter first, then plain assignment, then pointers, structs, slices
+// and maps element by element, and value coercion for the rest
+func (om *ObjectMapper) mapValue(dest, src reflect.Value) error {
+	if src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		return nil
+	}
+
+	if conv, ok := om.converters[converterKey{src.Type(), dest.Type()}]; ok {
+		out := conv.Call([]reflect.Value{src})
+		if len(out) == 2 && !out[1].IsNil() {
+			return out[1].Interface().(error)
+		}
+		dest.Set(out[0])
+		return nil
+	}
+	if src.Type().AssignableTo(dest.Type()) {
+		dest.Set(src)
+		return nil
+	}
+
+	switch {
+	case src.Kind() == reflect.Ptr:
+		if src.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		return om.mapValue(dest, src.Elem())
+
+	case dest.Kind() == reflect.Ptr:
+		if dest.IsNil() {
+			dest.Set(reflect.New(dest.Type().Elem()))
+		}
+		return om.mapValue(dest.Elem(), src)
+
+	case src.Kind() == reflect.Struct && dest.Kind() == reflect.Struct && dest.Type() != timeType:
+		return om.mapValues(src, dest)
+
+	case (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && dest.Kind() == reflect.Slice:
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		mapped := reflect.MakeSlice(dest.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := om.mapValue(mapped.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		dest.Set(mapped)
+		return nil
+
+	case src.Kind() == reflect.Map && dest.Kind() == reflect.Map:
+		if src.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		mapped := reflect.MakeMapWithSize(dest.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dest.Type().Key()).Elem()
+			if err := om.mapValue(key, iter.Key()); err != nil {
+				return fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			elem := reflect.New(dest.Type().Elem()).Elem()
+			if err := om.mapValue(elem, iter.Value()); err != nil {
+				return fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			mapped.SetMapIndex(key, elem)
+		}
+		dest.Set(mapped)
+		return nil
+	}
+
+	return CoerceInto(dest, src)
 }
 
+// mapValues maps two structs field by field: destination fields read the
+// source path in their tag (or their own name), then tagged source fields
+// write the destination path in their tag
 func (om *ObjectMapper) mapValues(src, dest reflect.Value) error {
 	destType := dest.Type()
 
@@ -259,13 +1642,32 @@ func (om *ObjectMapper) mapValues(src, dest reflect.Value) error {
 
 		// Get source value
 		srcValue, err := om.getNestedValue(src, mapTag)
-		if err != nil {
-			continue // Skip if source field not found
+		if err != nil || !srcValue.CanInterface() {
+			continue // Skip if source field not found or unexported
 		}
 
 		// Set destination field
 		if err := om.setValue(destField, srcValue); err != nil {
-			return fmt.Errorf("error setting field %s: %v", field.Name, err)
+			return fmt.Errorf("error setting field %s: %w", field.Name, err)
+		}
+	}
+
+	// The reverse direction: tags on the source say where its fields go
+	srcType := src.Type()
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+		mapTag := field.Tag.Get("map")
+		if mapTag == "" || !field.IsExported() {
+			continue
+		}
+
+		destField, err := om.getNestedField(dest, mapTag)
+		if err != nil || !destField.CanSet() {
+			continue // Skip if destination field not found
+		}
+
+		if err := om.setValue(destField, src.Field(i)); err != nil {
+			return fmt.Errorf("error setting field %s from %s: %w", mapTag, field.Name, err)
 		}
 	}
 
@@ -285,99 +1687,63 @@ func (om *ObjectMapper) getNestedValue(val reflect.Value, path string) (reflect.
 			return reflect.Value{}, fmt.Errorf("not a struct")
 		}
 
-		current = current.FieldByName(part)
-		if !current.IsValid() {
+		next := om.fieldByName(current, part)
+		if !next.IsValid() {
 			return reflect.Value{}, fmt.Errorf("field %s not found", part)
 		}
+		current = next
 	}
 
 	return current, nil
 }
 
-func (om *ObjectMapper) setValue(dest, src reflect.Value) error {
-	if !dest.CanSet() {
-		return fmt.Errorf("destination field cannot be set")
-	}
-
-	if src.Type().AssignableTo(dest.Type()) {
-		dest.Set(src)
-		return nil
-	}
-
-	if src.Type().ConvertibleTo(dest.Type()) {
-		dest.Set(src.Convert(dest.Type()))
-		return nil
-	}
-
-	return fmt.Errorf("cannot assign %v to %v", src.Type(), dest.Type())
-}
-
-// Dependency Injection Container
-type DIContainer struct {
-	singletons map[string]interface{}
-	factories  map[string]func() interface{}
-}
-
-func NewDIContainer() *DIContainer {
-	return &DIContainer{
-		singletons: make(map[string]interface{}),
-		factories:  make(map[string]func() interface{}),
-	}
-}
-
-func (di *DIContainer) RegisterSingleton(name string, instance interface{}) {
-	di.singletons[name] = instance
-}
-
-func (di *DIContainer) RegisterTransient(name string, factory func() interface{}) {
-	di.factories[name] = factory
-}
-
-func (di *DIContainer) Resolve(name string) (interface{}, error) {
-	if instance, exists := di.singletons[name]; exists {
-		return instance, nil
-	}
-
-	if factory, exists := di.factories[name]; exists {
-		return factory(), nil
-	}
-
-	return nil, fmt.Errorf("service %s not registered", name)
-}
-
-func (di *DIContainer) InjectDependencies(obj interface{}) error {
-	val := reflect.ValueOf(obj)
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
-	}
-
-	typ := val.Type()
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		fieldVal := val.Field(i)
+// getNestedField is getNestedValue for writing: nil pointers on the way
+// to the field are allocated, so "Customer.Name" works on a nil *Person
+func (om *ObjectMapper) getNestedField(val reflect.Value, path string) (reflect.Value, error) {
+	parts := strings.Split(path, ".")
+	current := val
 
-		if !fieldVal.CanSet() {
-			continue
+	for _, part := range parts {
+		if current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				if !current.CanSet() || current.Type().Elem().Kind() != reflect.Struct {
+					return reflect.Value{}, fmt.Errorf("cannot allocate %v", current.Type())
+				}
+				current.Set(reflect.New(current.Type().Elem()))
+			}
+			current = current.Elem()
 		}
 
-		// Check for inject tag
-		injectTag := field.Tag.Get("inject")
-		if injectTag == "" {
-			continue
+		if current.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("not a struct")
 		}
 
-		dependency, err := di.Resolve(injectTag)
-		if err != nil {
-			return fmt.Errorf("failed to resolve dependency %s: %v", injectTag, err)
+		next := om.fieldByName(current, part)
+		if !next.IsValid() {
+			return reflect.Value{}, fmt.Errorf("field %s not found", part)
 		}
+		current = next
+	}
+
+	return current, nil
+}
 
-		depVal := reflect.ValueOf(dependency)
-		if depVal.Type().AssignableTo(fieldVal.Type()) {
-			fieldVal.Set(depVal)
+// fieldByName finds a field by name, or by the naming strategy if one is set
+func (om *ObjectMapper) fieldByName(current reflect.Value, name string) reflect.Value {
+	next := current.FieldByName(name)
+	if !next.IsValid() && om.naming != nil {
+		want := om.naming.Name(name)
+		for i := 0; i < current.NumField(); i++ {
+			if current.Type().Field(i).IsExported() && om.naming.Name(current.Type().Field(i).Name) == want {
+				return current.Field(i)
+			}
 		}
 	}
+	return next
+}
 
-	return nil
+func (om *ObjectMapper) setValue(dest, src reflect.Value) error {
+	return om.mapValue(dest, src)
 }
 
 // Mock services for DI example
@@ -402,8 +1768,17 @@ func NewValidator() *ValidatorFramework {
 		return nil
 	}
 
-	v.rules["min"] = func(val reflect.Value) error {
-		// This is a simplified implementation
+	// An empty address is left to required
+	v.rules["email"] = func(val reflect.Value) error {
+		for val.Kind() == reflect.Ptr && !val.IsNil() {
+			val = val.Elem()
+		}
+		if val.Kind() != reflect.String || val.Len() == 0 {
+			return nil
+		}
+		if addr, err := mail.ParseAddress(val.String()); err != nil || addr.Address != val.String() {
+			return fmt.Errorf("invalid email address")
+		}
 		return nil
 	}
 
@@ -430,23 +1805,17 @@ func (vf *ValidatorFramework) Validate(obj interface{}) error {
 			continue
 		}
 
-		rules := strings.Split(validateTag, ",")
-		for _, rule := range rules {
-			rule = strings.TrimSpace(rule)
-
-			// Parse rule (e.g., "min=3")
-			parts := strings.Split(rule, "=")
-			ruleName := parts[0]
-
-			if validator, exists := vf.rules[ruleName]; exists {
+		// Parse rules (e.g., "required,min=3")
+		for _, rule := range parseValidateTag(validateTag) {
+			if validator, exists := vf.rules[rule.Name]; exists {
 				if err := validator(fieldVal); err != nil {
 					errors = append(errors, fmt.Sprintf("%s: %v", field.Name, err))
 				}
 			}
 
 			// Handle specific rules
-			if ruleName == "min" && len(parts) > 1 {
-				if err := vf.validateMin(fieldVal, parts[1]); err != nil {
+			if (rule.Name == "min" || rule.Name == "max" || rule.Name == "len") && rule.Param != "" {
+				if err := vf.validateBound(fieldVal, rule); err != nil {
 					errors = append(errors, fmt.Sprintf("%s: %v", field.Name, err))
 				}
 			}
@@ -460,25 +1829,67 @@ func (vf *ValidatorFramework) Validate(obj interface{}) error {
 	return nil
 }
 
-func (vf *ValidatorFramework) validateMin(val reflect.Value, minStr string) error {
+// validateBound checks min/max against a number's value or the length of a
+// string, slice or map, and len against a length only. It follows the same
+// rules as applyRules (constraintdocs.go), so documented limits are enforced.
+func (vf *ValidatorFramework) validateBound(val reflect.Value, rule ValidationRule) error {
+	bound, err := strconv.ParseFloat(rule.Param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s parameter %q", rule.Name, rule.Param)
+	}
+
+	// A nil pointer has no value to bound; required catches it if needed
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	var actual float64
+	isLength := true
+	tooSmall, tooLarge := "value too small", "value too large"
 	switch val.Kind() {
 	case reflect.String:
-		if len(val.String()) < 3 { // Simplified - should parse minStr
-			return fmt.Errorf("string too short")
-		}
-	case reflect.Int:
-		if val.Int() < 1 { // Simplified
-			return fmt.Errorf("value too small")
-		}
+		actual = float64(len(val.String()))
+		tooSmall, tooLarge = "string too short", "string too long"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual, isLength = float64(val.Int()), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual, isLength = float64(val.Uint()), false
+	case reflect.Float32, reflect.Float64:
+		actual, isLength = val.Float(), false
+	case reflect.Slice, reflect.Array, reflect.Map:
+		actual = float64(val.Len())
+		tooSmall, tooLarge = "too few items", "too many items"
+	default:
+		return nil
+	}
+
+	switch {
+	case rule.Name == "len" && isLength && actual != bound:
+		return fmt.Errorf("length must be %s", rule.Param)
+	case rule.Name == "min" && actual < bound:
+		return fmt.Errorf("%s", tooSmall)
+	case rule.Name == "max" && actual > bound:
+		return fmt.Errorf("%s", tooLarge)
 	}
 	return nil
 }
 
 // Generic Serialization Framework
-type GenericSerializer struct{}
+type GenericSerializer struct {
+	naming NamingStrategy // Keys for fields without a json name
+}
 
 func NewGenericSerializer() *GenericSerializer {
-	return &GenericSerializer{}
+	return &GenericSerializer{naming: ExactNaming} // encoding/json uses the field name as is
+}
+
+// WithNaming sets the key strategy for fields without a json name
+func (gs *GenericSerializer) WithNaming(naming NamingStrategy) *GenericSerializer {
+	gs.naming = naming
+	return gs
 }
 
 func (gs *GenericSerializer) Serialize(obj interface{}) (map[string]interface{}, error) {
@@ -499,20 +1910,12 @@ func (gs *GenericSerializer) Serialize(obj interface{}) (map[string]interface{},
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
 
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "-" {
+		_, omitEmpty, skip := jsonFieldName(field)
+		if skip || (omitEmpty && IsEmptyJSON(fieldVal)) {
 			continue
 		}
 
-		key := field.Name
-		if jsonTag != "" {
-			parts := strings.Split(jsonTag, ",")
-			if parts[0] != "" {
-				key = parts[0]
-			}
-		}
-
-		result[key] = fieldVal.Interface()
+		result[fieldKey(field, "json", gs.naming)] = fieldVal.Interface()
 	}
 
 	return result, nil
@@ -535,159 +1938,17 @@ func (gs *GenericSerializer) Deserialize(data map[string]interface{}, obj interf
 			continue
 		}
 
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "-" {
+		if _, _, skip := jsonFieldName(field); skip {
 			continue
 		}
 
-		key := field.Name
-		if jsonTag != "" {
-			parts := strings.Split(jsonTag, ",")
-			if parts[0] != "" {
-				key = parts[0]
-			}
-		}
-
+		key := fieldKey(field, "json", gs.naming)
 		if value, exists := data[key]; exists {
-			srcVal := reflect.ValueOf(value)
-			if srcVal.Type().AssignableTo(fieldVal.Type()) {
-				fieldVal.Set(srcVal)
-			} else if srcVal.Type().ConvertibleTo(fieldVal.Type()) {
-				fieldVal.Set(srcVal.Convert(fieldVal.Type()))
+			if err := CoerceInto(fieldVal, reflect.ValueOf(value)); err != nil {
+				return fmt.Errorf("field %s: %v", field.Name, err)
 			}
 		}
 	}
 
 	return nil
 }
-
-// Configuration Binding
-type ConfigBinder struct{}
-
-func NewConfigBinder() *ConfigBinder {
-	return &ConfigBinder{}
-}
-
-func (cb *ConfigBinder) Bind(data map[string]interface{}, obj interface{}) error {
-	val := reflect.ValueOf(obj)
-	if val.Kind() != reflect.Ptr {
-		return fmt.Errorf("destination must be a pointer")
-	}
-
-	val = val.Elem()
-	return cb.bindStruct(data, val, "")
-}
-
-func (cb *ConfigBinder) bindStruct(data map[string]interface{}, val reflect.Value, prefix string) error {
-	typ := val.Type()
-
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		fieldVal := val.Field(i)
-
-		if !fieldVal.CanSet() {
-			continue
-		}
-
-		mapTag := field.Tag.Get("mapstructure")
-		if mapTag == "" {
-			mapTag = strings.ToLower(field.Name)
-		}
-
-		key := mapTag
-		if prefix != "" {
-			key = prefix + "." + mapTag
-		}
-
-		if fieldVal.Kind() == reflect.Struct {
-			// Recursively bind nested structs
-			if err := cb.bindStruct(data, fieldVal, key); err != nil {
-				return err
-			}
-		} else {
-			// Bind simple field
-			if value, exists := data[key]; exists {
-				srcVal := reflect.ValueOf(value)
-				if srcVal.Type().AssignableTo(fieldVal.Type()) {
-					fieldVal.Set(srcVal)
-				} else if srcVal.Type().ConvertibleTo(fieldVal.Type()) {
-					fieldVal.Set(srcVal.Convert(fieldVal.Type()))
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-// Test Data Builder
-type TestDataBuilder struct {
-	generators map[reflect.Kind]func() interface{}
-}
-
-func NewTestDataBuilder() *TestDataBuilder {
-	tdb := &TestDataBuilder{
-		generators: make(map[reflect.Kind]func() interface{}),
-	}
-
-	// Register default generators
-	tdb.generators[reflect.String] = func() interface{} {
-		return "test_string"
-	}
-	tdb.generators[reflect.Int] = func() interface{} {
-		return 42
-	}
-	tdb.generators[reflect.Bool] = func() interface{} {
-		return true
-	}
-
-	return tdb
-}
-
-func (tdb *TestDataBuilder) Build(prototype interface{}) interface{} {
-	typ := reflect.TypeOf(prototype)
-	val := reflect.New(typ).Elem()
-
-	tdb.populateStruct(val)
-	return val.Addr().Interface()
-}
-
-func (tdb *TestDataBuilder) BuildWithRules(prototype interface{}, rules map[string]interface{}) interface{} {
-	obj := tdb.Build(prototype)
-	val := reflect.ValueOf(obj).Elem()
-
-	for fieldName, value := range rules {
-		field := val.FieldByName(fieldName)
-		if field.IsValid() && field.CanSet() {
-			srcVal := reflect.ValueOf(value)
-			if srcVal.Type().AssignableTo(field.Type()) {
-				field.Set(srcVal)
-			}
-		}
-	}
-
-	return obj
-}
-
-func (tdb *TestDataBuilder) populateStruct(val reflect.Value) {
-	typ := val.Type()
-
-	for i := 0; i < typ.NumField(); i++ {
-		// field := typ.Field(i) // Get the field type
-		fieldVal := val.Field(i)
-
-		if !fieldVal.CanSet() {
-			continue
-		}
-
-		if fieldVal.Kind() == reflect.Struct {
-			tdb.populateStruct(fieldVal)
-		} else if generator, exists := tdb.generators[fieldVal.Kind()]; exists {
-			generated := generator()
-			srcVal := reflect.ValueOf(generated)
-			if srcVal.Type().AssignableTo(fieldVal.Type()) {
-				fieldVal.Set(srcVal)
-			}
-		}
-	}
-}
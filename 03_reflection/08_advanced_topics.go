@@ -1,8 +1,12 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 	"unsafe"
@@ -29,6 +33,12 @@ func AdvancedTopicsExamples() {
 
 	// Example 6: Advanced type manipulation
 	advancedTypeManipulation()
+
+	// Example 7: Struct layout optimization
+	structLayoutOptimization()
+
+	// Example 8: Dynamic proxies with MakeFunc
+	dynamicProxies()
 }
 
 // Example 1: Performance optimization techniques
@@ -78,6 +88,8 @@ func performanceOptimization() {
 		reflection, total2, float64(reflection)/float64(direct))
 	fmt.Printf("  Reflection (cached): %v (total: %d, %.1fx slower)\n",
 		cached, total3, float64(cached)/float64(direct))
+	fmt.Println("  (single timed pass; for ns/op and allocations, including nested paths, run")
+	fmt.Println("   go test -bench=FieldAccess -benchmem ./03_reflection)")
 
 	// Demonstrate compilation optimization
 	demonstrateCompilerOptimization()
@@ -138,6 +150,10 @@ func memorySafetyConsiderations() {
 	// Security considerations
 	fmt.Printf("\nSecurity considerations:\n")
 	demonstrateSecurityConsiderations()
+
+	// Opt-in, guarded unsafe bridge
+	fmt.Printf("\nGuarded unsafe bridge (ForceSet, enabled=%v):\n", ForceSetEnabled)
+	demonstrateForceSet()
 }
 
 // Example 4: Concurrent reflection operations
@@ -209,6 +225,10 @@ func advancedTypeManipulation() {
 	fmt.Printf("Dynamic type creation:\n")
 	demonstrateDynamicTypeCreation()
 
+	// Dynamic struct creation
+	fmt.Printf("\nDynamic struct creation:\n")
+	demonstrateDynamicStructCreation()
+
 	// Type composition
 	fmt.Printf("\nType composition:\n")
 	demonstrateTypeComposition()
@@ -218,6 +238,163 @@ func advancedTypeManipulation() {
 	demonstrateInterfaceSynthesis()
 }
 
+// Example 7: Struct layout optimization
+func structLayoutOptimization() {
+	fmt.Println("\n--- Example 7: Struct Layout Optimization ---")
+
+	// A deliberately badly ordered struct: every small field forces padding
+	layout, _ := AnalyzeStructLayout(reflect.TypeOf(paddedRecord{}))
+	fmt.Printf("Detailed layout:\n")
+	layout.Print()
+
+	fmt.Printf("\nSuggested field order: %v\n", layout.OptimalOrder)
+	fmt.Printf("Reordered size: %d bytes (saves %d bytes per value, %d KB per million values)\n",
+		layout.OptimalSize, layout.Size-layout.OptimalSize, (layout.Size-layout.OptimalSize)*1000000/1024)
+
+	// Verify the suggestion against the compiler's own layout
+	fmt.Printf("Compiler size of reordered struct: %d bytes\n", unsafe.Sizeof(compactRecord{}))
+
+	// Summary over every example struct
+	fmt.Printf("\nLayout report for example structs:\n")
+	fmt.Printf("  %-16s %6s %8s %8s  %s\n", "Struct", "Size", "Padding", "Optimal", "Suggestion")
+	for _, t := range exampleStructTypes() {
+		layout, err := AnalyzeStructLayout(t)
+		if err != nil {
+			fmt.Printf("  %-16s error: %v\n", t.Name(), err)
+			continue
+		}
+		suggestion := "already optimal"
+		if layout.OptimalSize < layout.Size {
+			suggestion = fmt.Sprintf("reorder to %v", layout.OptimalOrder)
+		}
+		fmt.Printf("  %-16s %6d %8d %8d  %s\n", t.Name(), layout.Size, layout.Padding, layout.OptimalSize, suggestion)
+	}
+}
+
+// Example 8: Dynamic proxies with MakeFunc
+func dynamicProxies() {
+	fmt.Println("\n--- Example 8: Dynamic Proxies With MakeFunc ---")
+
+	// MakeFunc turns one generic body into a function of any signature
+	swap := func(in []reflect.Value) []reflect.Value { return []reflect.Value{in[1], in[0]} }
+	var swapInts func(int, int) (int, int)
+	var swapStrings func(string, string) (string, string)
+	for _, ptr := range []interface{}{&swapInts, &swapStrings} {
+		fn := reflect.ValueOf(ptr).Elem()
+		fn.Set(reflect.MakeFunc(fn.Type(), swap))
+	}
+	a, b := swapInts(1, 2)
+	s, t := swapStrings("x", "y")
+	fmt.Printf("One body, two signatures: swapInts(1, 2) = %d, %d; swapStrings(\"x\", \"y\") = %s, %s\n", a, b, s, t)
+
+	// WrapFunc keeps a function's type and runs interceptors around it
+	logf := func(format string, args ...interface{}) { fmt.Printf("    "+format+"\n", args...) }
+	join := WrapFunc("join", strings.Join, LoggingInterceptor(logf)).(func([]string, string) string)
+	fmt.Println("\nWrapped strings.Join:")
+	join([]string{"a", "b"}, "+")
+
+	// An interface proxy: the stub is written once, NewProxy fills its
+	// fields so every Calculator method goes through the interceptors
+	timings := map[string]int{}
+	count := TimingInterceptor(func(method string, _ time.Duration) { timings[method]++ })
+	calc, err := NewProxy[Calculator](SimpleCalculator{}, &calculatorStub{}, count, LoggingInterceptor(logf))
+	if err != nil {
+		fmt.Printf("Proxy error: %v\n", err)
+		return
+	}
+	fmt.Println("\nCalculator proxy:")
+	calc.Add(2, 3)
+	calc.Divide(7, 0)
+	calc.Multiply(6, 7)
+	fmt.Printf("  Calls timed: %v\n", timings)
+
+	// An interceptor that answers without calling next: memoization
+	cache := map[string][]reflect.Value{}
+	memo := func(call *MethodCall, next func()) {
+		key := call.String()
+		if results, ok := cache[key]; ok {
+			call.Results = results
+			return
+		}
+		next()
+		cache[key] = call.Results
+	}
+	calls := 0
+	counter := func(call *MethodCall, next func()) { calls++; next() }
+	calc, _ = NewProxy[Calculator](SimpleCalculator{}, &calculatorStub{}, memo, counter)
+	for i := 0; i < 3; i++ {
+		calc.Multiply(12, 12)
+	}
+	fmt.Printf("\nMemoized: 3 calls to Multiply(12, 12) reached the target %d time(s)\n", calls)
+
+	// Panic recovery: a panic becomes the error result where there is one
+	store, err := NewProxy[proxyStore](&mapStore{}, &proxyStoreStub{}, RecoverInterceptor())
+	if err != nil {
+		fmt.Printf("Proxy error: %v\n", err)
+		return
+	}
+	fmt.Println("\nRecovering store proxy (the target's map was never made):")
+	fmt.Printf("  Put: %v\n", store.Put("k", "v"))
+	_, err = store.Get("k")
+	fmt.Printf("  Get: %v\n", err)
+
+	// Stubs are checked against the interface
+	_, err = NewProxy[Calculator](SimpleCalculator{}, &incompleteCalculatorStub{})
+	fmt.Printf("\nIncomplete stub: %v\n", err)
+
+	fmt.Println("\nWhy a stub at all: reflect can build functions (MakeFunc) and struct")
+	fmt.Println("types (StructOf), but not methods, and only methods satisfy interfaces.")
+}
+
+// calculatorStub implements Calculator by calling its fields
+type calculatorStub struct {
+	AddFunc      func(a, b int) int
+	SubtractFunc func(a, b int) int
+	MultiplyFunc func(a, b int) int
+	DivideFunc   func(a, b int) (int, error)
+}
+
+func (s *calculatorStub) Add(a, b int) int             { return s.AddFunc(a, b) }
+func (s *calculatorStub) Subtract(a, b int) int        { return s.SubtractFunc(a, b) }
+func (s *calculatorStub) Multiply(a, b int) int        { return s.MultiplyFunc(a, b) }
+func (s *calculatorStub) Divide(a, b int) (int, error) { return s.DivideFunc(a, b) }
+
+// incompleteCalculatorStub is missing DivideFunc
+type incompleteCalculatorStub struct {
+	calculatorStub
+	DivideFunc func(a, b float64) (float64, error)
+}
+
+type proxyStore interface {
+	Get(key string) (string, error)
+	Put(key, value string) error
+}
+
+type mapStore struct {
+	data map[string]string
+}
+
+func (m *mapStore) Get(key string) (string, error) {
+	value, ok := m.data[key]
+	if !ok {
+		return "", fmt.Errorf("no key %q", key)
+	}
+	return value, nil
+}
+
+func (m *mapStore) Put(key, value string) error {
+	m.data[key] = value // Panics: nil map
+	return nil
+}
+
+type proxyStoreStub struct {
+	GetFunc func(key string) (string, error)
+	PutFunc func(key, value string) error
+}
+
+func (s *proxyStoreStub) Get(key string) (string, error) { return s.GetFunc(key) }
+func (s *proxyStoreStub) Put(key, value string) error    { return s.PutFunc(key, value) }
+
 // Helper implementations
 
 func findFieldIndex(t reflect.Type, fieldName string) int {
@@ -343,6 +520,11 @@ func analyzeMemoryLayout() {
 	fmt.Printf("  Name field offset: %d bytes\n", unsafe.Offsetof(person.Name))
 	fmt.Printf("  Age field offset: %d bytes\n", unsafe.Offsetof(person.Age))
 
+	// The same information for every field, plus padding, via reflection
+	if layout, err := AnalyzeStructLayout(reflect.TypeOf(person)); err == nil {
+		layout.Print()
+	}
+
 	// Show reflection overhead
 	v := reflect.ValueOf(person)
 	fmt.Printf("  reflect.Value size: %d bytes\n", unsafe.Sizeof(v))
@@ -351,6 +533,143 @@ func analyzeMemoryLayout() {
 	fmt.Printf("  reflect.Type size: %d bytes\n", unsafe.Sizeof(t))
 }
 
+// paddedRecord alternates small and large fields, wasting space on padding
+type paddedRecord struct {
+	Active  bool
+	ID      int64
+	Flag    bool
+	Count   int32
+	Kind    byte
+	Score   float64
+	Deleted bool
+}
+
+// compactRecord holds the same fields as paddedRecord in the suggested order
+type compactRecord struct {
+	ID      int64
+	Score   float64
+	Count   int32
+	Active  bool
+	Flag    bool
+	Kind    byte
+	Deleted bool
+}
+
+// exampleStructTypes lists the structs shared by the reflection examples
+func exampleStructTypes() []reflect.Type {
+	return []reflect.Type{
+		reflect.TypeOf(Person{}),
+		reflect.TypeOf(Address{}),
+		reflect.TypeOf(Employee{}),
+		reflect.TypeOf(Event{}),
+		reflect.TypeOf(User{}),
+		reflect.TypeOf(Config{}),
+		reflect.TypeOf(DatabaseConfig{}),
+		reflect.TypeOf(BenchmarkStruct{}),
+		reflect.TypeOf(CustomError{}),
+		reflect.TypeOf(paddedRecord{}),
+		reflect.TypeOf(compactRecord{}),
+	}
+}
+
+// FieldLayout describes where a field lives inside its struct
+type FieldLayout struct {
+	Name    string
+	Type    reflect.Type
+	Offset  uintptr
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // Bytes wasted after this field before the next one (or the struct end)
+}
+
+// StructLayout is the memory layout of a struct and its optimal reordering
+type StructLayout struct {
+	Type         reflect.Type
+	Size         uintptr
+	Align        uintptr
+	Padding      uintptr // Total padding bytes
+	Fields       []FieldLayout
+	OptimalOrder []string
+	OptimalSize  uintptr
+}
+
+// AnalyzeStructLayout reports field offsets and padding, and suggests a field order
+// that minimizes the struct size
+func AnalyzeStructLayout(t reflect.Type) (*StructLayout, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, got %v", t.Kind())
+	}
+
+	layout := &StructLayout{
+		Type:  t,
+		Size:  t.Size(),
+		Align: uintptr(t.Align()),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		end := t.Size()
+		if i+1 < t.NumField() {
+			end = t.Field(i + 1).Offset
+		}
+		fl := FieldLayout{
+			Name:    field.Name,
+			Type:    field.Type,
+			Offset:  field.Offset,
+			Size:    field.Type.Size(),
+			Align:   uintptr(field.Type.FieldAlign()),
+			Padding: end - field.Offset - field.Type.Size(),
+		}
+		layout.Fields = append(layout.Fields, fl)
+		layout.Padding += fl.Padding
+	}
+
+	// Sorting by alignment (largest first) packs fields without gaps;
+	// zero-size fields go first since a trailing one forces extra padding
+	ordered := make([]FieldLayout, len(layout.Fields))
+	copy(ordered, layout.Fields)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if (ordered[i].Size == 0) != (ordered[j].Size == 0) {
+			return ordered[i].Size == 0
+		}
+		return ordered[i].Align > ordered[j].Align
+	})
+
+	var offset uintptr
+	for _, f := range ordered {
+		offset = alignUp(offset, f.Align) + f.Size
+		layout.OptimalOrder = append(layout.OptimalOrder, f.Name)
+	}
+	layout.OptimalSize = alignUp(offset, layout.Align)
+
+	// Never suggest something worse than the current order
+	if layout.OptimalSize >= layout.Size {
+		layout.OptimalSize = layout.Size
+		layout.OptimalOrder = layout.OptimalOrder[:0]
+		for _, f := range layout.Fields {
+			layout.OptimalOrder = append(layout.OptimalOrder, f.Name)
+		}
+	}
+
+	return layout, nil
+}
+
+// Print writes a field-by-field table of the layout
+func (l *StructLayout) Print() {
+	fmt.Printf("  %s: size=%d align=%d padding=%d\n", l.Type.Name(), l.Size, l.Align, l.Padding)
+	fmt.Printf("    %-10s %-14s %6s %5s %7s\n", "Field", "Type", "Offset", "Size", "Padding")
+	for _, f := range l.Fields {
+		fmt.Printf("    %-10s %-14s %6d %5d %7d\n", f.Name, f.Type, f.Offset, f.Size, f.Padding)
+	}
+}
+
+func alignUp(offset, align uintptr) uintptr {
+	return (offset + align - 1) &^ (align - 1)
+}
+
 func demonstrateUnsafeOperations() {
 	// WARNING: These are dangerous operations for demonstration only
 	person := Person{Name: "Alice", Age: 30}
@@ -399,6 +718,61 @@ func demonstrateSecurityConsiderations() {
 	// Note: Actual type confusion would require more complex unsafe operations
 }
 
+// lockedAccount hides its state behind unexported fields
+type lockedAccount struct {
+	Owner   string
+	balance int
+	history []string
+	auditMeta
+	*lockedExtra
+}
+
+type auditMeta struct {
+	revision int
+}
+
+type lockedExtra struct {
+	note string
+}
+
+func demonstrateForceSet() {
+	account := lockedAccount{Owner: "Alice", balance: 100, history: []string{"open"}}
+
+	// Each case documents exactly when ForceSet is and isn't legal
+	cases := []struct {
+		desc   string
+		target interface{}
+		field  string
+		value  interface{}
+	}{
+		{"unexported field via pointer", &account, "balance", 500},
+		{"exported field (no unsafe needed)", &account, "Owner", "Bob"},
+		{"nil for a nillable field", &account, "history", nil},
+		{"promoted through embedded struct", &account, "revision", 7},
+		{"struct value instead of pointer", account, "balance", 1},
+		{"nil pointer", (*lockedAccount)(nil), "balance", 1},
+		{"missing field", &account, "overdraft", 1},
+		{"wrong type (no conversions)", &account, "balance", "lots"},
+		{"int64 into int (no conversions)", &account, "balance", int64(1)},
+		{"nil for a non-nillable field", &account, "balance", nil},
+		{"promoted through embedded pointer", &account, "note", "hi"},
+	}
+
+	for _, c := range cases {
+		if err := ForceSet(c.target, c.field, c.value); err != nil {
+			fmt.Printf("  ✗ %-36s %v\n", c.desc+":", err)
+		} else {
+			fmt.Printf("  ✓ %-36s ok\n", c.desc+":")
+		}
+	}
+	fmt.Printf("  Result: owner=%s balance=%d history=%v revision=%d\n",
+		account.Owner, account.balance, account.history, account.revision)
+
+	// Errors are sentinels, so callers can react to the specific guardrail
+	err := ForceSet(account, "balance", 1)
+	fmt.Printf("  errors.Is(err, ErrNotAddressable): %v\n", errors.Is(err, ErrNotAddressable))
+}
+
 // Thread-safe cache
 type ThreadSafeCache struct {
 	mu    sync.RWMutex
@@ -551,6 +925,72 @@ func demonstrateDynamicTypeCreation() {
 	fmt.Printf("  Dynamic map: %v\n", m.Interface())
 }
 
+func demonstrateDynamicStructCreation() {
+	// A schema known only at run time, say a CSV header with column types
+	columns := []struct{ name, kind string }{{"id", "int"}, {"name", "string"}, {"price", "float"}, {"tags", "list"}}
+	kinds := map[string]reflect.Type{
+		"int":    reflect.TypeOf(0),
+		"string": reflect.TypeOf(""),
+		"float":  reflect.TypeOf(0.0),
+		"list":   reflect.TypeOf([]string(nil)),
+	}
+
+	builder := NewDynamicStructBuilder()
+	for _, c := range columns {
+		name := strings.ToUpper(c.name[:1]) + c.name[1:]
+		builder.AddField(name, kinds[c.kind], fmt.Sprintf(`json:"%s,omitempty"`, c.name))
+	}
+	product, err := builder.Build()
+	if err != nil {
+		fmt.Printf("  Build error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Created struct type: %v\n", product.Type())
+
+	row := product.New()
+	for name, value := range map[string]interface{}{"Id": "42", "Name": "Lamp", "Price": 19.5, "Tags": "home,light"} {
+		if err := row.Set(name, value); err != nil {
+			fmt.Printf("  Set error: %v\n", err)
+			return
+		}
+	}
+	fmt.Printf("  Value: %+v\n", row.Interface())
+
+	// Tag-driven code sees an ordinary struct
+	data, _ := json.Marshal(row.Interface())
+	fmt.Printf("  json.Marshal: %s\n", data)
+	fields, _ := NewGenericSerializer().Serialize(row.Interface())
+	back := product.New()
+	if err := NewGenericSerializer().Deserialize(fields, back.Interface()); err != nil {
+		fmt.Printf("  Deserialize error: %v\n", err)
+		return
+	}
+	fmt.Printf("  Serializer round trip equal: %v\n", reflect.DeepEqual(row.Interface(), back.Interface()))
+
+	// The same fields give the same type, identical to the literal one
+	static := struct {
+		Id    int      `json:"id,omitempty"`
+		Name  string   `json:"name,omitempty"`
+		Price float64  `json:"price,omitempty"`
+		Tags  []string `json:"tags,omitempty"`
+	}{}
+	reflect.ValueOf(&static).Elem().Set(reflect.ValueOf(row.Interface()).Elem())
+	fmt.Printf("  Same type as the struct literal: %v, copied into it: %+v\n",
+		product.Type() == reflect.TypeOf(static), static)
+
+	// Limitations
+	fmt.Println("  Limitations:")
+	fmt.Printf("    no name (%q) and no methods of its own (%d); it cannot implement fmt.Stringer\n",
+		product.Type().Name(), product.Type().NumMethod())
+	_, err = NewDynamicStructBuilder().
+		AddField("secret", reflect.TypeOf(""), "").
+		AddField("Name", reflect.TypeOf(""), "").
+		AddField("Name", reflect.TypeOf(0), "").
+		AddField("2fast", reflect.TypeOf(0), "").
+		Build()
+	fmt.Printf("    rejected fields:\n      %s\n", strings.ReplaceAll(err.Error(), "\n", "\n      "))
+}
+
 func demonstrateTypeComposition() {
 	// Analyze composed types
 	rwType := reflect.TypeOf((*ReadWriter)(nil)).Elem()
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// JSONCodecExamples builds a JSON encoder and decoder out of reflect and
+// checks it against encoding/json: the capstone of struct tags, value
+// setting, pointer allocation and type caching
+func JSONCodecExamples() {
+	fmt.Println("\n=== Reflection JSON Codec Examples ===")
+
+	// Example 1: Encoding
+	jsonEncoding()
+
+	// Example 2: Decoding
+	jsonDecoding()
+
+	// Example 3: The field plan behind both
+	jsonFieldPlan()
+
+	// Example 4: Errors
+	jsonErrors()
+
+	// Example 5: Against encoding/json
+	jsonBenchmark()
+}
+
+// Example 1: Encoding
+func jsonEncoding() {
+	fmt.Println("\n--- Example 1: Encoding ---")
+
+	order := sampleCodecOrder()
+	ours, err := EncodeJSON(order)
+	if err != nil {
+		fmt.Printf("EncodeJSON error: %v\n", err)
+		return
+	}
+	theirs, _ := json.Marshal(order)
+
+	fmt.Printf("EncodeJSON:   %s\n", ours)
+	fmt.Printf("json.Marshal identical: %v\n", string(ours) == string(theirs))
+
+	// What each rule does to a single value
+	fmt.Println("\nRule by rule:")
+	note := "gift"
+	cases := []struct {
+		rule  string
+		value interface{}
+	}{
+		{"omitempty drops zero fields", codecItem{SKU: "A-1", Qty: 1}},
+		{"non-nil pointer is followed", codecItem{SKU: "A-2", Qty: 1, Note: &note}},
+		{"[]byte becomes base64", []byte("hello")},
+		{"nil slice is null", []string(nil)},
+		{"empty slice is []", []string{}},
+		{"map keys sorted", map[string]int{"b": 2, "a": 1, "c": 3}},
+		{"int keys quoted", map[int]bool{10: true, 2: false}},
+		{"control chars escaped", "tab\there \"quoted\"\n"},
+		{"small floats in exponent form", 0.0000001},
+		{"embedded fields promoted", Employee{Person: Person{Name: "Ann"}, ID: 7}},
+	}
+	for _, c := range cases {
+		ours, _ := EncodeJSON(c.value)
+		theirs, _ := json.Marshal(c.value)
+		match := "same as json.Marshal"
+		if string(ours) != string(theirs) {
+			match = "json.Marshal: " + string(theirs)
+		}
+		fmt.Printf("  %-30s %s  (%s)\n", c.rule+":", ours, match)
+	}
+
+	// Where the two part ways: encoding/json makes strings safe to embed in
+	// HTML, which this codec leaves out
+	ours, _ = EncodeJSON("<b>&</b>")
+	theirs, _ = json.Marshal("<b>&</b>")
+	fmt.Printf("  %-30s %s  (json.Marshal: %s)\n", "HTML escaping:", ours, theirs)
+}
+
+// Example 2: Decoding
+func jsonDecoding() {
+	fmt.Println("\n--- Example 2: Decoding ---")
+
+	input := []byte(`{
+		"id": 1042,
+		"CUSTOMER": {"name": "Dana", "age": 41, "email": "dana@example.com",
+		             "address": {"city": "Lisbon", "country": "PT"}},
+		"items": [
+			{"sku": "BK-7", "qty": 2, "price": 12.5},
+			{"sku": "PN-1", "qty": 10, "price": 0.99, "note": "blue \u00e9\ud83d\ude00"}
+		],
+		"tags": {"priority": "high"},
+		"signature": "c2lnbmVk",
+		"tracking": "ignored, no such field"
+	}`)
+
+	var ours, theirs codecOrder
+	if err := DecodeJSON(input, &ours); err != nil {
+		fmt.Printf("DecodeJSON error: %v\n", err)
+		return
+	}
+	if err := json.Unmarshal(input, &theirs); err != nil {
+		fmt.Printf("json.Unmarshal error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Customer: %+v\n", *ours.Customer)
+	for _, item := range ours.Items {
+		note := "<nil>"
+		if item.Note != nil {
+			note = *item.Note
+		}
+		fmt.Printf("Item: %s x%d at %.2f, note %s\n", item.SKU, item.Qty, item.Price, note)
+	}
+	fmt.Printf("Tags: %v, signature: %q\n", ours.Tags, ours.Signature)
+	fmt.Println("\"CUSTOMER\" matched the customer field case-insensitively; \"tracking\" was skipped")
+	fmt.Printf("Same result as json.Unmarshal: %v\n", reflect.DeepEqual(ours, theirs))
+
+	// Without a target type, the decoder picks the same Go types as
+	// json.Unmarshal does for an interface{}
+	var generic interface{}
+	if err := DecodeJSON([]byte(`{"n": 3, "list": [true, null, "x"], "nested": {"ok": 1.5}}`), &generic); err != nil {
+		fmt.Printf("DecodeJSON error: %v\n", err)
+		return
+	}
+	obj := generic.(map[string]interface{})
+	fmt.Println("\nInto interface{}:")
+	for _, key := range []string{"n", "list", "nested"} {
+		fmt.Printf("  %-6s %T %v\n", key, obj[key], obj[key])
+	}
+
+	// Decoding reuses what is already there, as encoding/json does: fields
+	// absent from the input keep their values, nil pointers get allocated
+	existing := codecItem{SKU: "KEEP", Qty: 1}
+	_ = DecodeJSON([]byte(`{"qty": 5, "note": "added"}`), &existing)
+	fmt.Printf("\nDecoding into an existing value: SKU %s, Qty %d, Note %q\n", existing.SKU, existing.Qty, *existing.Note)
+}
+
+// Example 3: The field plan behind both
+func jsonFieldPlan() {
+	fmt.Println("\n--- Example 3: The Field Plan ---")
+
+	// Walking tags on every call would dominate the cost, so the codec
+	// computes one plan per type: which key maps to which field index,
+	// embedded structs flattened into FieldByIndex paths
+	for _, t := range []reflect.Type{reflect.TypeOf(Employee{}), reflect.TypeOf(codecOrder{})} {
+		fmt.Printf("%v:\n", t)
+		for _, f := range jsonStructOf(t).fields {
+			path := make([]string, 0, len(f.index))
+			ft := t
+			for _, i := range f.index {
+				for ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				path = append(path, ft.Field(i).Name)
+				ft = ft.Field(i).Type
+			}
+			omit := ""
+			if f.omitEmpty {
+				omit = " (omitempty)"
+			}
+			fmt.Printf("  %-12q index %-6s %s%s\n", f.name, fmt.Sprint(f.index), strings.Join(path, "."), omit)
+		}
+	}
+
+	// Same depth, same key, neither tagged: encoding/json drops both
+	// rather than guess, and so does the plan
+	fmt.Printf("\nAmbiguous promoted fields:\n")
+	ours, _ := EncodeJSON(codecAmbiguous{codecLeft{ID: 1}, codecRight{ID: 2}, "x"})
+	theirs, _ := json.Marshal(codecAmbiguous{codecLeft{ID: 1}, codecRight{ID: 2}, "x"})
+	fmt.Printf("  EncodeJSON %s, json.Marshal %s\n", ours, theirs)
+}
+
+// Example 4: Errors
+func jsonErrors() {
+	fmt.Println("\n--- Example 4: Errors ---")
+
+	// Decoding: where the input is broken, or which field cannot take it
+	inputs := []string{
+		`{"id": 1, "items": [{"sku": "A", "qty": "two"}]}`,
+		`{"id": 1, "items": [{"sku": "A", "qty": 300}]}`,
+		`{"id": 1, "items": [{"sku": "A"},]}`,
+		`{"id": 01}`,
+		`{"signature": "not base64!"}`,
+		`{"id": 1} trailing`,
+	}
+	for _, in := range inputs {
+		var ours, theirs codecOrder
+		errOurs := DecodeJSON([]byte(in), &ours)
+		errTheirs := json.Unmarshal([]byte(in), &theirs)
+		fmt.Printf("  %s\n    ours:          %v\n    encoding/json: %v\n", in, errOurs, errTheirs)
+
+		var typeErr *JSONTypeError
+		var syntaxErr *JSONSyntaxError
+		switch {
+		case errors.As(errOurs, &typeErr):
+			fmt.Printf("    -> type error at %q, Go type %v\n", typeErr.Path, typeErr.Type)
+		case errors.As(errOurs, &syntaxErr):
+			fmt.Printf("    -> syntax error at offset %d: %q\n", syntaxErr.Offset, in[:syntaxErr.Offset])
+		}
+	}
+
+	// Encoding: values JSON has no form for
+	loop := &codecNode{Name: "loop"}
+	loop.Next = loop
+	fmt.Println()
+	for _, v := range []interface{}{math.NaN(), make(chan int), map[[2]int]string{{1, 2}: "x"}, loop} {
+		_, err := EncodeJSON(v)
+		fmt.Printf("  %-22T %v\n", v, err)
+		fmt.Printf("  %-22s sentinel: cycle %v, type %v, value %v\n", "",
+			errors.Is(err, ErrJSONCycle), errors.Is(err, ErrJSONUnsupportedType), errors.Is(err, ErrJSONUnsupportedValue))
+	}
+}
+
+// Example 5: Against encoding/json
+func jsonBenchmark() {
+	fmt.Println("\n--- Example 5: Against encoding/json ---")
+
+	order := sampleCodecOrder()
+	data, _ := json.Marshal(order)
+	const rounds = 5000
+	measure := func(name string, fn func()) {
+		fn() // Warm the type caches of both codecs
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < rounds; i++ {
+			fn()
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		fmt.Printf("  %-22s %7d ns/op %6.1f allocs/op\n", name,
+			elapsed.Nanoseconds()/rounds, float64(after.Mallocs-before.Mallocs)/rounds)
+	}
+
+	fmt.Printf("Order of %d bytes:\n", len(data))
+	measure("EncodeJSON", func() { _, _ = EncodeJSON(order) })
+	measure("json.Marshal", func() { _, _ = json.Marshal(order) })
+	measure("DecodeJSON", func() {
+		var o codecOrder
+		_ = DecodeJSON(data, &o)
+	})
+	measure("json.Unmarshal", func() {
+		var o codecOrder
+		_ = json.Unmarshal(data, &o)
+	})
+
+	// Speed is close because both do the same reflect work; allocation is
+	// where a decade of tuning shows
+	fmt.Println("\nWhere encoding/json does more:")
+	fmt.Println("  - It compiles an encoder function per type once and writes into a")
+	fmt.Println("    pooled buffer; EncodeJSON grows a fresh buffer on every call")
+	fmt.Println("  - It unescapes strings in place and decodes keys without copying them;")
+	fmt.Println("    DecodeJSON turns every key and string into a new Go string")
+	fmt.Println("  - Unmarshal checks the whole input before touching the value, so")
+	fmt.Println("    broken input leaves it alone; DecodeJSON checks as it goes and may")
+	fmt.Println("    leave it half filled")
+}
+
+func sampleCodecOrder() codecOrder {
+	gift := "gift wrap"
+	return codecOrder{
+		ID: 1042,
+		Customer: &Person{
+			Name:    "Dana",
+			Age:     41,
+			Email:   "dana@example.com",
+			Address: Address{City: "Lisbon", Country: "PT"},
+		},
+		Items: []codecItem{
+			{SKU: "BK-7", Qty: 2, Price: 12.5},
+			{SKU: "PN-1", Qty: 10, Price: 0.99, Note: &gift},
+		},
+		Tags:      map[string]string{"priority": "high", "channel": "web"},
+		Signature: []byte("signed"),
+	}
+}
+
+type codecOrder struct {
+	ID        int               `json:"id"`
+	Customer  *Person           `json:"customer"`
+	Items     []codecItem       `json:"items"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Signature []byte            `json:"signature,omitempty"`
+	Discount  float64           `json:"discount,omitempty"`
+	internal  string            // Unexported: invisible to both codecs
+}
+
+type codecItem struct {
+	SKU   string  `json:"sku"`
+	Qty   uint8   `json:"qty"`
+	Price float64 `json:"price,omitempty"`
+	Note  *string `json:"note,omitempty"`
+}
+
+type codecLeft struct{ ID int }
+type codecRight struct{ ID int }
+
+type codecAmbiguous struct {
+	codecLeft
+	codecRight
+	Label string
+}
+
+type codecNode struct {
+	Name string
+	Next *codecNode
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Assignability explainer: reflect answers AssignableTo/ConvertibleTo with a
+// bare bool. ExplainAssign walks the same rules from the Go specification and
+// records which one decided the answer, so type identity mistakes come with a
+// reason and a suggested fix.
+
+// AssignExplanation describes whether values of Src can be assigned or converted to Dst
+type AssignExplanation struct {
+	Src         reflect.Type
+	Dst         reflect.Type
+	Assignable  bool
+	Convertible bool
+	Reasons     []string
+	Hint        string
+}
+
+func (e AssignExplanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v -> %v: assignable=%v convertible=%v", e.Src, e.Dst, e.Assignable, e.Convertible)
+	for _, r := range e.Reasons {
+		fmt.Fprintf(&b, "\n      - %s", r)
+	}
+	if e.Hint != "" {
+		fmt.Fprintf(&b, "\n      hint: %s", e.Hint)
+	}
+	return b.String()
+}
+
+// ExplainAssign explains why src is or isn't assignable/convertible to dst
+func ExplainAssign(src, dst reflect.Type) AssignExplanation {
+	e := AssignExplanation{
+		Src:         src,
+		Dst:         dst,
+		Assignable:  src.AssignableTo(dst),
+		Convertible: src.ConvertibleTo(dst),
+	}
+
+	e.explainAssignability()
+	if !e.Assignable {
+		e.explainConversion()
+	}
+	return e
+}
+
+func (e *AssignExplanation) add(format string, args ...interface{}) {
+	e.Reasons = append(e.Reasons, fmt.Sprintf(format, args...))
+}
+
+// isNamed reports whether a type has a name (defined or predeclared)
+func isNamed(t reflect.Type) bool {
+	return t.Name() != ""
+}
+
+// underlying returns the type literal a named type is defined from, when reflect can express it
+func underlying(t reflect.Type) string {
+	if !isNamed(t) {
+		return t.String()
+	}
+	if t.Kind() <= reflect.Complex128 || t.Kind() == reflect.String || t.Kind() == reflect.UnsafePointer {
+		return t.Kind().String()
+	}
+	return t.Kind().String() + " (composite)"
+}
+
+func (e *AssignExplanation) explainAssignability() {
+	src, dst := e.Src, e.Dst
+
+	// Rule 1: identical types
+	if src == dst {
+		e.add("types are identical")
+		return
+	}
+
+	// Rule 2: interface satisfaction
+	if dst.Kind() == reflect.Interface {
+		if src.Implements(dst) {
+			e.add("%v implements interface %v", src, dst)
+			return
+		}
+		e.explainMissingMethods()
+		return
+	}
+
+	// Rule 3: channel direction
+	if src.Kind() == reflect.Chan && dst.Kind() == reflect.Chan {
+		e.explainChannels()
+		return
+	}
+
+	// Rule 4: identical underlying types, at most one named
+	if e.Convertible && sameUnderlying(src, dst) {
+		switch {
+		case !isNamed(src) || !isNamed(dst):
+			e.add("underlying types are identical and %v is unnamed", unnamedOf(src, dst))
+		default:
+			e.add("both %v and %v are named types; named types are distinct even with identical underlying type %s",
+				src, dst, underlying(src))
+			e.Hint = fmt.Sprintf("convert explicitly: %v(x)", dst)
+		}
+		return
+	}
+
+	if src.Kind() != dst.Kind() {
+		e.add("kinds differ: %v vs %v", src.Kind(), dst.Kind())
+	} else {
+		e.add("same kind %v but different types: %v vs %v", src.Kind(), src, dst)
+	}
+}
+
+// sameUnderlying approximates identical underlying types: unnamed copies of both sides must match
+func sameUnderlying(a, b reflect.Type) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Slice, reflect.Ptr, reflect.Array, reflect.Chan:
+		if a.Kind() == reflect.Array && a.Len() != b.Len() {
+			return false
+		}
+		if a.Kind() == reflect.Chan && a.ChanDir() != b.ChanDir() {
+			return false
+		}
+		return a.Elem() == b.Elem()
+	case reflect.Map:
+		return a.Key() == b.Key() && a.Elem() == b.Elem()
+	case reflect.Struct:
+		if a.NumField() != b.NumField() {
+			return false
+		}
+		for i := 0; i < a.NumField(); i++ {
+			fa, fb := a.Field(i), b.Field(i)
+			if fa.Name != fb.Name || fa.Type != fb.Type || fa.Tag != fb.Tag || fa.Anonymous != fb.Anonymous {
+				return false
+			}
+		}
+		return true
+	case reflect.Func, reflect.Interface:
+		return a.ConvertibleTo(b) && b.ConvertibleTo(a)
+	default:
+		return true // Basic kinds: the kind is the underlying type
+	}
+}
+
+func unnamedOf(a, b reflect.Type) reflect.Type {
+	if !isNamed(a) {
+		return a
+	}
+	return b
+}
+
+func (e *AssignExplanation) explainMissingMethods() {
+	src, dst := e.Src, e.Dst
+
+	var missing, pointerOnly []string
+	for i := 0; i < dst.NumMethod(); i++ {
+		m := dst.Method(i)
+		if _, ok := src.MethodByName(m.Name); ok {
+			continue
+		}
+		if src.Kind() != reflect.Ptr && src.Kind() != reflect.Interface {
+			if _, ok := reflect.PointerTo(src).MethodByName(m.Name); ok {
+				pointerOnly = append(pointerOnly, m.Name)
+				continue
+			}
+		}
+		missing = append(missing, m.Name)
+	}
+
+	if len(missing) > 0 {
+		e.add("%v does not implement %v: missing method(s) %s", src, dst, strings.Join(missing, ", "))
+	}
+	if len(pointerOnly) > 0 {
+		e.add("method(s) %s have pointer receivers, so only *%v implements %v",
+			strings.Join(pointerOnly, ", "), src, dst)
+		e.Hint = fmt.Sprintf("assign a pointer: &x (type *%v)", src)
+	}
+	if len(missing) == 0 && len(pointerOnly) == 0 {
+		e.add("method signatures of %v do not match %v", src, dst)
+	}
+}
+
+func (e *AssignExplanation) explainChannels() {
+	src, dst := e.Src, e.Dst
+
+	if src.Elem() != dst.Elem() {
+		e.add("channel element types differ: %v vs %v", src.Elem(), dst.Elem())
+		return
+	}
+	if e.Assignable {
+		if src.ChanDir() == reflect.BothDir && dst.ChanDir() != reflect.BothDir {
+			e.add("a bidirectional channel may be narrowed to %v", dst)
+		} else {
+			e.add("channel types are identical")
+		}
+		return
+	}
+	if src.ChanDir() != reflect.BothDir {
+		e.add("%v is directional; a %v channel can never be widened to %v",
+			src, chanDirName(src.ChanDir()), dst)
+		return
+	}
+	e.add("both channel types are named, so direction narrowing does not apply")
+}
+
+func chanDirName(dir reflect.ChanDir) string {
+	switch dir {
+	case reflect.RecvDir:
+		return "receive-only"
+	case reflect.SendDir:
+		return "send-only"
+	default:
+		return "bidirectional"
+	}
+}
+
+func (e *AssignExplanation) explainConversion() {
+	src, dst := e.Src, e.Dst
+
+	if !e.Convertible {
+		if dst.Kind() != reflect.Interface && !(src.Kind() == reflect.Chan && dst.Kind() == reflect.Chan) {
+			e.add("no conversion exists between %v and %v", src, dst)
+		}
+		return
+	}
+
+	isNumeric := func(k reflect.Kind) bool {
+		return k >= reflect.Int && k <= reflect.Complex128
+	}
+	switch {
+	case sameUnderlying(src, dst):
+		// Already explained as a named-type mismatch
+	case isNumeric(src.Kind()) && isNumeric(dst.Kind()):
+		e.add("conversion allowed between numeric types (may truncate or lose precision)")
+		e.Hint = fmt.Sprintf("convert explicitly: %v(x)", dst)
+	case src.Kind() >= reflect.Int && src.Kind() <= reflect.Uintptr && dst.Kind() == reflect.String:
+		e.add("integer to string conversion yields the UTF-8 rune, not decimal digits")
+		e.Hint = "use strconv.Itoa for digits"
+	case src.Kind() == reflect.String || dst.Kind() == reflect.String:
+		e.add("strings convert to and from []byte and []rune (copying the data)")
+		e.Hint = fmt.Sprintf("convert explicitly: %v(x)", dst)
+	case src.Kind() == reflect.Slice && (dst.Kind() == reflect.Array || dst.Kind() == reflect.Ptr):
+		e.add("slices convert to arrays or array pointers, panicking if the slice is too short")
+	default:
+		e.add("conversion allowed: %v and %v have convertible underlying types", src, dst)
+		e.Hint = fmt.Sprintf("convert explicitly: %v(x)", dst)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/reflectutil"
+)
+
+// Benchmarks behind performanceOptimization (08_advanced_topics.go), which
+// times one pass with time.Since. Run them with:
+//
+//	go test -bench=FieldAccess -benchmem ./03_reflection
+
+// benchPeople is the input every field access benchmark sums over
+var benchPeople = func() []Person {
+	people := make([]Person, 1000)
+	for i := range people {
+		people[i] = Person{Name: fmt.Sprintf("Person%d", i), Age: 20 + i%50, Address: Address{City: "Paris"}}
+	}
+	return people
+}()
+
+// benchTotal keeps results alive so the compiler cannot drop the loops
+var benchTotal int
+
+func BenchmarkFieldAccess(b *testing.B) {
+	personType := reflect.TypeOf(Person{})
+
+	b.Run("direct", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for _, p := range benchPeople {
+				total += p.Age
+			}
+			benchTotal = total
+		}
+	})
+
+	b.Run("FieldByName", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for _, p := range benchPeople {
+				total += int(reflect.ValueOf(p).FieldByName("Age").Int())
+			}
+			benchTotal = total
+		}
+	})
+
+	b.Run("cached-index", func(b *testing.B) {
+		ageIndex := findFieldIndex(personType, "Age") // Looked up once, outside the loop
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for _, p := range benchPeople {
+				total += int(reflect.ValueOf(p).Field(ageIndex).Int())
+			}
+			benchTotal = total
+		}
+	})
+
+	b.Run("ReflectionCache", func(b *testing.B) {
+		cache := NewReflectionCache()
+		cache.CacheFields("Person", personType)
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for _, p := range benchPeople {
+				info, _ := cache.GetField("Person", "Age") // A map lookup per access
+				total += int(reflect.ValueOf(p).Field(info.Index).Int())
+			}
+			benchTotal = total
+		}
+	})
+
+	b.Run("pointer-no-copy", func(b *testing.B) {
+		// ValueOf(p) copies the struct into an interface; going through a
+		// pointer avoids that allocation
+		ageIndex := findFieldIndex(personType, "Age")
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for i := range benchPeople {
+				total += int(reflect.ValueOf(&benchPeople[i]).Elem().Field(ageIndex).Int())
+			}
+			benchTotal = total
+		}
+	})
+}
+
+func BenchmarkNestedFieldAccess(b *testing.B) {
+	b.Run("direct", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for i := range benchPeople {
+				total += len(benchPeople[i].Address.City)
+			}
+			benchTotal = total
+		}
+	})
+
+	b.Run("reflectutil-path", func(b *testing.B) {
+		// Parses "Address.City" and resolves each step by name every time
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for i := range benchPeople {
+				city, _ := reflectutil.Get(&benchPeople[i], "Address.City")
+				total += len(city.(string))
+			}
+			benchTotal = total
+		}
+	})
+
+	b.Run("cached-FieldByIndex", func(b *testing.B) {
+		field, _ := reflect.TypeOf(Person{}).FieldByName("Address")
+		city, _ := field.Type.FieldByName("City")
+		index := append(append([]int(nil), field.Index...), city.Index...)
+		for n := 0; n < b.N; n++ {
+			total := 0
+			for i := range benchPeople {
+				total += reflect.ValueOf(&benchPeople[i]).Elem().FieldByIndex(index).Len()
+			}
+			benchTotal = total
+		}
+	})
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value coercion shared by the mapper, serializer and config binder patterns.
+// It goes beyond AssignableTo/ConvertibleTo: strings are parsed into numbers,
+// bools, durations and times, numeric narrowing is checked for overflow,
+// pointers are wrapped/unwrapped and slices are coerced element by element.
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// timeLayouts are tried in order when parsing strings into time.Time
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// CoercionError describes why a value could not be coerced
+type CoercionError struct {
+	From   reflect.Type
+	To     reflect.Type
+	Reason string
+}
+
+func (e *CoercionError) Error() string {
+	return fmt.Sprintf("cannot coerce %v to %v: %s", e.From, e.To, e.Reason)
+}
+
+// Coerce converts value to targetType
+func Coerce(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	return CoerceValue(reflect.ValueOf(value), targetType)
+}
+
+// CoerceInto coerces src and stores it in dest, which must be settable
+func CoerceInto(dest, src reflect.Value) error {
+	if !dest.CanSet() {
+		return fmt.Errorf("destination field cannot be set")
+	}
+
+	converted, err := CoerceValue(src, dest.Type())
+	if err != nil {
+		return err
+	}
+	dest.Set(converted)
+	return nil
+}
+
+// CoerceValue converts src to a value of type to
+func CoerceValue(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	// Missing values coerce to the zero value
+	if !src.IsValid() {
+		return reflect.Zero(to), nil
+	}
+
+	// Look through interfaces to the dynamic value
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return reflect.Zero(to), nil
+		}
+		src = src.Elem()
+	}
+
+	if src.Type().AssignableTo(to) {
+		return src, nil
+	}
+
+	// Pointer wrapping: coerce to the element type, then take its address
+	if to.Kind() == reflect.Ptr {
+		elem, err := CoerceValue(src, to.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(to.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	// Pointer unwrapping: nil becomes the zero value
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return reflect.Zero(to), nil
+		}
+		return CoerceValue(src.Elem(), to)
+	}
+
+	// Special types before generic kinds: Duration is an int64, Time is a struct
+	switch to {
+	case durationType:
+		return coerceDuration(src, to)
+	case timeType:
+		return coerceTime(src, to)
+	}
+
+	switch to.Kind() {
+	case reflect.String:
+		return coerceString(src, to)
+	case reflect.Bool:
+		return coerceBool(src, to)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return coerceInt(src, to)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return coerceUint(src, to)
+	case reflect.Float32, reflect.Float64:
+		return coerceFloat(src, to)
+	case reflect.Slice:
+		return coerceSlice(src, to)
+	}
+
+	if src.Type().ConvertibleTo(to) {
+		return src.Convert(to), nil
+	}
+
+	return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "no conversion rule"}
+}
+
+func coerceString(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	var s string
+	switch src.Kind() {
+	case reflect.String:
+		s = src.String()
+	case reflect.Bool:
+		s = strconv.FormatBool(src.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(src.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = strconv.FormatUint(src.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		s = strconv.FormatFloat(src.Float(), 'g', -1, 64)
+	default:
+		// Deliberately not using Convert: int -> string would yield a rune
+		if stringer, ok := src.Interface().(fmt.Stringer); ok {
+			s = stringer.String()
+		} else {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "no string form"}
+		}
+	}
+	return reflect.ValueOf(s).Convert(to), nil
+}
+
+func coerceBool(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	switch src.Kind() {
+	case reflect.Bool:
+		return src.Convert(to), nil
+	case reflect.String:
+		b, err := strconv.ParseBool(strings.TrimSpace(src.String()))
+		if err != nil {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("invalid bool %q", src.String())}
+		}
+		return reflect.ValueOf(b).Convert(to), nil
+	}
+	return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "only bools and strings convert to bool"}
+}
+
+func coerceInt(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	var n int64
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = src.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := src.Uint()
+		if u > 1<<63-1 {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%d overflows", u)}
+		}
+		n = int64(u)
+	case reflect.Float32, reflect.Float64:
+		f := src.Float()
+		if f != float64(int64(f)) {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%v is not a whole number", f)}
+		}
+		n = int64(f)
+	case reflect.String:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(src.String()), 10, 64)
+		if err != nil {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("invalid integer %q", src.String())}
+		}
+		n = parsed
+	default:
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "not numeric"}
+	}
+
+	result := reflect.New(to).Elem()
+	if result.OverflowInt(n) {
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%d overflows", n)}
+	}
+	result.SetInt(n)
+	return result, nil
+}
+
+func coerceUint(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	var n uint64
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if src.Int() < 0 {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%d is negative", src.Int())}
+		}
+		n = uint64(src.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n = src.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := src.Float()
+		if f < 0 || f != float64(uint64(f)) {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%v is not a non-negative whole number", f)}
+		}
+		n = uint64(f)
+	case reflect.String:
+		parsed, err := strconv.ParseUint(strings.TrimSpace(src.String()), 10, 64)
+		if err != nil {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("invalid unsigned integer %q", src.String())}
+		}
+		n = parsed
+	default:
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "not numeric"}
+	}
+
+	result := reflect.New(to).Elem()
+	if result.OverflowUint(n) {
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%d overflows", n)}
+	}
+	result.SetUint(n)
+	return result, nil
+}
+
+func coerceFloat(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	var f float64
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(src.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(src.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = src.Float()
+	case reflect.String:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(src.String()), 64)
+		if err != nil {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("invalid number %q", src.String())}
+		}
+		f = parsed
+	default:
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "not numeric"}
+	}
+
+	result := reflect.New(to).Elem()
+	if result.OverflowFloat(f) {
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("%v overflows", f)}
+	}
+	result.SetFloat(f)
+	return result, nil
+}
+
+func coerceDuration(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	if src.Kind() == reflect.String {
+		d, err := time.ParseDuration(strings.TrimSpace(src.String()))
+		if err != nil {
+			return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("invalid duration %q", src.String())}
+		}
+		return reflect.ValueOf(d), nil
+	}
+	// Plain numbers are interpreted as nanoseconds, like time.Duration itself
+	return coerceInt(src, to)
+}
+
+func coerceTime(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	if src.Kind() != reflect.String {
+		return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "only strings parse into time.Time"}
+	}
+
+	s := strings.TrimSpace(src.String())
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return reflect.ValueOf(t), nil
+		}
+	}
+	return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: fmt.Sprintf("unrecognized time %q", s)}
+}
+
+func coerceSlice(src reflect.Value, to reflect.Type) (reflect.Value, error) {
+	switch src.Kind() {
+	case reflect.Slice, reflect.Array:
+		result := reflect.MakeSlice(to, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			elem, err := CoerceValue(src.Index(i), to.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			result.Index(i).Set(elem)
+		}
+		return result, nil
+	case reflect.String:
+		if to.Elem().Kind() == reflect.Uint8 {
+			return src.Convert(to), nil // string -> []byte
+		}
+		// Comma-separated strings become slices, as in "a,b,c" or "80,443"
+		if src.Len() == 0 {
+			return reflect.MakeSlice(to, 0, 0), nil
+		}
+		parts := strings.Split(src.String(), ",")
+		return coerceSlice(reflect.ValueOf(parts), to)
+	}
+	return reflect.Value{}, &CoercionError{From: src.Type(), To: to, Reason: "not a slice"}
+}
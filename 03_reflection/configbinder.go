@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Configuration binder used by the reflection pattern examples. Every
+// leaf field of the target struct has a dotted key ("database.port"),
+// looked up in a list of sources where later ones override earlier ones:
+// typically a file, then the environment. Fields no source sets fall back
+// to their `default` tag, `required:"true"` fields must be set one way or
+// the other, and values are coerced into the field type, so "5s" fills a
+// time.Duration and "a,b" a []string. A list may also come as indexed
+// keys ("origins.0", "origins.1"), the form Flatten writes. Every problem
+// is reported, not just the first.
+
+// ErrConfigRequired means no source set a required key and it has no default
+var ErrConfigRequired = errors.New("config: required key missing")
+
+// ConfigFieldError is one key that could not be bound
+type ConfigFieldError struct {
+	Key    string
+	Source string // Where the bad value came from; empty for a missing key
+	Err    error
+}
+
+func (e *ConfigFieldError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("%v: %s", e.Err, e.Key)
+	}
+	return fmt.Sprintf("config: %s from %s: %v", e.Key, e.Source, e.Err)
+}
+
+func (e *ConfigFieldError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigSource supplies values by dotted key
+type ConfigSource interface {
+	Name() string
+	Lookup(key string) (interface{}, bool)
+}
+
+type mapSource struct {
+	name string
+	data map[string]interface{}
+}
+
+// MapSource serves a flat map whose keys are already dotted
+func MapSource(name string, data map[string]interface{}) ConfigSource {
+	return &mapSource{name: name, data: data}
+}
+
+func (s *mapSource) Name() string {
+	return s.name
+}
+
+func (s *mapSource) Lookup(key string) (interface{}, bool) {
+	value, ok := s.data[key]
+	return value, ok
+}
+
+type envSource struct {
+	prefix string
+	lookup func(string) (string, bool)
+}
+
+// EnvSource reads environment variables named after the key: with prefix
+// "APP", database.max-conns is APP_DATABASE_MAX_CONNS. lookup defaults to
+// os.LookupEnv.
+func EnvSource(prefix string, lookup func(string) (string, bool)) ConfigSource {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	return &envSource{prefix: prefix, lookup: lookup}
+}
+
+func (s *envSource) varName(key string) string {
+	name := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "_" + name
+}
+
+func (s *envSource) Name() string {
+	return "environment"
+}
+
+// Describe names the variable behind key, for error messages
+func (s *envSource) Describe(key string) string {
+	return "$" + s.varName(key)
+}
+
+func (s *envSource) Lookup(key string) (interface{}, bool) {
+	return s.lookup(s.varName(key))
+}
+
+// FileSource reads a .json or .yaml/.yml file. Nested objects become
+// dotted keys, and lists stay lists for the binder to coerce element by
+// element. YAML is limited to the subset parseYAMLSubset describes.
+func FileSource(path string) (ConfigSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]interface{}
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber() // Keep 8080 as "8080" rather than float64
+		if err := dec.Decode(&tree); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if tree, err = parseYAMLSubset(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format %q", path, ext)
+	}
+
+	flat := make(map[string]interface{})
+	flattenConfig("", tree, flat)
+	return &mapSource{name: path, data: flat}, nil
+}
+
+func flattenConfig(prefix string, tree map[string]interface{}, flat map[string]interface{}) {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenConfig(key, nested, flat)
+			continue
+		}
+		flat[key] = value
+	}
+}
+
+// yamlFrame is a block being parsed: a mapping, a list, or, right after
+// "key:", still undecided until its first line
+type yamlFrame struct {
+	outer  int                    // Indentation of the line that opened the block
+	indent int                    // Indentation of its entries, -1 before the first
+	m      map[string]interface{} // Set for a mapping
+	parent map[string]interface{} // Where the block is stored, under key
+	key    string
+	isList bool
+}
+
+// parseYAMLSubset parses the part of YAML configuration files mostly use:
+// nested mappings by indentation, block lists ("- item") and flow lists
+// ("[a, b]") of scalars, quoted or plain scalars, and comments. Scalars
+// stay strings, left for coercion to parse. Anchors, multi-line strings,
+// lists of mappings and multiple documents are not supported.
+func parseYAMLSubset(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	stack := []*yamlFrame{{outer: -1, indent: -1, m: root}}
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(stripYAMLComment(raw), " \r")
+		text := strings.TrimLeft(line, " ")
+		if text == "" || text == "---" {
+			continue
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs cannot indent YAML", n+1)
+		}
+		indent := len(line) - len(text)
+
+		// Close the blocks this line is not part of. A list may sit at its
+		// key's own indentation, so a list item there stays in it.
+		isItem := strings.HasPrefix(text, "- ") || text == "-"
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if indent > top.outer || (indent == top.outer && isItem && top.m == nil) {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		top := stack[len(stack)-1]
+		if top.indent == -1 {
+			top.indent = indent
+			if top.m == nil { // "key:" then its first line: a list or a mapping
+				top.isList = isItem
+				if top.isList {
+					top.parent[top.key] = []interface{}{}
+				} else {
+					top.m = make(map[string]interface{})
+					top.parent[top.key] = top.m
+				}
+			}
+		}
+		if indent != top.indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", n+1)
+		}
+
+		if top.isList {
+			if !strings.HasPrefix(text, "-") {
+				return nil, fmt.Errorf("line %d: expected a list item", n+1)
+			}
+			item, err := parseYAMLScalar(strings.TrimPrefix(text, "-"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			top.parent[top.key] = append(top.parent[top.key].([]interface{}), item)
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, ":")
+		if !ok || strings.HasPrefix(text, "-") {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", n+1)
+		}
+		key = strings.TrimSpace(key)
+		if strings.TrimSpace(value) == "" {
+			stack = append(stack, &yamlFrame{outer: indent, indent: -1, parent: top.m, key: key})
+			continue
+		}
+		parsed, err := parseYAMLScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		top.m[key] = parsed
+	}
+	return root, nil
+}
+
+// stripYAMLComment drops a # comment that is not inside quotes
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseYAMLScalar parses a scalar or a flow list of scalars
+func parseYAMLScalar(s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]"):
+		items := []interface{}{}
+		if inner := strings.TrimSpace(s[1 : len(s)-1]); inner != "" {
+			for _, part := range strings.Split(inner, ",") {
+				item, err := parseYAMLScalar(part)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return strconv.Unquote(s)
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return s, nil
+}
+
+// Configuration Binding
+type ConfigBinder struct {
+	naming NamingStrategy // Keys for fields without a mapstructure tag
+}
+
+func NewConfigBinder() *ConfigBinder {
+	return &ConfigBinder{naming: LowerNaming}
+}
+
+// WithNaming sets the key strategy for fields without a mapstructure tag
+func (cb *ConfigBinder) WithNaming(naming NamingStrategy) *ConfigBinder {
+	cb.naming = naming
+	return cb
+}
+
+// Bind binds a single flat map of dotted keys
+func (cb *ConfigBinder) Bind(data map[string]interface{}, obj interface{}) error {
+	return cb.BindSources(obj, MapSource("map", data))
+}
+
+// BindSources binds obj from the sources, later ones overriding earlier
+// ones. The error joins a *ConfigFieldError for every key that failed.
+func (cb *ConfigBinder) BindSources(obj interface{}, sources ...ConfigSource) error {
+	val := reflect.ValueOf(obj)
+	if val.Kind() != reflect.Ptr {
+		return fmt.Errorf("destination must be a pointer")
+	}
+
+	val = val.Elem()
+	var errs []error
+	cb.bindStruct(sources, val, "", &errs)
+	return errors.Join(errs...)
+}
+
+func (cb *ConfigBinder) bindStruct(sources []ConfigSource, val reflect.Value, prefix string, errs *[]error) {
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+
+		if !fieldVal.CanSet() {
+			continue
+		}
+
+		mapTag := fieldKey(field, "mapstructure", cb.naming)
+
+		key := mapTag
+		if prefix != "" {
+			key = prefix + "." + mapTag
+		}
+
+		if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != timeType {
+			// Recursively bind nested structs
+			cb.bindStruct(sources, fieldVal, key, errs)
+			continue
+		}
+
+		// Bind simple field: the last source that has the key wins
+		var value interface{}
+		from := ""
+		for j := len(sources) - 1; j >= 0; j-- {
+			v, ok := sources[j].Lookup(key)
+			if !ok && fieldVal.Kind() == reflect.Slice {
+				v, ok = lookupIndexed(sources[j], key)
+			}
+			if ok {
+				value, from = v, sources[j].Name()
+				if d, ok := sources[j].(interface{ Describe(string) string }); ok {
+					from = d.Describe(key)
+				}
+				break
+			}
+		}
+		if from == "" {
+			if def, ok := field.Tag.Lookup("default"); ok {
+				value, from = def, "default"
+			}
+		}
+		if from == "" {
+			if required, _ := strconv.ParseBool(field.Tag.Get("required")); required {
+				*errs = append(*errs, &ConfigFieldError{Key: key, Err: ErrConfigRequired})
+			}
+			continue
+		}
+
+		if err := CoerceInto(fieldVal, reflect.ValueOf(value)); err != nil {
+			*errs = append(*errs, &ConfigFieldError{Key: key, Source: from, Err: err})
+		}
+	}
+}
+
+// lookupIndexed gathers a list stored as key.0, key.1, ...
+func lookupIndexed(source ConfigSource, key string) (interface{}, bool) {
+	var items []interface{}
+	for i := 0; ; i++ {
+		item, ok := source.Lookup(key + "." + strconv.Itoa(i))
+		if !ok {
+			break
+		}
+		items = append(items, item)
+	}
+	return items, len(items) > 0
+}
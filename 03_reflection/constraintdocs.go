@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Constraint documentation: the validate tags the ValidatorFramework enforces
+// are also the most accurate description of what an API accepts. The exporter
+// walks a struct type once and renders the same rules as a Markdown table and
+// as an OpenAPI-style JSON schema, so the docs cannot drift from the checks.
+
+// ValidationRule is one entry of a validate tag, e.g. min=3 or required
+type ValidationRule struct {
+	Name  string
+	Param string
+}
+
+func (r ValidationRule) String() string {
+	if r.Param == "" {
+		return r.Name
+	}
+	return r.Name + "=" + r.Param
+}
+
+// parseValidateTag splits a validate tag into rules. The validator and the
+// documentation exporter share it so both read tags the same way.
+func parseValidateTag(tag string) []ValidationRule {
+	var rules []ValidationRule
+	for _, raw := range strings.Split(tag, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(raw, "=")
+		rules = append(rules, ValidationRule{Name: name, Param: param})
+	}
+	return rules
+}
+
+// FieldConstraints describes one field as an API consumer sees it
+type FieldConstraints struct {
+	GoName    string
+	JSONName  string // Empty when the field is not serialized (json:"-")
+	Type      string // OpenAPI type: string, integer, number, boolean, array or object
+	Format    string // e.g. date-time or email
+	Required  bool
+	OmitEmpty bool
+	Minimum   *float64 // Numbers
+	Maximum   *float64
+	MinLength *int // Strings, or item counts for arrays and maps
+	MaxLength *int
+	Unknown   []ValidationRule  // Rules the exporter cannot express
+	Nested    *ConstraintDoc    // Struct-typed fields
+	Items     *FieldConstraints // Element of an array-typed field
+}
+
+// ConstraintDoc is the documented shape of one struct type
+type ConstraintDoc struct {
+	Name   string
+	Fields []FieldConstraints
+}
+
+// DescribeConstraints builds the constraint document for a struct type.
+// Embedded structs without a json name are flattened, as encoding/json does.
+func DescribeConstraints(t reflect.Type) (ConstraintDoc, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ConstraintDoc{}, fmt.Errorf("expected struct type, got %v", t)
+	}
+
+	doc := ConstraintDoc{Name: t.Name()}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			embedded, err := DescribeConstraints(field.Type)
+			if err != nil {
+				return ConstraintDoc{}, err
+			}
+			doc.Fields = append(doc.Fields, embedded.Fields...)
+			continue
+		}
+
+		fc := describeType(field.Type)
+		fc.GoName = field.Name
+		if name, omitEmpty, skip := jsonFieldName(field); !skip {
+			fc.JSONName, fc.OmitEmpty = name, omitEmpty
+		}
+		applyRules(&fc, parseValidateTag(field.Tag.Get("validate")))
+		doc.Fields = append(doc.Fields, fc)
+	}
+	return doc, nil
+}
+
+// describeType maps a Go type to its OpenAPI type, format and nested shape
+func describeType(t reflect.Type) FieldConstraints {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var fc FieldConstraints
+	switch {
+	case t == timeType:
+		fc.Type, fc.Format = "string", "date-time"
+	case t == durationType:
+		fc.Type, fc.Format = "string", "duration"
+	}
+	if fc.Type != "" {
+		return fc
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		fc.Type = "string"
+	case reflect.Bool:
+		fc.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fc.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		fc.Type = "number"
+	case reflect.Slice, reflect.Array:
+		fc.Type = "array"
+		items := describeType(t.Elem())
+		fc.Items = &items
+	case reflect.Map:
+		fc.Type = "object"
+	case reflect.Struct:
+		fc.Type = "object"
+		if nested, err := DescribeConstraints(t); err == nil {
+			fc.Nested = &nested
+		}
+	}
+	return fc
+}
+
+// applyRules translates validate rules into schema keywords. min and max bound
+// the value of numbers and the length of strings, arrays and maps; len fixes
+// the length. ValidatorFramework.validateBound enforces the same reading.
+// Bounds on times and durations have no schema keyword and stay as rules.
+func applyRules(fc *FieldConstraints, rules []ValidationRule) {
+	for _, rule := range rules {
+		n, err := strconv.ParseFloat(rule.Param, 64)
+		numeric := err == nil
+		length := int(n)
+
+		switch {
+		case rule.Name == "required":
+			fc.Required = true
+		case rule.Name == "email":
+			fc.Format = "email"
+		case !numeric, fc.Format == "date-time", fc.Format == "duration":
+			fc.Unknown = append(fc.Unknown, rule)
+		case rule.Name == "len" && fc.Type != "integer" && fc.Type != "number":
+			fc.MinLength, fc.MaxLength = &length, &length
+		case rule.Name == "min" && (fc.Type == "integer" || fc.Type == "number"):
+			fc.Minimum = &n
+		case rule.Name == "max" && (fc.Type == "integer" || fc.Type == "number"):
+			fc.Maximum = &n
+		case rule.Name == "min":
+			fc.MinLength = &length
+		case rule.Name == "max":
+			fc.MaxLength = &length
+		default:
+			fc.Unknown = append(fc.Unknown, rule)
+		}
+	}
+}
+
+// constraintSummary renders the constraints of a field in prose
+func (fc FieldConstraints) constraintSummary() string {
+	var parts []string
+	noun := "length"
+	switch fc.Type {
+	case "array":
+		noun = "items"
+	case "object":
+		noun = "entries"
+	}
+	if fc.Minimum != nil {
+		parts = append(parts, fmt.Sprintf("≥ %g", *fc.Minimum))
+	}
+	if fc.Maximum != nil {
+		parts = append(parts, fmt.Sprintf("≤ %g", *fc.Maximum))
+	}
+	switch {
+	case fc.MinLength != nil && fc.MaxLength != nil && *fc.MinLength == *fc.MaxLength:
+		parts = append(parts, fmt.Sprintf("%s = %d", noun, *fc.MinLength))
+	default:
+		if fc.MinLength != nil {
+			parts = append(parts, fmt.Sprintf("%s ≥ %d", noun, *fc.MinLength))
+		}
+		if fc.MaxLength != nil {
+			parts = append(parts, fmt.Sprintf("%s ≤ %d", noun, *fc.MaxLength))
+		}
+	}
+	if fc.Format != "" {
+		parts = append(parts, "format "+fc.Format)
+	}
+	for _, rule := range fc.Unknown {
+		parts = append(parts, "`"+rule.String()+"`")
+	}
+	if fc.OmitEmpty {
+		parts = append(parts, "omitted when empty")
+	}
+	if fc.JSONName == "" {
+		parts = append(parts, "never serialized")
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Markdown renders the document as one table per struct, nested structs after
+// their parent
+func (d ConstraintDoc) Markdown() string {
+	var sb strings.Builder
+	seen := make(map[string]bool)
+	d.writeMarkdown(&sb, seen)
+	return sb.String()
+}
+
+func (d ConstraintDoc) writeMarkdown(sb *strings.Builder, seen map[string]bool) {
+	if seen[d.Name] {
+		return
+	}
+	seen[d.Name] = true
+
+	fmt.Fprintf(sb, "### %s\n\n", d.Name)
+	sb.WriteString("| Field | JSON | Type | Required | Constraints |\n")
+	sb.WriteString("|-------|------|------|----------|-------------|\n")
+
+	var nested []ConstraintDoc
+	for _, fc := range d.Fields {
+		jsonName, typ, required := "-", fc.Type, ""
+		if fc.JSONName != "" {
+			jsonName = "`" + fc.JSONName + "`"
+		}
+		if fc.Nested != nil {
+			typ = fc.Nested.Name
+			nested = append(nested, *fc.Nested)
+		}
+		if fc.Items != nil {
+			typ = "array of " + fc.Items.Type
+		}
+		if fc.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(sb, "| %s | %s | %s | %s | %s |\n", fc.GoName, jsonName, typ, required, fc.constraintSummary())
+	}
+	for _, n := range nested {
+		sb.WriteString("\n")
+		n.writeMarkdown(sb, seen)
+	}
+}
+
+// OpenAPISchema renders the document as an OpenAPI-style object schema, ready
+// for json.Marshal. Fields that are never serialized are left out.
+func (d ConstraintDoc) OpenAPISchema() map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	for _, fc := range d.Fields {
+		if fc.JSONName == "" {
+			continue
+		}
+		properties[fc.JSONName] = fc.schema()
+		if fc.Required {
+			required = append(required, fc.JSONName)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"title":      d.Name,
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (fc FieldConstraints) schema() map[string]interface{} {
+	if fc.Nested != nil {
+		return fc.Nested.OpenAPISchema()
+	}
+
+	s := make(map[string]interface{})
+	if fc.Type != "" {
+		s["type"] = fc.Type
+	}
+	if fc.Format != "" {
+		s["format"] = fc.Format
+	}
+	if fc.Items != nil {
+		s["items"] = fc.Items.schema()
+	}
+	if fc.Minimum != nil {
+		s["minimum"] = *fc.Minimum
+	}
+	if fc.Maximum != nil {
+		s["maximum"] = *fc.Maximum
+	}
+
+	minKey, maxKey := "minLength", "maxLength"
+	switch fc.Type {
+	case "array":
+		minKey, maxKey = "minItems", "maxItems"
+	case "object":
+		minKey, maxKey = "minProperties", "maxProperties"
+	}
+	if fc.MinLength != nil {
+		s[minKey] = *fc.MinLength
+	}
+	if fc.MaxLength != nil {
+		s[maxKey] = *fc.MaxLength
+	}
+
+	// Keep rules the schema cannot express visible as a vendor extension
+	if len(fc.Unknown) > 0 {
+		rules := make([]string, len(fc.Unknown))
+		for i, rule := range fc.Unknown {
+			rules[i] = rule.String()
+		}
+		s["x-validate"] = strings.Join(rules, ",")
+	}
+	return s
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validatedProfile uses every rule the exporter documents, on every kind the
+// validator bounds
+type validatedProfile struct {
+	Name    string            `json:"name" validate:"required,min=2,max=8"`
+	Code    string            `json:"code" validate:"len=4"`
+	Email   string            `json:"email" validate:"required,email"`
+	Age     int               `json:"age" validate:"min=18,max=120"`
+	Retries uint8             `json:"retries" validate:"max=5"`
+	Level   uint              `json:"level" validate:"min=1"`
+	Score   float64           `json:"score" validate:"min=0,max=1"`
+	Tags    []string          `json:"tags" validate:"min=1,max=3"`
+	Labels  map[string]string `json:"labels" validate:"max=2"`
+	Limit   *int              `json:"limit" validate:"min=1"`
+}
+
+func validProfile() validatedProfile {
+	limit := 10
+	return validatedProfile{
+		Name: "alice", Code: "AB12", Email: "alice@example.com", Age: 30, Retries: 3,
+		Level: 2, Score: 0.5, Tags: []string{"go"}, Labels: map[string]string{"team": "core"}, Limit: &limit,
+	}
+}
+
+// setSize sets a number to n, or gives a string, slice or map n elements
+func setSize(v reflect.Value, n float64) {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(n)
+	case reflect.String:
+		v.SetString(strings.Repeat("a", int(n)))
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), int(n), int(n)))
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for i := 0; i < int(n); i++ {
+			m.SetMapIndex(reflect.ValueOf(fmt.Sprintf("k%d", i)), reflect.ValueOf("v"))
+		}
+		v.Set(m)
+	}
+}
+
+// TestConstraintDocsMatchValidator derives boundary values from the
+// documented constraints and checks the validator accepts the ones on the
+// boundary and rejects the ones just past it
+func TestConstraintDocsMatchValidator(t *testing.T) {
+	if err := NewValidator().Validate(validProfile()); err != nil {
+		t.Fatalf("baseline profile rejected: %v", err)
+	}
+
+	doc, err := DescribeConstraints(reflect.TypeOf(validatedProfile{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type probe struct {
+		desc  string
+		set   func(reflect.Value)
+		valid bool
+	}
+	for _, fc := range doc.Fields {
+		if len(fc.Unknown) > 0 {
+			t.Errorf("%s: rules %v are not documented", fc.GoName, fc.Unknown)
+		}
+
+		var probes []probe
+		step := 1.0
+		if fc.Type == "number" {
+			step = 0.5
+		}
+		if fc.Required {
+			probes = append(probes, probe{"zero value", func(v reflect.Value) { v.Set(reflect.Zero(v.Type())) }, false})
+		}
+		if fc.Format == "email" {
+			probes = append(probes,
+				probe{"address", func(v reflect.Value) { v.SetString("bob@example.org") }, true},
+				probe{"no @", func(v reflect.Value) { v.SetString("bob.example.org") }, false},
+				probe{"display name", func(v reflect.Value) { v.SetString("Bob <bob@example.org>") }, false})
+		}
+		for _, b := range []struct {
+			bound *float64
+			dir   float64
+		}{{fc.Minimum, -1}, {fc.Maximum, 1}} {
+			if b.bound != nil {
+				n, past := *b.bound, *b.bound+b.dir*step
+				probes = append(probes,
+					probe{fmt.Sprint("value ", n), func(v reflect.Value) { setSize(v, n) }, true},
+					probe{fmt.Sprint("value ", past), func(v reflect.Value) { setSize(v, past) }, false})
+			}
+		}
+		for _, b := range []struct {
+			bound *int
+			dir   int
+		}{{fc.MinLength, -1}, {fc.MaxLength, 1}} {
+			if b.bound != nil && *b.bound+b.dir >= 0 {
+				n, past := float64(*b.bound), float64(*b.bound+b.dir)
+				probes = append(probes,
+					probe{fmt.Sprint("size ", n), func(v reflect.Value) { setSize(v, n) }, true},
+					probe{fmt.Sprint("size ", past), func(v reflect.Value) { setSize(v, past) }, false})
+			}
+		}
+		if len(probes) == 0 {
+			t.Errorf("%s: no documented constraints", fc.GoName)
+		}
+
+		for _, p := range probes {
+			t.Run(fc.GoName+"/"+p.desc, func(t *testing.T) {
+				profile := validProfile()
+				p.set(reflect.ValueOf(&profile).Elem().FieldByName(fc.GoName))
+				err := NewValidator().Validate(profile)
+				rejected := err != nil && strings.Contains(err.Error(), fc.GoName+":")
+				if rejected == p.valid {
+					t.Errorf("documented as valid=%v (%s), validator said %v", p.valid, fc.constraintSummary(), err)
+				}
+			})
+		}
+	}
+}
+
+func TestConstraintDocsSchemaKeywords(t *testing.T) {
+	doc, err := DescribeConstraints(reflect.TypeOf(validatedProfile{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := doc.OpenAPISchema()["properties"].(map[string]interface{})
+	tests := []struct {
+		field string
+		want  map[string]interface{}
+	}{
+		{"code", map[string]interface{}{"type": "string", "minLength": 4, "maxLength": 4}},
+		{"email", map[string]interface{}{"type": "string", "format": "email"}},
+		{"retries", map[string]interface{}{"type": "integer", "maximum": 5.0}},
+		{"tags", map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "minItems": 1, "maxItems": 3}},
+		{"labels", map[string]interface{}{"type": "object", "maxProperties": 2}},
+		{"limit", map[string]interface{}{"type": "integer", "minimum": 1.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := properties[tt.field]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schema = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Struct copier used by the reflection pattern examples. It sits between a
+// plain assignment and a full object mapper: fields are matched by name
+// (or renamed with a `copy` tag), nested structs are copied recursively even
+// when their types differ, slices and maps can be deep or shallow copied,
+// and per-field hooks can transform or skip values.
+
+// AfterCopier is implemented by destination types that need to finish
+// themselves after a copy, e.g. to derive fields from the source
+type AfterCopier interface {
+	AfterCopy(src interface{}) error
+}
+
+// FieldHook transforms a source field value before it is stored in the destination
+type FieldHook func(src reflect.Value) (interface{}, error)
+
+// Copier copies between identical or compatible structs
+type Copier struct {
+	tagName    string
+	hooks      map[string]FieldHook
+	skip       map[string]bool
+	deepSlices bool
+	deepMaps   bool
+}
+
+var afterCopierType = reflect.TypeOf((*AfterCopier)(nil)).Elem()
+
+// NewCopier creates a copier that deep copies slices and maps
+func NewCopier() *Copier {
+	return &Copier{
+		tagName:    "copy",
+		hooks:      make(map[string]FieldHook),
+		skip:       make(map[string]bool),
+		deepSlices: true,
+		deepMaps:   true,
+	}
+}
+
+// Transform registers a hook for a destination field (dotted path for nested fields)
+func (c *Copier) Transform(field string, hook FieldHook) *Copier {
+	c.hooks[field] = hook
+	return c
+}
+
+// Skip leaves the given destination fields untouched
+func (c *Copier) Skip(fields ...string) *Copier {
+	for _, f := range fields {
+		c.skip[f] = true
+	}
+	return c
+}
+
+// DeepCopySlices toggles whether slices get a fresh backing array
+func (c *Copier) DeepCopySlices(deep bool) *Copier {
+	c.deepSlices = deep
+	return c
+}
+
+// DeepCopyMaps toggles whether maps are rebuilt or shared
+func (c *Copier) DeepCopyMaps(deep bool) *Copier {
+	c.deepMaps = deep
+	return c
+}
+
+// Copy copies src into dst, which must be a pointer to a struct
+func (c *Copier) Copy(dst, src interface{}) error {
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got %T", dst)
+	}
+
+	srcVal := reflect.ValueOf(src)
+	for srcVal.Kind() == reflect.Ptr {
+		if srcVal.IsNil() {
+			return fmt.Errorf("source is a nil pointer")
+		}
+		srcVal = srcVal.Elem()
+	}
+
+	if dstVal.Elem().Kind() != reflect.Struct || srcVal.Kind() != reflect.Struct {
+		return fmt.Errorf("copy needs structs, got %v -> %v", srcVal.Type(), dstVal.Elem().Type())
+	}
+
+	return c.copyStruct(dstVal.Elem(), srcVal, "")
+}
+
+func (c *Copier) copyStruct(dst, src reflect.Value, prefix string) error {
+	dstType := dst.Type()
+
+	for i := 0; i < dstType.NumField(); i++ {
+		field := dstType.Field(i)
+		dstField := dst.Field(i)
+		if !dstField.CanSet() {
+			continue
+		}
+
+		path := prefix + field.Name
+		tag := field.Tag.Get(c.tagName)
+		if tag == "-" || c.skip[path] {
+			continue
+		}
+
+		srcName := field.Name
+		if tag != "" {
+			srcName = tag
+		}
+
+		srcField := src.FieldByName(srcName)
+		if !srcField.IsValid() {
+			continue // No counterpart in the source
+		}
+
+		if hook, exists := c.hooks[path]; exists {
+			value, err := hook(srcField)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", path, err)
+			}
+			if err := CoerceInto(dstField, reflect.ValueOf(value)); err != nil {
+				return fmt.Errorf("field %s: %v", path, err)
+			}
+			continue
+		}
+
+		if err := c.copyValue(dstField, srcField, path+"."); err != nil {
+			return fmt.Errorf("field %s: %v", path, err)
+		}
+	}
+
+	// Post-copy callback, looked up on the pointer so pointer receivers work
+	if dst.CanAddr() && dst.Addr().Type().Implements(afterCopierType) {
+		return dst.Addr().Interface().(AfterCopier).AfterCopy(src.Interface())
+	}
+	return nil
+}
+
+func (c *Copier) copyValue(dst, src reflect.Value, prefix string) error {
+	// Pointer destinations get their own allocation so nothing is shared
+	if dst.Kind() == reflect.Ptr {
+		if src.Kind() == reflect.Ptr && src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		elem := reflect.New(dst.Type().Elem())
+		if err := c.copyValue(elem.Elem(), reflect.Indirect(src), prefix); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		src = src.Elem()
+	}
+
+	switch {
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Struct && dst.Type() != timeType:
+		return c.copyStruct(dst, src, prefix)
+
+	case dst.Kind() == reflect.Slice && src.Kind() == reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if !c.deepSlices && src.Type().AssignableTo(dst.Type()) {
+			dst.Set(src)
+			return nil
+		}
+		result := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := c.copyValue(result.Index(i), src.Index(i), prefix); err != nil {
+				return fmt.Errorf("index %d: %v", i, err)
+			}
+		}
+		dst.Set(result)
+		return nil
+
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if !c.deepMaps && src.Type().AssignableTo(dst.Type()) {
+			dst.Set(src)
+			return nil
+		}
+		result := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key, err := CoerceValue(iter.Key(), dst.Type().Key())
+			if err != nil {
+				return fmt.Errorf("key %v: %v", iter.Key(), err)
+			}
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := c.copyValue(value, iter.Value(), prefix); err != nil {
+				return fmt.Errorf("key %v: %v", iter.Key(), err)
+			}
+			result.SetMapIndex(key, value)
+		}
+		dst.Set(result)
+		return nil
+	}
+
+	return CoerceInto(dst, src)
+}
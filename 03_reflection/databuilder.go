@@ -0,0 +1,357 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Test data builder used by the reflection pattern examples. Every value
+// reachable from the built type is filled: a `fake` tag names the
+// generator for a field ("email", "int,1,100"), a generator registered for
+// the field's type comes next, and anything else gets a random value of
+// its kind. Pointers are allocated and slices and maps get a few elements,
+// down to a maximum depth so recursive types end. The random source is
+// seeded, so a seed builds the same data on every run.
+
+// ErrUnknownFaker means a `fake` tag names no registered generator
+var ErrUnknownFaker = errors.New("fake: unknown generator")
+
+// FakeFunc generates the value for a `fake:"name,arg,..."` tag from its
+// arguments. The result is coerced into the field type, so a generator
+// returning an int serves uint8 and string fields alike.
+type FakeFunc func(r *rand.Rand, args []string) (interface{}, error)
+
+// Test Data Builder
+type TestDataBuilder struct {
+	rng      *rand.Rand
+	maxDepth int
+	minLen   int
+	maxLen   int
+	fakers   map[string]FakeFunc
+	types    map[reflect.Type]reflect.Value // func(*rand.Rand) T
+}
+
+func NewTestDataBuilder() *TestDataBuilder {
+	tdb := &TestDataBuilder{
+		rng:      rand.New(rand.NewSource(1)),
+		maxDepth: 5,
+		minLen:   1,
+		maxLen:   3,
+		fakers:   make(map[string]FakeFunc),
+		types:    make(map[reflect.Type]reflect.Value),
+	}
+	for name, fn := range defaultFakers {
+		tdb.fakers[name] = fn
+	}
+
+	// A random Time would be centuries away; keep it to recent seconds
+	_ = tdb.RegisterGenerator(func(r *rand.Rand) time.Time {
+		start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		return start.Add(time.Duration(r.Int63n(6*365*24*3600)) * time.Second)
+	})
+	return tdb
+}
+
+// WithSeed restarts the random source: whatever is built next repeats
+// what was built after any earlier WithSeed with the same seed
+func (tdb *TestDataBuilder) WithSeed(seed int64) *TestDataBuilder {
+	tdb.rng = rand.New(rand.NewSource(seed))
+	return tdb
+}
+
+// WithMaxDepth sets how many levels of structs, pointers, slices and maps
+// are filled below the built value; deeper ones are left zero
+func (tdb *TestDataBuilder) WithMaxDepth(depth int) *TestDataBuilder {
+	tdb.maxDepth = depth
+	return tdb
+}
+
+// WithLen sets the range of slice and map lengths, both inclusive
+func (tdb *TestDataBuilder) WithLen(min, max int) *TestDataBuilder {
+	tdb.minLen, tdb.maxLen = min, max
+	return tdb
+}
+
+// RegisterFaker adds a generator for `fake` tags, replacing any of the
+// same name
+func (tdb *TestDataBuilder) RegisterFaker(name string, fn FakeFunc) {
+	tdb.fakers[name] = fn
+}
+
+// RegisterGenerator adds a generator for every value of type T, as a
+// func(*rand.Rand) T. It takes over from the default for T's kind, but a
+// `fake` tag on a field still wins.
+func (tdb *TestDataBuilder) RegisterGenerator(fn interface{}) error {
+	fv := reflect.ValueOf(fn)
+	ft := fv.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.In(0) != reflect.TypeOf((*rand.Rand)(nil)) || ft.NumOut() != 1 {
+		return fmt.Errorf("generator must be func(*rand.Rand) T, got %v", ft)
+	}
+	tdb.types[ft.Out(0)] = fv
+	return nil
+}
+
+// Build returns a pointer to a new value of the prototype's type, filled.
+// Like regexp.MustCompile it panics on an error, which can only come from
+// a bad `fake` tag: a test cannot go on without the data it described.
+func (tdb *TestDataBuilder) Build(prototype interface{}) interface{} {
+	obj := reflect.New(reflect.TypeOf(prototype)).Interface()
+	if err := tdb.Fill(obj); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// BuildWithRules builds a value, then overrides fields by ApplyPatch
+// paths ("Name", "Address.City", "Tags[0]"), coercing the values
+func (tdb *TestDataBuilder) BuildWithRules(prototype interface{}, rules map[string]interface{}) interface{} {
+	obj := tdb.Build(prototype)
+	if err := ApplyPatch(obj, rules); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Fill fills the value ptr points to. The error joins every field whose
+// `fake` tag failed; the other fields are filled regardless.
+func (tdb *TestDataBuilder) Fill(ptr interface{}) error {
+	val := reflect.ValueOf(ptr)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("fake: target must be a non-nil pointer, got %T", ptr)
+	}
+
+	root := val.Elem().Type().Name()
+	if root == "" {
+		root = val.Elem().Type().String()
+	}
+	var errs []error
+	tdb.fill(val.Elem(), nil, root, 0, &errs)
+	return errors.Join(errs...)
+}
+
+// fill sets v, a value depth levels below the built one. spec is the
+// `fake` tag that applies, split at commas; on a pointer, slice, array or
+// map it passes down to the elements.
+func (tdb *TestDataBuilder) fill(v reflect.Value, spec []string, path string, depth int, errs *[]error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+	default:
+		if spec != nil {
+			if err := tdb.fake(v, spec); err != nil {
+				*errs = append(*errs, fmt.Errorf("%s: %w", path, err))
+			}
+			return
+		}
+	}
+	if gen, ok := tdb.types[v.Type()]; ok {
+		v.Set(gen.Call([]reflect.Value{reflect.ValueOf(tdb.rng)})[0])
+		return
+	}
+
+	if !tdb.reaches(v.Type(), depth) {
+		return
+	}
+	// Elements the depth limit would leave zero are left out altogether:
+	// a nil pointer ends a list better than a zero node does
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if !tdb.reaches(v.Type().Elem(), depth+1) {
+			return
+		}
+	}
+
+	r := tdb.rng
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(fakeWords[r.Intn(len(fakeWords))])
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Intn(100)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(r.Intn(100)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(math.Round(r.Float64()*10000) / 100)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(r.Intn(10)), float64(r.Intn(10))))
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		tdb.fill(v.Elem(), spec, path, depth+1, errs)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			tdb.fill(v.Index(i), spec, fmt.Sprintf("%s[%d]", path, i), depth+1, errs)
+		}
+	case reflect.Slice:
+		n := tdb.minLen + r.Intn(tdb.maxLen-tdb.minLen+1)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			tdb.fill(v.Index(i), spec, fmt.Sprintf("%s[%d]", path, i), depth+1, errs)
+		}
+	case reflect.Map:
+		n := tdb.minLen + r.Intn(tdb.maxLen-tdb.minLen+1)
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			tdb.fill(key, nil, path, depth+1, errs)
+			elem := reflect.New(v.Type().Elem()).Elem()
+			tdb.fill(elem, spec, fmt.Sprintf("%s[%v]", path, key), depth+1, errs)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !v.Field(i).CanSet() {
+				continue
+			}
+			tag, tagged := field.Tag.Lookup("fake")
+			if tag == "-" {
+				continue
+			}
+			var fieldSpec []string
+			if tagged {
+				fieldSpec = strings.Split(tag, ",")
+			}
+			tdb.fill(v.Field(i), fieldSpec, path+"."+field.Name, depth+1, errs)
+		}
+	}
+	// Interfaces, channels and functions have no sensible random value
+}
+
+// reaches reports whether a value of type t, depth levels down, is filled
+func (tdb *TestDataBuilder) reaches(t reflect.Type, depth int) bool {
+	if _, ok := tdb.types[t]; ok {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		return depth < tdb.maxDepth
+	}
+	return true
+}
+
+// fake sets v from the generator spec names
+func (tdb *TestDataBuilder) fake(v reflect.Value, spec []string) error {
+	fn, ok := tdb.fakers[spec[0]]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownFaker, spec[0])
+	}
+	value, err := fn(tdb.rng, spec[1:])
+	if err != nil {
+		return fmt.Errorf("fake %q: %w", spec[0], err)
+	}
+	return CoerceInto(v, reflect.ValueOf(value))
+}
+
+var (
+	fakeFirstNames = []string{"Ana", "Bruno", "Chen", "Dana", "Emeka", "Farah", "Goran", "Hana", "Ines", "Jonas"}
+	fakeLastNames  = []string{"Silva", "Okafor", "Novak", "Tanaka", "Moreau", "Haddad", "Larsen", "Costa", "Weber", "Ito"}
+	fakeCities     = []string{"Lisbon", "Lagos", "Zagreb", "Osaka", "Lyon", "Beirut", "Oslo", "Porto", "Berlin", "Kyoto"}
+	fakeCountries  = []string{"PT", "NG", "HR", "JP", "FR", "LB", "NO", "DE", "BR", "CA"}
+	fakeStreets    = []string{"Main St", "Oak Ave", "Harbour Rd", "Station Sq", "Mill Lane", "Park Blvd"}
+	fakeWords      = []string{"alpha", "orbit", "maple", "quartz", "harbor", "ember", "lumen", "cobalt", "fjord", "sable"}
+)
+
+// defaultFakers are the generators every builder starts with
+var defaultFakers = map[string]FakeFunc{
+	"firstname": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return pick(r, fakeFirstNames), nil
+	},
+	"lastname": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return pick(r, fakeLastNames), nil
+	},
+	"name": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return pick(r, fakeFirstNames) + " " + pick(r, fakeLastNames), nil
+	},
+	"email": func(r *rand.Rand, _ []string) (interface{}, error) {
+		user := strings.ToLower(pick(r, fakeFirstNames) + "." + pick(r, fakeLastNames))
+		return user + "@example." + pick(r, []string{"com", "org", "net"}), nil
+	},
+	"username": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return strings.ToLower(pick(r, fakeFirstNames)) + strconv.Itoa(10+r.Intn(90)), nil
+	},
+	"street": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return strconv.Itoa(1+r.Intn(200)) + " " + pick(r, fakeStreets), nil
+	},
+	"city": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return pick(r, fakeCities), nil
+	},
+	"country": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return pick(r, fakeCountries), nil
+	},
+	"phone": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return fmt.Sprintf("+1 555-01%02d", r.Intn(100)), nil // Reserved for fiction
+	},
+	"word": func(r *rand.Rand, _ []string) (interface{}, error) {
+		return pick(r, fakeWords), nil
+	},
+	"sentence": func(r *rand.Rand, _ []string) (interface{}, error) {
+		words := make([]string, 3+r.Intn(5))
+		for i := range words {
+			words[i] = pick(r, fakeWords)
+		}
+		s := strings.Join(words, " ")
+		return strings.ToUpper(s[:1]) + s[1:] + ".", nil
+	},
+	"uuid": func(r *rand.Rand, _ []string) (interface{}, error) {
+		var b [16]byte
+		r.Read(b[:])
+		b[6] = b[6]&0x0f | 0x40 // Version 4
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+	},
+	// "int,min,max", both inclusive; 0 to 100 without arguments
+	"int": func(r *rand.Rand, args []string) (interface{}, error) {
+		lo, hi, err := fakeRange(args, 0, 100)
+		if err != nil {
+			return nil, err
+		}
+		return int64(lo) + r.Int63n(int64(hi-lo)+1), nil
+	},
+	// "float,min,max", rounded to cents
+	"float": func(r *rand.Rand, args []string) (interface{}, error) {
+		lo, hi, err := fakeRange(args, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		return math.Round((lo+r.Float64()*(hi-lo))*100) / 100, nil
+	},
+	// "oneof,a,b,c" picks one of its arguments
+	"oneof": func(r *rand.Rand, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("needs at least one choice")
+		}
+		return pick(r, args), nil
+	},
+}
+
+func pick(r *rand.Rand, choices []string) string {
+	return choices[r.Intn(len(choices))]
+}
+
+// fakeRange parses the optional min and max arguments of a numeric faker
+func fakeRange(args []string, lo, hi float64) (float64, float64, error) {
+	switch len(args) {
+	case 0:
+		return lo, hi, nil
+	case 2:
+		var err error
+		if lo, err = strconv.ParseFloat(strings.TrimSpace(args[0]), 64); err != nil {
+			return 0, 0, fmt.Errorf("bad min %q", args[0])
+		}
+		if hi, err = strconv.ParseFloat(strings.TrimSpace(args[1]), 64); err != nil {
+			return 0, 0, fmt.Errorf("bad max %q", args[1])
+		}
+		if lo > hi {
+			return 0, 0, fmt.Errorf("min %v above max %v", lo, hi)
+		}
+		return lo, hi, nil
+	}
+	return 0, 0, fmt.Errorf("want min and max, got %d arguments", len(args))
+}
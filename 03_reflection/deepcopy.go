@@ -0,0 +1,335 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Deep copy and deep equality used by the reflection pattern examples. Both
+// walk any value through reflect and keep a visited map keyed by pointer, so
+// shared structure is copied once and cycles end instead of recursing
+// forever. DeepDiff also says where two values differ, and SameShape checks
+// the sharing itself, which reflect.DeepEqual cannot see.
+
+// visitKey identifies a pointer, map or slice already seen. Slices of one
+// array with different lengths are different values, so length is part of
+// the key.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func visitKeyOf(v reflect.Value) visitKey {
+	key := visitKey{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	return key
+}
+
+// DeepCopy returns a copy of v that shares no pointers, slices or maps with
+// it. A pointer reached twice is copied once, so shared structure and cycles
+// keep their shape in the copy. Unexported struct fields cannot be set
+// through reflect and are copied shallowly; channels and funcs are shared.
+func DeepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	src := reflect.ValueOf(v)
+	dst := reflect.New(src.Type()).Elem()
+	deepCopyValue(dst, src, make(map[visitKey]reflect.Value))
+	return dst.Interface()
+}
+
+// deepCopyValue copies src into dst, which is settable and still zero
+func deepCopyValue(dst, src reflect.Value, visited map[visitKey]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		key := visitKeyOf(src)
+		if copied, ok := visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.New(src.Type().Elem())
+		visited[key] = copied // Before recursing, so a cycle finds it
+		deepCopyValue(copied.Elem(), src.Elem(), visited)
+		dst.Set(copied)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := visitKeyOf(src)
+		if copied, ok := visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		visited[key] = copied
+		for i := 0; i < src.Len(); i++ {
+			deepCopyValue(copied.Index(i), src.Index(i), visited)
+		}
+		dst.Set(copied)
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := visitKeyOf(src)
+		if copied, ok := visited[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		visited[key] = copied
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(src.Type().Key()).Elem()
+			deepCopyValue(k, iter.Key(), visited)
+			v := reflect.New(src.Type().Elem()).Elem()
+			deepCopyValue(v, iter.Value(), visited)
+			copied.SetMapIndex(k, v)
+		}
+		dst.Set(copied)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		deepCopyValue(elem, src.Elem(), visited)
+		dst.Set(elem)
+
+	case reflect.Struct:
+		// Unexported fields come along with the whole struct; exported ones
+		// are then replaced by deep copies
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				dst.Field(i).Set(reflect.Zero(dst.Field(i).Type()))
+				deepCopyValue(dst.Field(i), src.Field(i), visited)
+			}
+		}
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopyValue(dst.Index(i), src.Index(i), visited)
+		}
+
+	default: // Scalars are copied by value; channels and funcs are shared
+		dst.Set(src)
+	}
+}
+
+// visitPair is a pair of pointers already being compared
+type visitPair struct {
+	a, b visitKey
+}
+
+// deepEqualizer compares two values, optionally requiring the same sharing
+type deepEqualizer struct {
+	visited map[visitPair]bool
+	sharing bool
+	aToB    map[visitKey]visitKey // With sharing: which b pointer each a pointer matched
+	bToA    map[visitKey]visitKey
+}
+
+// DeepEqual reports whether a and b are deeply equal. The rules are those
+// of reflect.DeepEqual: nil and empty slices differ, funcs are equal only
+// when both are nil, and unexported fields count.
+func DeepEqual(a, b interface{}) bool {
+	_, equal := DeepDiff(a, b)
+	return equal
+}
+
+// DeepDiff is DeepEqual that also describes the first difference found,
+// such as `.Reports[1].Skills[0]: "go" != "rust"`. Map keys are visited in
+// sorted order, so the description is stable.
+func DeepDiff(a, b interface{}) (string, bool) {
+	e := &deepEqualizer{visited: make(map[visitPair]bool)}
+	return e.compare(a, b)
+}
+
+// SameShape is DeepDiff that also requires the same sharing: wherever a
+// reaches one pointer, slice or map from two places, b must reach a single
+// one from the same places, and the reverse. reflect.DeepEqual cannot tell a
+// copy that preserved aliasing from one that duplicated it.
+func SameShape(a, b interface{}) (string, bool) {
+	e := &deepEqualizer{
+		visited: make(map[visitPair]bool),
+		sharing: true,
+		aToB:    make(map[visitKey]visitKey),
+		bToA:    make(map[visitKey]visitKey),
+	}
+	return e.compare(a, b)
+}
+
+func (e *deepEqualizer) compare(a, b interface{}) (string, bool) {
+	diff, equal := e.equal(reflect.ValueOf(a), reflect.ValueOf(b))
+	if !equal && diff[0] == ':' {
+		diff = "(root)" + diff
+	}
+	return diff, equal
+}
+
+// equal compares a and b and describes the first difference. The path to
+// it is assembled on the way back out, so equal values build no strings.
+func (e *deepEqualizer) equal(a, b reflect.Value) (string, bool) {
+	differ := func(format string, args ...interface{}) (string, bool) {
+		return ": " + fmt.Sprintf(format, args...), false
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			return differ("nil != non-nil")
+		}
+		return "", true
+	}
+	if a.Type() != b.Type() {
+		return differ("%v != %v", a.Type(), b.Type())
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if a.IsNil() != b.IsNil() {
+			return differ("%s != %s", nilOrNot(a), nilOrNot(b))
+		}
+		if a.IsNil() {
+			return "", true
+		}
+		ka, kb := visitKeyOf(a), visitKeyOf(b)
+		if e.sharing {
+			if prev, ok := e.aToB[ka]; ok && prev != kb {
+				return differ("shared on the left, separate %s on the right", a.Kind())
+			}
+			if prev, ok := e.bToA[kb]; ok && prev != ka {
+				return differ("separate %s on the left, shared on the right", a.Kind())
+			}
+			e.aToB[ka], e.bToA[kb] = kb, ka
+		}
+		pair := visitPair{ka, kb}
+		if ka == kb || e.visited[pair] {
+			// The same memory, or a pair already under comparison further up
+			// (a cycle): any difference inside is reported from there
+			return "", true
+		}
+		e.visited[pair] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		return e.equal(a.Elem(), b.Elem())
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return differ("%s != %s", nilOrNot(a), nilOrNot(b))
+			}
+			return "", true
+		}
+		return e.equal(a.Elem(), b.Elem())
+
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return differ("len %d != %d", a.Len(), b.Len())
+		}
+		for i := 0; i < a.Len(); i++ {
+			if diff, ok := e.equal(a.Index(i), b.Index(i)); !ok {
+				return fmt.Sprintf("[%d]", i) + diff, false
+			}
+		}
+		return "", true
+
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return differ("len %d != %d", a.Len(), b.Len())
+		}
+		keys := a.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			keyPath := fmt.Sprintf("[%v]", k)
+			if k.Kind() == reflect.String {
+				keyPath = fmt.Sprintf("[%q]", k.String())
+			}
+			bv := b.MapIndex(k)
+			if !bv.IsValid() {
+				return keyPath + ": missing on the right", false
+			}
+			if diff, ok := e.equal(a.MapIndex(k), bv); !ok {
+				return keyPath + diff, false
+			}
+		}
+		return "", true
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if diff, ok := e.equal(a.Field(i), b.Field(i)); !ok {
+				return "." + a.Type().Field(i).Name + diff, false
+			}
+		}
+		return "", true
+
+	case reflect.Func:
+		if a.IsNil() && b.IsNil() {
+			return "", true
+		}
+		return differ("funcs are only equal when both are nil")
+
+	case reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			return differ("different %s", a.Kind())
+		}
+		return "", true
+	}
+
+	// Scalars, read without Interface so unexported fields work too
+	var equal bool
+	switch a.Kind() {
+	case reflect.Bool:
+		equal = a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		equal = a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		equal = a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		equal = a.Float() == b.Float() // NaN differs from itself, as in reflect.DeepEqual
+	case reflect.Complex64, reflect.Complex128:
+		equal = a.Complex() == b.Complex()
+	case reflect.String:
+		equal = a.String() == b.String()
+	}
+	if !equal {
+		return differ("%s != %s", scalarString(a), scalarString(b))
+	}
+	return "", true
+}
+
+// scalarString formats a scalar for a difference description
+func scalarString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Bool:
+		return fmt.Sprint(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprint(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return fmt.Sprint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return fmt.Sprint(v.Complex())
+	}
+	return v.Type().String()
+}
+
+func nilOrNot(v reflect.Value) string {
+	if v.IsNil() {
+		return "nil"
+	}
+	return "non-nil " + v.Kind().String()
+}
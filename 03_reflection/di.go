@@ -0,0 +1,306 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Dependency injection container used by the reflection pattern examples.
+// Services are registered by name, as instances or factories, or by type,
+// as constructor functions: a constructor's parameters are resolved by
+// their types, recursively, and its result is cached according to its
+// lifetime. Resolution reports cycles, missing providers and lifetime
+// mistakes with the chain of types that led there.
+
+var (
+	// ErrNoProvider means nothing provides a type that was asked for
+	ErrNoProvider = errors.New("di: no provider")
+	// ErrDependencyCycle means a constructor needs, through its
+	// dependencies, the type it constructs
+	ErrDependencyCycle = errors.New("di: dependency cycle")
+	// ErrScopeRequired means a scoped service was resolved outside a scope
+	ErrScopeRequired = errors.New("di: scoped service resolved outside a scope")
+	// ErrCaptiveDependency means a singleton depends on a scoped service,
+	// which would keep one scope's instance alive for every later scope
+	ErrCaptiveDependency = errors.New("di: singleton depends on a scoped service")
+)
+
+// Lifetime says how long a constructed service is reused
+type Lifetime int
+
+const (
+	LifetimeSingleton Lifetime = iota // One instance for the container
+	LifetimeTransient                 // A new instance on every resolution
+	LifetimeScoped                    // One instance per DIScope
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeTransient:
+		return "transient"
+	case LifetimeScoped:
+		return "scoped"
+	}
+	return fmt.Sprintf("Lifetime(%d)", int(l))
+}
+
+// ResolveError is a failed resolution together with the types being
+// resolved when it failed, outermost first
+type ResolveError struct {
+	Path []reflect.Type
+	Err  error
+}
+
+func (e *ResolveError) Error() string {
+	names := make([]string, len(e.Path))
+	for i, t := range e.Path {
+		names[i] = t.String()
+	}
+	return fmt.Sprintf("%v (resolving %s)", e.Err, strings.Join(names, " -> "))
+}
+
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// TypeResolver is implemented by the container and its scopes
+type TypeResolver interface {
+	ResolveType(t reflect.Type) (reflect.Value, error)
+}
+
+// Resolve returns the T built by the resolver's providers, with its
+// dependencies. It is the typed front-end to ResolveType:
+// Resolve[*UserRepo](scope) instead of asserting an interface{}.
+func Resolve[T any](r TypeResolver) (T, error) {
+	var zero T
+	v, err := r.ResolveType(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return zero, err
+	}
+	// A constructor for an interface type may return a nil interface, which
+	// resolves to the zero T rather than failing the assertion
+	result, ok := v.Interface().(T)
+	if !ok && v.Interface() != nil {
+		return zero, fmt.Errorf("resolve %v: got %v", v.Type(), reflect.TypeOf(v.Interface()))
+	}
+	return result, nil
+}
+
+// provider is a registered constructor
+type provider struct {
+	ctor     reflect.Value
+	lifetime Lifetime
+}
+
+// Dependency Injection Container
+type DIContainer struct {
+	singletons map[string]interface{}
+	factories  map[string]func() interface{}
+
+	mu        sync.Mutex // Held for a whole resolution, so singletons are built once
+	providers map[reflect.Type]*provider
+	instances map[reflect.Type]reflect.Value // Singletons built from providers
+}
+
+func NewDIContainer() *DIContainer {
+	return &DIContainer{
+		singletons: make(map[string]interface{}),
+		factories:  make(map[string]func() interface{}),
+		providers:  make(map[reflect.Type]*provider),
+		instances:  make(map[reflect.Type]reflect.Value),
+	}
+}
+
+func (di *DIContainer) RegisterSingleton(name string, instance interface{}) {
+	di.singletons[name] = instance
+}
+
+func (di *DIContainer) RegisterTransient(name string, factory func() interface{}) {
+	di.factories[name] = factory
+}
+
+func (di *DIContainer) Resolve(name string) (interface{}, error) {
+	if instance, exists := di.singletons[name]; exists {
+		return instance, nil
+	}
+
+	if factory, exists := di.factories[name]; exists {
+		return factory(), nil
+	}
+
+	return nil, fmt.Errorf("service %s not registered", name)
+}
+
+// Provide registers a constructor, a func(A, B, ...) T or
+// func(A, B, ...) (T, error). It provides T, so a constructor returning an
+// interface type provides the interface; its parameters are resolved by
+// type when T is needed.
+func (di *DIContainer) Provide(ctor interface{}, lifetime Lifetime) error {
+	fv := reflect.ValueOf(ctor)
+	if fv.Kind() != reflect.Func {
+		return fmt.Errorf("constructor must be a function, got %T", ctor)
+	}
+	ft := fv.Type()
+	if ft.IsVariadic() || !(ft.NumOut() == 1 || (ft.NumOut() == 2 && ft.Out(1) == errorType)) {
+		return fmt.Errorf("constructor must return T or (T, error), got %v", ft)
+	}
+
+	di.mu.Lock()
+	defer di.mu.Unlock()
+	if _, exists := di.providers[ft.Out(0)]; exists {
+		return fmt.Errorf("%v already has a provider", ft.Out(0))
+	}
+	di.providers[ft.Out(0)] = &provider{ctor: fv, lifetime: lifetime}
+	return nil
+}
+
+// ResolveType builds a value of type t. Scoped services cannot be
+// resolved here; use a DIScope.
+func (di *DIContainer) ResolveType(t reflect.Type) (reflect.Value, error) {
+	di.mu.Lock()
+	defer di.mu.Unlock()
+	return (&resolution{di: di}).resolve(t)
+}
+
+// DIScope is a unit of work, typically one request: scoped services are
+// built once per scope, and singletons are shared with the container
+type DIScope struct {
+	di        *DIContainer
+	instances map[reflect.Type]reflect.Value
+}
+
+// NewScope starts a scope with no scoped instances yet
+func (di *DIContainer) NewScope() *DIScope {
+	return &DIScope{di: di, instances: make(map[reflect.Type]reflect.Value)}
+}
+
+// ResolveType builds a value of type t within the scope
+func (s *DIScope) ResolveType(t reflect.Type) (reflect.Value, error) {
+	s.di.mu.Lock()
+	defer s.di.mu.Unlock()
+	return (&resolution{di: s.di, scope: s}).resolve(t)
+}
+
+// resolution is one top-level ResolveType call, holding the types under
+// construction so cycles and captive dependencies can be seen
+type resolution struct {
+	di    *DIContainer
+	scope *DIScope // Nil when resolving from the container
+	stack []reflect.Type
+}
+
+func (r *resolution) fail(err error, t reflect.Type) error {
+	path := append(r.stack[:len(r.stack):len(r.stack)], t)
+	return &ResolveError{Path: path, Err: err}
+}
+
+func (r *resolution) resolve(t reflect.Type) (reflect.Value, error) {
+	p, ok := r.di.providers[t]
+	if !ok {
+		return reflect.Value{}, r.fail(ErrNoProvider, t)
+	}
+	for i, pending := range r.stack {
+		if pending == t {
+			// Report only the loop itself, starting and ending at t
+			r.stack = r.stack[i:]
+			return reflect.Value{}, r.fail(ErrDependencyCycle, t)
+		}
+	}
+
+	switch p.lifetime {
+	case LifetimeSingleton:
+		if v, ok := r.di.instances[t]; ok {
+			return v, nil
+		}
+	case LifetimeScoped:
+		if r.scope == nil {
+			return reflect.Value{}, r.fail(ErrScopeRequired, t)
+		}
+		for _, pending := range r.stack {
+			if r.di.providers[pending].lifetime == LifetimeSingleton {
+				return reflect.Value{}, r.fail(ErrCaptiveDependency, t)
+			}
+		}
+		if v, ok := r.scope.instances[t]; ok {
+			return v, nil
+		}
+	}
+
+	// Build the dependencies, then call the constructor with them
+	r.stack = append(r.stack, t)
+	ft := p.ctor.Type()
+	args := make([]reflect.Value, ft.NumIn())
+	for i := range args {
+		arg, err := r.resolve(ft.In(i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		args[i] = arg
+	}
+	r.stack = r.stack[:len(r.stack)-1]
+
+	out := p.ctor.Call(args)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, r.fail(fmt.Errorf("di: constructor failed: %w", out[1].Interface().(error)), t)
+	}
+
+	switch p.lifetime {
+	case LifetimeSingleton:
+		r.di.instances[t] = out[0]
+	case LifetimeScoped:
+		r.scope.instances[t] = out[0]
+	}
+	return out[0], nil
+}
+
+// InjectDependencies sets the fields tagged `inject`: `inject:"name"`
+// resolves a service registered by name, and an empty `inject:""` resolves
+// the field's type from the registered constructors
+func (di *DIContainer) InjectDependencies(obj interface{}) error {
+	val := reflect.ValueOf(obj)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+
+		if !fieldVal.CanSet() {
+			continue
+		}
+
+		// Check for inject tag
+		injectTag, tagged := field.Tag.Lookup("inject")
+		if !tagged {
+			continue
+		}
+
+		if injectTag == "" {
+			depVal, err := di.ResolveType(field.Type)
+			if err != nil {
+				return fmt.Errorf("failed to resolve field %s: %w", field.Name, err)
+			}
+			fieldVal.Set(depVal)
+			continue
+		}
+
+		dependency, err := di.Resolve(injectTag)
+		if err != nil {
+			return fmt.Errorf("failed to resolve dependency %s: %v", injectTag, err)
+		}
+
+		depVal := reflect.ValueOf(dependency)
+		if depVal.Type().AssignableTo(fieldVal.Type()) {
+			fieldVal.Set(depVal)
+		}
+	}
+
+	return nil
+}
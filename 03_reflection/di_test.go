@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// nilStringer provides a fmt.Stringer that is a nil interface
+func nilStringer() fmt.Stringer { return nil }
+
+// describedBy depends on a fmt.Stringer that may be nil
+type describedBy struct{ s fmt.Stringer }
+
+func TestResolveNilInterface(t *testing.T) {
+	di := NewDIContainer()
+	for _, ctor := range []interface{}{nilStringer, func(s fmt.Stringer) *describedBy { return &describedBy{s} }} {
+		if err := di.Provide(ctor, LifetimeTransient); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := Resolve[fmt.Stringer](di)
+	if err != nil || s != nil {
+		t.Errorf("Resolve[fmt.Stringer] = %v, %v; want nil, nil", s, err)
+	}
+	d, err := Resolve[*describedBy](di)
+	if err != nil || d == nil || d.s != nil {
+		t.Errorf("Resolve[*describedBy] = %+v, %v; want a value holding a nil Stringer", d, err)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode"
+)
+
+// Dynamic struct types used by the advanced topics examples. reflect.StructOf
+// makes a struct type from a list of fields, so a schema known only at run
+// time (a CSV header, a database table, a form definition) can become a real
+// struct that tag-driven code such as encoding/json or the serializer
+// handles like any other. Such types have no name and no methods, and their
+// fields must be exported: reflect cannot set unexported fields, and
+// StructOf panics on those without a package path.
+
+// ErrDynamicField means a field cannot be added to a dynamic struct
+var ErrDynamicField = errors.New("dynamic struct: invalid field")
+
+// DynamicStructBuilder collects fields, then makes a struct type of them
+type DynamicStructBuilder struct {
+	fields []reflect.StructField
+	seen   map[string]bool
+	errs   []error
+}
+
+func NewDynamicStructBuilder() *DynamicStructBuilder {
+	return &DynamicStructBuilder{seen: make(map[string]bool)}
+}
+
+// AddField appends a field. Problems are kept for Build to report, so
+// calls chain.
+func (b *DynamicStructBuilder) AddField(name string, typ reflect.Type, tag string) *DynamicStructBuilder {
+	switch {
+	case name == "" || !isIdentifier(name):
+		b.errs = append(b.errs, fmt.Errorf("%w: %q is not an identifier", ErrDynamicField, name))
+	case !unicode.IsUpper([]rune(name)[0]):
+		b.errs = append(b.errs, fmt.Errorf("%w: %s is unexported; reflect could never set it", ErrDynamicField, name))
+	case b.seen[name]:
+		b.errs = append(b.errs, fmt.Errorf("%w: %s added twice", ErrDynamicField, name))
+	case typ == nil:
+		b.errs = append(b.errs, fmt.Errorf("%w: %s has no type", ErrDynamicField, name))
+	default:
+		b.seen[name] = true
+		b.fields = append(b.fields, reflect.StructField{Name: name, Type: typ, Tag: reflect.StructTag(tag)})
+	}
+	return b
+}
+
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Build makes the struct type, or reports every field that was rejected
+func (b *DynamicStructBuilder) Build() (*DynamicStruct, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	fields := append([]reflect.StructField(nil), b.fields...)
+	ds := &DynamicStruct{typ: reflect.StructOf(fields), index: make(map[string]int, len(fields))}
+	for i, f := range fields {
+		ds.index[f.Name] = i
+	}
+	return ds, nil
+}
+
+// DynamicStruct is a struct type made at run time
+type DynamicStruct struct {
+	typ   reflect.Type
+	index map[string]int
+}
+
+// Type returns the struct type. StructOf returns the same type for the
+// same fields, and that type is identical to the struct literal type
+// written with those fields.
+func (ds *DynamicStruct) Type() reflect.Type {
+	return ds.typ
+}
+
+// New returns a zero value of the type
+func (ds *DynamicStruct) New() *DynamicValue {
+	return &DynamicValue{ds: ds, v: reflect.New(ds.typ).Elem()}
+}
+
+// DynamicValue is a value of a dynamic struct, accessed by field name
+type DynamicValue struct {
+	ds *DynamicStruct
+	v  reflect.Value // Addressable, so fields can be set
+}
+
+// Set sets a field, coercing the value into the field type
+func (dv *DynamicValue) Set(name string, value interface{}) error {
+	i, ok := dv.ds.index[name]
+	if !ok {
+		return fmt.Errorf("dynamic struct: no field %s", name)
+	}
+	if err := CoerceInto(dv.v.Field(i), reflect.ValueOf(value)); err != nil {
+		return fmt.Errorf("dynamic struct: field %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get returns a field's value
+func (dv *DynamicValue) Get(name string) (interface{}, error) {
+	i, ok := dv.ds.index[name]
+	if !ok {
+		return nil, fmt.Errorf("dynamic struct: no field %s", name)
+	}
+	return dv.v.Field(i).Interface(), nil
+}
+
+// Interface returns a pointer to the struct, for code that takes
+// interface{}: json.Marshal, the serializer, DecodeJSON
+func (dv *DynamicValue) Interface() interface{} {
+	return dv.v.Addr().Interface()
+}
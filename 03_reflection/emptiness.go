@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Emptiness analyzer: Go has three different answers to "is this field
+// empty?" — encoding/json's omitempty rule, reflect.Value.IsZero and
+// nil-ness — and they disagree in ways that surprise people. The analyzer
+// reports all three per field and explains every disagreement.
+
+// FieldEmptiness reports the three notions of emptiness for one struct field
+type FieldEmptiness struct {
+	Name      string
+	JSONName  string
+	OmitEmpty bool   // The json tag has the omitempty option
+	JSONEmpty bool   // encoding/json would drop the field under omitempty
+	IsZero    bool   // reflect.Value.IsZero
+	Nil       string // "nil", "non-nil" or "n/a" for kinds that cannot be nil
+	Note      string // Explains a disagreement between the three
+}
+
+// Omitted reports whether the field would be missing from json.Marshal output
+func (f FieldEmptiness) Omitted() bool {
+	return f.OmitEmpty && f.JSONEmpty
+}
+
+// IsEmptyJSON implements the omitempty rule of encoding/json: false, 0, a nil
+// pointer or interface, and any array, slice, map or string of length zero.
+// Structs are never empty, no matter how zero they are.
+func IsEmptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// jsonFieldName splits a json tag into the key and whether omitempty is set
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name = field.Name
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// AnalyzeEmptiness reports emptiness for every exported field of a struct
+func AnalyzeEmptiness(obj interface{}) ([]FieldEmptiness, error) {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("nil pointer")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, got %v", v.Kind())
+	}
+
+	var report []FieldEmptiness
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitEmpty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		fv := v.Field(i)
+		f := FieldEmptiness{
+			Name:      field.Name,
+			JSONName:  name,
+			OmitEmpty: omitEmpty,
+			JSONEmpty: IsEmptyJSON(fv),
+			IsZero:    fv.IsZero(),
+			Nil:       "n/a",
+		}
+		switch fv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+			f.Nil = "non-nil"
+			if fv.IsNil() {
+				f.Nil = "nil"
+			}
+		}
+		f.Note = explainEmptiness(fv, f)
+		report = append(report, f)
+	}
+	return report, nil
+}
+
+func explainEmptiness(v reflect.Value, f FieldEmptiness) string {
+	switch {
+	case v.Kind() == reflect.Interface && !v.IsNil() && v.Elem().Kind() == reflect.Ptr && v.Elem().IsNil():
+		return "interface holding a nil pointer is itself non-nil: kept, and marshals as null"
+	case v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().IsZero():
+		return "non-nil pointer to a zero value: kept, so pointers are how omitempty expresses \"unset\""
+	case f.JSONEmpty == f.IsZero:
+		return ""
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Map:
+		// Only a non-nil, zero-length value gets here
+		return "empty but non-nil: omitempty drops it, yet IsZero is false and it marshals as [] or {} without omitempty"
+	case v.Kind() == reflect.Struct:
+		return "zero struct: IsZero is true, but omitempty never drops structs (use a pointer, or omitzero in Go 1.24+)"
+	case v.Kind() == reflect.Array:
+		return "zero array of non-zero length: IsZero is true, but omitempty only drops length-0 arrays"
+	}
+	return fmt.Sprintf("omitempty says empty=%v but IsZero=%v", f.JSONEmpty, f.IsZero)
+}
+
+// PrintEmptinessReport prints a report as an aligned table
+func PrintEmptinessReport(report []FieldEmptiness) {
+	fmt.Printf("  %-10s %-10s %-9s %-9s %-7s %s\n", "Field", "omitempty", "JSONEmpty", "IsZero", "Nil", "Omitted")
+	for _, f := range report {
+		fmt.Printf("  %-10s %-10v %-9v %-9v %-7s %v\n", f.Name, f.OmitEmpty, f.JSONEmpty, f.IsZero, f.Nil, f.Omitted())
+		if f.Note != "" {
+			fmt.Printf("  %-10s ↳ %s\n", "", f.Note)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Event bus used by the reflection pattern examples. A handler is any
+// func(T) or func(T) error, and receives every published event whose
+// dynamic type is T or, when T is an interface, implements it: func(Audited)
+// hears every event with an Audit method, func(interface{}) hears them all.
+// 04_generics' EventHandler[T] needs one bus per event type; reflection
+// lets one bus carry every type, and Subscribe[T] gives the compile-time
+// check back.
+
+// ErrNilEvent means Publish was given a nil interface, which has no type
+// to route by
+var ErrNilEvent = errors.New("events: nil event")
+
+// subscription is a registered handler
+type subscription struct {
+	id     int
+	fn     reflect.Value
+	in     reflect.Type
+	hasErr bool
+}
+
+// EventBus delivers events to handlers by parameter type
+type EventBus struct {
+	mu     sync.Mutex
+	subs   []*subscription                  // In registration order
+	routes map[reflect.Type][]*subscription // Handlers per event type, filled on first publish
+	nextID int
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{routes: make(map[reflect.Type][]*subscription)}
+}
+
+// Subscribe registers handler for events of type T and returns the
+// function that removes it. Unlike SubscribeFunc it cannot fail: the
+// signature is checked by the compiler.
+func Subscribe[T any](bus *EventBus, handler func(T)) (unsubscribe func()) {
+	unsubscribe, _ = bus.SubscribeFunc(handler)
+	return unsubscribe
+}
+
+// SubscribeFunc registers a func(T) or func(T) error and returns the
+// function that removes it
+func (b *EventBus) SubscribeFunc(handler interface{}) (func(), error) {
+	fv := reflect.ValueOf(handler)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, fmt.Errorf("events: handler must be a function, got %T", handler)
+	}
+	ft := fv.Type()
+	if ft.NumIn() != 1 || ft.IsVariadic() || !(ft.NumOut() == 0 || (ft.NumOut() == 1 && ft.Out(0) == errorType)) {
+		return nil, fmt.Errorf("events: handler must be func(T) or func(T) error, got %v", ft)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	sub := &subscription{id: b.nextID, fn: fv, in: ft.In(0), hasErr: ft.NumOut() == 1}
+	b.subs = append(b.subs, sub)
+	b.routes = make(map[reflect.Type][]*subscription) // Any event type may now match more
+
+	return func() { b.unsubscribe(sub.id) }, nil
+}
+
+func (b *EventBus) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, sub := range b.subs {
+		if sub.id == id {
+			b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+			b.routes = make(map[reflect.Type][]*subscription)
+			return
+		}
+	}
+}
+
+// handlersFor returns the handlers an event of type t goes to, in
+// registration order. Matching walks every subscription, so the result is
+// kept until the subscriptions change.
+func (b *EventBus) handlersFor(t reflect.Type) []*subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if subs, ok := b.routes[t]; ok {
+		return subs
+	}
+
+	var subs []*subscription
+	for _, sub := range b.subs {
+		if t == sub.in || (sub.in.Kind() == reflect.Interface && t.Implements(sub.in)) {
+			subs = append(subs, sub)
+		}
+	}
+	b.routes[t] = subs
+	return subs
+}
+
+// Publish calls every handler the event matches and returns how many
+// there were. Handlers run outside the lock, so they may publish or
+// subscribe themselves; the error joins those the handlers returned.
+func (b *EventBus) Publish(event interface{}) (int, error) {
+	if event == nil {
+		return 0, ErrNilEvent
+	}
+
+	subs := b.handlersFor(reflect.TypeOf(event))
+	args := []reflect.Value{reflect.ValueOf(event)}
+	var errs []error
+	for _, sub := range subs {
+		// An interface-typed handler needs the event converted to its
+		// parameter type; Call does that for assignable values
+		out := sub.fn.Call(args)
+		if sub.hasErr && !out[0].IsNil() {
+			errs = append(errs, fmt.Errorf("events: %v handler: %w", sub.in, out[0].Interface().(error)))
+		}
+	}
+	return len(subs), errors.Join(errs...)
+}
+
+// Subscriptions lists the event types handled, one per handler, in
+// registration order
+func (b *EventBus) Subscriptions() []reflect.Type {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	types := make([]reflect.Type, len(b.subs))
+	for i, sub := range b.subs {
+		types[i] = sub.in
+	}
+	return types
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flattening used by the reflection pattern examples. Flatten turns a
+// struct into a flat map of dotted keys, one per leaf value, and Unflatten
+// writes such a map back into a struct. Keys follow the ConfigBinder's
+// scheme: a field's mapstructure tag, else its name under the naming
+// strategy (LowerNaming by default), joined by dots for nested structs.
+// List elements and map entries add their index or key ("origins.0",
+// "meta.team"), and pointers are followed, so the binder reads a
+// flattened struct as it is. Under ExactNaming the keys are Go field paths
+// ("Address.City"), the same paths ObjectMapper's map tags use.
+
+// ErrUnknownKey means a flat key names no field of the target
+var ErrUnknownKey = errors.New("unflatten: unknown key")
+
+// Flattener converts between structs and flat maps of dotted keys
+type Flattener struct {
+	naming NamingStrategy // Keys for fields without a mapstructure tag
+}
+
+func NewFlattener() *Flattener {
+	return &Flattener{naming: LowerNaming}
+}
+
+// WithNaming sets the key strategy for fields without a mapstructure tag
+func (f *Flattener) WithNaming(naming NamingStrategy) *Flattener {
+	f.naming = naming
+	return f
+}
+
+// Flatten flattens obj with the binder's default keys
+func Flatten(obj interface{}) map[string]interface{} {
+	return NewFlattener().Flatten(obj)
+}
+
+// Unflatten writes flat into obj with the binder's default keys
+func Unflatten(flat map[string]interface{}, obj interface{}) error {
+	return NewFlattener().Unflatten(flat, obj)
+}
+
+// Flatten returns a key for every leaf of obj. time.Time and []byte are
+// leaves, not structs and lists. Nil pointers, empty lists and empty maps
+// have no leaves, so they leave no keys and Unflatten leaves them nil.
+func (f *Flattener) Flatten(obj interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	f.flatten("", reflect.ValueOf(obj), flat)
+	return flat
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func (f *Flattener) flatten(prefix string, v reflect.Value, flat map[string]interface{}) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			f.flatten(prefix, v.Elem(), flat)
+		}
+		return
+	case reflect.Struct:
+		if v.Type() != timeType {
+			t := v.Type()
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				if !field.IsExported() || field.Tag.Get("mapstructure") == "-" {
+					continue
+				}
+				f.flatten(joinKey(prefix, fieldKey(field, "mapstructure", f.naming)), v.Field(i), flat)
+			}
+			return
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				f.flatten(joinKey(prefix, strconv.Itoa(i)), v.Index(i), flat)
+			}
+			return
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			f.flatten(joinKey(prefix, fmt.Sprint(iter.Key().Interface())), iter.Value(), flat)
+		}
+		return
+	}
+	flat[prefix] = v.Interface()
+}
+
+// Unflatten sets the field behind each key of flat in obj, a non-nil
+// pointer. Nil pointers and maps on the way are allocated, lists grow to
+// fit an index, and values are coerced into the field type. A key may
+// also hold a whole list, as the binder's sources do. Fields without a
+// key keep their values, so a flat map can patch a struct. The error
+// joins one error per key that could not be set.
+func (f *Flattener) Unflatten(flat map[string]interface{}, obj interface{}) error {
+	val := reflect.ValueOf(obj)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("unflatten: destination must be a non-nil pointer, got %T", obj)
+	}
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // Report errors in the same order on every run
+
+	var errs []error
+	for _, key := range keys {
+		err := f.setPath(val.Elem(), strings.Split(key, "."), flat[key])
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrUnknownKey):
+			errs = append(errs, fmt.Errorf("%w %s", ErrUnknownKey, key))
+		default:
+			errs = append(errs, fmt.Errorf("unflatten: %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setPath stores value at the path below v
+func (f *Flattener) setPath(v reflect.Value, path []string, value interface{}) error {
+	if len(path) == 0 {
+		return CoerceInto(v, reflect.ValueOf(value))
+	}
+	part := path[0]
+
+	switch {
+	case v.Kind() == reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return f.setPath(v.Elem(), path, value)
+
+	case v.Kind() == reflect.Interface && v.NumMethod() == 0:
+		// An interface{} below the struct holds nested maps, as JSON
+		// decoding would make them
+		m, ok := v.Interface().(map[string]interface{})
+		if !ok {
+			m = make(map[string]interface{})
+			v.Set(reflect.ValueOf(m))
+		}
+		return f.setPath(reflect.ValueOf(m), path, value)
+
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("mapstructure") == "-" {
+				continue
+			}
+			if fieldKey(field, "mapstructure", f.naming) == part {
+				return f.setPath(v.Field(i), path[1:], value)
+			}
+		}
+
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		i, err := strconv.Atoi(part)
+		if err != nil || i < 0 {
+			return fmt.Errorf("%q is not an index into %v", part, v.Type())
+		}
+		if i >= v.Len() {
+			if v.Kind() == reflect.Array {
+				return fmt.Errorf("index %d out of range for %v", i, v.Type())
+			}
+			grown := reflect.MakeSlice(v.Type(), i+1, i+1)
+			reflect.Copy(grown, v)
+			v.Set(grown)
+		}
+		return f.setPath(v.Index(i), path[1:], value)
+
+	case v.Kind() == reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := CoerceInto(key, reflect.ValueOf(part)); err != nil {
+			return fmt.Errorf("map key %q: %w", part, err)
+		}
+		// Map entries are not addressable: set a copy, then store it
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := f.setPath(elem, path[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return ErrUnknownKey
+}
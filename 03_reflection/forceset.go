@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ForceSet is an explicitly opt-in bridge for writing unexported struct
+// fields. Reflection refuses to Set unexported fields (CanSet is false); the
+// bridge rebuilds a settable Value over the same memory with unsafe. The
+// unsafe part lives in forceset_unsafe.go and can be compiled out with
+// `go build -tags noforceset`, in which case ForceSet always fails with
+// ErrForceSetDisabled.
+//
+// Legal: a non-nil pointer to a struct, an existing field, and a value whose
+// type is assignable to the field type (nil for nillable fields).
+// Not legal: struct values (copies are not addressable), missing fields,
+// conversions of any kind, and fields of embedded pointer structs.
+
+var (
+	ErrForceSetDisabled = errors.New("forceset: disabled by the noforceset build tag")
+	ErrNotAddressable   = errors.New("forceset: field is not addressable")
+	ErrFieldNotFound    = errors.New("forceset: field not found")
+	ErrTypeMismatch     = errors.New("forceset: value type does not match field type")
+)
+
+// ForceSet assigns value to the named field of *obj, even if it is unexported
+func ForceSet(obj interface{}, fieldName string, value interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: need a non-nil pointer to a struct, got %T", ErrNotAddressable, obj)
+	}
+
+	structType := v.Elem().Type()
+	sf, found := structType.FieldByName(fieldName)
+	if !found {
+		return fmt.Errorf("%w: %s.%s", ErrFieldNotFound, structType, fieldName)
+	}
+
+	// Promoted fields are fine through embedded structs, not through embedded pointers
+	field := v.Elem()
+	for i, index := range sf.Index {
+		field = field.Field(index)
+		if i < len(sf.Index)-1 && field.Kind() == reflect.Ptr {
+			return fmt.Errorf("%w: %s is promoted through embedded pointer %v", ErrNotAddressable, fieldName, field.Type())
+		}
+	}
+
+	// Strict typing: no conversions, so the bridge never reinterprets memory
+	var newValue reflect.Value
+	if value == nil {
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+			newValue = reflect.Zero(field.Type())
+		default:
+			return fmt.Errorf("%w: nil for %s %v", ErrTypeMismatch, fieldName, field.Type())
+		}
+	} else {
+		newValue = reflect.ValueOf(value)
+		if !newValue.Type().AssignableTo(field.Type()) {
+			return fmt.Errorf("%w: %v for %s %v", ErrTypeMismatch, newValue.Type(), fieldName, field.Type())
+		}
+	}
+
+	// Exported fields need no bridge at all
+	if field.CanSet() {
+		field.Set(newValue)
+		return nil
+	}
+
+	settable, err := forceSettable(field)
+	if err != nil {
+		return err
+	}
+	settable.Set(newValue)
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build noforceset
+
+package main
+
+import "reflect"
+
+// ForceSetEnabled reports whether the unsafe bridge is compiled in
+const ForceSetEnabled = false
+
+// forceSettable always fails when the bridge is compiled out
+func forceSettable(field reflect.Value) (reflect.Value, error) {
+	return reflect.Value{}, ErrForceSetDisabled
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// The same tests run with and without the bridge:
+//
+//	go test ./03_reflection
+//	go test -tags noforceset ./03_reflection
+//
+// Writes that need the bridge fail with ErrForceSetDisabled under the tag;
+// exported fields and every guardrail error behave the same either way.
+
+func newLockedAccount() *lockedAccount {
+	return &lockedAccount{Owner: "Alice", balance: 100, history: []string{"open"}, lockedExtra: &lockedExtra{note: "vip"}}
+}
+
+type myInt int
+
+func TestForceSet(t *testing.T) {
+	byPointer := func(a *lockedAccount) interface{} { return a }
+	tests := []struct {
+		name    string
+		target  func(a *lockedAccount) interface{}
+		field   string
+		value   interface{}
+		bridged bool                 // The write goes through the unsafe bridge
+		wantErr error                // nil when the write is legal
+		want    func(*lockedAccount) // Applies the expected write to a fresh account
+	}{
+		// Legal
+		{"unexported field via pointer", byPointer, "balance", 500, true, nil,
+			func(a *lockedAccount) { a.balance = 500 }},
+		{"exported field needs no bridge", byPointer, "Owner", "Bob", false, nil,
+			func(a *lockedAccount) { a.Owner = "Bob" }},
+		{"nil for a nillable field", byPointer, "history", nil, true, nil,
+			func(a *lockedAccount) { a.history = nil }},
+		{"promoted through embedded struct", byPointer, "revision", 7, true, nil,
+			func(a *lockedAccount) { a.revision = 7 }},
+
+		// Not addressable
+		{"struct value instead of pointer", func(a *lockedAccount) interface{} { return *a }, "balance", 1, false, ErrNotAddressable, nil},
+		{"nil pointer", func(*lockedAccount) interface{} { return (*lockedAccount)(nil) }, "balance", 1, false, ErrNotAddressable, nil},
+		{"nil target", func(*lockedAccount) interface{} { return nil }, "balance", 1, false, ErrNotAddressable, nil},
+		{"pointer to a non-struct", func(a *lockedAccount) interface{} { return &a.balance }, "balance", 1, false, ErrNotAddressable, nil},
+		{"promoted through embedded pointer", byPointer, "note", "hi", false, ErrNotAddressable, nil},
+
+		// Missing field
+		{"missing field", byPointer, "overdraft", 1, false, ErrFieldNotFound, nil},
+
+		// Type mismatch: no conversions of any kind
+		{"wrong type", byPointer, "balance", "lots", false, ErrTypeMismatch, nil},
+		{"int64 into int", byPointer, "balance", int64(1), false, ErrTypeMismatch, nil},
+		{"named int into int", byPointer, "balance", myInt(1), false, ErrTypeMismatch, nil},
+		{"nil for a non-nillable field", byPointer, "balance", nil, false, ErrTypeMismatch, nil},
+		{"wrong type for an exported field", byPointer, "Owner", 1, false, ErrTypeMismatch, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantErr := tt.wantErr
+			if tt.bridged && !ForceSetEnabled {
+				wantErr = ErrForceSetDisabled
+			}
+
+			account := newLockedAccount()
+			err := ForceSet(tt.target(account), tt.field, tt.value)
+			if !errors.Is(err, wantErr) {
+				t.Fatalf("ForceSet(%s, %v) error = %v, want %v", tt.field, tt.value, err, wantErr)
+			}
+
+			// A failed ForceSet leaves the target untouched
+			want := newLockedAccount()
+			if err == nil {
+				tt.want(want)
+			}
+			if !reflect.DeepEqual(account, want) {
+				t.Errorf("account = %+v, want %+v", *account, *want)
+			}
+		})
+	}
+}
+
+// TestForceSettableNeedsAddressableField checks the bridge itself: a field
+// of a struct copy has no address to alias, so there is nothing to write
+func TestForceSettableNeedsAddressableField(t *testing.T) {
+	field := reflect.ValueOf(*newLockedAccount()).FieldByName("balance")
+	wantErr := ErrNotAddressable
+	if !ForceSetEnabled {
+		wantErr = ErrForceSetDisabled
+	}
+	if _, err := forceSettable(field); !errors.Is(err, wantErr) {
+		t.Errorf("forceSettable(non-addressable field) error = %v, want %v", err, wantErr)
+	}
+}
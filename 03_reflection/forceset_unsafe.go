@@ -0,0 +1,19 @@
+//go:build !noforceset
+
+package main
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// ForceSetEnabled reports whether the unsafe bridge is compiled in
+const ForceSetEnabled = true
+
+// forceSettable returns a settable Value aliasing an addressable, unexported field
+func forceSettable(field reflect.Value) (reflect.Value, error) {
+	if !field.CanAddr() {
+		return reflect.Value{}, ErrNotAddressable
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), nil
+}
@@ -0,0 +1,970 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Miniature JSON codec used by 09_json_codec.go. It follows encoding/json's
+// rules for the common cases (json tags, omitempty, embedded structs,
+// []byte as base64, sorted map keys, case-insensitive field matching) and
+// is built on nothing but reflect and strconv, so every step json.Marshal
+// hides is visible. Left out on purpose: the Marshaler/Unmarshaler and
+// TextMarshaler hooks, the ",string" option and HTML escaping.
+
+var (
+	// ErrJSONUnsupportedType is returned for channels, funcs, complex
+	// numbers and maps whose keys are not strings or integers
+	ErrJSONUnsupportedType = errors.New("jsoncodec: unsupported type")
+	// ErrJSONUnsupportedValue is returned for NaN and infinite floats
+	ErrJSONUnsupportedValue = errors.New("jsoncodec: unsupported value")
+	// ErrJSONCycle is returned when a pointer leads back to itself
+	ErrJSONCycle = errors.New("jsoncodec: pointer cycle")
+)
+
+// JSONSyntaxError reports malformed input and where it went wrong
+type JSONSyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *JSONSyntaxError) Error() string {
+	return fmt.Sprintf("jsoncodec: syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// JSONTypeError reports a well-formed JSON value that does not fit the Go
+// value it is decoded into, e.g. a string for an int field
+type JSONTypeError struct {
+	Value string // "string", "number 300", "object"...
+	Type  reflect.Type
+	Path  string // Field path such as "items[2].qty"
+}
+
+func (e *JSONTypeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("jsoncodec: cannot decode %s into %v", e.Value, e.Type)
+	}
+	return fmt.Sprintf("jsoncodec: cannot decode %s into %s of type %v", e.Value, e.Path, e.Type)
+}
+
+// jsonField is one key of a struct's JSON object
+type jsonField struct {
+	name      string
+	index     []int // For FieldByIndex, through embedded structs
+	omitEmpty bool
+}
+
+// jsonStruct is the JSON view of a struct type, computed once per type
+type jsonStruct struct {
+	fields []jsonField
+	byName map[string]int // Exact key
+	byFold map[string]int // Lower-cased key, the fallback encoding/json uses
+}
+
+var jsonStructCache sync.Map // reflect.Type -> *jsonStruct
+
+// jsonStructOf returns the fields of t in encoding order. Fields of
+// embedded structs are promoted unless the embedding field has a json
+// name; a shallower field hides deeper ones with the same key, and two
+// untagged fields at the same depth hide each other.
+func jsonStructOf(t reflect.Type) *jsonStruct {
+	if cached, ok := jsonStructCache.Load(t); ok {
+		return cached.(*jsonStruct)
+	}
+
+	type candidate struct {
+		jsonField
+		depth  int
+		tagged bool
+	}
+	var candidates []candidate
+	var walk func(t reflect.Type, index []int, depth int)
+	walk = func(t reflect.Type, index []int, depth int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, skip := jsonFieldName(field)
+			if skip {
+				continue
+			}
+			fieldIndex := append(index[:len(index):len(index)], i)
+			tagged := strings.Split(field.Tag.Get("json"), ",")[0] != ""
+
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if field.Anonymous && !tagged && embedded.Kind() == reflect.Struct {
+				walk(embedded, fieldIndex, depth+1) // Promoted even if the type is unexported
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				jsonField: jsonField{name: name, index: fieldIndex, omitEmpty: omitEmpty},
+				depth:     depth,
+				tagged:    tagged,
+			})
+		}
+	}
+	walk(t, nil, 0)
+
+	// Keep the winner for every key: the shallowest, then the only tagged one
+	byKey := make(map[string][]candidate)
+	for _, c := range candidates {
+		byKey[c.name] = append(byKey[c.name], c)
+	}
+	s := &jsonStruct{byName: make(map[string]int), byFold: make(map[string]int)}
+	for _, c := range candidates {
+		rivals := byKey[c.name]
+		winner, ambiguous := rivals[0], false
+		for _, r := range rivals[1:] {
+			switch {
+			case r.depth < winner.depth || (r.depth == winner.depth && r.tagged && !winner.tagged):
+				winner, ambiguous = r, false
+			case r.depth == winner.depth && r.tagged == winner.tagged:
+				ambiguous = true
+			}
+		}
+		if ambiguous || !reflect.DeepEqual(winner.index, c.index) {
+			continue
+		}
+		s.byName[c.name] = len(s.fields)
+		if _, taken := s.byFold[strings.ToLower(c.name)]; !taken {
+			s.byFold[strings.ToLower(c.name)] = len(s.fields)
+		}
+		s.fields = append(s.fields, c.jsonField)
+	}
+
+	actual, _ := jsonStructCache.LoadOrStore(t, s)
+	return actual.(*jsonStruct)
+}
+
+// EncodeJSON returns the JSON encoding of v, byte for byte what
+// json.Marshal produces for the types this codec supports, as long as no
+// string needs HTML escaping
+func EncodeJSON(v interface{}) ([]byte, error) {
+	e := &jsonEncoder{seen: make(map[uintptr]bool)}
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+type jsonEncoder struct {
+	buf  []byte
+	seen map[uintptr]bool // Pointers on the path from the root
+}
+
+func (e *jsonEncoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf = append(e.buf, "null"...)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.buf = strconv.AppendBool(e.buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf = strconv.AppendInt(e.buf, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.buf = strconv.AppendUint(e.buf, v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return e.encodeFloat(v)
+	case reflect.String:
+		e.encodeString(v.String())
+
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			e.buf = append(e.buf, "null"...)
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			// Only pointers can close a cycle in a value Go can build
+			if e.seen[v.Pointer()] {
+				return fmt.Errorf("%w through %v", ErrJSONCycle, v.Type())
+			}
+			e.seen[v.Pointer()] = true
+			defer delete(e.seen, v.Pointer())
+		}
+		return e.encode(v.Elem())
+
+	case reflect.Slice:
+		if v.IsNil() {
+			e.buf = append(e.buf, "null"...)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.encodeString(base64.StdEncoding.EncodeToString(v.Bytes()))
+			return nil
+		}
+		return e.encodeArray(v)
+	case reflect.Array:
+		return e.encodeArray(v)
+
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Struct:
+		return e.encodeStruct(v)
+
+	default:
+		return fmt.Errorf("%w: %v", ErrJSONUnsupportedType, v.Type())
+	}
+	return nil
+}
+
+// encodeFloat writes the shortest representation that reads back as the
+// same float, switching to an exponent for very large or small magnitudes
+// as encoding/json does
+func (e *jsonEncoder) encodeFloat(v reflect.Value) error {
+	f := v.Float()
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("%w: %v", ErrJSONUnsupportedValue, f)
+	}
+	bits := v.Type().Bits()
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	e.buf = strconv.AppendFloat(e.buf, f, format, -1, bits)
+	if n := len(e.buf); format == 'e' && e.buf[n-4] == 'e' && e.buf[n-3] == '-' && e.buf[n-2] == '0' {
+		// Clean up e-09 to e-9, like encoding/json
+		e.buf[n-2] = e.buf[n-1]
+		e.buf = e.buf[:n-1]
+	}
+	return nil
+}
+
+const jsonHex = "0123456789abcdef"
+
+// encodeString quotes s, escaping what JSON requires and replacing invalid
+// UTF-8 with U+FFFD
+func (e *jsonEncoder) encodeString(s string) {
+	e.buf = append(e.buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			e.buf = append(e.buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				e.buf = append(e.buf, '\\', b)
+			case '\n':
+				e.buf = append(e.buf, '\\', 'n')
+			case '\r':
+				e.buf = append(e.buf, '\\', 'r')
+			case '\t':
+				e.buf = append(e.buf, '\\', 't')
+			default:
+				e.buf = append(e.buf, '\\', 'u', '0', '0', jsonHex[b>>4], jsonHex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			e.buf = append(e.buf, s[start:i]...)
+			e.buf = append(e.buf, '\\', 'u', 'f', 'f', 'f', 'd')
+			start = i + size
+		case r == 0x2028 || r == 0x2029:
+			// Valid JSON, but line terminators to JavaScript
+			e.buf = append(e.buf, s[start:i]...)
+			e.buf = append(e.buf, '\\', 'u', '2', '0', '2', jsonHex[r&0xF])
+			start = i + size
+		}
+		i += size
+	}
+	e.buf = append(e.buf, s[start:]...)
+	e.buf = append(e.buf, '"')
+}
+
+func (e *jsonEncoder) encodeArray(v reflect.Value) error {
+	e.buf = append(e.buf, '[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			e.buf = append(e.buf, ',')
+		}
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	e.buf = append(e.buf, ']')
+	return nil
+}
+
+// encodeMap writes the entries sorted by key, so equal maps encode equally
+func (e *jsonEncoder) encodeMap(v reflect.Value) error {
+	if v.IsNil() {
+		e.buf = append(e.buf, "null"...)
+		return nil
+	}
+
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k := iter.Key()
+		var key string
+		switch k.Kind() {
+		case reflect.String:
+			key = k.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			key = strconv.FormatInt(k.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			key = strconv.FormatUint(k.Uint(), 10)
+		default:
+			return fmt.Errorf("%w: map key %v", ErrJSONUnsupportedType, k.Type())
+		}
+		entries = append(entries, entry{key, iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	e.buf = append(e.buf, '{')
+	for i, en := range entries {
+		if i > 0 {
+			e.buf = append(e.buf, ',')
+		}
+		e.encodeString(en.key)
+		e.buf = append(e.buf, ':')
+		if err := e.encode(en.value); err != nil {
+			return err
+		}
+	}
+	e.buf = append(e.buf, '}')
+	return nil
+}
+
+func (e *jsonEncoder) encodeStruct(v reflect.Value) error {
+	e.buf = append(e.buf, '{')
+	first := true
+	for _, f := range jsonStructOf(v.Type()).fields {
+		fv, ok := fieldByJSONIndex(v, f.index)
+		if !ok || (f.omitEmpty && IsEmptyJSON(fv)) {
+			continue // Inside a nil embedded pointer, or empty
+		}
+		if !first {
+			e.buf = append(e.buf, ',')
+		}
+		first = false
+		e.encodeString(f.name)
+		e.buf = append(e.buf, ':')
+		if err := e.encode(fv); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	e.buf = append(e.buf, '}')
+	return nil
+}
+
+// fieldByJSONIndex is FieldByIndex that reports a nil embedded pointer
+// on the way instead of panicking
+func fieldByJSONIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// DecodeJSON parses data into the value v points to. Unknown keys are
+// skipped, keys match fields exactly or else case-insensitively, and nil
+// pointers, slices and maps on the way are allocated. Into an interface{}
+// it decodes what json.Unmarshal does: map[string]interface{},
+// []interface{}, float64, string, bool or nil.
+func DecodeJSON(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("jsoncodec: DecodeJSON needs a non-nil pointer, got %T", v)
+	}
+	d := &jsonDecoder{data: data}
+	if err := d.value(rv.Elem()); err != nil {
+		return err
+	}
+	if d.skipSpace(); d.pos < len(d.data) {
+		return d.syntaxError("data after the top-level value")
+	}
+	return nil
+}
+
+type jsonDecoder struct {
+	data []byte
+	pos  int
+	path []jsonStep // Where the decoder is, for JSONTypeError
+}
+
+// jsonStep is one step of the decoder's path. Steps are only turned into
+// text when an error needs them, so a successful decode builds no strings.
+type jsonStep struct {
+	field string // Struct field key, or map key when isKey is set
+	index int    // Array index when field is empty and isKey is not set
+	isKey bool
+}
+
+func (d *jsonDecoder) syntaxError(format string, args ...interface{}) error {
+	return &JSONSyntaxError{Offset: d.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (d *jsonDecoder) typeError(value string, t reflect.Type) error {
+	var path strings.Builder
+	for _, step := range d.path {
+		switch {
+		case step.isKey:
+			path.WriteString("[" + strconv.Quote(step.field) + "]")
+		case step.field != "":
+			if path.Len() > 0 {
+				path.WriteByte('.')
+			}
+			path.WriteString(step.field)
+		default:
+			path.WriteString("[" + strconv.Itoa(step.index) + "]")
+		}
+	}
+	return &JSONTypeError{Value: value, Type: t, Path: path.String()}
+}
+
+func (d *jsonDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+// value decodes the next JSON value into v, which is settable
+func (d *jsonDecoder) value(v reflect.Value) error {
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return d.syntaxError("unexpected end of input")
+	}
+
+	if d.data[d.pos] == 'n' {
+		if err := d.literal("null"); err != nil {
+			return err
+		}
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil // Like encoding/json, null leaves other kinds alone
+	}
+
+	// Anything else goes through pointers, allocating the ones still nil
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface {
+		if v.NumMethod() > 0 {
+			return d.typeError(d.describeNext(), v.Type())
+		}
+		generic, err := d.generic()
+		if err != nil {
+			return err
+		}
+		if generic != nil {
+			v.Set(reflect.ValueOf(generic))
+		}
+		return nil
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == '{':
+		return d.object(v)
+	case c == '[':
+		return d.array(v)
+	case c == '"':
+		return d.stringInto(v)
+	case c == 't' || c == 'f':
+		return d.boolInto(v)
+	case c == '-' || (c >= '0' && c <= '9'):
+		return d.numberInto(v)
+	}
+	return d.syntaxError("unexpected character %q", d.data[d.pos])
+}
+
+// describeNext names the upcoming value for a type error
+func (d *jsonDecoder) describeNext() string {
+	switch c := d.data[d.pos]; {
+	case c == '{':
+		return "object"
+	case c == '[':
+		return "array"
+	case c == '"':
+		return "string"
+	case c == 't' || c == 'f':
+		return "bool"
+	}
+	return "number"
+}
+
+func (d *jsonDecoder) literal(word string) error {
+	if !strings.HasPrefix(string(d.data[d.pos:min(d.pos+len(word), len(d.data))]), word) {
+		return d.syntaxError("invalid literal, expected %s", word)
+	}
+	d.pos += len(word)
+	return nil
+}
+
+func (d *jsonDecoder) boolInto(v reflect.Value) error {
+	b := d.data[d.pos] == 't'
+	word := "false"
+	if b {
+		word = "true"
+	}
+	if err := d.literal(word); err != nil {
+		return err
+	}
+	if v.Kind() != reflect.Bool {
+		return d.typeError("bool", v.Type())
+	}
+	v.SetBool(b)
+	return nil
+}
+
+// number scans a number token, validating the JSON grammar
+func (d *jsonDecoder) number() (string, error) {
+	start := d.pos
+	digits := func() int {
+		n := 0
+		for d.pos < len(d.data) && d.data[d.pos] >= '0' && d.data[d.pos] <= '9' {
+			d.pos++
+			n++
+		}
+		return n
+	}
+	if d.data[d.pos] == '-' {
+		d.pos++
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == '0' {
+		d.pos++ // No leading zeros
+	} else if digits() == 0 {
+		return "", d.syntaxError("invalid number")
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == '.' {
+		d.pos++
+		if digits() == 0 {
+			return "", d.syntaxError("missing digits after the decimal point")
+		}
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		d.pos++
+		if d.pos < len(d.data) && (d.data[d.pos] == '+' || d.data[d.pos] == '-') {
+			d.pos++
+		}
+		if digits() == 0 {
+			return "", d.syntaxError("missing digits in the exponent")
+		}
+	}
+	return string(d.data[start:d.pos]), nil
+}
+
+func (d *jsonDecoder) numberInto(v reflect.Value) error {
+	s, err := d.number()
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || v.OverflowInt(n) {
+			return d.typeError("number "+s, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || v.OverflowUint(n) {
+			return d.typeError("number "+s, v.Type())
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return d.typeError("number "+s, v.Type())
+		}
+		v.SetFloat(f)
+	default:
+		return d.typeError("number", v.Type())
+	}
+	return nil
+}
+
+// str scans a string token and returns its unescaped contents
+func (d *jsonDecoder) str() (string, error) {
+	d.pos++ // Opening quote
+	start := d.pos
+	// Fast path: no escapes, so the contents are the bytes as they are
+	for d.pos < len(d.data) {
+		switch c := d.data[d.pos]; {
+		case c == '"':
+			s := string(d.data[start:d.pos])
+			d.pos++
+			return s, nil
+		case c == '\\':
+			return d.escapedStr(start)
+		case c < 0x20:
+			return "", d.syntaxError("control character in string")
+		}
+		d.pos++
+	}
+	return "", d.syntaxError("unterminated string")
+}
+
+func (d *jsonDecoder) escapedStr(start int) (string, error) {
+	var sb strings.Builder
+	sb.Write(d.data[start:d.pos])
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		switch {
+		case c == '"':
+			d.pos++
+			return sb.String(), nil
+		case c < 0x20:
+			return "", d.syntaxError("control character in string")
+		case c != '\\':
+			sb.WriteByte(c)
+			d.pos++
+			continue
+		}
+
+		if d.pos+1 >= len(d.data) {
+			break
+		}
+		d.pos += 2
+		switch esc := d.data[d.pos-1]; esc {
+		case '"', '\\', '/':
+			sb.WriteByte(esc)
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'u':
+			r, err := d.hex4()
+			if err != nil {
+				return "", err
+			}
+			if utf16.IsSurrogate(r) {
+				// The second half must follow as another \u escape
+				r2 := utf8.RuneError
+				if d.pos+1 < len(d.data) && d.data[d.pos] == '\\' && d.data[d.pos+1] == 'u' {
+					d.pos += 2
+					if r2, err = d.hex4(); err != nil {
+						return "", err
+					}
+				}
+				r = utf16.DecodeRune(r, r2)
+			}
+			sb.WriteRune(r)
+		default:
+			return "", d.syntaxError("invalid escape \\%c", esc)
+		}
+	}
+	return "", d.syntaxError("unterminated string")
+}
+
+func (d *jsonDecoder) hex4() (rune, error) {
+	if d.pos+4 > len(d.data) {
+		return 0, d.syntaxError("short \\u escape")
+	}
+	n, err := strconv.ParseUint(string(d.data[d.pos:d.pos+4]), 16, 16)
+	if err != nil {
+		return 0, d.syntaxError("invalid \\u escape")
+	}
+	d.pos += 4
+	return rune(n), nil
+}
+
+func (d *jsonDecoder) stringInto(v reflect.Value) error {
+	s, err := d.str()
+	if err != nil {
+		return err
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return d.typeError("string (not base64)", v.Type())
+		}
+		v.SetBytes(b)
+	default:
+		return d.typeError("string", v.Type())
+	}
+	return nil
+}
+
+// expect consumes c after optional whitespace
+func (d *jsonDecoder) expect(c byte) error {
+	d.skipSpace()
+	if d.pos >= len(d.data) || d.data[d.pos] != c {
+		return d.syntaxError("expected %q", c)
+	}
+	d.pos++
+	return nil
+}
+
+// next reports whether the array or object has another element, consuming
+// the separator in between; close is ']' or '}'
+func (d *jsonDecoder) next(close byte, first bool) (bool, error) {
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return false, d.syntaxError("unexpected end of input")
+	}
+	if d.data[d.pos] == close {
+		d.pos++
+		return false, nil
+	}
+	if !first {
+		if d.data[d.pos] != ',' {
+			return false, d.syntaxError("expected ',' or %q", close)
+		}
+		d.pos++
+	}
+	return true, nil
+}
+
+func (d *jsonDecoder) array(v reflect.Value) error {
+	kind := v.Kind()
+	if kind != reflect.Slice && kind != reflect.Array {
+		return d.typeError("array", v.Type())
+	}
+	d.pos++ // [
+
+	i := 0
+	for {
+		more, err := d.next(']', i == 0)
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+
+		d.path = append(d.path, jsonStep{index: i})
+		switch {
+		case kind == reflect.Slice:
+			if i >= v.Cap() {
+				grown := reflect.MakeSlice(v.Type(), i, max(4, 2*v.Cap()))
+				reflect.Copy(grown, v)
+				v.Set(grown)
+			}
+			v.SetLen(i + 1)
+			elem := v.Index(i)
+			elem.Set(reflect.Zero(elem.Type()))
+			err = d.value(elem)
+		case i < v.Len():
+			err = d.value(v.Index(i))
+		default:
+			err = d.value(reflect.New(v.Type().Elem()).Elem()) // Past the array's end: dropped
+		}
+		d.path = d.path[:len(d.path)-1]
+		if err != nil {
+			return err
+		}
+		i++
+	}
+
+	switch {
+	case kind == reflect.Slice && i == 0 && v.IsNil():
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0)) // [] is empty, not nil
+	case kind == reflect.Slice:
+		v.SetLen(i)
+	default:
+		for ; i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		}
+	}
+	return nil
+}
+
+func (d *jsonDecoder) object(v reflect.Value) error {
+	var fields *jsonStruct
+	switch v.Kind() {
+	case reflect.Struct:
+		fields = jsonStructOf(v.Type())
+	case reflect.Map:
+		switch v.Type().Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			return d.typeError("object", v.Type())
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+	default:
+		return d.typeError("object", v.Type())
+	}
+	d.pos++ // {
+
+	for first := true; ; first = false {
+		more, err := d.next('}', first)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		d.skipSpace()
+		if d.pos >= len(d.data) || d.data[d.pos] != '"' {
+			return d.syntaxError("expected a string key")
+		}
+		key, err := d.str()
+		if err != nil {
+			return err
+		}
+		if err := d.expect(':'); err != nil {
+			return err
+		}
+
+		if fields != nil {
+			err = d.structField(v, fields, key)
+		} else {
+			err = d.mapEntry(v, key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (d *jsonDecoder) structField(v reflect.Value, fields *jsonStruct, key string) error {
+	i, ok := fields.byName[key]
+	if !ok {
+		i, ok = fields.byFold[strings.ToLower(key)]
+	}
+	if !ok {
+		return d.skip() // Unknown keys are ignored, as by encoding/json
+	}
+
+	// Walk the index, allocating embedded pointers on the way
+	f := fields.fields[i]
+	fv := v
+	for j, x := range f.index {
+		if j > 0 && fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				if !fv.CanSet() {
+					return d.typeError("object", fv.Type()) // Nil pointer to an unexported embedded struct
+				}
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		fv = fv.Field(x)
+	}
+
+	d.path = append(d.path, jsonStep{field: f.name})
+	err := d.value(fv)
+	d.path = d.path[:len(d.path)-1]
+	return err
+}
+
+func (d *jsonDecoder) mapEntry(v reflect.Value, key string) error {
+	kt := v.Type().Key()
+	k := reflect.New(kt).Elem()
+	switch kt.Kind() {
+	case reflect.String:
+		k.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || k.OverflowInt(n) {
+			return d.typeError("key "+strconv.Quote(key), kt)
+		}
+		k.SetInt(n)
+	default:
+		n, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || k.OverflowUint(n) {
+			return d.typeError("key "+strconv.Quote(key), kt)
+		}
+		k.SetUint(n)
+	}
+
+	// Map elements are not addressable: decode into a fresh value, then store it
+	elem := reflect.New(v.Type().Elem()).Elem()
+	d.path = append(d.path, jsonStep{field: key, isKey: true})
+	err := d.value(elem)
+	d.path = d.path[:len(d.path)-1]
+	if err != nil {
+		return err
+	}
+	v.SetMapIndex(k, elem)
+	return nil
+}
+
+// generic decodes the next value the way json.Unmarshal does into interface{}
+func (d *jsonDecoder) generic() (interface{}, error) {
+	switch c := d.data[d.pos]; {
+	case c == 'n':
+		return nil, d.literal("null")
+	case c == 't':
+		return true, d.literal("true")
+	case c == 'f':
+		return false, d.literal("false")
+	case c == '"':
+		return d.str()
+	case c == '-' || (c >= '0' && c <= '9'):
+		s, err := d.number()
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(s, 64)
+	case c == '[':
+		var items []interface{}
+		if err := d.array(reflect.ValueOf(&items).Elem()); err != nil {
+			return nil, err
+		}
+		return items, nil
+	case c == '{':
+		obj := make(map[string]interface{})
+		if err := d.object(reflect.ValueOf(obj)); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	return nil, d.syntaxError("unexpected character %q", d.data[d.pos])
+}
+
+// skip consumes the next value without decoding it anywhere
+func (d *jsonDecoder) skip() error {
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return d.syntaxError("unexpected end of input")
+	}
+	_, err := d.generic()
+	return err
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Named argument binding: reflect only knows parameter types, not names, so
+// names are supplied at registration (or taken from the fields of an args
+// struct). CallNamed then binds a JSON-style object to positional parameters,
+// coercing values with CoerceValue and filling defaults.
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NamedParam names one positional parameter and optionally gives it a default
+type NamedParam struct {
+	Name       string
+	Default    interface{}
+	HasDefault bool
+}
+
+// Param declares a required parameter
+func Param(name string) NamedParam {
+	return NamedParam{Name: name}
+}
+
+// Optional declares a parameter that falls back to def when missing
+func Optional(name string, def interface{}) NamedParam {
+	return NamedParam{Name: name, Default: def, HasDefault: true}
+}
+
+// NamedFunc is a registered function plus the names of its parameters
+type NamedFunc struct {
+	fn         reflect.Value
+	params     []NamedParam // One per non-context parameter, or per field in struct mode
+	takesCtx   bool
+	argsStruct reflect.Type // Non-nil when arguments are bound into a struct
+	argsFields []int        // Struct field index of each parameter in struct mode
+}
+
+// NamedResult holds the return values of a call, with a trailing error split out
+type NamedResult struct {
+	Values []interface{}
+	Err    error
+}
+
+// NamedRegistry maps function names to NamedFuncs
+type NamedRegistry struct {
+	funcs map[string]*NamedFunc
+}
+
+// NewNamedRegistry creates an empty registry
+func NewNamedRegistry() *NamedRegistry {
+	return &NamedRegistry{funcs: make(map[string]*NamedFunc)}
+}
+
+// Register adds fn with one NamedParam per parameter. A leading
+// context.Context is injected by the caller and must not be named.
+func (r *NamedRegistry) Register(name string, fn interface{}, params ...NamedParam) error {
+	nf, err := newNamedFunc(fn)
+	if err != nil {
+		return fmt.Errorf("register %s: %w", name, err)
+	}
+
+	t := nf.fn.Type()
+	if want := t.NumIn() - nf.firstParam(); len(params) != want {
+		return fmt.Errorf("register %s: %d parameter names for %d parameters", name, len(params), want)
+	}
+	nf.params = params
+	r.funcs[name] = nf
+	return nil
+}
+
+// RegisterArgs adds fn whose only parameter (after an optional context) is a
+// struct. Field names come from json tags and defaults from `default` tags;
+// fields tagged validate:"required" must be given, others default to zero.
+// Fields tagged json:"-" are not arguments and are always left zero.
+func (r *NamedRegistry) RegisterArgs(name string, fn interface{}) error {
+	nf, err := newNamedFunc(fn)
+	if err != nil {
+		return fmt.Errorf("register %s: %w", name, err)
+	}
+
+	t := nf.fn.Type()
+	if t.NumIn()-nf.firstParam() != 1 || t.In(nf.firstParam()).Kind() != reflect.Struct {
+		return fmt.Errorf("register %s: expected a single struct parameter, got %v", name, t)
+	}
+
+	nf.argsStruct = t.In(nf.firstParam())
+	for i := 0; i < nf.argsStruct.NumField(); i++ {
+		field := nf.argsStruct.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		argName, _, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		param := Param(argName)
+		switch def, ok := field.Tag.Lookup("default"); {
+		case ok:
+			param = Optional(param.Name, def) // Coerced from string like any other argument
+		case !strings.Contains(field.Tag.Get("validate"), "required"):
+			param = Optional(param.Name, nil) // Zero value
+		}
+		nf.params = append(nf.params, param)
+		nf.argsFields = append(nf.argsFields, i)
+	}
+	r.funcs[name] = nf
+	return nil
+}
+
+func newNamedFunc(fn interface{}) (*NamedFunc, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return nil, fmt.Errorf("not a function: %T", fn)
+	}
+	t := v.Type()
+	return &NamedFunc{fn: v, takesCtx: t.NumIn() > 0 && t.In(0) == contextType}, nil
+}
+
+func (nf *NamedFunc) firstParam() int {
+	if nf.takesCtx {
+		return 1
+	}
+	return 0
+}
+
+// CallNamed invokes the registered function, binding args by name
+func (r *NamedRegistry) CallNamed(name string, args map[string]interface{}) NamedResult {
+	return r.CallNamedContext(context.Background(), name, args)
+}
+
+// CallNamedContext is CallNamed with an explicit context for context-aware functions
+func (r *NamedRegistry) CallNamedContext(ctx context.Context, name string, args map[string]interface{}) NamedResult {
+	nf, ok := r.funcs[name]
+	if !ok {
+		return NamedResult{Err: fmt.Errorf("unknown function %q", name)}
+	}
+
+	values, err := nf.bind(args)
+	if err != nil {
+		return NamedResult{Err: fmt.Errorf("%s: %w", name, err)}
+	}
+
+	in := values
+	if nf.argsStruct != nil {
+		in = []reflect.Value{reflect.New(nf.argsStruct).Elem()}
+		for i, value := range values {
+			in[0].Field(nf.argsFields[i]).Set(value)
+		}
+	}
+	if nf.takesCtx {
+		in = append([]reflect.Value{reflect.ValueOf(ctx)}, in...)
+	}
+
+	var out []reflect.Value
+	if nf.fn.Type().IsVariadic() {
+		out = nf.fn.CallSlice(in)
+	} else {
+		out = nf.fn.Call(in)
+	}
+	return splitResults(out)
+}
+
+// bind resolves every parameter from args or its default, rejecting unknown names
+func (nf *NamedFunc) bind(args map[string]interface{}) ([]reflect.Value, error) {
+	known := make(map[string]bool, len(nf.params))
+	for _, p := range nf.params {
+		known[p.Name] = true
+	}
+	var unknown []string
+	for k := range args {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown argument(s) %s (accepted: %s)",
+			strings.Join(unknown, ", "), strings.Join(nf.paramNames(), ", "))
+	}
+
+	values := make([]reflect.Value, len(nf.params))
+	var errs []error
+	for i, p := range nf.params {
+		raw, present := args[p.Name]
+		if !present {
+			if !p.HasDefault {
+				errs = append(errs, fmt.Errorf("missing required argument %q", p.Name))
+				continue
+			}
+			raw = p.Default
+		}
+
+		value, err := CoerceValue(reflect.ValueOf(raw), nf.paramType(i))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("argument %q: %w", p.Name, err))
+			continue
+		}
+		values[i] = value
+	}
+	return values, errors.Join(errs...)
+}
+
+func (nf *NamedFunc) paramNames() []string {
+	names := make([]string, len(nf.params))
+	for i, p := range nf.params {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// paramType is the Go type the i-th named parameter is coerced to
+func (nf *NamedFunc) paramType(i int) reflect.Type {
+	if nf.argsStruct != nil {
+		return nf.argsStruct.Field(nf.argsFields[i]).Type
+	}
+	return nf.fn.Type().In(nf.firstParam() + i)
+}
+
+func splitResults(out []reflect.Value) NamedResult {
+	var result NamedResult
+	if n := len(out); n > 0 && out[n-1].Type() == errorType {
+		if !out[n-1].IsNil() {
+			result.Err = out[n-1].Interface().(error)
+		}
+		out = out[:n-1]
+	}
+	for _, v := range out {
+		result.Values = append(result.Values, v.Interface())
+	}
+	return result
+}
+
+// CallNamedAs calls a function and returns its first result as T
+func CallNamedAs[T any](r *NamedRegistry, name string, args map[string]interface{}) (T, error) {
+	var zero T
+	result := r.CallNamed(name, args)
+	if result.Err != nil {
+		return zero, result.Err
+	}
+	if len(result.Values) == 0 {
+		return zero, fmt.Errorf("%s returned no values", name)
+	}
+	typed, ok := result.Values[0].(T)
+	if !ok {
+		return zero, fmt.Errorf("%s returned %T, not %T", name, result.Values[0], zero)
+	}
+	return typed, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// reportArgs has a json:"-" field between two arguments, and an untagged
+// one that binds under its Go name
+type reportArgs struct {
+	Title  string `json:"title" validate:"required"`
+	Secret string `json:"-"`
+	Pages  int    `json:"pages,omitempty" default:"1"`
+	Draft  bool
+}
+
+func TestRegisterArgs(t *testing.T) {
+	registry := NewNamedRegistry()
+	if err := registry.RegisterArgs("report", func(args reportArgs) reportArgs { return args }); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(registry.funcs["report"].paramNames(), ","), "title,pages,Draft"; got != want {
+		t.Errorf("arguments = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    reportArgs
+		wantErr string
+	}{
+		{"defaults", map[string]interface{}{"title": "Q3"}, reportArgs{Title: "Q3", Pages: 1}, ""},
+		{"every argument", map[string]interface{}{"title": "Q3", "pages": "12", "Draft": true}, reportArgs{Title: "Q3", Pages: 12, Draft: true}, ""},
+		{"skipped field by json name", map[string]interface{}{"title": "Q3", "-": "x"}, reportArgs{}, "unknown argument(s) -"},
+		{"skipped field by Go name", map[string]interface{}{"title": "Q3", "Secret": "x"}, reportArgs{}, "unknown argument(s) Secret"},
+		{"missing required", map[string]interface{}{}, reportArgs{}, `missing required argument "title"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := registry.CallNamed("report", tt.args)
+			if tt.wantErr != "" {
+				if result.Err == nil || !strings.Contains(result.Err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", result.Err, tt.wantErr)
+				}
+				return
+			}
+			if result.Err != nil {
+				t.Fatal(result.Err)
+			}
+			if got := result.Values[0].(reportArgs); got != tt.want {
+				t.Errorf("bound %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
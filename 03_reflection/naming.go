@@ -0,0 +1,105 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Naming strategies: when a field has no tag, the serializer, binder and
+// mapper derive its external key from the Go field name. A NamingStrategy
+// makes that rule explicit and swappable instead of requiring exact names
+// (or a tag on every field) on both sides.
+
+// NamingStrategy converts a Go field name into an external key
+type NamingStrategy interface {
+	Name(field string) string
+}
+
+// NamingFunc adapts a function to NamingStrategy
+type NamingFunc func(field string) string
+
+// Name implements NamingStrategy
+func (f NamingFunc) Name(field string) string {
+	return f(field)
+}
+
+// Built-in strategies
+var (
+	ExactNaming     NamingStrategy = NamingFunc(func(s string) string { return s })
+	LowerNaming     NamingStrategy = NamingFunc(strings.ToLower)
+	SnakeNaming     NamingStrategy = NamingFunc(func(s string) string { return joinWords(splitWords(s), "_", strings.ToLower) })
+	KebabNaming     NamingStrategy = NamingFunc(func(s string) string { return joinWords(splitWords(s), "-", strings.ToLower) })
+	ScreamingNaming NamingStrategy = NamingFunc(func(s string) string { return joinWords(splitWords(s), "_", strings.ToUpper) })
+	CamelNaming     NamingStrategy = NamingFunc(camelCase)
+)
+
+// splitWords breaks a Go identifier into words, keeping acronyms together
+// (UserID -> User, ID; HTTPServer -> HTTP, Server). Digits stay with the word
+// before them, and existing _ or - separators are honored.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		boundary := false
+		switch {
+		case cur == '_' || cur == '-':
+			boundary = true
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			boundary = true // userID -> user|ID
+		case unicode.IsUpper(cur) && unicode.IsUpper(prev) && unicode.IsLower(next):
+			boundary = true // HTTPServer -> HTTP|Server
+		}
+		if boundary {
+			if word := strings.Trim(string(runes[start:i]), "_-"); word != "" {
+				words = append(words, word)
+			}
+			start = i
+		}
+	}
+	if word := strings.Trim(string(runes[start:]), "_-"); word != "" {
+		words = append(words, word)
+	}
+	return words
+}
+
+func joinWords(words []string, sep string, transform func(string) string) string {
+	for i, w := range words {
+		words[i] = transform(w)
+	}
+	return strings.Join(words, sep)
+}
+
+// camelCase lowercases the first word and capitalizes the rest: UserID -> userId
+func camelCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 && w != "" {
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		words[i] = w
+	}
+	return strings.Join(words, "")
+}
+
+// fieldKey returns the key named by the given tag, or the naming strategy's
+// key for the field when the tag does not name it
+func fieldKey(field reflect.StructField, tag string, naming NamingStrategy) string {
+	if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	if naming == nil {
+		naming = ExactNaming
+	}
+	return naming.Name(field.Name)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// namingStrategies are the strategies that split a field name into words
+var namingStrategies = []struct {
+	name   string
+	naming NamingStrategy
+}{
+	{"snake", SnakeNaming},
+	{"camel", CamelNaming},
+	{"kebab", KebabNaming},
+	{"screaming", ScreamingNaming},
+}
+
+func TestNamingStrategies(t *testing.T) {
+	tests := []struct {
+		field                          string
+		snake, camel, kebab, screaming string
+	}{
+		{"UserID", "user_id", "userId", "user-id", "USER_ID"},
+		{"userID", "user_id", "userId", "user-id", "USER_ID"},
+		{"HTTPServer", "http_server", "httpServer", "http-server", "HTTP_SERVER"},
+		{"APIKey", "api_key", "apiKey", "api-key", "API_KEY"},
+		{"CreatedAt", "created_at", "createdAt", "created-at", "CREATED_AT"},
+		{"Address2Line", "address2_line", "address2Line", "address2-line", "ADDRESS2_LINE"},
+		{"ID", "id", "id", "id", "ID"},
+		{"X", "x", "x", "x", "X"},
+		{"user_id", "user_id", "userId", "user-id", "USER_ID"},
+		{"max-retries", "max_retries", "maxRetries", "max-retries", "MAX_RETRIES"},
+		{"", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			want := map[string]string{"snake": tt.snake, "camel": tt.camel, "kebab": tt.kebab, "screaming": tt.screaming}
+			for _, st := range namingStrategies {
+				if got := st.naming.Name(tt.field); got != want[st.name] {
+					t.Errorf("%s(%q) = %q, want %q", st.name, tt.field, got, want[st.name])
+				}
+			}
+		})
+	}
+}
+
+// TestNamingStrategiesConvert checks that a key produced by one strategy
+// converts to the same key as the Go field name under every other strategy,
+// and that each strategy leaves its own output unchanged
+func TestNamingStrategiesConvert(t *testing.T) {
+	for _, field := range []string{"UserID", "HTTPServer", "APIKey", "CreatedAt", "Address2Line", "MaxRetries"} {
+		for _, from := range namingStrategies {
+			key := from.naming.Name(field)
+			for _, to := range namingStrategies {
+				if got, want := to.naming.Name(key), to.naming.Name(field); got != want {
+					t.Errorf("%s(%s(%q) = %q) = %q, want %q", to.name, from.name, field, key, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSerializerNamingRoundTrip(t *testing.T) {
+	account := apiAccount{UserID: 7, DisplayName: "Ada", APIKey: "k-123", MaxRetries: 3, Nickname: "ada"}
+	tests := []struct {
+		name     string
+		naming   NamingStrategy
+		wantKeys []string
+	}{
+		{"exact", ExactNaming, []string{"UserID", "DisplayName", "APIKey", "MaxRetries", "nick"}},
+		{"lower", LowerNaming, []string{"userid", "displayname", "apikey", "maxretries", "nick"}},
+		{"snake", SnakeNaming, []string{"user_id", "display_name", "api_key", "max_retries", "nick"}},
+		{"camel", CamelNaming, []string{"userId", "displayName", "apiKey", "maxRetries", "nick"}},
+		{"kebab", KebabNaming, []string{"user-id", "display-name", "api-key", "max-retries", "nick"}},
+		{"screaming", ScreamingNaming, []string{"USER_ID", "DISPLAY_NAME", "API_KEY", "MAX_RETRIES", "nick"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serializer := NewGenericSerializer().WithNaming(tt.naming)
+			data, err := serializer.Serialize(account)
+			if err != nil {
+				t.Fatalf("Serialize: %v", err)
+			}
+			if len(data) != len(tt.wantKeys) {
+				t.Errorf("Serialize produced %d keys, want %d: %v", len(data), len(tt.wantKeys), data)
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := data[key]; !ok {
+					t.Errorf("missing key %q in %v", key, data)
+				}
+			}
+
+			var back apiAccount
+			if err := serializer.Deserialize(data, &back); err != nil {
+				t.Fatalf("Deserialize: %v", err)
+			}
+			if !reflect.DeepEqual(back, account) {
+				t.Errorf("round trip = %+v, want %+v", back, account)
+			}
+		})
+	}
+}
+
+func TestConfigBinderNaming(t *testing.T) {
+	type tlsSettings struct {
+		CertFile string
+	}
+	type serverSettings struct {
+		ListenAddr     string
+		MaxConnections int
+		TLS            tlsSettings
+	}
+	tests := []struct {
+		name   string
+		binder *ConfigBinder
+		data   map[string]interface{}
+		want   serverSettings
+	}{
+		{
+			name:   "default lowercase",
+			binder: NewConfigBinder(),
+			data:   map[string]interface{}{"listenaddr": ":80", "maxconnections": "10", "tls.certfile": "a.pem"},
+			want:   serverSettings{ListenAddr: ":80", MaxConnections: 10, TLS: tlsSettings{CertFile: "a.pem"}},
+		},
+		{
+			name:   "default ignores kebab keys",
+			binder: NewConfigBinder(),
+			data:   map[string]interface{}{"listen-addr": ":80", "max-connections": "10"},
+			want:   serverSettings{},
+		},
+		{
+			name:   "kebab",
+			binder: NewConfigBinder().WithNaming(KebabNaming),
+			data:   map[string]interface{}{"listen-addr": ":8443", "max-connections": "512", "tls.cert-file": "b.pem"},
+			want:   serverSettings{ListenAddr: ":8443", MaxConnections: 512, TLS: tlsSettings{CertFile: "b.pem"}},
+		},
+		{
+			name:   "screaming",
+			binder: NewConfigBinder().WithNaming(ScreamingNaming),
+			data:   map[string]interface{}{"LISTEN_ADDR": ":9000", "MAX_CONNECTIONS": 3, "TLS.CERT_FILE": "c.pem"},
+			want:   serverSettings{ListenAddr: ":9000", MaxConnections: 3, TLS: tlsSettings{CertFile: "c.pem"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got serverSettings
+			if err := tt.binder.Bind(tt.data, &got); err != nil {
+				t.Fatalf("Bind: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Bind = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectMapperNaming(t *testing.T) {
+	type legacyUser struct {
+		UserId   int
+		FullName string
+	}
+	type userView struct {
+		UserID   int
+		FullName string
+	}
+	tests := []struct {
+		name   string
+		mapper *ObjectMapper
+		want   userView
+	}{
+		{"exact names only", NewObjectMapper(), userView{FullName: "Grace"}},
+		{"snake matching", NewObjectMapper().WithNaming(SnakeNaming), userView{UserID: 42, FullName: "Grace"}},
+		{"camel matching", NewObjectMapper().WithNaming(CamelNaming), userView{UserID: 42, FullName: "Grace"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got userView
+			if err := tt.mapper.Map(legacyUser{UserId: 42, FullName: "Grace"}, &got); err != nil {
+				t.Fatalf("Map: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Map = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
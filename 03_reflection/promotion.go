@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Promotion analyzer: Go resolves x.Name by searching embedded types breadth
+// first. The shallowest depth holding Name wins and shadows deeper members;
+// two members at that same depth make the selector ambiguous, which is a
+// compile error only when the selector is actually used. reflect silently
+// drops ambiguous members (FieldByName fails, the method set omits them), so
+// the analyzer rebuilds the search to show what happened and why.
+
+// PromotedMember is one field or method reachable from the analyzed type
+type PromotedMember struct {
+	Name  string
+	Kind  string   // "field" or "method"
+	Path  []string // Embedded fields walked to reach the member
+	Depth int
+	Type  string
+}
+
+// Selector renders the member the way it would be written in full
+func (m PromotedMember) Selector() string {
+	return strings.Join(append(append([]string{"x"}, m.Path...), m.Name), ".")
+}
+
+// Shadowing records a member that hides deeper members of the same name
+type Shadowing struct {
+	Winner PromotedMember
+	Hidden []PromotedMember
+}
+
+// Ambiguity records a name with several members at the shallowest depth
+type Ambiguity struct {
+	Name       string
+	Candidates []PromotedMember
+}
+
+// PromotionReport is the effective member set of a type with embedding
+type PromotionReport struct {
+	Type      reflect.Type
+	Resolved  []PromotedMember
+	Shadowed  []Shadowing
+	Ambiguous []Ambiguity
+}
+
+// AnalyzePromotion resolves every member name reachable from t
+func AnalyzePromotion(t reflect.Type) PromotionReport {
+	byName := make(map[string][]PromotedMember)
+	collectMembers(t, nil, 0, make(map[reflect.Type]bool), byName)
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := PromotionReport{Type: t}
+	for _, name := range names {
+		members := byName[name]
+		sort.SliceStable(members, func(i, j int) bool { return members[i].Depth < members[j].Depth })
+
+		shallowest := members[0].Depth
+		var winners, hidden []PromotedMember
+		for _, m := range members {
+			if m.Depth == shallowest {
+				winners = append(winners, m)
+			} else {
+				hidden = append(hidden, m)
+			}
+		}
+
+		if len(winners) > 1 {
+			report.Ambiguous = append(report.Ambiguous, Ambiguity{Name: name, Candidates: winners})
+			continue
+		}
+		report.Resolved = append(report.Resolved, winners[0])
+		if len(hidden) > 0 {
+			report.Shadowed = append(report.Shadowed, Shadowing{Winner: winners[0], Hidden: hidden})
+		}
+	}
+	return report
+}
+
+// collectMembers records the fields and declared methods of t at depth, then
+// descends into its embedded fields
+func collectMembers(t reflect.Type, path []string, depth int, visiting map[reflect.Type]bool, out map[string][]PromotedMember) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if visiting[t] {
+		return // Embedding cycles through pointers, e.g. type Node struct{ *Node }
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	add := func(m PromotedMember) {
+		m.Path, m.Depth = append([]string(nil), path...), depth
+		out[m.Name] = append(out[m.Name], m)
+	}
+
+	if t.Kind() == reflect.Interface {
+		for i := 0; i < t.NumMethod(); i++ {
+			m := t.Method(i)
+			add(PromotedMember{Name: m.Name, Kind: "method", Type: m.Type.String()})
+		}
+		return
+	}
+
+	for _, m := range declaredMethods(t) {
+		add(m)
+	}
+
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		add(PromotedMember{Name: f.Name, Kind: "field", Type: f.Type.String()})
+		if f.Anonymous {
+			collectMembers(f.Type, append(path, f.Name), depth+1, visiting, out)
+		}
+	}
+}
+
+// declaredMethods returns the methods written for t itself, excluding the
+// wrappers the compiler generates for promoted methods
+func declaredMethods(t reflect.Type) []PromotedMember {
+	if t.Name() == "" {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var methods []PromotedMember
+	for _, mt := range []reflect.Type{t, reflect.PointerTo(t)} {
+		for i := 0; i < mt.NumMethod(); i++ {
+			m := mt.Method(i)
+			if seen[m.Name] || isGeneratedWrapper(m.Func) {
+				continue
+			}
+			seen[m.Name] = true
+			// Drop the receiver from the signature
+			sig := strings.Replace(m.Type.String(), "("+mt.String()+", ", "(", 1)
+			sig = strings.Replace(sig, "("+mt.String()+")", "()", 1)
+			methods = append(methods, PromotedMember{Name: m.Name, Kind: "method", Type: sig})
+		}
+	}
+	return methods
+}
+
+// isGeneratedWrapper reports whether a method value is compiler-generated,
+// which is how promoted methods (and value methods on *T) are implemented
+func isGeneratedWrapper(fn reflect.Value) bool {
+	f := runtime.FuncForPC(fn.Pointer())
+	if f == nil {
+		return true
+	}
+	file, _ := f.FileLine(fn.Pointer())
+	return file == "<autogenerated>"
+}
+
+// Print writes the report, cross-checking each verdict against reflect
+func (r PromotionReport) Print() {
+	fmt.Printf("Promotion report for %v:\n", r.Type)
+
+	nameWidth, selectorWidth := 0, 0
+	for _, m := range r.Resolved {
+		nameWidth = max(nameWidth, len(m.Name))
+		selectorWidth = max(selectorWidth, len(m.Selector()))
+	}
+
+	fmt.Println("  Resolved members:")
+	for _, m := range r.Resolved {
+		note := ""
+		if m.Kind == "method" && m.Depth > 0 {
+			// Pointer-receiver methods of a value-embedded type need an addressable receiver
+			if _, ok := r.Type.MethodByName(m.Name); !ok {
+				note = fmt.Sprintf("  (method set of *%s only)", r.Type.Name())
+			}
+		}
+		fmt.Printf("    %-6s %-*s depth %d  %-*s  %s%s\n", m.Kind, nameWidth, m.Name, m.Depth,
+			selectorWidth, m.Selector(), m.Type, note)
+	}
+
+	if len(r.Shadowed) > 0 {
+		fmt.Println("  Shadowed:")
+		for _, s := range r.Shadowed {
+			for _, h := range s.Hidden {
+				fmt.Printf("    %s (depth %d) hides %s (depth %d); reach it explicitly as %s\n",
+					s.Winner.Selector(), s.Winner.Depth, h.Kind, h.Depth, h.Selector())
+			}
+		}
+	}
+
+	if len(r.Ambiguous) > 0 {
+		fmt.Println("  Ambiguous (using the selector would not compile):")
+		ptr := reflect.PointerTo(r.Type)
+		for _, a := range r.Ambiguous {
+			selectors := make([]string, len(a.Candidates))
+			for i, c := range a.Candidates {
+				selectors[i] = fmt.Sprintf("%s (%s)", c.Selector(), c.Kind)
+			}
+			_, fieldFound := r.Type.FieldByName(a.Name)
+			_, methodFound := ptr.MethodByName(a.Name)
+			fmt.Printf("    x.%s: %s\n", a.Name, strings.Join(selectors, " vs "))
+			fmt.Printf("      reflect agrees: FieldByName found=%v, method set has it=%v\n", fieldFound, methodFound)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Dynamic proxies used by the advanced topics examples. reflect.MakeFunc
+// builds a function of any signature from one generic body, which is
+// enough to wrap every call with interceptors. What reflect cannot do is
+// give a new type methods, so an interface proxy needs a stub written once
+// per interface: a struct with a <Method>Func field per method, whose
+// methods call those fields. NewProxy fills the fields at run time.
+
+// MethodCall is one call passing through the interceptors
+type MethodCall struct {
+	Method  string
+	Args    []reflect.Value
+	Results []reflect.Value // Set once next returns
+	fnType  reflect.Type
+}
+
+// Err returns the call's trailing error result, if it has one and the
+// call has returned
+func (c *MethodCall) Err() error {
+	if len(c.Results) == 0 || c.fnType.Out(c.fnType.NumOut()-1) != errorType {
+		return nil
+	}
+	err, _ := c.Results[len(c.Results)-1].Interface().(error)
+	return err
+}
+
+// Fail makes the call return err with zero values for its other results.
+// It reports false for a method with no trailing error to carry it.
+func (c *MethodCall) Fail(err error) bool {
+	n := c.fnType.NumOut()
+	if n == 0 || c.fnType.Out(n-1) != errorType {
+		return false
+	}
+	c.Results = make([]reflect.Value, n)
+	for i := 0; i < n-1; i++ {
+		c.Results[i] = reflect.Zero(c.fnType.Out(i))
+	}
+	c.Results[n-1] = reflect.ValueOf(&err).Elem()
+	return true
+}
+
+// String formats the call as Method(arg, arg)
+func (c *MethodCall) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = fmt.Sprintf("%#v", a.Interface())
+	}
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Interceptor runs around a call: code before next runs before it, code
+// after next sees its Results. Not calling next skips the call, in which
+// case the interceptor must set Results itself, e.g. with Fail.
+type Interceptor func(call *MethodCall, next func())
+
+// WrapFunc returns a function of fn's type that runs the interceptors,
+// first one outermost, around each call of fn
+func WrapFunc(name string, fn interface{}, interceptors ...Interceptor) interface{} {
+	return wrapFunc(name, reflect.ValueOf(fn), interceptors).Interface()
+}
+
+func wrapFunc(name string, fn reflect.Value, interceptors []Interceptor) reflect.Value {
+	fnType := fn.Type()
+	return reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		call := &MethodCall{Method: name, Args: args, fnType: fnType}
+
+		var run func(i int)
+		run = func(i int) {
+			if i == len(interceptors) {
+				if fnType.IsVariadic() {
+					call.Results = fn.CallSlice(call.Args)
+				} else {
+					call.Results = fn.Call(call.Args)
+				}
+				return
+			}
+			interceptors[i](call, func() { run(i + 1) })
+		}
+		run(0)
+
+		if len(call.Results) != fnType.NumOut() {
+			panic(fmt.Sprintf("proxy: %s returned no results; an interceptor skipped it without setting them", name))
+		}
+		return call.Results
+	})
+}
+
+// NewProxy fills stub, a pointer to a struct with a <Method>Func field
+// for each method of the interface T, with wrappers that run the
+// interceptors around the same method of target. It returns stub as a T.
+func NewProxy[T any](target, stub T, interceptors ...Interceptor) (T, error) {
+	iface := reflect.TypeOf((*T)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		return stub, fmt.Errorf("proxy: %v is not an interface", iface)
+	}
+	sv := reflect.ValueOf(stub)
+	if sv.Kind() != reflect.Ptr || sv.IsNil() || sv.Elem().Kind() != reflect.Struct {
+		return stub, fmt.Errorf("proxy: stub must be a non-nil pointer to a struct, got %T", stub)
+	}
+	tv := reflect.ValueOf(target)
+	if !tv.IsValid() {
+		return stub, fmt.Errorf("proxy: nil target")
+	}
+
+	for i := 0; i < iface.NumMethod(); i++ {
+		method := iface.Method(i)
+		field := sv.Elem().FieldByName(method.Name + "Func")
+		if !field.IsValid() || !field.CanSet() || field.Type() != method.Type {
+			return stub, fmt.Errorf("proxy: %T needs a field %sFunc of type %v", stub, method.Name, method.Type)
+		}
+		field.Set(wrapFunc(method.Name, tv.MethodByName(method.Name), interceptors))
+	}
+	return stub, nil
+}
+
+// LoggingInterceptor logs each call and what it returned
+func LoggingInterceptor(logf func(format string, args ...interface{})) Interceptor {
+	return func(call *MethodCall, next func()) {
+		logf("-> %v", call)
+		next()
+		results := make([]string, len(call.Results))
+		for i, r := range call.Results {
+			results[i] = fmt.Sprintf("%v", r.Interface())
+		}
+		logf("<- %s = %s", call.Method, strings.Join(results, ", "))
+	}
+}
+
+// TimingInterceptor reports how long each call took
+func TimingInterceptor(record func(method string, elapsed time.Duration)) Interceptor {
+	return func(call *MethodCall, next func()) {
+		start := time.Now()
+		defer func() { record(call.Method, time.Since(start)) }()
+		next()
+	}
+}
+
+// RecoverInterceptor turns a panic in the call into its error result.
+// Methods without a trailing error have nowhere to report it, so they
+// panic on as before.
+func RecoverInterceptor() Interceptor {
+	return func(call *MethodCall, next func()) {
+		defer func() {
+			if r := recover(); r != nil {
+				if !call.Fail(fmt.Errorf("%s panicked: %v", call.Method, r)) {
+					panic(r)
+				}
+			}
+		}()
+		next()
+	}
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Tag-driven query builder used by the reflection pattern examples, in the
+// spirit of sqlx and the lighter ORMs. A struct is a table: each field is a
+// column named by its `db` tag (or SnakeNaming of the field name), fields of
+// embedded structs are columns of the same table, and the options after the
+// name mark the primary key (pk) and columns the database fills (auto). The
+// builder writes SELECT, INSERT, UPDATE and DELETE statements with
+// placeholders, and ScanRows maps result rows back onto structs.
+
+// ErrUnknownColumn means a query or row names a column the struct lacks
+var ErrUnknownColumn = errors.New("query: unknown column")
+
+// TableNamer overrides the table name, which is otherwise the snake_case
+// plural of the type name
+type TableNamer interface {
+	TableName() string
+}
+
+// tableColumn is a column and the field behind it
+type tableColumn struct {
+	name  string
+	index []int // For FieldByIndex, through embedded structs
+	pk    bool
+	auto  bool
+}
+
+// tableInfo is the plan for one struct type
+type tableInfo struct {
+	name     string
+	columns  []*tableColumn
+	byColumn map[string]*tableColumn
+}
+
+var tableCache sync.Map // reflect.Type -> *tableInfo
+
+// tableOf returns the columns of struct type t, computed once per type
+func tableOf(t reflect.Type) (*tableInfo, error) {
+	if info, ok := tableCache.Load(t); ok {
+		return info.(*tableInfo), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query: %v is not a struct", t)
+	}
+
+	info := &tableInfo{byColumn: make(map[string]*tableColumn)}
+	info.name = SnakeNaming.Name(t.Name()) + "s"
+	if namer, ok := reflect.New(t).Interface().(TableNamer); ok {
+		info.name = namer.TableName()
+	}
+	if err := info.addColumns(t, nil); err != nil {
+		return nil, err
+	}
+	actual, _ := tableCache.LoadOrStore(t, info)
+	return actual.(*tableInfo), nil
+}
+
+func (info *tableInfo) addColumns(t reflect.Type, parent []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(parent[:len(parent):len(parent)], i)
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		// An embedded struct's exported fields are settable even when its
+		// own type is unexported
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && tag == "" {
+			if err := info.addColumns(field.Type, index); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = SnakeNaming.Name(field.Name)
+		}
+		col := &tableColumn{name: name, index: index}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "pk":
+				col.pk = true
+			case "auto":
+				col.auto = true
+			case "":
+			default:
+				return fmt.Errorf("query: field %s: unknown db option %q", field.Name, opt)
+			}
+		}
+		if _, dup := info.byColumn[name]; dup {
+			return fmt.Errorf("query: column %s is mapped twice", name)
+		}
+		info.columns = append(info.columns, col)
+		info.byColumn[name] = col
+	}
+	return nil
+}
+
+// Query is a statement and the arguments for its placeholders
+type Query struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Cond is one "column op value" term of a WHERE clause
+type Cond struct {
+	Column string
+	Op     string
+	Value  interface{}
+}
+
+// Where builds a condition; op is any SQL comparison such as "=" or ">="
+func Where(column, op string, value interface{}) Cond {
+	return Cond{Column: column, Op: op, Value: value}
+}
+
+// QueryBuilder writes statements with "?" placeholders, or "$1", "$2"...
+// for PostgreSQL
+type QueryBuilder struct {
+	numbered bool
+}
+
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// WithNumberedPlaceholders switches to PostgreSQL's $1, $2...
+func (qb *QueryBuilder) WithNumberedPlaceholders() *QueryBuilder {
+	qb.numbered = true
+	return qb
+}
+
+// statement accumulates SQL text and arguments
+type statement struct {
+	qb   *QueryBuilder
+	sql  strings.Builder
+	args []interface{}
+}
+
+func (s *statement) arg(value interface{}) string {
+	s.args = append(s.args, value)
+	if s.qb.numbered {
+		return "$" + strconv.Itoa(len(s.args))
+	}
+	return "?"
+}
+
+func (s *statement) where(info *tableInfo, conds []Cond) error {
+	for i, c := range conds {
+		if _, ok := info.byColumn[c.Column]; !ok {
+			return fmt.Errorf("%w %s in table %s", ErrUnknownColumn, c.Column, info.name)
+		}
+		if i == 0 {
+			s.sql.WriteString(" WHERE ")
+		} else {
+			s.sql.WriteString(" AND ")
+		}
+		fmt.Fprintf(&s.sql, "%s %s %s", c.Column, c.Op, s.arg(c.Value))
+	}
+	return nil
+}
+
+func (s *statement) query() Query {
+	return Query{SQL: s.sql.String(), Args: s.args}
+}
+
+// modelOf returns the table of model, a struct or pointer to one, and
+// the struct value itself
+func modelOf(model interface{}) (*tableInfo, reflect.Value, error) {
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() == reflect.Ptr {
+		return nil, reflect.Value{}, fmt.Errorf("query: nil model")
+	}
+	info, err := tableOf(v.Type())
+	return info, v, err
+}
+
+// Select reads every column of model's table, filtered by conds
+func (qb *QueryBuilder) Select(model interface{}, conds ...Cond) (Query, error) {
+	info, _, err := modelOf(model)
+	if err != nil {
+		return Query{}, err
+	}
+	s := &statement{qb: qb}
+	names := make([]string, len(info.columns))
+	for i, col := range info.columns {
+		names[i] = col.name
+	}
+	fmt.Fprintf(&s.sql, "SELECT %s FROM %s", strings.Join(names, ", "), info.name)
+	if err := s.where(info, conds); err != nil {
+		return Query{}, err
+	}
+	return s.query(), nil
+}
+
+// Insert writes obj's columns, except the auto ones the database fills
+func (qb *QueryBuilder) Insert(obj interface{}) (Query, error) {
+	info, v, err := modelOf(obj)
+	if err != nil {
+		return Query{}, err
+	}
+	s := &statement{qb: qb}
+	var names, marks []string
+	for _, col := range info.columns {
+		if col.auto {
+			continue
+		}
+		names = append(names, col.name)
+		marks = append(marks, s.arg(fieldArg(v, col)))
+	}
+	fmt.Fprintf(&s.sql, "INSERT INTO %s (%s) VALUES (%s)", info.name, strings.Join(names, ", "), strings.Join(marks, ", "))
+	return s.query(), nil
+}
+
+// Update writes obj's columns, or only the named ones, to the row with
+// obj's primary key. Keys and auto columns are never set.
+func (qb *QueryBuilder) Update(obj interface{}, columns ...string) (Query, error) {
+	info, v, err := modelOf(obj)
+	if err != nil {
+		return Query{}, err
+	}
+	only := make(map[string]bool, len(columns))
+	for _, name := range columns {
+		if _, ok := info.byColumn[name]; !ok {
+			return Query{}, fmt.Errorf("%w %s in table %s", ErrUnknownColumn, name, info.name)
+		}
+		only[name] = true
+	}
+
+	s := &statement{qb: qb}
+	var sets []string
+	for _, col := range info.columns {
+		if col.pk || col.auto || (len(only) > 0 && !only[col.name]) {
+			continue
+		}
+		sets = append(sets, col.name+" = "+s.arg(fieldArg(v, col)))
+	}
+	if len(sets) == 0 {
+		return Query{}, fmt.Errorf("query: nothing to update in %s", info.name)
+	}
+	conds := keyConds(info, v)
+	if len(conds) == 0 {
+		return Query{}, fmt.Errorf("query: %s has no pk column to update by", info.name)
+	}
+	fmt.Fprintf(&s.sql, "UPDATE %s SET %s", info.name, strings.Join(sets, ", "))
+	if err := s.where(info, conds); err != nil {
+		return Query{}, err
+	}
+	return s.query(), nil
+}
+
+// Delete removes the row with obj's primary key
+func (qb *QueryBuilder) Delete(obj interface{}) (Query, error) {
+	info, v, err := modelOf(obj)
+	if err != nil {
+		return Query{}, err
+	}
+	conds := keyConds(info, v)
+	if len(conds) == 0 {
+		return Query{}, fmt.Errorf("query: %s has no pk column to delete by", info.name)
+	}
+	s := &statement{qb: qb}
+	fmt.Fprintf(&s.sql, "DELETE FROM %s", info.name)
+	if err := s.where(info, conds); err != nil {
+		return Query{}, err
+	}
+	return s.query(), nil
+}
+
+func keyConds(info *tableInfo, v reflect.Value) []Cond {
+	var conds []Cond
+	for _, col := range info.columns {
+		if col.pk {
+			conds = append(conds, Where(col.name, "=", fieldArg(v, col)))
+		}
+	}
+	return conds
+}
+
+// fieldArg is the argument for a column: the field's value, with nil
+// pointers as nil so they become NULL
+func fieldArg(v reflect.Value, col *tableColumn) interface{} {
+	f := v.FieldByIndex(col.index)
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return nil
+		}
+		f = f.Elem()
+	}
+	return f.Interface()
+}
+
+// ScanRows stores rows, as a driver would return them keyed by column,
+// into dest: a pointer to a slice of structs or struct pointers, or to a
+// single struct for the first row. Values are coerced into the field
+// types, and NULLs (nil) leave pointer fields nil.
+func ScanRows(rows []map[string]interface{}, dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("query: scan destination must be a non-nil pointer, got %T", dest)
+	}
+	dv = dv.Elem()
+
+	if dv.Kind() == reflect.Struct {
+		if len(rows) == 0 {
+			return fmt.Errorf("query: no rows")
+		}
+		return scanRow(rows[0], dv)
+	}
+	if dv.Kind() != reflect.Slice {
+		return fmt.Errorf("query: scan destination must point to a struct or slice, got %T", dest)
+	}
+
+	elemType := dv.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	out := reflect.MakeSlice(dv.Type(), 0, len(rows))
+	for i, row := range rows {
+		elem := reflect.New(elemType)
+		if err := scanRow(row, elem.Elem()); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		if isPtr {
+			out = reflect.Append(out, elem)
+		} else {
+			out = reflect.Append(out, elem.Elem())
+		}
+	}
+	dv.Set(out)
+	return nil
+}
+
+func scanRow(row map[string]interface{}, v reflect.Value) error {
+	info, err := tableOf(v.Type())
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(row))
+	for name := range row {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Report the same column first on every run
+	for _, name := range names {
+		value := row[name]
+		col, ok := info.byColumn[name]
+		if !ok {
+			return fmt.Errorf("%w %s in table %s", ErrUnknownColumn, name, info.name)
+		}
+		if err := CoerceInto(v.FieldByIndex(col.index), reflect.ValueOf(value)); err != nil {
+			return fmt.Errorf("query: column %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// JSON-RPC layer over HandlerRegistry used by the function reflection
+// examples. Any registered function shaped func([context.Context,] [P])
+// ([R,] error) is a method: the server reads its signature to decode
+// "params" into a new P, calls it with the request's context, and encodes
+// R as "result" or the error as "error". The wire format is JSON-RPC 2.0,
+// single requests only; params and results go through the reflection
+// codec from jsoncodec.go, the envelope through encoding/json.
+
+// Error codes reserved by JSON-RPC 2.0
+const (
+	RPCParseError     = -32700
+	RPCInvalidRequest = -32600
+	RPCMethodNotFound = -32601
+	RPCInvalidParams  = -32602
+	RPCInternalError  = -32603
+	RPCServerError    = -32000 // A handler returned an error that is not an *RPCError
+)
+
+// RPCError is the "error" member of a response. Handlers return one to
+// choose the code; any other error is sent as RPCServerError.
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// rpcMethod is what the server needs from a handler's signature
+type rpcMethod struct {
+	fn       reflect.Value
+	takesCtx bool
+	params   reflect.Type // Nil when the handler takes no params
+	result   reflect.Type // Nil when the handler only returns an error
+}
+
+func newRPCMethod(fn reflect.Value) (*rpcMethod, error) {
+	t := fn.Type()
+	if t.Kind() != reflect.Func {
+		return nil, fmt.Errorf("handler must be a function, got %v", t)
+	}
+	m := &rpcMethod{fn: fn}
+
+	in := 0
+	if in < t.NumIn() && t.In(in) == contextType {
+		m.takesCtx = true
+		in++
+	}
+	if in < t.NumIn() {
+		m.params = t.In(in)
+		in++
+	}
+	if in != t.NumIn() || t.IsVariadic() {
+		return nil, fmt.Errorf("handler takes at most a context and one params value, got %v", t)
+	}
+
+	switch {
+	case t.NumOut() == 1 && t.Out(0) == errorType:
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+		m.result = t.Out(0)
+	default:
+		return nil, fmt.Errorf("handler must return error or (R, error), got %v", t)
+	}
+	return m, nil
+}
+
+// call decodes params, calls the handler and encodes its result
+func (m *rpcMethod) call(ctx context.Context, params json.RawMessage) (result json.RawMessage, rpcErr *RPCError) {
+	args := make([]reflect.Value, 0, 2)
+	if m.takesCtx {
+		args = append(args, reflect.ValueOf(ctx))
+	}
+	if m.params != nil {
+		if len(params) == 0 {
+			return nil, &RPCError{Code: RPCInvalidParams, Message: fmt.Sprintf("missing params of type %v", m.params)}
+		}
+		p := reflect.New(m.params)
+		if err := DecodeJSON(params, p.Interface()); err != nil {
+			return nil, &RPCError{Code: RPCInvalidParams, Message: err.Error()}
+		}
+		args = append(args, p.Elem())
+	}
+
+	// A panicking handler fails its own request, not the server
+	defer func() {
+		if r := recover(); r != nil {
+			result, rpcErr = nil, &RPCError{Code: RPCInternalError, Message: fmt.Sprintf("handler panicked: %v", r)}
+		}
+	}()
+	out := m.fn.Call(args)
+
+	if err, _ := out[len(out)-1].Interface().(error); err != nil {
+		var custom *RPCError
+		if errors.As(err, &custom) {
+			return nil, custom
+		}
+		return nil, &RPCError{Code: RPCServerError, Message: err.Error()}
+	}
+	if m.result == nil {
+		return json.RawMessage("null"), nil
+	}
+	data, err := EncodeJSON(out[0].Interface())
+	if err != nil {
+		return nil, &RPCError{Code: RPCInternalError, Message: err.Error()}
+	}
+	return data, nil
+}
+
+// RegisterMethod adds a handler of any shape the RPC server accepts.
+// Event handlers from Register are methods too, taking a string.
+func (hr *HandlerRegistry) RegisterMethod(name string, handler interface{}) error {
+	if _, err := newRPCMethod(reflect.ValueOf(handler)); err != nil {
+		return fmt.Errorf("register %s: %w", name, err)
+	}
+	hr.handlers[name] = reflect.ValueOf(handler)
+	return nil
+}
+
+// RPCServer serves the methods of a HandlerRegistry: POST a JSON-RPC
+// request to call one, GET to list them
+type RPCServer struct {
+	registry *HandlerRegistry
+}
+
+func NewRPCServer(registry *HandlerRegistry) *RPCServer {
+	return &RPCServer{registry: registry}
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// RPCMethodInfo describes a method for the GET listing
+type RPCMethodInfo struct {
+	Name   string `json:"name"`
+	Params string `json:"params,omitempty"`
+	Result string `json:"result,omitempty"`
+}
+
+func (s *RPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(s.Methods())
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := rpcResponse{JSONRPC: "2.0"}
+	var req rpcRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	switch {
+	case err != nil || json.Unmarshal(body, &req) != nil:
+		resp.Error = &RPCError{Code: RPCParseError, Message: "request body is not valid JSON"}
+	case req.JSONRPC != "2.0" || req.Method == "":
+		resp.ID = req.ID
+		resp.Error = &RPCError{Code: RPCInvalidRequest, Message: `want {"jsonrpc": "2.0", "method": ...}`}
+	default:
+		resp.ID = req.ID
+		resp.Result, resp.Error = s.call(r.Context(), req.Method, req.Params)
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *RPCServer) call(ctx context.Context, name string, params json.RawMessage) (json.RawMessage, *RPCError) {
+	handler, ok := s.registry.handlers[name]
+	if !ok {
+		return nil, &RPCError{Code: RPCMethodNotFound, Message: "no method " + name}
+	}
+	m, err := newRPCMethod(handler)
+	if err != nil {
+		return nil, &RPCError{Code: RPCInternalError, Message: err.Error()}
+	}
+	if string(params) == "null" {
+		params = nil
+	}
+	return m.call(ctx, params)
+}
+
+// Methods lists the registry's methods by name, with the Go types of
+// their params and results
+func (s *RPCServer) Methods() []RPCMethodInfo {
+	var infos []RPCMethodInfo
+	for name, handler := range s.registry.handlers {
+		info := RPCMethodInfo{Name: name}
+		if m, err := newRPCMethod(handler); err == nil {
+			if m.params != nil {
+				info.Params = m.params.String()
+			}
+			if m.result != nil {
+				info.Result = m.result.String()
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// RPCClient calls the methods of an RPCServer
+type RPCClient struct {
+	URL    string
+	HTTP   *http.Client
+	lastID int64
+}
+
+func NewRPCClient(url string) *RPCClient {
+	return &RPCClient{URL: url, HTTP: http.DefaultClient}
+}
+
+// Call calls method with params and decodes its result into result, a
+// pointer, unless result is nil. A method's own failure is an *RPCError.
+func (c *RPCClient) Call(ctx context.Context, method string, params, result interface{}) error {
+	req := map[string]interface{}{"jsonrpc": "2.0", "method": method, "id": atomic.AddInt64(&c.lastID, 1)}
+	if params != nil {
+		data, err := EncodeJSON(params)
+		if err != nil {
+			return err
+		}
+		req["params"] = json.RawMessage(data)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := c.HTTP.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	var resp rpcResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return fmt.Errorf("rpc: bad response to %s: %w", method, err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return DecodeJSON(resp.Result, result)
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Struct diff and patch used by the reflection pattern examples. Diff lists
+// every exported field that changed between two values of one type, by
+// path ("Address.City", "Tags[2]", `Meta["team"]`), and ApplyPatch sets
+// values by the same paths, so a diff can travel as a map of path to new
+// value and be replayed on another copy. DeepDiff in deepcopy.go stops at
+// the first difference; Diff keeps going.
+
+var (
+	// ErrPatchPath is returned for a path that does not lead to a settable value
+	ErrPatchPath = errors.New("patch: invalid path")
+	// ErrPatchValue is returned for a value the field at the path cannot hold
+	ErrPatchValue = errors.New("patch: invalid value")
+)
+
+// FieldChange is one difference found by Diff. Old or New is nil when
+// the value does not exist on that side: a slice element past the end, a
+// map key that is missing, or a nil pointer.
+type FieldChange struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+func (c FieldChange) String() string {
+	show := func(v interface{}) string {
+		switch v := v.(type) {
+		case nil:
+			return "<none>"
+		case string:
+			return strconv.Quote(v)
+		}
+		return fmt.Sprintf("%v", v)
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Path, show(c.Old), show(c.New))
+}
+
+// Diff returns the changes that turn old into new, in field order, with
+// map keys sorted. Pointers are followed, so only the values they point
+// to are compared; unexported fields are not compared, and structs without
+// exported fields, such as time.Time, are compared whole. Values of
+// different types are reported as a single change at "(root)".
+func Diff(old, new interface{}) []FieldChange {
+	a, b := reflect.ValueOf(old), reflect.ValueOf(new)
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		if reflect.DeepEqual(old, new) {
+			return nil
+		}
+		return []FieldChange{{Path: "(root)", Old: old, New: new}}
+	}
+	d := &differ{visited: make(map[visitPair]bool)}
+	d.diff("", a, b)
+	return d.changes
+}
+
+type differ struct {
+	changes []FieldChange
+	visited map[visitPair]bool // Pointer pairs already compared, against cycles
+}
+
+func (d *differ) add(path string, old, new interface{}) {
+	if path == "" {
+		path = "(root)"
+	}
+	d.changes = append(d.changes, FieldChange{Path: path, Old: old, New: new})
+}
+
+// valueOf returns v for a FieldChange, nil for what does not exist
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return v.Elem().Interface() // The value is what changed, not its address
+	}
+	return v.Interface()
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, valueOf(a), valueOf(b))
+			}
+			return
+		}
+		if a.Kind() == reflect.Interface {
+			if a.Elem().Type() != b.Elem().Type() {
+				d.add(path, a.Interface(), b.Interface())
+				return
+			}
+		} else {
+			pair := visitPair{visitKeyOf(a), visitKeyOf(b)}
+			if pair.a == pair.b || d.visited[pair] {
+				return
+			}
+			d.visited[pair] = true
+		}
+		d.diff(path, a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		t := a.Type()
+		exported := 0
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			exported++
+			name := t.Field(i).Name
+			if path != "" {
+				name = path + "." + name
+			}
+			d.diff(name, a.Field(i), b.Field(i))
+		}
+		if exported == 0 && a.CanInterface() && !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(path, a.Interface(), b.Interface())
+		}
+
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() && a.Len() == 0 && b.Len() == 0 {
+			d.add(path, a.Interface(), b.Interface()) // nil versus empty
+			return
+		}
+		for i := 0; i < max(a.Len(), b.Len()); i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= a.Len():
+				d.add(elemPath, nil, valueOf(b.Index(i)))
+			case i >= b.Len():
+				d.add(elemPath, valueOf(a.Index(i)), nil)
+			default:
+				d.diff(elemPath, a.Index(i), b.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		keys := a.MapKeys()
+		for _, k := range b.MapKeys() {
+			if !a.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			keyPath := path + patchKey(k)
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			if !av.IsValid() || !bv.IsValid() {
+				d.add(keyPath, valueOf(av), valueOf(bv))
+				continue
+			}
+			d.diff(keyPath, av, bv)
+		}
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			d.add(path, a.Interface(), b.Interface())
+		}
+
+	default:
+		if a.Interface() != b.Interface() {
+			d.add(path, a.Interface(), b.Interface())
+		}
+	}
+}
+
+// patchKey formats a map key as a path segment: ["name"] or [42]
+func patchKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return "[" + strconv.Quote(k.String()) + "]"
+	}
+	return fmt.Sprintf("[%v]", k.Interface())
+}
+
+// PatchOf turns changes into the patch ApplyPatch takes: each path mapped
+// to its new value
+func PatchOf(changes []FieldChange) map[string]interface{} {
+	patch := make(map[string]interface{}, len(changes))
+	for _, c := range changes {
+		patch[c.Path] = c.New
+	}
+	return patch
+}
+
+// patchSegment is one step of a path: a field name or a bracketed index
+// or key
+type patchSegment struct {
+	field   string
+	key     string // Index or map key, unquoted
+	bracket bool
+}
+
+func parsePatchPath(path string) ([]patchSegment, error) {
+	var segs []patchSegment
+	for rest := path; rest != ""; {
+		switch {
+		case rest[0] == '[':
+			var key string
+			end := strings.IndexByte(rest, ']')
+			if len(rest) > 1 && rest[1] == '"' {
+				quoted, err := strconv.QuotedPrefix(rest[1:])
+				if err != nil || !strings.HasPrefix(rest[1+len(quoted):], "]") {
+					return nil, fmt.Errorf("%w %q: bad quoted key", ErrPatchPath, path)
+				}
+				key, _ = strconv.Unquote(quoted)
+				end = 1 + len(quoted)
+			} else if end < 0 {
+				return nil, fmt.Errorf("%w %q: missing ]", ErrPatchPath, path)
+			} else {
+				key = rest[1:end]
+			}
+			segs = append(segs, patchSegment{key: key, bracket: true})
+			rest = rest[end+1:]
+		case rest[0] == '.' && len(segs) > 0 && len(rest) > 1 && rest[1] != '.' && rest[1] != '[':
+			rest = rest[1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("%w %q: empty field name", ErrPatchPath, path)
+			}
+			segs = append(segs, patchSegment{field: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	return segs, nil
+}
+
+// ApplyPatch sets the values in patch on the value obj points to, by Diff's
+// paths. Nil pointers and maps on the way are allocated, an index one past
+// the end of a slice appends, and a nil value clears: it truncates a slice
+// at that index, deletes a map key, or zeroes a field. The error joins
+// every path that failed; the others are still applied.
+func ApplyPatch(obj interface{}, patch map[string]interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("patch: target must be a non-nil pointer, got %T", obj)
+	}
+
+	// Parse every path first, then apply them in order, indexes compared as
+	// numbers so [2] is appended before [10]
+	type parsed struct {
+		path string
+		segs []patchSegment
+	}
+	var todo []parsed
+	var errs []error
+	for path := range patch {
+		if path == "(root)" {
+			todo = append(todo, parsed{path: path})
+			continue
+		}
+		segs, err := parsePatchPath(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		todo = append(todo, parsed{path, segs})
+	}
+	sort.Slice(todo, func(i, j int) bool { return lessPatchPath(todo[i].segs, todo[j].segs) })
+
+	for _, p := range todo {
+		if err := setPatchPath(v.Elem(), p.segs, patch[p.path]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func lessPatchPath(a, b []patchSegment) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		x, errX := strconv.Atoi(a[i].key)
+		y, errY := strconv.Atoi(b[i].key)
+		if a[i].bracket && b[i].bracket && errX == nil && errY == nil {
+			return x < y
+		}
+		return a[i].field+a[i].key < b[i].field+b[i].key
+	}
+	return len(a) < len(b)
+}
+
+// setPatchPath sets value at segs below v, which is settable. Map
+// elements cannot be set in place, so a path through a map copies the
+// element out, patches the copy and stores it back.
+func setPatchPath(v reflect.Value, segs []patchSegment, value interface{}) error {
+	for v.Kind() == reflect.Ptr && len(segs) > 0 {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if len(segs) == 0 {
+		if value == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		converted, err := CoerceValue(reflect.ValueOf(value), v.Type())
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrPatchValue, err)
+		}
+		v.Set(converted)
+		return nil
+	}
+
+	seg, rest := segs[0], segs[1:]
+	switch {
+	case !seg.bracket:
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: field %s of a %v", ErrPatchPath, seg.field, v.Type())
+		}
+		f, ok := v.Type().FieldByName(seg.field)
+		if !ok || !f.IsExported() {
+			return fmt.Errorf("%w: %v has no field %s", ErrPatchPath, v.Type(), seg.field)
+		}
+		return setPatchPath(v.FieldByIndex(f.Index), rest, value)
+
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		i, err := strconv.Atoi(seg.key)
+		if err != nil || i < 0 {
+			return fmt.Errorf("%w: index %q", ErrPatchPath, seg.key)
+		}
+		if v.Kind() == reflect.Slice && value == nil && len(rest) == 0 {
+			if i < v.Len() {
+				v.SetLen(i) // Clearing an element removes it and everything after
+			}
+			return nil
+		}
+		if v.Kind() == reflect.Slice && i == v.Len() {
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		}
+		if i >= v.Len() {
+			return fmt.Errorf("%w: index %d out of range [0:%d]", ErrPatchPath, i, v.Len())
+		}
+		return setPatchPath(v.Index(i), rest, value)
+
+	case v.Kind() == reflect.Map:
+		key, err := CoerceValue(reflect.ValueOf(seg.key), v.Type().Key())
+		if err != nil {
+			return fmt.Errorf("%w: map key %q: %v", ErrPatchPath, seg.key, err)
+		}
+		if value == nil && len(rest) == 0 {
+			if !v.IsNil() {
+				v.SetMapIndex(key, reflect.Value{})
+			}
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := setPatchPath(elem, rest, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return fmt.Errorf("%w: [%s] on a %v", ErrPatchPath, seg.key, v.Type())
+}
@@ -108,34 +108,35 @@ func Transform[T, U, V, W any](
 // Real-World Examples
 // ==========================================
 
-// Optional represents a value that may or may not be present (like Rust's Option)
-type Optional[T any] struct {
+// Option represents a value that may or may not be present (like Rust's Option).
+// Combinators and conversions live in 31_option.go.
+type Option[T any] struct {
 	value    T
 	hasValue bool
 }
 
-// Some creates an Optional with a value
-func Some[T any](value T) Optional[T] {
-	return Optional[T]{value: value, hasValue: true}
+// Some creates an Option with a value
+func Some[T any](value T) Option[T] {
+	return Option[T]{value: value, hasValue: true}
 }
 
-// None creates an empty Optional
-func None[T any]() Optional[T] {
-	return Optional[T]{hasValue: false}
+// None creates an empty Option
+func None[T any]() Option[T] {
+	return Option[T]{hasValue: false}
 }
 
-// IsSome checks if the Optional has a value
-func (o Optional[T]) IsSome() bool {
+// IsSome checks if the Option has a value
+func (o Option[T]) IsSome() bool {
 	return o.hasValue
 }
 
-// IsNone checks if the Optional is empty
-func (o Optional[T]) IsNone() bool {
+// IsNone checks if the Option is empty
+func (o Option[T]) IsNone() bool {
 	return !o.hasValue
 }
 
 // Unwrap returns the value (panics if None)
-func (o Optional[T]) Unwrap() T {
+func (o Option[T]) Unwrap() T {
 	if !o.hasValue {
 		panic("called Unwrap on a None value")
 	}
@@ -143,7 +144,7 @@ func (o Optional[T]) Unwrap() T {
 }
 
 // UnwrapOr returns the value or a default
-func (o Optional[T]) UnwrapOr(defaultValue T) T {
+func (o Option[T]) UnwrapOr(defaultValue T) T {
 	if o.hasValue {
 		return o.value
 	}
@@ -277,7 +278,7 @@ func runBasicGenericsExample() {
 	)
 	fmt.Printf("Transformed: (%v, %v)\n", transformed.First, transformed.Second)
 
-	fmt.Println("\n🔸 Optional Type (Rust-like)")
+	fmt.Println("\n🔸 Option Type (Rust-like)")
 	someValue := Some(42)
 	noneValue := None[int]()
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 )
 
 // ==========================================
@@ -96,10 +97,12 @@ func Multiply[T Number](a, b T) T {
 	return a * b
 }
 
-// Abs returns the absolute value (works only with signed numbers)
-func Abs[T Signed](x T) T {
-	if x < 0 {
-		return -x
+// Abs returns the absolute value (works only with signed numbers). For the
+// most negative integer it wraps back to itself; CheckedAbs (37_numeric.go)
+// reports that instead.
+func Abs[T Signed | Float](x T) T {
+	if x <= 0 {
+		return 0 - x // 0 - x rather than -x, so Abs(-0.0) is +0
 	}
 	return x
 }
@@ -243,17 +246,12 @@ func (sm SerializableMap[K, V]) Add(key K, value V) {
 
 // String returns a string representation of the map
 func (sm SerializableMap[K, V]) String() string {
-	result := "SerializableMap{"
-	first := true
+	// Keys are only comparable, so order entries by their printed form
+	entries := make([]string, 0, len(sm))
 	for k, v := range sm {
-		if !first {
-			result += ", "
-		}
-		result += fmt.Sprintf("%v: %s", k, v.String())
-		first = false
+		entries = append(entries, fmt.Sprintf("%v: %s", k, v.String()))
 	}
-	result += "}"
-	return result
+	return "SerializableMap{" + strings.Join(SortedBy(entries, CmpOrdered[string]), ", ") + "}"
 }
 
 // ==========================================
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
 // ==========================================
@@ -342,62 +343,6 @@ func (r Result[T]) Map(fn func(T) T) Result[T] {
 	return Err[T](r.err)
 }
 
-// ==========================================
-// Generic Cache with TTL
-// ==========================================
-
-// CacheItem represents a cached item with expiration
-type CacheItem[T any] struct {
-	Value   T
-	Expires int64
-}
-
-// Cache represents a generic cache with TTL
-type Cache[K comparable, V any] struct {
-	items map[K]CacheItem[V]
-	mu    sync.RWMutex
-}
-
-// NewCache creates a new cache
-func NewCache[K comparable, V any]() *Cache[K, V] {
-	return &Cache[K, V]{
-		items: make(map[K]CacheItem[V]),
-	}
-}
-
-// Set stores a value with TTL (in seconds)
-func (c *Cache[K, V]) Set(key K, value V, ttl int64) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	expires := int64(0)
-	if ttl > 0 {
-		expires = ttl // Simplified: would use time.Now().Unix() + ttl in real implementation
-	}
-
-	c.items[key] = CacheItem[V]{Value: value, Expires: expires}
-}
-
-// Get retrieves a value from cache
-func (c *Cache[K, V]) Get(key K) (V, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	item, exists := c.items[key]
-	if !exists {
-		var zero V
-		return zero, false
-	}
-
-	// Simplified TTL check (would check against current time in real implementation)
-	if item.Expires > 0 {
-		// In real implementation: if time.Now().Unix() > item.Expires
-		// For demo purposes, we'll just return the value
-	}
-
-	return item.Value, true
-}
-
 // ==========================================
 // Method Receivers with Type Parameters
 // ==========================================
@@ -520,9 +465,9 @@ func runGenericTypesExample() {
 	}
 
 	fmt.Printf("Map size: %d\n", userMap.Size())
-	fmt.Printf("All keys: %v\n", userMap.Keys())
+	fmt.Printf("All keys: %v\n", SortedKeys(userMap, CmpOrdered[string]))
 
-	userMap.ForEach(func(name string, age int) {
+	IterateSorted(userMap, CmpOrdered[string], func(name string, age int) {
 		fmt.Printf("User %s is %d years old\n", name, age)
 	})
 
@@ -546,9 +491,10 @@ func runGenericTypesExample() {
 
 	fmt.Println("\n🔸 Generic Cache")
 
+	// Cache is implemented in 27_cache.go, with expiry and eviction
 	cache := NewCache[string, int]()
-	cache.Set("user:123", 25, 3600)
-	cache.Set("user:456", 30, 7200)
+	cache.Set("user:123", 25, time.Hour)
+	cache.Set("user:456", 30, 2*time.Hour)
 
 	if age, found := cache.Get("user:123"); found {
 		fmt.Printf("Cached age for user:123: %d\n", age)
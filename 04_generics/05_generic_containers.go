@@ -318,28 +318,78 @@ func (t *Trie[T]) collectWords(node *TrieNode[T], currentWord string, result *[]
 // Generic Graph Implementation
 // ==========================================
 
-// Graph represents a generic directed graph
+// Graph represents a generic graph, directed unless created with
+// NewUndirectedGraph. Edges carry a weight, 1 unless given.
 type Graph[T comparable] struct {
-	vertices map[T][]T
+	vertices   map[T][]T
+	weights    map[Edge[T]]float64
+	order      []T // Vertices in the order they were added
+	undirected bool
 }
 
-// NewGraph creates a new graph
+// Edge is a pair of vertices; in an undirected graph it is stored both ways
+type Edge[T comparable] struct {
+	From, To T
+}
+
+// NewGraph creates a new directed graph
 func NewGraph[T comparable]() *Graph[T] {
-	return &Graph[T]{vertices: make(map[T][]T)}
+	return &Graph[T]{vertices: make(map[T][]T), weights: make(map[Edge[T]]float64)}
+}
+
+// NewUndirectedGraph creates a graph whose edges go both ways
+func NewUndirectedGraph[T comparable]() *Graph[T] {
+	g := NewGraph[T]()
+	g.undirected = true
+	return g
 }
 
 // AddVertex adds a vertex to the graph
 func (g *Graph[T]) AddVertex(vertex T) {
 	if _, exists := g.vertices[vertex]; !exists {
 		g.vertices[vertex] = make([]T, 0)
+		g.order = append(g.order, vertex)
 	}
 }
 
-// AddEdge adds a directed edge from source to destination
+// AddEdge adds an edge of weight 1 from source to destination
 func (g *Graph[T]) AddEdge(source, destination T) {
+	g.AddWeightedEdge(source, destination, 1)
+}
+
+// AddWeightedEdge adds an edge with a weight, or changes the weight of an
+// existing one
+func (g *Graph[T]) AddWeightedEdge(source, destination T, weight float64) {
 	g.AddVertex(source)
 	g.AddVertex(destination)
-	g.vertices[source] = append(g.vertices[source], destination)
+	g.link(source, destination, weight)
+	if g.undirected && source != destination {
+		g.link(destination, source, weight)
+	}
+}
+
+func (g *Graph[T]) link(source, destination T, weight float64) {
+	edge := Edge[T]{From: source, To: destination}
+	if _, exists := g.weights[edge]; !exists {
+		g.vertices[source] = append(g.vertices[source], destination)
+	}
+	g.weights[edge] = weight
+}
+
+// Weight returns the weight of the edge from source to destination
+func (g *Graph[T]) Weight(source, destination T) (float64, bool) {
+	w, exists := g.weights[Edge[T]{From: source, To: destination}]
+	return w, exists
+}
+
+// Vertices returns every vertex in the order it was added
+func (g *Graph[T]) Vertices() []T {
+	return append([]T(nil), g.order...)
+}
+
+// IsUndirected reports whether edges go both ways
+func (g *Graph[T]) IsUndirected() bool {
+	return g.undirected
 }
 
 // GetNeighbors returns the neighbors of a vertex
@@ -433,16 +483,16 @@ func runGenericContainersExample() {
 	set2.Add(5)
 	set2.Add(6)
 
-	fmt.Printf("Set1: %v\n", set1.ToSlice())
-	fmt.Printf("Set2: %v\n", set2.ToSlice())
+	fmt.Printf("Set1: %v\n", SortedView(set1, CmpOrdered[int]))
+	fmt.Printf("Set2: %v\n", SortedView(set2, CmpOrdered[int]))
 	fmt.Printf("Set1 contains 3: %t\n", set1.Contains(3))
 	fmt.Printf("Set1 contains 7: %t\n", set1.Contains(7))
 
 	union := set1.Union(set2)
-	fmt.Printf("Union: %v\n", union.ToSlice())
+	fmt.Printf("Union: %v\n", SortedView(union, CmpOrdered[int]))
 
 	intersection := set1.Intersection(set2)
-	fmt.Printf("Intersection: %v\n", intersection.ToSlice())
+	fmt.Printf("Intersection: %v\n", SortedView(intersection, CmpOrdered[int]))
 
 	fmt.Println("\n🔸 Generic Binary Tree")
 
@@ -497,10 +547,10 @@ func runGenericContainersExample() {
 
 	// Get all words with prefix
 	carWords := trie.GetWordsWithPrefix("car")
-	fmt.Printf("All words starting with 'car': %v\n", carWords)
+	fmt.Printf("All words starting with 'car': %v\n", SortedBy(carWords, CmpOrdered[string]))
 
 	catWords := trie.GetWordsWithPrefix("cat")
-	fmt.Printf("All words starting with 'cat': %v\n", catWords)
+	fmt.Printf("All words starting with 'cat': %v\n", SortedBy(catWords, CmpOrdered[string]))
 
 	fmt.Println("\n🔸 Generic Graph")
 
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ==========================================
@@ -233,7 +238,9 @@ func MaxSlice[T Sortable](slice []T) (T, bool) {
 	return max, true
 }
 
-// Average calculates the average of numeric values
+// Average calculates the average of numeric values. The division happens
+// in T, so integer averages are truncated; Mean in 30_statistics.go works
+// in float64.
 func AverageSlice[T Number](slice []T) T {
 	if len(slice) == 0 {
 		return T(0)
@@ -394,6 +401,85 @@ func (p *Pipeline[T]) Collect() []T {
 	return p.data
 }
 
+// ==========================================
+// Concurrent Transformation Algorithms
+// ==========================================
+
+// ParallelMap is MapSlice spread over workers goroutines. Results keep the
+// order of items. The first error cancels the remaining work and is returned
+// with the index of the item that failed; a cancelled ctx returns its error.
+func ParallelMap[T, R any](ctx context.Context, items []T, workers int, fn func(T) (R, error)) ([]R, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([]R, len(items))
+	var next atomic.Int64 // Index of the next item to claim
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(workers, 1), len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) {
+					return
+				}
+				result, err := fn(items[i])
+				if err != nil {
+					cancel(fmt.Errorf("item %d: %w", i, err))
+					return
+				}
+				results[i] = result // Each index is claimed by one worker only
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ParallelReduce is ReduceSlice spread over workers goroutines. Each worker
+// folds a contiguous chunk starting from initial, and the partial results
+// are then folded with combine in chunk order. That gives the sequential
+// answer only when initial is an identity for combine (0 for +, 1 for *, ""
+// for concatenation) and combine is associative; it need not be commutative.
+func ParallelReduce[T, U any](ctx context.Context, items []T, workers int, initial U, reducer func(U, T) U, combine func(U, U) U) (U, error) {
+	chunks := min(max(workers, 1), max(len(items), 1))
+	size := (len(items) + chunks - 1) / chunks
+	partials := make([]U, chunks)
+
+	var wg sync.WaitGroup
+	for c := 0; c < chunks; c++ {
+		lo, hi := min(c*size, len(items)), min((c+1)*size, len(items))
+		wg.Add(1)
+		go func(c int, chunk []T) {
+			defer wg.Done()
+			acc := initial
+			for i, item := range chunk {
+				if i%1024 == 0 && ctx.Err() != nil {
+					return
+				}
+				acc = reducer(acc, item)
+			}
+			partials[c] = acc
+		}(c, items[lo:hi])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		var zero U
+		return zero, err
+	}
+	result := partials[0]
+	for _, partial := range partials[1:] {
+		result = combine(result, partial)
+	}
+	return result, nil
+}
+
 // ==========================================
 // Mathematical Algorithms
 // ==========================================
@@ -618,6 +704,9 @@ func runGenericAlgorithmsExample() {
 
 	fmt.Printf("Pipeline result: %v\n", result)
 
+	fmt.Println("\n🔸 Concurrent Map/Reduce")
+	runParallelMapReduce()
+
 	fmt.Println("\n🔸 Mathematical Algorithms")
 
 	// Fibonacci
@@ -645,3 +734,58 @@ func runGenericAlgorithmsExample() {
 
 	fmt.Println("\n✅ Generic algorithms examples completed!")
 }
+
+// runParallelMapReduce contrasts MapSlice/ReduceSlice with their parallel
+// versions and measures where parallelism starts to pay off
+func runParallelMapReduce() {
+	ctx := context.Background()
+	nums := Range(1, 11, 1)
+
+	// Same answers as the sequential versions, in the same order
+	squares, _ := ParallelMap(ctx, nums, 4, func(n int) (int, error) { return n * n, nil })
+	fmt.Printf("ParallelMap squares: %v\n", squares)
+	sum, _ := ParallelReduce(ctx, nums, 4, 0, func(acc, n int) int { return acc + n }, func(a, b int) int { return a + b })
+	fmt.Printf("ParallelReduce sum: %d (ReduceSlice: %d)\n", sum, ReduceSlice(nums, 0, func(acc, n int) int { return acc + n }))
+
+	// Concatenation is associative but not commutative; chunk order keeps it right
+	words := strings.Fields("the quick brown fox jumps over the lazy dog")
+	concat, _ := ParallelReduce(ctx, words, 3, "", func(acc, w string) string { return acc + w[:1] }, func(a, b string) string { return a + b })
+	fmt.Printf("ParallelReduce initials: %s\n", concat)
+
+	// The first error stops the rest and names the item
+	_, err := ParallelMap(ctx, []string{"8080", "443", "http", "22"}, 2, func(s string) (int, error) {
+		var port int
+		_, err := fmt.Sscanf(s, "%d", &port)
+		return port, err
+	})
+	fmt.Printf("ParallelMap error: %v\n", err)
+
+	// Crossover: goroutines and coordination cost a fixed amount, so cheap
+	// per-item work is faster sequentially and parallelism wins only once
+	// the work per item (or the number of items) outweighs that cost
+	fmt.Printf("Crossover on %d CPU(s), 4 workers:\n", runtime.NumCPU())
+	fmt.Printf("  %-8s %-6s %12s %12s\n", "items", "work", "sequential", "parallel")
+	for _, tc := range []struct {
+		items int
+		work  int // Loop iterations per item
+	}{{100, 10}, {10000, 10}, {100, 10000}, {10000, 1000}} {
+		items := Range(0, tc.items, 1)
+		work := func(n int) int {
+			acc := n
+			for i := 0; i < tc.work; i++ {
+				acc = acc*31 + i
+			}
+			return acc
+		}
+
+		start := time.Now()
+		MapSlice(items, work)
+		sequential := time.Since(start)
+
+		start = time.Now()
+		ParallelMap(ctx, items, 4, func(n int) (int, error) { return work(n), nil })
+		parallel := time.Since(start)
+
+		fmt.Printf("  %-8d %-6d %12v %12v\n", tc.items, tc.work, sequential.Round(time.Microsecond), parallel.Round(time.Microsecond))
+	}
+}
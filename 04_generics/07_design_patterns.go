@@ -94,6 +94,63 @@ func (cb *ConfigBuilder[T]) Build() T {
 	return cb.config
 }
 
+// ==========================================
+// Staged Builder with Phantom Types
+// ==========================================
+
+// ConfigBuilder will build a Configuration with no host at all; the
+// mistake surfaces when something tries to connect. A staged builder
+// records which required steps have run in its type parameters, so the
+// mistake is a compile error instead.
+
+// Missing and Provided are phantom types: they are never stored, only used
+// as type arguments to track whether a required step has run
+type Missing struct{}
+type Provided struct{}
+
+// StagedConfigBuilder builds a Configuration whose Host and Database are
+// required. Each setter returns a builder whose type records the step, so
+// required steps can come in any order and optional ones anywhere.
+type StagedConfigBuilder[Host, Database any] struct {
+	config Configuration
+}
+
+// NewStagedConfigBuilder starts with both required steps missing
+func NewStagedConfigBuilder() StagedConfigBuilder[Missing, Missing] {
+	return StagedConfigBuilder[Missing, Missing]{config: Configuration{Port: 5432}}
+}
+
+// Host sets the required host
+func (b StagedConfigBuilder[H, D]) Host(host string) StagedConfigBuilder[Provided, D] {
+	b.config.Host = host
+	return StagedConfigBuilder[Provided, D](b)
+}
+
+// Database sets the required database name
+func (b StagedConfigBuilder[H, D]) Database(name string) StagedConfigBuilder[H, Provided] {
+	b.config.Database = name
+	return StagedConfigBuilder[H, Provided](b)
+}
+
+// Port overrides the default port; optional, so the type is unchanged
+func (b StagedConfigBuilder[H, D]) Port(port int) StagedConfigBuilder[H, D] {
+	b.config.Port = port
+	return b
+}
+
+// SSL turns on TLS; optional
+func (b StagedConfigBuilder[H, D]) SSL(enabled bool) StagedConfigBuilder[H, D] {
+	b.config.SSL = enabled
+	return b
+}
+
+// BuildConfig accepts only a builder with every required step provided.
+// It is a function rather than a Build method because a Go method cannot
+// narrow its receiver's type arguments; a parameter type can.
+func BuildConfig(b StagedConfigBuilder[Provided, Provided]) Configuration {
+	return b.config
+}
+
 // ==========================================
 // Generic Decorator Pattern
 // ==========================================
@@ -366,6 +423,20 @@ func (r *MemoryRepository[T, ID]) FindAll() []T {
 	return entities
 }
 
+// FindWhere returns the entities matching pred, keyed by ID
+func (r *MemoryRepository[T, ID]) FindWhere(pred Predicate[T]) map[ID]T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make(map[ID]T)
+	for id, entity := range r.data {
+		if pred(entity) {
+			matches[id] = entity
+		}
+	}
+	return matches
+}
+
 func (r *MemoryRepository[T, ID]) Delete(id ID) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -500,6 +571,25 @@ func runDesignPatternsExample() {
 	config := configBuilder.Build()
 	fmt.Printf("Built config: %+v\n", config)
 
+	// Nothing stops ConfigBuilder from building an unusable config
+	fmt.Printf("Built with no steps: %+v\n", NewConfigBuilder(Configuration{}).Build())
+
+	fmt.Println("\n🔸 Staged Builder with Phantom Types")
+
+	// Required steps in either order, optional steps anywhere
+	primary := BuildConfig(NewStagedConfigBuilder().Host("db.internal").Database("orders").SSL(true))
+	replica := BuildConfig(NewStagedConfigBuilder().Database("orders").Port(6432).Host("replica.internal"))
+	fmt.Printf("Primary: %+v\n", primary)
+	fmt.Printf("Replica: %+v\n", replica)
+
+	// Each step changes the builder's type
+	partial := NewStagedConfigBuilder().Host("db.internal").Port(6432)
+	fmt.Printf("After Host and Port: %T\n", partial)
+	// BuildConfig(partial) does not compile:
+	//   cannot use partial (variable of struct type StagedConfigBuilder[Provided, Missing])
+	//   as StagedConfigBuilder[Provided, Provided] value in argument to BuildConfig
+	fmt.Printf("After Database too: %T\n", partial.Database("orders"))
+
 	fmt.Println("\n🔸 Generic Decorator Pattern")
 
 	// Base component with string
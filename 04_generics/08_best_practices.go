@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"runtime"
@@ -169,52 +170,8 @@ func (s *OptimizedSlice[T]) Len() int {
 	return len(s.data)
 }
 
-// Pre-allocated worker pool pattern
-type WorkerPool[T any, R any] struct {
-	workers    int
-	jobQueue   chan T
-	resultChan chan R
-	processor  func(T) R
-	wg         sync.WaitGroup
-}
-
-func NewWorkerPool[T any, R any](workers int, processor func(T) R) *WorkerPool[T, R] {
-	return &WorkerPool[T, R]{
-		workers:    workers,
-		jobQueue:   make(chan T, workers*2), // Buffer to avoid blocking
-		resultChan: make(chan R, workers*2),
-		processor:  processor,
-	}
-}
-
-func (wp *WorkerPool[T, R]) Start() {
-	for i := 0; i < wp.workers; i++ {
-		wp.wg.Add(1)
-		go wp.worker()
-	}
-}
-
-func (wp *WorkerPool[T, R]) worker() {
-	defer wp.wg.Done()
-	for job := range wp.jobQueue {
-		result := wp.processor(job)
-		wp.resultChan <- result
-	}
-}
-
-func (wp *WorkerPool[T, R]) Submit(job T) {
-	wp.jobQueue <- job
-}
-
-func (wp *WorkerPool[T, R]) GetResult() R {
-	return <-wp.resultChan
-}
-
-func (wp *WorkerPool[T, R]) Close() {
-	close(wp.jobQueue)
-	wp.wg.Wait()
-	close(wp.resultChan)
-}
+// Pre-allocated worker pool pattern: WorkerPool (20_worker_pool.go) starts
+// its goroutines once and reuses them for every job
 
 // ==========================================
 // Best Practice 7: Error Handling Patterns
@@ -512,27 +469,26 @@ func runBestPracticesExample() {
 	fmt.Println("\n🔸 Worker Pool Pattern")
 
 	// Worker pool demonstration
-	pool := NewWorkerPool(3, func(n int) int {
+	pool := NewWorkerPool(3, func(_ context.Context, n int) (int, error) {
 		time.Sleep(10 * time.Millisecond) // Simulate work
-		return n * n
+		return n * n, nil
 	})
 
-	pool.Start()
-
 	// Submit jobs
+	var results []<-chan Outcome[int, int]
 	for i := 1; i <= 5; i++ {
-		pool.Submit(i)
+		result, _ := pool.Submit(context.Background(), i)
+		results = append(results, result)
 	}
 
 	// Collect results
 	fmt.Print("Worker pool results: ")
-	for i := 0; i < 5; i++ {
-		result := pool.GetResult()
-		fmt.Printf("%d ", result)
+	for _, result := range results {
+		fmt.Printf("%d ", (<-result).Result)
 	}
 	fmt.Println()
 
-	pool.Close()
+	pool.Drain()
 
 	fmt.Println("\n🔸 Error Handling with Result Type")
 
@@ -626,6 +582,8 @@ func runBestPracticesExample() {
 	fmt.Printf("Generic sum (%v): %d\n", genericTime, genericResult)
 	fmt.Printf("Interface sum (%v): %.0f\n", interfaceTime, interfaceResult)
 	fmt.Printf("Generic is %.2fx faster\n", float64(interfaceTime)/float64(genericTime))
+	fmt.Println("(One timed pass is noisy; for ns/op and allocations run")
+	fmt.Println(" go test -bench='Sum|Sort' -benchmem ./04_generics)")
 
 	fmt.Println("\n🔸 Memory Usage Analysis")
 
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==========================================
+// Stream Sources and Sinks
+// ==========================================
+
+// Each operator below comes in two forms: one over a channel, which runs
+// its own goroutine, and one over a pull-based Seq (22_lazy_seq.go), which
+// does its work only when the consumer pulls and so also works on infinite
+// sequences. The channel form is the Seq form fed by SeqFromChan.
+
+// StreamOf emits the given values on a channel and closes it
+func StreamOf[T any](values ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			out <- v
+		}
+	}()
+	return out
+}
+
+// CollectStream drains a channel into a slice
+func CollectStream[T any](in <-chan T) []T {
+	var result []T
+	for v := range in {
+		result = append(result, v)
+	}
+	return result
+}
+
+// streamFrom pulls s on its own goroutine and emits every value on a
+// channel, which it closes when s is exhausted
+func streamFrom[T any](s Seq[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		s.ForEach(func(v T) { out <- v })
+	}()
+	return out
+}
+
+// ==========================================
+// Distinct Operators
+// ==========================================
+
+// Distinct emits each value only the first time it is seen
+func Distinct[T comparable](in <-chan T) <-chan T {
+	return streamFrom(DistinctSeq(SeqFromChan(in)))
+}
+
+// DistinctSeq yields each value only the first time it is seen. It
+// remembers every value, so on an infinite sequence memory keeps growing.
+func DistinctSeq[T comparable](s Seq[T]) Seq[T] {
+	seen := make(map[T]struct{})
+	return s.Filter(func(v T) bool {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+		return true
+	})
+}
+
+// DistinctUntilChanged drops values equal to the one emitted just before them
+func DistinctUntilChanged[T comparable](in <-chan T) <-chan T {
+	return DistinctUntilChangedFunc(in, func(a, b T) bool { return a == b })
+}
+
+// DistinctUntilChangedSeq drops values equal to the one yielded just before them
+func DistinctUntilChangedSeq[T comparable](s Seq[T]) Seq[T] {
+	return DistinctUntilChangedFuncSeq(s, func(a, b T) bool { return a == b })
+}
+
+// DistinctUntilChangedFunc drops values the comparator considers equal to the
+// last emitted value. Comparing against the last *emitted* value (not the last
+// received one) means slow drift still triggers once it exceeds the tolerance.
+func DistinctUntilChangedFunc[T any](in <-chan T, equal func(a, b T) bool) <-chan T {
+	return streamFrom(DistinctUntilChangedFuncSeq(SeqFromChan(in), equal))
+}
+
+// DistinctUntilChangedFuncSeq is DistinctUntilChangedFunc over a Seq
+func DistinctUntilChangedFuncSeq[T any](s Seq[T], equal func(a, b T) bool) Seq[T] {
+	var last T
+	hasLast := false
+	return s.Filter(func(v T) bool {
+		if hasLast && equal(last, v) {
+			return false
+		}
+		last, hasLast = v, true
+		return true
+	})
+}
+
+// WithinTolerance returns a comparator treating numbers closer than tolerance as equal
+func WithinTolerance[T Number](tolerance T) func(a, b T) bool {
+	return func(a, b T) bool {
+		return math.Abs(float64(a)-float64(b)) < float64(tolerance)
+	}
+}
+
+// ==========================================
+// Rate-of-Change Operators
+// ==========================================
+
+// Pairwise emits (previous, current) pairs; the first value only primes the window
+func Pairwise[T any](in <-chan T) <-chan Pair[T, T] {
+	return streamFrom(PairwiseSeq(SeqFromChan(in)))
+}
+
+// PairwiseSeq yields (previous, current) pairs. The first pull takes two
+// values from s, every later pull one.
+func PairwiseSeq[T any](s Seq[T]) Seq[Pair[T, T]] {
+	var prev T
+	hasPrev := false
+	return func() (Pair[T, T], bool) {
+		if !hasPrev {
+			if prev, hasPrev = s(); !hasPrev {
+				return Pair[T, T]{}, false
+			}
+		}
+		v, ok := s()
+		if !ok {
+			return Pair[T, T]{}, false
+		}
+		p := NewPair(prev, v)
+		prev = v
+		return p, true
+	}
+}
+
+// Delta emits the difference between each value and its predecessor
+func Delta[T Number](in <-chan T) <-chan T {
+	return streamFrom(DeltaSeq(SeqFromChan(in)))
+}
+
+// DeltaSeq yields the difference between each value and its predecessor
+func DeltaSeq[T Number](s Seq[T]) Seq[T] {
+	return MapSeq(PairwiseSeq(s), func(p Pair[T, T]) T { return p.Second - p.First })
+}
+
+// SpikeDetector emits pairs whose change exceeds the given threshold in either direction
+func SpikeDetector[T Number](in <-chan T, threshold T) <-chan Pair[T, T] {
+	return streamFrom(PairwiseSeq(SeqFromChan(in)).Filter(func(p Pair[T, T]) bool {
+		return math.Abs(float64(p.Second)-float64(p.First)) > float64(threshold)
+	}))
+}
+
+// ==========================================
+// Simulated Sensor Data
+// ==========================================
+
+// SensorReading is a single reading from a simulated sensor
+type SensorReading struct {
+	Sensor string
+	Value  float64
+}
+
+// simulatedTemperatures mimics a noisy thermometer with one sudden jump
+func simulatedTemperatures() []float64 {
+	return []float64{20.0, 20.1, 20.1, 20.05, 20.3, 20.6, 20.6, 25.2, 25.3, 25.3, 24.9}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runStreamOperatorsExample() {
+	fmt.Println("\n🔸 Distinct Operators")
+
+	statuses := []string{"ok", "ok", "warn", "ok", "error", "error", "ok"}
+	fmt.Printf("Status stream: %v\n", statuses)
+	fmt.Printf("Distinct: %v\n", CollectStream(Distinct(StreamOf(statuses...))))
+	fmt.Printf("DistinctUntilChanged: %v\n", CollectStream(DistinctUntilChanged(StreamOf(statuses...))))
+
+	// Works with any comparable type, including structs
+	readings := []SensorReading{
+		{"kitchen", 21.5}, {"kitchen", 21.5}, {"garage", 12.0}, {"kitchen", 21.5},
+	}
+	fmt.Printf("Distinct readings: %v\n", CollectStream(Distinct(StreamOf(readings...))))
+
+	fmt.Println("\n🔸 Debounced Value Comparators")
+
+	temps := simulatedTemperatures()
+	fmt.Printf("Raw temperatures: %v\n", temps)
+
+	exact := CollectStream(DistinctUntilChanged(StreamOf(temps...)))
+	fmt.Printf("Exact changes (%d): %v\n", len(exact), exact)
+
+	// Ignore sensor noise below 0.5 degrees
+	significant := CollectStream(DistinctUntilChangedFunc(StreamOf(temps...), WithinTolerance(0.5)))
+	fmt.Printf("Significant changes (%d): %v\n", len(significant), significant)
+
+	fmt.Println("\n🔸 Rate-of-Change Detectors")
+
+	pairs := CollectStream(Pairwise(StreamOf(1, 4, 9, 16)))
+	fmt.Printf("Pairwise(1, 4, 9, 16): %v\n", pairs)
+
+	deltas := CollectStream(Delta(StreamOf(1, 4, 9, 16)))
+	fmt.Printf("Delta(1, 4, 9, 16): %v\n", deltas)
+
+	tempDeltas := CollectStream(Delta(StreamOf(temps...)))
+	fmt.Printf("Temperature deltas: ")
+	for _, d := range tempDeltas {
+		fmt.Printf("%+.2f ", d)
+	}
+	fmt.Println()
+
+	for _, spike := range CollectStream(SpikeDetector(StreamOf(temps...), 2.0)) {
+		fmt.Printf("⚠️  Spike detected: %.1f -> %.1f\n", spike.First, spike.Second)
+	}
+
+	fmt.Println("\n🔸 Pull-Based Variants")
+
+	// The Seq forms run only as far as the consumer pulls, so they work on
+	// an infinite sequence: the gaps between consecutive squares
+	squares := MapSeq(Iterate(1, func(n int) int { return n + 1 }), func(n int) int { return n * n })
+	fmt.Printf("DeltaSeq(squares).Take(5): %v\n", DeltaSeq(squares).Take(5).Collect())
+	fmt.Printf("DistinctUntilChangedSeq(statuses): %v\n", DistinctUntilChangedSeq(SeqOf(statuses...)).Collect())
+
+	fmt.Println("\n✅ Stream operators examples completed!")
+}
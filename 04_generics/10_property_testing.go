@@ -0,0 +1,454 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// Generators and Shrinkers
+// ==========================================
+
+// Generator produces random values of T and knows how to shrink them
+type Generator[T any] struct {
+	Generate func(r *rand.Rand) T
+	Shrink   func(T) []T // Optional: smaller candidates, most aggressive first
+}
+
+// Ints generates integers in [min, max] and shrinks towards zero
+func Ints(min, max int) Generator[int] {
+	return Generator[int]{
+		Generate: func(r *rand.Rand) int {
+			return min + r.Intn(max-min+1)
+		},
+		Shrink: func(n int) []int {
+			candidates := []int{}
+			if n != 0 && min <= 0 && 0 <= max {
+				candidates = append(candidates, 0)
+			}
+			if half := n / 2; half != n && half != 0 && half >= min {
+				candidates = append(candidates, half)
+			}
+			if n > 0 && n-1 >= min {
+				candidates = append(candidates, n-1)
+			} else if n < 0 && n+1 <= max {
+				candidates = append(candidates, n+1)
+			}
+			return candidates
+		},
+	}
+}
+
+// Strings generates strings up to maxLen characters from the given alphabet
+func Strings(maxLen int, alphabet string) Generator[string] {
+	runes := []rune(alphabet)
+	return Generator[string]{
+		Generate: func(r *rand.Rand) string {
+			var sb strings.Builder
+			n := r.Intn(maxLen + 1)
+			for i := 0; i < n; i++ {
+				sb.WriteRune(runes[r.Intn(len(runes))])
+			}
+			return sb.String()
+		},
+		Shrink: func(s string) []string {
+			rs := []rune(s)
+			shrunk := ShrinkSlice(rs, nil)
+			candidates := make([]string, len(shrunk))
+			for i, c := range shrunk {
+				candidates[i] = string(c)
+			}
+			return candidates
+		},
+	}
+}
+
+// SlicesOf generates slices up to maxLen elements using the element generator
+func SlicesOf[T any](elem Generator[T], maxLen int) Generator[[]T] {
+	return Generator[[]T]{
+		Generate: func(r *rand.Rand) []T {
+			n := r.Intn(maxLen + 1)
+			result := make([]T, n)
+			for i := range result {
+				result[i] = elem.Generate(r)
+			}
+			return result
+		},
+		Shrink: func(s []T) [][]T {
+			return ShrinkSlice(s, elem.Shrink)
+		},
+	}
+}
+
+// ShrinkSlice proposes smaller slices: drop halves, drop single elements,
+// then shrink individual elements with elemShrink (if provided)
+func ShrinkSlice[T any](s []T, elemShrink func(T) []T) [][]T {
+	var candidates [][]T
+	n := len(s)
+	if n == 0 {
+		return candidates
+	}
+
+	// Remove large chunks first so shrinking converges quickly
+	for size := n / 2; size >= 1; size /= 2 {
+		for start := 0; start+size <= n; start += size {
+			candidate := make([]T, 0, n-size)
+			candidate = append(candidate, s[:start]...)
+			candidate = append(candidate, s[start+size:]...)
+			candidates = append(candidates, candidate)
+		}
+	}
+	if n == 1 {
+		candidates = append(candidates, []T{})
+	}
+
+	if elemShrink != nil {
+		for i, item := range s {
+			for _, smaller := range elemShrink(item) {
+				candidate := make([]T, n)
+				copy(candidate, s)
+				candidate[i] = smaller
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// ==========================================
+// Property Runner
+// ==========================================
+
+// PropertyConfig controls how many cases are tried and with which seed. Seed
+// 0 means a new seed from the clock on every run; the result reports it.
+type PropertyConfig struct {
+	Runs           int
+	Seed           int64
+	MaxShrinkSteps int
+}
+
+// DefaultPropertyConfig is used by ForAll
+var DefaultPropertyConfig = PropertyConfig{Runs: 200, Seed: 42, MaxShrinkSteps: 500}
+
+// PropertyResult reports the outcome of a property check
+type PropertyResult[T any] struct {
+	Passed         bool
+	Runs           int
+	Seed           int64
+	Counterexample T // First failing input
+	Shrunk         T // Minimal failing input found by shrinking
+	ShrinkSteps    int
+}
+
+// String summarizes the result in one line
+func (r PropertyResult[T]) String() string {
+	if r.Passed {
+		return fmt.Sprintf("passed %d runs (seed %d)", r.Runs, r.Seed)
+	}
+	return fmt.Sprintf("FAILED after %d runs (seed %d): counterexample %v, shrunk to %v in %d steps",
+		r.Runs, r.Seed, r.Counterexample, r.Shrunk, r.ShrinkSteps)
+}
+
+// ForAll checks prop against values from gen using DefaultPropertyConfig
+func ForAll[T any](gen Generator[T], prop func(T) bool) PropertyResult[T] {
+	return ForAllWith(DefaultPropertyConfig, gen, prop)
+}
+
+// ForAllWith checks prop against values from gen and shrinks the first failure
+func ForAllWith[T any](cfg PropertyConfig, gen Generator[T], prop func(T) bool) PropertyResult[T] {
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(cfg.Seed))
+	result := PropertyResult[T]{Passed: true, Seed: cfg.Seed}
+
+	for i := 0; i < cfg.Runs; i++ {
+		value := gen.Generate(r)
+		result.Runs++
+		if prop(value) {
+			continue
+		}
+
+		result.Passed = false
+		result.Counterexample = value
+		result.Shrunk, result.ShrinkSteps = shrinkFailure(value, gen.Shrink, prop, cfg.MaxShrinkSteps)
+		return result
+	}
+	return result
+}
+
+// shrinkFailure greedily replaces the failing value with any smaller one that still fails
+func shrinkFailure[T any](value T, shrink func(T) []T, prop func(T) bool, maxSteps int) (T, int) {
+	if shrink == nil {
+		return value, 0
+	}
+
+	steps := 0
+	for steps < maxSteps {
+		improved := false
+		for _, candidate := range shrink(value) {
+			if !prop(candidate) {
+				value = candidate
+				steps++
+				improved = true
+				break
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return value, steps
+}
+
+// ==========================================
+// Container Properties
+// ==========================================
+
+// stackReversesInput: popping everything yields the pushed items in reverse
+func stackReversesInput(items []int) bool {
+	stack := NewStack[int]()
+	for _, item := range items {
+		stack.Push(item)
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		if got, ok := stack.Pop(); !ok || got != items[i] {
+			return false
+		}
+	}
+	return stack.IsEmpty()
+}
+
+// queuePreservesOrder: dequeuing everything yields the enqueued items in order
+func queuePreservesOrder(items []int) bool {
+	queue := NewQueue[int]()
+	for _, item := range items {
+		queue.Enqueue(item)
+	}
+	for _, want := range items {
+		if got, ok := queue.Dequeue(); !ok || got != want {
+			return false
+		}
+	}
+	return queue.IsEmpty()
+}
+
+// setFrom builds a set from a slice
+func setFrom[T comparable](items []T) *Set[T] {
+	set := NewSet[T]()
+	for _, item := range items {
+		set.Add(item)
+	}
+	return set
+}
+
+// setsEqual compares two sets by membership
+func setsEqual[T comparable](a, b *Set[T]) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	for _, item := range a.ToSlice() {
+		if !b.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// setUnionCommutes: a ∪ b == b ∪ a, and both contain every input element
+func setUnionCommutes(pair Pair[[]int, []int]) bool {
+	a, b := setFrom(pair.First), setFrom(pair.Second)
+	ab, ba := a.Union(b), b.Union(a)
+	for _, item := range append(pair.First, pair.Second...) {
+		if !ab.Contains(item) {
+			return false
+		}
+	}
+	return setsEqual(ab, ba)
+}
+
+// setIntersectionIsSubset: every element of a ∩ b is in both a and b
+func setIntersectionIsSubset(pair Pair[[]int, []int]) bool {
+	a, b := setFrom(pair.First), setFrom(pair.Second)
+	for _, item := range a.Intersection(b).ToSlice() {
+		if !a.Contains(item) || !b.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// trieFindsInsertedWords: every inserted word is found with its last value
+// and is reachable through every one of its prefixes
+func trieFindsInsertedWords(words []string) bool {
+	trie := NewTrie[int]()
+	last := make(map[string]int)
+	for i, word := range words {
+		trie.Insert(word, i)
+		last[word] = i
+	}
+
+	for word, want := range last {
+		if got, ok := trie.Search(word); !ok || got != want {
+			return false
+		}
+		for end := range word {
+			if !trie.StartsWith(word[:end]) {
+				return false
+			}
+		}
+	}
+	return len(trie.GetWordsWithPrefix("")) == len(last)
+}
+
+// sortThenSearchSucceeds: after sorting, binary search finds every element
+func sortThenSearchSucceeds(items []int) bool {
+	sorted := make([]int, len(items))
+	copy(sorted, items)
+	QuickSort(sorted)
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1] > sorted[i] {
+			return false
+		}
+	}
+	for _, item := range items {
+		idx := BinarySearch(sorted, item)
+		if idx < 0 || sorted[idx] != item {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedMapRangeMatchesModel: keys put into a SortedMap iterate in ascending
+// order, once each, and Range(lo, hi) yields exactly the inserted keys in
+// [lo, hi). The second half of the input holds lo and hi.
+func sortedMapRangeMatchesModel(input Pair[[]int, Pair[int, int]]) bool {
+	m := NewSortedMap[int, int]()
+	unique := make(map[int]bool)
+	for i, key := range input.First {
+		m.Put(key, i)
+		unique[key] = true
+	}
+	model := make([]int, 0, len(unique))
+	for key := range unique {
+		model = append(model, key)
+	}
+	sort.Ints(model)
+
+	var iterated []int
+	m.ForEach(func(k, _ int) { iterated = append(iterated, k) })
+	if fmt.Sprint(iterated) != fmt.Sprint(model) || m.Len() != len(model) {
+		return false
+	}
+
+	lo, hi := input.Second.First, input.Second.Second
+	var ranged, want []int
+	m.Range(lo, hi, func(k, _ int) { ranged = append(ranged, k) })
+	for _, key := range model {
+		if lo <= key && key < hi {
+			want = append(want, key)
+		}
+	}
+	return fmt.Sprint(ranged) == fmt.Sprint(want)
+}
+
+// PairsOf combines two generators into a generator of pairs
+func PairsOf[T, U any](first Generator[T], second Generator[U]) Generator[Pair[T, U]] {
+	return Generator[Pair[T, U]]{
+		Generate: func(r *rand.Rand) Pair[T, U] {
+			return NewPair(first.Generate(r), second.Generate(r))
+		},
+		Shrink: func(p Pair[T, U]) []Pair[T, U] {
+			var candidates []Pair[T, U]
+			if first.Shrink != nil {
+				for _, f := range first.Shrink(p.First) {
+					candidates = append(candidates, NewPair(f, p.Second))
+				}
+			}
+			if second.Shrink != nil {
+				for _, s := range second.Shrink(p.Second) {
+					candidates = append(candidates, NewPair(p.First, s))
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runPropertyTestingExample() {
+	intSlices := SlicesOf(Ints(-100, 100), 30)
+	words := SlicesOf(Strings(6, "abc"), 20)
+
+	fmt.Println("\n🔸 Generators")
+
+	r := rand.New(rand.NewSource(7))
+	fmt.Printf("Ints(-100, 100): %v %v %v\n", Ints(-100, 100).Generate(r), Ints(-100, 100).Generate(r), Ints(-100, 100).Generate(r))
+	fmt.Printf("Strings(6, \"abc\"): %q %q %q\n", Strings(6, "abc").Generate(r), Strings(6, "abc").Generate(r), Strings(6, "abc").Generate(r))
+	fmt.Printf("SlicesOf(Ints, 5): %v\n", SlicesOf(Ints(0, 9), 5).Generate(r))
+
+	fmt.Println("\n🔸 Shrinkers")
+
+	fmt.Printf("Shrink 40: %v\n", Ints(-100, 100).Shrink(40))
+	fmt.Printf("Shrink [5 6 7 8]: %v\n", intSlices.Shrink([]int{5, 6, 7, 8})[:4])
+
+	fmt.Println("\n🔸 Container Invariants")
+
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Stack pops in reverse push order", func() string { return ForAll(intSlices, stackReversesInput).String() }},
+		{"Queue dequeues in enqueue order", func() string { return ForAll(intSlices, queuePreservesOrder).String() }},
+		{"Set union commutes", func() string { return ForAll(PairsOf(intSlices, intSlices), setUnionCommutes).String() }},
+		{"Set intersection is a subset", func() string {
+			return ForAll(PairsOf(intSlices, intSlices), setIntersectionIsSubset).String()
+		}},
+		{"Trie finds every inserted word", func() string { return ForAll(words, trieFindsInsertedWords).String() }},
+		{"Sort then search succeeds", func() string { return ForAll(intSlices, sortThenSearchSucceeds).String() }},
+		{"SortedMap order and Range(lo, hi)", func() string {
+			return ForAll(PairsOf(intSlices, PairsOf(Ints(-100, 100), Ints(-100, 100))), sortedMapRangeMatchesModel).String()
+		}},
+	}
+
+	for _, p := range properties {
+		fmt.Printf("%-34s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n🔸 Shrinking a Failing Property")
+
+	// A deliberately wrong belief: "sorted output never contains duplicates"
+	noDuplicates := func(items []int) bool {
+		sorted := make([]int, len(items))
+		copy(sorted, items)
+		QuickSort(sorted)
+		for i := 1; i < len(sorted); i++ {
+			if sorted[i] == sorted[i-1] {
+				return false
+			}
+		}
+		return true
+	}
+	result := ForAll(SlicesOf(Ints(-10, 10), 30), noDuplicates)
+	fmt.Printf("Sorted output has no duplicates: %s\n", result)
+
+	// Another wrong belief, with a minimal counterexample that is easy to read
+	sumBelow100 := func(items []int) bool { return SumSlice(items) < 100 }
+	result = ForAll(SlicesOf(Ints(0, 50), 10), sumBelow100)
+	fmt.Printf("Sum of slice is below 100: %s\n", result)
+
+	fmt.Println("\n🔸 Reproducing a Failure With Its Seed")
+
+	replay := ForAllWith(PropertyConfig{Runs: 200, Seed: result.Seed, MaxShrinkSteps: 500}, SlicesOf(Ints(0, 50), 10), sumBelow100)
+	fmt.Printf("Replayed with seed %d: same counterexample = %v\n", replay.Seed, fmt.Sprint(replay.Counterexample) == fmt.Sprint(result.Counterexample))
+
+	fmt.Println("\n✅ Property testing examples completed!")
+}
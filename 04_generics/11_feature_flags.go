@@ -0,0 +1,430 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+)
+
+// ==========================================
+// Tri-State Values
+// ==========================================
+
+// Tristate is a boolean that can also be explicitly unset, so a flag can
+// say "no opinion" and let the caller's own default decide
+type Tristate int8
+
+const (
+	Unset Tristate = iota
+	On
+	Off
+)
+
+func (t Tristate) String() string {
+	switch t {
+	case On:
+		return "on"
+	case Off:
+		return "off"
+	default:
+		return "unset"
+	}
+}
+
+// Resolve returns the boolean value, or fallback when unset
+func (t Tristate) Resolve(fallback bool) bool {
+	switch t {
+	case On:
+		return true
+	case Off:
+		return false
+	default:
+		return fallback
+	}
+}
+
+// ==========================================
+// Watchable Values
+// ==========================================
+
+// Watchable holds a value and notifies watchers whenever it is replaced
+type Watchable[T any] struct {
+	mu       sync.RWMutex
+	value    T
+	watchers []watcher[T]
+	nextID   int
+}
+
+type watcher[T any] struct {
+	id int
+	fn func(old, new T)
+}
+
+// NewWatchable creates a watchable value
+func NewWatchable[T any](initial T) *Watchable[T] {
+	return &Watchable[T]{value: initial}
+}
+
+// Get returns the current value
+func (w *Watchable[T]) Get() T {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.value
+}
+
+// Set replaces the value and notifies every watcher with the old and new values
+func (w *Watchable[T]) Set(value T) {
+	w.swap(value)()
+}
+
+// swap replaces the value and returns the notification of the watchers
+// registered at that moment, for a caller that must run it outside its own
+// locks
+func (w *Watchable[T]) swap(value T) (notify func()) {
+	w.mu.Lock()
+	old := w.value
+	w.value = value
+	watchers := append([]watcher[T](nil), w.watchers...)
+	w.mu.Unlock()
+
+	// Callbacks run outside the lock so they may read the value again
+	return func() {
+		for _, watcher := range watchers {
+			watcher.fn(old, value)
+		}
+	}
+}
+
+// Watch registers a callback and returns a function that unregisters it
+func (w *Watchable[T]) Watch(fn func(old, new T)) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	w.watchers = append(w.watchers, watcher[T]{id: id, fn: fn})
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for i, watcher := range w.watchers {
+			if watcher.id == id {
+				w.watchers = append(w.watchers[:i], w.watchers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// ==========================================
+// Targeting Rules
+// ==========================================
+
+// Targeting rules reuse Predicate, And, Or and Not from the functional patterns
+
+// AttrIn matches when the attribute extracted from the context is one of values
+func AttrIn[C any, V comparable](attr func(C) V, values ...V) Predicate[C] {
+	return func(c C) bool {
+		return Contains(values, attr(c))
+	}
+}
+
+// AttrAtLeast matches when an ordered attribute is at least min
+func AttrAtLeast[C any, V Ordered](attr func(C) V, min V) Predicate[C] {
+	return func(c C) bool {
+		return attr(c) >= min
+	}
+}
+
+// Rule serves Value to contexts matching When, optionally limited to a percentage
+// of them. Percentage 0 means the rule is not a rollout and applies to everyone.
+type Rule[T any, C any] struct {
+	Name       string
+	When       Predicate[C]
+	Percentage int
+	Value      T
+}
+
+// FlagConfig is the replaceable part of a flag: its rules and default
+type FlagConfig[T any, C any] struct {
+	Rules   []Rule[T, C]
+	Default T
+}
+
+// Evaluation is the result of evaluating a flag, with the reason for auditing
+type Evaluation[T any] struct {
+	Value  T
+	Rule   string
+	Reason string
+}
+
+// ==========================================
+// Flags
+// ==========================================
+
+// Flag is a typed feature flag evaluated against a context of type C
+type Flag[T any, C any] struct {
+	name      string
+	bucketKey func(C) string
+	config    *Watchable[FlagConfig[T, C]]
+}
+
+// NewFlag creates a flag; bucketKey picks the stable identity used for percentage rollouts
+func NewFlag[T any, C any](name string, defaultValue T, bucketKey func(C) string) *Flag[T, C] {
+	return &Flag[T, C]{
+		name:      name,
+		bucketKey: bucketKey,
+		config:    NewWatchable(FlagConfig[T, C]{Default: defaultValue}),
+	}
+}
+
+// Name returns the flag name
+func (f *Flag[T, C]) Name() string {
+	return f.name
+}
+
+// Configure replaces the flag's rules and default, notifying watchers
+func (f *Flag[T, C]) Configure(config FlagConfig[T, C]) {
+	f.config.Set(config)
+}
+
+// Evaluate returns the value of the first matching rule, or the default
+func (f *Flag[T, C]) Evaluate(ctx C) Evaluation[T] {
+	return f.evaluate(f.config.Get(), ctx)
+}
+
+func (f *Flag[T, C]) evaluate(config FlagConfig[T, C], ctx C) Evaluation[T] {
+	for _, rule := range config.Rules {
+		if rule.When != nil && !rule.When(ctx) {
+			continue
+		}
+		if rule.Percentage > 0 && rolloutBucket(f.name, f.bucketKey(ctx)) >= rule.Percentage {
+			continue
+		}
+		reason := "rule matched"
+		if rule.Percentage > 0 {
+			reason = fmt.Sprintf("in %d%% rollout", rule.Percentage)
+		}
+		return Evaluation[T]{Value: rule.Value, Rule: rule.Name, Reason: reason}
+	}
+	return Evaluation[T]{Value: config.Default, Reason: "default"}
+}
+
+// Value is a shorthand for Evaluate(ctx).Value
+func (f *Flag[T, C]) Value(ctx C) T {
+	return f.Evaluate(ctx).Value
+}
+
+// Watch calls fn with the old and new configuration on every change
+func (f *Flag[T, C]) Watch(fn func(old, new FlagConfig[T, C])) (cancel func()) {
+	return f.config.Watch(fn)
+}
+
+// WatchValue calls fn only when a reconfiguration changes the value seen by ctx.
+// It is a function rather than a method because it needs T to be comparable.
+func WatchValue[T comparable, C any](f *Flag[T, C], ctx C, fn func(old, new T)) (cancel func()) {
+	return f.Watch(func(oldConfig, newConfig FlagConfig[T, C]) {
+		oldValue := f.evaluate(oldConfig, ctx).Value
+		newValue := f.evaluate(newConfig, ctx).Value
+		if oldValue != newValue {
+			fn(oldValue, newValue)
+		}
+	})
+}
+
+// rolloutBucket maps a flag and identity to a stable bucket in [0, 100)
+func rolloutBucket(flag, key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
+
+// ==========================================
+// Flag Registry
+// ==========================================
+
+// flagEvaluator is the type-erased view of a Flag used by the registry
+type flagEvaluator[C any] interface {
+	Name() string
+	describe(ctx C) string
+}
+
+func (f *Flag[T, C]) describe(ctx C) string {
+	e := f.Evaluate(ctx)
+	if e.Rule == "" {
+		return fmt.Sprintf("%v (%s)", e.Value, e.Reason)
+	}
+	return fmt.Sprintf("%v (%s: %s)", e.Value, e.Rule, e.Reason)
+}
+
+// FlagRegistry groups flags of different value types sharing one context type
+type FlagRegistry[C any] struct {
+	flags []flagEvaluator[C]
+}
+
+// Register adds a flag to the registry and returns it for chaining
+func RegisterFlag[T any, C any](r *FlagRegistry[C], flag *Flag[T, C]) *Flag[T, C] {
+	r.flags = append(r.flags, flag)
+	return flag
+}
+
+// Report evaluates every registered flag for a context
+func (r *FlagRegistry[C]) Report(ctx C) []string {
+	lines := make([]string, 0, len(r.flags))
+	for _, f := range r.flags {
+		lines = append(lines, fmt.Sprintf("%s = %s", f.Name(), f.describe(ctx)))
+	}
+	return lines
+}
+
+// ==========================================
+// Example Context
+// ==========================================
+
+// UserContext is the evaluation context used by the flag examples
+type UserContext struct {
+	UserID  string
+	Country string
+	Plan    string
+	Age     int
+	Beta    bool
+}
+
+func userID(u UserContext) string      { return u.UserID }
+func userPlan(u UserContext) string    { return u.Plan }
+func userCountry(u UserContext) string { return u.Country }
+func userAge(u UserContext) int        { return u.Age }
+func userIsBeta(u UserContext) bool    { return u.Beta }
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runFeatureFlagsExample() {
+	fmt.Println("\n🔸 Typed Flags")
+
+	registry := &FlagRegistry[UserContext]{}
+
+	darkMode := RegisterFlag(registry, NewFlag("dark-mode", false, userID))
+	darkMode.Configure(FlagConfig[bool, UserContext]{
+		Rules: []Rule[bool, UserContext]{
+			{Name: "beta-testers", When: AttrIn(userIsBeta, true), Value: true},
+		},
+		Default: false,
+	})
+
+	maxUploadMB := RegisterFlag(registry, NewFlag("max-upload-mb", 10, userID))
+	maxUploadMB.Configure(FlagConfig[int, UserContext]{
+		Rules: []Rule[int, UserContext]{
+			{Name: "enterprise", When: AttrIn(userPlan, "enterprise"), Value: 1000},
+			{Name: "paid", When: AttrIn(userPlan, "pro", "team"), Value: 100},
+		},
+		Default: 10,
+	})
+
+	checkout := RegisterFlag(registry, NewFlag("checkout-flow", "classic", userID))
+	checkout.Configure(FlagConfig[string, UserContext]{
+		Rules: []Rule[string, UserContext]{
+			{Name: "adult-us-rollout", When: And(AttrIn(userCountry, "US"), AttrAtLeast(userAge, 18)), Percentage: 50, Value: "one-click"},
+		},
+		Default: "classic",
+	})
+
+	// Unset lets the call site keep its own default
+	newSearch := RegisterFlag(registry, NewFlag("new-search", Unset, userID))
+	newSearch.Configure(FlagConfig[Tristate, UserContext]{
+		Rules: []Rule[Tristate, UserContext]{
+			{Name: "free-plan-off", When: AttrIn(userPlan, "free"), Value: Off},
+			{Name: "beta-on", When: AttrIn(userIsBeta, true), Value: On},
+		},
+		Default: Unset,
+	})
+
+	users := []UserContext{
+		{UserID: "alice", Country: "US", Plan: "enterprise", Age: 34, Beta: true},
+		{UserID: "bob", Country: "DE", Plan: "free", Age: 22},
+		{UserID: "carol", Country: "US", Plan: "pro", Age: 17},
+		{UserID: "dave", Country: "US", Plan: "team", Age: 41},
+	}
+	for _, u := range users {
+		fmt.Printf("%s:\n", u.UserID)
+		for _, line := range registry.Report(u) {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	fmt.Println("\n🔸 Tri-State Fallbacks")
+
+	for _, u := range users {
+		state := newSearch.Value(u)
+		fmt.Printf("%-6s new-search=%-5s -> enabled with fallback true: %v, fallback false: %v\n",
+			u.UserID, state, state.Resolve(true), state.Resolve(false))
+	}
+
+	fmt.Println("\n🔸 Percentage Rollouts")
+
+	rollout := NewFlag("new-pricing", false, userID)
+	for _, pct := range []int{10, 50, 90} {
+		rollout.Configure(FlagConfig[bool, UserContext]{
+			Rules: []Rule[bool, UserContext]{{Name: "rollout", Percentage: pct, Value: true}},
+		})
+		enabled := 0
+		for i := 0; i < 10000; i++ {
+			if rollout.Value(UserContext{UserID: fmt.Sprintf("user-%d", i)}) {
+				enabled++
+			}
+		}
+		fmt.Printf("%d%% rollout -> %.1f%% of 10000 users enabled\n", pct, float64(enabled)/100)
+	}
+
+	// Buckets are sticky: the same user keeps their answer across evaluations
+	sticky := true
+	first := rollout.Value(users[1])
+	for i := 0; i < 100; i++ {
+		sticky = sticky && rollout.Value(users[1]) == first
+	}
+	fmt.Printf("Same user always gets the same answer: %v\n", sticky)
+
+	fmt.Println("\n🔸 Change Notification")
+
+	cancel := maxUploadMB.Watch(func(old, new FlagConfig[int, UserContext]) {
+		fmt.Printf("max-upload-mb reconfigured: default %d -> %d, %d -> %d rules\n",
+			old.Default, new.Default, len(old.Rules), len(new.Rules))
+	})
+	stopBob := WatchValue(maxUploadMB, users[1], func(old, new int) {
+		fmt.Printf("bob's upload limit changed: %dMB -> %dMB\n", old, new)
+	})
+	stopAlice := WatchValue(maxUploadMB, users[0], func(old, new int) {
+		fmt.Printf("alice's upload limit changed: %dMB -> %dMB\n", old, new)
+	})
+
+	// Raising the default affects bob (free plan) but not alice (enterprise rule)
+	maxUploadMB.Configure(FlagConfig[int, UserContext]{
+		Rules: []Rule[int, UserContext]{
+			{Name: "enterprise", When: AttrIn(userPlan, "enterprise"), Value: 1000},
+			{Name: "paid", When: AttrIn(userPlan, "pro", "team"), Value: 100},
+		},
+		Default: 25,
+	})
+
+	cancel()
+	stopBob()
+	stopAlice()
+	maxUploadMB.Configure(FlagConfig[int, UserContext]{Default: 50})
+	fmt.Printf("After unsubscribing, no notifications (bob now sees %dMB)\n", maxUploadMB.Value(users[1]))
+
+	fmt.Println("\n🔸 Rule Composition")
+
+	adultNonBeta := And(AttrAtLeast(userAge, 18), Not(AttrIn(userIsBeta, true)))
+	var names []string
+	for _, u := range users {
+		if adultNonBeta(u) {
+			names = append(names, u.UserID)
+		}
+	}
+	fmt.Printf("Adults outside the beta: %s\n", strings.Join(names, ", "))
+
+	fmt.Println("\n✅ Feature flags examples completed!")
+}
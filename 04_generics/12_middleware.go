@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Handlers and Middleware
+// ==========================================
+
+// Handler processes an input of type T into a result of type R
+type Handler[T, R any] func(T) (R, error)
+
+// Middleware wraps a handler with extra behavior
+type Middleware[T, R any] func(Handler[T, R]) Handler[T, R]
+
+// ChainMiddleware composes middlewares so the first one is the outermost.
+// (Named ChainMiddleware because Chain already builds slice operation chains.)
+func ChainMiddleware[T, R any](middlewares ...Middleware[T, R]) Middleware[T, R] {
+	return func(h Handler[T, R]) Handler[T, R] {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			h = middlewares[i](h)
+		}
+		return h
+	}
+}
+
+// ==========================================
+// Built-in Middlewares
+// ==========================================
+
+// Timing reports how long every call took
+func Timing[T, R any](report func(input T, elapsed time.Duration, err error)) Middleware[T, R] {
+	return func(next Handler[T, R]) Handler[T, R] {
+		return func(input T) (R, error) {
+			start := time.Now()
+			result, err := next(input)
+			report(input, time.Since(start), err)
+			return result, err
+		}
+	}
+}
+
+// PanicError is returned by Recovery when the wrapped handler panicked
+type PanicError struct {
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered from panic: %v", e.Value)
+}
+
+// Recovery turns panics into errors
+func Recovery[T, R any]() Middleware[T, R] {
+	return func(next Handler[T, R]) Handler[T, R] {
+		return func(input T) (result R, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					var zero R
+					result, err = zero, &PanicError{Value: r}
+				}
+			}()
+			return next(input)
+		}
+	}
+}
+
+// Retry calls the handler up to attempts times, doubling the backoff after
+// each failure; errors for which retryable returns false are not retried.
+// RetryWithPolicy (34_resilience.go) adds jitter, caps and context.
+func Retry[T, R any](attempts int, backoff time.Duration, retryable func(error) bool) Middleware[T, R] {
+	policy := RetryPolicy{MaxAttempts: attempts, InitialDelay: backoff, Retryable: retryable}
+	return func(next Handler[T, R]) Handler[T, R] {
+		return func(input T) (R, error) {
+			return RetryWithPolicy(context.Background(), policy, func(context.Context) (R, error) {
+				return next(input)
+			})
+		}
+	}
+}
+
+// Validate rejects inputs before they reach the handler
+func Validate[T, R any](check func(T) error) Middleware[T, R] {
+	return func(next Handler[T, R]) Handler[T, R] {
+		return func(input T) (R, error) {
+			if err := check(input); err != nil {
+				var zero R
+				return zero, err
+			}
+			return next(input)
+		}
+	}
+}
+
+// ==========================================
+// Adapters
+// ==========================================
+
+// HandlerFunc lifts an infallible function into a Handler
+func HandlerFunc[T, R any](fn func(T) R) Handler[T, R] {
+	return func(input T) (R, error) {
+		return fn(input), nil
+	}
+}
+
+// FromStrategy turns a Strategy into a Handler so it can be wrapped
+func FromStrategy[T, R any](strategy Strategy[T, R]) Handler[T, R] {
+	return HandlerFunc(strategy.Execute)
+}
+
+// HandlerStrategy adapts a Handler back into a Strategy; errors map to a fallback result
+type HandlerStrategy[T, R any] struct {
+	handler  Handler[T, R]
+	fallback func(T, error) R
+}
+
+func NewHandlerStrategy[T, R any](handler Handler[T, R], fallback func(T, error) R) *HandlerStrategy[T, R] {
+	return &HandlerStrategy[T, R]{handler: handler, fallback: fallback}
+}
+
+func (s *HandlerStrategy[T, R]) Execute(input T) R {
+	result, err := s.handler(input)
+	if err != nil {
+		return s.fallback(input, err)
+	}
+	return result
+}
+
+// Outcome pairs a handler result with its error for APIs that return one value
+type Outcome[T, R any] struct {
+	Input  T
+	Result R
+	Err    error
+}
+
+// AsProcessor adapts a Handler to the processor a WorkerPool expects. The
+// handler takes no context, so it runs to completion even if the job's
+// context is cancelled; the pool then reports the context error.
+func AsProcessor[T, R any](handler Handler[T, R]) func(context.Context, T) (R, error) {
+	return func(_ context.Context, input T) (R, error) {
+		return handler(input)
+	}
+}
+
+// ==========================================
+// Example Handlers
+// ==========================================
+
+var errTransient = errors.New("transient failure")
+
+// flakyService fails the first few calls for every input
+type flakyService struct {
+	mu       sync.Mutex
+	failures map[string]int
+	failFor  int
+}
+
+func (s *flakyService) Lookup(name string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures[name] < s.failFor {
+		s.failures[name]++
+		return 0, errTransient
+	}
+	return len(name) * 10, nil
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runMiddlewareExample() {
+	fmt.Println("\n🔸 Composing Middleware")
+
+	var trace []string
+	tag := func(name string) Middleware[string, string] {
+		return func(next Handler[string, string]) Handler[string, string] {
+			return func(input string) (string, error) {
+				trace = append(trace, name+" before")
+				result, err := next(input)
+				trace = append(trace, name+" after")
+				return result, err
+			}
+		}
+	}
+
+	upper := HandlerFunc(strings.ToUpper)
+	wrapped := ChainMiddleware(tag("outer"), tag("middle"), tag("inner"))(upper)
+	result, _ := wrapped("hello")
+	fmt.Printf("Result: %s\n", result)
+	fmt.Printf("Call order: %s\n", strings.Join(trace, " → "))
+
+	fmt.Println("\n🔸 Timing, Validation and Recovery")
+
+	divide := Handler[[2]int, int](func(pair [2]int) (int, error) {
+		return pair[0] / pair[1], nil // Panics on division by zero
+	})
+
+	safeDivide := ChainMiddleware(
+		Timing[[2]int, int](func(input [2]int, elapsed time.Duration, err error) {
+			status := "ok"
+			if err != nil {
+				status = err.Error()
+			}
+			fmt.Printf("  timing: %v took %v (%s)\n", input, elapsed.Round(time.Microsecond), status)
+		}),
+		Recovery[[2]int, int](),
+		Validate[[2]int, int](func(pair [2]int) error {
+			if pair[0] < 0 {
+				return fmt.Errorf("negative dividend %d", pair[0])
+			}
+			return nil
+		}),
+	)(divide)
+
+	for _, input := range [][2]int{{10, 2}, {-4, 2}, {1, 0}} {
+		result, err := safeDivide(input)
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) {
+			fmt.Printf("%v -> panic converted to error: %v\n", input, panicErr.Value)
+		} else if err != nil {
+			fmt.Printf("%v -> error: %v\n", input, err)
+		} else {
+			fmt.Printf("%v -> %d\n", input, result)
+		}
+	}
+
+	fmt.Println("\n🔸 Retry With Backoff")
+
+	service := &flakyService{failures: make(map[string]int), failFor: 2}
+	lookup := Retry[string, int](3, 5*time.Millisecond, func(err error) bool {
+		return errors.Is(err, errTransient)
+	})(service.Lookup)
+
+	value, err := lookup("gopher")
+	fmt.Printf("Lookup(gopher) with 3 attempts: %d, err=%v\n", value, err)
+
+	service.failFor = 5
+	_, err = lookup("rustacean")
+	fmt.Printf("Lookup(rustacean) still failing: %v (is transient: %v)\n", err, errors.Is(err, errTransient))
+
+	fmt.Println("\n🔸 Wrapping the Strategy Pattern")
+
+	strategy := NewConcreteStrategy(func(prices []float64) float64 {
+		highest, ok := MaxSlice(prices)
+		if !ok {
+			panic("no prices")
+		}
+		lowest, _ := MinSlice(prices)
+		return highest - lowest
+	})
+
+	// Strategy -> Handler -> middleware -> Strategy, so Context is unchanged
+	guarded := Recovery[[]float64, float64]()(FromStrategy[[]float64, float64](strategy))
+	ctx := NewContext[[]float64, float64](NewHandlerStrategy(guarded, func(_ []float64, err error) float64 {
+		fmt.Printf("  strategy failed (%v), using 0\n", err)
+		return 0
+	}))
+	fmt.Printf("Price spread: %.2f\n", ctx.ExecuteStrategy([]float64{9.5, 12.25, 10.0}))
+	fmt.Printf("Empty spread: %.2f\n", ctx.ExecuteStrategy(nil))
+
+	fmt.Println("\n🔸 Wrapping the Worker Pool Processor")
+
+	var mu sync.Mutex
+	calls := 0
+	// Retry wraps Recovery, so a recovered panic reaches Retry as an error
+	// and is retried like any other failure
+	processor := ChainMiddleware(
+		Retry[string, int](3, time.Millisecond, nil),
+		Recovery[string, int](),
+	)(func(word string) (int, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		if word == "" {
+			panic("empty word")
+		}
+		return len(word), nil
+	})
+
+	words := []string{"generic", "", "middleware", "go"}
+	pool := NewWorkerPool(2, AsProcessor(processor))
+	var results []<-chan Outcome[string, int]
+	for _, w := range words {
+		result, _ := pool.Submit(context.Background(), w)
+		results = append(results, result)
+	}
+
+	outcomes := make(map[string]Outcome[string, int])
+	for _, result := range results {
+		o := <-result
+		outcomes[o.Input] = o
+	}
+	pool.Drain()
+
+	for _, w := range words {
+		o := outcomes[w]
+		if o.Err != nil {
+			fmt.Printf("  %-12q error: %v\n", w, o.Err)
+		} else {
+			fmt.Printf("  %-12q length %d\n", w, o.Result)
+		}
+	}
+	fmt.Printf("Handler calls: %d (one per word, plus 2 retries of the panicking one)\n", calls)
+
+	fmt.Println("\n✅ Middleware examples completed!")
+}
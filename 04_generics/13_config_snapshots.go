@@ -0,0 +1,307 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Immutable Snapshots
+// ==========================================
+
+// Snapshot is one published, never-modified version of a configuration
+type Snapshot[T any] struct {
+	Version   int
+	Value     T
+	Note      string
+	CreatedAt time.Time
+}
+
+// ErrUnknownVersion is returned when rolling back to a version not in history
+var ErrUnknownVersion = errors.New("config: version not in history")
+
+// Config publishes immutable snapshots of T. Readers load the current snapshot
+// with a single atomic read and never block; writers copy the current value,
+// modify the copy and atomically swap it in (copy-on-write).
+type Config[T any] struct {
+	current    atomic.Pointer[Snapshot[T]]
+	mu         sync.Mutex // Serializes writers only
+	clone      func(T) T
+	validate   func(T) error
+	history    []*Snapshot[T]
+	maxHistory int
+	changes    *Watchable[*Snapshot[T]]
+}
+
+// ConfigOptions customizes copying, validation and history length
+type ConfigOptions[T any] struct {
+	// Clone deep-copies a value before Update modifies it. Without it the copy
+	// is shallow, so slices and maps would be shared between versions.
+	Clone      func(T) T
+	Validate   func(T) error
+	MaxHistory int
+}
+
+// NewConfig publishes initial as version 1
+func NewConfig[T any](initial T, opts ConfigOptions[T]) *Config[T] {
+	if opts.Clone == nil {
+		opts.Clone = func(v T) T { return v }
+	}
+	if opts.MaxHistory < 1 {
+		opts.MaxHistory = 10
+	}
+
+	first := &Snapshot[T]{Version: 1, Value: opts.Clone(initial), Note: "initial", CreatedAt: time.Now()}
+	c := &Config[T]{
+		clone:      opts.Clone,
+		validate:   opts.Validate,
+		history:    []*Snapshot[T]{first},
+		maxHistory: opts.MaxHistory,
+		changes:    NewWatchable(first),
+	}
+	c.current.Store(first)
+	return c
+}
+
+// Load returns the current snapshot without locking
+func (c *Config[T]) Load() *Snapshot[T] {
+	return c.current.Load()
+}
+
+// Get returns a copy of the current value, safe for the caller to modify
+func (c *Config[T]) Get() T {
+	return c.clone(c.current.Load().Value)
+}
+
+// Update applies fn to a copy of the current value and publishes the result
+// as a new version. Invalid results are rejected and nothing is published.
+func (c *Config[T]) Update(note string, fn func(*T)) (*Snapshot[T], error) {
+	// Deferred before the unlock so watchers run after it and may call
+	// Update themselves
+	notify := func() {}
+	defer func() { notify() }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	next := c.clone(c.current.Load().Value)
+	fn(&next)
+	return c.publish(note, next, &notify)
+}
+
+// Rollback republishes the value of an earlier version as a new version
+func (c *Config[T]) Rollback(version int) (*Snapshot[T], error) {
+	notify := func() {}
+	defer func() { notify() }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range c.history {
+		if s.Version == version {
+			return c.publish(fmt.Sprintf("rollback to v%d", version), c.clone(s.Value), &notify)
+		}
+	}
+	return nil, fmt.Errorf("%w: v%d", ErrUnknownVersion, version)
+}
+
+// publish must be called with c.mu held. It sets *notify to the watcher
+// callbacks, which the caller runs once it has released c.mu.
+func (c *Config[T]) publish(note string, value T, notify *func()) (*Snapshot[T], error) {
+	if c.validate != nil {
+		if err := c.validate(value); err != nil {
+			return nil, fmt.Errorf("config update %q rejected: %w", note, err)
+		}
+	}
+
+	snapshot := &Snapshot[T]{
+		Version:   c.current.Load().Version + 1,
+		Value:     value,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+	c.current.Store(snapshot)
+
+	c.history = append(c.history, snapshot)
+	if len(c.history) > c.maxHistory {
+		c.history = c.history[len(c.history)-c.maxHistory:]
+	}
+
+	*notify = c.changes.swap(snapshot)
+	return snapshot, nil
+}
+
+// History returns the retained snapshots, oldest first
+func (c *Config[T]) History() []*Snapshot[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Snapshot[T](nil), c.history...)
+}
+
+// Watch calls fn with the previous and new snapshot after every publish.
+// It runs outside the writer lock, so fn may update the config again.
+func (c *Config[T]) Watch(fn func(old, new *Snapshot[T])) (cancel func()) {
+	return c.changes.Watch(fn)
+}
+
+// ==========================================
+// Example Configuration
+// ==========================================
+
+// ServiceSettings is the configuration managed in the examples
+type ServiceSettings struct {
+	Name     string
+	Port     int
+	MinConns int
+	MaxConns int
+	Timeout  time.Duration
+	Features []string
+	Limits   map[string]int
+}
+
+// Clone deep-copies the slice and map fields
+func (s ServiceSettings) Clone() ServiceSettings {
+	s.Features = append([]string(nil), s.Features...)
+	limits := make(map[string]int, len(s.Limits))
+	for k, v := range s.Limits {
+		limits[k] = v
+	}
+	s.Limits = limits
+	return s
+}
+
+// Validate checks invariants that must hold in every published version
+func (s ServiceSettings) Validate() error {
+	if s.Port < 1 || s.Port > 65535 {
+		return fmt.Errorf("port %d out of range", s.Port)
+	}
+	if s.MinConns > s.MaxConns {
+		return fmt.Errorf("min conns %d exceeds max conns %d", s.MinConns, s.MaxConns)
+	}
+	return nil
+}
+
+func (s ServiceSettings) String() string {
+	keys := make([]string, 0, len(s.Limits))
+	for k := range s.Limits {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	limits := make([]string, len(keys))
+	for i, k := range keys {
+		limits[i] = fmt.Sprintf("%s=%d", k, s.Limits[k])
+	}
+	return fmt.Sprintf("%s:%d conns=%d..%d timeout=%v features=%v limits=%v",
+		s.Name, s.Port, s.MinConns, s.MaxConns, s.Timeout, s.Features, limits)
+}
+
+func defaultServiceSettings() ServiceSettings {
+	// The builder from the design patterns example assembles the initial value
+	return NewConfigBuilder(ServiceSettings{Name: "api"}).
+		Set(func(s *ServiceSettings) { s.Port = 8080 }).
+		Set(func(s *ServiceSettings) { s.MinConns, s.MaxConns = 2, 10 }).
+		Set(func(s *ServiceSettings) { s.Timeout = 5 * time.Second }).
+		Set(func(s *ServiceSettings) { s.Features = []string{"search"} }).
+		Set(func(s *ServiceSettings) { s.Limits = map[string]int{"rps": 100} }).
+		Build()
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runConfigSnapshotsExample() {
+	fmt.Println("\n🔸 Copy-on-Write Updates")
+
+	config := NewConfig(defaultServiceSettings(), ConfigOptions[ServiceSettings]{
+		Clone:      ServiceSettings.Clone,
+		Validate:   ServiceSettings.Validate,
+		MaxHistory: 5,
+	})
+
+	cancel := config.Watch(func(old, new *Snapshot[ServiceSettings]) {
+		fmt.Printf("  📣 v%d -> v%d (%s)\n", old.Version, new.Version, new.Note)
+	})
+	defer cancel()
+
+	before := config.Load()
+	config.Update("enable export", func(s *ServiceSettings) {
+		s.Features = append(s.Features, "export")
+		s.Limits["rps"] = 250
+	})
+	after := config.Load()
+
+	// The old snapshot is untouched: readers holding it see a consistent value
+	fmt.Printf("Held v%d: %v\n", before.Version, before.Value)
+	fmt.Printf("Now  v%d: %v\n", after.Version, after.Value)
+
+	fmt.Println("\n🔸 Why Clone Matters")
+
+	shallow := NewConfig(defaultServiceSettings(), ConfigOptions[ServiceSettings]{})
+	v1 := shallow.Load()
+	shallow.Update("shallow edit", func(s *ServiceSettings) { s.Limits["rps"] = 999 })
+	fmt.Printf("Without Clone, v1 limits were mutated: rps=%d (was 100)\n", v1.Value.Limits["rps"])
+
+	fmt.Println("\n🔸 Validation")
+
+	_, err := config.Update("bad pool", func(s *ServiceSettings) { s.MinConns = 50 })
+	fmt.Printf("Rejected: %v\n", err)
+	fmt.Printf("Still at v%d\n", config.Load().Version)
+
+	fmt.Println("\n🔸 Lock-Free Readers During Updates")
+
+	var wg sync.WaitGroup
+	var reads, inconsistent atomic.Int64
+	stop := make(chan struct{})
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				// A snapshot is always internally consistent, even mid-update
+				s := config.Load().Value
+				if s.MinConns > s.MaxConns {
+					inconsistent.Add(1)
+				}
+				reads.Add(1)
+			}
+		}()
+	}
+
+	for i := 0; i < 3; i++ {
+		size := (i + 2) * 10
+		config.Update(fmt.Sprintf("resize pool to %d", size), func(s *ServiceSettings) {
+			// Two related fields change together; readers never see one without the other
+			s.MaxConns = size
+			s.MinConns = size / 2
+		})
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+	fmt.Printf("Readers performed %d loads, inconsistent snapshots seen: %d\n", reads.Load(), inconsistent.Load())
+
+	fmt.Println("\n🔸 History and Rollback")
+
+	for _, s := range config.History() {
+		fmt.Printf("  v%d %-20s %v\n", s.Version, s.Note, s.Value)
+	}
+
+	if _, err := config.Rollback(2); err != nil {
+		fmt.Printf("Rollback failed: %v\n", err)
+	}
+	fmt.Printf("After rollback: v%d %v\n", config.Load().Version, config.Load().Value)
+
+	_, err = config.Rollback(1)
+	fmt.Printf("Rollback beyond history: %v (is ErrUnknownVersion: %v)\n", err, errors.Is(err, ErrUnknownVersion))
+
+	fmt.Println("\n✅ Config snapshots examples completed!")
+}
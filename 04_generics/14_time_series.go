@@ -0,0 +1,307 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Time-Series Ring Buffer
+// ==========================================
+
+// Point is one timestamped sample
+type Point[T Number] struct {
+	Time  time.Time
+	Value T
+}
+
+// ErrOutOfOrder is returned when a point is older than the newest stored point
+var ErrOutOfOrder = errors.New("series: point is older than the newest sample")
+
+// Series keeps the most recent capacity points in a fixed ring buffer.
+// Points must arrive in time order, which keeps the buffer sorted so range
+// queries are binary searches. All methods are safe for concurrent use.
+type Series[T Number] struct {
+	mu     sync.RWMutex
+	points []Point[T]
+	start  int // Index of the oldest point
+	size   int
+}
+
+// NewSeries creates a series retaining at most capacity points
+func NewSeries[T Number](capacity int) *Series[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Series[T]{points: make([]Point[T], capacity)}
+}
+
+// at maps a logical index (0 = oldest) to the ring; callers hold the lock
+func (s *Series[T]) at(i int) Point[T] {
+	return s.points[(s.start+i)%len(s.points)]
+}
+
+// Append stores a point, overwriting the oldest one when the buffer is full
+func (s *Series[T]) Append(t time.Time, value T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size > 0 && t.Before(s.at(s.size-1).Time) {
+		return fmt.Errorf("%w: %v < %v", ErrOutOfOrder, t.Format(time.TimeOnly), s.at(s.size-1).Time.Format(time.TimeOnly))
+	}
+
+	if s.size < len(s.points) {
+		s.points[(s.start+s.size)%len(s.points)] = Point[T]{Time: t, Value: value}
+		s.size++
+		return nil
+	}
+	s.points[s.start] = Point[T]{Time: t, Value: value}
+	s.start = (s.start + 1) % len(s.points)
+	return nil
+}
+
+// Len returns the number of retained points
+func (s *Series[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// Query returns a copy of the points with from <= Time < to
+func (s *Series[T]) Query(from, to time.Time) []Point[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lo := sort.Search(s.size, func(i int) bool { return !s.at(i).Time.Before(from) })
+	hi := sort.Search(s.size, func(i int) bool { return !s.at(i).Time.Before(to) })
+
+	result := make([]Point[T], 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		result = append(result, s.at(i))
+	}
+	return result
+}
+
+// Points returns a copy of all retained points, oldest first
+func (s *Series[T]) Points() []Point[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Point[T], s.size)
+	for i := range result {
+		result[i] = s.at(i)
+	}
+	return result
+}
+
+// Downsample aggregates all retained points into fixed-width time buckets
+func (s *Series[T]) Downsample(bucket time.Duration, aggFn Aggregator[T]) []Point[T] {
+	return DownsamplePoints(s.Points(), bucket, aggFn)
+}
+
+// ==========================================
+// Aggregation
+// ==========================================
+
+// Aggregator reduces the values of one bucket to a single value
+type Aggregator[T Number] func(values []T) T
+
+// Aggregators built on the statistics helpers from the algorithms example
+func AggSum[T Number](values []T) T { return SumSlice(values) }
+func AggAvg[T Number](values []T) T { return AverageSlice(values) }
+
+func AggMax[T Number](values []T) T {
+	v, _ := MaxSlice(values)
+	return v
+}
+
+func AggMin[T Number](values []T) T {
+	v, _ := MinSlice(values)
+	return v
+}
+
+func AggCount[T Number](values []T) T { return T(len(values)) }
+
+func AggLast[T Number](values []T) T { return values[len(values)-1] }
+
+// AggPercentile returns an aggregator for the p-th percentile (0-100, nearest rank)
+func AggPercentile[T Number](p float64) Aggregator[T] {
+	return func(values []T) T {
+		sorted := append([]T(nil), values...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		rank := int(p/100*float64(len(sorted))+0.5) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		if rank >= len(sorted) {
+			rank = len(sorted) - 1
+		}
+		return sorted[rank]
+	}
+}
+
+// DownsamplePoints groups sorted points into buckets aligned to multiples of
+// bucket and emits one point per non-empty bucket, stamped with its start
+func DownsamplePoints[T Number](points []Point[T], bucket time.Duration, aggFn Aggregator[T]) []Point[T] {
+	var result []Point[T]
+	var values []T
+	var current time.Time
+
+	flush := func() {
+		if len(values) > 0 {
+			result = append(result, Point[T]{Time: current, Value: aggFn(values)})
+			values = values[:0]
+		}
+	}
+
+	for _, p := range points {
+		start := p.Time.Truncate(bucket)
+		if !start.Equal(current) {
+			flush()
+			current = start
+		}
+		values = append(values, p.Value)
+	}
+	flush()
+	return result
+}
+
+// ==========================================
+// Histogram Backend
+// ==========================================
+
+// Histogram records observations into a Series so quantiles can be computed
+// over a sliding window. It is safe for concurrent observers, which is what
+// a metrics registry needs from a histogram backend.
+type Histogram[T Number] struct {
+	series *Series[T]
+	now    func() time.Time
+	mu     sync.Mutex // Keeps timestamps monotonic across concurrent Observe calls
+}
+
+// NewHistogram creates a histogram retaining the last capacity observations
+func NewHistogram[T Number](capacity int, now func() time.Time) *Histogram[T] {
+	if now == nil {
+		now = time.Now
+	}
+	return &Histogram[T]{series: NewSeries[T](capacity), now: now}
+}
+
+// Observe records one value at the current time
+func (h *Histogram[T]) Observe(value T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.series.Append(h.now(), value)
+}
+
+// Quantiles returns the requested percentiles over the last window
+func (h *Histogram[T]) Quantiles(window time.Duration, percentiles ...float64) (count int, values []T) {
+	now := h.now()
+	points := h.series.Query(now.Add(-window), now.Add(time.Nanosecond))
+	if len(points) == 0 {
+		return 0, nil
+	}
+
+	raw := Map(points, func(p Point[T]) T { return p.Value })
+	for _, p := range percentiles {
+		values = append(values, AggPercentile[T](p)(raw))
+	}
+	return len(raw), values
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runTimeSeriesExample() {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	fmt.Println("\n🔸 Ring Buffer Retention")
+
+	series := NewSeries[float64](8)
+	for i := 0; i < 12; i++ {
+		series.Append(base.Add(time.Duration(i)*time.Second), float64(i*10))
+	}
+	points := series.Points()
+	fmt.Printf("Appended 12 points into capacity 8, retained %d: first=%v last=%v\n",
+		series.Len(), points[0].Value, points[len(points)-1].Value)
+
+	err := series.Append(base, 1)
+	fmt.Printf("Out-of-order append: %v (is ErrOutOfOrder: %v)\n", err, errors.Is(err, ErrOutOfOrder))
+
+	fmt.Println("\n🔸 Range Queries")
+
+	for _, p := range series.Query(base.Add(6*time.Second), base.Add(9*time.Second)) {
+		fmt.Printf("  %s -> %v\n", p.Time.Format(time.TimeOnly), p.Value)
+	}
+
+	fmt.Println("\n🔸 Downsampling")
+
+	// One latency sample per second for ten minutes, with a slow spell in minute 6
+	rng := rand.New(rand.NewSource(42))
+	latency := NewSeries[int](600)
+	for i := 0; i < 600; i++ {
+		ms := 20 + rng.Intn(30)
+		if i >= 300 && i < 360 {
+			ms += 200
+		}
+		latency.Append(base.Add(time.Duration(i)*time.Second), ms)
+	}
+
+	aggregators := []struct {
+		name string
+		fn   Aggregator[int]
+	}{
+		{"avg", AggAvg[int]},
+		{"max", AggMax[int]},
+		{"p95", AggPercentile[int](95)},
+		{"count", AggCount[int]},
+	}
+
+	perMinute := make([][]Point[int], len(aggregators))
+	for i, a := range aggregators {
+		perMinute[i] = latency.Downsample(time.Minute, a.fn)
+	}
+	fmt.Printf("  %-8s %5s %5s %5s %5s\n", "minute", "avg", "max", "p95", "count")
+	for m := range perMinute[0] {
+		fmt.Printf("  %-8s", perMinute[0][m].Time.Format("15:04"))
+		for i := range aggregators {
+			fmt.Printf(" %5d", perMinute[i][m].Value)
+		}
+		fmt.Println()
+	}
+
+	// Downsampling composes with Query for a zoomed-in view
+	zoom := DownsamplePoints(latency.Query(base.Add(290*time.Second), base.Add(310*time.Second)), 5*time.Second, AggMax[int])
+	fmt.Print("Zoom (5s max around the slow spell):")
+	for _, p := range zoom {
+		fmt.Printf(" %s=%d", p.Time.Format("04:05"), p.Value)
+	}
+	fmt.Println()
+
+	fmt.Println("\n🔸 Concurrent Histogram Backend")
+
+	histogram := NewHistogram[float64](10_000, nil)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i < 500; i++ {
+				histogram.Observe(rng.ExpFloat64() * 10)
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	count, quantiles := histogram.Quantiles(time.Minute, 50, 90, 99)
+	fmt.Printf("Observed %d values from 8 goroutines\n", count)
+	fmt.Printf("p50=%.2f p90=%.2f p99=%.2f\n", quantiles[0], quantiles[1], quantiles[2])
+
+	fmt.Println("\n✅ Time series examples completed!")
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Single-Assignment Dataflow Variables
+// ==========================================
+
+// ErrAlreadySet is returned when an IVar is written a second time
+var ErrAlreadySet = errors.New("ivar: already set")
+
+// IVar is a write-once dataflow variable. Any number of readers block in Get
+// until the single write (a value or an error) happens; later writes fail.
+type IVar[T any] struct {
+	mu    sync.Mutex
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// NewIVar creates an unset IVar
+func NewIVar[T any]() *IVar[T] {
+	return &IVar[T]{done: make(chan struct{})}
+}
+
+// Resolved creates an IVar that is already set to value
+func Resolved[T any](value T) *IVar[T] {
+	v := NewIVar[T]()
+	v.Put(value)
+	return v
+}
+
+// Put sets the value, waking all readers
+func (v *IVar[T]) Put(value T) error {
+	return v.resolve(value, nil)
+}
+
+// Fail sets an error instead of a value
+func (v *IVar[T]) Fail(err error) error {
+	var zero T
+	return v.resolve(zero, err)
+}
+
+func (v *IVar[T]) resolve(value T, err error) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	select {
+	case <-v.done:
+		return ErrAlreadySet
+	default:
+	}
+	v.value, v.err = value, err
+	close(v.done)
+	return nil
+}
+
+// Get blocks until the IVar is set
+func (v *IVar[T]) Get() (T, error) {
+	<-v.done
+	return v.value, v.err
+}
+
+// GetContext blocks until the IVar is set or ctx is done
+func (v *IVar[T]) GetContext(ctx context.Context) (T, error) {
+	select {
+	case <-v.done:
+		return v.value, v.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done is closed once the IVar is set
+func (v *IVar[T]) Done() <-chan struct{} {
+	return v.done
+}
+
+// ==========================================
+// Piping IVars Into Graphs
+// ==========================================
+
+// Then fires fn as soon as in resolves; an error in the input skips fn and
+// flows straight to the output
+func Then[A, B any](in *IVar[A], fn func(A) (B, error)) *IVar[B] {
+	out := NewIVar[B]()
+	go func() {
+		a, err := in.Get()
+		if err != nil {
+			out.Fail(err)
+			return
+		}
+		b, err := fn(a)
+		if err != nil {
+			out.Fail(err)
+			return
+		}
+		out.Put(b)
+	}()
+	return out
+}
+
+// Join2 fires fn once both inputs resolve, failing with the first input error
+func Join2[A, B, R any](a *IVar[A], b *IVar[B], fn func(A, B) (R, error)) *IVar[R] {
+	out := NewIVar[R]()
+	go func() {
+		va, err := a.Get()
+		if err != nil {
+			out.Fail(err)
+			return
+		}
+		vb, err := b.Get()
+		if err != nil {
+			out.Fail(err)
+			return
+		}
+		r, err := fn(va, vb)
+		if err != nil {
+			out.Fail(err)
+			return
+		}
+		out.Put(r)
+	}()
+	return out
+}
+
+// Gather resolves to all input values in order, or to the first error
+func Gather[T any](inputs ...*IVar[T]) *IVar[[]T] {
+	out := NewIVar[[]T]()
+	go func() {
+		values := make([]T, len(inputs))
+		for i, in := range inputs {
+			v, err := in.Get()
+			if err != nil {
+				out.Fail(err)
+				return
+			}
+			values[i] = v
+		}
+		out.Put(values)
+	}()
+	return out
+}
+
+// Pipe forwards the result of src into an existing IVar dst
+func Pipe[T any](src, dst *IVar[T]) {
+	go func() {
+		v, err := src.Get()
+		if err != nil {
+			dst.Fail(err)
+			return
+		}
+		dst.Put(v)
+	}()
+}
+
+// ==========================================
+// Example Graph
+// ==========================================
+
+// dataflowTrace logs when each node fires relative to the start of the graph
+type dataflowTrace struct {
+	start time.Time
+	mu    sync.Mutex
+	lines []string
+}
+
+func (t *dataflowTrace) node(name string, work time.Duration) {
+	begin := time.Since(t.start)
+	time.Sleep(work)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, fmt.Sprintf("  %-16s fired at %3dms, done at %3dms", name,
+		begin.Milliseconds(), time.Since(t.start).Milliseconds()))
+}
+
+func (t *dataflowTrace) print() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Println(strings.Join(t.lines, "\n"))
+}
+
+type userProfile struct {
+	ID   int
+	Name string
+	Age  int
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runDataflowExample() {
+	fmt.Println("\n🔸 Write-Once, Many Readers")
+
+	answer := NewIVar[int]()
+	var wg sync.WaitGroup
+	for r := 1; r <= 3; r++ {
+		wg.Add(1)
+		go func(reader int) {
+			defer wg.Done()
+			v, _ := answer.Get()
+			fmt.Printf("  reader %d woke with %d\n", reader, v)
+		}(r)
+	}
+	time.Sleep(10 * time.Millisecond)
+	answer.Put(42)
+	wg.Wait()
+	fmt.Printf("Second Put: %v\n", answer.Put(7))
+
+	fmt.Println("\n🔸 Composed Futures as a Dataflow Graph")
+
+	// The compose-future example from the concurrency package chains the
+	// same steps with Async().Then() on untyped futures; here they are typed
+	// IVars, and the graph can fan out: every node fires when its input resolves.
+	trace := &dataflowTrace{start: time.Now()}
+
+	userID := NewIVar[int]()
+	userInfo := Then(userID, func(id int) (userProfile, error) {
+		trace.node("userInfo", 200*time.Millisecond)
+		return userProfile{ID: id, Name: "John", Age: 30}, nil
+	})
+	orders := Then(userInfo, func(u userProfile) ([]string, error) {
+		trace.node("orders", 150*time.Millisecond)
+		return []string{fmt.Sprintf("order-%d-1", u.ID), fmt.Sprintf("order-%d-2", u.ID)}, nil
+	})
+
+	// Diamond: recommendations also depend on userInfo and run beside orders
+	recommendations := Then(userInfo, func(u userProfile) ([]string, error) {
+		trace.node("recommendations", 100*time.Millisecond)
+		return []string{"book", "lamp"}, nil
+	})
+	page := Join2(orders, recommendations, func(o, r []string) (string, error) {
+		trace.node("page", 10*time.Millisecond)
+		return fmt.Sprintf("orders=%v recommended=%v", o, r), nil
+	})
+
+	go func() {
+		trace.node("userID", 100*time.Millisecond)
+		userID.Put(12345)
+	}()
+
+	result, err := page.Get()
+	trace.print()
+	fmt.Printf("Result: %s (err=%v)\n", result, err)
+
+	fmt.Println("\n🔸 Error Propagation")
+
+	failing := NewIVar[int]()
+	downstream := Then(Then(failing, func(id int) (string, error) {
+		fmt.Println("  never runs")
+		return "", nil
+	}), func(s string) (int, error) {
+		return len(s), nil
+	})
+	failing.Fail(errors.New("user service unavailable"))
+	_, err = downstream.Get()
+	fmt.Printf("Downstream error: %v\n", err)
+
+	fmt.Println("\n🔸 Gather and Timeouts")
+
+	shards := make([]*IVar[int], 4)
+	for i := range shards {
+		shards[i] = NewIVar[int]()
+	}
+	for i, shard := range shards {
+		go func(i int, shard *IVar[int]) {
+			time.Sleep(time.Duration(4-i) * 10 * time.Millisecond)
+			shard.Put(i * 100)
+		}(i, shard)
+	}
+	all, _ := Gather(shards...).Get()
+	fmt.Printf("Gathered in input order: %v\n", all)
+
+	slow := NewIVar[string]()
+	forwarded := NewIVar[string]()
+	Pipe(slow, forwarded)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = forwarded.GetContext(ctx)
+	fmt.Printf("Waiting on an unresolved IVar: %v\n", err)
+	slow.Put("late")
+	late, _ := forwarded.Get()
+	fmt.Printf("Resolved later: %q\n", late)
+
+	fmt.Println("\n✅ Dataflow examples completed!")
+}
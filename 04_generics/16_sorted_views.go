@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ==========================================
+// Comparators
+// ==========================================
+
+// Cmp compares two values, returning a negative number when a sorts before b,
+// zero when they are equal and a positive number otherwise
+type Cmp[T any] func(a, b T) int
+
+// CmpOrdered is the natural order of a Sortable type
+func CmpOrdered[T Sortable](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// CmpBy orders values by a Sortable key
+func CmpBy[T any, K Sortable](key func(T) K) Cmp[T] {
+	return func(a, b T) int { return CmpOrdered(key(a), key(b)) }
+}
+
+// Reversed flips the order
+func (c Cmp[T]) Reversed() Cmp[T] {
+	return func(a, b T) int { return c(b, a) }
+}
+
+// ThenBy breaks ties with a second comparator
+func (c Cmp[T]) ThenBy(next Cmp[T]) Cmp[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// SortedBy returns a sorted copy, leaving the input untouched
+func SortedBy[T any](items []T, cmp Cmp[T]) []T {
+	sorted := append([]T(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return cmp(sorted[i], sorted[j]) < 0 })
+	return sorted
+}
+
+// ==========================================
+// Sorted Views Over Unordered Containers
+// ==========================================
+
+// KeyValueSource is anything that can visit its entries, such as SafeMap
+type KeyValueSource[K comparable, V any] interface {
+	ForEach(fn func(K, V))
+}
+
+// MapSource adapts a plain map to KeyValueSource
+type MapSource[K comparable, V any] map[K]V
+
+// ForEach visits the entries in map order
+func (m MapSource[K, V]) ForEach(fn func(K, V)) {
+	for k, v := range m {
+		fn(k, v)
+	}
+}
+
+// SortedKeys returns the keys of a SafeMap in cmp order
+func SortedKeys[K comparable, V any](m *SafeMap[K, V], cmp Cmp[K]) []K {
+	return SortedBy(m.Keys(), cmp)
+}
+
+// SortedView returns the items of a Set in cmp order
+func SortedView[T comparable](s *Set[T], cmp Cmp[T]) []T {
+	return SortedBy(s.ToSlice(), cmp)
+}
+
+// IterateSorted visits entries in key order. Entries are snapshotted first, so
+// fn may safely call back into a SafeMap without deadlocking.
+func IterateSorted[K comparable, V any](src KeyValueSource[K, V], cmp Cmp[K], fn func(K, V)) {
+	var entries []Pair[K, V]
+	src.ForEach(func(k K, v V) {
+		entries = append(entries, NewPair(k, v))
+	})
+
+	sorted := SortedBy(entries, func(a, b Pair[K, V]) int { return cmp(a.First, b.First) })
+	for _, e := range sorted {
+		fn(e.First, e.Second)
+	}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runSortedViewsExample() {
+	fmt.Println("\n🔸 Map Iteration Order Is Random")
+
+	scores := NewSafeMap[string, int]()
+	for name, score := range map[string]int{"dave": 71, "alice": 92, "carol": 85, "bob": 85, "erin": 60} {
+		scores.Set(name, score)
+	}
+
+	// Keys() follows map order, which Go randomizes on every range, so its
+	// output is not shown; the sorted view is the same on every run
+	fmt.Println("Keys() may return a different order on every call")
+	fmt.Printf("SortedKeys is stable: %v\n", SortedKeys(scores, CmpOrdered[string]))
+
+	fmt.Println("\n🔸 Sorted Iteration")
+
+	IterateSorted(scores, CmpOrdered[string], func(name string, score int) {
+		fmt.Printf("  %-6s %d\n", name, score)
+	})
+
+	fmt.Println("\n🔸 Composed Comparators")
+
+	type entry = Pair[string, int]
+	var ranking []entry
+	scores.ForEach(func(name string, score int) { ranking = append(ranking, NewPair(name, score)) })
+
+	// Highest score first, ties broken alphabetically
+	byScore := CmpBy(func(e entry) int { return e.Second }).Reversed()
+	byName := CmpBy(func(e entry) string { return e.First })
+	for i, e := range SortedBy(ranking, byScore.ThenBy(byName)) {
+		fmt.Printf("  #%d %s (%d)\n", i+1, e.First, e.Second)
+	}
+
+	fmt.Println("\n🔸 Sets and Plain Maps")
+
+	primes := NewSet[int]()
+	for _, p := range []int{13, 2, 7, 3, 11, 5} {
+		primes.Add(p)
+	}
+	fmt.Printf("Ascending:  %v\n", SortedView(primes, CmpOrdered[int]))
+	fmt.Printf("Descending: %v\n", SortedView(primes, Cmp[int](CmpOrdered[int]).Reversed()))
+
+	inventory := MapSource[string, int]{"pears": 3, "apples": 10, "figs": 0}
+	IterateSorted(inventory, CmpOrdered[string], func(item string, count int) {
+		fmt.Printf("  %s=%d", item, count)
+	})
+	fmt.Println()
+
+	fmt.Println("\n✅ Sorted views examples completed!")
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"strings"
+)
+
+// ==========================================
+// Dimension-Safe Vectors
+// ==========================================
+
+// Go has no const generics (no Vec[N int]), but a type parameter constrained
+// to a set of fixed-size arrays gets close: the array length is part of the
+// type, so Vec[[2]float64] and Vec[[3]float64] are unrelated types and mixing
+// them is a compile error. len and indexing work across the whole type set;
+// range does not, because the arrays share no core type.
+
+// Components is the set of backing arrays a vector may have. Adding [4]float64
+// here is all it takes to support another dimension.
+type Components interface {
+	[2]float64 | [3]float64
+}
+
+// Vec is a vector whose dimension is fixed by its backing array type
+type Vec[C Components] struct {
+	c C
+}
+
+// Vec2 and Vec3 are the concrete instantiations
+type (
+	Vec2 = Vec[[2]float64]
+	Vec3 = Vec[[3]float64]
+)
+
+// NewVec2 creates a 2D vector
+func NewVec2(x, y float64) Vec2 {
+	return Vec2{c: [2]float64{x, y}}
+}
+
+// NewVec3 creates a 3D vector
+func NewVec3(x, y, z float64) Vec3 {
+	return Vec3{c: [3]float64{x, y, z}}
+}
+
+// Dim is the dimension, a constant per instantiation
+func (v Vec[C]) Dim() int {
+	return len(v.c)
+}
+
+// At returns the i-th component
+func (v Vec[C]) At(i int) float64 {
+	return v.c[i]
+}
+
+// zipWith combines two vectors of the same dimension component by component.
+// v is a copy, so writing into v.c does not touch the caller's vector.
+func (v Vec[C]) zipWith(w Vec[C], fn func(a, b float64) float64) Vec[C] {
+	for i := 0; i < len(v.c); i++ {
+		v.c[i] = fn(v.c[i], w.c[i])
+	}
+	return v
+}
+
+// Add returns v + w
+func (v Vec[C]) Add(w Vec[C]) Vec[C] {
+	return v.zipWith(w, func(a, b float64) float64 { return a + b })
+}
+
+// Sub returns v - w
+func (v Vec[C]) Sub(w Vec[C]) Vec[C] {
+	return v.zipWith(w, func(a, b float64) float64 { return a - b })
+}
+
+// Scale returns k * v
+func (v Vec[C]) Scale(k float64) Vec[C] {
+	return v.zipWith(v, func(a, _ float64) float64 { return a * k })
+}
+
+// Dot returns the dot product
+func (v Vec[C]) Dot(w Vec[C]) float64 {
+	sum := 0.0
+	for i := 0; i < len(v.c); i++ {
+		sum += v.c[i] * w.c[i]
+	}
+	return sum
+}
+
+// Norm returns the Euclidean (L2) length
+func (v Vec[C]) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Norm1 returns the Manhattan (L1) length
+func (v Vec[C]) Norm1() float64 {
+	sum := 0.0
+	for i := 0; i < len(v.c); i++ {
+		sum += math.Abs(v.c[i])
+	}
+	return sum
+}
+
+// NormInf returns the largest absolute component (L∞)
+func (v Vec[C]) NormInf() float64 {
+	largest := 0.0
+	for i := 0; i < len(v.c); i++ {
+		largest = math.Max(largest, math.Abs(v.c[i]))
+	}
+	return largest
+}
+
+// Normalize returns the unit vector in the direction of v, or false for the
+// zero vector
+func (v Vec[C]) Normalize() (Vec[C], bool) {
+	n := v.Norm()
+	if n == 0 {
+		return v, false
+	}
+	return v.Scale(1 / n), true
+}
+
+func (v Vec[C]) String() string {
+	parts := make([]string, len(v.c))
+	for i := 0; i < len(v.c); i++ {
+		parts[i] = fmt.Sprintf("%g", v.c[i])
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// ==========================================
+// Dimension-Specific Operations
+// ==========================================
+
+// Methods cannot be added to one instantiation only, so operations that exist
+// in a single dimension are plain functions over the alias.
+
+// Cross returns the 3D cross product
+func Cross(a, b Vec3) Vec3 {
+	return NewVec3(
+		a.c[1]*b.c[2]-a.c[2]*b.c[1],
+		a.c[2]*b.c[0]-a.c[0]*b.c[2],
+		a.c[0]*b.c[1]-a.c[1]*b.c[0],
+	)
+}
+
+// Cross2 returns the z component of the cross product of two 2D vectors,
+// which is the signed area of the parallelogram they span
+func Cross2(a, b Vec2) float64 {
+	return a.c[0]*b.c[1] - a.c[1]*b.c[0]
+}
+
+// Extend lifts a 2D vector into 3D; changing dimension is always explicit
+func Extend(v Vec2, z float64) Vec3 {
+	return NewVec3(v.c[0], v.c[1], z)
+}
+
+// Project drops the z component
+func Project(v Vec3) Vec2 {
+	return NewVec2(v.c[0], v.c[1])
+}
+
+// Centroid averages points of any single dimension
+func Centroid[C Components](points ...Vec[C]) Vec[C] {
+	var sum Vec[C]
+	if len(points) == 0 {
+		return sum
+	}
+	for _, p := range points {
+		sum = sum.Add(p)
+	}
+	return sum.Scale(1 / float64(len(points)))
+}
+
+// ==========================================
+// Checking What Does Not Compile
+// ==========================================
+
+// vectorCheckPrelude mirrors the declarations above so misuse can be fed to
+// the type checker at run time instead of breaking the build
+const vectorCheckPrelude = `package vectors
+
+type Components interface{ [2]float64 | [3]float64 }
+type Vec[C Components] struct{ c C }
+type (
+	Vec2 = Vec[[2]float64]
+	Vec3 = Vec[[3]float64]
+)
+
+func (v Vec[C]) Add(w Vec[C]) Vec[C] { return v }
+func (v Vec[C]) Dot(w Vec[C]) float64 { return 0 }
+func Cross(a, b Vec3) Vec3 { return a }
+func Centroid[C Components](points ...Vec[C]) Vec[C] { return points[0] }
+
+var v2 Vec2
+var v3 Vec3
+`
+
+// typeCheckVectors type-checks one statement against the prelude and returns
+// the first compiler error, or nil if it compiles
+func typeCheckVectors(stmt string) error {
+	fset := token.NewFileSet()
+	src := vectorCheckPrelude + "\nfunc _() { _ = " + stmt + " }\n"
+	file, err := parser.ParseFile(fset, "check.go", src, 0)
+	if err != nil {
+		return err
+	}
+
+	var first error
+	conf := types.Config{Error: func(err error) {
+		if first == nil {
+			first = err
+		}
+	}}
+	conf.Check("vectors", fset, []*ast.File{file}, nil)
+	if first != nil {
+		// Drop the file position, it points into the generated source
+		if typeErr, ok := first.(types.Error); ok {
+			return fmt.Errorf("%s", typeErr.Msg)
+		}
+	}
+	return first
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runVectorsExample() {
+	fmt.Println("\n🔸 One Generic Core, Fixed Dimensions")
+
+	a, b := NewVec2(3, 4), NewVec2(1, -2)
+	fmt.Printf("a=%v b=%v (dim %d)\n", a, b, a.Dim())
+	fmt.Printf("a+b=%v a-b=%v 2a=%v\n", a.Add(b), a.Sub(b), a.Scale(2))
+	fmt.Printf("a·b=%g |a|=%g\n", a.Dot(b), a.Norm())
+
+	u := NewVec3(1, 0, 0)
+	v := NewVec3(0, 1, 0)
+	fmt.Printf("u=%v v=%v (dim %d)\n", u, v, u.Dim())
+	fmt.Printf("u·v=%g u×v=%v v×u=%v\n", u.Dot(v), Cross(u, v), Cross(v, u))
+
+	fmt.Println("\n🔸 Norms")
+
+	w := NewVec3(2, -3, 6)
+	unit, _ := w.Normalize()
+	fmt.Printf("w=%v L1=%g L2=%g L∞=%g\n", w, w.Norm1(), w.Norm(), w.NormInf())
+	fmt.Printf("unit(w)=%.3f |unit(w)|=%.6g\n", []float64{unit.At(0), unit.At(1), unit.At(2)}, unit.Norm())
+	_, ok := Vec2{}.Normalize()
+	fmt.Printf("Normalize zero vector ok=%v\n", ok)
+
+	fmt.Println("\n🔸 Dimension-Specific Operations")
+
+	fmt.Printf("Signed area of a, b: %g (clockwise is negative)\n", Cross2(a, b))
+	lifted := Extend(a, 12)
+	fmt.Printf("Extend(a, 12)=%v |.|=%g, Project back=%v\n", lifted, lifted.Norm(), Project(lifted))
+	fmt.Printf("Centroid of a triangle: %v\n", Centroid(NewVec2(0, 0), NewVec2(6, 0), NewVec2(0, 3)))
+
+	fmt.Println("\n🔸 Mismatches Are Compile Errors")
+
+	// Each line is type-checked with go/types against a copy of the
+	// declarations; writing the same code in this package fails the build
+	for _, stmt := range []string{
+		"v2.Add(v2)",
+		"v2.Add(v3)",
+		"v3.Dot(v2)",
+		"Cross(v2, v2)",
+		"Centroid(v2, v3)",
+		"Vec[[4]float64]{}",
+		"Vec[[]float64]{}",
+	} {
+		if err := typeCheckVectors(stmt); err != nil {
+			fmt.Printf("  ✗ %-18s %v\n", stmt, err)
+		} else {
+			fmt.Printf("  ✓ %-18s compiles\n", stmt)
+		}
+	}
+
+	fmt.Println("\n✅ Vectors examples completed!")
+}
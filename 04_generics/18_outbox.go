@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Transactional Outbox
+// ==========================================
+
+// The outbox pattern makes "change state and tell someone" atomic: messages
+// are staged in the same transaction as the state change, so either both
+// happen or neither does. Delivery is then at-least-once, and consumers that
+// remember what they processed turn it into exactly-once processing.
+
+// ErrDuplicateKey is returned by Publish when the key was already published,
+// by this transaction or an earlier committed one
+var ErrDuplicateKey = errors.New("outbox: message key already published")
+
+// OutboxMessage is one staged message. Key is the producer's idempotency key:
+// a retried transaction that publishes the same key fails instead of enqueuing
+// a second copy, and its state mutations are not applied.
+type OutboxMessage[T any] struct {
+	ID       uint64
+	Key      string
+	Payload  T
+	Attempts int // Deliveries so far, including the current one
+}
+
+// OutboxTx collects the effects of one transaction until it commits
+type OutboxTx[T any] struct {
+	outbox   *Outbox[T]
+	staged   []OutboxMessage[T]
+	onCommit []func()
+	keys     map[string]bool
+}
+
+// Publish stages a message, visible to the dispatcher only after commit
+func (tx *OutboxTx[T]) Publish(key string, payload T) error {
+	if tx.keys[key] || tx.outbox.published[key] { // The outbox lock is held during fn
+		tx.outbox.stats.Duplicates++
+		return fmt.Errorf("%w: %s", ErrDuplicateKey, key)
+	}
+	tx.keys[key] = true
+	tx.staged = append(tx.staged, OutboxMessage[T]{Key: key, Payload: payload})
+	return nil
+}
+
+// OnCommit registers a state mutation that is applied together with the
+// staged messages, under the outbox lock
+func (tx *OutboxTx[T]) OnCommit(apply func()) {
+	tx.onCommit = append(tx.onCommit, apply)
+}
+
+// OutboxStats counts what happened to messages
+type OutboxStats struct {
+	Committed    int
+	RolledBack   int
+	Duplicates   int // Publishes rejected because the key was already published
+	Delivered    int
+	Redeliveries int
+	DeadLettered int
+}
+
+// Outbox is an in-memory transactional outbox with an ordered dispatcher
+type Outbox[T any] struct {
+	mu          sync.Mutex
+	nextID      uint64
+	pending     []OutboxMessage[T]
+	published   map[string]bool // Keys ever committed, for producer-side dedupe
+	deadLetters []OutboxMessage[T]
+	stats       OutboxStats
+	notify      chan struct{}
+	idle        []chan struct{}
+}
+
+// NewOutbox creates an empty outbox
+func NewOutbox[T any]() *Outbox[T] {
+	return &Outbox[T]{
+		published: make(map[string]bool),
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Transact runs fn with a fresh transaction. If fn returns an error nothing it
+// staged is applied; otherwise its state mutations run and its messages are
+// enqueued in one step, so the dispatcher never sees one without the other.
+func (o *Outbox[T]) Transact(fn func(tx *OutboxTx[T]) error) error {
+	tx := &OutboxTx[T]{outbox: o, keys: make(map[string]bool)}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := fn(tx); err != nil {
+		o.stats.RolledBack++
+		return err
+	}
+
+	for _, apply := range tx.onCommit {
+		apply()
+	}
+	for _, msg := range tx.staged {
+		o.published[msg.Key] = true
+		o.nextID++
+		msg.ID = o.nextID
+		o.pending = append(o.pending, msg)
+	}
+	o.stats.Committed++
+
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// DispatchOptions control redelivery
+type DispatchOptions struct {
+	MaxAttempts int           // Deliveries before a message is dead-lettered
+	Backoff     time.Duration // Wait before redelivering, doubled per attempt
+}
+
+// Run delivers pending messages in order until ctx is done. A message stays
+// at the head of the queue until it is acknowledged (the consumer returns
+// nil) or dead-lettered, which keeps delivery ordered at the cost of
+// head-of-line blocking while it is retried. Run one dispatcher at a time.
+func (o *Outbox[T]) Run(ctx context.Context, consume func(OutboxMessage[T]) error, opts DispatchOptions) {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+
+	for {
+		msg, ok := o.head()
+		if !ok {
+			select {
+			case <-o.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		msg.Attempts++
+		err := consume(msg)
+
+		o.mu.Lock()
+		o.pending[0].Attempts = msg.Attempts
+		if msg.Attempts > 1 {
+			o.stats.Redeliveries++
+		}
+		switch {
+		case err == nil:
+			o.stats.Delivered++
+			o.pending = o.pending[1:]
+		case msg.Attempts >= opts.MaxAttempts:
+			o.stats.DeadLettered++
+			o.deadLetters = append(o.deadLetters, msg)
+			o.pending = o.pending[1:]
+		}
+		if len(o.pending) == 0 {
+			for _, ch := range o.idle {
+				close(ch)
+			}
+			o.idle = nil
+		}
+		o.mu.Unlock()
+
+		if err != nil && msg.Attempts < opts.MaxAttempts {
+			select {
+			case <-time.After(opts.Backoff << (msg.Attempts - 1)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (o *Outbox[T]) head() (OutboxMessage[T], bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.pending) == 0 {
+		return OutboxMessage[T]{}, false
+	}
+	return o.pending[0], true
+}
+
+// WaitIdle blocks until every committed message is acknowledged or
+// dead-lettered
+func (o *Outbox[T]) WaitIdle(ctx context.Context) error {
+	o.mu.Lock()
+	if len(o.pending) == 0 {
+		o.mu.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	o.idle = append(o.idle, ch)
+	o.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the counters
+func (o *Outbox[T]) Stats() OutboxStats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.stats
+}
+
+// DeadLetters returns the messages that exhausted their attempts
+func (o *Outbox[T]) DeadLetters() []OutboxMessage[T] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]OutboxMessage[T](nil), o.deadLetters...)
+}
+
+// ==========================================
+// Idempotent Consumers
+// ==========================================
+
+// Idempotent wraps a consumer so each message ID is processed once, even when
+// it is delivered again because an acknowledgement was lost. ack runs after
+// processing and models the reply to the dispatcher; if it fails the message
+// comes back, and the wrapper acknowledges it without processing it again.
+func Idempotent[T any](process func(T) error, ack func(OutboxMessage[T]) error) func(OutboxMessage[T]) error {
+	var mu sync.Mutex
+	processed := make(map[uint64]bool)
+
+	return func(msg OutboxMessage[T]) error {
+		mu.Lock()
+		done := processed[msg.ID]
+		mu.Unlock()
+
+		if !done {
+			if err := process(msg.Payload); err != nil {
+				return err
+			}
+			mu.Lock()
+			processed[msg.ID] = true
+			mu.Unlock()
+		}
+		if ack != nil {
+			return ack(msg)
+		}
+		return nil
+	}
+}
+
+// ==========================================
+// Example Domain
+// ==========================================
+
+type accountEvent struct {
+	Account string
+	Kind    string
+	Amount  int
+}
+
+func (e accountEvent) String() string {
+	return fmt.Sprintf("%s %s %d", e.Account, e.Kind, e.Amount)
+}
+
+var errInsufficientFunds = errors.New("insufficient funds")
+
+// ledger is the state the outbox transactions mutate
+type ledger struct {
+	balances map[string]int
+}
+
+func (l *ledger) withdraw(outbox *Outbox[accountEvent], requestID, account string, amount int) error {
+	return outbox.Transact(func(tx *OutboxTx[accountEvent]) error {
+		if l.balances[account] < amount {
+			return fmt.Errorf("withdraw %d from %s: %w", amount, account, errInsufficientFunds)
+		}
+		tx.OnCommit(func() { l.balances[account] -= amount })
+		return tx.Publish(requestID, accountEvent{Account: account, Kind: "withdrew", Amount: amount})
+	})
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runOutboxExample() {
+	fmt.Println("\n🔸 State and Messages Commit Together")
+
+	outbox := NewOutbox[accountEvent]()
+	books := &ledger{balances: map[string]int{"alice": 100, "bob": 20}}
+
+	fmt.Printf("withdraw req-1: %v\n", books.withdraw(outbox, "req-1", "alice", 30))
+	fmt.Printf("withdraw req-2: %v\n", books.withdraw(outbox, "req-2", "bob", 50))
+	// A client retry reuses the request ID, so the whole transaction rolls back
+	// and the balance is debited once
+	err := books.withdraw(outbox, "req-1", "alice", 30)
+	fmt.Printf("withdraw req-1 again (client retry): %v (already applied: %v)\n", err, errors.Is(err, ErrDuplicateKey))
+	fmt.Printf("Balances: alice=%d bob=%d\n", books.balances["alice"], books.balances["bob"])
+
+	stats := outbox.Stats()
+	fmt.Printf("Committed=%d RolledBack=%d Duplicates=%d\n", stats.Committed, stats.RolledBack, stats.Duplicates)
+
+	fmt.Println("\n🔸 Lost Acks, Retries and Dedupe")
+
+	outbox = NewOutbox[accountEvent]()
+	books = &ledger{balances: map[string]int{"alice": 100, "carol": 100}}
+	for i, account := range []string{"alice", "carol", "alice"} {
+		books.withdraw(outbox, fmt.Sprintf("req-%d", i+1), account, 10)
+	}
+
+	var mu sync.Mutex
+	var applied []string
+	deliveries := make(map[uint64]int)
+
+	consumer := Idempotent(func(e accountEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		applied = append(applied, e.String())
+		return nil
+	}, func(msg OutboxMessage[accountEvent]) error {
+		mu.Lock()
+		defer mu.Unlock()
+		deliveries[msg.ID]++
+		if msg.ID == 2 && msg.Attempts == 1 {
+			return errors.New("ack lost in transit") // Processed, but the dispatcher never hears
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		outbox.Run(ctx, consumer, DispatchOptions{MaxAttempts: 3, Backoff: 5 * time.Millisecond})
+	}()
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	fmt.Printf("WaitIdle: %v\n", outbox.WaitIdle(waitCtx))
+	waitCancel()
+
+	mu.Lock()
+	ids := make([]uint64, 0, len(deliveries))
+	for id := range deliveries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		fmt.Printf("  message %d delivered %d time(s)\n", id, deliveries[id])
+	}
+	fmt.Printf("Applied exactly once, in order: %s\n", strings.Join(applied, "; "))
+	mu.Unlock()
+
+	stats = outbox.Stats()
+	fmt.Printf("Delivered=%d Redeliveries=%d\n", stats.Delivered, stats.Redeliveries)
+
+	// Stop the dispatcher; pending messages wait in the outbox until the next Run
+	cancel()
+	<-done
+
+	fmt.Println("\n🔸 Dead Letters")
+
+	books.withdraw(outbox, "req-poison", "carol", 5)
+	books.withdraw(outbox, "req-after", "alice", 5)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go outbox.Run(ctx, func(msg OutboxMessage[accountEvent]) error {
+		if msg.Key == "req-poison" {
+			return fmt.Errorf("cannot apply %v", msg.Payload)
+		}
+		fmt.Printf("  delivered %s (%v)\n", msg.Key, msg.Payload)
+		return nil
+	}, DispatchOptions{MaxAttempts: 3, Backoff: 2 * time.Millisecond})
+
+	waitCtx, waitCancel = context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	fmt.Printf("WaitIdle: %v\n", outbox.WaitIdle(waitCtx))
+	for _, msg := range outbox.DeadLetters() {
+		fmt.Printf("  dead letter %s after %d attempts: %v\n", msg.Key, msg.Attempts, msg.Payload)
+	}
+	stats = outbox.Stats()
+	fmt.Printf("Delivered=%d Redeliveries=%d DeadLettered=%d\n", stats.Delivered, stats.Redeliveries, stats.DeadLettered)
+
+	fmt.Println("\n✅ Outbox examples completed!")
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ==========================================
+// Cursor-Based Pagination
+// ==========================================
+
+// Offset pagination ("skip 20, take 10") breaks when the data changes between
+// requests: an insert shifts every later item, so a client sees one item twice
+// or never. A keyset cursor instead remembers the sort key of the last item
+// returned, and the next page starts strictly after it, wherever that is now.
+
+var (
+	// ErrInvalidCursor is returned for cursors this paginator did not issue
+	ErrInvalidCursor = errors.New("paginate: invalid cursor")
+	// ErrDuplicatePageKey is returned when two items share a sort key, which would
+	// make "strictly after the cursor" ambiguous
+	ErrDuplicatePageKey = errors.New("paginate: sort key is not unique")
+	// ErrUnorderedPageKey is returned for a NaN sort key, which is neither
+	// before nor after any other key
+	ErrUnorderedPageKey = errors.New("paginate: sort key is NaN")
+)
+
+// Page is one slice of results plus the cursor for the next one
+type Page[T any] struct {
+	Items      []T
+	NextCursor string // Empty on the last page
+}
+
+// HasMore reports whether another page follows
+func (p Page[T]) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// pageCursor is the decoded form of an opaque cursor
+type pageCursor[K Sortable] struct {
+	After K `json:"after"`
+}
+
+// encodeCursor hides the key behind base64 so clients treat it as opaque. It
+// fails for keys JSON cannot represent, which are the infinities.
+func encodeCursor[K Sortable](key K) (string, error) {
+	raw, err := json.Marshal(pageCursor[K]{After: key})
+	if err != nil {
+		return "", fmt.Errorf("paginate: cannot encode a cursor after %v: %w", key, err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor[K Sortable](cursor string) (K, error) {
+	var c pageCursor[K]
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(raw, &c)
+	}
+	if err != nil {
+		return c.After, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	return c.After, nil
+}
+
+// Paginate returns up to limit items ordered by key, starting after cursor
+// (an empty cursor starts at the beginning). Keys must be unique; the order is
+// then total, so pages never overlap and never skip an item that existed for
+// the whole walk, whatever is inserted or deleted in between.
+func Paginate[T any, K Sortable](items []T, key func(T) K, cursor string, limit int) (Page[T], error) {
+	if limit < 1 {
+		return Page[T]{}, fmt.Errorf("paginate: limit must be positive, got %d", limit)
+	}
+
+	for _, item := range items {
+		if k := key(item); k != k { // Only NaN is not equal to itself
+			return Page[T]{}, ErrUnorderedPageKey
+		}
+	}
+	sorted := SortedBy(items, CmpBy(key))
+	for i := 1; i < len(sorted); i++ {
+		if key(sorted[i-1]) == key(sorted[i]) {
+			return Page[T]{}, fmt.Errorf("%w: %v", ErrDuplicatePageKey, key(sorted[i]))
+		}
+	}
+
+	start := 0
+	if cursor != "" {
+		after, err := decodeCursor[K](cursor)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		for start < len(sorted) && key(sorted[start]) <= after {
+			start++
+		}
+	}
+
+	end := min(start+limit, len(sorted))
+	page := Page[T]{Items: sorted[start:end]}
+	if end < len(sorted) {
+		next, err := encodeCursor(key(sorted[end-1]))
+		if err != nil {
+			return Page[T]{}, err
+		}
+		page.NextCursor = next
+	}
+	return page, nil
+}
+
+// PaginateMap pages through any KeyValueSource (a SafeMap, a MapSource or a
+// repository's FindWhere result) in key order
+func PaginateMap[K Sortable, V any](src KeyValueSource[K, V], cursor string, limit int) (Page[Pair[K, V]], error) {
+	var entries []Pair[K, V]
+	src.ForEach(func(k K, v V) {
+		entries = append(entries, NewPair(k, v))
+	})
+	return Paginate(entries, func(e Pair[K, V]) K { return e.First }, cursor, limit)
+}
+
+// offsetPage is the fragile alternative, kept for comparison
+func offsetPage[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+type article struct {
+	Slug  string
+	Title string
+}
+
+func runPaginationExample() {
+	fmt.Println("\n🔸 Paging a Slice")
+
+	numbers := []int{42, 7, 19, 3, 88, 61, 25, 14}
+	var cursor string
+	for pageNo := 1; ; pageNo++ {
+		page, err := Paginate(numbers, func(n int) int { return n }, cursor, 3)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Page %d: %v next=%q\n", pageNo, page.Items, page.NextCursor)
+		if !page.HasMore() {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	fmt.Println("\n🔸 Inserts Between Pages")
+
+	// Both clients read page 1, then new articles land before and after the
+	// boundary, then both read page 2
+	articles := []article{{"go-maps", "Maps"}, {"go-slices", "Slices"}, {"go-chans", "Channels"}, {"go-iface", "Interfaces"}}
+	bySlug := func(a article) string { return a.Slug }
+
+	first, _ := Paginate(articles, bySlug, "", 2)
+	sortedForOffset := SortedBy(articles, CmpBy(bySlug))
+	offsetFirst := offsetPage(sortedForOffset, 0, 2)
+
+	articles = append(articles, article{"go-arrays", "Arrays"}, article{"go-structs", "Structs"})
+	sortedForOffset = SortedBy(articles, CmpBy(bySlug))
+
+	second, _ := Paginate(articles, bySlug, first.NextCursor, 10)
+	offsetSecond := offsetPage(sortedForOffset, 2, 10)
+
+	slugs := func(items []article) string {
+		names := make([]string, len(items))
+		for i, a := range items {
+			names[i] = a.Slug
+		}
+		return strings.Join(names, ", ")
+	}
+	fmt.Printf("Offset: page 1 [%s], page 2 [%s]\n", slugs(offsetFirst), slugs(offsetSecond))
+	fmt.Printf("Cursor: page 1 [%s], page 2 [%s]\n", slugs(first.Items), slugs(second.Items))
+
+	fmt.Println("Cursor page 2 keeps the insert after the cursor and skips the one it already passed")
+
+	fmt.Println("\n🔸 Maps and Repository Queries")
+
+	stock := NewSafeMap[string, int]()
+	for item, count := range map[string]int{"bolts": 120, "nuts": 80, "gears": 6, "springs": 40, "washers": 300} {
+		stock.Set(item, count)
+	}
+	page, _ := PaginateMap[string, int](stock, "", 2)
+	fmt.Printf("SafeMap page 1: %v\n", page.Items)
+	page, _ = PaginateMap[string, int](stock, page.NextCursor, 2)
+	fmt.Printf("SafeMap page 2: %v\n", page.Items)
+
+	var nextID int
+	repo := NewMemoryRepository[AppUser, int](func() int { nextID++; return nextID })
+	for i, name := range []string{"Ann", "Ben", "Cy", "Dee", "Eve", "Fay", "Gus"} {
+		repo.Save(AppUser{Name: name, Age: 20 + i*5})
+	}
+	adults := func(u AppUser) bool { return u.Age >= 30 }
+
+	cursor = ""
+	for {
+		page, err := PaginateMap[int, AppUser](MapSource[int, AppUser](repo.FindWhere(adults)), cursor, 2)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		names := make([]string, len(page.Items))
+		for i, e := range page.Items {
+			names[i] = fmt.Sprintf("#%d %s", e.First, e.Second.Name)
+		}
+		fmt.Printf("FindWhere(age >= 30) page: %s\n", strings.Join(names, ", "))
+		if !page.HasMore() {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	fmt.Println("\n🔸 Rejected Input")
+
+	_, err := Paginate(numbers, func(n int) int { return n }, "not-a-cursor", 3)
+	fmt.Printf("Forged cursor: %v\n", err)
+	_, err = Paginate([]int{1, 2, 2, 3}, func(n int) int { return n }, "", 2)
+	fmt.Printf("Duplicate keys: %v\n", err)
+
+	fmt.Println("\n✅ Pagination examples completed!")
+}
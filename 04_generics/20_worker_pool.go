@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Worker Pool
+// ==========================================
+
+// A fixed set of goroutines that take jobs from a bounded queue. Each job
+// gets its own result channel, so a caller that never reads its result
+// cannot stall the workers, and shutting down never depends on anyone
+// consuming results. The pool can grow and shrink while running, bounds
+// every job by its submit context plus an optional timeout, and turns a
+// panicking job into an error instead of a dead worker.
+
+var (
+	// ErrPoolClosed is returned by Submit after Drain or Close
+	ErrPoolClosed = errors.New("worker pool: closed")
+	// ErrJobPanicked wraps the value of a job that panicked
+	ErrJobPanicked = errors.New("worker pool: job panicked")
+)
+
+// PoolOptions configures a WorkerPool
+type PoolOptions struct {
+	QueueSize  int           // Jobs that may wait for a worker; default 2 per worker
+	JobTimeout time.Duration // Upper bound for each job; 0 means none
+}
+
+type poolJob[T, R any] struct {
+	ctx    context.Context
+	input  T
+	result chan Outcome[T, R] // Buffered: a worker never waits for the caller
+}
+
+// WorkerPool runs processor on submitted jobs with a resizable number of
+// workers. Results are Outcome values (see 12_middleware.go).
+type WorkerPool[T, R any] struct {
+	processor func(context.Context, T) (R, error)
+	opts      PoolOptions
+	jobs      chan poolJob[T, R]
+
+	// ctx is cancelled by Close, aborting queued and running jobs
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// sendMu lets Drain close jobs without racing a Submit in progress
+	sendMu sync.RWMutex
+	closed bool
+
+	mu    sync.Mutex      // Guards stops
+	stops []chan struct{} // One per live worker; closing it retires that worker
+	wg    sync.WaitGroup
+
+	running, completed atomic.Int64
+}
+
+// NewWorkerPool starts a pool of workers running processor
+func NewWorkerPool[T, R any](workers int, processor func(context.Context, T) (R, error)) *WorkerPool[T, R] {
+	return NewWorkerPoolWithOptions(workers, processor, PoolOptions{})
+}
+
+// NewWorkerPoolWithOptions starts a pool with a custom queue size or job timeout
+func NewWorkerPoolWithOptions[T, R any](workers int, processor func(context.Context, T) (R, error), opts PoolOptions) *WorkerPool[T, R] {
+	workers = max(workers, 1)
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = workers * 2
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &WorkerPool[T, R]{
+		processor: processor,
+		opts:      opts,
+		jobs:      make(chan poolJob[T, R], opts.QueueSize),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	p.Resize(workers)
+	return p
+}
+
+// Submit queues a job, waiting while the queue is full until ctx is done.
+// The job runs under ctx too: cancelling it also abandons a queued or
+// running job. The returned channel receives exactly one Outcome.
+func (p *WorkerPool[T, R]) Submit(ctx context.Context, job T) (<-chan Outcome[T, R], error) {
+	p.sendMu.RLock()
+	defer p.sendMu.RUnlock()
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	j := poolJob[T, R]{ctx: ctx, input: job, result: make(chan Outcome[T, R], 1)}
+	select {
+	case p.jobs <- j:
+		return j.result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Do submits a job and waits for its result
+func (p *WorkerPool[T, R]) Do(ctx context.Context, job T) (R, error) {
+	result, err := p.Submit(ctx, job)
+	if err != nil {
+		var zero R
+		return zero, err
+	}
+	o := <-result
+	return o.Result, o.Err
+}
+
+// Resize changes the number of workers, which is at least one. Extra
+// workers start at once; retired ones finish their current job first.
+func (p *WorkerPool[T, R]) Resize(workers int) {
+	workers = max(workers, 1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.stops) < workers {
+		stop := make(chan struct{})
+		p.stops = append(p.stops, stop)
+		p.wg.Add(1)
+		go p.worker(stop)
+	}
+	for len(p.stops) > workers {
+		last := len(p.stops) - 1
+		close(p.stops[last])
+		p.stops = p.stops[:last]
+	}
+}
+
+// Workers returns the current number of workers
+func (p *WorkerPool[T, R]) Workers() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.stops)
+}
+
+// Running returns how many jobs are executing right now
+func (p *WorkerPool[T, R]) Running() int {
+	return int(p.running.Load())
+}
+
+// Completed returns how many jobs have finished, successfully or not
+func (p *WorkerPool[T, R]) Completed() int {
+	return int(p.completed.Load())
+}
+
+// Drain stops accepting jobs and waits until every queued and running job
+// has finished and the workers have exited. Safe to call more than once.
+func (p *WorkerPool[T, R]) Drain() {
+	p.sendMu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.sendMu.Unlock()
+	p.wg.Wait()
+	p.cancel() // Nothing left to abort; release the context
+}
+
+// Close is Drain that first cancels every job: running jobs see their
+// context cancelled and queued ones fail without running
+func (p *WorkerPool[T, R]) Close() {
+	p.cancel()
+	p.Drain()
+}
+
+// worker runs jobs until the queue is closed and empty or stop is closed
+func (p *WorkerPool[T, R]) worker(stop chan struct{}) {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-stop:
+			return // Checked first: select picks randomly among ready cases
+		default:
+		}
+		select {
+		case j, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+			p.run(j)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// run executes one job under its submit context, the pool's context and
+// the job timeout, and delivers exactly one Outcome
+func (p *WorkerPool[T, R]) run(j poolJob[T, R]) {
+	p.running.Add(1)
+	defer p.running.Add(-1)
+	defer p.completed.Add(1)
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if p.opts.JobTimeout > 0 {
+		ctx, cancel = context.WithTimeout(j.ctx, p.opts.JobTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(j.ctx)
+	}
+	defer cancel()
+	stopAbort := context.AfterFunc(p.ctx, cancel) // Close aborts the job
+	defer stopAbort()
+
+	out := Outcome[T, R]{Input: j.input}
+	defer func() { j.result <- out }()
+	if err := ctx.Err(); err != nil {
+		out.Err = err // Cancelled or timed out while queued
+		return
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				out.Err = fmt.Errorf("%w: %v", ErrJobPanicked, r)
+			}
+		}()
+		out.Result, out.Err = p.processor(ctx, j.input)
+	}()
+	// A processor that ignored ctx still reports that it overran
+	if out.Err == nil && ctx.Err() != nil {
+		var zero R
+		out.Result, out.Err = zero, ctx.Err()
+	}
+}
+
+// ==========================================
+// Example
+// ==========================================
+
+func runWorkerPoolExample() {
+	fmt.Println("\n🔸 Submitting Jobs and Collecting Results")
+	square := func(_ context.Context, n int) (int, error) {
+		time.Sleep(10 * time.Millisecond) // Simulate work
+		return n * n, nil
+	}
+	pool := NewWorkerPool(3, square)
+	ctx := context.Background()
+
+	var results []<-chan Outcome[int, int]
+	for i := 1; i <= 6; i++ {
+		result, _ := pool.Submit(ctx, i)
+		results = append(results, result)
+	}
+	fmt.Print("Squares in submission order: ")
+	for _, result := range results {
+		fmt.Printf("%d ", (<-result).Result)
+	}
+	fmt.Println()
+
+	// Nobody reads these results; the old Close would block on a full
+	// result channel here, Drain does not care
+	for i := 0; i < 10; i++ {
+		pool.Submit(ctx, i)
+	}
+	pool.Drain()
+	fmt.Printf("Drained with 10 unread results: %d jobs completed\n", pool.Completed())
+	_, err := pool.Submit(ctx, 1)
+	fmt.Printf("Submit after Drain: %v\n", err)
+
+	fmt.Println("\n🔸 Resizing at Runtime")
+	var peak atomic.Int64
+	slow := func(_ context.Context, n int) (int, error) {
+		time.Sleep(40 * time.Millisecond)
+		return n, nil
+	}
+	var elastic *WorkerPool[int, int]
+	elastic = NewWorkerPoolWithOptions(1, func(ctx context.Context, n int) (int, error) {
+		for {
+			cur, p := int64(elastic.Running()), peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		return slow(ctx, n)
+	}, PoolOptions{QueueSize: 32})
+
+	runBatch := func(label string) {
+		peak.Store(0)
+		start := time.Now()
+		var batch []<-chan Outcome[int, int]
+		for i := 0; i < 8; i++ {
+			result, _ := elastic.Submit(ctx, i)
+			batch = append(batch, result)
+		}
+		for _, result := range batch {
+			<-result
+		}
+		fmt.Printf("%-22s workers=%d peak concurrency=%d took %v\n",
+			label, elastic.Workers(), peak.Load(), time.Since(start).Round(10*time.Millisecond))
+	}
+	runBatch("8 jobs, 1 worker:")
+	elastic.Resize(4)
+	runBatch("Resized to 4:")
+	elastic.Resize(2)
+	runBatch("Shrunk to 2:")
+	elastic.Drain()
+
+	fmt.Println("\n🔸 Timeouts, Cancellation and Panics")
+	worker := NewWorkerPoolWithOptions(2, func(ctx context.Context, job string) (string, error) {
+		switch job {
+		case "panic":
+			var m map[string]int
+			m["boom"]++ // Assignment to a nil map
+		case "slow":
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		case "ignores-ctx":
+			time.Sleep(80 * time.Millisecond) // Overruns without checking ctx
+		}
+		return job + " ok", nil
+	}, PoolOptions{JobTimeout: 50 * time.Millisecond})
+
+	jobs := []string{"fast", "panic", "slow", "ignores-ctx"}
+	outcomes := make(map[string]Outcome[string, string])
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job string) {
+			defer wg.Done()
+			result, err := worker.Do(ctx, job)
+			mu.Lock()
+			defer mu.Unlock()
+			outcomes[job] = Outcome[string, string]{Input: job, Result: result, Err: err}
+		}(job)
+	}
+	wg.Wait()
+	for _, job := range jobs {
+		o := outcomes[job]
+		fmt.Printf("  %-12s result=%-10q err=%v\n", job, o.Result, o.Err)
+	}
+	fmt.Printf("Panic is ErrJobPanicked: %t, workers still alive: %d\n",
+		errors.Is(outcomes["panic"].Err, ErrJobPanicked), worker.Workers())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	pending, _ := worker.Submit(cancelled, "slow")
+	cancel()
+	fmt.Printf("Job whose submit context was cancelled: %v\n", (<-pending).Err)
+	worker.Drain()
+
+	fmt.Println("\n🔸 Close Aborts Queued and Running Jobs")
+	aborting := NewWorkerPoolWithOptions(1, func(ctx context.Context, n int) (int, error) {
+		select {
+		case <-time.After(time.Second):
+			return n, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}, PoolOptions{QueueSize: 4})
+	var queued []<-chan Outcome[int, int]
+	for i := 1; i <= 4; i++ {
+		result, _ := aborting.Submit(ctx, i)
+		queued = append(queued, result)
+	}
+	time.Sleep(10 * time.Millisecond) // Let the first job start
+	start := time.Now()
+	aborting.Close()
+	errs := map[string]int{}
+	for _, result := range queued {
+		errs[fmt.Sprint((<-result).Err)]++
+	}
+	var summary []string
+	for err, n := range errs {
+		summary = append(summary, fmt.Sprintf("%d × %s", n, err))
+	}
+	sort.Strings(summary)
+	fmt.Printf("Close returned after %v: %v\n", time.Since(start).Round(time.Millisecond), summary)
+
+	fmt.Println("\n✅ Worker pool examples completed!")
+}
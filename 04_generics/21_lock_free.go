@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Lock-Free Data Structures
+// ==========================================
+
+// Two classic structures built from atomic.Pointer instead of a mutex. A
+// lock-free operation retries a compare-and-swap until it wins, so a
+// goroutine that is descheduled mid-operation never blocks the others, and
+// under contention the losers spin instead of sleeping. Garbage collection
+// makes both simpler than their C versions: a node cannot be freed and
+// reused while another goroutine still holds a pointer to it, which rules
+// out the ABA problem that needs tagged pointers elsewhere.
+
+// lfNode is a Treiber stack node; next never changes once pushed
+type lfNode[T any] struct {
+	value T
+	next  *lfNode[T]
+}
+
+// TreiberStack is a lock-free LIFO stack: the top is an atomic pointer to
+// an immutable linked list, and Push and Pop swing it with CompareAndSwap
+type TreiberStack[T any] struct {
+	top  atomic.Pointer[lfNode[T]]
+	size atomic.Int64
+}
+
+// Push adds an item to the top of the stack
+func (s *TreiberStack[T]) Push(item T) {
+	n := &lfNode[T]{value: item}
+	for {
+		n.next = s.top.Load()
+		if s.top.CompareAndSwap(n.next, n) {
+			s.size.Add(1)
+			return
+		}
+		// Another Push or Pop moved top between Load and CAS; retry
+	}
+}
+
+// Pop removes and returns the top item
+func (s *TreiberStack[T]) Pop() (T, bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			s.size.Add(-1)
+			return top.value, true
+		}
+	}
+}
+
+// Size returns the number of items; under concurrent use it is a snapshot
+func (s *TreiberStack[T]) Size() int {
+	return int(s.size.Load())
+}
+
+// mpscNode is an MPSC queue node; next is set once, by the producer that
+// links the following node
+type mpscNode[T any] struct {
+	value T
+	next  atomic.Pointer[mpscNode[T]]
+}
+
+// MPSCQueue is a lock-free FIFO queue for many producers and a single
+// consumer (Vyukov's intrusive queue). Producers only swap the head pointer,
+// one atomic operation with no retry loop; the consumer alone walks from the
+// tail, so it needs no atomics of its own. Items from one producer come out
+// in the order it pushed them.
+//
+// Between a producer's swap and its link to the previous node the queue is
+// briefly cut in two: Pop may report empty although a push is finishing.
+// Consumers treat a false Pop as "nothing yet" and try again later.
+type MPSCQueue[T any] struct {
+	head atomic.Pointer[mpscNode[T]] // Last pushed node; producers swap it
+	tail *mpscNode[T]                // Consumed sentinel; owned by the consumer
+}
+
+// NewMPSCQueue creates an empty queue
+func NewMPSCQueue[T any]() *MPSCQueue[T] {
+	stub := &mpscNode[T]{}
+	q := &MPSCQueue[T]{tail: stub}
+	q.head.Store(stub)
+	return q
+}
+
+// Push adds an item; safe for any number of goroutines
+func (q *MPSCQueue[T]) Push(item T) {
+	n := &mpscNode[T]{value: item}
+	prev := q.head.Swap(n)
+	prev.next.Store(n) // Until here, the consumer stops at prev
+}
+
+// Pop removes the oldest item. Only one goroutine may call Pop.
+func (q *MPSCQueue[T]) Pop() (T, bool) {
+	next := q.tail.next.Load()
+	if next == nil {
+		var zero T
+		return zero, false
+	}
+	q.tail = next // next becomes the new sentinel
+	item := next.value
+	var zero T
+	next.value = zero // Do not keep the item alive through the sentinel
+	return item, true
+}
+
+// ==========================================
+// Example Function
+// ==========================================
+
+func runLockFreeExample() {
+	fmt.Println("\n🔸 Treiber Stack")
+	stack := &TreiberStack[string]{}
+	for _, s := range []string{"a", "b", "c"} {
+		stack.Push(s)
+	}
+	fmt.Printf("Pushed a, b, c; size %d\n", stack.Size())
+	for {
+		item, ok := stack.Pop()
+		if !ok {
+			break
+		}
+		fmt.Printf("Popped %s\n", item)
+	}
+
+	fmt.Println("\n🔸 MPSC Queue: Per-Producer Order")
+	lockFreeQueueOrder()
+
+	fmt.Println("\n🔸 Correctness Under Contention")
+	fmt.Println("(run with go run -race . 21 to have the race detector watch as well)")
+	lockFreeStress()
+
+	fmt.Println("\n🔸 Lock-Free vs Mutex")
+	lockFreeComparison()
+
+	fmt.Println("\n✅ Lock-free examples completed!")
+}
+
+// lockFreeQueueOrder checks that each producer's items come out in order
+func lockFreeQueueOrder() {
+	type msg struct{ producer, seq int }
+	const producers, perProducer = 4, 1000
+
+	q := NewMPSCQueue[msg]()
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for seq := 0; seq < perProducer; seq++ {
+				q.Push(msg{p, seq})
+			}
+		}(p)
+	}
+
+	nextSeq := make([]int, producers)
+	received, outOfOrder := 0, 0
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for finished := false; ; {
+		m, ok := q.Pop()
+		if !ok {
+			if finished {
+				break
+			}
+			select {
+			case <-done:
+				finished = true // One more pass drains what is left
+			default:
+				runtime.Gosched()
+			}
+			continue
+		}
+		if m.seq != nextSeq[m.producer] {
+			outOfOrder++
+		}
+		nextSeq[m.producer] = m.seq + 1
+		received++
+	}
+	fmt.Printf("%d producers x %d items: received %d, out of order %d\n", producers, perProducer, received, outOfOrder)
+}
+
+// lockFreeStress pushes and pops from many goroutines at once and checks
+// that every value came out exactly once
+func lockFreeStress() {
+	const workers, perWorker = 8, 5000
+	stack := &TreiberStack[int]{}
+	seen := make([]atomic.Int32, workers*perWorker)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				stack.Push(w*perWorker + i)
+				if i%2 == 1 { // Pop about half along the way, racing other pushes
+					if v, ok := stack.Pop(); ok {
+						seen[v].Add(1)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for {
+		v, ok := stack.Pop()
+		if !ok {
+			break
+		}
+		seen[v].Add(1)
+	}
+
+	lost, duplicated := 0, 0
+	for i := range seen {
+		switch n := seen[i].Load(); {
+		case n == 0:
+			lost++
+		case n > 1:
+			duplicated++
+		}
+	}
+	fmt.Printf("Treiber stack, %d goroutines x %d pushes: lost %d, duplicated %d, size now %d\n",
+		workers, perWorker, lost, duplicated, stack.Size())
+}
+
+// measureOps runs op from goroutines goroutines, ops times each, and prints
+// the time per operation and the heap allocations per operation
+func measureOps(name string, goroutines, ops int, op func(i int)) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				op(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	total := goroutines * ops
+	fmt.Printf("  %-28s %10d %10.2f\n", name,
+		(elapsed / time.Duration(total)).Nanoseconds(), float64(after.Mallocs-before.Mallocs)/float64(total))
+}
+
+// lockFreeComparison times the lock-free structures against the
+// mutex-based Stack and a mutex-guarded Queue from this module
+func lockFreeComparison() {
+	const goroutines, ops = 8, 20000
+	fmt.Printf("%d goroutines x %d ops, GOMAXPROCS=%d\n", goroutines, ops, runtime.GOMAXPROCS(0))
+	fmt.Printf("  %-28s %10s %10s\n", "structure", "ns/op", "allocs/op")
+
+	// Push+Pop pairs on a shared stack
+	treiber := &TreiberStack[int]{}
+	measureOps("TreiberStack push+pop", goroutines, ops, func(i int) {
+		treiber.Push(i)
+		treiber.Pop()
+	})
+	mutexStack := NewStack[int]()
+	measureOps("Stack (RWMutex) push+pop", goroutines, ops, func(i int) {
+		mutexStack.Push(i)
+		mutexStack.Pop()
+	})
+
+	// Many producers into one queue; the consumer drains afterwards, so
+	// this measures the producer side, which is what MPSC optimizes
+	mpsc := NewMPSCQueue[int]()
+	measureOps("MPSCQueue push", goroutines, ops, func(i int) { mpsc.Push(i) })
+	var mu sync.Mutex
+	queue := NewQueue[int]()
+	measureOps("Queue + Mutex enqueue", goroutines, ops, func(i int) {
+		mu.Lock()
+		queue.Enqueue(i)
+		mu.Unlock()
+	})
+	ch := make(chan int, goroutines*ops)
+	measureOps("buffered channel send", goroutines, ops, func(i int) { ch <- i })
+
+	fmt.Println("The lock-free versions allocate a node per push where slices amortize")
+	fmt.Println("growth. Their advantage grows with cores and contention: compare runs")
+	fmt.Println("with GOMAXPROCS=1 and the default, or run the benchmarks:")
+	fmt.Println("  go test -bench=LockFree -benchmem -cpu=1,4,8 ./04_generics")
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// Lazy Sequences
+// ==========================================
+
+// The Pipeline in 06_generic_algorithms.go is eager: every stage walks the
+// whole slice and allocates a new one before the next stage starts, so
+// Filter, Map, Take(3) over a million items builds two large slices to keep
+// three values. A Seq is a pull-based iterator instead: each stage asks the
+// one before it for the next value only when its own consumer asks, so
+// work and memory are proportional to what is actually consumed, and
+// sequences may be infinite.
+//
+// A Seq is single use: pulling values consumes them, the way reading a
+// channel does. Build a new one to iterate again.
+
+// Seq returns the next value and true, or the zero value and false once
+// exhausted
+type Seq[T any] func() (T, bool)
+
+// SeqOf is a sequence of the given values
+func SeqOf[T any](values ...T) Seq[T] {
+	i := 0
+	return func() (T, bool) {
+		if i == len(values) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return values[i-1], true
+	}
+}
+
+// Iterate is the infinite sequence seed, next(seed), next(next(seed))...
+func Iterate[T any](seed T, next func(T) T) Seq[T] {
+	current, started := seed, false
+	return func() (T, bool) {
+		if started {
+			current = next(current)
+		}
+		started = true
+		return current, true
+	}
+}
+
+// SeqFromChan pulls from a channel until it is closed, so the stream
+// operators of 09_stream_operators.go can feed a Seq
+func SeqFromChan[T any](ch <-chan T) Seq[T] {
+	return func() (T, bool) {
+		v, ok := <-ch
+		return v, ok
+	}
+}
+
+// Filter keeps the values predicate accepts
+func (s Seq[T]) Filter(predicate func(T) bool) Seq[T] {
+	return func() (T, bool) {
+		for {
+			v, ok := s()
+			if !ok || predicate(v) {
+				return v, ok
+			}
+		}
+	}
+}
+
+// Take stops after n values without pulling an (n+1)th from s
+func (s Seq[T]) Take(n int) Seq[T] {
+	return func() (T, bool) {
+		if n <= 0 {
+			var zero T
+			return zero, false
+		}
+		n--
+		return s()
+	}
+}
+
+// Collect pulls every value into a slice; never call it on an infinite
+// sequence without Take
+func (s Seq[T]) Collect() []T {
+	var result []T
+	for v, ok := s(); ok; v, ok = s() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// ForEach calls fn with every value
+func (s Seq[T]) ForEach(fn func(T)) {
+	for v, ok := s(); ok; v, ok = s() {
+		fn(v)
+	}
+}
+
+// MapSeq transforms each value when it is pulled. It is a function, not a
+// method, because methods cannot introduce the type parameter U.
+func MapSeq[T, U any](s Seq[T], fn func(T) U) Seq[U] {
+	return func() (U, bool) {
+		v, ok := s()
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		return fn(v), true
+	}
+}
+
+// ZipSeq pairs values from a and b, ending with the shorter one
+func ZipSeq[A, B any](a Seq[A], b Seq[B]) Seq[Pair[A, B]] {
+	return func() (Pair[A, B], bool) {
+		x, ok := a()
+		if !ok {
+			return Pair[A, B]{}, false
+		}
+		y, ok := b()
+		if !ok {
+			return Pair[A, B]{}, false
+		}
+		return NewPair(x, y), true
+	}
+}
+
+// ChunkSeq groups values into slices of size n; the last may be shorter
+func ChunkSeq[T any](s Seq[T], n int) Seq[[]T] {
+	return func() ([]T, bool) {
+		chunk := make([]T, 0, n)
+		for len(chunk) < n {
+			v, ok := s()
+			if !ok {
+				break
+			}
+			chunk = append(chunk, v)
+		}
+		return chunk, len(chunk) > 0
+	}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runLazySeqExample() {
+	fmt.Println("\n🔸 Evaluation On Demand")
+
+	// Log every value each stage touches
+	var trace []string
+	record := func(stage string, n int) { trace = append(trace, fmt.Sprintf("%s(%d)", stage, n)) }
+
+	pulled := MapSeq(SeqOf(Range(1, 11, 1)...), func(n int) int { record("pull", n); return n })
+	squares := MapSeq(pulled.Filter(func(n int) bool { return n%2 == 0 }), func(n int) int {
+		record("square", n)
+		return n * n
+	}).Take(2)
+	fmt.Printf("Built the sequence; work done so far: %d steps\n", len(trace))
+	fmt.Printf("Collected: %v\n", squares.Collect())
+	fmt.Printf("Steps: %s\n", strings.Join(trace, " "))
+	fmt.Println("Values 5-10 were never pulled")
+
+	trace = nil
+	NewPipeline(Range(1, 11, 1)).
+		Map(func(n int) int { record("pull", n); return n }).
+		Filter(func(n int) bool { return n%2 == 0 }).
+		Map(func(n int) int { record("square", n); return n * n }).
+		Take(2).
+		Collect()
+	fmt.Printf("Eager Pipeline for the same result: %d steps\n", len(trace))
+
+	fmt.Println("\n🔸 Infinite Sequences")
+
+	naturals := func() Seq[int] { return Iterate(1, func(n int) int { return n + 1 }) }
+	fmt.Printf("First 10 primes: %v\n", naturals().Filter(IsPrime[int]).Take(10).Collect())
+
+	fibPairs := Iterate(NewPair(0, 1), func(p Pair[int, int]) Pair[int, int] { return NewPair(p.Second, p.First+p.Second) })
+	fib := MapSeq(fibPairs, func(p Pair[int, int]) int { return p.First })
+	fmt.Printf("Fibonacci: %v\n", fib.Take(12).Collect())
+
+	powers := Iterate(1, func(n int) int { return n * 2 }).Filter(func(n int) bool { return n > 1000 }).Take(1).Collect()
+	fmt.Printf("First power of two over 1000: %v\n", powers)
+
+	fmt.Println("\n🔸 Zip and Chunk")
+
+	names := SeqOf("ann", "bob", "cy")
+	ZipSeq(naturals(), names).ForEach(func(p Pair[int, string]) {
+		fmt.Printf("  #%d %s\n", p.First, p.Second)
+	})
+	batches := ChunkSeq(naturals().Take(10), 4).Collect()
+	fmt.Printf("Batches of 4: %v\n", batches)
+
+	// A channel stream from 09_stream_operators.go feeds a Seq
+	fromChan := SeqFromChan(Distinct(StreamOf(3, 3, 1, 3, 2, 1))).Collect()
+	fmt.Printf("From a channel stream: %v\n", fromChan)
+
+	fmt.Println("\n🔸 Eager Pipeline vs Lazy Seq on Large Inputs")
+	lazySeqComparison()
+
+	fmt.Println("\n✅ Lazy sequence examples completed!")
+}
+
+// measureAlloc runs fn and returns the bytes it allocated and how long it
+// took
+func measureAlloc(fn func()) (uint64, time.Duration) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc, elapsed
+}
+
+// lazySeqComparison runs Filter, Map, Take(5) both ways over growing
+// inputs. The eager Pipeline allocates in proportion to the input, the
+// lazy Seq only in proportion to the five values it keeps.
+func lazySeqComparison() {
+	fmt.Printf("  %-10s %14s %12s %14s %12s\n", "items", "eager bytes", "eager time", "lazy bytes", "lazy time")
+	for _, n := range []int{1_000, 100_000, 1_000_000} {
+		data := Range(0, n, 1)
+		isMultipleOf7 := func(v int) bool { return v%7 == 0 }
+		square := func(v int) int { return v * v }
+
+		var eager, lazy []int
+		eagerBytes, eagerTime := measureAlloc(func() {
+			eager = NewPipeline(data).Filter(isMultipleOf7).Map(square).Take(5).Collect()
+		})
+		lazyBytes, lazyTime := measureAlloc(func() {
+			lazy = MapSeq(SeqOf(data...).Filter(isMultipleOf7), square).Take(5).Collect()
+		})
+		if fmt.Sprint(eager) != fmt.Sprint(lazy) {
+			fmt.Printf("  results differ: %v vs %v\n", eager, lazy)
+		}
+		fmt.Printf("  %-10d %14d %12v %14d %12v\n", n, eagerBytes, eagerTime.Round(100*time.Nanosecond), lazyBytes, lazyTime.Round(100*time.Nanosecond))
+	}
+	fmt.Println("Laziness pays when only part of the input is consumed; a full pass")
+	fmt.Println("over every item costs the same work either way, plus a function call")
+	fmt.Println("per stage and value for the Seq.")
+}
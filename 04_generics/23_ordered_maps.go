@@ -0,0 +1,578 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ==========================================
+// Insertion-Ordered Map
+// ==========================================
+
+// Ranging over a Go map, or calling SafeMap.Keys, visits keys in a
+// different order every run. OrderedMap remembers the order keys were first
+// set in, like a Python dict or a JavaScript Map; SortedMap below keeps
+// them sorted. Unlike SafeMap neither is safe for concurrent use.
+
+// orderedEntry is a node of the list that records insertion order
+type orderedEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *orderedEntry[K, V]
+}
+
+// OrderedMap is a map that iterates in insertion order. The map gives
+// O(1) lookup and the doubly linked list O(1) removal from the middle.
+type OrderedMap[K comparable, V any] struct {
+	entries    map[K]*orderedEntry[K, V]
+	head, tail *orderedEntry[K, V]
+}
+
+// NewOrderedMap creates an empty insertion-ordered map
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{entries: make(map[K]*orderedEntry[K, V])}
+}
+
+// Set stores a value. Updating an existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, exists := m.entries[key]; exists {
+		e.value = value
+		return
+	}
+	e := &orderedEntry[K, V]{key: key, value: value}
+	m.entries[key] = e
+	m.pushBack(e)
+}
+
+// Get retrieves a value by key
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, exists := m.entries[key]; exists {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes a key and reports whether it was present
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, exists := m.entries[key]
+	if !exists {
+		return false
+	}
+	m.unlink(e)
+	delete(m.entries, key)
+	return true
+}
+
+// MoveToBack makes key the most recently inserted, as an LRU cache does
+// on every access
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	e, exists := m.entries[key]
+	if !exists {
+		return false
+	}
+	m.unlink(e)
+	m.pushBack(e)
+	return true
+}
+
+// Oldest returns the first key in insertion order
+func (m *OrderedMap[K, V]) Oldest() (K, V, bool) {
+	if m.head == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return m.head.key, m.head.value, true
+}
+
+// Len returns the number of keys
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	m.ForEach(func(k K, _ V) { keys = append(keys, k) })
+	return keys
+}
+
+// ForEach calls fn for every pair in insertion order
+func (m *OrderedMap[K, V]) ForEach(fn func(K, V)) {
+	for e := m.head; e != nil; e = e.next {
+		fn(e.key, e.value)
+	}
+}
+
+func (m *OrderedMap[K, V]) pushBack(e *orderedEntry[K, V]) {
+	e.prev, e.next = m.tail, nil
+	if m.tail == nil {
+		m.head = e
+	} else {
+		m.tail.next = e
+	}
+	m.tail = e
+}
+
+func (m *OrderedMap[K, V]) unlink(e *orderedEntry[K, V]) {
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+// ==========================================
+// Sorted Map (AVL Tree)
+// ==========================================
+
+// avlNode is a node of an AVL tree: the heights of its two subtrees differ
+// by at most one, which keeps the tree height below 1.44*log2(n)
+type avlNode[K Orderable, V any] struct {
+	key         K
+	value       V
+	left, right *avlNode[K, V]
+	height      int
+}
+
+// SortedMap is a map that iterates in key order and answers range queries,
+// with O(log n) Put, Get and Delete
+type SortedMap[K Orderable, V any] struct {
+	root *avlNode[K, V]
+	size int
+}
+
+// NewSortedMap creates an empty sorted map
+func NewSortedMap[K Orderable, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{}
+}
+
+// Put stores a value, replacing any previous value for key
+func (m *SortedMap[K, V]) Put(key K, value V) {
+	var added bool
+	m.root, added = avlInsert(m.root, key, value)
+	if added {
+		m.size++
+	}
+}
+
+// Get retrieves a value by key
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes a key and reports whether it was present
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	var removed bool
+	m.root, removed = avlDelete(m.root, key)
+	if removed {
+		m.size--
+	}
+	return removed
+}
+
+// Len returns the number of keys
+func (m *SortedMap[K, V]) Len() int {
+	return m.size
+}
+
+// Height returns the height of the underlying tree
+func (m *SortedMap[K, V]) Height() int {
+	return m.root.getHeight()
+}
+
+// Min returns the smallest key
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	n := m.root.leftmost()
+	return n.key, n.value, true
+}
+
+// Max returns the largest key
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor returns the largest key less than or equal to key
+func (m *SortedMap[K, V]) Floor(key K) (K, bool) {
+	var found *avlNode[K, V]
+	for n := m.root; n != nil; {
+		if n.key <= key {
+			found, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	if found == nil {
+		var zero K
+		return zero, false
+	}
+	return found.key, true
+}
+
+// Ceiling returns the smallest key greater than or equal to key
+func (m *SortedMap[K, V]) Ceiling(key K) (K, bool) {
+	var found *avlNode[K, V]
+	for n := m.root; n != nil; {
+		if n.key >= key {
+			found, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	if found == nil {
+		var zero K
+		return zero, false
+	}
+	return found.key, true
+}
+
+// ForEach calls fn for every pair in ascending key order
+func (m *SortedMap[K, V]) ForEach(fn func(K, V)) {
+	avlInOrder(m.root, fn)
+}
+
+// Keys returns the keys in ascending order
+func (m *SortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.ForEach(func(k K, _ V) { keys = append(keys, k) })
+	return keys
+}
+
+// Range calls fn for every pair with lo <= key < hi, in key order. Only
+// subtrees that can hold such keys are visited, so the cost is O(log n)
+// plus the number of pairs reported.
+func (m *SortedMap[K, V]) Range(lo, hi K, fn func(K, V)) {
+	avlRange(m.root, lo, hi, fn)
+}
+
+func (n *avlNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *avlNode[K, V]) balance() int {
+	return n.left.getHeight() - n.right.getHeight()
+}
+
+func (n *avlNode[K, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+}
+
+func (n *avlNode[K, V]) leftmost() *avlNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func avlRotateRight[K Orderable, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+func avlRotateLeft[K Orderable, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+// avlRebalance restores the height invariant at n after one of its
+// subtrees grew or shrank by one, and returns the new subtree root
+func avlRebalance[K Orderable, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	n.update()
+	switch b := n.balance(); {
+	case b > 1:
+		if n.left.balance() < 0 {
+			n.left = avlRotateLeft(n.left)
+		}
+		return avlRotateRight(n)
+	case b < -1:
+		if n.right.balance() > 0 {
+			n.right = avlRotateRight(n.right)
+		}
+		return avlRotateLeft(n)
+	}
+	return n
+}
+
+func avlInsert[K Orderable, V any](n *avlNode[K, V], key K, value V) (*avlNode[K, V], bool) {
+	if n == nil {
+		return &avlNode[K, V]{key: key, value: value, height: 1}, true
+	}
+	var added bool
+	switch {
+	case key < n.key:
+		n.left, added = avlInsert(n.left, key, value)
+	case key > n.key:
+		n.right, added = avlInsert(n.right, key, value)
+	default:
+		n.value = value
+		return n, false
+	}
+	return avlRebalance(n), added
+}
+
+func avlDelete[K Orderable, V any](n *avlNode[K, V], key K) (*avlNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch {
+	case key < n.key:
+		n.left, removed = avlDelete(n.left, key)
+	case key > n.key:
+		n.right, removed = avlDelete(n.right, key)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Two children: take the in-order successor's place
+		succ := n.right.leftmost()
+		n.key, n.value = succ.key, succ.value
+		n.right, _ = avlDelete(n.right, succ.key)
+		removed = true
+	}
+	return avlRebalance(n), removed
+}
+
+func avlInOrder[K Orderable, V any](n *avlNode[K, V], fn func(K, V)) {
+	if n == nil {
+		return
+	}
+	avlInOrder(n.left, fn)
+	fn(n.key, n.value)
+	avlInOrder(n.right, fn)
+}
+
+func avlRange[K Orderable, V any](n *avlNode[K, V], lo, hi K, fn func(K, V)) {
+	if n == nil {
+		return
+	}
+	if lo < n.key {
+		avlRange(n.left, lo, hi, fn)
+	}
+	if lo <= n.key && n.key < hi {
+		fn(n.key, n.value)
+	}
+	if n.key < hi {
+		avlRange(n.right, lo, hi, fn)
+	}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runOrderedMapsExample() {
+	fmt.Println("\n🔸 Iteration Order: SafeMap vs OrderedMap vs SortedMap")
+
+	steps := []string{"mix", "bake", "cool", "frost", "serve"}
+	safe := NewSafeMap[string, int]()
+	ordered := NewOrderedMap[string, int]()
+	sorted := NewSortedMap[string, int]()
+	for i, step := range steps {
+		safe.Set(step, i+1)
+		ordered.Set(step, i+1)
+		sorted.Put(step, i+1)
+	}
+	fmt.Printf("SafeMap.Keys():    %v (random, may differ next run)\n", safe.Keys())
+	fmt.Printf("OrderedMap.Keys(): %v\n", ordered.Keys())
+	fmt.Printf("SortedMap.Keys():  %v\n", sorted.Keys())
+
+	ordered.Set("mix", 10)
+	ordered.Delete("cool")
+	ordered.Set("cool", 11)
+	fmt.Printf("After updating mix and re-adding cool: %v\n", ordered.Keys())
+
+	fmt.Println("\n🔸 OrderedMap as an LRU Cache")
+
+	const capacity = 3
+	lru := NewOrderedMap[string, string]()
+	access := func(key string) {
+		if _, ok := lru.Get(key); ok {
+			lru.MoveToBack(key)
+			fmt.Printf("  hit  %-6s %v\n", key, lru.Keys())
+			return
+		}
+		if lru.Len() == capacity {
+			oldest, _, _ := lru.Oldest()
+			lru.Delete(oldest)
+		}
+		lru.Set(key, strings.ToUpper(key))
+		fmt.Printf("  miss %-6s %v\n", key, lru.Keys())
+	}
+	for _, key := range []string{"home", "about", "home", "blog", "shop", "about"} {
+		access(key)
+	}
+
+	fmt.Println("\n🔸 SortedMap Range Queries")
+
+	// Order totals keyed by day of the month
+	sales := NewSortedMap[int, float64]()
+	for day, total := range map[int]float64{3: 120, 7: 80.5, 9: 42, 14: 300, 15: 18, 21: 99.9, 28: 250} {
+		sales.Put(day, total)
+	}
+	var week2 float64
+	sales.Range(8, 15, func(day int, total float64) {
+		fmt.Printf("  day %2d: %6.2f\n", day, total)
+		week2 += total
+	})
+	fmt.Printf("Days 8-14 total: %.2f\n", week2)
+
+	first, _, _ := sales.Min()
+	last, _, _ := sales.Max()
+	floor, _ := sales.Floor(20)
+	ceiling, _ := sales.Ceiling(20)
+	fmt.Printf("Min day %d, max day %d, floor(20) = %d, ceiling(20) = %d\n", first, last, floor, ceiling)
+	if _, ok := sales.Ceiling(29); !ok {
+		fmt.Println("No day at or after 29")
+	}
+
+	fmt.Println("\n🔸 Balancing")
+
+	// Sorted input is the worst case for a plain search tree: a chain of
+	// 1000 nodes. The AVL rotations keep it shallow.
+	balanced := NewSortedMap[int, bool]()
+	for i := 1; i <= 1000; i++ {
+		balanced.Put(i, true)
+	}
+	fmt.Printf("1000 sorted inserts: height %d\n", balanced.Height())
+	for i := 1; i <= 1000; i += 2 {
+		balanced.Delete(i)
+	}
+	fmt.Printf("After deleting the odd keys: %d keys, height %d\n", balanced.Len(), balanced.Height())
+
+	fmt.Println("\n🔸 Invariants")
+
+	// The property runner from 10_property_testing.go checks both maps
+	// against a plain map model
+	intSlices := SlicesOf(Ints(-50, 50), 40)
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"OrderedMap keeps first-set order", func() string { return ForAll(intSlices, orderedMapKeepsFirstSetOrder).String() }},
+		{"SortedMap keys are sorted and unique", func() string { return ForAll(intSlices, sortedMapKeysSorted).String() }},
+		{"SortedMap stays AVL-balanced", func() string { return ForAll(intSlices, sortedMapStaysBalanced).String() }},
+		{"SortedMap.Range matches a filter", func() string { return ForAll(intSlices, sortedMapRangeMatchesFilter).String() }},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-38s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Ordered map examples completed!")
+}
+
+// orderedMapKeepsFirstSetOrder sets every item and deletes the negative
+// ones; the keys must be the non-negative items in order of first
+// appearance
+func orderedMapKeepsFirstSetOrder(items []int) bool {
+	m := NewOrderedMap[int, int]()
+	for i, item := range items {
+		m.Set(item, i)
+	}
+	var want []int
+	seen := make(map[int]bool)
+	for _, item := range items {
+		if item < 0 {
+			m.Delete(item)
+		} else if !seen[item] {
+			want = append(want, item)
+		}
+		seen[item] = true
+	}
+	return fmt.Sprint(m.Keys()) == fmt.Sprint(want) && m.Len() == len(want)
+}
+
+// sortedMapKeysSorted checks that keys come out sorted, once each
+func sortedMapKeysSorted(items []int) bool {
+	m := NewSortedMap[int, int]()
+	unique := make(map[int]bool)
+	for _, item := range items {
+		m.Put(item, item)
+		unique[item] = true
+	}
+	keys := m.Keys()
+	return len(keys) == len(unique) && m.Len() == len(unique) && sort.IntsAreSorted(keys)
+}
+
+// sortedMapStaysBalanced inserts the items, deletes every other one and
+// checks the AVL invariant at every node
+func sortedMapStaysBalanced(items []int) bool {
+	m := NewSortedMap[int, int]()
+	for _, item := range items {
+		m.Put(item, item)
+	}
+	for i := 0; i < len(items); i += 2 {
+		m.Delete(items[i])
+	}
+	var check func(n *avlNode[int, int]) (int, bool)
+	check = func(n *avlNode[int, int]) (int, bool) {
+		if n == nil {
+			return 0, true
+		}
+		lh, lok := check(n.left)
+		rh, rok := check(n.right)
+		h := 1 + max(lh, rh)
+		return h, lok && rok && lh-rh <= 1 && rh-lh <= 1 && n.height == h
+	}
+	_, ok := check(m.root)
+	return ok
+}
+
+// sortedMapRangeMatchesFilter compares Range with filtering every key
+func sortedMapRangeMatchesFilter(items []int) bool {
+	m := NewSortedMap[int, int]()
+	for _, item := range items {
+		m.Put(item, item)
+	}
+	lo, hi := -10, 20
+	var got []int
+	m.Range(lo, hi, func(k, _ int) { got = append(got, k) })
+	want := Filter(m.Keys(), func(k int) bool { return lo <= k && k < hi })
+	return fmt.Sprint(got) == fmt.Sprint(want)
+}
@@ -0,0 +1,614 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ==========================================
+// Binary Search Tree
+// ==========================================
+
+// The BinaryTree in 05_generic_containers.go fills levels left to right,
+// so it is always compact but its values are in no particular order and
+// finding one means visiting every node. A search tree keeps smaller
+// values to the left and larger ones to the right, so a lookup follows a
+// single path. How long that path is depends on the shape: a plain BST
+// fed sorted input degenerates into a linked list, while the red-black
+// tree below rebalances itself on every change.
+
+// searchNode is a node of BST and RBTree; only RBTree uses red
+type searchNode[T Orderable] struct {
+	value       T
+	left, right *searchNode[T]
+	red         bool
+}
+
+// BST is an unbalanced binary search tree of distinct values
+type BST[T Orderable] struct {
+	root *searchNode[T]
+	size int
+}
+
+// NewBST creates an empty binary search tree
+func NewBST[T Orderable]() *BST[T] {
+	return &BST[T]{}
+}
+
+// Insert adds value and reports whether it was not already present
+func (t *BST[T]) Insert(value T) bool {
+	link := &t.root
+	for *link != nil {
+		switch n := *link; {
+		case value < n.value:
+			link = &n.left
+		case value > n.value:
+			link = &n.right
+		default:
+			return false
+		}
+	}
+	*link = &searchNode[T]{value: value}
+	t.size++
+	return true
+}
+
+// Delete removes value and reports whether it was present
+func (t *BST[T]) Delete(value T) bool {
+	link := &t.root
+	for *link != nil && (*link).value != value {
+		if value < (*link).value {
+			link = &(*link).left
+		} else {
+			link = &(*link).right
+		}
+	}
+	n := *link
+	if n == nil {
+		return false
+	}
+	switch {
+	case n.left == nil:
+		*link = n.right
+	case n.right == nil:
+		*link = n.left
+	default:
+		// Two children: unlink the successor and move its value up
+		succ := &n.right
+		for (*succ).left != nil {
+			succ = &(*succ).left
+		}
+		n.value = (*succ).value
+		*succ = (*succ).right
+	}
+	t.size--
+	return true
+}
+
+// Search reports whether value is in the tree
+func (t *BST[T]) Search(value T) bool { return searchFind(t.root, value) != nil }
+
+// Min returns the smallest value
+func (t *BST[T]) Min() (T, bool) { return searchMin(t.root) }
+
+// Max returns the largest value
+func (t *BST[T]) Max() (T, bool) { return searchMax(t.root) }
+
+// Successor returns the smallest value greater than value, which need not
+// be in the tree itself
+func (t *BST[T]) Successor(value T) (T, bool) { return searchSuccessor(t.root, value) }
+
+// Predecessor returns the largest value less than value
+func (t *BST[T]) Predecessor(value T) (T, bool) { return searchPredecessor(t.root, value) }
+
+// InOrder returns the values in ascending order
+func (t *BST[T]) InOrder() []T { return searchInOrder(t.root, make([]T, 0, t.size)) }
+
+// Size returns the number of values
+func (t *BST[T]) Size() int { return t.size }
+
+// Height returns the number of nodes on the longest root-to-leaf path
+func (t *BST[T]) Height() int { return searchHeight(t.root) }
+
+// ==========================================
+// Red-Black Tree
+// ==========================================
+
+// RBTree is a left-leaning red-black tree (Sedgewick, 2008). Every path
+// from the root to a missing child passes the same number of black nodes
+// and no red node has a red child, so the longest path is at most twice
+// the shortest and the height stays below 2*log2(n+1). Left-leaning means
+// red links only ever point left, which halves the cases to handle.
+type RBTree[T Orderable] struct {
+	root *searchNode[T]
+	size int
+}
+
+// NewRBTree creates an empty red-black tree
+func NewRBTree[T Orderable]() *RBTree[T] {
+	return &RBTree[T]{}
+}
+
+// Insert adds value and reports whether it was not already present
+func (t *RBTree[T]) Insert(value T) bool {
+	var added bool
+	t.root, added = rbInsert(t.root, value)
+	t.root.red = false
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Delete removes value and reports whether it was present
+func (t *RBTree[T]) Delete(value T) bool {
+	if !t.Search(value) {
+		return false
+	}
+	// Deletion pushes a red link down the search path, so it must start
+	// with one at the root
+	if !isRed(t.root.left) && !isRed(t.root.right) {
+		t.root.red = true
+	}
+	t.root = rbDelete(t.root, value)
+	if t.root != nil {
+		t.root.red = false
+	}
+	t.size--
+	return true
+}
+
+// Search reports whether value is in the tree
+func (t *RBTree[T]) Search(value T) bool { return searchFind(t.root, value) != nil }
+
+// Min returns the smallest value
+func (t *RBTree[T]) Min() (T, bool) { return searchMin(t.root) }
+
+// Max returns the largest value
+func (t *RBTree[T]) Max() (T, bool) { return searchMax(t.root) }
+
+// Successor returns the smallest value greater than value
+func (t *RBTree[T]) Successor(value T) (T, bool) { return searchSuccessor(t.root, value) }
+
+// Predecessor returns the largest value less than value
+func (t *RBTree[T]) Predecessor(value T) (T, bool) { return searchPredecessor(t.root, value) }
+
+// InOrder returns the values in ascending order
+func (t *RBTree[T]) InOrder() []T { return searchInOrder(t.root, make([]T, 0, t.size)) }
+
+// Size returns the number of values
+func (t *RBTree[T]) Size() int { return t.size }
+
+// Height returns the number of nodes on the longest root-to-leaf path
+func (t *RBTree[T]) Height() int { return searchHeight(t.root) }
+
+// CheckInvariants returns an error describing the first red-black rule
+// the tree breaks, or nil
+func (t *RBTree[T]) CheckInvariants() error {
+	if isRed(t.root) {
+		return fmt.Errorf("root is red")
+	}
+	if _, err := rbCheck(t.root, nil, nil); err != nil {
+		return err
+	}
+	if n := searchSize(t.root); n != t.size {
+		return fmt.Errorf("size is %d but the tree holds %d values", t.size, n)
+	}
+	return nil
+}
+
+func isRed[T Orderable](n *searchNode[T]) bool {
+	return n != nil && n.red
+}
+
+func rbRotateLeft[T Orderable](h *searchNode[T]) *searchNode[T] {
+	x := h.right
+	h.right, x.left = x.left, h
+	x.red, h.red = h.red, true
+	return x
+}
+
+func rbRotateRight[T Orderable](h *searchNode[T]) *searchNode[T] {
+	x := h.left
+	h.left, x.right = x.right, h
+	x.red, h.red = h.red, true
+	return x
+}
+
+// rbFlip splits a temporary 4-node into two 2-nodes, or merges them back
+func rbFlip[T Orderable](h *searchNode[T]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+// rbFixUp restores the left-leaning invariants on the way back up
+func rbFixUp[T Orderable](h *searchNode[T]) *searchNode[T] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rbRotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rbRotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		rbFlip(h)
+	}
+	return h
+}
+
+func rbInsert[T Orderable](h *searchNode[T], value T) (*searchNode[T], bool) {
+	if h == nil {
+		return &searchNode[T]{value: value, red: true}, true
+	}
+	var added bool
+	switch {
+	case value < h.value:
+		h.left, added = rbInsert(h.left, value)
+	case value > h.value:
+		h.right, added = rbInsert(h.right, value)
+	default:
+		return h, false
+	}
+	return rbFixUp(h), added
+}
+
+// rbMoveRedLeft makes h.left or one of its children red, so a node can be
+// removed from the left subtree without changing its black height
+func rbMoveRedLeft[T Orderable](h *searchNode[T]) *searchNode[T] {
+	rbFlip(h)
+	if isRed(h.right.left) {
+		h.right = rbRotateRight(h.right)
+		h = rbRotateLeft(h)
+		rbFlip(h)
+	}
+	return h
+}
+
+// rbMoveRedRight is the mirror image of rbMoveRedLeft
+func rbMoveRedRight[T Orderable](h *searchNode[T]) *searchNode[T] {
+	rbFlip(h)
+	if isRed(h.left.left) {
+		h = rbRotateRight(h)
+		rbFlip(h)
+	}
+	return h
+}
+
+func rbDeleteMin[T Orderable](h *searchNode[T]) *searchNode[T] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = rbMoveRedLeft(h)
+	}
+	h.left = rbDeleteMin(h.left)
+	return rbFixUp(h)
+}
+
+// rbDelete removes value, which must be in the subtree rooted at h
+func rbDelete[T Orderable](h *searchNode[T], value T) *searchNode[T] {
+	if value < h.value {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = rbMoveRedLeft(h)
+		}
+		h.left = rbDelete(h.left, value)
+		return rbFixUp(h)
+	}
+	if isRed(h.left) {
+		h = rbRotateRight(h)
+	}
+	if value == h.value && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = rbMoveRedRight(h)
+	}
+	if value == h.value {
+		h.value, _ = searchMin(h.right)
+		h.right = rbDeleteMin(h.right)
+	} else {
+		h.right = rbDelete(h.right, value)
+	}
+	return rbFixUp(h)
+}
+
+// rbCheck returns the black height of the subtree at n, checking order
+// against the exclusive bounds lo and hi on the way
+func rbCheck[T Orderable](n *searchNode[T], lo, hi *T) (int, error) {
+	if n == nil {
+		return 1, nil
+	}
+	if (lo != nil && n.value <= *lo) || (hi != nil && n.value >= *hi) {
+		return 0, fmt.Errorf("%v is out of search order", n.value)
+	}
+	if isRed(n.right) {
+		return 0, fmt.Errorf("right child of %v is red", n.value)
+	}
+	if isRed(n) && isRed(n.left) {
+		return 0, fmt.Errorf("red %v has a red child", n.value)
+	}
+	left, err := rbCheck(n.left, lo, &n.value)
+	if err != nil {
+		return 0, err
+	}
+	right, err := rbCheck(n.right, &n.value, hi)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("black heights under %v differ: %d vs %d", n.value, left, right)
+	}
+	if isRed(n) {
+		return left, nil
+	}
+	return left + 1, nil
+}
+
+// ==========================================
+// Shared Search Helpers
+// ==========================================
+
+func searchFind[T Orderable](n *searchNode[T], value T) *searchNode[T] {
+	for n != nil && n.value != value {
+		if value < n.value {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return n
+}
+
+func searchMin[T Orderable](n *searchNode[T]) (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.value, true
+}
+
+func searchMax[T Orderable](n *searchNode[T]) (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, true
+}
+
+// searchSuccessor walks down from the root remembering the last node it
+// went left at: that is the smallest value seen so far above value
+func searchSuccessor[T Orderable](n *searchNode[T], value T) (T, bool) {
+	var found *searchNode[T]
+	for n != nil {
+		if n.value > value {
+			found, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	if found == nil {
+		var zero T
+		return zero, false
+	}
+	return found.value, true
+}
+
+func searchPredecessor[T Orderable](n *searchNode[T], value T) (T, bool) {
+	var found *searchNode[T]
+	for n != nil {
+		if n.value < value {
+			found, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	if found == nil {
+		var zero T
+		return zero, false
+	}
+	return found.value, true
+}
+
+func searchInOrder[T Orderable](n *searchNode[T], result []T) []T {
+	if n == nil {
+		return result
+	}
+	result = searchInOrder(n.left, result)
+	result = append(result, n.value)
+	return searchInOrder(n.right, result)
+}
+
+func searchHeight[T Orderable](n *searchNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + max(searchHeight(n.left), searchHeight(n.right))
+}
+
+func searchSize[T Orderable](n *searchNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + searchSize(n.left) + searchSize(n.right)
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runSearchTreesExample() {
+	fmt.Println("\n🔸 BinaryTree vs BST")
+
+	words := []string{"pear", "apple", "fig", "plum", "kiwi", "date", "lime"}
+	level := NewBinaryTree[string]()
+	bst := NewBST[string]()
+	for _, w := range words {
+		level.Insert(w)
+		bst.Insert(w)
+	}
+	fmt.Printf("BinaryTree in-order: %v (insertion shape, not sorted)\n", level.InOrder())
+	fmt.Printf("BST in-order:        %v\n", bst.InOrder())
+
+	fmt.Println("\n🔸 BST Operations")
+
+	first, _ := bst.Min()
+	last, _ := bst.Max()
+	fmt.Printf("Min %q, max %q, contains \"kiwi\": %v, contains \"mango\": %v\n",
+		first, last, bst.Search("kiwi"), bst.Search("mango"))
+	next, _ := bst.Successor("kiwi")
+	prev, _ := bst.Predecessor("kiwi")
+	between, _ := bst.Successor("grape")
+	fmt.Printf("Successor of \"kiwi\": %q, predecessor: %q, successor of absent \"grape\": %q\n", next, prev, between)
+	if _, ok := bst.Successor("plum"); !ok {
+		fmt.Println("\"plum\" is the largest, so it has no successor")
+	}
+
+	// Deleting a leaf, a node with one child, and the root with two
+	for _, w := range []string{"lime", "fig", "pear"} {
+		bst.Delete(w)
+		fmt.Printf("Deleted %-6q %v\n", w, bst.InOrder())
+	}
+
+	fmt.Println("\n🔸 Shape: Sorted vs Random Input")
+
+	const n = 1023
+	shuffled := Range(0, n, 1)
+	rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	fmt.Printf("  %-14s %10s %10s\n", "input", "BST", "RBTree")
+	for _, input := range []struct {
+		name   string
+		values []int
+	}{{"sorted", Range(0, n, 1)}, {"shuffled", shuffled}} {
+		plain, balanced := NewBST[int](), NewRBTree[int]()
+		for _, v := range input.values {
+			plain.Insert(v)
+			balanced.Insert(v)
+		}
+		fmt.Printf("  %-14s %10d %10d\n", input.name, plain.Height(), balanced.Height())
+	}
+	fmt.Printf("Heights for %d values; the best possible is %d, the red-black bound %.0f\n",
+		n, int(math.Ceil(math.Log2(n+1))), 2*math.Log2(n+1))
+
+	fmt.Println("\n🔸 Red-Black Invariants")
+
+	rb := NewRBTree[int]()
+	for _, v := range shuffled {
+		rb.Insert(v)
+	}
+	for v := 0; v < n; v += 3 {
+		rb.Delete(v)
+	}
+	fmt.Printf("After deleting every third value: %d values, height %d, invariants: %v\n",
+		rb.Size(), rb.Height(), rb.CheckInvariants())
+
+	// Breaking the tree by hand shows what the checker reports
+	rb.root.left.red = true
+	rb.root.left.left.red = true
+	fmt.Printf("After recoloring two nodes red: %v\n", rb.CheckInvariants())
+
+	fmt.Println("\n🔸 Property Checks")
+
+	// The property runner from 10_property_testing.go; each case inserts a
+	// random slice and then deletes some of it
+	ops := SlicesOf(Ints(-60, 60), 60)
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"BST in-order matches a sorted set", func() string { return ForAll(ops, bstMatchesSortedSet).String() }},
+		{"Successor and Predecessor match a scan", func() string { return ForAll(ops, bstNeighborsMatchScan).String() }},
+		{"RBTree keeps red-black invariants", func() string { return ForAll(ops, rbKeepsInvariants).String() }},
+		{"RBTree height is at most 2*log2(n+1)", func() string { return ForAll(ops, rbHeightIsLogarithmic).String() }},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Search tree examples completed!")
+}
+
+// sortedSetAfterOps applies the operations used by the properties to a
+// plain map: insert every item, then delete the odd ones
+func sortedSetAfterOps(items []int) []int {
+	set := make(map[int]bool)
+	for _, item := range items {
+		set[item] = true
+	}
+	var result []int
+	for v := range set {
+		if v%2 == 0 {
+			result = append(result, v)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// searchTree is what the properties need from both trees
+type searchTree interface {
+	Insert(int) bool
+	Delete(int) bool
+	InOrder() []int
+	Size() int
+}
+
+func applyOps(t searchTree, items []int) {
+	for _, item := range items {
+		t.Insert(item)
+	}
+	for _, item := range items {
+		if item%2 != 0 {
+			t.Delete(item)
+		}
+	}
+}
+
+func bstMatchesSortedSet(items []int) bool {
+	t := NewBST[int]()
+	applyOps(t, items)
+	want := sortedSetAfterOps(items)
+	return fmt.Sprint(t.InOrder()) == fmt.Sprint(want) && t.Size() == len(want)
+}
+
+// bstNeighborsMatchScan compares Successor and Predecessor of every value
+// in range, present or not, with a linear scan of the sorted values
+func bstNeighborsMatchScan(items []int) bool {
+	t := NewBST[int]()
+	applyOps(t, items)
+	sorted := t.InOrder()
+	for v := -61; v <= 61; v++ {
+		i := sort.SearchInts(sorted, v+1) // First value > v
+		succ, ok := t.Successor(v)
+		if ok != (i < len(sorted)) || (ok && succ != sorted[i]) {
+			return false
+		}
+		j := sort.SearchInts(sorted, v) - 1 // Last value < v
+		pred, ok := t.Predecessor(v)
+		if ok != (j >= 0) || (ok && pred != sorted[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+func rbKeepsInvariants(items []int) bool {
+	t := NewRBTree[int]()
+	applyOps(t, items)
+	return t.CheckInvariants() == nil && fmt.Sprint(t.InOrder()) == fmt.Sprint(sortedSetAfterOps(items))
+}
+
+func rbHeightIsLogarithmic(items []int) bool {
+	t := NewRBTree[int]()
+	applyOps(t, items)
+	return float64(t.Height()) <= 2*math.Log2(float64(t.Size()+1))
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Binary Heap
+// ==========================================
+
+// container/heap works on an interface the caller implements over their
+// own slice, with interface{} values going in and out. Heap[T] does the
+// same sifting on a slice it owns and orders it with a Cmp from
+// 16_sorted_views.go, so a max-heap is just cmp.Reversed().
+
+// Heap keeps the item that sorts first under cmp at the top. Push and Pop
+// are O(log n), Peek is O(1).
+type Heap[T any] struct {
+	items []T
+	cmp   Cmp[T]
+	moved func(item T, index int) // Called whenever an item changes position
+}
+
+// NewHeap creates an empty heap ordered by cmp
+func NewHeap[T any](cmp Cmp[T]) *Heap[T] {
+	return &Heap[T]{cmp: cmp}
+}
+
+// HeapFrom builds a heap from items in O(n), which beats n Pushes. The
+// heap takes ownership of the slice.
+func HeapFrom[T any](items []T, cmp Cmp[T]) *Heap[T] {
+	h := &Heap[T]{items: items, cmp: cmp}
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Push adds an item
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.notify(len(h.items) - 1)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the top item
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Remove(0), true
+}
+
+// Peek returns the top item without removing it
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Len returns the number of items
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Fix restores the heap order after the item at index i changed
+func (h *Heap[T]) Fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// Remove removes and returns the item at index i
+func (h *Heap[T]) Remove(i int) T {
+	last := len(h.items) - 1
+	item := h.items[i]
+	if i != last {
+		h.swap(i, last)
+	}
+	var zero T
+	h.items[last] = zero // Let the garbage collector have it
+	h.items = h.items[:last]
+	if i != last {
+		h.Fix(i)
+	}
+	return item
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h.cmp(h.items[i], h.items[parent]) >= 0 {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts the item at i towards the leaves and reports whether it moved
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	for {
+		first := 2*i + 1
+		if first >= len(h.items) {
+			break
+		}
+		if right := first + 1; right < len(h.items) && h.cmp(h.items[right], h.items[first]) < 0 {
+			first = right
+		}
+		if h.cmp(h.items[first], h.items[i]) >= 0 {
+			break
+		}
+		h.swap(i, first)
+		i = first
+	}
+	return i > start
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.notify(i)
+	h.notify(j)
+}
+
+func (h *Heap[T]) notify(i int) {
+	if h.moved != nil {
+		h.moved(h.items[i], i)
+	}
+}
+
+// ==========================================
+// Priority Queue
+// ==========================================
+
+// PQItem is a handle to a queued value, used to change its priority
+type PQItem[T any] struct {
+	Value    T
+	priority int
+	seq      uint64 // Insertion order, so equal priorities stay FIFO
+	index    int    // Position in the heap, -1 once popped
+}
+
+// Priority returns the item's current priority
+func (it *PQItem[T]) Priority() int {
+	return it.priority
+}
+
+// PriorityQueue pops the value with the highest priority first, and the
+// oldest among equals. It is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	heap *Heap[*PQItem[T]]
+	seq  uint64
+}
+
+// NewPriorityQueue creates an empty priority queue
+func NewPriorityQueue[T any]() *PriorityQueue[T] {
+	byPriority := CmpBy(func(it *PQItem[T]) int { return it.priority }).Reversed()
+	bySeq := CmpBy(func(it *PQItem[T]) uint64 { return it.seq })
+	h := NewHeap(byPriority.ThenBy(bySeq))
+	h.moved = func(it *PQItem[T], index int) { it.index = index }
+	return &PriorityQueue[T]{heap: h}
+}
+
+// Push queues value and returns its handle
+func (q *PriorityQueue[T]) Push(value T, priority int) *PQItem[T] {
+	q.seq++
+	it := &PQItem[T]{Value: value, priority: priority, seq: q.seq}
+	q.heap.Push(it)
+	return it
+}
+
+// Pop removes and returns the value with the highest priority
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	it, ok := q.heap.Pop()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	it.index = -1
+	return it.Value, true
+}
+
+// Peek returns the value Pop would return, without removing it
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	it, ok := q.heap.Peek()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return it.Value, true
+}
+
+// Update changes the priority of a queued item in O(log n). It reports
+// false if the item has already been popped or removed.
+func (q *PriorityQueue[T]) Update(it *PQItem[T], priority int) bool {
+	if it.index < 0 {
+		return false
+	}
+	it.priority = priority
+	q.heap.Fix(it.index)
+	return true
+}
+
+// Remove takes a queued item out without popping everything before it
+func (q *PriorityQueue[T]) Remove(it *PQItem[T]) bool {
+	if it.index < 0 {
+		return false
+	}
+	q.heap.Remove(it.index)
+	it.index = -1
+	return true
+}
+
+// Values returns the queued values in the order Pop would return them,
+// without removing them
+func (q *PriorityQueue[T]) Values() []T {
+	values := make([]T, 0, q.heap.Len())
+	for _, it := range SortedBy(q.heap.items, q.heap.cmp) {
+		values = append(values, it.Value)
+	}
+	return values
+}
+
+// Len returns the number of queued values
+func (q *PriorityQueue[T]) Len() int {
+	return q.heap.Len()
+}
+
+// ==========================================
+// Prioritized Worker Pool Submission
+// ==========================================
+
+// The WorkerPool in 20_worker_pool.go takes jobs from a FIFO channel.
+// PriorityDispatcher holds waiting jobs in a PriorityQueue instead and
+// hands the most urgent one to the pool whenever the pool can take it, so
+// jobs still waiting here can be overtaken or re-prioritized. Jobs already
+// handed over keep their place: keep the pool's QueueSize small.
+
+// dispatchJob is a job waiting in a PriorityDispatcher
+type dispatchJob[T, R any] struct {
+	ctx    context.Context
+	input  T
+	result chan Outcome[T, R]
+}
+
+// Ticket identifies a submitted job; Result receives exactly one Outcome
+type Ticket[T, R any] struct {
+	Result <-chan Outcome[T, R]
+	item   *PQItem[dispatchJob[T, R]]
+}
+
+// PriorityDispatcher feeds a WorkerPool in priority order
+type PriorityDispatcher[T, R any] struct {
+	pool *WorkerPool[T, R]
+
+	mu      sync.Mutex // Guards queue, started and closed
+	queue   *PriorityQueue[dispatchJob[T, R]]
+	started bool
+	closed  bool
+	wake    chan struct{} // Buffered: a pending wake-up is never lost
+	done    chan struct{}
+}
+
+// NewPriorityDispatcher creates a dispatcher for pool. Jobs wait until
+// Start, which lets a backlog be queued and ordered first.
+func NewPriorityDispatcher[T, R any](pool *WorkerPool[T, R]) *PriorityDispatcher[T, R] {
+	return &PriorityDispatcher[T, R]{
+		pool:  pool,
+		queue: NewPriorityQueue[dispatchJob[T, R]](),
+		wake:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+// Submit queues a job with a priority; higher runs sooner
+func (d *PriorityDispatcher[T, R]) Submit(ctx context.Context, job T, priority int) (*Ticket[T, R], error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil, ErrPoolClosed
+	}
+	result := make(chan Outcome[T, R], 1)
+	item := d.queue.Push(dispatchJob[T, R]{ctx: ctx, input: job, result: result}, priority)
+	d.signal()
+	return &Ticket[T, R]{Result: result, item: item}, nil
+}
+
+// Reprioritize changes the priority of a job still waiting in the
+// dispatcher, and reports false once it has been handed to the pool
+func (d *PriorityDispatcher[T, R]) Reprioritize(t *Ticket[T, R], priority int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queue.Update(t.item, priority)
+}
+
+// Pending returns how many jobs wait in the dispatcher
+func (d *PriorityDispatcher[T, R]) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queue.Len()
+}
+
+// Start begins handing jobs to the pool. Calling it again does nothing.
+func (d *PriorityDispatcher[T, R]) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.started {
+		d.started = true
+		go d.dispatch()
+	}
+}
+
+// Close stops accepting jobs and waits until every waiting job has been
+// handed to the pool; Drain the pool afterwards to wait for the results
+func (d *PriorityDispatcher[T, R]) Close() {
+	d.mu.Lock()
+	d.closed = true
+	d.signal()
+	d.mu.Unlock()
+	d.Start() // A dispatcher that never started still hands over its backlog
+	<-d.done
+}
+
+func (d *PriorityDispatcher[T, R]) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch pops the most urgent job and submits it. Submit blocks while
+// the pool's queue is full, and meanwhile the remaining jobs stay here,
+// where priority still applies.
+func (d *PriorityDispatcher[T, R]) dispatch() {
+	defer close(d.done)
+	for {
+		d.mu.Lock()
+		for d.queue.Len() == 0 && !d.closed {
+			d.mu.Unlock()
+			<-d.wake
+			d.mu.Lock()
+		}
+		job, ok := d.queue.Pop()
+		d.mu.Unlock()
+		if !ok {
+			return // Closed and empty
+		}
+
+		result, err := d.pool.Submit(job.ctx, job.input)
+		if err != nil {
+			job.result <- Outcome[T, R]{Input: job.input, Err: err}
+			continue
+		}
+		go func() { job.result <- <-result }()
+	}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runHeapExample() {
+	fmt.Println("\n🔸 Min-Heap and Max-Heap From One Comparator")
+
+	values := []int{42, 7, 19, 3, 88, 23, 7, 61}
+	minHeap := HeapFrom(append([]int(nil), values...), CmpOrdered[int])
+	maxHeap := NewHeap(Cmp[int](CmpOrdered[int]).Reversed())
+	for _, v := range values {
+		maxHeap.Push(v)
+	}
+	drain := func(h *Heap[int]) []int {
+		var out []int
+		for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+			out = append(out, v)
+		}
+		return out
+	}
+	fmt.Printf("Input:         %v\n", values)
+	fmt.Printf("Min-heap pops: %v\n", drain(minHeap))
+	fmt.Printf("Max-heap pops: %v\n", drain(maxHeap))
+
+	fmt.Println("\n🔸 Top-K With a Bounded Heap")
+
+	// Keep the 3 largest of a stream in a min-heap of size 3: the smallest
+	// of the kept ones sits on top, ready to be evicted
+	type score struct {
+		player string
+		points int
+	}
+	stream := []score{{"ann", 120}, {"bob", 340}, {"cy", 95}, {"dee", 410}, {"eve", 230}, {"fox", 380}, {"gus", 150}}
+	top := NewHeap(CmpBy(func(s score) int { return s.points }))
+	for _, s := range stream {
+		top.Push(s)
+		if top.Len() > 3 {
+			top.Pop()
+		}
+	}
+	var leaders []string
+	for s, ok := top.Pop(); ok; s, ok = top.Pop() {
+		leaders = append([]string{fmt.Sprintf("%s=%d", s.player, s.points)}, leaders...)
+	}
+	fmt.Printf("Top 3 of %d scores, memory for 4: %v\n", len(stream), leaders)
+
+	fmt.Println("\n🔸 Priority Queue With Updates")
+
+	tickets := NewPriorityQueue[string]()
+	tickets.Push("typo on pricing page", 1)
+	outage := tickets.Push("checkout returns 500", 5)
+	login := tickets.Push("login slow for some users", 3)
+	tickets.Push("export button misaligned", 1)
+	spam := tickets.Push("spam signups", 2)
+
+	next, _ := tickets.Peek()
+	fmt.Printf("Next up: %q (priority %d)\n", next, outage.Priority())
+	tickets.Update(login, 9) // Turned out to be every user
+	tickets.Remove(spam)     // Fixed by someone else
+	fmt.Printf("Escalated %q to %d and dropped %q\n", login.Value, login.Priority(), spam.Value)
+	for i := 1; tickets.Len() > 0; i++ {
+		t, _ := tickets.Pop()
+		fmt.Printf("  %d. %s\n", i, t)
+	}
+	fmt.Printf("Updating a popped item: %v\n", tickets.Update(outage, 10))
+
+	fmt.Println("\n🔸 Worker Pool Fed by Priority")
+
+	var mu sync.Mutex
+	var order []string
+	pool := NewWorkerPoolWithOptions(1, func(_ context.Context, job string) (string, error) {
+		time.Sleep(30 * time.Millisecond)
+		mu.Lock()
+		order = append(order, job)
+		mu.Unlock()
+		return strings.ToUpper(job), nil
+	}, PoolOptions{QueueSize: 1})
+	dispatcher := NewPriorityDispatcher(pool)
+	ctx := context.Background()
+
+	// A backlog queued while the dispatcher is stopped
+	backlog := map[string]int{"resize-images": 1, "send-receipts": 5, "rebuild-index": 2, "charge-cards": 8}
+	var submitted []*Ticket[string, string]
+	for _, job := range []string{"resize-images", "send-receipts", "rebuild-index", "charge-cards"} {
+		t, _ := dispatcher.Submit(ctx, job, backlog[job])
+		submitted = append(submitted, t)
+	}
+	dispatcher.Reprioritize(submitted[2], 9) // rebuild-index is now urgent
+	fmt.Printf("Queued %d jobs; rebuild-index escalated to priority 9\n", dispatcher.Pending())
+	dispatcher.Start()
+
+	// Arrives while the others run; overtakes the ones still waiting here
+	time.Sleep(10 * time.Millisecond)
+	late, _ := dispatcher.Submit(ctx, "fraud-alert", 10)
+	submitted = append(submitted, late)
+
+	dispatcher.Close()
+	pool.Drain()
+	fmt.Printf("Processed in order: %v\n", order)
+	for _, t := range submitted {
+		o := <-t.Result
+		fmt.Printf("  %-14s -> %s\n", o.Input, o.Result)
+	}
+	fmt.Println("Jobs already handed to the pool keep their place, which is why")
+	fmt.Println("the pool's QueueSize is 1 here.")
+
+	fmt.Println("\n✅ Heap and priority queue examples completed!")
+}
@@ -0,0 +1,494 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Persistent Collections
+// ==========================================
+
+// A persistent collection is never modified: Set, Append, Put and Delete
+// return a new version and leave the old one intact. Copying everything
+// would make that O(n), so the versions share structure: an update copies
+// only the nodes on the path from the root to the change, O(log n) of
+// them, and points the copies at the untouched subtrees.
+//
+// Because no version ever changes after it is built, any number of
+// goroutines may read one without locks. A writer builds the next version
+// and publishes it with a single atomic pointer store.
+
+// ==========================================
+// Persistent List (32-way Trie)
+// ==========================================
+
+const (
+	plBits  = 5
+	plWidth = 1 << plBits
+	plMask  = plWidth - 1
+)
+
+// plNode is a trie node: inner nodes hold children, leaves hold values
+type plNode[T any] struct {
+	children []*plNode[T]
+	values   []T
+}
+
+// PersistentList is an immutable indexed sequence, shaped like Clojure's
+// vector: a trie with 32 slots per node, indexed by 5 bits of the
+// position per level, so a million values are only four levels deep. The
+// zero value is an empty list.
+type PersistentList[T any] struct {
+	root  *plNode[T]
+	size  int
+	shift int // Bits consumed below the root: 0 when the root is a leaf
+}
+
+// PersistentListOf builds a list from values
+func PersistentListOf[T any](values ...T) PersistentList[T] {
+	var l PersistentList[T]
+	for _, v := range values {
+		l = l.Append(v)
+	}
+	return l
+}
+
+// Len returns the number of values
+func (l PersistentList[T]) Len() int {
+	return l.size
+}
+
+// Get returns the value at index i
+func (l PersistentList[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= l.size {
+		var zero T
+		return zero, false
+	}
+	n := l.root
+	for level := l.shift; level > 0; level -= plBits {
+		n = n.children[(i>>level)&plMask]
+	}
+	return n.values[i&plMask], true
+}
+
+// Set returns a list with the value at index i replaced. Like indexing a
+// slice, it panics when i is out of range.
+func (l PersistentList[T]) Set(i int, value T) PersistentList[T] {
+	if i < 0 || i >= l.size {
+		panic(fmt.Sprintf("persistent list: index %d out of range [0:%d]", i, l.size))
+	}
+	l.root = plSet(l.root, l.shift, i, value)
+	return l
+}
+
+// Append returns a list with value added at the end
+func (l PersistentList[T]) Append(value T) PersistentList[T] {
+	switch {
+	case l.root == nil:
+		l.root = &plNode[T]{values: []T{value}}
+	case l.size == 1<<(l.shift+plBits):
+		// Every slot is used: grow a level above the old root
+		l.root = &plNode[T]{children: []*plNode[T]{l.root, plPath(l.shift, value)}}
+		l.shift += plBits
+	default:
+		l.root = plAppend(l.root, l.shift, l.size, value)
+	}
+	l.size++
+	return l
+}
+
+// ToSlice copies the values into a new slice
+func (l PersistentList[T]) ToSlice() []T {
+	result := make([]T, 0, l.size)
+	var walk func(n *plNode[T])
+	walk = func(n *plNode[T]) {
+		result = append(result, n.values...)
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	if l.root != nil {
+		walk(l.root)
+	}
+	return result
+}
+
+// plSet copies the path to index i and replaces the value at its end
+func plSet[T any](n *plNode[T], level, i int, value T) *plNode[T] {
+	copied := &plNode[T]{}
+	if level == 0 {
+		copied.values = append([]T(nil), n.values...)
+		copied.values[i&plMask] = value
+		return copied
+	}
+	copied.children = append([]*plNode[T](nil), n.children...)
+	slot := (i >> level) & plMask
+	copied.children[slot] = plSet(n.children[slot], level-plBits, i, value)
+	return copied
+}
+
+// plAppend copies the path to index i, which is one past the end
+func plAppend[T any](n *plNode[T], level, i int, value T) *plNode[T] {
+	copied := &plNode[T]{}
+	if level == 0 {
+		copied.values = append(append(make([]T, 0, len(n.values)+1), n.values...), value)
+		return copied
+	}
+	copied.children = append([]*plNode[T](nil), n.children...)
+	if slot := (i >> level) & plMask; slot < len(n.children) {
+		copied.children[slot] = plAppend(n.children[slot], level-plBits, i, value)
+	} else {
+		copied.children = append(copied.children, plPath(level-plBits, value))
+	}
+	return copied
+}
+
+// plPath builds a chain of new nodes down to a leaf holding value
+func plPath[T any](level int, value T) *plNode[T] {
+	if level == 0 {
+		return &plNode[T]{values: []T{value}}
+	}
+	return &plNode[T]{children: []*plNode[T]{plPath(level-plBits, value)}}
+}
+
+// ==========================================
+// Persistent Map (Path-Copying AVL Tree)
+// ==========================================
+
+// pmNode is a node of an immutable AVL tree; it is never changed once built
+type pmNode[K Orderable, V any] struct {
+	key         K
+	value       V
+	left, right *pmNode[K, V]
+	height      int
+}
+
+// PersistentMap is an immutable sorted map. It balances like the SortedMap
+// in 23_ordered_maps.go, but rotations build new nodes instead of
+// relinking old ones. The zero value is an empty map.
+type PersistentMap[K Orderable, V any] struct {
+	root *pmNode[K, V]
+	size int
+}
+
+// Len returns the number of keys
+func (m PersistentMap[K, V]) Len() int {
+	return m.size
+}
+
+// Get retrieves a value by key
+func (m PersistentMap[K, V]) Get(key K) (V, bool) {
+	for n := m.root; n != nil; {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Put returns a map with key set to value
+func (m PersistentMap[K, V]) Put(key K, value V) PersistentMap[K, V] {
+	var added bool
+	m.root, added = pmPut(m.root, key, value)
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Delete returns a map without key
+func (m PersistentMap[K, V]) Delete(key K) PersistentMap[K, V] {
+	var removed bool
+	m.root, removed = pmDelete(m.root, key)
+	if removed {
+		m.size--
+	}
+	return m
+}
+
+// ForEach calls fn for every pair in ascending key order
+func (m PersistentMap[K, V]) ForEach(fn func(K, V)) {
+	var walk func(n *pmNode[K, V])
+	walk = func(n *pmNode[K, V]) {
+		if n != nil {
+			walk(n.left)
+			fn(n.key, n.value)
+			walk(n.right)
+		}
+	}
+	walk(m.root)
+}
+
+func pmHeight[K Orderable, V any](n *pmNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func pmMake[K Orderable, V any](key K, value V, left, right *pmNode[K, V]) *pmNode[K, V] {
+	return &pmNode[K, V]{key: key, value: value, left: left, right: right,
+		height: 1 + max(pmHeight(left), pmHeight(right))}
+}
+
+// pmBalance builds a node from parts whose heights differ by at most two,
+// rotating with new nodes when they differ by two
+func pmBalance[K Orderable, V any](key K, value V, left, right *pmNode[K, V]) *pmNode[K, V] {
+	switch {
+	case pmHeight(left) > pmHeight(right)+1:
+		if pmHeight(left.left) >= pmHeight(left.right) {
+			return pmMake(left.key, left.value, left.left, pmMake(key, value, left.right, right))
+		}
+		lr := left.right
+		return pmMake(lr.key, lr.value, pmMake(left.key, left.value, left.left, lr.left), pmMake(key, value, lr.right, right))
+	case pmHeight(right) > pmHeight(left)+1:
+		if pmHeight(right.right) >= pmHeight(right.left) {
+			return pmMake(right.key, right.value, pmMake(key, value, left, right.left), right.right)
+		}
+		rl := right.left
+		return pmMake(rl.key, rl.value, pmMake(key, value, left, rl.left), pmMake(right.key, right.value, rl.right, right.right))
+	}
+	return pmMake(key, value, left, right)
+}
+
+func pmPut[K Orderable, V any](n *pmNode[K, V], key K, value V) (*pmNode[K, V], bool) {
+	if n == nil {
+		return pmMake[K, V](key, value, nil, nil), true
+	}
+	var added bool
+	var child *pmNode[K, V]
+	switch {
+	case key < n.key:
+		child, added = pmPut(n.left, key, value)
+		return pmBalance(n.key, n.value, child, n.right), added
+	case key > n.key:
+		child, added = pmPut(n.right, key, value)
+		return pmBalance(n.key, n.value, n.left, child), added
+	}
+	return pmMake(key, value, n.left, n.right), false
+}
+
+func pmDelete[K Orderable, V any](n *pmNode[K, V], key K) (*pmNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch {
+	case key < n.key:
+		child, removed := pmDelete(n.left, key)
+		if !removed {
+			return n, false // Nothing changed: share the whole subtree
+		}
+		return pmBalance(n.key, n.value, child, n.right), true
+	case key > n.key:
+		child, removed := pmDelete(n.right, key)
+		if !removed {
+			return n, false
+		}
+		return pmBalance(n.key, n.value, n.left, child), true
+	}
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+	succ := n.right
+	for succ.left != nil {
+		succ = succ.left
+	}
+	right, _ := pmDelete(n.right, succ.key)
+	return pmBalance(succ.key, succ.value, n.left, right), true
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runPersistentExample() {
+	fmt.Println("\n🔸 Updates Return New Versions")
+
+	v1 := PersistentListOf("a", "b", "c")
+	v2 := v1.Append("d")
+	v3 := v2.Set(0, "A")
+	fmt.Printf("v1 %v, v2 %v, v3 %v\n", v1.ToSlice(), v2.ToSlice(), v3.ToSlice())
+
+	var prices PersistentMap[string, float64]
+	prices = prices.Put("apple", 1.20).Put("pear", 0.90).Put("plum", 2.10)
+	sale := prices.Put("apple", 0.99).Delete("plum")
+	show := func(m PersistentMap[string, float64]) string {
+		var parts []string
+		m.ForEach(func(k string, v float64) { parts = append(parts, fmt.Sprintf("%s=%.2f", k, v)) })
+		return fmt.Sprint(parts)
+	}
+	fmt.Printf("Regular prices: %s\n", show(prices))
+	fmt.Printf("Sale prices:    %s\n", show(sale))
+
+	fmt.Println("\n🔸 Structural Sharing")
+
+	big := PersistentListOf(Range(0, 100_000, 1)...)
+	bytes, _ := measureAlloc(func() { big = big.Set(54_321, -1) })
+	fmt.Printf("Set on a 100000-item list allocated %d bytes (the slice itself is %d)\n", bytes, 100_000*8)
+	var bigMap PersistentMap[int, int]
+	for i := 0; i < 100_000; i++ {
+		bigMap = bigMap.Put(i, i)
+	}
+	bytes, _ = measureAlloc(func() { bigMap = bigMap.Put(54_321, -1) })
+	fmt.Printf("Put on a 100000-key map allocated %d bytes: one node per level\n", bytes)
+
+	fmt.Println("\n🔸 Lock-Free Readers")
+
+	// Money moves between two accounts; every consistent view totals 1000.
+	// Readers of the published snapshot never see a transfer half done.
+	var accounts PersistentMap[string, int]
+	var current atomic.Pointer[PersistentMap[string, int]]
+	accounts = accounts.Put("alice", 500).Put("bob", 500)
+	current.Store(&accounts)
+
+	safe := NewSafeMap[string, int]()
+	safe.Set("alice", 500)
+	safe.Set("bob", 500)
+
+	// The writer transfers until the readers are done
+	stop := make(chan struct{})
+	writerDone := make(chan int)
+	go func() {
+		transfers := 0
+		for ; ; transfers++ {
+			select {
+			case <-stop:
+				writerDone <- transfers
+				return
+			default:
+			}
+			amount := transfers%7 - 3
+			next := current.Load()
+			a, _ := next.Get("alice")
+			b, _ := next.Get("bob")
+			updated := next.Put("alice", a-amount).Put("bob", b+amount)
+			current.Store(&updated)
+
+			sa, _ := safe.Get("alice")
+			safe.Set("alice", sa-amount)
+			runtime.Gosched() // Let a reader in between the two Sets
+			sb, _ := safe.Get("bob")
+			safe.Set("bob", sb+amount)
+		}
+	}()
+
+	const readers, reads = 4, 5_000
+	var wg sync.WaitGroup
+	var snapshotBad, safeBad atomic.Int64
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < reads; i++ {
+				snap := current.Load()
+				a, _ := snap.Get("alice")
+				b, _ := snap.Get("bob")
+				if a+b != 1000 {
+					snapshotBad.Add(1)
+				}
+				// Each SafeMap call is atomic, but two calls are not
+				sa, _ := safe.Get("alice")
+				sb, _ := safe.Get("bob")
+				if sa+sb != 1000 {
+					safeBad.Add(1)
+				}
+				runtime.Gosched()
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	fmt.Printf("%d reads during %d transfers\n", readers*reads, <-writerDone)
+	fmt.Printf("Snapshot reads with a wrong total: %d\n", snapshotBad.Load())
+	fmt.Printf("SafeMap reads with a wrong total:  %d (two locked calls are not one transaction)\n", safeBad.Load())
+
+	fmt.Println("\n🔸 PersistentMap vs SafeMap")
+	persistentComparison()
+
+	fmt.Println("\n✅ Persistent collection examples completed!")
+}
+
+// persistentComparison times writes and concurrent reads of both maps
+func persistentComparison() {
+	const n = 100_000
+	fmt.Printf("  %-38s %12s %14s\n", "operation", "time", "bytes")
+	row := func(label string, fn func()) {
+		bytes, elapsed := measureAlloc(fn)
+		fmt.Printf("  %-38s %12v %14d\n", label, elapsed.Round(10*time.Microsecond), bytes)
+	}
+
+	safe := NewSafeMap[int, int]()
+	var persistent PersistentMap[int, int]
+	row(fmt.Sprintf("SafeMap %d Sets", n), func() {
+		for i := 0; i < n; i++ {
+			safe.Set(i, i)
+		}
+	})
+	row(fmt.Sprintf("PersistentMap %d Puts", n), func() {
+		for i := 0; i < n; i++ {
+			persistent = persistent.Put(i, i)
+		}
+	})
+
+	// 8 readers, each doing n lookups, while a writer updates a key every
+	// 50µs, as a config or routing table might
+	readUnderWriter := func(read func(int), write func(int)) {
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		go func() {
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				case <-time.After(50 * time.Microsecond):
+					write(i % n)
+				}
+			}
+		}()
+		for r := 0; r < 8; r++ {
+			wg.Add(1)
+			go func(r int) {
+				defer wg.Done()
+				for i := 0; i < n; i++ {
+					read((i*31 + r) % n)
+				}
+			}(r)
+		}
+		wg.Wait()
+		close(done)
+	}
+
+	row("SafeMap 8x reads under a writer", func() {
+		readUnderWriter(
+			func(k int) { safe.Get(k) },
+			func(k int) { safe.Set(k, -k) })
+	})
+	var published atomic.Pointer[PersistentMap[int, int]]
+	published.Store(&persistent)
+	row("PersistentMap 8x reads under a writer", func() {
+		readUnderWriter(
+			func(k int) { published.Load().Get(k) },
+			func(k int) {
+				next := published.Load().Put(k, -k)
+				published.Store(&next)
+			})
+	})
+	fmt.Println("Persistent writes cost more: each allocates a path of new nodes, and")
+	fmt.Println("a tree lookup is O(log n) against the hash map's O(1). What readers")
+	fmt.Println("gain is that they never wait for the writer, never contend on the")
+	fmt.Println("RWMutex's reader count as cores are added, and get a consistent view")
+	fmt.Println("of every key at once.")
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Cache With Expiry and Eviction
+// ==========================================
+
+// Cache bounds memory two ways: entries expire after their TTL, and once
+// the cache is full, Set evicts the entry its policy values least. LRU
+// order is an OrderedMap from 23_ordered_maps.go moved to the back on each
+// access; LFU keeps a PriorityQueue from 25_heap.go keyed by use count.
+// Expired entries are dropped when they are looked up, and by an optional
+// janitor goroutine so that entries nobody asks for again are freed too.
+
+// EvictionPolicy chooses which entry a full cache gives up
+type EvictionPolicy int
+
+const (
+	// EvictLRU evicts the least recently used entry
+	EvictLRU EvictionPolicy = iota
+	// EvictLFU evicts the least frequently used entry, the oldest among equals
+	EvictLFU
+)
+
+// EvictionReason says why an entry left the cache
+type EvictionReason int
+
+const (
+	EvictedExpired EvictionReason = iota
+	EvictedCapacity
+	EvictedDeleted
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictedExpired:
+		return "expired"
+	case EvictedCapacity:
+		return "capacity"
+	case EvictedDeleted:
+		return "deleted"
+	}
+	return fmt.Sprintf("EvictionReason(%d)", int(r))
+}
+
+// CacheOptions configures a Cache; the zero value is an unbounded LRU
+// cache without a janitor
+type CacheOptions[K comparable, V any] struct {
+	MaxSize         int            // Entries kept before evicting; 0 means unbounded
+	Policy          EvictionPolicy // Which entry to evict when full
+	JanitorInterval time.Duration  // How often to sweep expired entries; 0 means never
+	// OnEvict is called for every entry that leaves the cache, outside the
+	// cache's lock so it may use the cache itself
+	OnEvict func(key K, value V, reason EvictionReason)
+	// Now is the clock, time.Now by default; tests and demos may replace it
+	Now func() time.Time
+}
+
+// CacheStats counts cache activity
+type CacheStats struct {
+	Hits, Misses, Evictions, Expirations int
+}
+
+type cacheEntry[K comparable, V any] struct {
+	value   V
+	expires time.Time  // Zero means never
+	uses    *PQItem[K] // LFU only: priority is minus the use count
+}
+
+type evicted[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// Cache is a thread-safe key-value cache with per-entry TTL and a size
+// limit
+type Cache[K comparable, V any] struct {
+	opts CacheOptions[K, V]
+
+	mu      sync.Mutex // Guards everything below; a Get reorders entries too
+	entries *OrderedMap[K, *cacheEntry[K, V]]
+	lfu     *PriorityQueue[K]
+	stats   CacheStats
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	janitor  sync.WaitGroup
+}
+
+// NewCache creates an unbounded cache without a janitor
+func NewCache[K comparable, V any]() *Cache[K, V] {
+	return NewCacheWithOptions(CacheOptions[K, V]{})
+}
+
+// NewCacheWithOptions creates a cache with a size limit, eviction policy,
+// janitor or eviction callback. Call Stop when a janitor is configured.
+func NewCacheWithOptions[K comparable, V any](opts CacheOptions[K, V]) *Cache[K, V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	c := &Cache[K, V]{
+		opts:    opts,
+		entries: NewOrderedMap[K, *cacheEntry[K, V]](),
+		lfu:     NewPriorityQueue[K](),
+		stop:    make(chan struct{}),
+	}
+	if opts.JanitorInterval > 0 {
+		c.janitor.Add(1)
+		go c.runJanitor()
+	}
+	return c
+}
+
+// Set stores a value that expires after ttl; a ttl of 0 never expires.
+// Setting an existing key replaces its value and TTL and counts as a use.
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	var out []evicted[K, V]
+	defer func() { c.notify(out) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.opts.Now().Add(ttl)
+	}
+	if e, exists := c.entries.Get(key); exists {
+		e.value, e.expires = value, expires
+		c.touch(key, e)
+		return
+	}
+
+	if c.opts.MaxSize > 0 && c.entries.Len() >= c.opts.MaxSize {
+		out = c.removeExpired(out) // Expired entries go before live ones
+		if c.entries.Len() >= c.opts.MaxSize {
+			victim := c.victim()
+			out = append(out, c.remove(victim, EvictedCapacity))
+		}
+	}
+	e := &cacheEntry[K, V]{value: value, expires: expires}
+	if c.opts.Policy == EvictLFU {
+		e.uses = c.lfu.Push(key, -1)
+	}
+	c.entries.Set(key, e)
+}
+
+// Get retrieves a value that has not expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var out []evicted[K, V]
+	defer func() { c.notify(out) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, exists := c.entries.Get(key)
+	if exists && c.expired(e) {
+		out = append(out, c.remove(key, EvictedExpired))
+		exists = false
+	}
+	if !exists {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.touch(key, e)
+	return e.value, true
+}
+
+// Delete removes a key and reports whether it was cached
+func (c *Cache[K, V]) Delete(key K) bool {
+	var out []evicted[K, V]
+	defer func() { c.notify(out) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries.Get(key); !exists {
+		return false
+	}
+	out = append(out, c.remove(key, EvictedDeleted))
+	return true
+}
+
+// DeleteExpired removes every expired entry; the janitor calls it on each
+// tick. It scans the whole cache, so the interval should not be tiny.
+func (c *Cache[K, V]) DeleteExpired() int {
+	var out []evicted[K, V]
+	defer func() { c.notify(out) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out = c.removeExpired(out)
+	return len(out)
+}
+
+// Len returns the number of entries, including expired ones not yet removed
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// Keys returns the keys from the first to be evicted to the last
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.opts.Policy == EvictLFU {
+		return c.lfu.Values()
+	}
+	return c.entries.Keys()
+}
+
+// Stats returns a snapshot of the counters
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Stop ends the janitor goroutine and waits for it. The cache stays usable.
+// Safe to call more than once.
+func (c *Cache[K, V]) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	c.janitor.Wait()
+}
+
+func (c *Cache[K, V]) runJanitor() {
+	defer c.janitor.Done()
+	ticker := time.NewTicker(c.opts.JanitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *Cache[K, V]) expired(e *cacheEntry[K, V]) bool {
+	return !e.expires.IsZero() && !c.opts.Now().Before(e.expires)
+}
+
+// touch records a use of key for the eviction policy
+func (c *Cache[K, V]) touch(key K, e *cacheEntry[K, V]) {
+	if c.opts.Policy == EvictLFU {
+		c.lfu.Update(e.uses, e.uses.Priority()-1)
+		return
+	}
+	c.entries.MoveToBack(key)
+}
+
+// victim returns the key the policy evicts next
+func (c *Cache[K, V]) victim() K {
+	if c.opts.Policy == EvictLFU {
+		key, _ := c.lfu.Peek()
+		return key
+	}
+	key, _, _ := c.entries.Oldest()
+	return key
+}
+
+func (c *Cache[K, V]) remove(key K, reason EvictionReason) evicted[K, V] {
+	e, _ := c.entries.Get(key)
+	c.entries.Delete(key)
+	if e.uses != nil {
+		c.lfu.Remove(e.uses)
+	}
+	switch reason {
+	case EvictedExpired:
+		c.stats.Expirations++
+	case EvictedCapacity:
+		c.stats.Evictions++
+	}
+	return evicted[K, V]{key: key, value: e.value, reason: reason}
+}
+
+func (c *Cache[K, V]) removeExpired(out []evicted[K, V]) []evicted[K, V] {
+	var expired []K
+	c.entries.ForEach(func(k K, e *cacheEntry[K, V]) {
+		if c.expired(e) {
+			expired = append(expired, k)
+		}
+	})
+	for _, k := range expired {
+		out = append(out, c.remove(k, EvictedExpired))
+	}
+	return out
+}
+
+// notify runs the eviction callback; it is deferred by every method that
+// removes entries, so it runs after the lock is released
+func (c *Cache[K, V]) notify(out []evicted[K, V]) {
+	if c.opts.OnEvict == nil {
+		return
+	}
+	for _, ev := range out {
+		c.opts.OnEvict(ev.key, ev.value, ev.reason)
+	}
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+// fakeClock is a clock the example moves by hand, so expiry is exact
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func runCacheExample() {
+	fmt.Println("\n🔸 TTL Expiry")
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	var log []string
+	sessions := NewCacheWithOptions(CacheOptions[string, string]{
+		Now: clock.Now,
+		OnEvict: func(key, _ string, reason EvictionReason) {
+			log = append(log, key+" "+reason.String())
+		},
+	})
+	sessions.Set("token-a", "ann", 5*time.Minute)
+	sessions.Set("token-b", "bob", 30*time.Minute)
+	sessions.Set("api-key", "ci", 0) // Never expires
+
+	for _, step := range []time.Duration{0, 10 * time.Minute, 30 * time.Minute} {
+		clock.Advance(step)
+		var alive []string
+		for _, key := range []string{"token-a", "token-b", "api-key"} {
+			if user, ok := sessions.Get(key); ok {
+				alive = append(alive, key+"="+user)
+			}
+		}
+		fmt.Printf("  %-16s %v\n", clock.Now().Format("15:04")+":", alive)
+	}
+	fmt.Printf("Evicted: %v, stats %+v\n", log, sessions.Stats())
+
+	fmt.Println("\n🔸 LRU vs LFU Under the Same Accesses")
+
+	// "home" is popular, then a burst of one-off pages arrives
+	accesses := strings.Fields("home home home about home blog shop cart faq")
+	for _, policy := range []EvictionPolicy{EvictLRU, EvictLFU} {
+		var gone []string
+		c := NewCacheWithOptions(CacheOptions[string, int]{
+			MaxSize: 3,
+			Policy:  policy,
+			OnEvict: func(key string, _ int, _ EvictionReason) { gone = append(gone, key) },
+		})
+		for i, page := range accesses {
+			if _, ok := c.Get(page); !ok {
+				c.Set(page, i, 0)
+			}
+		}
+		name := map[EvictionPolicy]string{EvictLRU: "LRU", EvictLFU: "LFU"}[policy]
+		_, kept := c.Get("home")
+		fmt.Printf("  %s kept %v, evicted %v, still has home: %v\n", name, c.Keys(), gone, kept)
+	}
+	fmt.Println("LRU drops the popular page once the burst pushes it out; LFU keeps it")
+	fmt.Println("and cycles the one-off pages through the remaining slots.")
+
+	fmt.Println("\n🔸 Background Janitor")
+
+	var mu sync.Mutex
+	var swept []string
+	tokens := NewCacheWithOptions(CacheOptions[int, string]{
+		JanitorInterval: 10 * time.Millisecond,
+		OnEvict: func(key int, _ string, reason EvictionReason) {
+			mu.Lock()
+			defer mu.Unlock()
+			swept = append(swept, fmt.Sprintf("%d:%s", key, reason))
+		},
+	})
+	for i, ttl := range []time.Duration{10, 20, 60, 70, 80} {
+		tokens.Set(i+1, "token", ttl*time.Millisecond)
+	}
+	fmt.Printf("Cached %d tokens with TTLs of 10-80ms, never read again\n", tokens.Len())
+	time.Sleep(40 * time.Millisecond)
+	fmt.Printf("After 40ms the janitor left %d\n", tokens.Len())
+	time.Sleep(60 * time.Millisecond)
+	tokens.Stop()
+	mu.Lock()
+	fmt.Printf("After 100ms: %d left, swept %v\n", tokens.Len(), swept)
+	mu.Unlock()
+	tokens.Set(9, "late", 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	fmt.Printf("After Stop nothing sweeps: %d entry left until a Get finds it expired\n", tokens.Len())
+
+	fmt.Println("\n✅ Cache examples completed!")
+}
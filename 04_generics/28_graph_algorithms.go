@@ -0,0 +1,446 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ==========================================
+// Graph Algorithms
+// ==========================================
+
+// Algorithms over the Graph in 05_generic_containers.go. They visit
+// vertices in the order they were added and neighbors in the order their
+// edges were, so every result is deterministic even though the graph is
+// built on maps.
+
+var (
+	// ErrNegativeWeight is returned by ShortestPath, which relies on no
+	// path ever getting shorter by growing
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrNoPath is returned when the destination is unreachable
+	ErrNoPath = errors.New("graph: no path")
+	// ErrUndirected is returned by algorithms defined for directed graphs
+	ErrUndirected = errors.New("graph: operation needs a directed graph")
+)
+
+// CycleError reports a cycle where none is allowed; extract it with
+// errors.As
+type CycleError[T comparable] struct {
+	Cycle []T // Starts and ends at the same vertex
+}
+
+func (e *CycleError[T]) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, v := range e.Cycle {
+		parts[i] = fmt.Sprint(v)
+	}
+	return "graph: cycle " + strings.Join(parts, " -> ")
+}
+
+// ShortestPath finds the path of least total weight with Dijkstra's
+// algorithm, taking the closest unsettled vertex from a Heap (see
+// 25_heap.go) each step. Stale heap entries are skipped when popped rather
+// than updated in place: simpler, and at most one entry per edge.
+func (g *Graph[T]) ShortestPath(from, to T) ([]T, float64, error) {
+	for _, w := range g.weights {
+		if w < 0 {
+			return nil, 0, ErrNegativeWeight
+		}
+	}
+	if _, exists := g.vertices[from]; !exists {
+		return nil, 0, fmt.Errorf("%w from %v: not in the graph", ErrNoPath, from)
+	}
+
+	type reached struct {
+		vertex T
+		dist   float64
+	}
+	dist := map[T]float64{from: 0}
+	prev := make(map[T]T)
+	settled := make(map[T]bool)
+	frontier := NewHeap(CmpBy(func(r reached) float64 { return r.dist }))
+	frontier.Push(reached{from, 0})
+
+	for frontier.Len() > 0 {
+		r, _ := frontier.Pop()
+		if settled[r.vertex] {
+			continue // A stale entry: a shorter path was found later
+		}
+		settled[r.vertex] = true
+		if r.vertex == to {
+			break
+		}
+		for _, next := range g.vertices[r.vertex] {
+			w, _ := g.Weight(r.vertex, next)
+			if d, seen := dist[next]; !seen || r.dist+w < d {
+				dist[next] = r.dist + w
+				prev[next] = r.vertex
+				frontier.Push(reached{next, r.dist + w})
+			}
+		}
+	}
+
+	if !settled[to] {
+		return nil, 0, fmt.Errorf("%w from %v to %v", ErrNoPath, from, to)
+	}
+	path := []T{to}
+	for v := to; v != from; {
+		v = prev[v]
+		path = append(path, v)
+	}
+	Reverse(path)
+	return path, dist[to], nil
+}
+
+// TopologicalSort orders the vertices so every edge points forward, using
+// Kahn's algorithm: repeatedly take a vertex nothing left points to. If
+// some vertices are never freed they lie on or behind a cycle, which is
+// returned as a *CycleError.
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	if g.undirected {
+		return nil, ErrUndirected
+	}
+	inDegree := make(map[T]int)
+	for _, v := range g.order {
+		for _, next := range g.vertices[v] {
+			inDegree[next]++
+		}
+	}
+	var ready, sorted []T
+	for _, v := range g.order {
+		if inDegree[v] == 0 {
+			ready = append(ready, v)
+		}
+	}
+	for len(ready) > 0 {
+		v := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, v)
+		for _, next := range g.vertices[v] {
+			if inDegree[next]--; inDegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	if len(sorted) < len(g.order) {
+		cycle, _ := g.FindCycle()
+		return sorted, &CycleError[T]{Cycle: cycle}
+	}
+	return sorted, nil
+}
+
+// FindCycle returns a cycle if the graph has one. In a directed graph that
+// is a path back to a vertex still on the depth-first stack; in an
+// undirected one, any edge to a visited vertex other than the one just
+// come from, since every edge can be walked straight back.
+func (g *Graph[T]) FindCycle() ([]T, bool) {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[T]int)
+	parent := make(map[T]T)
+	var cycle []T
+
+	var visit func(v T, from T, root bool) bool
+	visit = func(v T, from T, root bool) bool {
+		state[v] = onStack
+		skippedParent := false
+		for _, next := range g.vertices[v] {
+			if g.undirected && !root && next == from && !skippedParent {
+				skippedParent = true // The edge we arrived by, not a cycle
+				continue
+			}
+			switch state[next] {
+			case unvisited:
+				parent[next] = v
+				if visit(next, v, false) {
+					return true
+				}
+			case onStack:
+				cycle = []T{next}
+				for u := v; u != next; u = parent[u] {
+					cycle = append(cycle, u)
+				}
+				cycle = append(cycle, next)
+				Reverse(cycle)
+				return true
+			}
+		}
+		state[v] = done
+		return false
+	}
+
+	for _, v := range g.order {
+		var none T
+		if state[v] == unvisited && visit(v, none, true) {
+			return cycle, true
+		}
+	}
+	return nil, false
+}
+
+// StronglyConnectedComponents groups vertices that can all reach each
+// other, using Tarjan's algorithm: one depth-first search that tracks the
+// lowest stack index each vertex can reach back to. Components come out
+// in reverse topological order of the graph they condense into. In an
+// undirected graph they are the connected components.
+func (g *Graph[T]) StronglyConnectedComponents() [][]T {
+	index := make(map[T]int)
+	low := make(map[T]int)
+	onStack := make(map[T]bool)
+	stack := NewStack[T]()
+	var components [][]T
+
+	var connect func(v T)
+	connect = func(v T) {
+		index[v] = len(index)
+		low[v] = index[v]
+		stack.Push(v)
+		onStack[v] = true
+
+		for _, next := range g.vertices[v] {
+			if _, seen := index[next]; !seen {
+				connect(next)
+				low[v] = min(low[v], low[next])
+			} else if onStack[next] {
+				low[v] = min(low[v], index[next])
+			}
+		}
+
+		// v is the root of a component: pop it off the stack
+		if low[v] == index[v] {
+			var component []T
+			for {
+				w, _ := stack.Pop()
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			Reverse(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, v := range g.order {
+		if _, seen := index[v]; !seen {
+			connect(v)
+		}
+	}
+	return components
+}
+
+// ==========================================
+// Main Example Function
+// ==========================================
+
+func runGraphAlgorithmsExample() {
+	fmt.Println("\n🔸 Weighted Undirected Graph: Dijkstra")
+
+	roads := NewUndirectedGraph[string]()
+	for _, r := range []struct {
+		a, b string
+		km   float64
+	}{
+		{"Lisbon", "Porto", 313}, {"Lisbon", "Madrid", 625}, {"Porto", "Madrid", 561},
+		{"Madrid", "Barcelona", 621}, {"Madrid", "Valencia", 357}, {"Valencia", "Barcelona", 349},
+		{"Barcelona", "Lyon", 644}, {"Lyon", "Paris", 465}, {"Madrid", "Bordeaux", 690},
+		{"Bordeaux", "Paris", 584},
+	} {
+		roads.AddWeightedEdge(r.a, r.b, r.km)
+	}
+	fmt.Printf("BFS from Lisbon ignores weights: %v\n", roads.BFS("Lisbon"))
+	for _, trip := range [][2]string{{"Lisbon", "Paris"}, {"Porto", "Barcelona"}, {"Paris", "Lisbon"}} {
+		path, km, err := roads.ShortestPath(trip[0], trip[1])
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("Shortest %s -> %s: %.0f km via %v\n", trip[0], trip[1], km, path)
+	}
+	roads.AddVertex("Reykjavik")
+	if _, _, err := roads.ShortestPath("Lisbon", "Reykjavik"); errors.Is(err, ErrNoPath) {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("\n🔸 Topological Sort of Build Steps")
+
+	build := NewGraph[string]()
+	for _, dep := range [][2]string{
+		{"fetch", "compile"}, {"generate", "compile"}, {"compile", "test"},
+		{"compile", "package"}, {"test", "publish"}, {"package", "publish"}, {"lint", "publish"},
+	} {
+		build.AddEdge(dep[0], dep[1]) // dep[0] must run before dep[1]
+	}
+	order, err := build.TopologicalSort()
+	fmt.Printf("Run order: %v (err %v)\n", order, err)
+
+	build.AddEdge("publish", "generate") // A release step that regenerates code
+	_, err = build.TopologicalSort()
+	var cycleErr *CycleError[string]
+	if errors.As(err, &cycleErr) {
+		fmt.Printf("Error: %v\nCycle length: %d steps\n", err, len(cycleErr.Cycle)-1)
+	}
+
+	fmt.Println("\n🔸 Strongly Connected Components")
+
+	// Services calling each other; each component must be deployed together
+	calls := NewGraph[string]()
+	for _, c := range [][2]string{
+		{"web", "auth"}, {"auth", "users"}, {"users", "auth"}, {"web", "orders"},
+		{"orders", "billing"}, {"billing", "invoices"}, {"invoices", "orders"},
+		{"billing", "users"}, {"orders", "search"},
+	} {
+		calls.AddEdge(c[0], c[1])
+	}
+	for _, component := range calls.StronglyConnectedComponents() {
+		fmt.Printf("  %v\n", component)
+	}
+
+	fmt.Println("\n🔸 Cycle Detection, Directed vs Undirected")
+
+	for _, undirected := range []bool{false, true} {
+		g := NewGraph[int]()
+		if undirected {
+			g = NewUndirectedGraph[int]()
+		}
+		g.AddEdge(1, 2)
+		g.AddEdge(1, 3)
+		g.AddEdge(2, 4)
+		g.AddEdge(3, 4) // Two ways from 1 to 4, but no way back
+		cycle, found := g.FindCycle()
+		fmt.Printf("  undirected=%-5v cycle=%v %v\n", undirected, found, cycle)
+	}
+
+	fmt.Println("\n🔸 Property Checks Against Brute Force")
+
+	// Random graphs on vertices 0-7, checked with the property runner from
+	// 10_property_testing.go
+	edges := SlicesOf(PairsOf(Ints(0, 7), Ints(0, 7)), 20)
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Dijkstra matches Bellman-Ford", func() string { return ForAll(edges, dijkstraMatchesBellmanFord).String() }},
+		{"Topological order respects every edge", func() string { return ForAll(edges, topoOrderRespectsEdges).String() }},
+		{"SCCs are mutually reachable sets", func() string { return ForAll(edges, sccMatchesReachability).String() }},
+		{"FindCycle agrees with TopologicalSort", func() string { return ForAll(edges, findCycleAgreesWithTopo).String() }},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Graph algorithm examples completed!")
+}
+
+// graphFromPairs builds a directed graph on vertices 0-7 with weights
+// derived from the endpoints
+func graphFromPairs(pairs []Pair[int, int]) *Graph[int] {
+	g := NewGraph[int]()
+	for v := 0; v < 8; v++ {
+		g.AddVertex(v)
+	}
+	for _, p := range pairs {
+		g.AddWeightedEdge(p.First, p.Second, float64((p.First*7+p.Second*3)%9+1))
+	}
+	return g
+}
+
+// bellmanFord relaxes every edge n-1 times; slow but obviously correct
+func bellmanFord(g *Graph[int], from int) map[int]float64 {
+	dist := map[int]float64{}
+	for _, v := range g.Vertices() {
+		dist[v] = math.Inf(1)
+	}
+	dist[from] = 0
+	for i := 1; i < len(dist); i++ {
+		for e, w := range g.weights {
+			dist[e.To] = min(dist[e.To], dist[e.From]+w)
+		}
+	}
+	return dist
+}
+
+func dijkstraMatchesBellmanFord(pairs []Pair[int, int]) bool {
+	g := graphFromPairs(pairs)
+	want := bellmanFord(g, 0)
+	for _, v := range g.Vertices() {
+		path, got, err := g.ShortestPath(0, v)
+		switch {
+		case math.IsInf(want[v], 1):
+			if !errors.Is(err, ErrNoPath) {
+				return false
+			}
+		case err != nil || got != want[v] || path[0] != 0 || path[len(path)-1] != v:
+			return false
+		}
+	}
+	return true
+}
+
+func topoOrderRespectsEdges(pairs []Pair[int, int]) bool {
+	g := graphFromPairs(pairs)
+	order, err := g.TopologicalSort()
+	if err != nil {
+		var ce *CycleError[int]
+		return errors.As(err, &ce) && len(ce.Cycle) > 1
+	}
+	position := make(map[int]int)
+	for i, v := range order {
+		position[v] = i
+	}
+	for e := range g.weights {
+		if position[e.From] >= position[e.To] {
+			return false
+		}
+	}
+	return len(order) == 8
+}
+
+func sccMatchesReachability(pairs []Pair[int, int]) bool {
+	g := graphFromPairs(pairs)
+	reaches := make(map[int]map[int]bool)
+	for _, v := range g.Vertices() {
+		reaches[v] = make(map[int]bool)
+		for _, u := range g.BFS(v) {
+			reaches[v][u] = true
+		}
+	}
+	componentOf := make(map[int]int)
+	for i, component := range g.StronglyConnectedComponents() {
+		for _, v := range component {
+			componentOf[v] = i
+		}
+	}
+	for _, a := range g.Vertices() {
+		for _, b := range g.Vertices() {
+			if (componentOf[a] == componentOf[b]) != (reaches[a][b] && reaches[b][a]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// findCycleAgreesWithTopo checks that a cycle is found exactly when no
+// topological order exists, and that it is made of real edges
+func findCycleAgreesWithTopo(pairs []Pair[int, int]) bool {
+	g := graphFromPairs(pairs)
+	cycle, found := g.FindCycle()
+	_, err := g.TopologicalSort()
+	if found != (err != nil) {
+		return false
+	}
+	for i := 1; i < len(cycle); i++ {
+		if _, ok := g.Weight(cycle[i-1], cycle[i]); !ok {
+			return false
+		}
+	}
+	return !found || cycle[0] == cycle[len(cycle)-1]
+}
@@ -0,0 +1,593 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// ==========================================
+// Exact Statistics
+// ==========================================
+
+// The aggregations in 06_generic_algorithms.go stop at Sum, Min, Max and
+// AverageSlice, and AverageSlice divides in T, so the average of the ints
+// 1 and 2 is 1. Everything here returns float64 and reports false for empty
+// input instead of inventing a zero.
+
+// Mean returns the arithmetic mean, computed in float64
+func Mean[T Number](values []T) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	return sum / float64(len(values)), true
+}
+
+// Median returns the middle value, or the mean of the two middle values
+func Median[T Number](values []T) (float64, bool) {
+	return Percentile(values, 50)
+}
+
+// Percentile returns the p-th percentile (0-100), interpolating linearly
+// between the two closest ranks. Unlike the nearest-rank AggPercentile in
+// 14_time_series.go, the result need not be one of the values. It reports
+// false for empty input and for p outside [0, 100] or NaN, rather than
+// clamping p to the nearest end.
+func Percentile[T Number](values []T, p float64) (float64, bool) {
+	if len(values) == 0 || !validPercentile(p) {
+		return 0, false
+	}
+	return Percentiles(values, p)[0], true
+}
+
+// Percentiles returns several percentiles at the cost of a single sort. The
+// entry for a p outside [0, 100] or NaN is NaN.
+func Percentiles[T Number](values []T, ps ...float64) []float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	result := make([]float64, len(ps))
+	for i, p := range ps {
+		if v, ok := percentileOfSorted(sorted, p); ok {
+			result[i] = v
+		} else {
+			result[i] = math.NaN()
+		}
+	}
+	return result
+}
+
+// validPercentile reports whether p is in [0, 100]; NaN fails both comparisons
+func validPercentile(p float64) bool {
+	return p >= 0 && p <= 100
+}
+
+// percentileOfSorted interpolates the p-th percentile of non-empty sorted
+// values. An invalid p reports false: its position would fall outside the
+// slice, and int(NaN) is not a usable index.
+func percentileOfSorted[T Number](sorted []T, p float64) (float64, bool) {
+	if !validPercentile(p) {
+		return 0, false
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo == len(sorted)-1 {
+		return float64(sorted[lo]), true
+	}
+	frac := pos - float64(lo)
+	return float64(sorted[lo]) + frac*(float64(sorted[lo+1])-float64(sorted[lo])), true
+}
+
+// Variance returns the population variance
+func Variance[T Number](values []T) (float64, bool) {
+	var stats RunningStats[T]
+	for _, v := range values {
+		stats.Add(v)
+	}
+	return stats.Variance(), stats.Count() > 0
+}
+
+// StdDev returns the population standard deviation
+func StdDev[T Number](values []T) (float64, bool) {
+	v, ok := Variance(values)
+	return math.Sqrt(v), ok
+}
+
+// ==========================================
+// Streaming Statistics
+// ==========================================
+
+// RunningStats tracks count, mean, variance, min and max in O(1) memory
+// using Welford's algorithm. The naive sum-of-squares formula subtracts two
+// huge, nearly equal numbers and loses every significant digit when the
+// values sit far from zero; Welford updates the mean and the squared
+// deviations incrementally and stays accurate. The zero value is ready.
+type RunningStats[T Number] struct {
+	n        int
+	mean     float64
+	m2       float64 // Sum of squared deviations from the mean
+	min, max T
+}
+
+// Add records one value
+func (s *RunningStats[T]) Add(v T) {
+	if s.n == 0 || v < s.min {
+		s.min = v
+	}
+	if s.n == 0 || v > s.max {
+		s.max = v
+	}
+	s.n++
+	delta := float64(v) - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (float64(v) - s.mean)
+}
+
+// Merge folds in the statistics of another stream (Chan et al.), so shards
+// can be summarized independently and combined afterwards
+func (s *RunningStats[T]) Merge(other RunningStats[T]) {
+	if other.n == 0 {
+		return
+	}
+	if s.n == 0 {
+		*s = other
+		return
+	}
+	n := s.n + other.n
+	delta := other.mean - s.mean
+	s.m2 += other.m2 + delta*delta*float64(s.n)*float64(other.n)/float64(n)
+	s.mean += delta * float64(other.n) / float64(n)
+	s.n = n
+	s.min = min(s.min, other.min)
+	s.max = max(s.max, other.max)
+}
+
+// Count returns the number of values seen
+func (s *RunningStats[T]) Count() int { return s.n }
+
+// Mean returns the mean, or 0 before the first value
+func (s *RunningStats[T]) Mean() float64 { return s.mean }
+
+// Variance returns the population variance
+func (s *RunningStats[T]) Variance() float64 {
+	if s.n == 0 {
+		return 0
+	}
+	return s.m2 / float64(s.n)
+}
+
+// SampleVariance returns the unbiased (n-1) variance of a sample
+func (s *RunningStats[T]) SampleVariance() float64 {
+	if s.n < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.n-1)
+}
+
+// StdDev returns the population standard deviation
+func (s *RunningStats[T]) StdDev() float64 { return math.Sqrt(s.Variance()) }
+
+// Min returns the smallest value seen
+func (s *RunningStats[T]) Min() (T, bool) { return s.min, s.n > 0 }
+
+// Max returns the largest value seen
+func (s *RunningStats[T]) Max() (T, bool) { return s.max, s.n > 0 }
+
+// RunningMedian maintains the exact median of a stream with two heaps: a
+// max-heap holding the lower half and a min-heap holding the upper half.
+// Add is O(log n) and Median is O(1), but every value is kept.
+type RunningMedian[T Number] struct {
+	low  *Heap[T] // Max-heap; holds the extra value when the count is odd
+	high *Heap[T] // Min-heap
+}
+
+// NewRunningMedian creates an empty running median
+func NewRunningMedian[T Number]() *RunningMedian[T] {
+	return &RunningMedian[T]{
+		low:  NewHeap(Cmp[T](CmpOrdered[T]).Reversed()),
+		high: NewHeap(CmpOrdered[T]),
+	}
+}
+
+// Add records one value
+func (m *RunningMedian[T]) Add(v T) {
+	if top, ok := m.low.Peek(); !ok || v <= top {
+		m.low.Push(v)
+	} else {
+		m.high.Push(v)
+	}
+
+	// Rebalance so that len(low) is len(high) or len(high)+1
+	if m.low.Len() > m.high.Len()+1 {
+		v, _ := m.low.Pop()
+		m.high.Push(v)
+	} else if m.high.Len() > m.low.Len() {
+		v, _ := m.high.Pop()
+		m.low.Push(v)
+	}
+}
+
+// Median returns the median of all values added so far
+func (m *RunningMedian[T]) Median() (float64, bool) {
+	lo, ok := m.low.Peek()
+	if !ok {
+		return 0, false
+	}
+	if m.low.Len() > m.high.Len() {
+		return float64(lo), true
+	}
+	hi, _ := m.high.Peek()
+	return (float64(lo) + float64(hi)) / 2, true
+}
+
+// Len returns the number of values added
+func (m *RunningMedian[T]) Len() int { return m.low.Len() + m.high.Len() }
+
+// ==========================================
+// Approximate Quantiles: t-digest
+// ==========================================
+
+// centroid summarizes weight values by their mean
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest estimates quantiles of an unbounded stream in bounded memory
+// (Dunning's merging t-digest). Values are summarized by centroids sorted by
+// mean; a scale function lets centroids near the median absorb many values
+// while keeping those in the tails tiny, so p99 and p999 stay accurate where
+// it matters. The number of centroids stays below about compression.
+type TDigest[T Number] struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid // Unmerged values, flushed when full
+	count       float64
+	min, max    float64
+}
+
+// NewTDigest creates a digest; compression 100 is a common default that
+// keeps the rank error well under 1% with around 60 centroids
+func NewTDigest[T Number](compression float64) *TDigest[T] {
+	if compression < 20 {
+		compression = 20
+	}
+	return &TDigest[T]{
+		compression: compression,
+		buffer:      make([]centroid, 0, int(5*compression)),
+	}
+}
+
+// Add records one value
+func (d *TDigest[T]) Add(v T) {
+	d.addCentroid(centroid{mean: float64(v), weight: 1})
+}
+
+// Merge folds another digest into this one
+func (d *TDigest[T]) Merge(other *TDigest[T]) {
+	for _, c := range other.centroids {
+		d.addCentroid(c)
+	}
+	for _, c := range other.buffer {
+		d.addCentroid(c)
+	}
+}
+
+func (d *TDigest[T]) addCentroid(c centroid) {
+	if d.count == 0 || c.mean < d.min {
+		d.min = c.mean
+	}
+	if d.count == 0 || c.mean > d.max {
+		d.max = c.mean
+	}
+	d.count += c.weight
+	d.buffer = append(d.buffer, c)
+	if len(d.buffer) == cap(d.buffer) {
+		d.flush()
+	}
+}
+
+// k maps a quantile to the scale on which every centroid spans at most 1.
+// Its slope grows towards q=0 and q=1, which is what keeps tail centroids small.
+func (d *TDigest[T]) k(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// kInverse is the quantile for scale value k
+func (d *TDigest[T]) kInverse(k float64) float64 {
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
+
+// flush merges the buffer into the centroids in one sorted pass
+func (d *TDigest[T]) flush() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	slices.SortFunc(all, CmpBy(func(c centroid) float64 { return c.mean }))
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	current := all[0]
+	var weightBefore float64
+	limit := d.kInverse(d.k(0) + 1)
+	for _, c := range all[1:] {
+		if (weightBefore+current.weight+c.weight)/d.count <= limit {
+			current.weight += c.weight
+			current.mean += (c.mean - current.mean) * c.weight / current.weight
+			continue
+		}
+		merged = append(merged, current)
+		weightBefore += current.weight
+		limit = d.kInverse(d.k(weightBefore/d.count) + 1)
+		current = c
+	}
+	d.centroids = append(merged, current)
+	d.buffer = d.buffer[:0]
+}
+
+// Quantile estimates the q-th quantile (0-1) by interpolating between the
+// centers of neighbouring centroids. Like Percentile it reports false for an
+// empty digest and for q outside [0, 1] or NaN.
+func (d *TDigest[T]) Quantile(q float64) (float64, bool) {
+	d.flush()
+	if d.count == 0 || !(q >= 0 && q <= 1) {
+		return 0, false
+	}
+	target := q * d.count
+
+	// Position of each centroid's center on the cumulative weight axis
+	first := d.centroids[0]
+	if target < first.weight/2 {
+		return d.min + (first.mean-d.min)*target/(first.weight/2), true
+	}
+	var weightBefore float64
+	for i := 0; i < len(d.centroids)-1; i++ {
+		left, right := d.centroids[i], d.centroids[i+1]
+		leftCenter := weightBefore + left.weight/2
+		rightCenter := weightBefore + left.weight + right.weight/2
+		if target < rightCenter {
+			frac := (target - leftCenter) / (rightCenter - leftCenter)
+			return left.mean + frac*(right.mean-left.mean), true
+		}
+		weightBefore += left.weight
+	}
+	last := d.centroids[len(d.centroids)-1]
+	lastCenter := d.count - last.weight/2
+	return last.mean + (d.max-last.mean)*(target-lastCenter)/(d.count-lastCenter), true
+}
+
+// Count returns the number of values added
+func (d *TDigest[T]) Count() int { return int(d.count) }
+
+// Centroids returns how many centroids summarize the stream
+func (d *TDigest[T]) Centroids() int {
+	d.flush()
+	return len(d.centroids)
+}
+
+func runStatisticsExample() {
+	fmt.Println("\n🔸 Integer Averages")
+
+	scores := []int{1, 2, 2}
+	mean, _ := Mean(scores)
+	fmt.Printf("Scores %v: AverageSlice=%d, Mean=%.3f\n", scores, AverageSlice(scores), mean)
+
+	fmt.Println("\n🔸 Exact Statistics")
+
+	// Response times in ms: mostly fast, with two slow outliers
+	latencies := []int{12, 15, 11, 14, 13, 16, 12, 15, 14, 13, 12, 11, 15, 14, 13, 16, 12, 14, 480, 950}
+	mean, _ = Mean(latencies)
+	median, _ := Median(latencies)
+	stddev, _ := StdDev(latencies)
+	fmt.Printf("Latencies: %v\n", latencies)
+	fmt.Printf("Mean %.1fms, median %.1fms, stddev %.1fms: the outliers drag the mean, not the median\n", mean, median, stddev)
+
+	ps := []float64{50, 90, 95, 99}
+	interpolated := Percentiles(latencies, ps...)
+	for i, p := range ps {
+		nearest := AggPercentile[int](p)(latencies)
+		fmt.Printf("  p%-3.0f interpolated %6.1fms   nearest-rank %4dms\n", p, interpolated[i], nearest)
+	}
+
+	fmt.Println("\n🔸 Streaming Aggregation")
+
+	// Log-normal latencies: a long right tail like real request timings
+	r := rand.New(rand.NewSource(42))
+	sample := func() float64 { return math.Exp(3 + 0.6*r.NormFloat64()) }
+
+	const n = 100_000
+	all := make([]float64, 0, n)
+	var stats, shardA, shardB RunningStats[float64]
+	runningMedian := NewRunningMedian[float64]()
+	for i := 0; i < n; i++ {
+		v := sample()
+		all = append(all, v)
+		stats.Add(v)
+		runningMedian.Add(v)
+		if i%2 == 0 {
+			shardA.Add(v)
+		} else {
+			shardB.Add(v)
+		}
+	}
+	shardA.Merge(shardB)
+
+	exactMean, _ := Mean(all)
+	exactStd, _ := StdDev(all)
+	exactMedian, _ := Median(all)
+	streamMedian, _ := runningMedian.Median()
+	fmt.Printf("%-16s %10s %10s %10s\n", "", "mean", "stddev", "median")
+	fmt.Printf("%-16s %10.4f %10.4f %10.4f\n", "Exact (slice)", exactMean, exactStd, exactMedian)
+	fmt.Printf("%-16s %10.4f %10.4f %10.4f\n", "Streaming", stats.Mean(), stats.StdDev(), streamMedian)
+	fmt.Printf("%-16s %10.4f %10.4f %10s\n", "Merged shards", shardA.Mean(), shardA.StdDev(), "-")
+
+	// Values far from zero: the textbook E[x²]-E[x]² loses every digit
+	offset := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	var sum, sumSq float64
+	var welford RunningStats[float64]
+	for _, v := range offset {
+		sum += v
+		sumSq += v * v
+		welford.Add(v)
+	}
+	k := float64(len(offset))
+	naive := sumSq/k - (sum/k)*(sum/k)
+	fmt.Printf("Variance of %v: naive %.1f, Welford %.1f (exact 22.5)\n", []float64{4, 7, 13, 16}, naive, welford.Variance())
+
+	fmt.Println("\n🔸 Approximate Quantiles")
+
+	const big = 1_000_000
+	values := make([]float64, big)
+	shards := make([]*TDigest[float64], 4)
+	for i := range shards {
+		shards[i] = NewTDigest[float64](100)
+	}
+	digest := NewTDigest[float64](100)
+	for i := range values {
+		values[i] = sample()
+		digest.Add(values[i])
+		shards[i%len(shards)].Add(values[i])
+	}
+	merged := NewTDigest[float64](100)
+	for _, s := range shards {
+		merged.Merge(s)
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	fmt.Printf("%d values (%d KB as a slice) summarized by %d centroids (%d bytes)\n",
+		big, big*8/1024, digest.Centroids(), digest.Centroids()*16)
+	fmt.Printf("%-7s %10s %10s %11s %10s\n", "q", "exact", "t-digest", "rank error", "merged")
+	for _, q := range []float64{0.001, 0.01, 0.25, 0.5, 0.75, 0.99, 0.999} {
+		exact, _ := percentileOfSorted(sorted, q*100)
+		est, _ := digest.Quantile(q)
+		fromShards, _ := merged.Quantile(q)
+		rank, _ := slices.BinarySearch(sorted, est)
+		fmt.Printf("%-7g %10.3f %10.3f %10.4f%% %10.3f\n", q, exact, est, math.Abs(float64(rank)/big-q)*100, fromShards)
+	}
+
+	fmt.Println("\n🔸 Property Checks")
+
+	samples := SlicesOf(Ints(-1000, 1000), 200)
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Welford matches two-pass variance", func() string { return ForAll(samples, welfordMatchesTwoPass).String() }},
+		{"Merged halves match one pass", func() string { return ForAll(samples, mergedStatsMatchOnePass).String() }},
+		{"RunningMedian matches Median", func() string { return ForAll(samples, runningMedianMatchesMedian).String() }},
+		{"Percentile is monotone and bounded", func() string { return ForAll(samples, percentileMonotoneAndBounded).String() }},
+		{"TDigest stays within rank tolerance", func() string { return ForAll(samples, tdigestWithinRankTolerance).String() }},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Statistics examples completed!")
+}
+
+// closeTo compares floats with a tolerance relative to their magnitude
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*max(1, math.Abs(a), math.Abs(b))
+}
+
+// welfordMatchesTwoPass compares RunningStats with the two-pass formula
+func welfordMatchesTwoPass(values []int) bool {
+	var stats RunningStats[int]
+	for _, v := range values {
+		stats.Add(v)
+	}
+	mean, ok := Mean(values)
+	if !ok {
+		return stats.Count() == 0
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return closeTo(stats.Mean(), mean) && closeTo(stats.Variance(), squares/float64(len(values)))
+}
+
+// mergedStatsMatchOnePass splits the input, summarizes each half and merges
+func mergedStatsMatchOnePass(values []int) bool {
+	var whole, left, right RunningStats[int]
+	for i, v := range values {
+		whole.Add(v)
+		if i < len(values)/2 {
+			left.Add(v)
+		} else {
+			right.Add(v)
+		}
+	}
+	left.Merge(right)
+	lo1, _ := left.Min()
+	lo2, _ := whole.Min()
+	hi1, _ := left.Max()
+	hi2, _ := whole.Max()
+	return left.Count() == whole.Count() && closeTo(left.Mean(), whole.Mean()) &&
+		closeTo(left.Variance(), whole.Variance()) && lo1 == lo2 && hi1 == hi2
+}
+
+// runningMedianMatchesMedian checks the two-heap median after every Add
+func runningMedianMatchesMedian(values []int) bool {
+	m := NewRunningMedian[int]()
+	for i, v := range values {
+		m.Add(v)
+		got, _ := m.Median()
+		want, _ := Median(values[:i+1])
+		if got != want {
+			return false
+		}
+	}
+	return m.Len() == len(values)
+}
+
+// percentileMonotoneAndBounded checks p0 = min, p100 = max and ordering
+func percentileMonotoneAndBounded(values []int) bool {
+	if len(values) == 0 {
+		_, ok := Percentile(values, 50)
+		return !ok
+	}
+	ps := Percentiles(values, 0, 10, 25, 50, 75, 90, 100)
+	lo, _ := MinSlice(values)
+	hi, _ := MaxSlice(values)
+	return ps[0] == float64(lo) && ps[len(ps)-1] == float64(hi) && slices.IsSorted(ps)
+}
+
+// tdigestWithinRankTolerance checks that each estimate falls within 2% of
+// the requested rank (plus one value for interpolation between neighbours)
+func tdigestWithinRankTolerance(values []int) bool {
+	d := NewTDigest[int](50)
+	for _, v := range values {
+		d.Add(v)
+	}
+	if len(values) == 0 {
+		_, ok := d.Quantile(0.5)
+		return !ok
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := float64(len(sorted))
+	tolerance := 1 + 0.02*n
+	for _, q := range []float64{0, 0.01, 0.1, 0.5, 0.9, 0.99, 1} {
+		est, _ := d.Quantile(q)
+		below := float64(sort.Search(len(sorted), func(i int) bool { return float64(sorted[i]) >= est }))
+		atOrBelow := float64(sort.Search(len(sorted), func(i int) bool { return float64(sorted[i]) > est }))
+		if below > q*n+tolerance || atOrBelow < q*n-tolerance {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// ==========================================
+// Option Combinators
+// ==========================================
+
+// Go's idiom for "maybe a value" is the comma-ok pair, and every container
+// in this package follows it: Stack.Pop, SafeMap.Get, SortedMap.Floor. The
+// pair is cheap and explicit, but each lookup that depends on the previous
+// one costs another if-block. Option (01_basic_generics.go) packs the pair
+// into one value so dependent lookups chain like Result does for errors.
+
+// OptionFrom converts a comma-ok pair; OptionFrom(stack.Pop()) works
+// directly because Go passes a multi-value call as the arguments
+func OptionFrom[T any](value T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(value)
+}
+
+// OptionFromPtr converts a nil-able pointer, copying the value it points to
+func OptionFromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// Lookup adapts a comma-ok method value such as m.Get into a function
+// returning Option
+func Lookup[K, V any](get func(K) (V, bool)) func(K) Option[V] {
+	return func(key K) Option[V] {
+		return OptionFrom(get(key))
+	}
+}
+
+// Get returns the comma-ok pair, the way back to idiomatic Go
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.hasValue
+}
+
+// Ptr returns a pointer to a copy of the value, or nil for None
+func (o Option[T]) Ptr() *T {
+	if !o.hasValue {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// Filter keeps the value only if it satisfies the predicate
+func (o Option[T]) Filter(pred func(T) bool) Option[T] {
+	if o.hasValue && pred(o.value) {
+		return o
+	}
+	return None[T]()
+}
+
+// OrElse returns o if it has a value, otherwise the fallback's result. The
+// fallback runs only when needed, so it can be an expensive lookup.
+func (o Option[T]) OrElse(fallback func() Option[T]) Option[T] {
+	if o.hasValue {
+		return o
+	}
+	return fallback()
+}
+
+// OkOr converts to a Result, using err when there is no value
+func (o Option[T]) OkOr(err error) Result[T] {
+	if !o.hasValue {
+		return Err[T](err)
+	}
+	return Ok(o.value)
+}
+
+// String formats as Some(value) or None
+func (o Option[T]) String() string {
+	if !o.hasValue {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// Methods cannot declare type parameters, so the combinators that change
+// the value's type are functions, like MapBP and AndThenBP in
+// 08_best_practices.go
+
+// MapOption transforms the value, if there is one
+func MapOption[T, U any](o Option[T], fn func(T) U) Option[U] {
+	if !o.hasValue {
+		return None[U]()
+	}
+	return Some(fn(o.value))
+}
+
+// AndThenOption chains a step that may itself produce no value
+func AndThenOption[T, U any](o Option[T], fn func(T) Option[U]) Option[U] {
+	if !o.hasValue {
+		return None[U]()
+	}
+	return fn(o.value)
+}
+
+// employee is the record behind the lookup examples
+type employee struct {
+	name    string
+	manager string // Employee id, empty for the CEO
+	email   string // Empty when not on file
+}
+
+// managerEmailCommaOk answers "whom do I escalate to?" with comma-ok lookups
+func managerEmailCommaOk(staff *SafeMap[string, employee], id string) string {
+	e, ok := staff.Get(id)
+	if !ok {
+		return "unknown"
+	}
+	m, ok := staff.Get(e.manager)
+	if !ok {
+		return "unknown"
+	}
+	if m.email == "" {
+		return "unknown"
+	}
+	return m.email
+}
+
+// managerEmailOption answers the same question by chaining Options
+func managerEmailOption(staff *SafeMap[string, employee], id string) string {
+	find := Lookup(staff.Get)
+	manager := AndThenOption(find(id), func(e employee) Option[employee] { return find(e.manager) })
+	email := MapOption(manager, func(m employee) string { return m.email })
+	return email.Filter(func(s string) bool { return s != "" }).UnwrapOr("unknown")
+}
+
+func runOptionExample() {
+	fmt.Println("\n🔸 Constructors and Conversions")
+
+	fmt.Printf("Some(42): %v, None: %v\n", Some(42), None[int]())
+
+	stack := NewStack[string]()
+	stack.Push("only")
+	fmt.Printf("OptionFrom(stack.Pop()): %v, then %v\n", OptionFrom(stack.Pop()), OptionFrom(stack.Pop()))
+
+	port := 8080
+	var missing *int
+	fmt.Printf("OptionFromPtr(&port): %v, OptionFromPtr(nil): %v\n", OptionFromPtr(&port), OptionFromPtr(missing))
+	if v, ok := Some("back").Get(); ok {
+		fmt.Printf("Get() returns to comma-ok: %q, %v\n", v, ok)
+	}
+	fmt.Printf("None.Ptr() == nil: %v\n", None[int]().Ptr() == nil)
+
+	fmt.Println("\n🔸 Combinators")
+
+	env := NewSafeMap[string, string]()
+	env.Set("PORT", "9000")
+	env.Set("TIMEOUT", "soon")
+	getenv := Lookup(env.Get)
+	parseInt := func(s string) Option[int] {
+		n, err := strconv.Atoi(s)
+		return OptionFrom(n, err == nil)
+	}
+	validPort := func(n int) bool { return n > 0 && n < 65536 }
+	for _, key := range []string{"PORT", "TIMEOUT", "MISSING"} {
+		value := AndThenOption(getenv(key), parseInt).Filter(validPort)
+		fmt.Printf("  %-8s raw=%-12v parsed=%-10v or default: %d\n", key, getenv(key), value, value.UnwrapOr(80))
+	}
+
+	listenAddr := func(port string) string { return ":" + port }
+	fmt.Printf("MapOption transforms Some and keeps None: %v, %v\n", MapOption(getenv("PORT"), listenAddr), MapOption(getenv("NOPE"), listenAddr))
+
+	lookups := 0
+	fromFile := func() Option[string] { lookups++; return Some("7000") }
+	fmt.Printf("OrElse: %v (fallback ran %d times), ", getenv("PORT").OrElse(fromFile), lookups)
+	fmt.Printf("%v (fallback ran %d times)\n", getenv("ADMIN_PORT").OrElse(fromFile), lookups)
+
+	fmt.Println("\n🔸 Comma-ok Container APIs as Options")
+
+	staff := NewSafeMap[string, employee]()
+	staff.Set("ana", employee{name: "Ana", email: "ana@example.com"})
+	staff.Set("ben", employee{name: "Ben", manager: "ana", email: ""})
+	staff.Set("cy", employee{name: "Cy", manager: "ana", email: "cy@example.com"})
+	staff.Set("dee", employee{name: "Dee", manager: "ben", email: "dee@example.com"})
+	staff.Set("eve", employee{name: "Eve", manager: "gone"})
+
+	fmt.Printf("%-6s %-18s %-18s\n", "id", "comma-ok", "Option")
+	for _, id := range []string{"cy", "dee", "eve", "ana", "zed"} {
+		fmt.Printf("%-6s %-18s %-18s\n", id, managerEmailCommaOk(staff, id), managerEmailOption(staff, id))
+	}
+
+	// Shipping rates by minimum weight: Floor finds the tier, Get its price
+	rates := NewSortedMap[int, float64]()
+	rates.Put(1, 4.99)
+	rates.Put(5, 9.99)
+	rates.Put(20, 24.99)
+	price := func(kg int) Option[float64] {
+		return AndThenOption(OptionFrom(rates.Floor(kg)), Lookup(rates.Get))
+	}
+	for _, kg := range []int{0, 3, 12, 40} {
+		fmt.Printf("  %2dkg -> %v\n", kg, price(kg))
+	}
+
+	fmt.Println("\n🔸 Option and Result")
+
+	errNoManager := errors.New("no manager on file")
+	for _, id := range []string{"dee", "ana"} {
+		find := Lookup(staff.Get)
+		r := AndThenOption(find(id), func(e employee) Option[employee] { return find(e.manager) }).OkOr(errNoManager)
+		if r.IsOk() {
+			fmt.Printf("  %s reports to %s\n", id, r.Unwrap().name)
+		} else {
+			fmt.Printf("  %s: %v\n", id, r.Error())
+		}
+	}
+
+	fmt.Println("\n🔸 Property Checks")
+
+	options := Generator[Option[int]]{
+		Generate: func(r *rand.Rand) Option[int] {
+			return OptionFrom(r.Intn(200)-100, r.Intn(4) != 0)
+		},
+	}
+	double := func(n int) int { return 2 * n }
+	half := func(n int) Option[int] { return OptionFrom(n/2, n%2 == 0) }
+	properties := []struct {
+		name string
+		prop func(Option[int]) bool
+	}{
+		{"OptionFrom(o.Get()) == o", func(o Option[int]) bool { return OptionFrom(o.Get()) == o }},
+		{"OptionFromPtr(o.Ptr()) == o", func(o Option[int]) bool { return OptionFromPtr(o.Ptr()) == o }},
+		{"MapOption with identity is a no-op", func(o Option[int]) bool { return MapOption(o, func(n int) int { return n }) == o }},
+		{"AndThenOption(o, Some) == o", func(o Option[int]) bool { return AndThenOption(o, Some[int]) == o }},
+		{"Map then AndThen composes", func(o Option[int]) bool {
+			chained := AndThenOption(MapOption(o, double), half)
+			direct := AndThenOption(o, func(n int) Option[int] { return half(double(n)) })
+			return chained == direct
+		}},
+		{"Filter(always) == o, Filter(never) == None", func(o Option[int]) bool {
+			return o.Filter(func(int) bool { return true }) == o && o.Filter(func(int) bool { return false }).IsNone()
+		}},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-45s %s\n", p.name+":", ForAll(options, p.prop))
+	}
+
+	fmt.Println("\n✅ Option examples completed!")
+}
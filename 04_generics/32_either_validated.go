@@ -0,0 +1,465 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/mail"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ==========================================
+// Either
+// ==========================================
+
+// Either holds exactly one of two values. Result and BestPracticeResult are
+// the special case where the left side is an error; Either is for two
+// legitimate outcomes, such as an input that is an id or a name.
+type Either[L, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// Left creates an Either holding the left value
+func Left[L, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right creates an Either holding the right value
+func Right[L, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// IsLeft reports whether the left value is set
+func (e Either[L, R]) IsLeft() bool { return !e.isRight }
+
+// IsRight reports whether the right value is set
+func (e Either[L, R]) IsRight() bool { return e.isRight }
+
+// LeftValue returns the left value as an Option
+func (e Either[L, R]) LeftValue() Option[L] { return OptionFrom(e.left, !e.isRight) }
+
+// RightValue returns the right value as an Option
+func (e Either[L, R]) RightValue() Option[R] { return OptionFrom(e.right, e.isRight) }
+
+// Swap exchanges the sides
+func (e Either[L, R]) Swap() Either[R, L] {
+	return Either[R, L]{left: e.right, right: e.left, isRight: !e.isRight}
+}
+
+// String formats as Left(value) or Right(value)
+func (e Either[L, R]) String() string {
+	if e.isRight {
+		return fmt.Sprintf("Right(%v)", e.right)
+	}
+	return fmt.Sprintf("Left(%v)", e.left)
+}
+
+// FoldEither handles both cases and produces a single value, which is how
+// an Either is usually consumed
+func FoldEither[L, R, T any](e Either[L, R], onLeft func(L) T, onRight func(R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+// MapRight transforms the right value and passes a left value through
+func MapRight[L, R, U any](e Either[L, R], fn func(R) U) Either[L, U] {
+	if !e.isRight {
+		return Left[L, U](e.left)
+	}
+	return Right[L](fn(e.right))
+}
+
+// MapLeft transforms the left value and passes a right value through
+func MapLeft[L, R, U any](e Either[L, R], fn func(L) U) Either[U, R] {
+	if e.isRight {
+		return Right[U](e.right)
+	}
+	return Left[U, R](fn(e.left))
+}
+
+// PartitionEithers splits a slice into its left and right values, keeping
+// the order within each side
+func PartitionEithers[L, R any](items []Either[L, R]) ([]L, []R) {
+	var lefts []L
+	var rights []R
+	for _, e := range items {
+		if e.isRight {
+			rights = append(rights, e.right)
+		} else {
+			lefts = append(lefts, e.left)
+		}
+	}
+	return lefts, rights
+}
+
+// ==========================================
+// Validated
+// ==========================================
+
+// Validated is a value or every reason it is invalid. Result stops at the
+// first error, which suits steps that depend on each other; a form has
+// independent fields, and the user wants to hear about all of them at once.
+type Validated[T any] struct {
+	value T
+	errs  []error
+}
+
+// Valid wraps a value that passed validation
+func Valid[T any](value T) Validated[T] {
+	return Validated[T]{value: value}
+}
+
+// Invalid records one or more problems
+func Invalid[T any](errs ...error) Validated[T] {
+	return Validated[T]{errs: errs}
+}
+
+// IsValid reports whether there are no errors
+func (v Validated[T]) IsValid() bool { return len(v.errs) == 0 }
+
+// Get returns the value and whether it is valid
+func (v Validated[T]) Get() (T, bool) { return v.value, len(v.errs) == 0 }
+
+// Errors returns every problem found
+func (v Validated[T]) Errors() []error { return v.errs }
+
+// Err joins all problems into one error (nil when valid), so errors.Is and
+// errors.As still find each of them
+func (v Validated[T]) Err() error { return errors.Join(v.errs...) }
+
+// FieldError ties a problem to the input field it came from
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string { return e.Field + ": " + e.Err.Error() }
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Check tests one property of a value
+type Check[T any] func(T) error
+
+// CheckField runs every check against the value, collecting all failures
+// as FieldErrors instead of stopping at the first
+func CheckField[T any](field string, value T, checks ...Check[T]) Validated[T] {
+	var errs []error
+	for _, check := range checks {
+		if err := check(value); err != nil {
+			errs = append(errs, &FieldError{Field: field, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return Invalid[T](errs...)
+	}
+	return Valid(value)
+}
+
+// MapValidated transforms a valid value and keeps the errors otherwise
+func MapValidated[T, U any](v Validated[T], fn func(T) U) Validated[U] {
+	if !v.IsValid() {
+		return Invalid[U](v.errs...)
+	}
+	return Valid(fn(v.value))
+}
+
+// AndThenValidated runs a check that needs a valid value first, such as a
+// cross-field rule; like Result, it cannot continue past a failure
+func AndThenValidated[T, U any](v Validated[T], fn func(T) Validated[U]) Validated[U] {
+	if !v.IsValid() {
+		return Invalid[U](v.errs...)
+	}
+	return fn(v.value)
+}
+
+// Go has no variadic type parameters, so combining independent results
+// takes one function per arity. Each collects the errors of all inputs and
+// builds the value only when every input is valid.
+
+// Combine2 builds a value from two independent validations
+func Combine2[A, B, T any](a Validated[A], b Validated[B], build func(A, B) T) Validated[T] {
+	if errs := concatErrors(a.errs, b.errs); len(errs) > 0 {
+		return Invalid[T](errs...)
+	}
+	return Valid(build(a.value, b.value))
+}
+
+// Combine3 builds a value from three independent validations
+func Combine3[A, B, C, T any](a Validated[A], b Validated[B], c Validated[C], build func(A, B, C) T) Validated[T] {
+	if errs := concatErrors(a.errs, b.errs, c.errs); len(errs) > 0 {
+		return Invalid[T](errs...)
+	}
+	return Valid(build(a.value, b.value, c.value))
+}
+
+// Combine4 builds a value from four independent validations
+func Combine4[A, B, C, D, T any](a Validated[A], b Validated[B], c Validated[C], d Validated[D], build func(A, B, C, D) T) Validated[T] {
+	if errs := concatErrors(a.errs, b.errs, c.errs, d.errs); len(errs) > 0 {
+		return Invalid[T](errs...)
+	}
+	return Valid(build(a.value, b.value, c.value, d.value))
+}
+
+func concatErrors(lists ...[]error) []error {
+	var all []error
+	for _, errs := range lists {
+		all = append(all, errs...)
+	}
+	return all
+}
+
+// Reusable checks
+
+// NotBlank rejects empty or whitespace-only strings
+func NotBlank(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("is required")
+	}
+	return nil
+}
+
+// MinLen rejects strings shorter than n characters
+func MinLen(n int) Check[string] {
+	return func(s string) error {
+		if len([]rune(s)) < n {
+			return fmt.Errorf("must be at least %d characters", n)
+		}
+		return nil
+	}
+}
+
+// InRange rejects values outside [lo, hi]
+func InRange[T Orderable](lo, hi T) Check[T] {
+	return func(v T) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("must be between %v and %v", lo, hi)
+		}
+		return nil
+	}
+}
+
+// Satisfies turns a predicate into a check with a fixed message
+func Satisfies[T any](pred func(T) bool, message string) Check[T] {
+	return func(v T) error {
+		if !pred(v) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// signupForm is raw user input; account is what a valid form becomes
+type signupForm struct {
+	Username, Email, Password, Age string
+}
+
+type account struct {
+	username string
+	email    string
+	password string
+	age      int
+}
+
+func (a account) String() string {
+	return fmt.Sprintf("account{%s <%s>, age %d}", a.username, a.email, a.age)
+}
+
+var errNotAnEmail = errors.New("is not an email address")
+
+// IsEmail rejects strings that do not parse as an email address
+func IsEmail(s string) error {
+	if _, err := mail.ParseAddress(s); err != nil {
+		return errNotAnEmail
+	}
+	return nil
+}
+
+// The signup checks, shared by the Validated and Result versions
+var (
+	usernameChecks = []Check[string]{NotBlank, MinLen(3)}
+	emailChecks    = []Check[string]{NotBlank, IsEmail}
+	passwordChecks = []Check[string]{
+		MinLen(8),
+		Satisfies(func(s string) bool { return strings.ContainsFunc(s, unicode.IsDigit) }, "must contain a digit"),
+	}
+)
+
+// validateSignup checks every field independently, then combines them
+func validateSignup(form signupForm) Validated[account] {
+	age := AndThenValidated(CheckField("age", form.Age, NotBlank), func(s string) Validated[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Invalid[int](&FieldError{Field: "age", Err: errors.New("must be a whole number")})
+		}
+		return CheckField("age", n, InRange(13, 130))
+	})
+
+	return Combine4(
+		CheckField("username", form.Username, usernameChecks...),
+		CheckField("email", form.Email, emailChecks...),
+		CheckField("password", form.Password, passwordChecks...),
+		age,
+		func(username, email, password string, age int) account {
+			return account{username: username, email: email, password: password, age: age}
+		},
+	)
+}
+
+// validateSignupResult is the short-circuiting version built on
+// BestPracticeResult: it reports only the first problem (age is left out
+// to keep the nesting readable)
+func validateSignupResult(form signupForm) BestPracticeResult[account] {
+	check := func(field, value string, checks ...Check[string]) BestPracticeResult[string] {
+		if errs := CheckField(field, value, checks...).Errors(); len(errs) > 0 {
+			return BPErr[string](errs[0])
+		}
+		return BPOk(value)
+	}
+	return AndThenBP(check("username", form.Username, usernameChecks...), func(username string) BestPracticeResult[account] {
+		return AndThenBP(check("email", form.Email, emailChecks...), func(email string) BestPracticeResult[account] {
+			return MapBP(check("password", form.Password, passwordChecks...), func(password string) account {
+				return account{username: username, email: email, password: password}
+			})
+		})
+	})
+}
+
+func runEitherValidatedExample() {
+	fmt.Println("\n🔸 Either: Two Legitimate Outcomes")
+
+	// A support tool accepts customers by numeric id or by username
+	parseCustomer := func(token string) Either[int, string] {
+		if id, err := strconv.Atoi(token); err == nil {
+			return Left[int, string](id)
+		}
+		return Right[int](strings.ToLower(token))
+	}
+	var refs []Either[int, string]
+	for _, token := range []string{"1042", "Alice", "77", "bob"} {
+		ref := parseCustomer(token)
+		refs = append(refs, ref)
+		describe := FoldEither(ref,
+			func(id int) string { return fmt.Sprintf("look up row %d", id) },
+			func(name string) string { return fmt.Sprintf("search index for %q", name) })
+		fmt.Printf("  %-6s -> %-14v %s\n", token, ref, describe)
+	}
+	ids, names := PartitionEithers(refs)
+	fmt.Printf("Batch queries: ids %v, names %v\n", ids, names)
+	fmt.Printf("MapRight: %v, RightValue of a Left: %v, Swap: %v\n",
+		MapRight(refs[1], strings.ToUpper), refs[0].RightValue(), refs[0].Swap())
+
+	fmt.Println("\n🔸 Result Stops at the First Error")
+
+	bad := signupForm{Username: "al", Email: "not-an-email", Password: "secret", Age: "twelve"}
+	if _, err := validateSignupResult(bad).Unwrap(); err != nil {
+		fmt.Printf("Result: %v\n", err)
+	}
+
+	fmt.Println("\n🔸 Validated Reports Every Problem")
+
+	result := validateSignup(bad)
+	fmt.Printf("Valid: %v, %d problems:\n", result.IsValid(), len(result.Errors()))
+	for _, err := range result.Errors() {
+		fmt.Printf("  - %v\n", err)
+	}
+
+	// The joined error still answers errors.Is, and FieldErrors group per field for a UI
+	fmt.Printf("errors.Is(Err(), errNotAnEmail): %v\n", errors.Is(result.Err(), errNotAnEmail))
+	byField := map[string]int{}
+	for _, err := range result.Errors() {
+		var fe *FieldError
+		if errors.As(err, &fe) {
+			byField[fe.Field]++
+		}
+	}
+	fmt.Printf("Problems per field: %v\n", byField)
+
+	good := signupForm{Username: "alice", Email: "alice@example.com", Password: "hunter22", Age: "34"}
+	if acct, ok := validateSignup(good).Get(); ok {
+		fmt.Printf("Valid form: %v\n", acct)
+	}
+	fmt.Printf("Age 9: %v\n", validateSignup(signupForm{Username: "kid", Email: "k@example.com", Password: "abcdefg1", Age: "9"}).Err())
+
+	fmt.Println("\n🔸 Property Checks")
+
+	// A field is generated as valid (non-negative) or invalid with 1-3 errors
+	field := Generator[Validated[int]]{
+		Generate: func(r *rand.Rand) Validated[int] {
+			if r.Intn(2) == 0 {
+				return Valid(r.Intn(100))
+			}
+			errs := make([]error, 1+r.Intn(3))
+			for i := range errs {
+				errs[i] = fmt.Errorf("problem %d", i)
+			}
+			return Invalid[int](errs...)
+		},
+	}
+	fields := PairsOf(PairsOf(field, field), field)
+	sum3 := func(a, b, c int) int { return a + b + c }
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Combine3 keeps every error, in order", func() string {
+			return ForAll(fields, func(p Pair[Pair[Validated[int], Validated[int]], Validated[int]]) bool {
+				a, b, c := p.First.First, p.First.Second, p.Second
+				combined := Combine3(a, b, c, sum3)
+				want := concatErrors(a.Errors(), b.Errors(), c.Errors())
+				if len(combined.Errors()) != len(want) {
+					return false
+				}
+				for i := range want {
+					if combined.Errors()[i] != want[i] {
+						return false
+					}
+				}
+				return true
+			}).String()
+		}},
+		{"Combine3 is valid iff all inputs are", func() string {
+			return ForAll(fields, func(p Pair[Pair[Validated[int], Validated[int]], Validated[int]]) bool {
+				a, b, c := p.First.First, p.First.Second, p.Second
+				combined := Combine3(a, b, c, sum3)
+				allValid := a.IsValid() && b.IsValid() && c.IsValid()
+				if !allValid {
+					return !combined.IsValid()
+				}
+				v, ok := combined.Get()
+				return ok && v == a.value+b.value+c.value
+			}).String()
+		}},
+		{"PartitionEithers keeps every item", func() string {
+			return ForAll(SlicesOf(Ints(-50, 50), 30), func(xs []int) bool {
+				items := make([]Either[int, int], len(xs))
+				for i, x := range xs {
+					if x < 0 {
+						items[i] = Left[int, int](x)
+					} else {
+						items[i] = Right[int](x)
+					}
+				}
+				lefts, rights := PartitionEithers(items)
+				neg, nonNeg := Partition(xs, func(x int) bool { return x < 0 })
+				return fmt.Sprint(lefts) == fmt.Sprint(neg) && fmt.Sprint(rights) == fmt.Sprint(nonNeg)
+			}).String()
+		}},
+		{"Swap twice is the identity", func() string {
+			return ForAll(Ints(-50, 50), func(x int) bool {
+				e := Left[int, string](x)
+				return e.Swap().Swap() == e && Right[int](x).Swap().IsLeft()
+			}).String()
+		}},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Either and Validated examples completed!")
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ==========================================
+// Function Composition
+// ==========================================
+
+// Predicate, And, Or and Not (06_generic_algorithms.go) combine boolean
+// functions; the helpers here combine any functions. Go has no variadic
+// type parameters, so composition of differently typed steps comes in
+// fixed arities.
+
+// Compose2 runs f and then g: Compose2(f, g)(x) is g(f(x)). The arguments
+// are in the order the data flows, like a Pipeline, not mathematical g∘f.
+func Compose2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C {
+		return g(f(a))
+	}
+}
+
+// Compose3 runs f, g and then h
+func Compose3[A, B, C, D any](f func(A) B, g func(B) C, h func(C) D) func(A) D {
+	return func(a A) D {
+		return h(g(f(a)))
+	}
+}
+
+// ComposeAll composes any number of same-typed steps, such as the mappers a
+// Pipeline takes; with no steps it is the identity
+func ComposeAll[T any](steps ...func(T) T) func(T) T {
+	return func(v T) T {
+		for _, step := range steps {
+			v = step(v)
+		}
+		return v
+	}
+}
+
+// ==========================================
+// Currying and Partial Application
+// ==========================================
+
+// Curry turns a two-argument function into a chain of one-argument
+// functions, so the first argument can be fixed once and reused
+func Curry[A, B, C any](f func(A, B) C) func(A) func(B) C {
+	return func(a A) func(B) C {
+		return func(b B) C {
+			return f(a, b)
+		}
+	}
+}
+
+// Uncurry reverses Curry
+func Uncurry[A, B, C any](f func(A) func(B) C) func(A, B) C {
+	return func(a A, b B) C {
+		return f(a)(b)
+	}
+}
+
+// Partial fixes the first argument of f
+func Partial[A, B, C any](f func(A, B) C, a A) func(B) C {
+	return func(b B) C {
+		return f(a, b)
+	}
+}
+
+// PartialRight fixes the second argument of f, which is what most standard
+// library functions need: strings.HasPrefix(s, prefix) takes the subject first
+func PartialRight[A, B, C any](f func(A, B) C, b B) func(A) C {
+	return func(a A) C {
+		return f(a, b)
+	}
+}
+
+// Flip swaps the arguments of f
+func Flip[A, B, C any](f func(A, B) C) func(B, A) C {
+	return func(b B, a A) C {
+		return f(a, b)
+	}
+}
+
+// ==========================================
+// Memoization
+// ==========================================
+
+// Memoize caches fn's results in an LRU Cache (27_cache.go) holding at most
+// capacity entries, so memory stays bounded however many keys are seen.
+// fn must be pure: the cache cannot tell when a result goes stale. The
+// returned function is safe for concurrent use, but two goroutines missing
+// on the same key at once will both call fn.
+func Memoize[K comparable, V any](fn func(K) V, capacity int) func(K) V {
+	cache := NewCacheWithOptions(CacheOptions[K, V]{MaxSize: capacity, Policy: EvictLRU})
+	return func(key K) V {
+		if v, ok := cache.Get(key); ok {
+			return v
+		}
+		// Computed outside the cache's lock, so fn may call the memoized
+		// function recursively
+		v := fn(key)
+		cache.Set(key, v, 0)
+		return v
+	}
+}
+
+func runFunctionalExample() {
+	fmt.Println("\n🔸 Composition")
+
+	collapseSpaces := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	normalize := ComposeAll(strings.TrimSpace, strings.ToLower, collapseSpaces)
+	titles := []string{"  Go   Generics ", "GO GENERICS", "Channels  in   Practice", " go generics"}
+	cleaned := NewPipeline(titles).Map(normalize).Collect()
+	fmt.Printf("Pipeline.Map(ComposeAll(...)): %q\n", cleaned)
+	fmt.Printf("Unique after normalizing: %q\n", Unique(cleaned))
+
+	wordCount := Compose3(strings.TrimSpace, strings.Fields, func(words []string) int { return len(words) })
+	slug := Compose2(normalize, func(s string) string { return strings.ReplaceAll(s, " ", "-") })
+	fmt.Printf("wordCount(%q) = %d, slug = %q\n", titles[2], wordCount(titles[2]), slug(titles[2]))
+
+	fmt.Println("\n🔸 Currying and Partial Application")
+
+	add := Curry(func(a, b int) int { return a + b })
+	fmt.Printf("MapSlice([1 2 3], add(10)): %v\n", MapSlice([]int{1, 2, 3}, add(10)))
+
+	// Build Predicates from standard library functions, then combine them
+	hasPrefix := Curry(Flip(strings.HasPrefix)) // hasPrefix(prefix)(s)
+	contains := func(sub string) Predicate[string] { return PartialRight(strings.Contains, sub) }
+	alerting := And(
+		Or(hasPrefix("ERROR"), hasPrefix("WARN")),
+		Not(contains("healthcheck")),
+	)
+	logs := []string{
+		"INFO  server started",
+		"WARN  disk 91% full",
+		"ERROR healthcheck timed out",
+		"ERROR payment gateway 502",
+		"DEBUG cache warm",
+	}
+	for _, line := range FilterSlice(logs, alerting) {
+		fmt.Printf("  alert: %s\n", line)
+	}
+
+	repeat := Partial(strings.Repeat, "=-")
+	fmt.Printf("Partial(strings.Repeat, \"=-\")(5): %s\n", repeat(5))
+
+	fmt.Println("\n🔸 Memoization")
+
+	// The memoized function refers to itself, so subproblems hit the cache too
+	calls := 0
+	var climb func(n int) int // Ways to climb n stairs taking 1 or 2 at a time
+	climb = Memoize(func(n int) int {
+		calls++
+		if n <= 1 {
+			return 1
+		}
+		return climb(n-1) + climb(n-2)
+	}, 100)
+	fmt.Printf("climb(60) = %d with %d calls (naive recursion: %d)\n", climb(60), calls, naiveClimbCalls(60))
+
+	// The bound keeps memory fixed: cycling through more keys than fit evicts
+	lookups := 0
+	geocode := func(city string) string {
+		lookups++
+		return fmt.Sprintf("geo:%s", city)
+	}
+	cached := Memoize(geocode, 2)
+	for _, city := range []string{"paris", "oslo", "paris", "lima", "paris", "oslo"} {
+		cached(city)
+	}
+	fmt.Printf("6 calls over 3 cities, capacity 2: %d real lookups\n", lookups)
+
+	// Compose normalization in front, so equivalent inputs share an entry
+	lookups = 0
+	byName := Compose2(normalize, Memoize(geocode, 100))
+	for _, city := range []string{"Paris", " paris ", "PARIS", "Oslo"} {
+		byName(city)
+	}
+	fmt.Printf("4 spellings of 2 cities with normalize first: %d real lookups\n", lookups)
+
+	fmt.Println("\n🔸 Property Checks")
+
+	inc := func(n int) int { return n + 1 }
+	double := func(n int) int { return n * 2 }
+	square := func(n int) int { return n * n }
+	sub := func(a, b int) int { return a - b }
+	pairs := PairsOf(Ints(-1000, 1000), Ints(-1000, 1000))
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Composition is associative", func() string {
+			return ForAll(Ints(-1000, 1000), func(x int) bool {
+				return Compose2(Compose2(inc, double), square)(x) == Compose2(inc, Compose2(double, square))(x) &&
+					Compose3(inc, double, square)(x) == ComposeAll(inc, double, square)(x)
+			}).String()
+		}},
+		{"Uncurry(Curry(f)) == f", func() string {
+			return ForAll(pairs, func(p Pair[int, int]) bool {
+				return Uncurry(Curry(sub))(p.First, p.Second) == sub(p.First, p.Second)
+			}).String()
+		}},
+		{"Partial and PartialRight fix one side", func() string {
+			return ForAll(pairs, func(p Pair[int, int]) bool {
+				return Partial(sub, p.First)(p.Second) == sub(p.First, p.Second) &&
+					PartialRight(sub, p.Second)(p.First) == sub(p.First, p.Second) &&
+					Flip(sub)(p.Second, p.First) == sub(p.First, p.Second)
+			}).String()
+		}},
+		{"Memoize returns what fn returns", func() string {
+			return ForAll(SlicesOf(Ints(0, 20), 50), func(keys []int) bool {
+				memo := Memoize(square, 4) // Small, so evictions happen
+				for _, k := range keys {
+					if memo(k) != square(k) {
+						return false
+					}
+				}
+				return true
+			}).String()
+		}},
+		{"Memoize calls fn once per key", func() string {
+			return ForAll(SlicesOf(Ints(0, 20), 50), func(keys []int) bool {
+				seen := map[int]int{}
+				memo := Memoize(func(k int) int { seen[k]++; return k }, 21)
+				for _, k := range keys {
+					memo(k)
+				}
+				for _, n := range seen {
+					if n != 1 {
+						return false
+					}
+				}
+				return len(seen) == len(Unique(keys))
+			}).String()
+		}},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Functional utility examples completed!")
+}
+
+// naiveClimbCalls counts the calls the unmemoized recursion would make:
+// calls(n) = 1 + calls(n-1) + calls(n-2). Counting them by running it
+// would take hours for n = 60.
+func naiveClimbCalls(n int) int {
+	prev, cur := 1, 1 // calls(0), calls(1)
+	for i := 2; i <= n; i++ {
+		prev, cur = cur, 1+cur+prev
+	}
+	return cur
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Retry With Backoff and Jitter
+// ==========================================
+
+// RetryPolicy describes when and how often to retry. The zero value makes
+// a single attempt; the Retry middleware (12_middleware.go) is this policy
+// with doubling delays and no jitter.
+type RetryPolicy struct {
+	MaxAttempts    int           // Total calls, including the first
+	InitialDelay   time.Duration // Wait before the first retry
+	MaxDelay       time.Duration // Cap on any single wait; 0 means no cap
+	Multiplier     float64       // Growth per retry; 0 means 2
+	Jitter         float64       // Fraction of each wait that is randomized, 0-1
+	AttemptTimeout time.Duration // Deadline for each call; 0 means only ctx's
+	// Retryable decides which errors are worth another attempt; nil
+	// retries every error
+	Retryable func(error) bool
+	// OnRetry is called before each wait, for logging and metrics
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// Delay returns the wait before retry number retry (1-based). Without
+// jitter, clients that failed together retry together and hit the
+// recovering service in synchronized waves; jitter spreads them out.
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialDelay)
+	for i := 1; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 {
+		delay = min(delay, float64(p.MaxDelay))
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		delay -= jitter * delay * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// RetryWithPolicy calls fn until it succeeds, returns an error the policy
+// does not retry, or runs out of attempts. Running out wraps the last error
+// as "after N attempts: err". If ctx ends during a wait, the result wraps
+// both ctx's error and the last failure, so errors.Is matches either.
+func RetryWithPolicy[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) (T, error)) (T, error) {
+	attempts := max(policy.MaxAttempts, 1)
+	var result T
+	var err error
+	for attempt := 1; ; attempt++ {
+		result, err = callWithTimeout(ctx, policy.AttemptTimeout, fn)
+		if err == nil || (policy.Retryable != nil && !policy.Retryable(err)) {
+			return result, err
+		}
+		if attempt == attempts {
+			return result, fmt.Errorf("after %d attempts: %w", attempts, err)
+		}
+
+		delay := policy.Delay(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, fmt.Errorf("retry stopped after %d attempts: %w (last error: %w)", attempt, ctx.Err(), err)
+		}
+	}
+}
+
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+// ==========================================
+// Circuit Breaker
+// ==========================================
+
+// BreakerState is where a CircuitBreaker is in its cycle
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Calls pass through; failures are counted
+	BreakerOpen                         // Calls fail fast without reaching the service
+	BreakerHalfOpen                     // A few trial calls decide whether to close again
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// ErrCircuitOpen is returned instead of calling the service while the
+// breaker is open, or when half-open and all trial slots are taken
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerOptions configures a CircuitBreaker; zero fields take defaults
+type BreakerOptions struct {
+	FailureThreshold int           // Consecutive failures that open the breaker; default 5
+	OpenTimeout      time.Duration // How long to stay open before a trial; default 1s
+	HalfOpenProbes   int           // Trial calls that must succeed to close; default 1
+	// IsFailure decides which errors count against the service; nil counts
+	// every error. A caller's own mistake, like a 404, should not.
+	IsFailure func(error) bool
+	// OnStateChange is called after every transition, outside the lock
+	OnStateChange func(from, to BreakerState)
+	// Now is the clock, time.Now by default
+	Now func() time.Time
+}
+
+// BreakerMetrics counts what the breaker has seen
+type BreakerMetrics struct {
+	Requests, Successes, Failures, Rejected, Opened int
+}
+
+// CircuitBreaker stops calling a service that keeps failing, so callers
+// fail fast instead of piling up behind timeouts, and the service gets room
+// to recover. It is generic in the result type so Execute needs no
+// assertions; a Go method cannot have its own type parameter.
+type CircuitBreaker[T any] struct {
+	mu             sync.Mutex
+	opts           BreakerOptions
+	state          BreakerState
+	generation     int // Bumped on each transition, so stale results are ignored
+	consecutive    int // Failures in a row while closed
+	openedAt       time.Time
+	probesInFlight int
+	probeSuccesses int
+	metrics        BreakerMetrics
+}
+
+// NewCircuitBreaker creates a closed breaker
+func NewCircuitBreaker[T any](opts BreakerOptions) *CircuitBreaker[T] {
+	if opts.FailureThreshold < 1 {
+		opts.FailureThreshold = 5
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = time.Second
+	}
+	if opts.HalfOpenProbes < 1 {
+		opts.HalfOpenProbes = 1
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &CircuitBreaker[T]{opts: opts}
+}
+
+// Execute calls fn if the breaker allows it and records the outcome. A
+// panic in fn is recorded as a failure before it carries on to the caller,
+// so a half-open probe that panics still frees its slot.
+func (b *CircuitBreaker[T]) Execute(ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	generation, err := b.before()
+	if err != nil {
+		return zero, err
+	}
+	failed := true // Still set if fn panics
+	defer func() { b.after(generation, failed) }()
+	result, err := fn(ctx)
+	failed = err != nil && (b.opts.IsFailure == nil || b.opts.IsFailure(err))
+	return result, err
+}
+
+// State returns the current state, moving from open to half-open if the
+// open timeout has passed
+func (b *CircuitBreaker[T]) State() BreakerState {
+	b.mu.Lock()
+	change := b.refresh()
+	state := b.state
+	b.mu.Unlock()
+	b.notify(change)
+	return state
+}
+
+// Metrics returns a snapshot of the counters
+func (b *CircuitBreaker[T]) Metrics() BreakerMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.metrics
+}
+
+// transition is a state change waiting to be reported outside the lock
+type transition struct {
+	from, to BreakerState
+	changed  bool
+}
+
+func (b *CircuitBreaker[T]) before() (int, error) {
+	b.mu.Lock()
+	change := b.refresh()
+	defer b.notify(change)
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen || (b.state == BreakerHalfOpen && b.probesInFlight >= b.opts.HalfOpenProbes) {
+		b.metrics.Rejected++
+		return 0, ErrCircuitOpen
+	}
+	if b.state == BreakerHalfOpen {
+		b.probesInFlight++
+	}
+	b.metrics.Requests++
+	return b.generation, nil
+}
+
+func (b *CircuitBreaker[T]) after(generation int, failed bool) {
+	b.mu.Lock()
+	var change transition
+	defer func() { b.notify(change) }()
+	defer b.mu.Unlock()
+
+	if failed {
+		b.metrics.Failures++
+	} else {
+		b.metrics.Successes++
+	}
+	if generation != b.generation {
+		return // The state changed while fn ran; this result belongs to the old one
+	}
+
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.consecutive = 0
+		} else if b.consecutive++; b.consecutive >= b.opts.FailureThreshold {
+			change = b.setState(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		b.probesInFlight--
+		if failed {
+			change = b.setState(BreakerOpen)
+		} else if b.probeSuccesses++; b.probeSuccesses >= b.opts.HalfOpenProbes {
+			change = b.setState(BreakerClosed)
+		}
+	}
+}
+
+// refresh moves an open breaker to half-open once the timeout has passed;
+// callers hold the lock
+func (b *CircuitBreaker[T]) refresh() transition {
+	if b.state == BreakerOpen && b.opts.Now().Sub(b.openedAt) >= b.opts.OpenTimeout {
+		return b.setState(BreakerHalfOpen)
+	}
+	return transition{}
+}
+
+// setState resets the per-state counters; callers hold the lock
+func (b *CircuitBreaker[T]) setState(to BreakerState) transition {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.consecutive, b.probesInFlight, b.probeSuccesses = 0, 0, 0
+	if to == BreakerOpen {
+		b.openedAt = b.opts.Now()
+		b.metrics.Opened++
+	}
+	return transition{from: from, to: to, changed: true}
+}
+
+func (b *CircuitBreaker[T]) notify(t transition) {
+	if t.changed && b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(t.from, t.to)
+	}
+}
+
+// ==========================================
+// Simulated Service
+// ==========================================
+
+var (
+	errUnavailable = errors.New("503 service unavailable")
+	errNotFound    = errors.New("404 not found")
+)
+
+// simulatedBackend fails with 503 while down, and for its first failFirst calls
+type simulatedBackend struct {
+	mu        sync.Mutex
+	calls     int
+	failFirst int
+	down      bool
+	latency   time.Duration
+}
+
+func (s *simulatedBackend) Fetch(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	s.calls++
+	failing := s.down || s.calls <= s.failFirst
+	s.mu.Unlock()
+
+	select {
+	case <-time.After(s.latency):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	switch {
+	case key == "":
+		return "", errNotFound
+	case failing:
+		return "", errUnavailable
+	}
+	return "value-of-" + key, nil
+}
+
+func (s *simulatedBackend) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func runResilienceExample() {
+	fmt.Println("\n🔸 Backoff Schedule")
+
+	policy := RetryPolicy{MaxAttempts: 8, InitialDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+	fmt.Print("Exponential, capped at 2s:")
+	for retry := 1; retry < policy.MaxAttempts; retry++ {
+		fmt.Printf(" %v", policy.Delay(retry))
+	}
+	policy.Jitter = 0.5
+	fmt.Println("\nWith Jitter 0.5 each wait is drawn from [d/2, d], e.g.:")
+	for retry := 1; retry <= 3; retry++ {
+		var samples []string
+		for i := 0; i < 4; i++ {
+			samples = append(samples, policy.Delay(retry).Round(time.Millisecond).String())
+		}
+		fmt.Printf("  retry %d: %v\n", retry, samples)
+	}
+
+	fmt.Println("\n🔸 Retry Against a Flaky Service")
+
+	isTransient := func(err error) bool {
+		return errors.Is(err, errUnavailable) || errors.Is(err, context.DeadlineExceeded)
+	}
+	retry := RetryPolicy{
+		MaxAttempts:  4,
+		InitialDelay: 2 * time.Millisecond,
+		Jitter:       0.2,
+		Retryable:    isTransient,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			fmt.Printf("  attempt %d failed (%v), waiting ~%v\n", attempt, err, delay.Round(time.Millisecond))
+		},
+	}
+	ctx := context.Background()
+
+	backend := &simulatedBackend{failFirst: 2}
+	value, err := RetryWithPolicy(ctx, retry, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, "user:7") })
+	fmt.Printf("Result: %q, err=%v, calls=%d\n", value, err, backend.Calls())
+
+	backend = &simulatedBackend{}
+	_, err = RetryWithPolicy(ctx, retry, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, "") })
+	fmt.Printf("404 is not retried: err=%v, calls=%d\n", err, backend.Calls())
+
+	backend = &simulatedBackend{down: true}
+	retry.OnRetry = nil
+	_, err = RetryWithPolicy(ctx, retry, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, "user:7") })
+	fmt.Printf("Still down: %v, calls=%d\n", err, backend.Calls())
+
+	// A slow service: each attempt gets 5ms, the whole operation 12ms
+	backend = &simulatedBackend{latency: 20 * time.Millisecond}
+	slow := retry
+	slow.AttemptTimeout = 5 * time.Millisecond
+	deadline, cancel := context.WithTimeout(ctx, 12*time.Millisecond)
+	_, err = RetryWithPolicy(deadline, slow, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, "user:7") })
+	cancel()
+	fmt.Printf("Overall deadline: %v (is DeadlineExceeded: %v)\n", err, errors.Is(err, context.DeadlineExceeded))
+
+	fmt.Println("\n🔸 Circuit Breaker States")
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	backend = &simulatedBackend{down: true}
+	breaker := NewCircuitBreaker[string](BreakerOptions{
+		FailureThreshold: 3,
+		OpenTimeout:      30 * time.Second,
+		IsFailure:        func(err error) bool { return !errors.Is(err, errNotFound) },
+		OnStateChange: func(from, to BreakerState) {
+			fmt.Printf("  [%s] breaker %s -> %s\n", clock.Now().Format(time.TimeOnly), from, to)
+		},
+		Now: clock.Now,
+	})
+	call := func(key string) error {
+		_, err := breaker.Execute(ctx, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, key) })
+		return err
+	}
+
+	for i := 1; i <= 5; i++ {
+		err := call("user:7")
+		fmt.Printf("  call %d: %v (backend calls: %d)\n", i, err, backend.Calls())
+	}
+	clock.Advance(30 * time.Second)
+	fmt.Printf("  after 30s: state %v, trial call: %v\n", breaker.State(), call("user:7"))
+
+	backend.down = false
+	clock.Advance(30 * time.Second)
+	fmt.Printf("  service recovered; trial call: %v\n", call("user:7"))
+	fmt.Printf("  404s do not count: %v, state %v\n", call(""), breaker.State())
+	fmt.Printf("Metrics: %+v\n", breaker.Metrics())
+
+	fmt.Println("\n🔸 Retry Plus Breaker During an Outage")
+
+	// 20 requests during an outage, each retried up to 3 times
+	outage := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, Retryable: isTransient}
+	backend = &simulatedBackend{down: true}
+	for i := 0; i < 20; i++ {
+		RetryWithPolicy(ctx, outage, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, "user:7") })
+	}
+	fmt.Printf("Retry only:        %2d backend calls\n", backend.Calls())
+
+	// ErrCircuitOpen is not transient, so an open breaker also stops the retries
+	backend = &simulatedBackend{down: true}
+	guard := NewCircuitBreaker[string](BreakerOptions{FailureThreshold: 5, OpenTimeout: time.Minute})
+	rejected := 0
+	for i := 0; i < 20; i++ {
+		_, err := RetryWithPolicy(ctx, outage, func(ctx context.Context) (string, error) {
+			return guard.Execute(ctx, func(ctx context.Context) (string, error) { return backend.Fetch(ctx, "user:7") })
+		})
+		if errors.Is(err, ErrCircuitOpen) {
+			rejected++
+		}
+	}
+	fmt.Printf("Retry plus breaker: %2d backend calls, %d requests failed fast\n", backend.Calls(), rejected)
+
+	fmt.Println("\n✅ Resilience examples completed!")
+}
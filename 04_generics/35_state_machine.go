@@ -0,0 +1,427 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ==========================================
+// State Machine
+// ==========================================
+
+// Transition is one step a StateMachine took, or may take
+type Transition[S, E comparable] struct {
+	From  S
+	Event E
+	To    S
+}
+
+// Guard must approve a transition before it happens. Guards close over the
+// entity the machine describes; Name labels them in errors and diagrams.
+type Guard struct {
+	Name  string
+	Allow func() bool
+}
+
+// When builds a Guard
+func When(name string, allow func() bool) Guard {
+	return Guard{Name: name, Allow: allow}
+}
+
+var (
+	// ErrInvalidTransition is returned for an event the current state has no
+	// transition for
+	ErrInvalidTransition = errors.New("statemachine: invalid transition")
+	// ErrGuardRejected is returned when transitions exist but every one of
+	// them was refused by a guard
+	ErrGuardRejected = errors.New("statemachine: rejected by guard")
+)
+
+// edge is a registered transition and the guards it needs
+type edge[S, E comparable] struct {
+	Transition[S, E]
+	guards []Guard
+}
+
+// StateMachine moves between states of type S in response to events of
+// type E. States and events are usually small named types, so a typo or an
+// order event sent to a payment machine is a compile error rather than a
+// "no such state" at run time. Configure it fully before the first Fire;
+// every method is safe for concurrent use afterwards. Actions run while the
+// machine is locked and must not call Fire.
+type StateMachine[S, E comparable] struct {
+	mu           sync.Mutex
+	initial      S
+	current      S
+	edges        []edge[S, E] // Registration order, which is also priority order
+	onEnter      map[S][]func(Transition[S, E])
+	onExit       map[S][]func(Transition[S, E])
+	onTransition []func(Transition[S, E])
+	history      []Transition[S, E]
+}
+
+// NewStateMachine creates a machine in the initial state
+func NewStateMachine[S, E comparable](initial S) *StateMachine[S, E] {
+	return &StateMachine[S, E]{
+		initial: initial,
+		current: initial,
+		onEnter: make(map[S][]func(Transition[S, E])),
+		onExit:  make(map[S][]func(Transition[S, E])),
+	}
+}
+
+// Permit registers a transition from one state to another on event. When
+// several transitions share a state and event, the first one registered
+// whose guards all pass is taken, so an unguarded fallback goes last.
+func (m *StateMachine[S, E]) Permit(from S, event E, to S, guards ...Guard) *StateMachine[S, E] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.edges = append(m.edges, edge[S, E]{Transition: Transition[S, E]{From: from, Event: event, To: to}, guards: guards})
+	return m
+}
+
+// OnEnter registers an action run after entering state
+func (m *StateMachine[S, E]) OnEnter(state S, action func(Transition[S, E])) *StateMachine[S, E] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEnter[state] = append(m.onEnter[state], action)
+	return m
+}
+
+// OnExit registers an action run before leaving state
+func (m *StateMachine[S, E]) OnExit(state S, action func(Transition[S, E])) *StateMachine[S, E] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onExit[state] = append(m.onExit[state], action)
+	return m
+}
+
+// OnTransition registers an action run on every transition, between the
+// exit and entry actions
+func (m *StateMachine[S, E]) OnTransition(action func(Transition[S, E])) *StateMachine[S, E] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onTransition = append(m.onTransition, action)
+	return m
+}
+
+// Fire applies event to the current state. It returns ErrInvalidTransition
+// if the state has no transition for the event and ErrGuardRejected if
+// every candidate was refused; the state is unchanged in both cases.
+func (m *StateMachine[S, E]) Fire(event E) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var refused []string
+	for _, e := range m.edges {
+		if e.From != m.current || e.Event != event {
+			continue
+		}
+		if guard, ok := firstRefusal(e.guards); !ok {
+			refused = append(refused, guard)
+			continue
+		}
+
+		t := e.Transition
+		for _, action := range m.onExit[t.From] {
+			action(t)
+		}
+		m.current = t.To
+		m.history = append(m.history, t)
+		for _, action := range m.onTransition {
+			action(t)
+		}
+		for _, action := range m.onEnter[t.To] {
+			action(t)
+		}
+		return nil
+	}
+
+	if len(refused) > 0 {
+		return fmt.Errorf("%w: %v on %v needs %s", ErrGuardRejected, m.current, event, strings.Join(refused, " or "))
+	}
+	return fmt.Errorf("%w: %v on %v", ErrInvalidTransition, m.current, event)
+}
+
+// firstRefusal returns the name of the first guard that refuses
+func firstRefusal(guards []Guard) (string, bool) {
+	for _, g := range guards {
+		if !g.Allow() {
+			return g.Name, false
+		}
+	}
+	return "", true
+}
+
+// State returns the current state
+func (m *StateMachine[S, E]) State() S {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// Can reports whether event would succeed now, guards included
+func (m *StateMachine[S, E]) Can(event E) bool {
+	return slices.Contains(m.AvailableEvents(), event)
+}
+
+// AvailableEvents lists the events that would succeed now, in
+// registration order; a UI can show exactly these as buttons
+func (m *StateMachine[S, E]) AvailableEvents() []E {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var events []E
+	for _, e := range m.edges {
+		if e.From != m.current || slices.Contains(events, e.Event) {
+			continue
+		}
+		if _, ok := firstRefusal(e.guards); ok {
+			events = append(events, e.Event)
+		}
+	}
+	return events
+}
+
+// History returns the transitions taken so far
+func (m *StateMachine[S, E]) History() []Transition[S, E] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.history)
+}
+
+// Graph returns the transition table as a Graph (05_generic_containers.go),
+// so the graph algorithms apply: reachability, shortest paths, cycles
+func (m *StateMachine[S, E]) Graph() *Graph[S] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g := NewGraph[S]()
+	g.AddVertex(m.initial)
+	for _, e := range m.edges {
+		g.AddEdge(e.From, e.To)
+	}
+	return g
+}
+
+// Unreachable lists states that appear in the table but can never be
+// entered from the initial state, usually a forgotten Permit
+func (m *StateMachine[S, E]) Unreachable() []S {
+	g := m.Graph()
+	reachable := g.BFS(m.initial)
+	var result []S
+	for _, s := range g.Vertices() {
+		if !slices.Contains(reachable, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// DOT renders the transition table in Graphviz format: the initial state
+// has an incoming arrow, final states (no way out) a double circle, and
+// each edge is labelled with its event and guards
+func (m *StateMachine[S, E]) DOT(name string) string {
+	g := m.Graph()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", name)
+	b.WriteString("  rankdir=LR;\n  start [shape=point];\n")
+	for _, s := range g.Vertices() {
+		shape := "circle"
+		if len(g.GetNeighbors(s)) == 0 {
+			shape = "doublecircle"
+		}
+		fmt.Fprintf(&b, "  %q [shape=%s];\n", fmt.Sprint(s), shape)
+	}
+	fmt.Fprintf(&b, "  start -> %q;\n", fmt.Sprint(m.initial))
+	for _, e := range m.edges {
+		label := fmt.Sprint(e.Event)
+		for _, guard := range e.guards {
+			label += " [" + guard.Name + "]"
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", fmt.Sprint(e.From), fmt.Sprint(e.To), label)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ==========================================
+// Order Lifecycle
+// ==========================================
+
+// The CSP example in 01_concurrency/08_csp.go moves orders through
+// VALIDATED, PROCESSED and COMPLETED by passing them between goroutines,
+// with the lifecycle implicit in the channel wiring. Here the lifecycle is
+// data: one table says which moves are legal, and everything else is an error.
+
+type OrderState string
+
+const (
+	OrderPending   OrderState = "Pending"
+	OrderValidated OrderState = "Validated"
+	OrderRejected  OrderState = "Rejected"
+	OrderPaid      OrderState = "Paid"
+	OrderShipped   OrderState = "Shipped"
+	OrderDelivered OrderState = "Delivered"
+	OrderCancelled OrderState = "Cancelled"
+	OrderRefunded  OrderState = "Refunded"
+)
+
+type OrderEvent string
+
+const (
+	EventValidate OrderEvent = "validate"
+	EventPay      OrderEvent = "pay"
+	EventShip     OrderEvent = "ship"
+	EventDeliver  OrderEvent = "deliver"
+	EventCancel   OrderEvent = "cancel"
+	EventReturn   OrderEvent = "return"
+)
+
+// lifecycleOrder is the entity the machine tracks
+type lifecycleOrder struct {
+	ID       int
+	Customer string
+	Items    []string
+	Total    float64
+	Paid     float64
+	Tracking string
+	DaysHeld int // Days since delivery
+}
+
+// newOrderMachine wires the lifecycle for one order; guards and actions
+// close over it
+func newOrderMachine(o *lifecycleOrder, log func(string)) *StateMachine[OrderState, OrderEvent] {
+	hasItems := When("has items", func() bool { return len(o.Items) > 0 && o.Total > 0 })
+	paidInFull := When("paid in full", func() bool { return o.Paid >= o.Total })
+	withinWindow := When("within 30 days", func() bool { return o.DaysHeld <= 30 })
+
+	return NewStateMachine[OrderState, OrderEvent](OrderPending).
+		Permit(OrderPending, EventValidate, OrderValidated, hasItems).
+		Permit(OrderPending, EventValidate, OrderRejected). // Fallback when the guard refuses
+		Permit(OrderValidated, EventPay, OrderPaid, paidInFull).
+		Permit(OrderPaid, EventShip, OrderShipped).
+		Permit(OrderShipped, EventDeliver, OrderDelivered).
+		Permit(OrderDelivered, EventReturn, OrderRefunded, withinWindow).
+		Permit(OrderPending, EventCancel, OrderCancelled).
+		Permit(OrderValidated, EventCancel, OrderCancelled).
+		Permit(OrderPaid, EventCancel, OrderRefunded).
+		OnEnter(OrderShipped, func(Transition[OrderState, OrderEvent]) {
+			o.Tracking = fmt.Sprintf("TRK-%04d", o.ID*37)
+			log("label printed: " + o.Tracking)
+		}).
+		OnEnter(OrderRefunded, func(t Transition[OrderState, OrderEvent]) {
+			log(fmt.Sprintf("refunded %.2f to %s (%s from %s)", o.Paid, o.Customer, t.Event, t.From))
+			o.Paid = 0
+		}).
+		OnExit(OrderPaid, func(t Transition[OrderState, OrderEvent]) {
+			log(fmt.Sprintf("stock released from the warehouse (%s)", t.Event))
+		})
+}
+
+func runStateMachineExample() {
+	fmt.Println("\n🔸 Order Lifecycle")
+
+	// The same four orders as the CSP example
+	orders := []*lifecycleOrder{
+		{ID: 1, Customer: "Alice", Items: []string{"Apple", "Banana"}, Total: 15.50},
+		{ID: 2, Customer: "Bob", Items: []string{"Orange"}, Total: 8.00},
+		{ID: 3, Customer: "Charlie", Items: []string{"Apple", "Grape", "Pear"}, Total: 25.00},
+		{ID: 4, Customer: "David", Items: []string{}, Total: 0.00},
+	}
+	quiet := func(string) {}
+	for _, o := range orders {
+		o.Paid = o.Total
+		m := newOrderMachine(o, quiet)
+		for _, event := range []OrderEvent{EventValidate, EventPay, EventShip, EventDeliver} {
+			if err := m.Fire(event); err != nil {
+				break
+			}
+		}
+		var path []string
+		for _, t := range m.History() {
+			path = append(path, fmt.Sprintf("-%s-> %s", t.Event, t.To))
+		}
+		fmt.Printf("  order %d (%-7s) %s %s\n", o.ID, o.Customer, OrderPending, strings.Join(path, " "))
+	}
+
+	fmt.Println("\n🔸 Guards and Invalid Events")
+
+	order := &lifecycleOrder{ID: 5, Customer: "Erin", Items: []string{"Kiwi"}, Total: 12}
+	m := newOrderMachine(order, quiet)
+	m.Fire(EventValidate)
+	fmt.Printf("State %s, available events: %v\n", m.State(), m.AvailableEvents())
+
+	err := m.Fire(EventShip)
+	fmt.Printf("ship before paying: %v (invalid: %v)\n", err, errors.Is(err, ErrInvalidTransition))
+
+	order.Paid = 5
+	err = m.Fire(EventPay)
+	fmt.Printf("pay 5 of 12: %v (guard: %v), still %s\n", err, errors.Is(err, ErrGuardRejected), m.State())
+
+	order.Paid = 12
+	fmt.Printf("pay 12 of 12: %v, now %s, can ship: %v\n", m.Fire(EventPay), m.State(), m.Can(EventShip))
+
+	fmt.Println("\n🔸 Entry and Exit Actions")
+
+	logLine := func(s string) { fmt.Printf("  action: %s\n", s) }
+	shipped := &lifecycleOrder{ID: 6, Customer: "Finn", Items: []string{"Fig"}, Total: 9, Paid: 9}
+	m = newOrderMachine(shipped, logLine)
+	m.OnTransition(func(t Transition[OrderState, OrderEvent]) {
+		fmt.Printf("  %s --%s--> %s\n", t.From, t.Event, t.To)
+	})
+	for _, event := range []OrderEvent{EventValidate, EventPay, EventShip, EventDeliver} {
+		m.Fire(event)
+	}
+	shipped.DaysHeld = 45
+	fmt.Printf("  return after 45 days: %v\n", m.Fire(EventReturn))
+
+	cancelled := &lifecycleOrder{ID: 7, Customer: "Gus", Items: []string{"Lime"}, Total: 4, Paid: 4}
+	m = newOrderMachine(cancelled, logLine)
+	m.Fire(EventValidate)
+	m.Fire(EventPay)
+	m.Fire(EventCancel)
+	fmt.Printf("  cancelled after paying: %s\n", m.State())
+
+	fmt.Println("\n🔸 Concurrent Events")
+
+	// A customer cancels while the warehouse ships: exactly one may win
+	for round := 1; round <= 3; round++ {
+		o := &lifecycleOrder{ID: 8, Customer: "Hana", Items: []string{"Plum"}, Total: 3, Paid: 3}
+		m := newOrderMachine(o, quiet)
+		m.Fire(EventValidate)
+		m.Fire(EventPay)
+
+		var wg sync.WaitGroup
+		results := make([]error, 2)
+		for i, event := range []OrderEvent{EventShip, EventCancel} {
+			wg.Add(1)
+			go func(i int, event OrderEvent) {
+				defer wg.Done()
+				results[i] = m.Fire(event)
+			}(i, event)
+		}
+		wg.Wait()
+		fmt.Printf("  round %d: ship err=%v, cancel err=%v -> %s\n", round, results[0] != nil, results[1] != nil, m.State())
+	}
+
+	fmt.Println("\n🔸 Analysis and DOT Export")
+
+	m = newOrderMachine(&lifecycleOrder{}, quiet)
+	path, _, err := m.Graph().ShortestPath(OrderPending, OrderRefunded)
+	fmt.Printf("Fewest steps to a refund: %v (err=%v)\n", path, err)
+	fmt.Printf("Unreachable states: %v\n", m.Unreachable())
+	m.Permit("Archived", "archive", OrderDelivered) // Nothing ever enters Archived
+	fmt.Printf("After a stray Permit: unreachable %v\n", m.Unreachable())
+
+	fmt.Print(newOrderMachine(&lifecycleOrder{}, quiet).DOT("order"))
+
+	fmt.Println("\n✅ State machine examples completed!")
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/pubsub"
+)
+
+// ==========================================
+// Typed Publish/Subscribe
+// ==========================================
+
+// The Broker and Topic[T] live in internal/pubsub. They replace the
+// interface{}-based Subject of 01_concurrency/10_reactive.go, where every
+// observer asserts the value's type and any publisher can send anything.
+
+// PriceTick is published on the "prices" topic
+type PriceTick struct {
+	Symbol string
+	Price  float64
+}
+
+// TradeFill is published on the "fills" topic
+type TradeFill struct {
+	Symbol   string
+	Quantity int
+	Price    float64
+}
+
+// collect drains a subscription on its own goroutine; the returned function
+// waits for the channel to close and returns what arrived
+func collect[T any](sub *pubsub.Subscription[T]) func() []T {
+	var (
+		got []T
+		wg  sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := range sub.C() {
+			got = append(got, v)
+		}
+	}()
+	return func() []T {
+		wg.Wait()
+		return got
+	}
+}
+
+// stalledRun records what runStalled saw
+type stalledRun struct {
+	published  int
+	publishErr error
+	live       []int
+	stalled    []int
+	stalledErr error
+	stats      pubsub.Stats
+	remaining  int
+}
+
+// runStalled publishes 1..n to a fresh topic with a subscriber that keeps up
+// and one that reads nothing until the end, and reports what each received.
+// The live subscriber is drained after every Publish, so the result does not
+// depend on goroutine scheduling.
+func runStalled(policy pubsub.Policy, buffer, n int) stalledRun {
+	broker := pubsub.NewBroker()
+	defer broker.Close()
+	topic, _ := pubsub.OpenTopic[int](broker, "numbers", pubsub.TopicOptions{Buffer: buffer, Policy: policy})
+	live, stalled := topic.Subscribe(), topic.Subscribe()
+
+	var run stalledRun
+	for i := 1; i <= n; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		err := topic.Publish(ctx, i)
+		cancel()
+		if err != nil {
+			run.publishErr = err
+			break
+		}
+		run.published++
+		run.live = append(run.live, <-live.C())
+	}
+	run.remaining = topic.Subscribers()
+	topic.Close()
+
+	for v := range live.C() { // Block's failed Publish may have reached it
+		run.live = append(run.live, v)
+	}
+	for v := range stalled.C() {
+		run.stalled = append(run.stalled, v)
+	}
+	run.stalledErr = stalled.Err()
+	run.stats = topic.Stats()
+	return run
+}
+
+func runPubSubExample() {
+	fmt.Println("\n🔸 Typed Topics")
+
+	broker := pubsub.NewBroker()
+	prices, _ := pubsub.OpenTopic[PriceTick](broker, "prices", pubsub.TopicOptions{Buffer: 8})
+	fills, _ := pubsub.OpenTopic[TradeFill](broker, "fills", pubsub.TopicOptions{Buffer: 8})
+
+	// Subscribers receive typed values: no assertion, no "unexpected type" branch
+	dashboard := collect(prices.Subscribe())
+	var (
+		exposure = map[string]float64{}
+		auditWG  sync.WaitGroup
+	)
+	audit := fills.Subscribe()
+	auditWG.Add(1)
+	go func() {
+		defer auditWG.Done()
+		for fill := range audit.C() {
+			exposure[fill.Symbol] += float64(fill.Quantity) * fill.Price
+		}
+	}()
+
+	ctx := context.Background()
+	for _, tick := range []PriceTick{{"GOOG", 171.2}, {"MSFT", 415.9}, {"GOOG", 171.8}} {
+		prices.Publish(ctx, tick)
+	}
+	fills.Publish(ctx, TradeFill{"GOOG", 10, 171.8})
+	fills.Publish(ctx, TradeFill{"MSFT", -5, 415.9})
+	// prices.Publish(ctx, TradeFill{}) would not compile: the topic carries PriceTick
+
+	same, _ := pubsub.OpenTopic[PriceTick](broker, "prices", pubsub.TopicOptions{})
+	_, err := pubsub.OpenTopic[string](broker, "prices", pubsub.TopicOptions{})
+	fmt.Printf("Topics: %v\n", broker.Topics())
+	fmt.Printf("OpenTopic[PriceTick](\"prices\") again: same handle = %t\n", same == prices)
+	fmt.Printf("OpenTopic[string](\"prices\"): %v\n", err)
+
+	broker.Close() // Ends every subscriber's range loop
+	for _, tick := range dashboard() {
+		fmt.Printf("  dashboard: %-4s %7.2f\n", tick.Symbol, tick.Price)
+	}
+	auditWG.Wait()
+	fmt.Printf("  audit exposure: GOOG %.2f, MSFT %.2f\n", exposure["GOOG"], exposure["MSFT"])
+	fmt.Printf("Publish after Close: %v\n", prices.Publish(ctx, PriceTick{"GOOG", 172}))
+
+	fmt.Println("\n🔸 Slow-Subscriber Policies")
+
+	// Each topic buffers 3 values per subscriber; 10 values are published to
+	// one subscriber that keeps up and one that has stalled
+	for _, policy := range []pubsub.Policy{pubsub.Block, pubsub.DropNewest, pubsub.DropOldest, pubsub.Disconnect} {
+		run := runStalled(policy, 3, 10)
+		fmt.Printf("%-10s published %2d, live got %d, stalled got %v", policy, run.published, len(run.live), run.stalled)
+		switch policy {
+		case pubsub.Block:
+			fmt.Printf(" (publish %d: %v)\n", run.published+1, run.publishErr)
+		case pubsub.Disconnect:
+			fmt.Printf(" (%v; %d subscriber left)\n", run.stalledErr, run.remaining)
+		default:
+			fmt.Printf(" (%d dropped)\n", run.stats.Dropped)
+		}
+	}
+	fmt.Println("Block:      the stalled reader stops everyone; bound the wait with ctx")
+	fmt.Println("DropNewest: the reader sees a prefix, e.g. an audit log that must not reorder")
+	fmt.Println("DropOldest: the reader catches up on the latest values, e.g. prices")
+	fmt.Println("Disconnect: the reader finds out (Err) and can resubscribe from a snapshot")
+
+	fmt.Println("\n🔸 Unsubscribe")
+
+	broker = pubsub.NewBroker()
+	defer broker.Close()
+	events, _ := pubsub.OpenTopic[string](broker, "events", pubsub.TopicOptions{Buffer: 4})
+	first, second := events.Subscribe(), events.Subscribe()
+	events.Publish(ctx, "login")
+	events.Publish(ctx, "view")
+	first.Unsubscribe()
+	events.Publish(ctx, "logout")
+	fmt.Printf("Subscribers after Unsubscribe: %d\n", events.Subscribers())
+	events.Close()
+	var firstGot, secondGot []string
+	for e := range first.C() { // Buffered values survive Unsubscribe
+		firstGot = append(firstGot, e)
+	}
+	for e := range second.C() {
+		secondGot = append(secondGot, e)
+	}
+	fmt.Printf("Unsubscribed: %v (Err %v), still subscribed: %v (Err %v)\n", firstGot, first.Err(), secondGot, second.Err())
+	late := events.Subscribe()
+	_, open := <-late.C()
+	fmt.Printf("Subscribe after Close: channel open = %t, Err = %v\n", open, late.Err())
+
+	fmt.Println("\n🔸 Concurrent Publishers")
+
+	counts, _ := pubsub.OpenTopic[int](broker, "counts", pubsub.TopicOptions{Buffer: 16})
+	readers := []func() []int{collect(counts.Subscribe()), collect(counts.Subscribe())}
+	var publishers sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		publishers.Add(1)
+		go func(p int) {
+			defer publishers.Done()
+			for i := 0; i < 250; i++ {
+				counts.Publish(ctx, p*1000+i)
+			}
+		}(p)
+	}
+	publishers.Wait()
+	counts.Close()
+	for i, read := range readers {
+		got := read()
+		fmt.Printf("Reader %d: %d values, per-publisher order kept = %t\n", i+1, len(got), perPublisherOrdered(got))
+	}
+	fmt.Printf("Stats: %+v\n", counts.Stats())
+
+	fmt.Println("\n🔸 Property Checks")
+
+	runs := PairsOf(Ints(1, 6), Ints(0, 15)) // Buffer size, values published
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Block loses nothing when buffers fit", func() string {
+			return ForAll(runs, func(p Pair[int, int]) bool {
+				run := runStalled(pubsub.Block, p.First+p.Second, p.Second)
+				return run.publishErr == nil && slices.Equal(run.stalled, run.live) && len(run.live) == p.Second
+			}).String()
+		}},
+		{"DropNewest keeps the first values", func() string {
+			return ForAll(runs, func(p Pair[int, int]) bool {
+				run := runStalled(pubsub.DropNewest, p.First, p.Second)
+				kept := min(p.First, p.Second)
+				return slices.Equal(run.stalled, run.live[:kept]) && run.stats.Dropped == int64(p.Second-kept)
+			}).String()
+		}},
+		{"DropOldest keeps the last values", func() string {
+			return ForAll(runs, func(p Pair[int, int]) bool {
+				run := runStalled(pubsub.DropOldest, p.First, p.Second)
+				kept := min(p.First, p.Second)
+				return slices.Equal(run.stalled, run.live[p.Second-kept:]) && run.stats.Dropped == int64(p.Second-kept)
+			}).String()
+		}},
+		{"Disconnect cuts off only the slow one", func() string {
+			return ForAll(runs, func(p Pair[int, int]) bool {
+				run := runStalled(pubsub.Disconnect, p.First, p.Second)
+				slow := p.Second > p.First
+				return len(run.live) == p.Second && (run.remaining == 1) == slow &&
+					errors.Is(run.stalledErr, pubsub.ErrSlowSubscriber) == slow
+			}).String()
+		}},
+		{"Received + Dropped = Published × subs", func() string {
+			return ForAll(runs, func(p Pair[int, int]) bool {
+				for _, policy := range []pubsub.Policy{pubsub.DropNewest, pubsub.DropOldest} {
+					run := runStalled(policy, p.First, p.Second)
+					received := int64(len(run.live) + len(run.stalled))
+					if received+run.stats.Dropped != 2*run.stats.Published {
+						return false
+					}
+				}
+				return true
+			}).String()
+		}},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Publish/subscribe examples completed!")
+}
+
+// perPublisherOrdered reports whether the values from each publisher (the
+// thousands digit) arrived in increasing order
+func perPublisherOrdered(values []int) bool {
+	last := map[int]int{}
+	for _, v := range values {
+		p := v / 1000
+		if prev, ok := last[p]; ok && v <= prev {
+			return false
+		}
+		last[p] = v
+	}
+	return true
+}
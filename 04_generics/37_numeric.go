@@ -0,0 +1,304 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"unsafe"
+)
+
+// ==========================================
+// Numeric Utilities
+// ==========================================
+
+// Go converts between integer types by truncating and wraps on overflow,
+// silently: int8(200) is -56 and math.MinInt64 / -1 is math.MinInt64. The
+// helpers here use the Number and IntegerTypes constraints to do the same
+// jobs with the failure made visible.
+
+var (
+	// ErrOverflow is returned when a result does not fit in its type
+	ErrOverflow = errors.New("integer overflow")
+	// ErrDivideByZero is returned by SafeDivide for a zero divisor
+	ErrDivideByZero = errors.New("division by zero")
+)
+
+// Clamp limits v to [lo, hi]. It panics if lo > hi, which is a bug in the
+// caller rather than a value to recover from.
+func Clamp[T Ordered](v, lo, hi T) T {
+	if lo > hi {
+		panic(fmt.Sprintf("Clamp: lo %v > hi %v", lo, hi))
+	}
+	return min(max(v, lo), hi)
+}
+
+// Sign returns -1, 0 or +1. Unsigned values are never negative; NaN gives 0.
+func Sign[T Number](x T) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CheckedAbs is Abs (02_constraints.go) for integers, reporting the one input
+// whose absolute value does not fit: the most negative value of T
+func CheckedAbs[T Signed](x T) (T, error) {
+	a := Abs(x)
+	if a < 0 {
+		return x, fmt.Errorf("%w: |%v| does not fit in %T", ErrOverflow, x, x)
+	}
+	return a, nil
+}
+
+// SafeDivide returns a / b, or an error Result for a zero divisor (including
+// floats, which would otherwise give ±Inf or NaN) and for the most negative
+// signed integer divided by -1
+func SafeDivide[T Number](a, b T) Result[T] {
+	if b == 0 {
+		return Err[T](fmt.Errorf("%w: %v / 0", ErrDivideByZero, a))
+	}
+	q := a / b
+	if a < 0 && b < 0 && q < 0 { // Only MinInt / -1 wraps; floats stay positive
+		return Err[T](fmt.Errorf("%w: %v / %v does not fit in %T", ErrOverflow, a, b, q))
+	}
+	return Ok(q)
+}
+
+// ==========================================
+// Checked Integer Conversions
+// ==========================================
+
+// isSigned reports whether T is a signed integer type
+func isSigned[T IntegerTypes]() bool {
+	var zero T
+	return ^zero < 0 // All ones is -1 when signed, the maximum when not
+}
+
+// MaxOf returns the largest value of the integer type T, like math.MaxInt16
+// for int16, and also for named types such as CustomInt
+func MaxOf[T IntegerTypes]() T {
+	var zero T
+	if !isSigned[T]() {
+		return ^zero
+	}
+	bits := unsafe.Sizeof(zero) * 8
+	return T(1)<<(bits-1) - 1
+}
+
+// MinOf returns the smallest value of the integer type T
+func MinOf[T IntegerTypes]() T {
+	if !isSigned[T]() {
+		return 0
+	}
+	return -MaxOf[T]() - 1
+}
+
+// CheckedConvert converts v to To, or reports ErrOverflow when To cannot
+// hold it. The conversion is exact when converting back gives v and the
+// sign survived; the sign check catches uint64 → int64 style wraps, where
+// the round trip alone succeeds.
+func CheckedConvert[To, From IntegerTypes](v From) (To, error) {
+	to := To(v)
+	if From(to) != v || (v < 0) != (to < 0) {
+		return to, fmt.Errorf("%w: %v does not fit in %T", ErrOverflow, v, to)
+	}
+	return to, nil
+}
+
+// SaturatingConvert converts v to To, clamping values out of range to To's
+// minimum or maximum instead of wrapping
+func SaturatingConvert[To, From IntegerTypes](v From) To {
+	to, err := CheckedConvert[To](v)
+	switch {
+	case err == nil:
+		return to
+	case v < 0:
+		return MinOf[To]()
+	default:
+		return MaxOf[To]()
+	}
+}
+
+// MustConvert is CheckedConvert for values known to fit, such as constants;
+// it panics otherwise
+func MustConvert[To, From IntegerTypes](v From) To {
+	to, err := CheckedConvert[To](v)
+	if err != nil {
+		panic(err)
+	}
+	return to
+}
+
+// ==========================================
+// Example Usage
+// ==========================================
+
+// Port is a TCP port; it is a uint16 on the wire
+type Port uint16
+
+// parsePort converts a port read as int64 (for example from JSON, where
+// numbers decode wide) to the width the protocol uses
+func parsePort(raw int64) (Port, error) {
+	port, err := CheckedConvert[Port](raw)
+	if err != nil {
+		return 0, fmt.Errorf("port %d: %w", raw, err)
+	}
+	return port, nil
+}
+
+// edgeInt64s generates int64 values, half of them at or next to the
+// boundaries of the narrower integer types, where conversions go wrong
+func edgeInt64s() Generator[int64] {
+	edges := []int64{0, 1, -1,
+		math.MaxInt8, math.MinInt8, math.MaxUint8,
+		math.MaxInt16, math.MinInt16, math.MaxUint16,
+		math.MaxInt32, math.MinInt32, math.MaxUint32,
+		math.MaxInt64, math.MinInt64,
+	}
+	return Generator[int64]{
+		Generate: func(r *rand.Rand) int64 {
+			if r.Intn(2) == 0 {
+				return edges[r.Intn(len(edges))] + int64(r.Intn(3)-1)
+			}
+			return int64(r.Uint64())
+		},
+	}
+}
+
+// fitsInRange reports whether v lies in [lo, hi]; hi is a uint64 so that
+// math.MaxUint64 can be a bound, and is only compared with non-negative v
+func fitsInRange(v int64, lo int64, hi uint64) bool {
+	return v >= lo && (v < 0 || uint64(v) <= hi)
+}
+
+func runNumericExample() {
+	fmt.Println("\n🔸 Clamp and Sign")
+
+	volume := func(level int) int { return Clamp(level, 0, 100) }
+	fmt.Printf("volume(-5), volume(42), volume(180): %d, %d, %d\n", volume(-5), volume(42), volume(180))
+	pageSize := func(requested int) int { return Clamp(requested, 1, 50) }
+	fmt.Printf("pageSize(0), pageSize(500): %d, %d\n", pageSize(0), pageSize(500))
+	fmt.Printf("Clamp on strings: %q\n", Clamp("zebra", "apple", "mango"))
+
+	arrows := map[int]string{-1: "↓", 0: "→", 1: "↑"}
+	changes := []float64{2.5, -0.75, 0, -3.1, 0.02}
+	fmt.Print("Price trend:")
+	for _, c := range changes {
+		fmt.Printf(" %s", arrows[Sign(c)])
+	}
+	fmt.Printf("   Sign(NaN) = %d, Sign(uint(7)) = %d\n", Sign(math.NaN()), Sign(uint(7)))
+
+	fmt.Println("\n🔸 Abs and SafeDivide")
+
+	fmt.Printf("Abs(-3), Abs(-2.5), Abs(CustomInt(-9)): %d, %g, %d\n", Abs(-3), Abs(-2.5), Abs(CustomInt(-9)))
+	fmt.Printf("Abs(int8(-128)) = %d, silently\n", Abs(int8(math.MinInt8)))
+	if _, err := CheckedAbs(int8(math.MinInt8)); err != nil {
+		fmt.Printf("CheckedAbs(int8(-128)): %v\n", err)
+	}
+
+	// Requests per second for each server; one has not reported its uptime yet
+	type serverLoad struct {
+		name     string
+		requests int64
+		seconds  int64
+	}
+	for _, s := range []serverLoad{{"api-1", 18000, 60}, {"api-2", 4200, 0}, {"api-3", 9000, 45}} {
+		if rate := SafeDivide(s.requests, s.seconds); rate.IsOk() {
+			fmt.Printf("  %s: %d req/s\n", s.name, rate.Unwrap())
+		} else {
+			fmt.Printf("  %s: %v\n", s.name, rate.Error())
+		}
+	}
+	fmt.Printf("SafeDivide(int64(MinInt64), -1): %v\n", SafeDivide(int64(math.MinInt64), -1).Error())
+	zero := 0.0
+	fmt.Printf("SafeDivide(1.0, 0.0): %v (plain division gives %v)\n", SafeDivide(1.0, zero).Error(), 1.0/zero)
+	avg := SafeDivide(7.0, 2.0).Map(math.Round)
+	fmt.Printf("SafeDivide(7.0, 2.0).Map(math.Round): %v\n", avg.UnwrapOr(0))
+
+	fmt.Println("\n🔸 Checked Conversions")
+
+	fmt.Printf("MinOf/MaxOf int8: %d..%d, uint16: %d..%d, CustomInt max: %d\n",
+		MinOf[int8](), MaxOf[int8](), MinOf[uint16](), MaxOf[uint16](), MaxOf[CustomInt]())
+	for _, raw := range []int64{8080, 70000, -1} {
+		if port, err := parsePort(raw); err != nil {
+			fmt.Printf("  parsePort(%d): %v (a plain conversion gives %d)\n", raw, err, uint16(raw))
+		} else {
+			fmt.Printf("  parsePort(%d): %d\n", raw, port)
+		}
+	}
+	big := uint64(math.MaxUint64)
+	_, err := CheckedConvert[int64](big)
+	fmt.Printf("CheckedConvert[int64](MaxUint64): %v (int64(x) = %d)\n", err, int64(big))
+	fmt.Printf("SaturatingConvert[int8]: 300 → %d, -300 → %d, 42 → %d\n",
+		SaturatingConvert[int8](300), SaturatingConvert[int8](-300), SaturatingConvert[int8](42))
+	fmt.Printf("MustConvert[uint8](200): %d\n", MustConvert[uint8](200))
+
+	fmt.Println("\n🔸 Property Checks")
+
+	ints := PairsOf(Ints(-1000, 1000), Ints(-1000, 1000))
+	properties := []struct {
+		name  string
+		check func() string
+	}{
+		{"Clamp stays in range and is idempotent", func() string {
+			return ForAll(PairsOf(ints, Ints(-1000, 1000)), func(p Pair[Pair[int, int], int]) bool {
+				lo, hi := min(p.First.First, p.First.Second), max(p.First.First, p.First.Second)
+				c := Clamp(p.Second, lo, hi)
+				return lo <= c && c <= hi && Clamp(c, lo, hi) == c && (c == p.Second) == (lo <= p.Second && p.Second <= hi)
+			}).String()
+		}},
+		{"Sign(x) * Abs(x) == x", func() string {
+			return ForAll(Ints(-1000, 1000), func(x int) bool {
+				return Sign(x)*Abs(x) == x
+			}).String()
+		}},
+		{"SafeDivide: q*b + a%b == a", func() string {
+			return ForAll(ints, func(p Pair[int, int]) bool {
+				q := SafeDivide(p.First, p.Second)
+				if p.Second == 0 {
+					return errors.Is(q.Error(), ErrDivideByZero)
+				}
+				return q.Unwrap()*p.Second+p.First%p.Second == p.First
+			}).String()
+		}},
+		{"CheckedConvert ok exactly when in range", func() string {
+			return ForAll(edgeInt64s(), func(v int64) bool {
+				_, err8 := CheckedConvert[int8](v)
+				_, errU16 := CheckedConvert[uint16](v)
+				_, err32 := CheckedConvert[int32](v)
+				_, errU64 := CheckedConvert[uint64](v)
+				return (err8 == nil) == fitsInRange(v, math.MinInt8, math.MaxInt8) &&
+					(errU16 == nil) == fitsInRange(v, 0, math.MaxUint16) &&
+					(err32 == nil) == fitsInRange(v, math.MinInt32, math.MaxInt32) &&
+					(errU64 == nil) == fitsInRange(v, 0, math.MaxUint64)
+			}).String()
+		}},
+		{"Converting back gives the input", func() string {
+			return ForAll(edgeInt64s(), func(v int64) bool {
+				if to, err := CheckedConvert[int16](v); err == nil && int64(to) != v {
+					return false
+				}
+				if to, err := CheckedConvert[uint32](v); err == nil && int64(to) != v {
+					return false
+				}
+				return true
+			}).String()
+		}},
+		{"SaturatingConvert == Clamp to the range", func() string {
+			return ForAll(edgeInt64s(), func(v int64) bool {
+				return int64(SaturatingConvert[int16](v)) == Clamp(v, math.MinInt16, math.MaxInt16) &&
+					int64(SaturatingConvert[uint8](v)) == Clamp(v, 0, math.MaxUint8)
+			}).String()
+		}},
+	}
+	for _, p := range properties {
+		fmt.Printf("%-40s %s\n", p.name+":", p.check())
+	}
+
+	fmt.Println("\n✅ Numeric utility examples completed!")
+}
@@ -0,0 +1,736 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ==========================================
+// Generators for Any Number Type
+// ==========================================
+
+// Numbers generates values of any Number type in [lo, hi] and shrinks them
+// towards zero, or towards the bound nearest zero when zero is out of range.
+// Ints (10_property_testing.go) is the int-only original.
+func Numbers[T Number](lo, hi T) Generator[T] {
+	integral := T(1)/T(2) == 0
+	target := Clamp(0, lo, hi)
+	return Generator[T]{
+		Generate: func(r *rand.Rand) T {
+			if !integral {
+				return lo + T(r.Float64()*float64(hi-lo))
+			}
+			// Differences of the uint64 images are exact for every integer
+			// type, signed or not, so the full range of int64 works too
+			span := uint64(hi) - uint64(lo)
+			if span == math.MaxUint64 {
+				return T(r.Uint64())
+			}
+			return T(uint64(lo) + r.Uint64()%(span+1))
+		},
+		Shrink: func(v T) []T {
+			var candidates []T
+			add := func(c T) {
+				if c != v && lo <= c && c <= hi && !slices.Contains(candidates, c) {
+					candidates = append(candidates, c)
+				}
+			}
+			add(target)
+			if !integral {
+				add(T(math.Trunc(float64(v))))
+				if math.Abs(float64(v)-float64(target)) < 1 {
+					// Halving forever would end in values like 0.5000000000000001
+					return candidates
+				}
+			}
+			add(target + (v-target)/2)
+			switch {
+			case v > target:
+				add(v - 1)
+			case v < target:
+				add(v + 1)
+			}
+			return candidates
+		},
+	}
+}
+
+// ==========================================
+// Generators Derived by Reflection
+// ==========================================
+
+// quickLimits bound the values Arbitrary generates. A struct field can set
+// its own with a tag such as `quick:"min=1,max=5,maxlen=3"`; min and max
+// apply to numbers (also inside slices and maps), maxlen to strings, slices
+// and maps.
+type quickLimits struct {
+	min, max float64
+	maxLen   int
+}
+
+var defaultQuickLimits = quickLimits{min: -1000, max: 1000, maxLen: 10}
+
+// quickAlphabet is what generated strings are made of
+const quickAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// quickGen is a Generator over reflect.Value, built once per type
+type quickGen struct {
+	generate func(r *rand.Rand) reflect.Value
+	shrink   func(v reflect.Value) []reflect.Value
+}
+
+// Arbitrary builds a Generator for T by walking its type with reflection:
+// numbers, bools, strings, slices, arrays, maps, pointers and structs of
+// those. Unexported struct fields are left at their zero value, since
+// reflection cannot set them. It panics for types it cannot generate
+// (functions, channels, interfaces, recursive types), which is a mistake in
+// the property rather than a failure of it.
+func Arbitrary[T any]() Generator[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	g := buildQuickGen(t, defaultQuickLimits, map[reflect.Type]bool{})
+	return Generator[T]{
+		Generate: func(r *rand.Rand) T {
+			return g.generate(r).Interface().(T)
+		},
+		Shrink: func(v T) []T {
+			shrunk := g.shrink(reflect.ValueOf(&v).Elem())
+			candidates := make([]T, len(shrunk))
+			for i, c := range shrunk {
+				candidates[i] = c.Interface().(T)
+			}
+			return candidates
+		},
+	}
+}
+
+// Quick checks prop against arbitrary values of T, using DefaultPropertyConfig
+func Quick[T any](prop func(T) bool) PropertyResult[T] {
+	return ForAll(Arbitrary[T](), prop)
+}
+
+// QuickWith is Quick with an explicit configuration. With Seed 0 a seed is
+// picked from the clock; the result reports it, so a failure found that way
+// can be replayed.
+func QuickWith[T any](cfg PropertyConfig, prop func(T) bool) PropertyResult[T] {
+	return ForAllWith(cfg, Arbitrary[T](), prop)
+}
+
+func buildQuickGen(t reflect.Type, lim quickLimits, building map[reflect.Type]bool) quickGen {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return quickInts(t, lim)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return quickUints(t, lim)
+	case reflect.Float32, reflect.Float64:
+		return quickFloats(t, lim)
+	case reflect.Bool:
+		return quickGen{
+			generate: func(r *rand.Rand) reflect.Value {
+				return reflect.ValueOf(r.Intn(2) == 1).Convert(t)
+			},
+			shrink: func(v reflect.Value) []reflect.Value {
+				if v.Bool() {
+					return []reflect.Value{reflect.Zero(t)}
+				}
+				return nil
+			},
+		}
+	case reflect.String:
+		return quickStrings(t, lim)
+	}
+
+	if building[t] {
+		panic(fmt.Sprintf("Arbitrary: recursive type %v is not supported", t))
+	}
+	building[t] = true
+	defer delete(building, t)
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return quickSlices(t, buildQuickGen(t.Elem(), lim, building), lim)
+	case reflect.Array:
+		return quickArrays(t, buildQuickGen(t.Elem(), lim, building))
+	case reflect.Map:
+		return quickMaps(t, buildQuickGen(t.Key(), lim, building), buildQuickGen(t.Elem(), lim, building), lim)
+	case reflect.Pointer:
+		return quickPointers(t, buildQuickGen(t.Elem(), lim, building))
+	case reflect.Struct:
+		return quickStructs(t, building)
+	}
+	panic(fmt.Sprintf("Arbitrary: cannot generate values of type %v", t))
+}
+
+func quickInts(t reflect.Type, lim quickLimits) quickGen {
+	kindMax := int64(1)<<(t.Bits()-1) - 1
+	lo, hi := -kindMax-1, kindMax
+	if lim.min > float64(lo) {
+		lo = int64(math.Ceil(lim.min))
+	}
+	if lim.max < float64(hi) {
+		hi = int64(math.Floor(lim.max))
+	}
+	if lo > hi {
+		panic(fmt.Sprintf("Arbitrary: range [%v, %v] is empty for %v", lim.min, lim.max, t))
+	}
+	gen := Numbers(lo, hi)
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			v := reflect.New(t).Elem()
+			v.SetInt(gen.Generate(r))
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var candidates []reflect.Value
+			for _, c := range gen.Shrink(v.Int()) {
+				s := reflect.New(t).Elem()
+				s.SetInt(c)
+				candidates = append(candidates, s)
+			}
+			return candidates
+		},
+	}
+}
+
+func quickUints(t reflect.Type, lim quickLimits) quickGen {
+	kindMax := uint64(1)<<(t.Bits()-1)<<1 - 1 // Two shifts, so 64 bits gives MaxUint64
+	lo, hi := uint64(0), kindMax
+	if lim.min > 0 {
+		lo = uint64(math.Ceil(lim.min))
+	}
+	if lim.max < float64(hi) {
+		hi = uint64(math.Floor(max(lim.max, 0)))
+	}
+	if lo > hi || lim.max < 0 {
+		panic(fmt.Sprintf("Arbitrary: range [%v, %v] is empty for %v", lim.min, lim.max, t))
+	}
+	gen := Numbers(lo, hi)
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			v := reflect.New(t).Elem()
+			v.SetUint(gen.Generate(r))
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var candidates []reflect.Value
+			for _, c := range gen.Shrink(v.Uint()) {
+				s := reflect.New(t).Elem()
+				s.SetUint(c)
+				candidates = append(candidates, s)
+			}
+			return candidates
+		},
+	}
+}
+
+func quickFloats(t reflect.Type, lim quickLimits) quickGen {
+	gen := Numbers(lim.min, lim.max)
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			v := reflect.New(t).Elem()
+			v.SetFloat(gen.Generate(r))
+			return v
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var candidates []reflect.Value
+			for _, c := range gen.Shrink(v.Float()) {
+				s := reflect.New(t).Elem()
+				s.SetFloat(c)
+				if s.Float() != v.Float() { // A float32 may round back to v
+					candidates = append(candidates, s)
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+func quickStrings(t reflect.Type, lim quickLimits) quickGen {
+	gen := Strings(lim.maxLen, quickAlphabet)
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			return reflect.ValueOf(gen.Generate(r)).Convert(t)
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var candidates []reflect.Value
+			for _, c := range gen.Shrink(v.String()) {
+				candidates = append(candidates, reflect.ValueOf(c).Convert(t))
+			}
+			return candidates
+		},
+	}
+}
+
+func quickSlices(t reflect.Type, elem quickGen, lim quickLimits) quickGen {
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			n := r.Intn(lim.maxLen + 1)
+			s := reflect.MakeSlice(t, n, n)
+			for i := 0; i < n; i++ {
+				s.Index(i).Set(elem.generate(r))
+			}
+			return s
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			items := make([]reflect.Value, v.Len())
+			for i := range items {
+				items[i] = v.Index(i)
+			}
+			var candidates []reflect.Value
+			for _, c := range ShrinkSlice(items, elem.shrink) {
+				s := reflect.MakeSlice(t, len(c), len(c))
+				for i, item := range c {
+					s.Index(i).Set(item)
+				}
+				candidates = append(candidates, s)
+			}
+			return candidates
+		},
+	}
+}
+
+func quickArrays(t reflect.Type, elem quickGen) quickGen {
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			a := reflect.New(t).Elem()
+			for i := 0; i < t.Len(); i++ {
+				a.Index(i).Set(elem.generate(r))
+			}
+			return a
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var candidates []reflect.Value
+			for i := 0; i < t.Len(); i++ {
+				for _, smaller := range elem.shrink(v.Index(i)) {
+					a := reflect.New(t).Elem()
+					a.Set(v)
+					a.Index(i).Set(smaller)
+					candidates = append(candidates, a)
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+func quickMaps(t reflect.Type, key, elem quickGen, lim quickLimits) quickGen {
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			m := reflect.MakeMap(t)
+			for n := r.Intn(lim.maxLen + 1); n > 0; n-- {
+				m.SetMapIndex(key.generate(r), elem.generate(r)) // Repeated keys make some maps smaller
+			}
+			return m
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			// Map order is random; sorting the keys keeps shrinking reproducible
+			keys := v.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+			copyWithout := func(skip reflect.Value) reflect.Value {
+				m := reflect.MakeMapWithSize(t, v.Len())
+				for _, k := range keys {
+					if !skip.IsValid() || k.Interface() != skip.Interface() {
+						m.SetMapIndex(k, v.MapIndex(k))
+					}
+				}
+				return m
+			}
+			var candidates []reflect.Value
+			for _, k := range keys {
+				candidates = append(candidates, copyWithout(k))
+			}
+			for _, k := range keys {
+				for _, smaller := range elem.shrink(v.MapIndex(k)) {
+					m := copyWithout(reflect.Value{})
+					m.SetMapIndex(k, smaller)
+					candidates = append(candidates, m)
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+func quickPointers(t reflect.Type, elem quickGen) quickGen {
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			if r.Intn(5) == 0 {
+				return reflect.Zero(t)
+			}
+			p := reflect.New(t.Elem())
+			p.Elem().Set(elem.generate(r))
+			return p
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			if v.IsNil() {
+				return nil
+			}
+			candidates := []reflect.Value{reflect.Zero(t)}
+			for _, smaller := range elem.shrink(v.Elem()) {
+				p := reflect.New(t.Elem())
+				p.Elem().Set(smaller)
+				candidates = append(candidates, p)
+			}
+			return candidates
+		},
+	}
+}
+
+func quickStructs(t reflect.Type, building map[reflect.Type]bool) quickGen {
+	fields := make([]*quickGen, t.NumField())
+	for i := range fields {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		lim, err := parseQuickTag(f.Tag.Get("quick"), defaultQuickLimits)
+		if err != nil {
+			panic(fmt.Sprintf("Arbitrary: %v.%s: %v", t, f.Name, err))
+		}
+		g := buildQuickGen(f.Type, lim, building)
+		fields[i] = &g
+	}
+	return quickGen{
+		generate: func(r *rand.Rand) reflect.Value {
+			s := reflect.New(t).Elem()
+			for i, g := range fields {
+				if g != nil {
+					s.Field(i).Set(g.generate(r))
+				}
+			}
+			return s
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var candidates []reflect.Value
+			for i, g := range fields {
+				if g == nil {
+					continue
+				}
+				for _, smaller := range g.shrink(v.Field(i)) {
+					s := reflect.New(t).Elem()
+					s.Set(v)
+					s.Field(i).Set(smaller)
+					candidates = append(candidates, s)
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+// parseQuickTag applies a `quick:"..."` tag to the default limits
+func parseQuickTag(tag string, lim quickLimits) (quickLimits, error) {
+	if tag == "" {
+		return lim, nil
+	}
+	for _, option := range strings.Split(tag, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(option), "=")
+		if !ok {
+			return lim, fmt.Errorf("bad quick tag option %q", option)
+		}
+		var err error
+		switch name {
+		case "min":
+			lim.min, err = strconv.ParseFloat(value, 64)
+		case "max":
+			lim.max, err = strconv.ParseFloat(value, 64)
+		case "maxlen":
+			lim.maxLen, err = strconv.Atoi(value)
+		default:
+			err = fmt.Errorf("unknown quick tag option %q", name)
+		}
+		if err != nil {
+			return lim, err
+		}
+	}
+	if lim.min > lim.max || lim.maxLen < 0 {
+		return lim, fmt.Errorf("empty range in quick tag %q", tag)
+	}
+	return lim, nil
+}
+
+// ==========================================
+// Example Usage
+// ==========================================
+
+// Shipment is generated field by field; the tags keep values realistic
+type Shipment struct {
+	ID       string   `quick:"maxlen=4"`
+	WeightKg float64  `quick:"min=0.5,max=40"`
+	Priority int      `quick:"min=1,max=3"`
+	Labels   []string `quick:"maxlen=2"`
+	Express  bool
+	notes    string // Unexported: always ""
+}
+
+// MapOp is one step of a random workload for the map-like containers
+type MapOp struct {
+	Kind  int `quick:"min=0,max=2"` // 0 put, 1 delete, 2 get
+	Key   int `quick:"min=0,max=15"`
+	Value int `quick:"min=0,max=99"`
+}
+
+// Workload wraps a sequence of operations so its tag can allow longer
+// sequences than the default
+type Workload struct {
+	Ops []MapOp `quick:"maxlen=40"`
+}
+
+// sortedMapMatchesModel runs w against SortedMap and a Go map side by side
+func sortedMapMatchesModel(w Workload) bool {
+	sm, model := NewSortedMap[int, int](), map[int]int{}
+	for _, op := range w.Ops {
+		switch op.Kind {
+		case 0:
+			sm.Put(op.Key, op.Value)
+			model[op.Key] = op.Value
+		case 1:
+			_, existed := model[op.Key]
+			delete(model, op.Key)
+			if sm.Delete(op.Key) != existed {
+				return false
+			}
+		default:
+			got, ok := sm.Get(op.Key)
+			want, wantOK := model[op.Key]
+			if got != want || ok != wantOK {
+				return false
+			}
+		}
+	}
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return slices.Equal(sm.Keys(), keys) && sm.Len() == len(model)
+}
+
+// persistentMapKeepsVersions checks every version of a PersistentMap
+// against a snapshot of the model taken when it was current
+func persistentMapKeepsVersions(w Workload) bool {
+	var pm PersistentMap[int, int]
+	versions := []PersistentMap[int, int]{pm}
+	snapshots := []map[int]int{{}}
+	model := map[int]int{}
+	for _, op := range w.Ops {
+		switch op.Kind {
+		case 0:
+			pm = pm.Put(op.Key, op.Value)
+			model[op.Key] = op.Value
+		case 1:
+			pm = pm.Delete(op.Key)
+			delete(model, op.Key)
+		default:
+			continue
+		}
+		versions = append(versions, pm)
+		snapshot := make(map[int]int, len(model))
+		for k, v := range model {
+			snapshot[k] = v
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	for i, version := range versions {
+		got := map[int]int{}
+		version.ForEach(func(k, v int) { got[k] = v })
+		if !reflect.DeepEqual(got, snapshots[i]) || version.Len() != len(snapshots[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// rbTreeKeepsInvariants uses the keys as a set and checks the red-black
+// invariants after every operation
+func rbTreeKeepsInvariants(w Workload) bool {
+	tree, model := NewRBTree[int](), map[int]bool{}
+	for _, op := range w.Ops {
+		switch op.Kind {
+		case 0:
+			if tree.Insert(op.Key) == model[op.Key] {
+				return false
+			}
+			model[op.Key] = true
+		case 1:
+			if tree.Delete(op.Key) != model[op.Key] {
+				return false
+			}
+			delete(model, op.Key)
+		default:
+			if tree.Search(op.Key) != model[op.Key] {
+				return false
+			}
+		}
+		if tree.CheckInvariants() != nil {
+			return false
+		}
+	}
+	return tree.Size() == len(model)
+}
+
+// orderedMapKeepsInsertionOrder checks OrderedMap's key order against a
+// slice of keys in first-insertion order
+func orderedMapKeepsInsertionOrder(w Workload) bool {
+	om, order := NewOrderedMap[int, int](), []int{}
+	for _, op := range w.Ops {
+		switch op.Kind {
+		case 0:
+			if !slices.Contains(order, op.Key) {
+				order = append(order, op.Key)
+			}
+			om.Set(op.Key, op.Value)
+		case 1:
+			if i := slices.Index(order, op.Key); i >= 0 {
+				order = slices.Delete(order, i, i+1)
+			}
+			om.Delete(op.Key)
+		}
+	}
+	return slices.Equal(om.Keys(), order)
+}
+
+// heapPopsMinimum treats puts as Push and deletes as Pop, comparing each
+// popped value with the minimum of a plain slice
+func heapPopsMinimum(w Workload) bool {
+	h, model := NewHeap(CmpOrdered[int]), []int{}
+	for _, op := range w.Ops {
+		switch op.Kind {
+		case 0:
+			h.Push(op.Key)
+			model = append(model, op.Key)
+		case 1:
+			got, ok := h.Pop()
+			if ok != (len(model) > 0) {
+				return false
+			}
+			if ok {
+				i := slices.Index(model, slices.Min(model))
+				if got != model[i] {
+					return false
+				}
+				model = slices.Delete(model, i, i+1)
+			}
+		}
+		if h.Len() != len(model) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortsCorrectly reports whether sorter orders items by cmp without
+// losing or inventing any
+func sortsCorrectly[T any](items []T, cmp Cmp[T], sorter func([]T)) bool {
+	sorted := slices.Clone(items)
+	sorter(sorted)
+	if !slices.IsSortedFunc(sorted, cmp) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[fmt.Sprint(item)]++
+	}
+	for _, item := range sorted {
+		counts[fmt.Sprint(item)]--
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sortsStably reports whether sorter agrees with slices.SortStableFunc
+func sortsStably[T any](items []T, cmp Cmp[T], sorter func([]T)) bool {
+	got, want := slices.Clone(items), slices.Clone(items)
+	sorter(got)
+	slices.SortStableFunc(want, cmp)
+	return reflect.DeepEqual(got, want)
+}
+
+func runQuickExample() {
+	fmt.Println("\n🔸 Number Generators for Any Type")
+
+	r := rand.New(rand.NewSource(3))
+	sample := func(n int, gen func() string) string {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = gen()
+		}
+		return strings.Join(values, " ")
+	}
+	i8, u16, f := Numbers[int8](-128, 127), Numbers[uint16](1000, 2000), Numbers(-1.0, 1.0)
+	fmt.Printf("Numbers[int8](-128, 127):     %s\n", sample(5, func() string { return fmt.Sprint(i8.Generate(r)) }))
+	fmt.Printf("Numbers[uint16](1000, 2000):  %s\n", sample(5, func() string { return fmt.Sprint(u16.Generate(r)) }))
+	fmt.Printf("Numbers(-1.0, 1.0):           %s\n", sample(5, func() string { return fmt.Sprintf("%.3f", f.Generate(r)) }))
+	fmt.Printf("Shrink int8 -100: %v, uint16 1800: %v, float 0.75: %v\n", i8.Shrink(-100), u16.Shrink(1800), f.Shrink(0.75))
+
+	fmt.Println("\n🔸 Arbitrary Values by Reflection")
+
+	shipments := Arbitrary[Shipment]()
+	for i := 0; i < 3; i++ {
+		fmt.Printf("  %+v\n", shipments.Generate(r))
+	}
+	fmt.Printf("  map[string][2]uint8: %v\n", Arbitrary[map[string][2]uint8]().Generate(r))
+	fmt.Printf("  Shrinking one shipment gives %d candidates, e.g. %+v\n",
+		len(shipments.Shrink(Shipment{ID: "ab", WeightKg: 12, Priority: 3})),
+		shipments.Shrink(Shipment{ID: "ab", WeightKg: 12, Priority: 3})[0])
+
+	fmt.Println("\n🔸 Sorting Algorithms")
+
+	byPriority := CmpBy(func(s Shipment) int { return s.Priority })
+	sorters := append([]namedSorter[Shipment]{
+		{"InsertionSort", func(s []Shipment) { InsertionSort(s, byPriority) }},
+	}, sortersFor(byPriority)...)
+	for _, s := range sorters {
+		result := Quick(func(items []Shipment) bool { return sortsCorrectly(items, byPriority, s.sort) })
+		fmt.Printf("  %-18s sorts []Shipment: %s\n", s.name, result)
+	}
+	for _, s := range sorters[:2] { // InsertionSort and MergeSort promise stability
+		result := Quick(func(items []Shipment) bool { return sortsStably(items, byPriority, s.sort) })
+		fmt.Printf("  %-18s is stable:        %s\n", s.name, result)
+	}
+	heap := sorters[2]
+	heapStable := Quick(func(items []Shipment) bool { return sortsStably(items, byPriority, heap.sort) })
+	fmt.Printf("  %s is stable? %v\n", heap.name, heapStable.Passed)
+	fmt.Printf("    shrunk in %d steps to %+v\n", heapStable.ShrinkSteps, heapStable.Shrunk)
+
+	fmt.Println("\n🔸 Containers Against Simple Models")
+
+	containers := []struct {
+		name string
+		prop func(Workload) bool
+	}{
+		{"SortedMap matches a map", sortedMapMatchesModel},
+		{"PersistentMap keeps old versions", persistentMapKeepsVersions},
+		{"RBTree keeps its invariants", rbTreeKeepsInvariants},
+		{"OrderedMap keeps insertion order", orderedMapKeepsInsertionOrder},
+		{"Heap pops the minimum", heapPopsMinimum},
+	}
+	for _, c := range containers {
+		fmt.Printf("  %-34s %s\n", c.name+":", Quick(c.prop))
+	}
+
+	fmt.Println("\n🔸 Shrinking and Seeds")
+
+	// A wrong belief about the data, found and shrunk to a readable case
+	expressIsLight := func(s Shipment) bool { return !s.Express || s.WeightKg < 30 }
+	cfg := PropertyConfig{Runs: 200, Seed: 0, MaxShrinkSteps: 500} // Seed 0: pick one from the clock
+	result := QuickWith(cfg, expressIsLight)
+	fmt.Printf("Express shipments weigh under 30kg: passed = %v (seed %d, varies per run)\n", result.Passed, result.Seed)
+	fmt.Printf("  shrunk to %+v\n", result.Shrunk)
+
+	cfg.Seed = result.Seed
+	replay := QuickWith(cfg, expressIsLight)
+	fmt.Printf("  replaying the reported seed finds the same case: %v\n",
+		reflect.DeepEqual(replay.Counterexample, result.Counterexample))
+
+	fmt.Println("\n✅ Quick property testing examples completed!")
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// Benchmarks for the generic vs interface comparisons that 08_best_practices.go
+// and 38_matrix.go time once with time.Since. Run them with:
+//
+//	go test -bench=. -benchmem ./04_generics
+//
+// or pick one family, e.g. -bench=MatMul.
+
+var (
+	benchInts       = benchIntData(10000)
+	benchInterfaces = func() []interface{} {
+		values := make([]interface{}, len(benchInts))
+		for i, n := range benchInts {
+			values[i] = n
+		}
+		return values
+	}()
+
+	// Sinks keep results alive so the compiler cannot drop the work
+	benchIntSink    int
+	benchFloatSink  float64
+	benchMatrixSink Matrix[float64]
+)
+
+func benchIntData(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, n)
+	for i := range data {
+		data[i] = r.Intn(1_000_000)
+	}
+	return data
+}
+
+// BenchmarkSum: a constrained type parameter compiles to plain integer adds,
+// while []interface{} pays for boxing and a type switch per element
+func BenchmarkSum(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			benchIntSink = genericSum(benchInts)
+		}
+	})
+	b.Run("interface", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			benchFloatSink = interfaceSum(benchInterfaces)
+		}
+	})
+}
+
+// BenchmarkSort: slices.Sort is generic; sort.Sort calls Less and Swap
+// through the sort.Interface method table
+func BenchmarkSort(b *testing.B) {
+	run := func(name string, sortFn func([]int)) {
+		b.Run(name, func(b *testing.B) {
+			data := make([]int, len(benchInts))
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				copy(data, benchInts)
+				b.StartTimer()
+				sortFn(data)
+			}
+		})
+	}
+	run("slices.Sort", func(s []int) { slices.Sort(s) })
+	run("generic-MergeSort", func(s []int) { MergeSort(s, CmpOrdered[int]) })
+	run("sort.Sort-interface", func(s []int) { sort.Sort(sort.IntSlice(s)) })
+}
+
+// BenchmarkMatMul compares the same product three ways: Matrix[float64],
+// the Scalar interface and a hand-written float64 loop, plus ParallelMul
+func BenchmarkMatMul(b *testing.B) {
+	const size = 96
+	r := rand.New(rand.NewSource(1))
+	unit := func(r *rand.Rand) float64 { return r.Float64() }
+	m, n := randomMatrix(r, size, size, unit), randomMatrix(r, size, size, unit)
+
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchMatrixSink, _ = m.Mul(n)
+		}
+	})
+	b.Run("interface", func(b *testing.B) {
+		sm, snt := toScalarMatrix(m), toScalarMatrix(n.Transpose())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			mulScalarMatrices(sm, snt)
+		}
+	})
+	b.Run("hand-written", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchMatrixSink = mulFloat64(m, n)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchMatrixSink, _ = m.ParallelMul(n, 0)
+		}
+	})
+}
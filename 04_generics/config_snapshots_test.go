@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestConfigWatcherMayUpdate checks that watchers run after the writer lock
+// is released: one that reacts to a change by updating again must not
+// deadlock
+func TestConfigWatcherMayUpdate(t *testing.T) {
+	config := NewConfig(defaultServiceSettings(), ConfigOptions[ServiceSettings]{Clone: ServiceSettings.Clone})
+	config.Watch(func(old, new *Snapshot[ServiceSettings]) {
+		// Keep MaxConns at least twice MinConns
+		if new.Value.MaxConns < 2*new.Value.MinConns {
+			config.Update("widen pool", func(s *ServiceSettings) { s.MaxConns = 2 * s.MinConns })
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		config.Update("raise min conns", func(s *ServiceSettings) { s.MinConns = 8 })
+		config.Rollback(1)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Update from a watcher deadlocked")
+	}
+
+	var notes []string
+	for _, s := range config.History() {
+		notes = append(notes, s.Note)
+	}
+	want := []string{"initial", "raise min conns", "widen pool", "rollback to v1"}
+	if len(notes) != len(want) {
+		t.Fatalf("history = %q, want %q", notes, want)
+	}
+	for i := range want {
+		if notes[i] != want[i] {
+			t.Fatalf("history = %q, want %q", notes, want)
+		}
+	}
+	if got := config.Load().Value.MaxConns; got != 10 {
+		t.Errorf("MaxConns after rollback = %d, want 10", got)
+	}
+}
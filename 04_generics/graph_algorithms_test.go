@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testEdge is a weighted edge for building test graphs
+type testEdge struct {
+	from, to string
+	weight   float64
+}
+
+func buildGraph(undirected bool, vertices []string, edges []testEdge) *Graph[string] {
+	g := NewGraph[string]()
+	if undirected {
+		g = NewUndirectedGraph[string]()
+	}
+	for _, v := range vertices {
+		g.AddVertex(v)
+	}
+	for _, e := range edges {
+		g.AddWeightedEdge(e.from, e.to, e.weight)
+	}
+	return g
+}
+
+func TestShortestPath(t *testing.T) {
+	diamond := []testEdge{{"a", "b", 1}, {"a", "c", 4}, {"b", "c", 1}, {"c", "d", 1}, {"b", "d", 5}}
+	tests := []struct {
+		name       string
+		undirected bool
+		vertices   []string
+		edges      []testEdge
+		from, to   string
+		wantPath   []string
+		wantDist   float64
+		wantErr    error
+	}{
+		{"cheaper path with more hops", false, nil, diamond, "a", "d", []string{"a", "b", "c", "d"}, 3, nil},
+		{"source is target", false, nil, diamond, "b", "b", []string{"b"}, 0, nil},
+		{"zero-weight edges", false, nil, []testEdge{{"a", "b", 0}, {"b", "c", 0}, {"a", "c", 1}}, "a", "c", []string{"a", "b", "c"}, 0, nil},
+		{"self-loop is ignored", false, nil, []testEdge{{"a", "a", 1}, {"a", "b", 2}}, "a", "b", []string{"a", "b"}, 2, nil},
+		{"edges only point away", false, nil, diamond, "d", "a", nil, 0, ErrNoPath},
+		{"unreachable target", false, []string{"z"}, diamond, "a", "z", nil, 0, ErrNoPath},
+		{"source not in graph", false, nil, diamond, "x", "a", nil, 0, ErrNoPath},
+		{"target not in graph", false, nil, diamond, "a", "x", nil, 0, ErrNoPath},
+		{"undirected walks back", true, nil, diamond, "d", "a", []string{"d", "c", "b", "a"}, 3, nil},
+		{"negative weight", false, nil, []testEdge{{"a", "b", 1}, {"b", "c", -1}}, "a", "b", nil, 0, ErrNegativeWeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildGraph(tt.undirected, tt.vertices, tt.edges)
+			path, dist, err := g.ShortestPath(tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(path, tt.wantPath) || dist != tt.wantDist {
+				t.Errorf("ShortestPath(%s, %s) = %v, %v; want %v, %v", tt.from, tt.to, path, dist, tt.wantPath, tt.wantDist)
+			}
+		})
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	tests := []struct {
+		name       string
+		undirected bool
+		vertices   []string
+		edges      []testEdge
+		want       []string
+		wantCycle  []string // Set when a *CycleError is expected
+		wantErr    error
+	}{
+		{"empty", false, nil, nil, nil, nil, nil},
+		{"isolated vertices keep insertion order", false, []string{"c", "a", "b"}, nil, []string{"c", "a", "b"}, nil, nil},
+		{"chain added backwards", false, nil, []testEdge{{"b", "c", 1}, {"a", "b", 1}}, []string{"a", "b", "c"}, nil, nil},
+		{"diamond", false, nil, []testEdge{{"a", "b", 1}, {"a", "c", 1}, {"b", "d", 1}, {"c", "d", 1}}, []string{"a", "b", "c", "d"}, nil, nil},
+		{"self-loop", false, nil, []testEdge{{"a", "b", 1}, {"b", "b", 1}}, nil, []string{"b", "b"}, nil},
+		{"two-vertex cycle", false, nil, []testEdge{{"a", "b", 1}, {"b", "a", 1}}, nil, []string{"a", "b", "a"}, nil},
+		{"cycle behind a DAG prefix", false, nil, []testEdge{{"s", "a", 1}, {"a", "b", 1}, {"b", "c", 1}, {"c", "a", 1}}, nil, []string{"a", "b", "c", "a"}, nil},
+		{"undirected", true, nil, []testEdge{{"a", "b", 1}}, nil, nil, ErrUndirected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildGraph(tt.undirected, tt.vertices, tt.edges)
+			order, err := g.TopologicalSort()
+			if tt.wantCycle != nil {
+				var ce *CycleError[string]
+				if !errors.As(err, &ce) {
+					t.Fatalf("error = %v, want a *CycleError", err)
+				}
+				if !reflect.DeepEqual(ce.Cycle, tt.wantCycle) {
+					t.Errorf("cycle = %v, want %v", ce.Cycle, tt.wantCycle)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(order, tt.want) {
+				t.Errorf("order = %v, want %v", order, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		undirected bool
+		edges      []testEdge
+		want       []string // nil when there is no cycle
+	}{
+		{"directed chain", false, []testEdge{{"a", "b", 1}, {"b", "c", 1}}, nil},
+		{"directed diamond is not a cycle", false, []testEdge{{"a", "b", 1}, {"a", "c", 1}, {"b", "d", 1}, {"c", "d", 1}}, nil},
+		{"directed self-loop", false, []testEdge{{"a", "a", 1}}, []string{"a", "a"}},
+		{"directed triangle", false, []testEdge{{"a", "b", 1}, {"b", "c", 1}, {"c", "a", 1}}, []string{"a", "b", "c", "a"}},
+		{"undirected edge is not a cycle", true, []testEdge{{"a", "b", 1}}, nil},
+		{"undirected tree", true, []testEdge{{"a", "b", 1}, {"a", "c", 1}, {"c", "d", 1}}, nil},
+		{"undirected self-loop", true, []testEdge{{"a", "b", 1}, {"b", "b", 1}}, []string{"b", "b"}},
+		{"undirected triangle", true, []testEdge{{"a", "b", 1}, {"b", "c", 1}, {"c", "a", 1}}, []string{"a", "b", "c", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildGraph(tt.undirected, nil, tt.edges)
+			cycle, found := g.FindCycle()
+			if found != (tt.want != nil) || !reflect.DeepEqual(cycle, tt.want) {
+				t.Errorf("FindCycle() = %v, %t; want %v", cycle, found, tt.want)
+			}
+			for i := 1; i < len(cycle); i++ {
+				if _, ok := g.Weight(cycle[i-1], cycle[i]); !ok {
+					t.Errorf("cycle step %s -> %s is not an edge", cycle[i-1], cycle[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		undirected bool
+		vertices   []string
+		edges      []testEdge
+		want       [][]string
+	}{
+		{"empty", false, nil, nil, nil},
+		{"single vertex", false, []string{"a"}, nil, [][]string{{"a"}}},
+		{"single vertex with self-loop", false, nil, []testEdge{{"a", "a", 1}}, [][]string{{"a"}}},
+		{"chain is all singletons", false, nil, []testEdge{{"a", "b", 1}, {"b", "c", 1}}, [][]string{{"c"}, {"b"}, {"a"}}},
+		{"cycle plus tail", false, nil, []testEdge{{"a", "b", 1}, {"b", "a", 1}, {"b", "c", 1}}, [][]string{{"c"}, {"a", "b"}}},
+		{"two cycles joined one way", false, nil,
+			[]testEdge{{"a", "b", 1}, {"b", "a", 1}, {"b", "c", 1}, {"c", "d", 1}, {"d", "c", 1}},
+			[][]string{{"c", "d"}, {"a", "b"}}},
+		{"undirected components", true, []string{"z"}, []testEdge{{"a", "b", 1}, {"c", "d", 1}}, [][]string{{"z"}, {"a", "b"}, {"c", "d"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildGraph(tt.undirected, tt.vertices, tt.edges)
+			got := g.StronglyConnectedComponents()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StronglyConnectedComponents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGraphProperties runs the brute-force comparisons the example prints
+func TestGraphProperties(t *testing.T) {
+	edges := SlicesOf(PairsOf(Ints(0, 7), Ints(0, 7)), 20)
+	properties := []struct {
+		name string
+		prop func([]Pair[int, int]) bool
+	}{
+		{"Dijkstra matches Bellman-Ford", dijkstraMatchesBellmanFord},
+		{"Topological order respects every edge", topoOrderRespectsEdges},
+		{"SCCs are mutually reachable sets", sccMatchesReachability},
+		{"FindCycle agrees with TopologicalSort", findCycleAgreesWithTopo},
+	}
+	for _, p := range properties {
+		t.Run(p.name, func(t *testing.T) {
+			if result := ForAll(edges, p.prop); !result.Passed {
+				t.Error(result)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Tests and benchmarks for 21_lock_free.go. The concurrent tests mean
+// something only under the race detector:
+//
+//	go test -race -run 'Treiber|MPSC' ./04_generics
+//	go test -bench=LockFree -benchmem -cpu=1,4,8 ./04_generics
+
+// lfOp is one step of a sequential script: push value, or pop when pop is set
+type lfOp struct {
+	pop   bool
+	value int
+}
+
+func lfPush(v int) lfOp { return lfOp{value: v} }
+
+var lfPop = lfOp{pop: true}
+
+// lfScripts are run against both structures; want is what the pops return
+// for a stack and for a queue, with -1 for a pop on an empty structure
+var lfScripts = []struct {
+	name      string
+	ops       []lfOp
+	wantStack []int
+	wantQueue []int
+}{
+	{"pop empty", []lfOp{lfPop}, []int{-1}, []int{-1}},
+	{"push pop", []lfOp{lfPush(1), lfPop, lfPop}, []int{1, -1}, []int{1, -1}},
+	{"order", []lfOp{lfPush(1), lfPush(2), lfPush(3), lfPop, lfPop, lfPop}, []int{3, 2, 1}, []int{1, 2, 3}},
+	{"interleaved", []lfOp{lfPush(1), lfPush(2), lfPop, lfPush(3), lfPop, lfPop, lfPop}, []int{2, 3, 1, -1}, []int{1, 2, 3, -1}},
+	{"reuse after empty", []lfOp{lfPush(1), lfPop, lfPop, lfPush(2), lfPush(3), lfPop}, []int{1, -1, 3}, []int{1, -1, 2}},
+	{"zero value item", []lfOp{lfPush(0), lfPop, lfPop}, []int{0, -1}, []int{0, -1}},
+}
+
+func TestTreiberStack(t *testing.T) {
+	for _, tt := range lfScripts {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TreiberStack[int]{}
+			size := 0
+			var got []int
+			for _, op := range tt.ops {
+				if !op.pop {
+					s.Push(op.value)
+					size++
+				} else if v, ok := s.Pop(); ok {
+					got = append(got, v)
+					size--
+				} else {
+					if v != 0 {
+						t.Errorf("empty Pop returned %d, want the zero value", v)
+					}
+					got = append(got, -1)
+				}
+				if s.Size() != size {
+					t.Fatalf("Size() = %d, want %d", s.Size(), size)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantStack) {
+				t.Errorf("pops = %v, want %v", got, tt.wantStack)
+			}
+		})
+	}
+}
+
+func TestMPSCQueue(t *testing.T) {
+	for _, tt := range lfScripts {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewMPSCQueue[int]()
+			var got []int
+			for _, op := range tt.ops {
+				if !op.pop {
+					q.Push(op.value)
+				} else if v, ok := q.Pop(); ok {
+					got = append(got, v)
+				} else {
+					got = append(got, -1)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantQueue) {
+				t.Errorf("pops = %v, want %v", got, tt.wantQueue)
+			}
+		})
+	}
+}
+
+// TestTreiberStackConcurrent pushes and pops from many goroutines at once:
+// every value must come out exactly once
+func TestTreiberStackConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 2000
+	s := &TreiberStack[int]{}
+	seen := make([]atomic.Int32, workers*perWorker)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				s.Push(w*perWorker + i)
+				if i%2 == 1 {
+					if v, ok := s.Pop(); ok {
+						seen[v].Add(1)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for {
+		v, ok := s.Pop()
+		if !ok {
+			break
+		}
+		seen[v].Add(1)
+	}
+
+	for v := range seen {
+		if n := seen[v].Load(); n != 1 {
+			t.Errorf("value %d popped %d times, want once", v, n)
+		}
+	}
+	if s.Size() != 0 {
+		t.Errorf("Size() = %d after draining, want 0", s.Size())
+	}
+}
+
+// TestMPSCQueueConcurrent consumes while the producers are still pushing:
+// nothing may be lost or duplicated, and each producer's items must come out
+// in the order it pushed them
+func TestMPSCQueueConcurrent(t *testing.T) {
+	const producers, perProducer = 8, 2000
+	type msg struct{ producer, seq int }
+	q := NewMPSCQueue[msg]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for seq := 0; seq < perProducer; seq++ {
+				q.Push(msg{p, seq})
+			}
+		}(p)
+	}
+
+	nextSeq := make([]int, producers)
+	deadline := time.After(10 * time.Second)
+	for received := 0; received < producers*perProducer; {
+		m, ok := q.Pop()
+		if !ok {
+			// Empty, or a push is between its swap and its link
+			select {
+			case <-deadline:
+				t.Fatalf("received %d of %d items", received, producers*perProducer)
+			default:
+				runtime.Gosched()
+			}
+			continue
+		}
+		if m.seq != nextSeq[m.producer] {
+			t.Fatalf("producer %d: got item %d, want %d", m.producer, m.seq, nextSeq[m.producer])
+		}
+		nextSeq[m.producer]++
+		received++
+	}
+	wg.Wait()
+	if m, ok := q.Pop(); ok {
+		t.Errorf("extra item %v after all were received", m)
+	}
+}
+
+// BenchmarkLockFreeStack: a push+pop pair per op on one shared stack
+func BenchmarkLockFreeStack(b *testing.B) {
+	b.Run("TreiberStack", func(b *testing.B) {
+		s := &TreiberStack[int]{}
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				s.Push(i)
+				s.Pop()
+			}
+		})
+	})
+	b.Run("Stack-RWMutex", func(b *testing.B) {
+		s := NewStack[int]()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				s.Push(i)
+				s.Pop()
+			}
+		})
+	})
+}
+
+// BenchmarkLockFreeQueue: the producer side, one push per op
+func BenchmarkLockFreeQueue(b *testing.B) {
+	b.Run("MPSCQueue", func(b *testing.B) {
+		q := NewMPSCQueue[int]()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				q.Push(i)
+			}
+		})
+	})
+	b.Run("Queue-Mutex", func(b *testing.B) {
+		var mu sync.Mutex
+		q := NewQueue[int]()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				mu.Lock()
+				q.Enqueue(i)
+				mu.Unlock()
+			}
+		})
+	})
+}
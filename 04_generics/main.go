@@ -2,71 +2,84 @@ package main
 
 import (
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/topic"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		printHelp()
-		return
-	}
+// examples lists every runnable example; the shared topic package handles
+// argument parsing, the --interactive menu and the launcher's --list query
+var examples = []topic.Example{
+	{Number: 1, Title: "Basic Generics (Functions, Type Parameters, Type Inference)", Banner: "📚 Basic Generics - Functions, Type Parameters, Type Inference", Run: runBasicGenericsExample},
+	{Number: 2, Title: "Constraints (Built-in, Custom, Type Sets)", Banner: "🔒 Constraints - Built-in, Custom, Type Sets", Run: runConstraintsExample},
+	{Number: 3, Title: "Generic Types (Structs, Interfaces, Methods)", Banner: "🏗️ Generic Types - Structs, Interfaces, Methods", Run: runGenericTypesExample},
+	{Number: 4, Title: "Advanced Constraints (Type Sets, Unions, Approximations)", Banner: "🔧 Advanced Constraints - Type Sets, Unions, Approximations", Run: runAdvancedConstraintsExample},
+	{Number: 5, Title: "Generic Containers (Stack, Queue, Map, Tree)", Banner: "📦 Generic Containers - Stack, Queue, Map, Tree", Run: runGenericContainersExample},
+	{Number: 6, Title: "Generic Algorithms (Sort, Search, Transform, Aggregate)", Banner: "⚡ Generic Algorithms - Sort, Search, Transform, Aggregate", Run: runGenericAlgorithmsExample},
+	{Number: 7, Title: "Design Patterns (Factory, Builder, Decorator)", Banner: "🎨 Design Patterns - Factory, Builder, Decorator with Generics", Run: runDesignPatternsExample},
+	{Number: 8, Title: "Best Practices (Performance, Pitfalls, Organization)", Banner: "✨ Best Practices - Performance, Pitfalls, Code Organization", Run: runBestPracticesExample},
+	{Number: 9, Title: "Stream Operators (Distinct, Pairwise, Delta, Debounced Comparators)", Banner: "🌊 Stream Operators - Distinct, Pairwise, Delta, Debounced Comparators", Run: runStreamOperatorsExample},
+	{Number: 10, Title: "Property Testing (Generators, Shrinkers, Container Invariants)", Banner: "🧪 Property Testing - Generators, Shrinkers, Container Invariants", Run: runPropertyTestingExample},
+	{Number: 11, Title: "Feature Flags (typed flags, rollouts, change notification)", Banner: "🚩 Feature Flags - Typed Flags, Targeting Rules, Rollouts, Watchable", Run: runFeatureFlagsExample},
+	{Number: 12, Title: "Middleware (handler chains, timing, recovery, retry)", Banner: "🧅 Middleware - Handler Chains, Timing, Recovery, Retry", Run: runMiddlewareExample},
+	{Number: 13, Title: "Config Snapshots (copy-on-write, history, rollback)", Banner: "🗂️ Config Snapshots - Copy-on-Write, atomic.Pointer, History, Rollback", Run: runConfigSnapshotsExample},
+	{Number: 14, Title: "Time Series (Ring Buffer, Downsampling, Histograms)", Banner: "📈 Time Series - Ring Buffer, Range Queries, Downsampling, Histograms", Run: runTimeSeriesExample},
+	{Number: 15, Title: "Dataflow (IVar, Piping, Declarative Graphs)", Banner: "🧮 Dataflow - IVar, Then, Join2, Gather, Declarative Graphs", Run: runDataflowExample},
+	{Number: 16, Title: "Sorted Views (Comparators, Sorted Iteration Adaptors)", Banner: "🔢 Sorted Views - Cmp Comparators, SortedKeys, SortedView, IterateSorted", Run: runSortedViewsExample},
+	{Number: 17, Title: "Dimension-Safe Vectors (Vec2/Vec3, Compile-Time Dimension Checks)", Banner: "📐 Dimension-Safe Vectors", Run: runVectorsExample},
+	{Number: 18, Title: "Transactional Outbox (Atomic Staging, Retries, Idempotent Consumers)", Banner: "📮 Transactional Outbox", Run: runOutboxExample},
+	{Number: 19, Title: "Cursor Pagination (Opaque Cursors, Stable Keyset Paging)", Banner: "📄 Cursor Pagination", Run: runPaginationExample},
+	{Number: 20, Title: "Worker Pool (Resizing, Job Timeouts, Panic Recovery, Drain)", Banner: "🏭 Worker Pool", Run: runWorkerPoolExample},
+	{Number: 21, Title: "Lock-Free Structures (Treiber Stack, MPSC Queue, Mutex Comparison)", Banner: "🔓 Lock-Free Data Structures", Run: runLockFreeExample},
+	{Number: 22, Title: "Lazy Sequences (Seq, Map/Filter/Take/Zip/Chunk, Eager vs Lazy)", Banner: "💤 Lazy Sequences", Run: runLazySeqExample},
+	{Number: 23, Title: "Ordered Maps (Insertion Order, AVL-Backed SortedMap, Range Queries)", Banner: "🗂️ Ordered Maps", Run: runOrderedMapsExample},
+	{Number: 24, Title: "Search Trees (BST, Red-Black Tree, Successor, Invariant Checks)", Banner: "🌳 Search Trees", Run: runSearchTreesExample},
+	{Number: 25, Title: "Heaps and Priority Queues (Comparator Heap, Updates, Prioritized Worker Pool)", Banner: "⛰️ Heaps and Priority Queues", Run: runHeapExample},
+	{Number: 26, Title: "Persistent Collections (Structural Sharing, Lock-Free Snapshots)", Banner: "🧊 Persistent Collections", Run: runPersistentExample},
+	{Number: 27, Title: "Cache (Real TTL, LRU/LFU Eviction, Callbacks, Janitor)", Banner: "🗄️ Cache", Run: runCacheExample},
+	{Number: 28, Title: "Graph Algorithms (Dijkstra, Topological Sort, SCC, Cycle Detection)", Banner: "🕸️ Graph Algorithms", Run: runGraphAlgorithmsExample},
+	{Number: 29, Title: "Sorting (Merge, Heap, Hybrid and Parallel Quicksort, Benchmarks)", Banner: "📶 Sorting Algorithms", Run: runSortingExample},
+	{Number: 30, Title: "Statistics (Median, Percentiles, Variance, t-digest)", Banner: "📊 Streaming Statistics", Run: runStatisticsExample},
+	{Number: 31, Title: "Option Type (Combinators, Comma-ok Conversions)", Banner: "🎁 Option Type", Run: runOptionExample},
+	{Number: 32, Title: "Either and Validated (Error Accumulation)", Banner: "🔀 Either and Validated", Run: runEitherValidatedExample},
+	{Number: 33, Title: "Functional Utilities (Compose, Curry, Partial, Memoize)", Banner: "🧮 Functional Utilities", Run: runFunctionalExample},
+	{Number: 34, Title: "Resilience (Retry With Backoff, Circuit Breaker)", Banner: "🛡️ Retry and Circuit Breaker", Run: runResilienceExample},
+	{Number: 35, Title: "State Machine (Guards, Actions, DOT Export)", Banner: "🚦 State Machine", Run: runStateMachineExample},
+	{Number: 36, Title: "Publish/Subscribe (Typed Topics, Slow-Subscriber Policies)", Banner: "📣 Publish/Subscribe", Run: runPubSubExample},
+	{Number: 37, Title: "Numeric Utilities (Clamp, Abs, SafeDivide, Checked Conversions)", Banner: "🔢 Numeric Utilities", Run: runNumericExample},
+	{Number: 38, Title: "Matrix and Vector Math (Products, Transpose, Parallel Mul)", Banner: "🧊 Matrix and Vector Math", Run: runMatrixExample},
+	{Number: 39, Title: "Quick Property Testing (Reflection Generators, Shrinking, Seeds)", Banner: "🎲 Quick Property Testing", Run: runQuickExample},
+}
 
-	example, err := strconv.Atoi(os.Args[1])
-	if err != nil || example < 1 || example > 8 {
-		fmt.Printf("Invalid example number: %s\n", os.Args[1])
-		printHelp()
-		return
-	}
+func main() {
+	topic.Main(topic.Topic{
+		Name:     "generics",
+		Title:    "Generics",
+		Examples: examples,
+		Help:     printHelp,
+		Header:   printHeader,
+	})
+}
 
-	fmt.Printf("🚀 Running Go Generics Example %d\n", example)
+func printHeader(e topic.Example) {
+	fmt.Printf("🚀 Running Go Generics Example %d\n", e.Number)
 	fmt.Println(strings.Repeat("=", 50))
-
-	switch example {
-	case 1:
-		fmt.Println("📚 Basic Generics - Functions, Type Parameters, Type Inference")
-		runBasicGenericsExample()
-	case 2:
-		fmt.Println("🔒 Constraints - Built-in, Custom, Type Sets")
-		runConstraintsExample()
-	case 3:
-		fmt.Println("🏗️ Generic Types - Structs, Interfaces, Methods")
-		runGenericTypesExample()
-	case 4:
-		fmt.Println("🔧 Advanced Constraints - Type Sets, Unions, Approximations")
-		runAdvancedConstraintsExample()
-	case 5:
-		fmt.Println("📦 Generic Containers - Stack, Queue, Map, Tree")
-		runGenericContainersExample()
-	case 6:
-		fmt.Println("⚡ Generic Algorithms - Sort, Search, Transform, Aggregate")
-		runGenericAlgorithmsExample()
-	case 7:
-		fmt.Println("🎨 Design Patterns - Factory, Builder, Decorator with Generics")
-		runDesignPatternsExample()
-	case 8:
-		fmt.Println("✨ Best Practices - Performance, Pitfalls, Code Organization")
-		runBestPracticesExample()
-	}
+	fmt.Println(e.Banner)
 }
 
-func printHelp() {
+func printHelp(t topic.Topic) {
 	fmt.Println("🧬 Go Generics Programming Examples")
 	fmt.Println("====================================")
 	fmt.Println()
 	fmt.Println("📖 Available Examples:")
-	fmt.Println("  1 - Basic Generics (Functions, Type Parameters, Type Inference)")
-	fmt.Println("  2 - Constraints (Built-in, Custom, Type Sets)")
-	fmt.Println("  3 - Generic Types (Structs, Interfaces, Methods)")
-	fmt.Println("  4 - Advanced Constraints (Type Sets, Unions, Approximations)")
-	fmt.Println("  5 - Generic Containers (Stack, Queue, Map, Tree)")
-	fmt.Println("  6 - Generic Algorithms (Sort, Search, Transform, Aggregate)")
-	fmt.Println("  7 - Design Patterns (Factory, Builder, Decorator)")
-	fmt.Println("  8 - Best Practices (Performance, Pitfalls, Organization)")
+	for _, e := range t.Examples {
+		fmt.Printf("  %d - %s\n", e.Number, e.Title)
+	}
 	fmt.Println()
 	fmt.Println("🚀 Usage:")
 	fmt.Println("  go run . <example_number>")
+	fmt.Println("  go run . --interactive   # Browse, run and re-run from a menu")
+	fmt.Println("  go run . <example_number> --format=json   # One JSON record per output line")
 	fmt.Println()
 	fmt.Println("💡 Examples:")
 	fmt.Println("  go run . 1    # Basic generics")
@@ -74,7 +87,7 @@ func printHelp() {
 	fmt.Println("  go run . 8    # Best practices")
 	fmt.Println()
 	fmt.Println("📋 Learning Path:")
-	fmt.Println("  Basic (1-3) → Intermediate (4-6) → Advanced (7-8)")
+	fmt.Println("  Basic (1-3) → Intermediate (4-6) → Advanced (7-8) → Applied (9+)")
 }
 
 // Example function placeholders (to be implemented in separate files)
@@ -93,3 +106,55 @@ func printHelp() {
 // runDesignPatternsExample is implemented in 07_design_patterns.go
 
 // runBestPracticesExample is implemented in 08_best_practices.go
+
+// runStreamOperatorsExample is implemented in 09_stream_operators.go
+
+// runPropertyTestingExample is implemented in 10_property_testing.go
+
+// runFeatureFlagsExample is implemented in 11_feature_flags.go
+
+// runMiddlewareExample is implemented in 12_middleware.go
+
+// runConfigSnapshotsExample is implemented in 13_config_snapshots.go
+
+// runTimeSeriesExample is implemented in 14_time_series.go
+
+// runDataflowExample is implemented in 15_dataflow.go
+
+// runSortedViewsExample is implemented in 16_sorted_views.go
+
+// runVectorsExample is implemented in 17_vectors.go
+
+// runOutboxExample is implemented in 18_outbox.go
+
+// runPaginationExample is implemented in 19_pagination.go
+
+// runWorkerPoolExample is implemented in 20_worker_pool.go
+
+// runLockFreeExample is implemented in 21_lock_free.go
+
+// runLazySeqExample is implemented in 22_lazy_seq.go
+
+// runOrderedMapsExample is implemented in 23_ordered_maps.go
+
+// runSearchTreesExample is implemented in 24_search_trees.go
+
+// runHeapExample is implemented in 25_heap.go
+
+// runPersistentExample is implemented in 26_persistent.go
+
+// runCacheExample is implemented in 27_cache.go
+
+// runGraphAlgorithmsExample is implemented in 28_graph_algorithms.go
+
+// runSortingExample is implemented in 29_sorting.go
+// runStatisticsExample is implemented in 30_statistics.go
+// runOptionExample is implemented in 31_option.go
+// runEitherValidatedExample is implemented in 32_either_validated.go
+// runFunctionalExample is implemented in 33_functional.go
+// runResilienceExample is implemented in 34_resilience.go
+// runStateMachineExample is implemented in 35_state_machine.go
+// runPubSubExample is implemented in 36_pubsub.go
+// runNumericExample is implemented in 37_numeric.go
+// runMatrixExample is implemented in 38_matrix.go
+// runQuickExample is implemented in 39_quick.go
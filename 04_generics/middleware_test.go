@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRetryAroundRecovery checks that middleware order decides whether a
+// panic is retried: Recovery only turns it into an error Retry can see when
+// Retry is the outer layer
+func TestRetryAroundRecovery(t *testing.T) {
+	retry := Retry[string, int](3, time.Microsecond, nil)
+	recovery := Recovery[string, int]()
+	tests := []struct {
+		name      string
+		chain     Middleware[string, int]
+		wantCalls int
+	}{
+		{"retry outside recovery", ChainMiddleware(retry, recovery), 3},
+		{"recovery outside retry", ChainMiddleware(recovery, retry), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := tt.chain(func(word string) (int, error) {
+				calls++
+				panic("empty word")
+			})
+			_, err := handler("")
+			var pe *PanicError
+			if !errors.As(err, &pe) {
+				t.Errorf("error = %v, want a *PanicError", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnSuccess(t *testing.T) {
+	calls := 0
+	handler := ChainMiddleware(Retry[string, int](3, time.Microsecond, nil), Recovery[string, int]())(
+		func(word string) (int, error) {
+			calls++
+			if calls == 1 {
+				panic("first call")
+			}
+			return len(word), nil
+		})
+	got, err := handler("go")
+	if err != nil || got != 2 || calls != 2 {
+		t.Errorf("handler(go) = %d, %v after %d calls; want 2, nil after 2", got, err, calls)
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		ops      func(m *OrderedMap[string, int])
+		wantKeys []string
+	}{
+		{"empty", func(m *OrderedMap[string, int]) {}, []string{}},
+		{"insertion order", func(m *OrderedMap[string, int]) {
+			m.Set("c", 1)
+			m.Set("a", 2)
+			m.Set("b", 3)
+		}, []string{"c", "a", "b"}},
+		{"update keeps position", func(m *OrderedMap[string, int]) {
+			m.Set("a", 1)
+			m.Set("b", 2)
+			m.Set("a", 3)
+		}, []string{"a", "b"}},
+		{"delete head, middle and tail", func(m *OrderedMap[string, int]) {
+			for i, k := range []string{"a", "b", "c", "d", "e"} {
+				m.Set(k, i)
+			}
+			m.Delete("a")
+			m.Delete("c")
+			m.Delete("e")
+		}, []string{"b", "d"}},
+		{"re-set after delete goes to the back", func(m *OrderedMap[string, int]) {
+			m.Set("a", 1)
+			m.Set("b", 2)
+			m.Delete("a")
+			m.Set("a", 3)
+		}, []string{"b", "a"}},
+		{"delete only key", func(m *OrderedMap[string, int]) {
+			m.Set("a", 1)
+			m.Delete("a")
+		}, []string{}},
+		{"move to back", func(m *OrderedMap[string, int]) {
+			m.Set("a", 1)
+			m.Set("b", 2)
+			m.Set("c", 3)
+			m.MoveToBack("a")
+			m.MoveToBack("a") // Already last
+		}, []string{"b", "c", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewOrderedMap[string, int]()
+			tt.ops(m)
+			keys := m.Keys()
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Fatalf("Keys() = %v, want %v", keys, tt.wantKeys)
+			}
+			if m.Len() != len(tt.wantKeys) {
+				t.Errorf("Len() = %d, want %d", m.Len(), len(tt.wantKeys))
+			}
+			visited := []string{}
+			m.ForEach(func(k string, _ int) { visited = append(visited, k) })
+			if !reflect.DeepEqual(visited, keys) {
+				t.Errorf("ForEach visited %v, Keys() = %v", visited, keys)
+			}
+			oldest, _, ok := m.Oldest()
+			if ok != (len(keys) > 0) || (ok && oldest != keys[0]) {
+				t.Errorf("Oldest() = %q, %t; want the first of %v", oldest, ok, keys)
+			}
+		})
+	}
+}
+
+func TestOrderedMapMissingKeys(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	if v, ok := m.Get("b"); ok || v != 0 {
+		t.Errorf("Get(missing) = %d, %t", v, ok)
+	}
+	if m.Delete("b") {
+		t.Error("Delete(missing) reported true")
+	}
+	if m.MoveToBack("b") {
+		t.Error("MoveToBack(missing) reported true")
+	}
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %t; want 1, true", v, ok)
+	}
+	if !m.Delete("a") || m.Delete("a") {
+		t.Error("Delete(a) should succeed once")
+	}
+}
+
+func TestSortedMapQueries(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		m.Put(k, "v")
+	}
+	m.Put(30, "updated")
+
+	if v, ok := m.Get(30); !ok || v != "updated" {
+		t.Errorf("Get(30) = %q, %t; want the updated value", v, ok)
+	}
+	if m.Len() != 5 {
+		t.Errorf("Len() = %d after updating a key, want 5", m.Len())
+	}
+	if k, _, _ := m.Min(); k != 10 {
+		t.Errorf("Min() = %d, want 10", k)
+	}
+	if k, _, _ := m.Max(); k != 50 {
+		t.Errorf("Max() = %d, want 50", k)
+	}
+
+	bounds := []struct {
+		key                int
+		floor, ceiling     int
+		floorOK, ceilingOK bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{25, 20, 30, true, true},
+		{50, 50, 50, true, true},
+		{55, 50, 0, true, false},
+	}
+	for _, b := range bounds {
+		if k, ok := m.Floor(b.key); k != b.floor || ok != b.floorOK {
+			t.Errorf("Floor(%d) = %d, %t; want %d, %t", b.key, k, ok, b.floor, b.floorOK)
+		}
+		if k, ok := m.Ceiling(b.key); k != b.ceiling || ok != b.ceilingOK {
+			t.Errorf("Ceiling(%d) = %d, %t; want %d, %t", b.key, k, ok, b.ceiling, b.ceilingOK)
+		}
+	}
+}
+
+func TestSortedMapRange(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	for _, k := range []int{1, 3, 5, 7, 9} {
+		m.Put(k, k*k)
+	}
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   []int
+	}{
+		{"all", 0, 100, []int{1, 3, 5, 7, 9}},
+		{"lo inclusive, hi exclusive", 3, 7, []int{3, 5}},
+		{"bounds between keys", 2, 8, []int{3, 5, 7}},
+		{"single key", 5, 6, []int{5}},
+		{"empty interval", 5, 5, nil},
+		{"inverted interval", 7, 3, nil},
+		{"below every key", -10, 1, nil},
+		{"above every key", 10, 20, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			m.Range(tt.lo, tt.hi, func(k, v int) {
+				if v != k*k {
+					t.Errorf("Range passed %d with value %d", k, v)
+				}
+				got = append(got, k)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Range(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortedMapEmptyAndDelete(t *testing.T) {
+	m := NewSortedMap[string, int]()
+	if _, _, ok := m.Min(); ok {
+		t.Error("Min() on an empty map reported ok")
+	}
+	if _, _, ok := m.Max(); ok {
+		t.Error("Max() on an empty map reported ok")
+	}
+	if _, ok := m.Floor("x"); ok {
+		t.Error("Floor() on an empty map reported ok")
+	}
+	if m.Delete("x") {
+		t.Error("Delete() on an empty map reported true")
+	}
+
+	for _, k := range []string{"d", "b", "f", "a", "c", "e", "g"} {
+		m.Put(k, 0)
+	}
+	// Leaf, one child, two children and the root
+	for _, k := range []string{"g", "f", "b", "d"} {
+		if !m.Delete(k) {
+			t.Fatalf("Delete(%q) = false", k)
+		}
+		if _, ok := m.Get(k); ok {
+			t.Fatalf("Get(%q) found a deleted key", k)
+		}
+	}
+	if want := []string{"a", "c", "e"}; !reflect.DeepEqual(m.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", m.Keys(), want)
+	}
+}
+
+// TestOrderedMapProperties runs the invariants the example prints against
+// generated input
+func TestOrderedMapProperties(t *testing.T) {
+	intSlices := SlicesOf(Ints(-50, 50), 40)
+	properties := []struct {
+		name string
+		prop func([]int) bool
+	}{
+		{"OrderedMap keeps first-set order", orderedMapKeepsFirstSetOrder},
+		{"SortedMap keys are sorted and unique", sortedMapKeysSorted},
+		{"SortedMap stays AVL-balanced", sortedMapStaysBalanced},
+		{"SortedMap.Range matches a filter", sortedMapRangeMatchesFilter},
+	}
+	for _, p := range properties {
+		t.Run(p.name, func(t *testing.T) {
+			if result := ForAll(intSlices, p.prop); !result.Passed {
+				t.Error(result)
+			}
+		})
+	}
+}
+
+func TestSortedMapHeightAfterSortedInserts(t *testing.T) {
+	m := NewSortedMap[int, bool]()
+	for i := 1; i <= 1024; i++ {
+		m.Put(i, true)
+	}
+	// An AVL tree of n nodes is at most 1.44*log2(n) high: 14 for 1024
+	if h := m.Height(); h > 14 {
+		t.Errorf("Height() = %d after 1024 sorted inserts, want at most 14", h)
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func identity(n int) int { return n }
+
+// walkPages follows NextCursor from the first page to the last, calling
+// between after each page so a test can change the data mid-walk
+func walkPages(t *testing.T, limit int, fetch func(cursor string) (Page[int], error), between func(page int)) [][]int {
+	t.Helper()
+	var pages [][]int
+	cursor := ""
+	for {
+		page, err := fetch(cursor)
+		if err != nil {
+			t.Fatalf("page %d: %v", len(pages)+1, err)
+		}
+		if len(page.Items) > limit {
+			t.Fatalf("page %d has %d items, limit is %d", len(pages)+1, len(page.Items), limit)
+		}
+		pages = append(pages, page.Items)
+		if !page.HasMore() {
+			return pages
+		}
+		if len(pages) > 100 {
+			t.Fatal("cursor walk does not terminate")
+		}
+		if between != nil {
+			between(len(pages))
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		limit int
+		want  [][]int
+	}{
+		{"empty", nil, 3, [][]int{nil}},
+		{"single page", []int{3, 1, 2}, 5, [][]int{{1, 2, 3}}},
+		{"exact multiple has no empty last page", []int{4, 3, 2, 1}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"partial last page", []int{5, 4, 3, 2, 1}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"limit one", []int{2, 1, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{"negative keys", []int{0, -5, 7, -1}, 3, [][]int{{-5, -1, 0}, {7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := walkPages(t, tt.limit, func(cursor string) (Page[int], error) {
+				return Paginate(tt.items, identity, cursor, tt.limit)
+			}, nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateDoesNotReorderInput(t *testing.T) {
+	items := []int{3, 1, 2}
+	if _, err := Paginate(items, identity, "", 2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(items, []int{3, 1, 2}) {
+		t.Errorf("input reordered to %v", items)
+	}
+}
+
+func TestPaginateRejects(t *testing.T) {
+	stringCursor, err := Paginate([]string{"a", "b", "c"}, func(s string) string { return s }, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		items   []int
+		cursor  string
+		limit   int
+		wantErr error
+	}{
+		{"zero limit", []int{1}, "", 0, nil},
+		{"negative limit", []int{1}, "", -1, nil},
+		{"not base64", []int{1}, "not a cursor!", 2, ErrInvalidCursor},
+		{"base64 but not JSON", []int{1}, base64.RawURLEncoding.EncodeToString([]byte("{oops")), 2, ErrInvalidCursor},
+		{"cursor for another key type", []int{1}, stringCursor.NextCursor, 2, ErrInvalidCursor},
+		{"duplicate keys", []int{1, 2, 2, 3}, "", 2, ErrDuplicatePageKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := Paginate(tt.items, identity, tt.cursor, tt.limit)
+			if err == nil {
+				t.Fatalf("got page %v, want an error", page.Items)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaginateFloatKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []float64
+		limit   int
+		want    [][]float64 // Pages of a full walk
+		wantErr error       // Set when the walk must fail instead
+	}{
+		{"finite keys", []float64{0.5, -1, 2.25}, 2, [][]float64{{-1, 0.5}, {2.25}}, nil},
+		{"NaN key", []float64{1, math.NaN(), 2}, 2, nil, ErrUnorderedPageKey},
+		{"NaN on a later page", []float64{1, 2, 3, math.NaN()}, 1, nil, ErrUnorderedPageKey},
+		// An infinity cannot be encoded in a cursor, so the walk must fail
+		// rather than end early with no cursor
+		{"-Inf ends a non-final page", []float64{1, math.Inf(-1)}, 1, nil, nil},
+		{"+Inf sorts last and needs no cursor", []float64{math.Inf(1), 1, 2}, 1, [][]float64{{1}, {2}, {math.Inf(1)}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages [][]float64
+			cursor := ""
+			var err error
+			for {
+				var page Page[float64]
+				page, err = Paginate(tt.items, func(f float64) float64 { return f }, cursor, tt.limit)
+				if err != nil {
+					break
+				}
+				pages = append(pages, page.Items)
+				if !page.HasMore() {
+					break
+				}
+				cursor = page.NextCursor
+			}
+
+			if tt.want == nil {
+				if err == nil {
+					t.Fatalf("walk gave %v, want an error", pages)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("walk failed after %v: %v", pages, err)
+			}
+			if !reflect.DeepEqual(pages, tt.want) {
+				t.Errorf("pages = %v, want %v", pages, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateCursorStability(t *testing.T) {
+	items := []int{10, 20, 30, 40, 50}
+	first, err := Paginate(items, identity, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same cursor yields the same page for unchanged data
+	a, _ := Paginate(items, identity, first.NextCursor, 2)
+	b, _ := Paginate(items, identity, first.NextCursor, 2)
+	if !reflect.DeepEqual(a, b) || !reflect.DeepEqual(a.Items, []int{30, 40}) {
+		t.Errorf("repeated cursor gave %v and %v, want [30 40] twice", a, b)
+	}
+
+	// A cursor is the last key, so it does not depend on the input's order
+	shuffled := []int{50, 30, 10, 40, 20}
+	c, _ := Paginate(shuffled, identity, first.NextCursor, 2)
+	if !reflect.DeepEqual(c.Items, a.Items) || c.NextCursor != a.NextCursor {
+		t.Errorf("shuffled input gave %v, want %v", c, a)
+	}
+
+	// The cursor's item may disappear; the walk resumes after where it was
+	withoutBoundary := []int{10, 30, 40, 50}
+	d, _ := Paginate(withoutBoundary, identity, first.NextCursor, 2)
+	if !reflect.DeepEqual(d.Items, []int{30, 40}) {
+		t.Errorf("after deleting the cursor item got %v, want [30 40]", d.Items)
+	}
+}
+
+func TestPaginateChangesDuringWalk(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial []int
+		change  func(items []int, afterPage int) []int
+		want    []int // Every item the walk returns, in order
+	}{
+		{
+			name:    "insert before cursor is not seen",
+			initial: []int{10, 20, 30, 40},
+			change: func(items []int, afterPage int) []int {
+				return append(items, 5, 15)
+			},
+			want: []int{10, 20, 30, 40},
+		},
+		{
+			name:    "insert after cursor is seen once",
+			initial: []int{10, 20, 30, 40},
+			change: func(items []int, afterPage int) []int {
+				if afterPage == 1 {
+					return append(items, 25, 99)
+				}
+				return items
+			},
+			want: []int{10, 20, 25, 30, 40, 99},
+		},
+		{
+			name:    "delete ahead of cursor is skipped",
+			initial: []int{10, 20, 30, 40, 50},
+			change: func(items []int, afterPage int) []int {
+				if afterPage == 1 {
+					return []int{10, 20, 40, 50}
+				}
+				return items
+			},
+			want: []int{10, 20, 40, 50},
+		},
+		{
+			name:    "insert just past the cursor on every page",
+			initial: []int{10, 20, 30, 40, 50, 60},
+			change: func(items []int, afterPage int) []int {
+				return append(items, 10*afterPage+11)
+			},
+			want: []int{10, 20, 21, 30, 31, 40, 41, 50, 60},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := append([]int(nil), tt.initial...)
+			pages := walkPages(t, 2, func(cursor string) (Page[int], error) {
+				return Paginate(items, identity, cursor, 2)
+			}, func(page int) {
+				if page < 4 {
+					items = tt.change(items, page)
+				}
+			})
+			var got []int
+			for _, p := range pages {
+				got = append(got, p...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("walk returned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateMap(t *testing.T) {
+	entries := map[int]string{5: "e", 1: "a", 4: "d", 2: "b", 3: "c"}
+	safe := NewSafeMap[int, string]()
+	sorted := NewSortedMap[int, string]()
+	for k, v := range entries {
+		safe.Set(k, v)
+		sorted.Put(k, v)
+	}
+	tests := []struct {
+		name string
+		src  KeyValueSource[int, string]
+	}{
+		{"SafeMap", safe},
+		{"MapSource", MapSource[int, string](entries)},
+		{"SortedMap", sorted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []int
+			var values string
+			cursor := ""
+			for pages := 1; ; pages++ {
+				page, err := PaginateMap(tt.src, cursor, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, e := range page.Items {
+					keys = append(keys, e.First)
+					values += e.Second
+				}
+				if !page.HasMore() {
+					if pages != 3 {
+						t.Errorf("got %d pages, want 3", pages)
+					}
+					break
+				}
+				cursor = page.NextCursor
+			}
+			if !reflect.DeepEqual(keys, []int{1, 2, 3, 4, 5}) || values != "abcde" {
+				t.Errorf("walk returned keys %v values %q, want [1 2 3 4 5] \"abcde\"", keys, values)
+			}
+		})
+	}
+}
+
+func TestPaginateSortedMapInsertDuringWalk(t *testing.T) {
+	m := NewSortedMap[string, int]()
+	for i, k := range []string{"b", "d", "f", "h"} {
+		m.Put(k, i)
+	}
+	first, err := PaginateMap[string, int](m, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Put("a", 0) // Before the cursor
+	m.Put("e", 0) // After it
+	m.Delete("f")
+	rest, err := PaginateMap[string, int](m, first.NextCursor, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range append(first.Items, rest.Items...) {
+		got = append(got, e.First)
+	}
+	if want := []string{"b", "d", "e", "h"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("walk returned %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestContainerProperties runs the container invariants the example prints
+func TestContainerProperties(t *testing.T) {
+	intSlices := SlicesOf(Ints(-100, 100), 30)
+	intPairs := PairsOf(intSlices, intSlices)
+	ranges := PairsOf(intSlices, PairsOf(Ints(-100, 100), Ints(-100, 100)))
+	tests := []struct {
+		name  string
+		check func() (bool, string)
+	}{
+		{"Stack pops in reverse push order", func() (bool, string) {
+			r := ForAll(intSlices, stackReversesInput)
+			return r.Passed, r.String()
+		}},
+		{"Queue dequeues in enqueue order", func() (bool, string) {
+			r := ForAll(intSlices, queuePreservesOrder)
+			return r.Passed, r.String()
+		}},
+		{"Set union commutes", func() (bool, string) {
+			r := ForAll(intPairs, setUnionCommutes)
+			return r.Passed, r.String()
+		}},
+		{"Set intersection is a subset", func() (bool, string) {
+			r := ForAll(intPairs, setIntersectionIsSubset)
+			return r.Passed, r.String()
+		}},
+		{"Trie finds every inserted word", func() (bool, string) {
+			r := ForAll(SlicesOf(Strings(6, "abc"), 20), trieFindsInsertedWords)
+			return r.Passed, r.String()
+		}},
+		{"Sort then search succeeds", func() (bool, string) {
+			r := ForAll(intSlices, sortThenSearchSucceeds)
+			return r.Passed, r.String()
+		}},
+		{"SortedMap order and Range(lo, hi)", func() (bool, string) {
+			r := ForAll(ranges, sortedMapRangeMatchesModel)
+			return r.Passed, r.String()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if passed, summary := tt.check(); !passed {
+				t.Error(summary)
+			}
+		})
+	}
+}
+
+// TestSortedMapRangeMatchesModelCases pins the boundary cases random input
+// only hits by chance
+func TestSortedMapRangeMatchesModelCases(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []int
+		lo, hi int
+	}{
+		{"empty map", nil, 0, 10},
+		{"lo is a key, hi is a key", []int{1, 2, 3, 4}, 2, 4},
+		{"empty interval on a key", []int{1, 2, 3}, 2, 2},
+		{"inverted interval", []int{1, 2, 3}, 3, 1},
+		{"duplicate keys", []int{5, 5, 1, 5, 1}, 0, 6},
+		{"negative keys", []int{-3, -1, 0, 2}, -2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := NewPair(tt.keys, NewPair(tt.lo, tt.hi))
+			if !sortedMapRangeMatchesModel(input) {
+				t.Errorf("property fails for %v", input)
+			}
+		})
+	}
+}
+
+// TestForAllShrinksAndReplays checks the runner itself: a false property
+// fails, shrinks to a smaller input that still fails, and replays with the
+// reported seed
+func TestForAllShrinksAndReplays(t *testing.T) {
+	sumBelow100 := func(items []int) bool { return SumSlice(items) < 100 }
+	gen := SlicesOf(Ints(0, 50), 10)
+
+	result := ForAll(gen, sumBelow100)
+	if result.Passed {
+		t.Fatal("a false property passed")
+	}
+	if sumBelow100(result.Shrunk) {
+		t.Errorf("shrunk input %v passes the property", result.Shrunk)
+	}
+	if len(result.Shrunk) > len(result.Counterexample) {
+		t.Errorf("shrunk input %v is longer than %v", result.Shrunk, result.Counterexample)
+	}
+
+	cfg := DefaultPropertyConfig
+	cfg.Seed = result.Seed
+	replay := ForAllWith(cfg, gen, sumBelow100)
+	if fmt.Sprint(replay.Counterexample) != fmt.Sprint(result.Counterexample) {
+		t.Errorf("replay with seed %d found %v, want %v", result.Seed, replay.Counterexample, result.Counterexample)
+	}
+}
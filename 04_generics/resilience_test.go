@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestBreakerProbePanics checks that a half-open probe that panics counts as
+// a failure and frees its slot instead of leaving the breaker stuck
+func TestBreakerProbePanics(t *testing.T) {
+	now := time.Unix(0, 0)
+	breaker := NewCircuitBreaker[int](BreakerOptions{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Second,
+		Now:              func() time.Time { return now },
+	})
+	ctx := context.Background()
+	fail := func(context.Context) (int, error) { return 0, errors.New("down") }
+	succeed := func(context.Context) (int, error) { return 1, nil }
+
+	breaker.Execute(ctx, fail)
+	now = now.Add(time.Second)
+	if state := breaker.State(); state != BreakerHalfOpen {
+		t.Fatalf("state = %v, want half-open", state)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("panic did not reach the caller")
+			}
+		}()
+		breaker.Execute(ctx, func(context.Context) (int, error) { panic("probe") })
+	}()
+	if state := breaker.State(); state != BreakerOpen {
+		t.Errorf("state after a panicking probe = %v, want open", state)
+	}
+	if m := breaker.Metrics(); m.Failures != 2 {
+		t.Errorf("failures = %d, want 2", m.Failures)
+	}
+
+	now = now.Add(time.Second)
+	if got, err := breaker.Execute(ctx, succeed); err != nil || got != 1 {
+		t.Fatalf("next probe = %d, %v; want 1, nil", got, err)
+	}
+	if state := breaker.State(); state != BreakerClosed {
+		t.Errorf("state after a good probe = %v, want closed", state)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// orderedTree is the full API both BST and RBTree offer
+type orderedTree interface {
+	searchTree
+	Search(int) bool
+	Min() (int, bool)
+	Max() (int, bool)
+	Successor(int) (int, bool)
+	Predecessor(int) (int, bool)
+}
+
+var searchTreeKinds = []struct {
+	name string
+	new  func() orderedTree
+}{
+	{"BST", func() orderedTree { return NewBST[int]() }},
+	{"RBTree", func() orderedTree { return NewRBTree[int]() }},
+}
+
+// checkRB fails the test if tree is an RBTree that breaks an invariant
+func checkRB(t *testing.T, tree orderedTree, after string) {
+	t.Helper()
+	if rb, ok := tree.(*RBTree[int]); ok {
+		if err := rb.CheckInvariants(); err != nil {
+			t.Fatalf("after %s: %v", after, err)
+		}
+	}
+}
+
+func TestSearchTreeEmpty(t *testing.T) {
+	for _, kind := range searchTreeKinds {
+		t.Run(kind.name, func(t *testing.T) {
+			tree := kind.new()
+			if _, ok := tree.Min(); ok {
+				t.Error("Min() on an empty tree reported ok")
+			}
+			if _, ok := tree.Max(); ok {
+				t.Error("Max() on an empty tree reported ok")
+			}
+			if _, ok := tree.Successor(0); ok {
+				t.Error("Successor() on an empty tree reported ok")
+			}
+			if _, ok := tree.Predecessor(0); ok {
+				t.Error("Predecessor() on an empty tree reported ok")
+			}
+			if tree.Search(0) || tree.Delete(0) {
+				t.Error("Search or Delete found a value in an empty tree")
+			}
+			if tree.Size() != 0 || len(tree.InOrder()) != 0 {
+				t.Errorf("Size() = %d, InOrder() = %v", tree.Size(), tree.InOrder())
+			}
+			checkRB(t, tree, "nothing")
+		})
+	}
+}
+
+func TestSearchTreeInsertDelete(t *testing.T) {
+	// Inserting 50 30 70 20 40 60 80 35 gives the same BST shape every time:
+	// 20 is a leaf, 40 has one child (35), 30 and the root 50 have two
+	initial := []int{50, 30, 70, 20, 40, 60, 80, 35}
+	tests := []struct {
+		name   string
+		delete []int
+		want   []int
+	}{
+		{"nothing", nil, []int{20, 30, 35, 40, 50, 60, 70, 80}},
+		{"leaf", []int{20}, []int{30, 35, 40, 50, 60, 70, 80}},
+		{"one child", []int{40}, []int{20, 30, 35, 50, 60, 70, 80}},
+		{"two children", []int{30}, []int{20, 35, 40, 50, 60, 70, 80}},
+		{"root", []int{50}, []int{20, 30, 35, 40, 60, 70, 80}},
+		{"missing value", []int{45}, []int{20, 30, 35, 40, 50, 60, 70, 80}},
+		{"everything", initial, []int{}},
+		{"twice", []int{60, 60}, []int{20, 30, 35, 40, 50, 70, 80}},
+	}
+	for _, kind := range searchTreeKinds {
+		for _, tt := range tests {
+			t.Run(kind.name+"/"+tt.name, func(t *testing.T) {
+				tree := kind.new()
+				for _, v := range initial {
+					if !tree.Insert(v) {
+						t.Fatalf("Insert(%d) = false for a new value", v)
+					}
+					checkRB(t, tree, "inserting")
+				}
+				if tree.Insert(50) {
+					t.Error("Insert of a duplicate reported true")
+				}
+
+				present := make(map[int]bool)
+				for _, v := range initial {
+					present[v] = true
+				}
+				for _, v := range tt.delete {
+					if got := tree.Delete(v); got != present[v] {
+						t.Errorf("Delete(%d) = %t, want %t", v, got, present[v])
+					}
+					delete(present, v)
+					checkRB(t, tree, "deleting")
+				}
+
+				got := tree.InOrder()
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("InOrder() = %v, want %v", got, tt.want)
+				}
+				if tree.Size() != len(tt.want) {
+					t.Errorf("Size() = %d, want %d", tree.Size(), len(tt.want))
+				}
+				for _, v := range initial {
+					if tree.Search(v) != present[v] {
+						t.Errorf("Search(%d) = %t, want %t", v, !present[v], present[v])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSearchTreeNeighbors(t *testing.T) {
+	tests := []struct {
+		value          int
+		succ, pred     int
+		succOK, predOK bool
+	}{
+		{5, 10, 0, true, false},  // Below every value
+		{10, 20, 0, true, false}, // The minimum
+		{20, 30, 10, true, true}, // Present
+		{25, 30, 20, true, true}, // Absent, between values
+		{40, 0, 30, false, true}, // The maximum
+		{99, 0, 40, false, true}, // Above every value
+	}
+	for _, kind := range searchTreeKinds {
+		tree := kind.new()
+		for _, v := range []int{30, 10, 40, 20} {
+			tree.Insert(v)
+		}
+		if v, ok := tree.Min(); !ok || v != 10 {
+			t.Errorf("%s: Min() = %d, %t", kind.name, v, ok)
+		}
+		if v, ok := tree.Max(); !ok || v != 40 {
+			t.Errorf("%s: Max() = %d, %t", kind.name, v, ok)
+		}
+		for _, tt := range tests {
+			if v, ok := tree.Successor(tt.value); v != tt.succ || ok != tt.succOK {
+				t.Errorf("%s: Successor(%d) = %d, %t; want %d, %t", kind.name, tt.value, v, ok, tt.succ, tt.succOK)
+			}
+			if v, ok := tree.Predecessor(tt.value); v != tt.pred || ok != tt.predOK {
+				t.Errorf("%s: Predecessor(%d) = %d, %t; want %d, %t", kind.name, tt.value, v, ok, tt.pred, tt.predOK)
+			}
+		}
+	}
+}
+
+// TestRBTreeCheckInvariants breaks a valid tree by hand and expects the
+// checker to name the rule
+func TestRBTreeCheckInvariants(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(root *searchNode[int])
+		wantErr string
+	}{
+		{"valid", func(root *searchNode[int]) {}, ""},
+		{"red root", func(root *searchNode[int]) { root.red = true }, "root is red"},
+		{"red right child", func(root *searchNode[int]) { root.right.red = true }, "right child"},
+		{"red node with red child", func(root *searchNode[int]) {
+			root.left.red = true
+			root.left.left.red = true
+		}, "has a red child"},
+		{"unequal black heights", func(root *searchNode[int]) { root.right.right = &searchNode[int]{value: 100} }, "black heights"},
+		{"out of order", func(root *searchNode[int]) { root.left.value = root.value + 1 }, "out of search order"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := NewRBTree[int]()
+			for v := 1; v <= 15; v++ {
+				rb.Insert(v)
+			}
+			tt.corrupt(rb.root)
+			err := rb.CheckInvariants()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("CheckInvariants() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("CheckInvariants() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRBTreeSortedInputStaysShallow(t *testing.T) {
+	rb := NewRBTree[int]()
+	for v := 0; v < 1023; v++ {
+		rb.Insert(v)
+	}
+	// 2*log2(n+1) = 20 for 1023 values
+	if h := rb.Height(); h > 20 {
+		t.Errorf("Height() = %d after 1023 sorted inserts, want at most 20", h)
+	}
+	for v := 0; v < 1023; v += 2 {
+		rb.Delete(v)
+	}
+	if err := rb.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSearchTreeProperties runs the property checks the example prints
+func TestSearchTreeProperties(t *testing.T) {
+	ops := SlicesOf(Ints(-60, 60), 60)
+	properties := []struct {
+		name string
+		prop func([]int) bool
+	}{
+		{"BST in-order matches a sorted set", bstMatchesSortedSet},
+		{"Successor and Predecessor match a scan", bstNeighborsMatchScan},
+		{"RBTree keeps red-black invariants", rbKeepsInvariants},
+		{"RBTree height is at most 2*log2(n+1)", rbHeightIsLogarithmic},
+	}
+	for _, p := range properties {
+		t.Run(p.name, func(t *testing.T) {
+			if result := ForAll(ops, p.prop); !result.Passed {
+				t.Error(result)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []int{40, 10, 30, 20, 50}
+	tests := []struct {
+		name   string
+		values []int
+		p      float64
+		want   float64
+		wantOK bool
+	}{
+		{"minimum", values, 0, 10, true},
+		{"maximum", values, 100, 50, true},
+		{"median", values, 50, 30, true},
+		{"interpolated", values, 10, 14, true},
+		{"single value", []int{7}, 99, 7, true},
+		{"empty", nil, 50, 0, false},
+		{"above 100", values, 150, 0, false},
+		{"just above 100", values, math.Nextafter(100, 101), 0, false},
+		{"negative", values, -1, 0, false},
+		{"NaN", values, math.NaN(), 0, false},
+		{"+Inf", values, math.Inf(1), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Percentile(tt.values, tt.p)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Percentile(%v, %v) = %v, %t; want %v, %t", tt.values, tt.p, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPercentilesInvalidEntries(t *testing.T) {
+	got := Percentiles([]float64{1, 2, 3}, 0, 150, 50, math.NaN(), -5)
+	want := []float64{1, math.NaN(), 2, math.NaN(), math.NaN()}
+	for i := range want {
+		if got[i] != want[i] && !(math.IsNaN(got[i]) && math.IsNaN(want[i])) {
+			t.Errorf("Percentiles entry %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTDigestQuantileRange(t *testing.T) {
+	d := NewTDigest[int](50)
+	if _, ok := d.Quantile(0.5); ok {
+		t.Error("Quantile on an empty digest reported ok")
+	}
+	for i := 1; i <= 100; i++ {
+		d.Add(i)
+	}
+	for _, q := range []float64{-0.1, 1.5, math.NaN()} {
+		if v, ok := d.Quantile(q); ok {
+			t.Errorf("Quantile(%v) = %v, ok; want not ok", q, v)
+		}
+	}
+	for _, q := range []float64{0, 0.5, 1} {
+		if _, ok := d.Quantile(q); !ok {
+			t.Errorf("Quantile(%v) not ok", q)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// operatorForms runs one operator over input in both its channel and its
+// Seq form and fails the test if the two disagree
+func operatorForms[T, U any](t *testing.T, input []T, ch func(<-chan T) <-chan U, seq func(Seq[T]) Seq[U]) []U {
+	t.Helper()
+	fromChan := CollectStream(ch(StreamOf(input...)))
+	fromSeq := seq(SeqOf(input...)).Collect()
+	if !reflect.DeepEqual(fromChan, fromSeq) {
+		t.Fatalf("channel form gave %v, Seq form %v", fromChan, fromSeq)
+	}
+	return fromSeq
+}
+
+func TestDistinct(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"single", []string{"ok"}, []string{"ok"}},
+		{"all equal", []string{"ok", "ok", "ok"}, []string{"ok"}},
+		{"first occurrence wins", []string{"ok", "ok", "warn", "ok", "error", "error", "ok"}, []string{"ok", "warn", "error"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := operatorForms(t, tt.input, Distinct[string], DistinctSeq[string]); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Distinct(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	readings := []SensorReading{{"kitchen", 21.5}, {"kitchen", 21.5}, {"garage", 12.0}, {"kitchen", 21.5}}
+	got := operatorForms(t, readings, Distinct[SensorReading], DistinctSeq[SensorReading])
+	if want := readings[1:3]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Distinct on structs = %v, want %v", got, want)
+	}
+}
+
+func TestDistinctUntilChanged(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"single", []string{"ok"}, []string{"ok"}},
+		{"runs collapse", []string{"ok", "ok", "warn", "ok", "error", "error", "ok"}, []string{"ok", "warn", "ok", "error", "ok"}},
+		{"alternating keeps everything", []string{"a", "b", "a", "b"}, []string{"a", "b", "a", "b"}},
+		{"zero value first", []string{"", "", "x"}, []string{"", "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := operatorForms(t, tt.input, DistinctUntilChanged[string], DistinctUntilChangedSeq[string])
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistinctUntilChanged(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinctUntilChangedWithinTolerance(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []float64
+		tolerance float64
+		want      []float64
+	}{
+		{"sensor noise", simulatedTemperatures(), 0.5, []float64{20, 20.6, 25.2}},
+		// Compared with the last emitted value, so slow drift still shows
+		{"slow drift", []float64{0, 0.3, 0.6, 0.9, 1.2}, 0.5, []float64{0, 0.6, 1.2}},
+		{"exactly the tolerance apart", []float64{1, 1.5, 2}, 0.5, []float64{1, 1.5, 2}},
+		{"zero tolerance keeps equal values", []float64{1, 1, 1}, 0, []float64{1, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal := WithinTolerance(tt.tolerance)
+			got := operatorForms(t, tt.input,
+				func(in <-chan float64) <-chan float64 { return DistinctUntilChangedFunc(in, equal) },
+				func(s Seq[float64]) Seq[float64] { return DistinctUntilChangedFuncSeq(s, equal) })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPairwiseAndDelta(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []int
+		wantPairs []Pair[int, int]
+		wantDelta []int
+	}{
+		{"empty", nil, nil, nil},
+		{"single value only primes", []int{7}, nil, nil},
+		{"two values", []int{7, 3}, []Pair[int, int]{{7, 3}}, []int{-4}},
+		{"squares", []int{1, 4, 9, 16}, []Pair[int, int]{{1, 4}, {4, 9}, {9, 16}}, []int{3, 5, 7}},
+		{"flat", []int{2, 2, 2}, []Pair[int, int]{{2, 2}, {2, 2}}, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := operatorForms(t, tt.input, Pairwise[int], PairwiseSeq[int]); !reflect.DeepEqual(got, tt.wantPairs) {
+				t.Errorf("Pairwise(%v) = %v, want %v", tt.input, got, tt.wantPairs)
+			}
+			if got := operatorForms(t, tt.input, Delta[int], DeltaSeq[int]); !reflect.DeepEqual(got, tt.wantDelta) {
+				t.Errorf("Delta(%v) = %v, want %v", tt.input, got, tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestSpikeDetector(t *testing.T) {
+	temps := simulatedTemperatures()
+	got := CollectStream(SpikeDetector(StreamOf(temps...), 2.0))
+	if want := []Pair[float64, float64]{{20.6, 25.2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SpikeDetector(temps, 2) = %v, want %v", got, want)
+	}
+
+	// A change of exactly the threshold is not a spike, in either direction
+	got2 := CollectStream(SpikeDetector(StreamOf(0, 5, 0, 6, 0), 5))
+	if want := []Pair[int, int]{{0, 6}, {6, 0}}; !reflect.DeepEqual(got2, want) {
+		t.Errorf("SpikeDetector threshold 5 = %v, want %v", got2, want)
+	}
+}
+
+// TestSensorDataInvariants holds for any input stream; the sensor data is
+// one such input
+func TestSensorDataInvariants(t *testing.T) {
+	temps := simulatedTemperatures()
+	deltas := CollectStream(Delta(StreamOf(temps...)))
+	distinct := CollectStream(Distinct(StreamOf(temps...)))
+	exact := CollectStream(DistinctUntilChanged(StreamOf(temps...)))
+	significant := CollectStream(DistinctUntilChangedFunc(StreamOf(temps...), WithinTolerance(0.5)))
+
+	if len(deltas) != len(temps)-1 {
+		t.Errorf("Delta has %d values for %d inputs", len(deltas), len(temps))
+	}
+	for i, d := range deltas {
+		if math.Abs(temps[i]+d-temps[i+1]) > 1e-9 {
+			t.Errorf("delta %d: %v + %v != %v", i, temps[i], d, temps[i+1])
+		}
+	}
+	if len(Unique(distinct)) != len(distinct) {
+		t.Errorf("Distinct output has duplicates: %v", distinct)
+	}
+	if len(significant) > len(exact) {
+		t.Errorf("tolerance filter emitted %d values, exact filter %d", len(significant), len(exact))
+	}
+	if significant[0] != temps[0] || exact[0] != temps[0] {
+		t.Errorf("first value not emitted: %v, %v", significant, exact)
+	}
+}
+
+func TestSeqOperatorsAreLazy(t *testing.T) {
+	pulls := 0
+	counting := MapSeq(Iterate(1, func(n int) int { return n + 1 }), func(n int) int {
+		pulls++
+		return n * n
+	})
+
+	// An infinite source works because only what is consumed is pulled
+	if got := DeltaSeq(counting).Take(3).Collect(); !reflect.DeepEqual(got, []int{3, 5, 7}) {
+		t.Errorf("DeltaSeq(squares).Take(3) = %v, want [3 5 7]", got)
+	}
+	if pulls != 4 {
+		t.Errorf("three deltas pulled %d values, want 4", pulls)
+	}
+
+	pulls = 0
+	if got := DistinctSeq(MapSeq(Iterate(0, func(n int) int { return n + 1 }), func(n int) int {
+		pulls++
+		return n % 3
+	})).Take(3).Collect(); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("DistinctSeq(n %% 3).Take(3) = %v, want [0 1 2]", got)
+	}
+	if pulls != 3 {
+		t.Errorf("three distinct values pulled %d values, want 3", pulls)
+	}
+}
@@ -0,0 +1,229 @@
+// Command aistudy runs the examples of every topic module from one place:
+//
+//	aistudy list                  # every topic and its examples
+//	aistudy list concurrency      # one topic
+//	aistudy run concurrency 3     # same as: cd 01_concurrency && go run . 3
+//	aistudy run -race concurrency 18 -buggy
+//
+// Topics are discovered from the NN_name directories with a main.go at the
+// repository root, and their examples from each module's --list output, so a
+// new module only needs a topic.Main table to show up here.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/topic"
+)
+
+// topicDirPattern matches topic directories such as 01_concurrency
+var topicDirPattern = regexp.MustCompile(`^(\d+)_(\w+)$`)
+
+// topicDir is a discovered topic module
+type topicDir struct {
+	Number int
+	Name   string // Directory name without the number prefix
+	Path   string
+}
+
+// listedExample is one line of a module's --list output
+type listedExample struct {
+	Number int
+	Title  string
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.ExitCode()) // The example already reported its failure
+		}
+		fmt.Fprintf(os.Stderr, "aistudy: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		printUsage()
+		return nil
+	}
+
+	root, err := findRepoRoot()
+	if err != nil {
+		return err
+	}
+	topics, err := discoverTopics(root)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		selected := topics
+		if len(args) > 1 {
+			t, err := resolveTopic(topics, args[1])
+			if err != nil {
+				return err
+			}
+			selected = []topicDir{t}
+		}
+		return listTopics(selected)
+	case "run":
+		var goFlags []string
+		args = args[1:]
+		if len(args) > 0 && args[0] == "-race" {
+			goFlags, args = append(goFlags, "-race"), args[1:]
+		}
+		if len(args) < 2 {
+			return errors.New("usage: aistudy run [-race] <topic> <example> [args...]")
+		}
+		t, err := resolveTopic(topics, args[0])
+		if err != nil {
+			return err
+		}
+		return runExample(t, goFlags, args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q (want list or run)", args[0])
+	}
+}
+
+func printUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  aistudy list [topic]                     List topics and their examples")
+	fmt.Println("  aistudy run [-race] <topic> <example> [args...]")
+	fmt.Println("                                           Run one example, optionally under the race detector;")
+	fmt.Println("                                           add --format=json for JSON records instead of text")
+	fmt.Println()
+	fmt.Println("A topic is named by its directory (01_concurrency), its name (concurrency),")
+	fmt.Println("a unique prefix of the name (conc) or its number (1).")
+}
+
+// findRepoRoot walks up from the working directory to this module's go.mod
+func findRepoRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil && bytes.Contains(data, []byte("module github.com/Rookie0x80/AIStudy-go\n")) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not inside the AIStudy-go repository")
+		}
+		dir = parent
+	}
+}
+
+// discoverTopics returns the topic modules under root in number order
+func discoverTopics(root string) ([]topicDir, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	var topics []topicDir
+	for _, entry := range entries {
+		m := topicDirPattern.FindStringSubmatch(entry.Name())
+		if !entry.IsDir() || m == nil {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		if _, err := os.Stat(filepath.Join(path, "main.go")); err != nil {
+			continue // Planned topics have no module yet
+		}
+		number, _ := strconv.Atoi(m[1])
+		topics = append(topics, topicDir{Number: number, Name: m[2], Path: path})
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Number < topics[j].Number })
+	return topics, nil
+}
+
+// resolveTopic matches a directory name, topic name, number or unique prefix
+func resolveTopic(topics []topicDir, query string) (topicDir, error) {
+	var prefixed []topicDir
+	for _, t := range topics {
+		if query == filepath.Base(t.Path) || query == t.Name {
+			return t, nil
+		}
+		if n, err := strconv.Atoi(query); err == nil && n == t.Number {
+			return t, nil
+		}
+		if strings.HasPrefix(t.Name, query) {
+			prefixed = append(prefixed, t)
+		}
+	}
+	switch len(prefixed) {
+	case 1:
+		return prefixed[0], nil
+	case 0:
+		return topicDir{}, fmt.Errorf("unknown topic %q (see aistudy list)", query)
+	default:
+		return topicDir{}, fmt.Errorf("topic %q is ambiguous", query)
+	}
+}
+
+// listExamples asks a module for its examples through the --list flag
+func listExamples(t topicDir) ([]listedExample, error) {
+	cmd := exec.Command("go", "run", ".", topic.ListFlag)
+	cmd.Dir = t.Path
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", filepath.Base(t.Path), err)
+	}
+
+	var examples []listedExample
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		number, title, ok := strings.Cut(scanner.Text(), "\t")
+		n, err := strconv.Atoi(number)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("listing %s: unexpected line %q", filepath.Base(t.Path), scanner.Text())
+		}
+		examples = append(examples, listedExample{Number: n, Title: title})
+	}
+	return examples, scanner.Err()
+}
+
+func listTopics(topics []topicDir) error {
+	for i, t := range topics {
+		examples, err := listExamples(t)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s (%s)\n", t.Name, filepath.Base(t.Path))
+		for _, e := range examples {
+			fmt.Printf("  %3d  %s\n", e.Number, e.Title)
+		}
+	}
+	return nil
+}
+
+// runExample runs the example inside its module directory, so relative paths
+// and extra arguments behave exactly as with go run . N
+func runExample(t topicDir, goFlags, args []string) error {
+	goArgs := append(append([]string{"run"}, goFlags...), ".")
+	cmd := exec.Command("go", append(goArgs, args...)...)
+	cmd.Dir = t.Path
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
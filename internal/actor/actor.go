@@ -0,0 +1,130 @@
+// Package actor is a typed counterpart of the Actor in
+// 01_concurrency/07_actor.go. There, every message is a Message interface
+// value and each handler starts with a type assertion; here the mailbox,
+// the handler and Send are all parameterized by the message type M, so a
+// wrong message is a compile error instead of a runtime branch.
+//
+// 01_concurrency bridges the two (BridgeToTyped, ForwardToUntyped) so typed
+// and untyped actors can be mixed in one system.
+package actor
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrStopped is returned when sending to an actor that has stopped
+	ErrStopped = errors.New("actor: stopped")
+	// ErrTimeout is returned by Ask when no reply arrives in time
+	ErrTimeout = errors.New("actor: ask timed out")
+)
+
+// Actor processes messages of type M one at a time on its own goroutine
+type Actor[M any] struct {
+	id       string
+	mailbox  chan M
+	handler  func(M)
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New creates an actor with a mailbox of the given capacity
+func New[M any](id string, capacity int, handler func(M)) *Actor[M] {
+	return &Actor[M]{
+		id:      id,
+		mailbox: make(chan M, capacity),
+		handler: handler,
+		stop:    make(chan struct{}),
+	}
+}
+
+// ID returns the actor's name
+func (a *Actor[M]) ID() string {
+	return a.id
+}
+
+// Start starts the actor's goroutine
+func (a *Actor[M]) Start() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		for {
+			select {
+			case msg := <-a.mailbox:
+				a.handler(msg)
+			case <-a.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Send enqueues msg, blocking while the mailbox is full
+func (a *Actor[M]) Send(msg M) error {
+	select {
+	case <-a.stop:
+		return ErrStopped // Checked first: select picks randomly among ready cases
+	default:
+	}
+	select {
+	case a.mailbox <- msg:
+		return nil
+	case <-a.stop:
+		return ErrStopped
+	}
+}
+
+// Stop stops the actor, dropping queued messages, and waits for it. Safe to
+// call more than once, but not from the actor's own handler.
+func (a *Actor[M]) Stop() {
+	a.stopOnce.Do(func() { close(a.stop) })
+	a.wg.Wait()
+}
+
+// Request pairs a query of type Q with a reply slot of type R; an actor of
+// Request[Q, R] answers with Reply instead of a hand-made Response channel
+type Request[Q, R any] struct {
+	Body  Q
+	reply chan R
+}
+
+// Reply answers the request. It never blocks, and only the first reply counts.
+func (r Request[Q, R]) Reply(value R) {
+	select {
+	case r.reply <- value:
+	default:
+	}
+}
+
+// Ask sends body to a and waits up to timeout for the typed reply
+func Ask[Q, R any](a *Actor[Request[Q, R]], body Q, timeout time.Duration) (R, error) {
+	var zero R
+	req := Request[Q, R]{Body: body, reply: make(chan R, 1)}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-a.stop:
+		return zero, ErrStopped
+	default:
+	}
+	select {
+	case a.mailbox <- req:
+	case <-a.stop:
+		return zero, ErrStopped
+	case <-timer.C:
+		return zero, ErrTimeout
+	}
+
+	select {
+	case value := <-req.reply:
+		return value, nil
+	case <-a.stop:
+		return zero, ErrStopped
+	case <-timer.C:
+		return zero, ErrTimeout
+	}
+}
@@ -0,0 +1,200 @@
+// Package chans collects the channel idioms the concurrency examples kept
+// writing by hand: copying a stream to several consumers (Tee, Broadcast),
+// merging streams (Merge) and grouping values (Batch, Window).
+//
+// Every helper owns the channels it returns and closes them when its input
+// closes or ctx is cancelled, so ranging over a result always terminates.
+// Cancelling ctx is also how a consumer that stops early releases the
+// goroutines behind a helper.
+package chans
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// send delivers v on out unless ctx ends first, and reports whether it did
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Tee copies every value of in to two channels. See Broadcast for how the
+// copies are paced.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	outs := Broadcast(ctx, in, 2)
+	return outs[0], outs[1]
+}
+
+// Broadcast copies every value of in to n channels. Delivery is in
+// lockstep: the next value is read only once every output has taken the
+// current one, so the slowest consumer sets the pace and none falls behind
+// by more than one value. Consumers may read the outputs in any order.
+func Broadcast[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			// One sender per output, so a consumer blocked on another output
+			// does not hold this one up
+			var wg sync.WaitGroup
+			wg.Add(len(outs))
+			for _, out := range outs {
+				go func(out chan<- T) {
+					defer wg.Done()
+					send(ctx, out, v)
+				}(out)
+			}
+			wg.Wait()
+		}
+	}()
+	return result
+}
+
+// recv reads from in unless ctx ends first; ok is false once either happens
+func recv[T any](ctx context.Context, in <-chan T) (v T, ok bool) {
+	select {
+	case v, ok = <-in:
+		return v, ok
+	case <-ctx.Done():
+		return v, false
+	}
+}
+
+// Merge forwards the values of all inputs to one channel, in arrival order
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func(in <-chan T) {
+			defer wg.Done()
+			for {
+				v, ok := recv(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Batch groups the values of in into slices of up to size values. A batch
+// is sent once it is full or its first value has waited maxWait, so a slow
+// trickle of input is not held back indefinitely; a maxWait of zero or less
+// waits for full batches only. The last, partial batch is sent when in
+// closes.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	out := make(chan []T)
+	size = max(size, 1)
+	go func() {
+		defer close(out)
+		var (
+			batch  []T
+			timer  *time.Timer
+			timerC <-chan time.Time
+		)
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timerC = nil, nil
+			}
+			full := batch
+			batch = nil
+			return send(ctx, out, full)
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timerC = timer.C
+				}
+				if len(batch) == size && !flush() {
+					return
+				}
+			case <-timerC:
+				timer, timerC = nil, nil
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Window emits sliding windows of size consecutive values, starting a new
+// window every step values: step 1 gives a moving window, step == size
+// splits the stream into disjoint chunks, and step > size skips the values
+// between windows. Every window is a fresh slice the receiver may keep.
+// Values left over when in closes that do not fill a window are dropped.
+func Window[T any](ctx context.Context, in <-chan T, size, step int) <-chan []T {
+	out := make(chan []T)
+	size, step = max(size, 1), max(step, 1)
+	go func() {
+		defer close(out)
+		window := make([]T, 0, size)
+		skip := 0 // Values to drop before the next window starts
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			window = append(window, v)
+			if len(window) < size {
+				continue
+			}
+			if !send(ctx, out, append([]T(nil), window...)) {
+				return
+			}
+			if step >= size {
+				window, skip = window[:0], step-size
+			} else {
+				window = append(window[:0], window[step:]...)
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,167 @@
+// Package leakcheck finds goroutines an example left behind. A Snapshot
+// records which goroutines exist before the example runs; Leaked, called
+// afterwards, returns the ones that appeared since and are still alive once a
+// grace period for winding down has passed, with their stack traces.
+//
+// A leaked goroutine blocked on a channel, a lock or a WaitGroup is usually
+// waiting for something that will never happen, which inside a single
+// example is a deadlock that the runtime cannot detect because other
+// goroutines are still alive. Report points those out separately from
+// goroutines that are merely sleeping or still running.
+package leakcheck
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGrace is how long Leaked waits for new goroutines to finish
+const DefaultGrace = time.Second
+
+// Goroutine is one entry of a full stack dump
+type Goroutine struct {
+	ID    int64
+	State string // As printed by the runtime, e.g. "chan receive, 2 minutes"
+	Stack string // The frames below the "goroutine N [state]:" header
+}
+
+// Blocked reports whether the goroutine is waiting on a synchronization
+// primitive rather than sleeping, running or doing I/O
+func (g Goroutine) Blocked() bool {
+	for _, prefix := range []string{"chan send", "chan receive", "select", "sync.", "semacquire"} {
+		if strings.HasPrefix(g.State, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot is the set of goroutines alive at some point
+type Snapshot map[int64]struct{}
+
+// Take records the goroutines alive now
+func Take() Snapshot {
+	s := make(Snapshot)
+	for _, g := range dump() {
+		s[g.ID] = struct{}{}
+	}
+	return s
+}
+
+// Leaked returns the goroutines started since s that are still alive after
+// at most grace. It returns as soon as they have all finished, so a clean
+// example costs no waiting.
+func (s Snapshot) Leaked(grace time.Duration) []Goroutine {
+	deadline := time.Now().Add(grace)
+	for {
+		leaked := s.newSince()
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			return leaked
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newSince returns the goroutines not in s, other than the caller and the
+// runtime's own helpers
+func (s Snapshot) newSince() []Goroutine {
+	self := currentID()
+	var leaked []Goroutine
+	for _, g := range dump() {
+		if _, ok := s[g.ID]; ok || g.ID == self || isSystem(g) {
+			continue
+		}
+		leaked = append(leaked, g)
+	}
+	sort.Slice(leaked, func(i, j int) bool { return leaked[i].ID < leaked[j].ID })
+	return leaked
+}
+
+// isSystem reports goroutines the standard library starts lazily and keeps
+// for the life of the process, which are not the example's doing
+func isSystem(g Goroutine) bool {
+	for _, frame := range []string{"os/signal.signal_recv", "os/signal.loop", "runtime.ensureSigM"} {
+		if strings.Contains(g.Stack, frame) {
+			return true
+		}
+	}
+	return false
+}
+
+// Report prints leaked goroutines with their stacks, blocked ones first,
+// and returns how many there were
+func Report(w io.Writer, leaked []Goroutine) int {
+	if len(leaked) == 0 {
+		fmt.Fprintln(w, "[leakcheck] no leaked goroutines")
+		return 0
+	}
+
+	var blocked, other []Goroutine
+	for _, g := range leaked {
+		if g.Blocked() {
+			blocked = append(blocked, g)
+		} else {
+			other = append(other, g)
+		}
+	}
+	fmt.Fprintf(w, "[leakcheck] %d leaked goroutine(s), %d blocked\n", len(leaked), len(blocked))
+	for _, g := range blocked {
+		fmt.Fprintf(w, "\ngoroutine %d [%s] (blocked, likely forever):\n%s", g.ID, g.State, g.Stack)
+	}
+	for _, g := range other {
+		fmt.Fprintf(w, "\ngoroutine %d [%s]:\n%s", g.ID, g.State, g.Stack)
+	}
+	return len(leaked)
+}
+
+// dump parses a stack dump of every goroutine
+func dump() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var goroutines []Goroutine
+	for _, block := range bytes.Split(buf, []byte("\n\n")) {
+		if g, ok := parse(string(block)); ok {
+			goroutines = append(goroutines, g)
+		}
+	}
+	return goroutines
+}
+
+// parse reads one "goroutine N [state]:" block of a stack dump
+func parse(block string) (Goroutine, bool) {
+	header, stack, _ := strings.Cut(block, "\n")
+	rest, ok := strings.CutPrefix(header, "goroutine ")
+	if !ok {
+		return Goroutine{}, false
+	}
+	idText, state, ok := strings.Cut(rest, " [")
+	if !ok {
+		return Goroutine{}, false
+	}
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil {
+		return Goroutine{}, false
+	}
+	return Goroutine{ID: id, State: strings.TrimSuffix(state, "]:"), Stack: stack + "\n"}, true
+}
+
+// currentID returns the calling goroutine's ID from its own stack header
+func currentID() int64 {
+	buf := make([]byte, 64)
+	g, _ := parse(string(buf[:runtime.Stack(buf, false)]))
+	return g.ID
+}
@@ -0,0 +1,360 @@
+// Package pubsub is a typed counterpart of the Subject in
+// 01_concurrency/10_reactive.go. A Subject carries interface{} values, so
+// every observer starts with a type assertion and a publisher can emit
+// anything; here a Broker hands out Topic[T] handles, Publish takes a T and
+// each subscriber receives on a chan T.
+//
+// Every subscriber has its own buffer. What Publish does when that buffer is
+// full is the topic's Policy: wait, drop a value, or cut the subscriber off,
+// so one slow reader cannot silently stall the others unless asked to.
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrClosed is returned when publishing to a closed topic or broker
+	ErrClosed = errors.New("pubsub: closed")
+	// ErrTopicType is returned by OpenTopic when the name is already taken
+	// by a topic of another element type
+	ErrTopicType = errors.New("pubsub: topic exists with a different type")
+	// ErrSlowSubscriber is a subscription's Err after the Disconnect policy
+	// removed it
+	ErrSlowSubscriber = errors.New("pubsub: subscriber too slow")
+)
+
+// Policy decides what Publish does when a subscriber's buffer is full
+type Policy int
+
+const (
+	// Block waits for room, so the slowest subscriber paces the publisher.
+	// Publish gives up when its ctx ends.
+	Block Policy = iota
+	// DropNewest discards the value being published, for that subscriber only
+	DropNewest
+	// DropOldest evicts the oldest buffered value to make room, so a lagging
+	// subscriber sees the most recent values
+	DropOldest
+	// Disconnect unsubscribes the slow subscriber and closes its channel
+	Disconnect
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Block:
+		return "Block"
+	case DropNewest:
+		return "DropNewest"
+	case DropOldest:
+		return "DropOldest"
+	case Disconnect:
+		return "Disconnect"
+	default:
+		return "Policy(?)"
+	}
+}
+
+// TopicOptions configure a topic when it is created
+type TopicOptions struct {
+	Buffer int // Per-subscriber channel capacity
+	Policy Policy
+}
+
+// Stats counts a topic's traffic since it was created
+type Stats struct {
+	Published    int64 // Successful Publish calls
+	Delivered    int64 // Values handed to subscriber buffers
+	Dropped      int64 // Values a subscriber lost to DropNewest or DropOldest
+	Disconnected int64 // Subscribers removed by Disconnect
+}
+
+// topic is what the broker needs from a Topic[T] whatever its T
+type topic interface {
+	Close()
+}
+
+// Broker owns a set of named topics
+type Broker struct {
+	mu     sync.Mutex
+	topics map[string]topic
+	closed bool
+}
+
+// NewBroker creates an empty broker
+func NewBroker() *Broker {
+	return &Broker{topics: make(map[string]topic)}
+}
+
+// OpenTopic returns the topic called name, creating it with opts on first
+// use; later calls get the same topic and their opts are ignored. Methods
+// cannot have type parameters, so this is a function rather than a method
+// on Broker.
+func OpenTopic[T any](b *Broker, name string, opts TopicOptions) (*Topic[T], error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	if existing, ok := b.topics[name]; ok {
+		t, ok := existing.(*Topic[T])
+		if !ok {
+			return nil, ErrTopicType
+		}
+		return t, nil
+	}
+	if opts.Buffer < 0 {
+		opts.Buffer = 0
+	}
+	t := &Topic[T]{name: name, opts: opts, done: make(chan struct{})}
+	b.topics[name] = t
+	return t, nil
+}
+
+// Topics lists the broker's topic names in order
+func (b *Broker) Topics() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, 0, len(b.topics))
+	for name := range b.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close closes every topic, which ends every subscriber's range loop. Safe to
+// call more than once.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	b.closed = true
+	topics := make([]topic, 0, len(b.topics))
+	for _, t := range b.topics {
+		topics = append(topics, t)
+	}
+	b.mu.Unlock()
+
+	for _, t := range topics {
+		t.Close()
+	}
+}
+
+// Topic is a named stream of T values fanned out to its subscribers
+type Topic[T any] struct {
+	name string
+	opts TopicOptions
+
+	// Publish holds mu for reading while it sends, so removing a subscriber
+	// (which closes its channel) waits until no send can be in flight
+	mu        sync.RWMutex
+	subs      []*Subscription[T]
+	closed    bool
+	done      chan struct{} // Closed as Close starts, to release blocked publishers
+	closeOnce sync.Once
+
+	published, delivered, dropped, disconnected atomic.Int64
+}
+
+// Name returns the topic's name
+func (t *Topic[T]) Name() string { return t.name }
+
+// Policy returns the topic's slow-subscriber policy
+func (t *Topic[T]) Policy() Policy { return t.opts.Policy }
+
+// Subscribe adds a subscriber that receives every value published from now
+// on. On a closed topic the subscription's channel is already closed.
+func (t *Topic[T]) Subscribe() *Subscription[T] {
+	s := &Subscription[T]{
+		topic: t,
+		ch:    make(chan T, t.opts.Buffer),
+		done:  make(chan struct{}),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		s.end(ErrClosed)
+		return s
+	}
+	t.subs = append(t.subs, s)
+	return s
+}
+
+// Subscribers returns the number of current subscribers
+func (t *Topic[T]) Subscribers() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.subs)
+}
+
+// Publish delivers v to every subscriber, applying the topic's policy to
+// those whose buffers are full. Only Block can wait; it returns ctx's error
+// if ctx ends first, by which time some subscribers may already have v.
+func (t *Topic[T]) Publish(ctx context.Context, v T) error {
+	var slow []*Subscription[T]
+
+	t.mu.RLock()
+	if t.closed {
+		t.mu.RUnlock()
+		return ErrClosed
+	}
+	var err error
+	for _, s := range t.subs {
+		err = t.deliver(ctx, s, v)
+		if err == errFull {
+			slow, err = append(slow, s), nil
+		}
+		if err != nil {
+			break
+		}
+	}
+	t.mu.RUnlock()
+
+	// Disconnect needs the write lock, so it happens after the sends
+	for _, s := range slow {
+		if t.remove(s, ErrSlowSubscriber) {
+			t.disconnected.Add(1)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	t.published.Add(1)
+	return nil
+}
+
+// errFull tells Publish to disconnect a subscriber
+var errFull = errors.New("pubsub: subscriber buffer full")
+
+// deliver sends v to one subscriber; the caller holds t.mu for reading
+func (t *Topic[T]) deliver(ctx context.Context, s *Subscription[T], v T) error {
+	select {
+	case s.ch <- v:
+		t.delivered.Add(1)
+		return nil
+	default:
+	}
+
+	switch t.opts.Policy {
+	case DropNewest:
+		s.dropped.Add(1)
+		t.dropped.Add(1)
+	case DropOldest:
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+			t.dropped.Add(1)
+		default:
+		}
+		// Another publisher may have refilled the slot; then v is lost instead
+		select {
+		case s.ch <- v:
+			t.delivered.Add(1)
+		default:
+			s.dropped.Add(1)
+			t.dropped.Add(1)
+		}
+	case Disconnect:
+		return errFull
+	default: // Block
+		select {
+		case s.ch <- v:
+			t.delivered.Add(1)
+		case <-s.done: // Unsubscribed while we waited
+		case <-t.done: // Closed while we waited
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// remove unsubscribes s and reports whether it was still subscribed
+func (t *Topic[T]) remove(s *Subscription[T], reason error) bool {
+	s.stop()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, sub := range t.subs {
+		if sub == s {
+			t.subs = append(t.subs[:i], t.subs[i+1:]...)
+			s.end(reason)
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes every subscription; buffered values can still be received.
+// Later Publish calls return ErrClosed. Safe to call more than once.
+func (t *Topic[T]) Close() {
+	t.closeOnce.Do(func() { close(t.done) }) // So the Lock below can't wait on a blocked Publish
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for _, s := range t.subs {
+		s.end(ErrClosed)
+	}
+	t.subs = nil
+}
+
+// Stats returns the topic's counters
+func (t *Topic[T]) Stats() Stats {
+	return Stats{
+		Published:    t.published.Load(),
+		Delivered:    t.delivered.Load(),
+		Dropped:      t.dropped.Load(),
+		Disconnected: t.disconnected.Load(),
+	}
+}
+
+// Subscription is one subscriber's view of a topic
+type Subscription[T any] struct {
+	topic    *Topic[T]
+	ch       chan T
+	done     chan struct{} // Closed first, to release a blocked publisher
+	stopOnce sync.Once
+	dropped  atomic.Int64
+	err      atomic.Pointer[error]
+}
+
+// C returns the channel values arrive on. It is closed when the subscription
+// ends; ranging over it is the usual way to consume a topic.
+func (s *Subscription[T]) C() <-chan T { return s.ch }
+
+// Unsubscribe stops delivery and closes C. Values already buffered can still
+// be received. Safe to call more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	s.topic.remove(s, nil)
+}
+
+// Dropped counts the values this subscriber lost to a full buffer
+func (s *Subscription[T]) Dropped() int64 { return s.dropped.Load() }
+
+// Err reports why the subscription ended: nil while active or after
+// Unsubscribe, ErrClosed when the topic closed, ErrSlowSubscriber when the
+// Disconnect policy removed it
+func (s *Subscription[T]) Err() error {
+	if err := s.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (s *Subscription[T]) stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// end closes the channel; the caller holds the topic's write lock
+func (s *Subscription[T]) end(reason error) {
+	s.stop()
+	if reason != nil {
+		s.err.Store(&reason)
+	}
+	close(s.ch)
+}
@@ -0,0 +1,536 @@
+// Package reflectutil collects the defensive reflection helpers the
+// reflection examples kept rewriting: reading and writing fields by path
+// ("Address.City", "Tags[0]", "Meta[team]"), calling functions and methods
+// with argument checks, and indexing slices and maps, all reporting
+// problems as errors instead of panicking.
+//
+// Value wraps reflect.Value so that a chain of lookups can be written
+// without a check at every step: the first failure is remembered, with the
+// path that led to it, and every later step is a no-op.
+package reflectutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotFound means a field, method, index or key does not exist
+	ErrNotFound = errors.New("reflectutil: not found")
+	// ErrNil means a step went through a nil pointer, interface or map
+	ErrNil = errors.New("reflectutil: nil value")
+	// ErrType means a value has the wrong kind or type for an operation
+	ErrType = errors.New("reflectutil: wrong type")
+	// ErrNotSettable means a target cannot be set: an unexported field,
+	// or a value reached without a pointer
+	ErrNotSettable = errors.New("reflectutil: cannot be set")
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// PathError is a failure at one step of a path
+type PathError struct {
+	Path string // The path up to and including the failing step
+	Err  error
+}
+
+func (e *PathError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is a panic recovered from a called function
+type PanicError struct {
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("reflectutil: call panicked: %v", e.Value)
+}
+
+// Value is a reflect.Value that carries the first error met on the way to
+// it. Every method is safe on a failed Value and keeps its error.
+type Value struct {
+	v    reflect.Value
+	path string
+	err  error
+}
+
+// Of wraps x. A nil x gives a Value that is nil but not failed.
+func Of(x interface{}) Value {
+	return Value{v: reflect.ValueOf(x)}
+}
+
+func (v Value) fail(step string, err error) Value {
+	return Value{path: v.path + step, err: &PathError{Path: v.path + step, Err: err}}
+}
+
+// Err returns the first error met, or nil
+func (v Value) Err() error {
+	return v.err
+}
+
+// Reflect returns the underlying reflect.Value, invalid after an error
+func (v Value) Reflect() reflect.Value {
+	return v.v
+}
+
+// IsNil reports whether the value is missing or a nil pointer, interface,
+// map, slice, channel or function. Unlike reflect.Value.IsNil it accepts
+// every kind: an int is never nil.
+func (v Value) IsNil() bool {
+	if !v.v.IsValid() {
+		return true
+	}
+	switch v.v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.v.IsNil()
+	}
+	return false
+}
+
+// Indirect follows pointers and interfaces to the value they hold
+func (v Value) Indirect() Value {
+	for v.err == nil && (v.v.Kind() == reflect.Ptr || v.v.Kind() == reflect.Interface) {
+		if v.v.IsNil() {
+			return v.fail("", ErrNil)
+		}
+		v.v = v.v.Elem()
+	}
+	if v.err == nil && !v.v.IsValid() {
+		return v.fail("", ErrNil)
+	}
+	return v
+}
+
+// Field steps into a struct field, through any pointers on the way
+func (v Value) Field(name string) Value {
+	v = v.Indirect()
+	if v.err != nil {
+		return v
+	}
+	step := "." + name
+	if v.path == "" {
+		step = name
+	}
+	if v.v.Kind() != reflect.Struct {
+		return v.fail(step, fmt.Errorf("%w: %v has no fields", ErrType, v.v.Type()))
+	}
+	sf, ok := v.v.Type().FieldByName(name)
+	if !ok {
+		return v.fail(step, fmt.Errorf("%w: %v has no field %s", ErrNotFound, v.v.Type(), name))
+	}
+	// FieldByName would panic on a field promoted through a nil embedded
+	// pointer; FieldByIndexErr reports it
+	f, err := v.v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return v.fail(step, fmt.Errorf("%w: %v", ErrNil, err))
+	}
+	return Value{v: f, path: v.path + step}
+}
+
+// Index steps into element i of a slice, array or string
+func (v Value) Index(i int) Value {
+	v = v.Indirect()
+	if v.err != nil {
+		return v
+	}
+	step := "[" + strconv.Itoa(i) + "]"
+	switch v.v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.String:
+		if i < 0 || i >= v.v.Len() {
+			return v.fail(step, fmt.Errorf("%w: index %d out of range [0:%d]", ErrNotFound, i, v.v.Len()))
+		}
+		return Value{v: v.v.Index(i), path: v.path + step}
+	}
+	return v.fail(step, fmt.Errorf("%w: cannot index %v", ErrType, v.v.Type()))
+}
+
+// Key steps into the entry of a map. key is converted to the key type
+// where Go would allow it, so "7" can look up a map[string]T but not a
+// map[int]T.
+func (v Value) Key(key interface{}) Value {
+	v = v.Indirect()
+	if v.err != nil {
+		return v
+	}
+	step := fmt.Sprintf("[%v]", key)
+	if v.v.Kind() != reflect.Map {
+		return v.fail(step, fmt.Errorf("%w: %v is not a map", ErrType, v.v.Type()))
+	}
+	k, err := assignable(reflect.ValueOf(key), v.v.Type().Key())
+	if err != nil {
+		return v.fail(step, err)
+	}
+	elem := v.v.MapIndex(k)
+	if !elem.IsValid() {
+		return v.fail(step, fmt.Errorf("%w: no key %v", ErrNotFound, key))
+	}
+	return Value{v: elem, path: v.path + step}
+}
+
+// Path follows a path such as "Address.City", "Items[2].SKU" or
+// "Meta[team]". Map keys in brackets are strings, or integers for maps
+// with integer keys.
+func (v Value) Path(path string) Value {
+	steps, err := parsePath(path)
+	if err != nil {
+		return v.fail(path, err)
+	}
+	for _, s := range steps {
+		if s.field != "" {
+			v = v.Field(s.field)
+			continue
+		}
+		ind := v.Indirect()
+		switch {
+		case ind.err != nil:
+			return ind
+		case ind.v.Kind() == reflect.Map:
+			v = ind.Key(mapKey(ind.v.Type().Key(), s.key))
+		case s.isIndex:
+			v = ind.Index(s.index)
+		default:
+			v = ind.Key(s.key) // Reports that a non-map cannot take a key
+		}
+	}
+	return v
+}
+
+// Interface returns the value, or an error if it was not reached or is an
+// unexported field
+func (v Value) Interface() (interface{}, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	if !v.v.IsValid() {
+		return nil, nil
+	}
+	if !v.v.CanInterface() {
+		return nil, &PathError{Path: v.path, Err: fmt.Errorf("%w: unexported field", ErrNotSettable)}
+	}
+	return v.v.Interface(), nil
+}
+
+// Set stores x in the value, which must have been reached through a
+// pointer. See TrySet for the conversions allowed.
+func (v Value) Set(x interface{}) error {
+	if v.err != nil {
+		return v.err
+	}
+	if err := TrySet(v.v, x); err != nil {
+		return &PathError{Path: v.path, Err: err}
+	}
+	return nil
+}
+
+// Get reads the value at path below obj
+func Get(obj interface{}, path string) (interface{}, error) {
+	return Of(obj).Path(path).Interface()
+}
+
+// Set writes x at path below obj, which must be a pointer. Nil pointers
+// on the way are allocated, and a path ending in a map key stores the
+// entry; map entries cannot be set further down, as Go does not allow
+// taking their address.
+func Set(obj interface{}, path string, x interface{}) error {
+	root := reflect.ValueOf(obj)
+	if root.Kind() != reflect.Ptr || root.IsNil() {
+		return &PathError{Path: path, Err: fmt.Errorf("%w: target must be a non-nil pointer, got %T", ErrNotSettable, obj)}
+	}
+	steps, err := parsePath(path)
+	if err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+
+	v := Value{v: root}
+	for i, s := range steps {
+		// Allocate nil pointers so the step has a struct to go into
+		for v.v.Kind() == reflect.Ptr && v.v.IsNil() && v.v.CanSet() {
+			v.v.Set(reflect.New(v.v.Type().Elem()))
+		}
+		ind := v.Indirect()
+		if s.field == "" && ind.err == nil && ind.v.Kind() == reflect.Map {
+			if i != len(steps)-1 {
+				return &PathError{Path: path, Err: fmt.Errorf("%w: cannot set below a map entry", ErrNotSettable)}
+			}
+			return setMapEntry(ind, s.key, x)
+		}
+		switch {
+		case s.field != "":
+			v = v.Field(s.field)
+		case s.isIndex:
+			v = v.Index(s.index)
+		default:
+			v = v.Key(s.key)
+		}
+		if v.err != nil {
+			return v.err
+		}
+	}
+	return v.Set(x)
+}
+
+func setMapEntry(m Value, key string, x interface{}) error {
+	step := m.path + "[" + key + "]"
+	if m.v.IsNil() {
+		if !m.v.CanSet() {
+			return &PathError{Path: step, Err: ErrNil}
+		}
+		m.v.Set(reflect.MakeMap(m.v.Type()))
+	}
+	k, err := assignable(reflect.ValueOf(mapKey(m.v.Type().Key(), key)), m.v.Type().Key())
+	if err != nil {
+		return &PathError{Path: step, Err: err}
+	}
+	elem, err := assignable(reflect.ValueOf(x), m.v.Type().Elem())
+	if err != nil {
+		return &PathError{Path: step, Err: err}
+	}
+	m.v.SetMapIndex(k, elem)
+	return nil
+}
+
+// TrySet stores x in dst without panicking. x must be assignable to dst's
+// type, or convertible without changing its meaning: a number converts to
+// another number type it fits in exactly, but never to a string, where
+// Go's conversion would produce a character rather than digits. A nil x
+// sets the zero value.
+func TrySet(dst reflect.Value, x interface{}) error {
+	if !dst.IsValid() {
+		return ErrNil
+	}
+	if !dst.CanSet() {
+		return fmt.Errorf("%w: %v value is not addressable or is unexported", ErrNotSettable, dst.Type())
+	}
+	val, err := assignable(reflect.ValueOf(x), dst.Type())
+	if err != nil {
+		return err
+	}
+	dst.Set(val)
+	return nil
+}
+
+// assignable returns x as a value of type t, converting where that keeps
+// its meaning
+func assignable(x reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if !x.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if x.Type().AssignableTo(t) {
+		return x, nil
+	}
+	if isNumber(x.Type()) && isNumber(t) && x.Type().ConvertibleTo(t) {
+		// Only if converting back gives the same number: 2.5 does not
+		// become 2, nor 300 a uint8 44. A float keeps its meaning as the
+		// nearest float of another size.
+		converted := x.Convert(t)
+		bothFloat := x.CanFloat() && converted.CanFloat()
+		if !bothFloat && converted.Convert(x.Type()).Interface() != x.Interface() {
+			return reflect.Value{}, fmt.Errorf("%w: %v does not fit in %v", ErrType, x.Interface(), t)
+		}
+		return converted, nil
+	}
+	if x.Type().ConvertibleTo(t) && !isNumber(x.Type()) && !isNumber(t) {
+		return x.Convert(t), nil // Named types over the same underlying type
+	}
+	return reflect.Value{}, fmt.Errorf("%w: cannot use %v as %v", ErrType, x.Type(), t)
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// TryCall calls fn, a function, with args. The argument count and types
+// are checked first, nil arguments stand for zero values, and a panic in
+// fn is returned as a *PanicError. A trailing error result is split off
+// and returned as the error.
+func TryCall(fn interface{}, args ...interface{}) (results []interface{}, err error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%w: %T is not a function", ErrType, fn)
+	}
+	if fv.IsNil() {
+		return nil, ErrNil
+	}
+	return call(fv, args)
+}
+
+// TryCallMethod calls the method name of obj, as TryCall does
+func TryCallMethod(obj interface{}, name string, args ...interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(obj)
+	if !v.IsValid() {
+		return nil, &PathError{Path: name, Err: ErrNil}
+	}
+	m := v.MethodByName(name)
+	if !m.IsValid() {
+		hint := ""
+		if v.Kind() != reflect.Ptr && reflect.New(v.Type()).MethodByName(name).IsValid() {
+			hint = " (it has a pointer receiver; pass a pointer)"
+		}
+		return nil, &PathError{Path: name, Err: fmt.Errorf("%w: %v has no method %s%s", ErrNotFound, v.Type(), name, hint)}
+	}
+	results, err := call(m, args)
+	if err != nil {
+		var pe *PanicError
+		if !errors.As(err, &pe) {
+			err = &PathError{Path: name, Err: err}
+		}
+	}
+	return results, err
+}
+
+func call(fv reflect.Value, args []interface{}) (results []interface{}, err error) {
+	ft := fv.Type()
+	if n := len(args); (!ft.IsVariadic() && n != ft.NumIn()) || (ft.IsVariadic() && n < ft.NumIn()-1) {
+		return nil, fmt.Errorf("%w: %v takes %d arguments, got %d", ErrType, ft, ft.NumIn(), n)
+	}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		var t reflect.Type
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			t = ft.In(ft.NumIn() - 1).Elem()
+		} else {
+			t = ft.In(i)
+		}
+		v, err := assignable(reflect.ValueOf(a), t)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		in[i] = v
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			results, err = nil, &PanicError{Value: r}
+		}
+	}()
+	out := fv.Call(in)
+
+	if n := len(out); n > 0 && ft.Out(n-1) == errorType {
+		if e, _ := out[n-1].Interface().(error); e != nil {
+			err = e
+		}
+		out = out[:n-1]
+	}
+	results = make([]interface{}, len(out))
+	for i, o := range out {
+		results[i] = o.Interface()
+	}
+	return results, err
+}
+
+// Len returns the length of a slice, array, string, map or channel,
+// through pointers; nil has length 0
+func Len(obj interface{}) (int, error) {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return 0, nil
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.String, reflect.Map, reflect.Chan:
+		return v.Len(), nil
+	}
+	return 0, fmt.Errorf("%w: %v has no length", ErrType, v.Type())
+}
+
+// Index returns element i of a slice, array or string
+func Index(obj interface{}, i int) (interface{}, error) {
+	return Of(obj).Index(i).Interface()
+}
+
+// Lookup returns the entry of a map for key, and whether it exists
+func Lookup(m interface{}, key interface{}) (interface{}, bool, error) {
+	v := Of(m).Indirect()
+	if v.err != nil {
+		return nil, false, v.err
+	}
+	if v.v.Kind() != reflect.Map {
+		return nil, false, fmt.Errorf("%w: %v is not a map", ErrType, v.v.Type())
+	}
+	entry := v.Key(key)
+	if errors.Is(entry.err, ErrNotFound) {
+		return nil, false, nil
+	}
+	x, err := entry.Interface()
+	return x, err == nil, err
+}
+
+// step is one segment of a path: a field name, or a bracketed index or key
+type step struct {
+	field   string
+	key     string // Bracket contents, for a map
+	index   int
+	isIndex bool // The bracket holds an integer
+}
+
+func parsePath(path string) ([]step, error) {
+	var steps []step
+	rest := path
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("reflectutil: unclosed [ in path %q", path)
+			}
+			key := strings.Trim(rest[1:end], `"`)
+			s := step{key: key}
+			if n, err := strconv.Atoi(key); err == nil {
+				s.index, s.isIndex = n, true
+			}
+			steps = append(steps, s)
+			rest = rest[end+1:]
+		case rest[0] == '.':
+			rest = rest[1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			steps = append(steps, step{field: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("reflectutil: empty path")
+	}
+	return steps, nil
+}
+
+// mapKey turns bracket text into a key of type t where it can, leaving
+// it a string otherwise so the lookup reports the mismatch
+func mapKey(t reflect.Type, key string) interface{} {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(key, 10, 64); err == nil {
+			return reflect.ValueOf(n).Convert(t).Interface()
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(key, 10, 64); err == nil {
+			return reflect.ValueOf(n).Convert(t).Interface()
+		}
+	}
+	return key
+}
@@ -0,0 +1,375 @@
+package reflectutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type address struct {
+	City string
+	zip  string
+}
+
+type person struct {
+	Name    string
+	Age     int
+	Address *address
+	Tags    []string
+	Meta    map[string]string
+	Scores  map[int]int
+	Friends []*person
+	secret  string
+}
+
+func (p person) Greeting(greeting string) string { return greeting + ", " + p.Name }
+
+func (p *person) Birthday() { p.Age++ }
+
+func (p *person) City() string { return p.Address.City } // Panics without an Address
+
+var errNameRequired = errors.New("name required")
+
+func (p person) Validate() error {
+	if p.Name == "" {
+		return errNameRequired
+	}
+	return nil
+}
+
+func (p person) whisper() string { return p.secret }
+
+func samplePerson() *person {
+	return &person{
+		Name:    "Ada",
+		Age:     36,
+		Address: &address{City: "London", zip: "N1"},
+		Tags:    []string{"math", "engines"},
+		Meta:    map[string]string{"team": "analytics"},
+		Scores:  map[int]int{3: 30},
+		Friends: []*person{{Name: "Charles", Address: &address{City: "Cambridge"}}, {Name: "Mary"}},
+		secret:  "notes",
+	}
+}
+
+func TestTrySet(t *testing.T) {
+	p := samplePerson()
+	addressable := reflect.ValueOf(p).Elem()
+
+	tests := []struct {
+		name    string
+		dst     reflect.Value
+		x       interface{}
+		wantErr error
+		want    interface{} // Value of dst afterwards, when wantErr is nil
+	}{
+		{"exported field", addressable.FieldByName("Name"), "Grace", nil, "Grace"},
+		{"exact numeric conversion", addressable.FieldByName("Age"), int64(40), nil, 40},
+		{"nil sets zero value", addressable.FieldByName("Tags"), nil, nil, []string(nil)},
+		{"unexported field", addressable.FieldByName("secret"), "x", ErrNotSettable, nil},
+		{"unexported nested field", addressable.FieldByName("Address").Elem().FieldByName("zip"), "x", ErrNotSettable, nil},
+		{"not addressable", reflect.ValueOf(*p).FieldByName("Name"), "x", ErrNotSettable, nil},
+		{"map entry", reflect.ValueOf(p.Meta).MapIndex(reflect.ValueOf("team")), "x", ErrNotSettable, nil},
+		{"invalid value", reflect.Value{}, "x", ErrNil, nil},
+		{"through nil pointer", reflect.ValueOf((*person)(nil)).Elem(), person{}, ErrNil, nil},
+		{"wrong type", addressable.FieldByName("Age"), "forty", ErrType, nil},
+		{"number to string", addressable.FieldByName("Name"), 65, ErrType, nil},
+		{"fraction to int", addressable.FieldByName("Age"), 2.5, ErrType, nil},
+		{"overflowing int", reflect.ValueOf(new(uint8)).Elem(), 300, ErrType, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TrySet(tt.dst, tt.x)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("TrySet error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(tt.dst.Interface(), tt.want) {
+				t.Errorf("after TrySet: %#v, want %#v", tt.dst.Interface(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTryCall(t *testing.T) {
+	var nilFunc func()
+	tests := []struct {
+		name      string
+		fn        interface{}
+		args      []interface{}
+		want      []interface{}
+		wantErr   error
+		wantPanic bool
+	}{
+		{"plain call", func(a, b int) int { return a + b }, []interface{}{2, 3}, []interface{}{5}, nil, false},
+		{"converted argument", func(x float64) float64 { return x * 2 }, []interface{}{3}, []interface{}{6.0}, nil, false},
+		{"nil argument is zero", func(s []int) int { return len(s) }, []interface{}{nil}, []interface{}{0}, nil, false},
+		{"variadic", func(xs ...int) int { return len(xs) }, []interface{}{1, 2, 3}, []interface{}{3}, nil, false},
+		{"trailing error split off", func() (int, error) { return 1, nil }, nil, []interface{}{1}, nil, false},
+		{"not a function", 42, nil, nil, ErrType, false},
+		{"nil function", nilFunc, nil, nil, ErrNil, false},
+		{"too few arguments", func(a, b int) {}, []interface{}{1}, nil, ErrType, false},
+		{"too many arguments", func() {}, []interface{}{1}, nil, ErrType, false},
+		{"wrong argument type", func(int) {}, []interface{}{"one"}, nil, ErrType, false},
+		{"panic recovered", func() { panic("boom") }, nil, nil, nil, true},
+		{"nil map write recovered", func() { var m map[string]int; m["x"] = 1 }, nil, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TryCall(tt.fn, tt.args...)
+			var pe *PanicError
+			switch {
+			case tt.wantPanic:
+				if !errors.As(err, &pe) {
+					t.Fatalf("error = %v, want a *PanicError", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case !reflect.DeepEqual(got, tt.want):
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	failing := errors.New("failed")
+	if _, err := TryCall(func() (string, error) { return "", failing }); err != failing {
+		t.Errorf("returned error = %v, want the function's own error", err)
+	}
+	_, err := TryCall(func() { panic("boom") })
+	if pe := (*PanicError)(nil); !errors.As(err, &pe) || pe.Value != "boom" {
+		t.Errorf("panic value not kept: %v", err)
+	}
+}
+
+func TestTryCallMethod(t *testing.T) {
+	p := samplePerson()
+	tests := []struct {
+		name      string
+		obj       interface{}
+		method    string
+		args      []interface{}
+		want      []interface{}
+		wantErr   error
+		wantPanic bool
+	}{
+		{"value receiver", *p, "Greeting", []interface{}{"Hello"}, []interface{}{"Hello, Ada"}, nil, false},
+		{"pointer receiver through pointer", p, "Birthday", nil, []interface{}{}, nil, false},
+		{"error result", person{}, "Validate", nil, nil, errNameRequired, false},
+		{"pointer receiver on value", *p, "Birthday", nil, nil, ErrNotFound, false},
+		{"unexported method", *p, "whisper", nil, nil, ErrNotFound, false},
+		{"missing method", p, "Fly", nil, nil, ErrNotFound, false},
+		{"nil object", nil, "Greeting", nil, nil, ErrNil, false},
+		{"wrong argument", *p, "Greeting", []interface{}{42}, nil, ErrType, false},
+		{"nil field dereferenced", &person{}, "City", nil, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TryCallMethod(tt.obj, tt.method, tt.args...)
+			var pe *PanicError
+			var pathErr *PathError
+			switch {
+			case tt.wantPanic:
+				if !errors.As(err, &pe) {
+					t.Fatalf("error = %v, want a *PanicError", err)
+				}
+			case tt.wantErr != nil:
+				// Every failure but a panic names the method, even the
+				// method's own error
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &pathErr) || pathErr.Path != tt.method {
+					t.Fatalf("error = %v, want %v at path %s", err, tt.wantErr, tt.method)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case !reflect.DeepEqual(got, tt.want):
+				t.Errorf("results = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+	if p.Age != 37 {
+		t.Errorf("Birthday through TryCallMethod: age %d, want 37", p.Age)
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	p := samplePerson()
+	pp := &p
+	tests := []struct {
+		name     string
+		obj      interface{}
+		path     string
+		want     interface{}
+		wantErr  error
+		wantPath string // PathError.Path for a failure: where the path stopped
+	}{
+		{"field", p, "Name", "Ada", nil, ""},
+		{"through pointer field", p, "Address.City", "London", nil, ""},
+		{"pointer to pointer", pp, "Address.City", "London", nil, ""},
+		{"struct value", *p, "Age", 36, nil, ""},
+		{"slice index", p, "Tags[1]", "engines", nil, ""},
+		{"slice of pointers", p, "Friends[0].Address.City", "Cambridge", nil, ""},
+		{"string map key", p, "Meta[team]", "analytics", nil, ""},
+		{"quoted map key", p, `Meta["team"]`, "analytics", nil, ""},
+		{"int map key", p, "Scores[3]", 30, nil, ""},
+		{"whole slice", p, "Tags", []string{"math", "engines"}, nil, ""},
+		{"nil pointer on the way", p, "Friends[1].Address.City", nil, ErrNil, "Friends[1].Address"},
+		{"nil root", (*person)(nil), "Name", nil, ErrNil, ""},
+		{"index out of range", p, "Tags[5]", nil, ErrNotFound, "Tags[5]"},
+		{"negative index", p, "Tags[-1]", nil, ErrNotFound, "Tags[-1]"},
+		{"missing field", p, "Address.Street", nil, ErrNotFound, "Address.Street"},
+		{"missing map key", p, "Meta[owner]", nil, ErrNotFound, "Meta[owner]"},
+		{"non-integer key for int map", p, "Scores[x]", nil, ErrType, "Scores[x]"},
+		{"field of a string", p, "Name.First", nil, ErrType, "Name.First"},
+		{"index into a struct", p, "Address[0]", nil, ErrType, "Address[0]"},
+		{"unexported field", p, "secret", nil, ErrNotSettable, "secret"},
+		{"unexported nested field", p, "Address.zip", nil, ErrNotSettable, "Address.zip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Get(tt.obj, tt.path)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Get(%q): %v", tt.path, err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Get(%q) = %#v, want %#v", tt.path, got, tt.want)
+				}
+				return
+			}
+			var pathErr *PathError
+			if !errors.Is(err, tt.wantErr) || !errors.As(err, &pathErr) {
+				t.Fatalf("Get(%q) error = %v, want a *PathError wrapping %v", tt.path, err, tt.wantErr)
+			}
+			if pathErr.Path != tt.wantPath {
+				t.Errorf("Get(%q) failed at %q, want %q", tt.path, pathErr.Path, tt.wantPath)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "Tags[0", "Meta[team"} {
+		if _, err := Get(p, bad); err == nil {
+			t.Errorf("Get(%q): malformed path accepted", bad)
+		}
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		x       interface{}
+		wantErr error
+		check   func(p *person) bool
+	}{
+		{"field", "Name", "Grace", nil, func(p *person) bool { return p.Name == "Grace" }},
+		{"allocates nil pointer", "Address.City", "Oslo", nil, func(p *person) bool { return p.Address != nil && p.Address.City == "Oslo" }},
+		{"creates nil map", "Meta[team]", "compilers", nil, func(p *person) bool { return p.Meta["team"] == "compilers" }},
+		{"int map key", "Scores[7]", 70, nil, func(p *person) bool { return p.Scores[7] == 70 }},
+		{"slice element of pointer", "Friends[0].Name", "Chuck", nil, func(p *person) bool { return p.Friends[0].Name == "Chuck" }},
+		{"below a map entry", "Meta[team].Name", "x", ErrNotSettable, nil},
+		{"unexported", "secret", "x", ErrNotSettable, nil},
+		{"wrong type", "Age", "old", ErrType, nil},
+		{"index out of range", "Friends[3].Name", "x", ErrNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &person{Friends: []*person{{Name: "Charles"}}}
+			err := Set(p, tt.path, tt.x)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Set(%q) error = %v, want %v", tt.path, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set(%q): %v", tt.path, err)
+			}
+			if !tt.check(p) {
+				t.Errorf("Set(%q, %v) left %+v", tt.path, tt.x, *p)
+			}
+		})
+	}
+
+	if err := Set(person{}, "Name", "x"); !errors.Is(err, ErrNotSettable) {
+		t.Errorf("Set on a non-pointer: %v, want ErrNotSettable", err)
+	}
+	if err := Set((*person)(nil), "Name", "x"); !errors.Is(err, ErrNotSettable) {
+		t.Errorf("Set on a nil pointer: %v, want ErrNotSettable", err)
+	}
+}
+
+func TestValueChainKeepsFirstError(t *testing.T) {
+	v := Of(samplePerson()).Field("Nope").Field("City").Index(3).Key("k")
+	var pathErr *PathError
+	if !errors.Is(v.Err(), ErrNotFound) || !errors.As(v.Err(), &pathErr) || pathErr.Path != "Nope" {
+		t.Errorf("chain error = %v, want ErrNotFound at Nope", v.Err())
+	}
+	if v.Reflect().IsValid() {
+		t.Error("failed Value still holds a reflect.Value")
+	}
+	if !Of(nil).IsNil() || Of(nil).Err() != nil {
+		t.Error("Of(nil) should be nil without failing")
+	}
+	if Of(0).IsNil() {
+		t.Error("an int reported nil")
+	}
+}
+
+func TestIndexLenLookup(t *testing.T) {
+	p := samplePerson()
+	tests := []struct {
+		name    string
+		run     func() (interface{}, error)
+		want    interface{}
+		wantErr error
+	}{
+		{"Index slice", func() (interface{}, error) { return Index(p.Tags, 0) }, "math", nil},
+		{"Index string", func() (interface{}, error) { return Index("abc", 2) }, byte('c'), nil},
+		{"Index out of range", func() (interface{}, error) { return Index(p.Tags, 2) }, nil, ErrNotFound},
+		{"Index map", func() (interface{}, error) { return Index(p.Meta, 0) }, nil, ErrType},
+		{"Len through pointer", func() (interface{}, error) { return Len(&p.Tags) }, 2, nil},
+		{"Len nil pointer", func() (interface{}, error) { return Len((*[]int)(nil)) }, 0, nil},
+		{"Len map", func() (interface{}, error) { return Len(p.Meta) }, 1, nil},
+		{"Len int", func() (interface{}, error) { return Len(3) }, 0, ErrType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.run()
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	lookups := []struct {
+		m       interface{}
+		key     interface{}
+		want    interface{}
+		found   bool
+		wantErr error
+	}{
+		{p.Meta, "team", "analytics", true, nil},
+		{p.Meta, "owner", nil, false, nil},
+		{p.Scores, 3, 30, true, nil},
+		{p.Scores, "3", nil, false, ErrType},
+		{map[string]int(nil), "x", nil, false, nil},
+		{p.Tags, 0, nil, false, ErrType},
+	}
+	for _, l := range lookups {
+		t.Run(fmt.Sprintf("Lookup %T %v", l.m, l.key), func(t *testing.T) {
+			got, found, err := Lookup(l.m, l.key)
+			if !errors.Is(err, l.wantErr) || (err == nil) != (l.wantErr == nil) {
+				t.Fatalf("error = %v, want %v", err, l.wantErr)
+			}
+			if found != l.found || !reflect.DeepEqual(got, l.want) {
+				t.Errorf("Lookup = %v, %t; want %v, %t", got, found, l.want, l.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package topic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InteractiveFlag starts the menu loop instead of running a single example
+const InteractiveFlag = "--interactive"
+
+// PrintMenu prints the numbered example menu
+func (t Topic) PrintMenu() {
+	fmt.Printf("\n%s examples:\n", t.Title)
+	for _, e := range t.Examples {
+		fmt.Printf("  %3d  %s\n", e.Number, e.Title)
+	}
+	fmt.Println("Enter a number (with optional arguments), r to re-run, l to list, q to quit")
+}
+
+// Interactive reads commands from in until q or end of input, running each
+// selected example in this process. A panicking example is reported and the
+// loop continues; an example that calls os.Exit still ends the session.
+func (t Topic) Interactive(in io.Reader) {
+	t.PrintMenu()
+
+	var last []string // Fields of the last example command, for r
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Print("\n> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "q", "quit", "exit":
+			return
+		case "l", "list", "?", "help":
+			t.PrintMenu()
+			continue
+		case "r", "rerun":
+			if last == nil {
+				fmt.Println("Nothing to re-run yet")
+				continue
+			}
+			fields = last
+		}
+
+		number, err := strconv.Atoi(fields[0])
+		e, ok := t.Find(number)
+		if err != nil || !ok {
+			fmt.Printf("Unknown example %q (l lists them)\n", fields[0])
+			continue
+		}
+		last = fields
+		t.runInteractive(e, fields[1:])
+	}
+}
+
+// runInteractive runs one example as if started with go run . N args...,
+// recovering from panics so the session survives a failing example
+func (t Topic) runInteractive(e Example, args []string) {
+	// Examples read their extra arguments from os.Args[2:]
+	saved := os.Args
+	os.Args = append([]string{saved[0], strconv.Itoa(e.Number)}, args...)
+	start := time.Now()
+	defer func() {
+		os.Args = saved
+		if r := recover(); r != nil {
+			fmt.Printf("\nExample %d panicked: %v\n", e.Number, r)
+		}
+		fmt.Printf("\n[example %d finished in %v]\n", e.Number, time.Since(start).Round(time.Millisecond))
+	}()
+	t.RunExample(e)
+}
@@ -0,0 +1,190 @@
+// Package topic holds the command-line handling shared by every topic module
+// (01_concurrency, 02_interfaces, ...). A module describes its examples as a
+// table and hands it to Main, which parses the arguments, prints help, runs
+// the --interactive menu, answers the machine-readable --list query the
+// aistudy launcher relies on, and wraps a run in JSON records for
+// --format=json.
+package topic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/Rookie0x80/AIStudy-go/internal/leakcheck"
+)
+
+// ListFlag makes a module print its examples as "<number>\t<title>" lines and
+// exit; the launcher uses it to discover examples without a second registry
+const ListFlag = "--list"
+
+// LeakCheckFlag reports goroutines an example leaves running, with their
+// stacks. It may appear anywhere in the arguments and is removed before the
+// example sees them; with it, a run that leaks exits with status 1.
+const LeakCheckFlag = "-leakcheck"
+
+// Example is one runnable entry of a topic
+type Example struct {
+	Number int
+	Title  string // One-line summary shown in help and listings
+	Banner string // Printed before running; defaults to "=== Title ==="
+	Run    func()
+}
+
+// Topic describes a module's examples
+type Topic struct {
+	Name     string // Short name used by the launcher, e.g. "concurrency"
+	Title    string
+	Examples []Example
+
+	// Help replaces the default help text when set
+	Help func(t Topic)
+	// Header replaces the default banner printed before an example when set
+	Header func(e Example)
+	// LeakCheck checks for leaked goroutines after every example; Main sets
+	// it from LeakCheckFlag
+	LeakCheck bool
+}
+
+// Find returns the example with the given number
+func (t Topic) Find(number int) (Example, bool) {
+	for _, e := range t.Examples {
+		if e.Number == number {
+			return e, true
+		}
+	}
+	return Example{}, false
+}
+
+// PrintList writes the examples in the --list format
+func (t Topic) PrintList(w io.Writer) {
+	for _, e := range t.Examples {
+		fmt.Fprintf(w, "%d\t%s\n", e.Number, e.Title)
+	}
+}
+
+// PrintHelp prints the module's help text
+func (t Topic) PrintHelp() {
+	if t.Help != nil {
+		t.Help(t)
+		return
+	}
+	fmt.Println("Please select an example to run:")
+	for _, e := range t.Examples {
+		fmt.Printf("%d - %s\n", e.Number, e.Title)
+	}
+	fmt.Println("Usage: go run . <example_number>")
+	fmt.Println("       go run . " + InteractiveFlag + "   (browse and re-run from a menu)")
+	fmt.Println("       go run . <example_number> " + LeakCheckFlag + "   (report goroutines left running)")
+	fmt.Println("       go run . <example_number> " + FormatFlag + "=json   (one JSON record per output line)")
+}
+
+// RunExample prints the example's banner and runs it. With LeakCheck set it
+// then reports leaked goroutines and returns how many there were.
+func (t Topic) RunExample(e Example) (leaked int) {
+	switch {
+	case t.Header != nil:
+		t.Header(e)
+	case e.Banner != "":
+		fmt.Println(e.Banner)
+	default:
+		fmt.Printf("=== %s ===\n", e.Title)
+	}
+	return t.run(e)
+}
+
+// run is RunExample without the banner, which RunJSON replaces with a start
+// record
+func (t Topic) run(e Example) (leaked int) {
+	if t.LeakCheck {
+		before := leakcheck.Take()
+		defer func() {
+			fmt.Println()
+			leaked = leakcheck.Report(os.Stdout, before.Leaked(leakcheck.DefaultGrace))
+		}()
+	}
+	e.Run()
+	return 0
+}
+
+// Main is the body of a module's main function. os.Args[1] selects the
+// example; anything after it is left in os.Args for the example to read.
+// Unknown example numbers print the help and exit with status 2.
+func Main(t Topic) {
+	os.Args, t.LeakCheck = removeFlag(os.Args, LeakCheckFlag)
+	var format string
+	os.Args, format = removeFormatFlag(os.Args)
+	switch format {
+	case "text":
+	case "json":
+		mainJSON(t)
+		return
+	default:
+		fmt.Printf("Unknown format %q (want text or json)\n", format)
+		os.Exit(2)
+	}
+
+	if len(os.Args) < 2 {
+		t.PrintHelp()
+		return
+	}
+
+	switch os.Args[1] {
+	case ListFlag:
+		t.PrintList(os.Stdout)
+		return
+	case InteractiveFlag:
+		t.Interactive(os.Stdin)
+		return
+	}
+
+	number, err := strconv.Atoi(os.Args[1])
+	e, ok := t.Find(number)
+	if err != nil || !ok {
+		fmt.Printf("Invalid example number: %s\n", os.Args[1])
+		t.PrintHelp()
+		os.Exit(2)
+	}
+	if t.RunExample(e) > 0 {
+		os.Exit(1)
+	}
+}
+
+// mainJSON is Main for --format=json: every line it writes to standard output
+// is a Record. Without an example number it lists the examples.
+func mainJSON(t Topic) {
+	if len(os.Args) < 2 || os.Args[1] == ListFlag {
+		t.PrintJSONList(os.Stdout)
+		return
+	}
+	number, err := strconv.Atoi(os.Args[1])
+	e, ok := t.Find(number)
+	if err != nil || !ok {
+		message := fmt.Sprintf("invalid example number: %s", os.Args[1])
+		if os.Args[1] == InteractiveFlag {
+			message = InteractiveFlag + " cannot be combined with " + FormatFlag + "=json"
+		}
+		json.NewEncoder(os.Stdout).Encode(Record{Topic: t.Name, Kind: "error", Error: message})
+		os.Exit(2)
+	}
+	if !t.RunJSON(e, os.Stdout) {
+		os.Exit(1)
+	}
+}
+
+// removeFlag returns args without flag (or its --flag spelling) and whether
+// it was present
+func removeFlag(args []string, flag string) ([]string, bool) {
+	kept := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == flag || arg == "-"+flag {
+			found = true
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept, found
+}